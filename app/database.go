@@ -8,14 +8,15 @@ import (
 	"os"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
 )
 
-func GetConnection() *sql.DB {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file:", err)
+// dataSourceName DSN dari DB_URL, atau disusun dari DB_USER/DB_HOST/DB_PORT/DB_NAME
+func dataSourceName() string {
+	// .env opsional, konfigurasi juga bisa dari environment (container, test integrasi)
+	if err := godotenv.Load(); err != nil {
+		log.Printf("File .env tidak dimuat, memakai environment variable: %v", err)
 	}
 
 	dbUser := os.Getenv("DB_USER")
@@ -36,7 +37,24 @@ func GetConnection() *sql.DB {
 	if dbUrl != "" {
 		connStr = dbUrl
 	}
+	return connStr
+}
+
+func GetConnection() *sql.DB {
+	return openConnection(dataSourceName())
+}
+
+// GetMigrationConnection koneksi untuk menjalankan migrasi, satu file migrasi bisa berisi beberapa statement
+func GetMigrationConnection() *sql.DB {
+	config, err := mysql.ParseDSN(dataSourceName())
+	if err != nil {
+		log.Fatalf("DSN database tidak valid: %v", err)
+	}
+	config.MultiStatements = true
+	return openConnection(config.FormatDSN())
+}
 
+func openConnection(connStr string) *sql.DB {
 	db, err := sql.Open("mysql", connStr)
 	if err != nil {
 		log.Fatalf("Error membuka koneksi database: %v", err)
@@ -68,7 +86,7 @@ func GetConnection() *sql.DB {
 		}
 	}
 
-	log.Printf("Berhasil terhubung ke database %s", dbName)
+	log.Printf("Berhasil terhubung ke database")
 	log.Printf("Max Open Connections: %d", db.Stats().MaxOpenConnections)
 	log.Printf("Open Connections: %d", db.Stats().OpenConnections)
 	log.Printf("In Use Connections: %d", db.Stats().InUse)
@@ -0,0 +1,311 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// schemaMigrationsTable tabel versi yang sama dengan golang-migrate,
+// database yang sebelumnya dimigrasi dengan CLI migrate tetap terbaca
+const schemaMigrationsTable = "schema_migrations"
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration satu versi migrasi beserta file up/down
+type Migration struct {
+	Version  uint64
+	Name     string
+	UpFile   string
+	DownFile string
+}
+
+// MigrationStatus status satu migrasi terhadap versi schema di database
+type MigrationStatus struct {
+	Migration
+	Applied bool
+	Current bool
+}
+
+// SchemaVersion versi schema yang tercatat di database, Version 0 berarti belum ada migrasi
+type SchemaVersion struct {
+	Version uint64
+	Dirty   bool
+}
+
+// Migrator menjalankan migrasi dari file SQL yang di-embed ke binary
+type Migrator struct {
+	db         *sql.DB
+	files      fs.FS
+	migrations []Migration
+	out        io.Writer
+}
+
+// NewMigrator db harus mengizinkan multi statement (GetMigrationConnection),
+// out tujuan log proses dan statement dry run
+func NewMigrator(db *sql.DB, files fs.FS, out io.Writer) (*Migrator, error) {
+	migrations, err := readMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		files:      files,
+		migrations: migrations,
+		out:        out,
+	}, nil
+}
+
+// readMigrations mengelompokkan file up/down per versi, urut dari versi terlama
+func readMigrations(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("versi migrasi %s tidak valid: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("versi migrasi %d dipakai dua nama: %s dan %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.UpFile = entry.Name()
+		} else {
+			migration.DownFile = entry.Name()
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpFile == "" {
+			return nil, fmt.Errorf("migrasi %d_%s tidak memiliki file up", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Latest versi migrasi terbaru yang di-embed
+func (migrator *Migrator) Latest() uint64 {
+	if len(migrator.migrations) == 0 {
+		return 0
+	}
+	return migrator.migrations[len(migrator.migrations)-1].Version
+}
+
+// Version versi schema di database, tabel schema_migrations yang belum ada dianggap versi 0
+func (migrator *Migrator) Version(ctx context.Context) (SchemaVersion, error) {
+	var exists int
+	err := migrator.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_name = ?`, schemaMigrationsTable).Scan(&exists)
+	if err != nil {
+		return SchemaVersion{}, err
+	}
+	if exists == 0 {
+		return SchemaVersion{}, nil
+	}
+
+	var current SchemaVersion
+	err = migrator.db.QueryRowContext(ctx, "SELECT version, dirty FROM "+schemaMigrationsTable+" LIMIT 1").
+		Scan(&current.Version, &current.Dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return SchemaVersion{}, nil
+	}
+	return current, err
+}
+
+// Status semua migrasi yang di-embed beserta tanda sudah diterapkan
+func (migrator *Migrator) Status(ctx context.Context) ([]MigrationStatus, SchemaVersion, error) {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		return nil, current, err
+	}
+	statuses := make([]MigrationStatus, 0, len(migrator.migrations))
+	for _, migration := range migrator.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Migration: migration,
+			Applied:   migration.Version <= current.Version,
+			Current:   migration.Version == current.Version,
+		})
+	}
+	return statuses, current, nil
+}
+
+// Pending migrasi yang belum diterapkan
+func (migrator *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.pendingAfter(current.Version), nil
+}
+
+func (migrator *Migrator) pendingAfter(version uint64) []Migration {
+	var pending []Migration
+	for _, migration := range migrator.migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// CheckUpToDate error jika schema tertinggal dari migrasi yang di-embed atau dalam keadaan dirty
+func (migrator *Migrator) CheckUpToDate(ctx context.Context) error {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal membaca versi schema: %w", err)
+	}
+	if current.Dirty {
+		return dirtyError(current.Version)
+	}
+	if pending := migrator.pendingAfter(current.Version); len(pending) > 0 {
+		return fmt.Errorf("schema database tertinggal: versi %d, terbaru %d (%d migrasi belum diterapkan), jalankan -migrate up",
+			current.Version, migrator.Latest(), len(pending))
+	}
+	return nil
+}
+
+// Up menerapkan semua migrasi yang belum diterapkan sesuai urutan versi.
+// Dry run hanya menulis statement ke out tanpa mengubah database.
+func (migrator *Migrator) Up(ctx context.Context, dryRun bool) error {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if current.Dirty {
+		return dirtyError(current.Version)
+	}
+
+	pending := migrator.pendingAfter(current.Version)
+	if len(pending) == 0 {
+		fmt.Fprintf(migrator.out, "schema sudah versi terbaru (%d)\n", current.Version)
+		return nil
+	}
+	if !dryRun {
+		if err := migrator.ensureVersionTable(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, migration := range pending {
+		if err := migrator.run(ctx, migration.UpFile, migration.Version, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down membatalkan satu migrasi terakhir yang diterapkan
+func (migrator *Migrator) Down(ctx context.Context, dryRun bool) error {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if current.Dirty {
+		return dirtyError(current.Version)
+	}
+	if current.Version == 0 {
+		fmt.Fprintln(migrator.out, "belum ada migrasi yang diterapkan")
+		return nil
+	}
+
+	index := -1
+	for i, migration := range migrator.migrations {
+		if migration.Version == current.Version {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("versi schema %d tidak ada di migrasi yang di-embed", current.Version)
+	}
+	migration := migrator.migrations[index]
+	if migration.DownFile == "" {
+		return fmt.Errorf("migrasi %d_%s tidak memiliki file down", migration.Version, migration.Name)
+	}
+
+	var previous uint64
+	if index > 0 {
+		previous = migrator.migrations[index-1].Version
+	}
+	return migrator.run(ctx, migration.DownFile, previous, dryRun)
+}
+
+// run menjalankan satu file migrasi lalu mencatat versi tujuan.
+// Versi ditandai dirty selama file dijalankan karena DDL MySQL tidak bisa di-rollback.
+func (migrator *Migrator) run(ctx context.Context, file string, targetVersion uint64, dryRun bool) error {
+	script, err := fs.ReadFile(migrator.files, file)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Fprintf(migrator.out, "-- %s\n%s\n", file, script)
+		return nil
+	}
+
+	fmt.Fprintf(migrator.out, "menjalankan %s\n", file)
+	if err := migrator.setVersion(ctx, targetVersion, true); err != nil {
+		return err
+	}
+	if len(script) > 0 {
+		if _, err := migrator.db.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("migrasi %s gagal: %w", file, err)
+		}
+	}
+	return migrator.setVersion(ctx, targetVersion, false)
+}
+
+func (migrator *Migrator) ensureVersionTable(ctx context.Context) error {
+	_, err := migrator.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+schemaMigrationsTable+
+		" (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	return err
+}
+
+// setVersion tabel versi hanya berisi satu baris, versi 0 berarti tabel kosong (sama dengan golang-migrate)
+func (migrator *Migrator) setVersion(ctx context.Context, version uint64, dirty bool) error {
+	tx, err := migrator.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+schemaMigrationsTable); err != nil {
+		return err
+	}
+	if version > 0 || dirty {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO "+schemaMigrationsTable+" (version, dirty) VALUES (?, ?)", version, dirty); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func dirtyError(version uint64) error {
+	return fmt.Errorf("schema dirty pada versi %d (migrasi sebelumnya gagal di tengah jalan), "+
+		"perbaiki database lalu set dirty = 0 di tabel %s", version, schemaMigrationsTable)
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"ekak_kabupaten_madiun/db"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadMigrations(t *testing.T) {
+	file := &fstest.MapFile{Data: []byte("SELECT 1;")}
+
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []uint64
+		wantErr      bool
+	}{
+		{
+			name: "urut versi dan abaikan file lain",
+			files: fstest.MapFS{
+				"20240202000000_b.up.sql":   file,
+				"20240202000000_b.down.sql": file,
+				"20240101000000_a.up.sql":   file,
+				"migrations.go":             file,
+			},
+			wantVersions: []uint64{20240101000000, 20240202000000},
+		},
+		{
+			name:    "down tanpa up",
+			files:   fstest.MapFS{"20240101000000_a.down.sql": file},
+			wantErr: true,
+		},
+		{
+			name: "satu versi dua nama",
+			files: fstest.MapFS{
+				"20240101000000_a.up.sql": file,
+				"20240101000000_b.up.sql": file,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := readMigrations(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(migrations) != len(tt.wantVersions) {
+				t.Fatalf("jumlah migrasi = %d, want %d", len(migrations), len(tt.wantVersions))
+			}
+			for i, version := range tt.wantVersions {
+				if migrations[i].Version != version {
+					t.Errorf("migrations[%d].Version = %d, want %d", i, migrations[i].Version, version)
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil, db.Migrations, nil)
+	if err != nil {
+		t.Fatalf("migrasi embed tidak valid: %v", err)
+	}
+	if migrator.Latest() == 0 {
+		t.Fatal("tidak ada migrasi yang di-embed")
+	}
+	if pending := migrator.pendingAfter(migrator.Latest()); len(pending) != 0 {
+		t.Errorf("pending setelah versi terbaru = %d, want 0", len(pending))
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"ekak_kabupaten_madiun/controller"
+	"ekak_kabupaten_madiun/exception"
 	"net/http"
 
 	_ "ekak_kabupaten_madiun/docs"
@@ -61,8 +62,20 @@ func NewRouter(
 	matrixRenjaController controller.MatrixRenjaController,
 	pkController controller.PkController,
 	strategicArahKebijakanController controller.SrategicArahKebijakanPemdaController,
+	lockDataController controller.LockDataController,
+	auditLogController controller.AuditLogController,
+	nomenklaturController controller.NomenklaturController,
+	jobController controller.JobController,
+	rollForwardController controller.RollForwardController,
+	realisasiController controller.RealisasiController,
+	realisasiAnggaranController controller.RealisasiAnggaranController,
+	persetujuanRekinController controller.PersetujuanRekinController,
+	notifikasiController controller.NotifikasiController,
+	healthController controller.HealthController,
+	metricsController controller.MetricsController,
 ) *httprouter.Router {
 	router := httprouter.New()
+	router.PanicHandler = exception.ErrorHandler
 
 	router.GET("/swagger/*any", func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		handler := httpSwagger.Handler(
@@ -233,6 +246,7 @@ func NewRouter(
 	router.GET("/pohon_kinerja/status/:kode_opd/:tahun", pohonKinerjaAdminController.FindPokinByStatus)
 	router.GET("/pohon_kinerja/pemda/:kode_opd/:tahun", pohonKinerjaAdminController.FindPokinFromPemda)
 	router.GET("/pohon_kinerja/pilih_parent/:kode_opd/:tahun/:level_pohon", pohonKinerjaAdminController.FindPokinFromOpd)
+	router.GET("/pohon_kinerja/transisi/:id", pohonKinerjaAdminController.FindTransisi)
 	router.GET("/pohon_kinerja_opd/pokinpemda_review/:id", pohonKinerjaOpdController.FindidPokinWithAllTema)
 
 	// isustrategis - csf
@@ -303,6 +317,7 @@ func NewRouter(
 
 	//rincian kak
 	router.GET("/rencana_kinerja/:rencana_kinerja_id/pegawai/:pegawai_id/input_rincian_kak", rencanaKinerjaController.FindAllRincianKak)
+	router.GET("/rencana_kinerja/:rencana_kinerja_id/pegawai/:pegawai_id/kak/:format", rencanaKinerjaController.CetakKak)
 
 	//role
 	router.POST("/role/create", roleController.Create)
@@ -318,6 +333,8 @@ func NewRouter(
 	router.DELETE("/user/delete/:id", userController.Delete)
 	router.GET("/user/findall", userController.FindAll)
 	router.POST("/user/login", userController.Login)
+	router.POST("/user/refresh", userController.Refresh)
+	router.POST("/user/logout", userController.Logout)
 	router.GET("/user/findbykodeopdandrole", userController.FindByKodeOpdAndRole)
 	router.GET("/user/findpegawai/:nip", userController.FindByNip)
 
@@ -495,6 +512,9 @@ func NewRouter(
 	router.POST("/matrix_renja/indikator/rankhir/upsert", matrixRenjaController.UpsertBatchIndikatorRenjaRankhir)
 	router.POST("/matrix_renja/indikator/penetapan/upsert", matrixRenjaController.UpsertBatchIndikatorRenjaPenetapan)
 	router.POST("/matrix_renja/anggaran_penetapan/upsert", matrixRenjaController.UpsertAnggaran)
+	router.GET("/matrix_renja/ranwal/:kode_opd/:tahun/excel", matrixRenjaController.ExportRenjaRanwal)
+	router.GET("/matrix_renja/rankhir/:kode_opd/:tahun/excel", matrixRenjaController.ExportRenjaRankhir)
+	router.GET("/matrix_renja/penetapan/:kode_opd/:tahun/excel", matrixRenjaController.ExportRenjaPenetapan)
 
 	//Api Internal Consume
 	router.GET("/api/pokin_opd/findall/:kode_opd/:tahun", pohonKinerjaOpdController.FindAll)
@@ -523,6 +543,8 @@ func NewRouter(
 	router.GET("/pk_opd/:kode_opd/:tahun", pkController.FindAllPkOpdTahunan)
 	router.POST("/pk_opd/hubungkan", pkController.HubungkanRekin)
 	router.POST("/pk_opd/hubungkan_atasan", pkController.HubungkanAtasan)
+	router.GET("/pk_opd/:kode_opd/:tahun/pdf", pkController.CetakPdfOpd)
+	router.GET("/pk_opd/:kode_opd/:tahun/pdf/:nip", pkController.CetakPdfPegawai)
 
 	//clone rekin
 	router.POST("/rencana_kinerja/clone/:rekin_id/:tahun_tujuan", rencanaKinerjaController.CloneRencanaKinerja)
@@ -572,5 +594,59 @@ func NewRouter(
 	//tujuan opd penetapan
 	router.GET("/tujuan_opd/penetapan/:kode_opd/:tahun", tujuanOpdController.TujuanOpdPenetapan)
 
+	//lock data (kunci dokumen per opd dan tahun)
+	router.POST("/lock_data/lock", lockDataController.Lock)
+	router.POST("/lock_data/unlock", lockDataController.Unlock)
+	router.GET("/lock_data/findall", lockDataController.FindAll)
+
+	//audit log
+	router.GET("/audit_log/findall", auditLogController.FindAll)
+	router.GET("/audit_log/entity/:entity_type/:entity_id", auditLogController.FindByEntity)
+
+	//import nomenklatur kemendagri
+	router.POST("/nomenklatur/import/preview", nomenklaturController.PreviewImport)
+	router.POST("/nomenklatur/import/apply", nomenklaturController.ApplyImport)
+
+	//job background
+	router.GET("/jobs/:id", jobController.FindById)
+	router.POST("/jobs/:id/cancel", jobController.Cancel)
+
+	//roll forward perencanaan OPD ke tahun berikutnya
+	router.POST("/roll_forward/opd", rollForwardController.RollForwardOpd)
+
+	//realisasi dan capaian kinerja
+	router.POST("/realisasi_indikator/create", realisasiController.Create)
+	router.PUT("/realisasi_indikator/update/:id", realisasiController.Update)
+	router.DELETE("/realisasi_indikator/delete/:id", realisasiController.Delete)
+	router.GET("/realisasi_indikator/findall/:indikator_id/:tahun", realisasiController.FindByIndikator)
+	router.PUT("/realisasi_indikator/polaritas/:indikator_id", realisasiController.UpdatePolaritas)
+	router.GET("/capaian_kinerja/opd/:kode_opd/:tahun", realisasiController.CapaianOpd)
+	router.GET("/capaian_kinerja/pemda/:tahun", realisasiController.CapaianPemda)
+
+	//realisasi dan serapan anggaran
+	router.POST("/realisasi_anggaran/create", realisasiAnggaranController.Create)
+	router.PUT("/realisasi_anggaran/update/:id", realisasiAnggaranController.Update)
+	router.DELETE("/realisasi_anggaran/delete/:id", realisasiAnggaranController.Delete)
+	router.GET("/realisasi_anggaran/rencana_aksi/:renaksi_id", realisasiAnggaranController.FindByRencanaAksi)
+	router.GET("/realisasi_anggaran/laporan/:kode_opd/:tahun", realisasiAnggaranController.Laporan)
+	router.GET("/realisasi_anggaran/deviasi/:kode_opd/:tahun", realisasiAnggaranController.Deviasi)
+
+	//persetujuan rencana kinerja
+	router.POST("/persetujuan_rekin/:rencana_kinerja_id", persetujuanRekinController.Transisi)
+	router.GET("/persetujuan_rekin/detail/:rencana_kinerja_id", persetujuanRekinController.FindByRekinId)
+	router.GET("/persetujuan_rekin/menunggu/:tahun", persetujuanRekinController.FindMenunggu)
+
+	//notifikasi
+	router.GET("/notifikasi", notifikasiController.FindAll)
+	router.GET("/notifikasi/belum_dibaca/jumlah", notifikasiController.JumlahBelumDibaca)
+	router.PUT("/notifikasi/baca/:id", notifikasiController.Baca)
+	router.PUT("/notifikasi/baca_semua", notifikasiController.BacaSemua)
+	router.GET("/notifikasi/stream", notifikasiController.Stream)
+
+	//health check
+	router.GET("/healthz", healthController.Healthz)
+	router.GET("/readyz", healthController.Readyz)
+	router.GET("/metrics", metricsController.Metrics)
+
 	return router
 }
@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuditLogController interface {
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByEntity(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
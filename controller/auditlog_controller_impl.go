@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/auditlog"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuditLogControllerImpl struct {
+	AuditLogService service.AuditLogService
+}
+
+func NewAuditLogControllerImpl(auditLogService service.AuditLogService) *AuditLogControllerImpl {
+	return &AuditLogControllerImpl{AuditLogService: auditLogService}
+}
+
+// FindAll godoc
+// @Summary      Daftar audit log
+// @Description  Riwayat perubahan pohon kinerja, rencana kinerja, indikator, crosscutting dan review. Selain super_admin hanya bisa melihat OPD sendiri.
+// @Tags         Audit Log
+// @Produce      json
+// @Param        entity_type    query     string  false  "Jenis entitas (pohon_kinerja, rencana_kinerja, indikator, crosscutting, review, pelaksana_pokin)"
+// @Param        entity_id      query     string  false  "ID entitas"
+// @Param        kode_opd       query     string  false  "Kode OPD"
+// @Param        tahun          query     string  false  "Tahun"
+// @Param        user_id        query     int     false  "ID user pelaku"
+// @Param        nip            query     string  false  "NIP pelaku"
+// @Param        tanggal_awal   query     string  false  "Tanggal awal (YYYY-MM-DD)"
+// @Param        tanggal_akhir  query     string  false  "Tanggal akhir (YYYY-MM-DD)"
+// @Param        limit          query     int     false  "Jumlah data (default 100, maksimal 1000)"
+// @Success      200            {object}  web.WebResponse{data=[]auditlog.AuditLogResponse}
+// @Failure      400            {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /audit_log/findall [get]
+func (controller *AuditLogControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	filterRequest := auditlog.AuditLogFilterRequest{
+		EntityType:   query.Get("entity_type"),
+		EntityId:     query.Get("entity_id"),
+		KodeOpd:      query.Get("kode_opd"),
+		Tahun:        query.Get("tahun"),
+		Nip:          query.Get("nip"),
+		TanggalAwal:  query.Get("tanggal_awal"),
+		TanggalAkhir: query.Get("tanggal_akhir"),
+	}
+	if userId := query.Get("user_id"); userId != "" {
+		id, err := strconv.Atoi(userId)
+		if err != nil {
+			exception.WriteError(writer, request, web.NewBadRequestError("user_id harus berupa angka"))
+			return
+		}
+		filterRequest.UserId = id
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			exception.WriteError(writer, request, web.NewBadRequestError("limit harus berupa angka"))
+			return
+		}
+		filterRequest.Limit = value
+	}
+
+	responses, err := controller.AuditLogService.FindAll(request.Context(), filterRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   responses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindByEntity godoc
+// @Summary      Riwayat perubahan satu entitas
+// @Description  Audit log untuk satu entitas, diurutkan dari perubahan terbaru.
+// @Tags         Audit Log
+// @Produce      json
+// @Param        entity_type  path      string  true  "Jenis entitas"
+// @Param        entity_id    path      string  true  "ID entitas"
+// @Success      200          {object}  web.WebResponse{data=[]auditlog.AuditLogResponse}
+// @Failure      400          {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /audit_log/entity/{entity_type}/{entity_id} [get]
+func (controller *AuditLogControllerImpl) FindByEntity(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	filterRequest := auditlog.AuditLogFilterRequest{
+		EntityType: params.ByName("entity_type"),
+		EntityId:   params.ByName("entity_id"),
+	}
+
+	responses, err := controller.AuditLogService.FindAll(request.Context(), filterRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   responses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/bidangurusanresponse"
@@ -26,6 +27,9 @@ func (controller *BidangUrusanControllerImpl) Create(writer http.ResponseWriter,
 
 	bidangUrusanCreateResponse, err := controller.BidangUrusanService.Create(request.Context(), bidangUrusanCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -51,6 +55,9 @@ func (controller *BidangUrusanControllerImpl) Update(writer http.ResponseWriter,
 
 	bidangUrusanUpdateResponse, err := controller.BidangUrusanService.Update(request.Context(), bidangUrusanUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -154,6 +161,9 @@ func (controller *BidangUrusanControllerImpl) CreateOPD(writer http.ResponseWrit
 
 	bidangUrusanTerpilihCreateResponse, err := controller.BidangUrusanService.CreateOPD(request.Context(), bidangUrusanTerpilihCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
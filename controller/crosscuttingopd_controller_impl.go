@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
@@ -49,12 +50,7 @@ func (controller *CrosscuttingOpdControllerImpl) Create(writer http.ResponseWrit
 
 	crosscuttingResponse, err := controller.CrosscuttingOpdService.Create(request.Context(), crosscuttingCreateRequest, parentId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -100,6 +96,9 @@ func (controller *CrosscuttingOpdControllerImpl) Update(writer http.ResponseWrit
 	// Panggil service
 	crosscuttingResponse, err := controller.CrosscuttingOpdService.Update(request.Context(), crosscuttingUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		// Handle specific errors
 		if err.Error() == "crosscutting tidak ditemukan" {
 			webResponse := web.WebResponse{
@@ -120,13 +119,7 @@ func (controller *CrosscuttingOpdControllerImpl) Update(writer http.ResponseWrit
 			return
 		}
 
-		// Default error response
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -154,12 +147,7 @@ func (controller *CrosscuttingOpdControllerImpl) FindAllByParent(writer http.Res
 
 	crosscuttingResponses, err := controller.CrosscuttingOpdService.FindAllByParent(request.Context(), parentId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -197,13 +185,7 @@ func (controller *CrosscuttingOpdControllerImpl) Delete(writer http.ResponseWrit
 			return
 		}
 
-		// Default error response
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -232,12 +214,7 @@ func (controller *CrosscuttingOpdControllerImpl) FindAll(writer http.ResponseWri
 	// Panggil service untuk mendapatkan data
 	crosscuttingResponses, err := controller.CrosscuttingOpdService.FindAllByParent(request.Context(), parentId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -289,12 +266,7 @@ func (controller *CrosscuttingOpdControllerImpl) ApproveOrReject(writer http.Res
 
 	response, err := controller.CrosscuttingOpdService.ApproveOrReject(request.Context(), crosscuttingId, approveRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -331,12 +303,7 @@ func (controller *CrosscuttingOpdControllerImpl) DeleteUnused(writer http.Respon
 			return
 		}
 
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -365,12 +332,7 @@ func (controller *CrosscuttingOpdControllerImpl) FindPokinByCrosscuttingStatus(w
 
 	crosscuttingResponses, err := controller.CrosscuttingOpdService.FindPokinByCrosscuttingStatus(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -397,12 +359,7 @@ func (controller *CrosscuttingOpdControllerImpl) FindOPDCrosscuttingFrom(writer
 
 	response, err := controller.CrosscuttingOpdService.FindOPDCrosscuttingFrom(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -427,12 +384,7 @@ func (controller *CrosscuttingOpdControllerImpl) DeleteCrosscuttingDiterima(writ
 	}
 	err = controller.CrosscuttingOpdService.DeleteCrosscuttingDiterima(request.Context(), crosscuttingId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/dasarhukum"
@@ -43,11 +44,7 @@ func (controller *DasarHukumControllerImpl) Create(writer http.ResponseWriter, r
 	// Panggil service untuk membuat gambaran umum
 	dasarHukumResponse, err := controller.DasarHukumService.Create(request.Context(), dasarHukumCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -68,11 +65,7 @@ func (controller *DasarHukumControllerImpl) Update(writer http.ResponseWriter, r
 
 	dasarHukumResponse, err := controller.DasarHukumService.Update(request.Context(), dasarHukumUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -108,11 +101,7 @@ func (controller *DasarHukumControllerImpl) FindAll(writer http.ResponseWriter,
 
 	dasarHukumResponses, err := controller.DasarHukumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -128,11 +117,7 @@ func (controller *DasarHukumControllerImpl) Delete(writer http.ResponseWriter, r
 
 	err := controller.DasarHukumService.Delete(request.Context(), dasarHukumId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -148,11 +133,7 @@ func (controller *DasarHukumControllerImpl) FindAllByRekinId(writer http.Respons
 
 	dasarHukumResponses, err := controller.DasarHukumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
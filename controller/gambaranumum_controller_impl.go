@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/gambaranumum"
@@ -45,11 +46,7 @@ func (controller *GambaranUmumControllerImpl) Create(writer http.ResponseWriter,
 	// Panggil service untuk membuat gambaran umum
 	gambaranUmumResponse, err := controller.GambaranUmumService.Create(request.Context(), gambaranUmumCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -91,11 +88,7 @@ func (controller *GambaranUmumControllerImpl) Update(writer http.ResponseWriter,
 	// Panggil service untuk update gambaran umum
 	gambaranUmumResponse, err := controller.GambaranUmumService.Update(request.Context(), gambaranUmumUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -122,11 +115,7 @@ func (controller *GambaranUmumControllerImpl) Delete(writer http.ResponseWriter,
 	// Panggil service untuk menghapus gambaran umum
 	err := controller.GambaranUmumService.Delete(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -153,11 +142,7 @@ func (controller *GambaranUmumControllerImpl) FindAll(writer http.ResponseWriter
 	// Panggil service untuk mendapatkan semua gambaran umum
 	gambaranUmumResponses, err := controller.GambaranUmumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -184,11 +169,7 @@ func (controller *GambaranUmumControllerImpl) FindById(writer http.ResponseWrite
 	// Panggil service untuk mendapatkan gambaran umum berdasarkan ID
 	gambaranUmumResponse, err := controller.GambaranUmumService.FindById(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -215,11 +196,7 @@ func (controller *GambaranUmumControllerImpl) FindAllByRekinId(writer http.Respo
 	// Panggil service untuk mendapatkan semua gambaran umum
 	gambaranUmumResponses, err := controller.GambaranUmumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
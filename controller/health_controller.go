@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type HealthController interface {
+	Healthz(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Readyz(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
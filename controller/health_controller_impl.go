@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/health"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type HealthControllerImpl struct {
+	HealthService service.HealthService
+}
+
+func NewHealthControllerImpl(healthService service.HealthService) *HealthControllerImpl {
+	return &HealthControllerImpl{HealthService: healthService}
+}
+
+// Healthz godoc
+// @Summary      Health check
+// @Description  Status MySQL dan Redis. 503 jika MySQL tidak bisa diakses, Redis yang mati dilaporkan DEGRADED karena cache opsional.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=health.HealthResponse}
+// @Failure      503  {object}  web.WebResponse{data=health.HealthResponse}
+// @Router       /healthz [get]
+func (controller *HealthControllerImpl) Healthz(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response := controller.HealthService.Check(request.Context())
+	writeHealthResponse(writer, response, response.Status != health.StatusDown)
+}
+
+// Readyz godoc
+// @Summary      Readiness check
+// @Description  Sama dengan /healthz, tetapi juga 503 saat server sedang shutdown agar tidak menerima request baru.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=health.HealthResponse}
+// @Failure      503  {object}  web.WebResponse{data=health.HealthResponse}
+// @Router       /readyz [get]
+func (controller *HealthControllerImpl) Readyz(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	if !controller.HealthService.Ready() {
+		writeHealthResponse(writer, health.HealthResponse{Status: health.StatusDown, Checks: []health.HealthCheckResponse{}}, false)
+		return
+	}
+	response := controller.HealthService.Check(request.Context())
+	writeHealthResponse(writer, response, response.Status != health.StatusDown)
+}
+
+func writeHealthResponse(writer http.ResponseWriter, response health.HealthResponse, ok bool) {
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: response.Status,
+		Data:   response,
+	}
+	if !ok {
+		webResponse.Code = http.StatusServiceUnavailable
+	}
+	helper.WriteToResponseBodyWstatus(writer, webResponse)
+}
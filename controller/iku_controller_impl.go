@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/iku"
@@ -67,14 +68,8 @@ func (controller *IkuControllerImpl) FindAllIkuOpd(writer http.ResponseWriter, r
 
 	ikuOpdResponses, err := controller.IkuService.FindAllIkuOpd(request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-
-		helper.WriteToResponseBody(writer, webResponse)
-
+		exception.WriteError(writer, request, err)
+		return
 	}
 
 	webResponse := web.WebResponse{
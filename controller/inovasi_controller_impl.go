@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/inovasi"
@@ -43,11 +44,7 @@ func (controller *InovasiControllerImpl) Create(writer http.ResponseWriter, requ
 	// Panggil service untuk membuat gambaran umum
 	inovasiResponse, err := controller.InovasiService.Create(request.Context(), inovasiCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -68,11 +65,7 @@ func (controller *InovasiControllerImpl) Update(writer http.ResponseWriter, requ
 
 	inovasiResponse, err := controller.InovasiService.Update(request.Context(), inovasiUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -116,11 +109,7 @@ func (controller *InovasiControllerImpl) FindById(writer http.ResponseWriter, re
 				Data:   "ID inovasi tidak ditemukan",
 			})
 		} else {
-			helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-				Code:   http.StatusInternalServerError,
-				Status: "INTERNAL SERVER ERROR",
-				Data:   err.Error(),
-			})
+			exception.WriteError(writer, request, err)
 		}
 		return
 	}
@@ -146,11 +135,7 @@ func (controller *InovasiControllerImpl) Delete(writer http.ResponseWriter, requ
 				Data:   "ID inovasi tidak ditemukan",
 			})
 		} else {
-			helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-				Code:   http.StatusInternalServerError,
-				Status: "INTERNAL SERVER ERROR",
-				Data:   err.Error(),
-			})
+			exception.WriteError(writer, request, err)
 		}
 		return
 	}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/jabatan"
@@ -27,6 +28,9 @@ func (controller *JabatanControllerImpl) Create(writer http.ResponseWriter, requ
 
 	jabatanResponse, err := controller.jabatanService.Create(request.Context(), jabatanCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create data jabatan",
@@ -51,6 +55,9 @@ func (controller *JabatanControllerImpl) Update(writer http.ResponseWriter, requ
 
 	jabatanResponse, err := controller.jabatanService.Update(request.Context(), jabatanUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed update data jabatan",
@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type JobController interface {
+	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Cancel(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
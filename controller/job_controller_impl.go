@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type JobControllerImpl struct {
+	JobService service.JobService
+}
+
+func NewJobControllerImpl(jobService service.JobService) *JobControllerImpl {
+	return &JobControllerImpl{JobService: jobService}
+}
+
+// FindById godoc
+// @Summary      Status job
+// @Description  Status, progress, warning dan hasil job background (clone rencana kinerja, clone pohon kinerja OPD, clone pohon kinerja pemda, roll forward OPD).
+// @Tags         Job
+// @Produce      json
+// @Param        id   path      int  true  "ID job"
+// @Success      200  {object}  web.WebResponse{data=job.JobResponse}
+// @Failure      404  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /jobs/{id} [get]
+func (controller *JobControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id job harus berupa angka"))
+		return
+	}
+
+	response, err := controller.JobService.FindById(request.Context(), id)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Cancel godoc
+// @Summary      Batalkan job
+// @Description  Job yang belum jalan langsung dibatalkan, job yang sedang jalan dihentikan pada heartbeat berikutnya dan seluruh perubahannya di-rollback.
+// @Tags         Job
+// @Produce      json
+// @Param        id   path      int  true  "ID job"
+// @Success      200  {object}  web.WebResponse{data=job.JobResponse}
+// @Failure      409  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /jobs/{id}/cancel [post]
+func (controller *JobControllerImpl) Cancel(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id job harus berupa angka"))
+		return
+	}
+
+	response, err := controller.JobService.Cancel(request.Context(), id)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/kegiatan"
@@ -28,6 +29,9 @@ func (controller *KegiatanControllerImpl) Create(writer http.ResponseWriter, req
 	// Panggil service untuk membuat kegiatan baru
 	kegiatanResponse, err := controller.KegiatanService.Create(request.Context(), kegiatanCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -57,6 +61,9 @@ func (controller *KegiatanControllerImpl) Update(writer http.ResponseWriter, req
 	// Panggil service untuk update kegiatan
 	kegiatanResponse, err := controller.KegiatanService.Update(request.Context(), kegiatanUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
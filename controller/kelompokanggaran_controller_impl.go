@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/kelompokanggarans"
@@ -28,6 +29,9 @@ func (controller *KelompokAnggaranControllerImpl) Create(writer http.ResponseWri
 	// Panggil service untuk membuat kegiatan baru
 	kelompokResponse, err := controller.kelompokanggaranService.Create(request.Context(), KelompokAnggaranCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -58,6 +62,9 @@ func (controller *KelompokAnggaranControllerImpl) Update(writer http.ResponseWri
 	// Panggil service untuk update kegiatan
 	kelompokResponse, err := controller.kelompokanggaranService.Update(request.Context(), KelompokAnggaranUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/lembaga"
@@ -26,12 +27,7 @@ func (controller *LembagaControllerImpl) Create(writer http.ResponseWriter, requ
 
 	lembagaResponse, err := controller.LembagaService.Create(request.Context(), lembagaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -53,12 +49,7 @@ func (controller *LembagaControllerImpl) Update(writer http.ResponseWriter, requ
 
 	lembagaResponse, err := controller.LembagaService.Update(request.Context(), lembagaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -88,12 +79,7 @@ func (controller *LembagaControllerImpl) FindById(writer http.ResponseWriter, re
 
 	lembagaResponse, err := controller.LembagaService.FindById(request.Context(), lembagaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -108,12 +94,7 @@ func (controller *LembagaControllerImpl) FindById(writer http.ResponseWriter, re
 func (controller *LembagaControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	lembagaResponse, err := controller.LembagaService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
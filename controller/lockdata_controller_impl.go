@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/lockdata"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type LockDataControllerImpl struct {
+	LockDataService service.LockDataService
+}
+
+func NewLockDataControllerImpl(lockDataService service.LockDataService) *LockDataControllerImpl {
+	return &LockDataControllerImpl{LockDataService: lockDataService}
+}
+
+// Lock godoc
+// @Summary      Kunci data
+// @Description  Mengunci dokumen perencanaan (jenis_data) untuk kode OPD dan tahun tertentu. Data yang terkunci tidak dapat diubah (423 Locked).
+// @Tags         Lock Data
+// @Accept       json
+// @Produce      json
+// @Param        request  body      lockdata.LockDataRequest  true  "Data yang dikunci"
+// @Success      200      {object}  web.WebResponse{data=lockdata.LockDataResponse}
+// @Failure      400      {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /lock_data/lock [post]
+func (controller *LockDataControllerImpl) Lock(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	lockRequest := lockdata.LockDataRequest{}
+	helper.ReadFromRequestBody(request, &lockRequest)
+
+	response, err := controller.LockDataService.Lock(request.Context(), lockRequest)
+	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Unlock godoc
+// @Summary      Buka kunci data
+// @Description  Membuka kunci dokumen perencanaan (jenis_data) untuk kode OPD dan tahun tertentu.
+// @Tags         Lock Data
+// @Accept       json
+// @Produce      json
+// @Param        request  body      lockdata.LockDataRequest  true  "Data yang dibuka kuncinya"
+// @Success      200      {object}  web.WebResponse{data=lockdata.LockDataResponse}
+// @Failure      400      {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /lock_data/unlock [post]
+func (controller *LockDataControllerImpl) Unlock(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	unlockRequest := lockdata.LockDataRequest{}
+	helper.ReadFromRequestBody(request, &unlockRequest)
+
+	response, err := controller.LockDataService.Unlock(request.Context(), unlockRequest)
+	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindAll godoc
+// @Summary      Daftar data terkunci
+// @Description  Menampilkan daftar dokumen yang terkunci, bisa difilter kode_opd dan tahun.
+// @Tags         Lock Data
+// @Produce      json
+// @Param        kode_opd  query     string  false  "Kode OPD"
+// @Param        tahun     query     string  false  "Tahun"
+// @Success      200       {object}  web.WebResponse{data=[]lockdata.LockDataResponse}
+// @Failure      400       {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /lock_data/findall [get]
+func (controller *LockDataControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	kodeOpd := request.URL.Query().Get("kode_opd")
+	tahun := request.URL.Query().Get("tahun")
+
+	responses, err := controller.LockDataService.FindAll(request.Context(), kodeOpd, tahun)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   responses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rencanakinerja"
@@ -28,6 +29,9 @@ func (controller *ManualIKControllerImpl) Create(writer http.ResponseWriter, req
 
 	manualIKResponse, err := controller.ManualIKService.Create(request.Context(), manualIKCreateRequest, indikatorId)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD_REQUEST",
@@ -53,7 +57,10 @@ func (controller *ManualIKControllerImpl) Update(writer http.ResponseWriter, req
 	helper.ReadFromRequestBody(request, &manualIKUpdateRequest)
 
 	manualIKResponse, err := controller.ManualIKService.Update(request.Context(), manualIKUpdateRequest, indikatorId)
-	helper.PanicIfError(err)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
 
 	webResponse := web.WebResponse{
 		Code:   200,
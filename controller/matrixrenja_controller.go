@@ -14,4 +14,7 @@ type MatrixRenjaController interface {
 	UpsertBatchIndikatorRenjaRankhir(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpsertBatchIndikatorRenjaPenetapan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpsertAnggaran(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportRenjaRanwal(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportRenjaRankhir(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportRenjaPenetapan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
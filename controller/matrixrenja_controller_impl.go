@@ -1,10 +1,12 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 	"ekak_kabupaten_madiun/service"
+	"fmt"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -138,6 +140,9 @@ func (controller *MatrixRenjaControllerImpl) UpsertBatchIndikatorRenjaRanwal(wri
 	}
 	BatchIndikatorRenjaResponse, err := controller.MatrixRenjaService.UpsertBatchIndikatorRenja(request.Context(), BatchIndikatorRenjaRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -175,6 +180,9 @@ func (controller *MatrixRenjaControllerImpl) UpsertBatchIndikatorRenjaRankhir(wr
 
 	BatchIndikatorRenjaResponse, err := controller.MatrixRenjaService.UpsertBatchIndikatorRenja(request.Context(), BatchIndikatorRenjaRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -212,6 +220,9 @@ func (controller *MatrixRenjaControllerImpl) UpsertBatchIndikatorRenjaPenetapan(
 
 	BatchIndikatorRenjaResponse, err := controller.MatrixRenjaService.UpsertBatchIndikatorRenjaPenetapan(request.Context(), BatchIndikatorRenjaRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -245,6 +256,9 @@ func (controller *MatrixRenjaControllerImpl) UpsertAnggaran(writer http.Response
 
 	AnggaranRenjaResponse, err := controller.MatrixRenjaService.UpsertAnggaran(request.Context(), AnggaranRenjaRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -261,3 +275,66 @@ func (controller *MatrixRenjaControllerImpl) UpsertAnggaran(writer http.Response
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+// @Summary      Export Excel Matrix Renja Ranwal
+// @Description  Mengunduh matrix renja rancangan awal dalam format .xlsx (urusan → bidang urusan → program → kegiatan → subkegiatan) beserta indikator, target, satuan dan subtotal pagu tiap level.
+// @Tags         Matrix Renja
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        kode_opd  path     string  true  "Kode OPD"   example("1.01.1.01.0.00.01.0000")
+// @Param        tahun     path     string  true  "Tahun"      example("2025")
+// @Success      200  {file}    file
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /matrix_renja/ranwal/{kode_opd}/{tahun}/excel [get]
+func (controller *MatrixRenjaControllerImpl) ExportRenjaRanwal(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	controller.exportRenjaExcel(writer, request, params, "ranwal")
+}
+
+// @Summary      Export Excel Matrix Renja Rankhir
+// @Description  Mengunduh matrix renja rancangan akhir dalam format .xlsx (urusan → bidang urusan → program → kegiatan → subkegiatan) beserta indikator, target, satuan dan subtotal pagu tiap level.
+// @Tags         Matrix Renja
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        kode_opd  path     string  true  "Kode OPD"   example("1.01.1.01.0.00.01.0000")
+// @Param        tahun     path     string  true  "Tahun"      example("2025")
+// @Success      200  {file}    file
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /matrix_renja/rankhir/{kode_opd}/{tahun}/excel [get]
+func (controller *MatrixRenjaControllerImpl) ExportRenjaRankhir(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	controller.exportRenjaExcel(writer, request, params, "rankhir")
+}
+
+// @Summary      Export Excel Matrix Renja Penetapan
+// @Description  Mengunduh matrix renja penetapan dalam format .xlsx (urusan → bidang urusan → program → kegiatan → subkegiatan) beserta indikator, target, satuan dan subtotal pagu tiap level.
+// @Tags         Matrix Renja
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        kode_opd  path     string  true  "Kode OPD"   example("1.01.1.01.0.00.01.0000")
+// @Param        tahun     path     string  true  "Tahun"      example("2025")
+// @Success      200  {file}    file
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /matrix_renja/penetapan/{kode_opd}/{tahun}/excel [get]
+func (controller *MatrixRenjaControllerImpl) ExportRenjaPenetapan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	controller.exportRenjaExcel(writer, request, params, "penetapan")
+}
+
+func (controller *MatrixRenjaControllerImpl) exportRenjaExcel(writer http.ResponseWriter, request *http.Request, params httprouter.Params, jenis string) {
+	kodeOpd := params.ByName("kode_opd")
+	tahun := params.ByName("tahun")
+	content, err := controller.MatrixRenjaService.ExportRenjaExcel(request.Context(), kodeOpd, tahun, jenis)
+	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
+		webResponse := web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err.Error(),
+		}
+		helper.WriteToResponseBodyWstatus(writer, webResponse)
+		return
+	}
+
+	filename := fmt.Sprintf("matrix_renja_%s_%s_%s.xlsx", jenis, kodeOpd, tahun)
+	helper.WriteFileResponse(writer, helper.ContentTypeXlsx, filename, content)
+}
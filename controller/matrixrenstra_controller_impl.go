@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
@@ -102,6 +103,9 @@ func (controller *MatrixRenstraControllerImpl) UpsertAnggaran(writer http.Respon
 
 	AnggaranRenstraResponse, err := controller.MatrixRenstraService.UpsertAnggaran(request.Context(), AnggaranRenstraRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsControllerImpl struct {
+	handler http.Handler
+	token   string
+}
+
+// NewMetricsControllerImpl mendaftarkan statistik connection pool database ke registry Prometheus.
+// Jika env METRICS_TOKEN diisi, /metrics hanya bisa diakses dengan header Authorization: Bearer <token>.
+func NewMetricsControllerImpl(DB *sql.DB) *MetricsControllerImpl {
+	err := prometheus.Register(collectors.NewDBStatsCollector(DB, "mysql"))
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &alreadyRegistered) {
+		log.Printf("gagal mendaftarkan metrics database: %v", err)
+	}
+
+	return &MetricsControllerImpl{
+		handler: promhttp.Handler(),
+		token:   os.Getenv("METRICS_TOKEN"),
+	}
+}
+
+// Metrics godoc
+// @Summary      Prometheus metrics
+// @Description  Latency per route, statistik pool MySQL, hit/miss Redis cache dan jumlah job background dalam format Prometheus.
+// @Tags         Health
+// @Produce      plain
+// @Success      200  {string}  string
+// @Failure      401  {object}  web.WebResponse
+// @Router       /metrics [get]
+func (controller *MetricsControllerImpl) Metrics(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	if controller.token != "" {
+		token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(controller.token)) != 1 {
+			exception.WriteError(writer, request, web.NewUnauthorizedError("token metrics tidak valid"))
+			return
+		}
+	}
+	controller.handler.ServeHTTP(writer, request)
+}
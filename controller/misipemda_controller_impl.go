@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	visimisipemda "ekak_kabupaten_madiun/model/web/visimisi"
@@ -25,6 +26,9 @@ func (controller *MisiPemdaControllerImpl) Create(writer http.ResponseWriter, re
 
 	misiPemdaResponse, err := controller.MisiPemdaService.Create(request.Context(), misiPemdaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -62,6 +66,9 @@ func (controller *MisiPemdaControllerImpl) Update(writer http.ResponseWriter, re
 
 	misiPemdaResponse, err := controller.MisiPemdaService.Update(request.Context(), misiPemdaUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		helper.WriteToResponseBody(writer, err.Error())
 		return
 	}
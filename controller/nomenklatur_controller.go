@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type NomenklaturController interface {
+	PreviewImport(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ApplyImport(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
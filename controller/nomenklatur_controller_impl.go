@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// nomenklaturMaxUpload batas ukuran file nomenklatur, file resmi kemendagri sekitar 2 MB
+const nomenklaturMaxUpload = 20 << 20
+
+type NomenklaturControllerImpl struct {
+	NomenklaturService service.NomenklaturService
+}
+
+func NewNomenklaturControllerImpl(nomenklaturService service.NomenklaturService) *NomenklaturControllerImpl {
+	return &NomenklaturControllerImpl{NomenklaturService: nomenklaturService}
+}
+
+// PreviewImport godoc
+// @Summary      Preview import nomenklatur kemendagri
+// @Description  Dry run import nomenklatur urusan sampai sub kegiatan dari file CSV/XLSX. Menampilkan data yang akan di-insert, di-update dan dinonaktifkan tanpa mengubah database.
+// @Tags         Nomenklatur
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "File nomenklatur (.csv atau .xlsx)"
+// @Success      200   {object}  web.WebResponse{data=nomenklatur.NomenklaturImportResponse}
+// @Failure      400   {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /nomenklatur/import/preview [post]
+func (controller *NomenklaturControllerImpl) PreviewImport(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	filename, content, err := helper.ReadUploadedFile(request, "file", nomenklaturMaxUpload)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	response, err := controller.NomenklaturService.PreviewImport(request.Context(), filename, content)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// ApplyImport godoc
+// @Summary      Terapkan import nomenklatur kemendagri
+// @Description  Menerapkan change set import nomenklatur dalam satu transaksi. Kode yang tidak ada lagi di file dinonaktifkan, bukan dihapus.
+// @Tags         Nomenklatur
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "File nomenklatur (.csv atau .xlsx)"
+// @Success      200   {object}  web.WebResponse{data=nomenklatur.NomenklaturImportResponse}
+// @Failure      400   {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /nomenklatur/import/apply [post]
+func (controller *NomenklaturControllerImpl) ApplyImport(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	filename, content, err := helper.ReadUploadedFile(request, "file", nomenklaturMaxUpload)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	response, err := controller.NomenklaturService.ApplyImport(request.Context(), filename, content)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
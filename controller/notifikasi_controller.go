@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type NotifikasiController interface {
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	JumlahBelumDibaca(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Baca(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	BacaSemua(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Stream(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
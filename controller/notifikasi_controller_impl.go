@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/notifikasi"
+	"ekak_kabupaten_madiun/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type NotifikasiControllerImpl struct {
+	NotifikasiService service.NotifikasiService
+}
+
+func NewNotifikasiControllerImpl(notifikasiService service.NotifikasiService) *NotifikasiControllerImpl {
+	return &NotifikasiControllerImpl{NotifikasiService: notifikasiService}
+}
+
+// FindAll godoc
+// @Summary      Inbox notifikasi user yang sedang login
+// @Description  Urut dari yang terbaru. Filter: dibaca (true/false), jenis, kode_opd, tahun. Pencarian q pada judul dan pesan.
+// @Tags         Notifikasi
+// @Produce      json
+// @Param        dibaca     query     bool    false  "Filter sudah/belum dibaca"
+// @Param        jenis      query     string  false  "crosscutting, review, pokin_ditolak, persetujuan_rekin"
+// @Param        page       query     int     false  "Halaman"
+// @Param        page_size  query     int     false  "Jumlah data per halaman"
+// @Success      200        {object}  web.WebResponse{data=[]notifikasi.NotifikasiResponse}
+// @Failure      400        {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /notifikasi [get]
+func (controller *NotifikasiControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	listQuery, err := helper.ParseListQuery(request)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	responses, total, err := controller.NotifikasiService.FindAll(request.Context(), listQuery)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:       http.StatusOK,
+		Status:     "OK",
+		Data:       responses,
+		Pagination: helper.NewPagination(listQuery, total),
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// JumlahBelumDibaca godoc
+// @Summary      Jumlah notifikasi belum dibaca
+// @Tags         Notifikasi
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=notifikasi.JumlahBelumDibacaResponse}
+// @Security     BearerAuth
+// @Router       /notifikasi/belum_dibaca/jumlah [get]
+func (controller *NotifikasiControllerImpl) JumlahBelumDibaca(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.NotifikasiService.JumlahBelumDibaca(request.Context())
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Baca godoc
+// @Summary      Tandai notifikasi sudah dibaca
+// @Tags         Notifikasi
+// @Produce      json
+// @Param        id   path      int  true  "Id notifikasi"
+// @Success      200  {object}  web.WebResponse
+// @Failure      400  {object}  web.WebResponse
+// @Failure      404  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /notifikasi/baca/{id} [put]
+func (controller *NotifikasiControllerImpl) Baca(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id notifikasi harus berupa angka"))
+		return
+	}
+
+	if err := controller.NotifikasiService.Baca(request.Context(), id); err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "notifikasi ditandai sudah dibaca",
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// BacaSemua godoc
+// @Summary      Tandai semua notifikasi sudah dibaca
+// @Tags         Notifikasi
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=notifikasi.BacaSemuaResponse}
+// @Security     BearerAuth
+// @Router       /notifikasi/baca_semua [put]
+func (controller *NotifikasiControllerImpl) BacaSemua(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.NotifikasiService.BacaSemua(request.Context())
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Stream godoc
+// @Summary      Stream notifikasi baru (Server-Sent Events)
+// @Description  Event "notifikasi" berisi NotifikasiResponse dengan id event = id notifikasi. Saat reconnect, notifikasi setelah Last-Event-ID dikirim ulang.
+// @Description  EventSource browser tidak dapat mengirim header Authorization, token dapat dikirim lewat query access_token.
+// @Tags         Notifikasi
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header    int     false  "Id notifikasi terakhir yang sudah diterima"
+// @Param        access_token   query     string  false  "Access token jika header Authorization tidak dapat dikirim"
+// @Success      200            {object}  notifikasi.NotifikasiResponse
+// @Security     BearerAuth
+// @Router       /notifikasi/stream [get]
+func (controller *NotifikasiControllerImpl) Stream(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	lastId := int64(-1)
+	if lastEventId := request.Header.Get("Last-Event-ID"); lastEventId != "" {
+		if id, err := strconv.ParseInt(lastEventId, 10, 64); err == nil && id >= 0 {
+			lastId = id
+		}
+	}
+
+	sse, err := helper.NewSSEWriter(writer)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	kirim := func(response notifikasi.NotifikasiResponse) error {
+		return sse.Event(strconv.FormatInt(response.Id, 10), "notifikasi", response)
+	}
+	ping := func() error {
+		return sse.Comment("ping")
+	}
+	// header sudah terkirim, error setelah ini hanya bisa dicatat
+	if err := controller.NotifikasiService.Stream(request.Context(), lastId, kirim, ping); err != nil && request.Context().Err() == nil {
+		slog.ErrorContext(request.Context(), "stream notifikasi berhenti", slog.String("error", err.Error()))
+	}
+}
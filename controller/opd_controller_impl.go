@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
@@ -27,11 +28,7 @@ func (controller *OpdControllerImpl) Create(writer http.ResponseWriter, request
 
 	opdResponse, err := controller.OpdService.Create(request.Context(), opdCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   500,
-			Status: "error",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -53,6 +50,9 @@ func (controller *OpdControllerImpl) Update(writer http.ResponseWriter, request
 
 	opdResponse, err := controller.OpdService.Update(request.Context(), opdUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		helper.WriteToResponseBody(writer, err)
 		return
 	}
@@ -88,11 +88,7 @@ func (controller *OpdControllerImpl) FindById(writer http.ResponseWriter, reques
 
 	opdResponse, err := controller.OpdService.FindById(request.Context(), opdId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   500,
-			Status: "error",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -107,11 +103,7 @@ func (controller *OpdControllerImpl) FindById(writer http.ResponseWriter, reques
 func (controller *OpdControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	opdResponses, err := controller.OpdService.FindAll(request.Context())
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   500,
-			Status: "error",
-			Data:   "Gagal mengambil data OPD. Silakan coba lagi.",
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
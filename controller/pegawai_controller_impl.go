@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pegawai"
@@ -26,12 +27,7 @@ func (controller *PegawaiControllerImpl) Create(writer http.ResponseWriter, requ
 
 	pegawaiResponse, err := controller.PegawaiService.Create(request.Context(), pegawaiCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -53,6 +49,9 @@ func (controller *PegawaiControllerImpl) Update(writer http.ResponseWriter, requ
 
 	pegawaiResponse, err := controller.PegawaiService.Update(request.Context(), pegawaiUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   404,
 			Status: "Not Found",
@@ -114,23 +113,23 @@ func (controller *PegawaiControllerImpl) FindById(writer http.ResponseWriter, re
 }
 
 func (controller *PegawaiControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	kodeOpd := request.URL.Query().Get("kode_opd")
-	nip := request.URL.Query().Get("nip")
-	pegawaiResponses, err := controller.PegawaiService.FindAll(request.Context(), kodeOpd, nip)
+	listQuery, err := helper.ParseListQuery(request)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	pegawaiResponses, total, err := controller.PegawaiService.FindAll(request.Context(), listQuery)
+	if err != nil {
+		exception.WriteError(writer, request, err)
 		return
 	}
 
 	webResponse := web.WebResponse{
-		Code:   200,
-		Status: "OK",
-		Data:   pegawaiResponses,
+		Code:       200,
+		Status:     "OK",
+		Data:       pegawaiResponses,
+		Pagination: helper.NewPagination(listQuery, total),
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
@@ -141,12 +140,7 @@ func (controller *PegawaiControllerImpl) TambahJabatanPegawai(writer http.Respon
 
 	pegawaiResponse, err := controller.PegawaiService.TambahJabatan(request.Context(), tambahJabatanRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
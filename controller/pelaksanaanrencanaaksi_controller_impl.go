@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rencanaaksi"
@@ -39,6 +40,9 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) Create(writer http.Respo
 
 	response, err := controller.PelaksanaanRencanaAksiService.Create(request.Context(), createRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebPelaksanaanRencanaAksiResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -64,21 +68,7 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) Update(writer http.Respo
 
 	response, err := controller.PelaksanaanRencanaAksiService.Update(request.Context(), updateRequest)
 	if err != nil {
-		if customErr, ok := err.(*web.CustomError); ok {
-			webResponse := web.WebPelaksanaanRencanaAksiResponse{
-				Code:   customErr.Code,
-				Status: http.StatusText(customErr.Code),
-				Data:   customErr.Message,
-			}
-			helper.WriteToResponseBody(writer, webResponse)
-		} else {
-			webResponse := web.WebPelaksanaanRencanaAksiResponse{
-				Code:   http.StatusInternalServerError,
-				Status: "INTERNAL SERVER ERROR",
-				Data:   err.Error(),
-			}
-			helper.WriteToResponseBody(writer, webResponse)
-		}
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -119,12 +109,7 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) Delete(writer http.Respo
 
 	err := controller.PelaksanaanRencanaAksiService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -142,12 +127,7 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) FindByRencanaAksiId(writ
 
 	responses, err := controller.PelaksanaanRencanaAksiService.FindByRencanaAksiId(request.Context(), rencanaAksiId)
 	if err != nil {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/periodetahun"
@@ -25,6 +26,9 @@ func (controller *PeriodeControllerImpl) Create(writer http.ResponseWriter, requ
 
 	periodeResponse, err := controller.PeriodeService.Create(request.Context(), periodeCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -50,6 +54,9 @@ func (controller *PeriodeControllerImpl) Update(writer http.ResponseWriter, requ
 
 	periodeResponse, err := controller.PeriodeService.Update(request.Context(), periodeUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/permasalahan"
@@ -27,6 +28,9 @@ func (controller *PermasalahanRekinControllerImpl) Create(writer http.ResponseWr
 
 	permasalahanRekinResponse, err := controller.PermasalahanRekinService.Create(request.Context(), permasalahanRekinCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -63,6 +67,9 @@ func (controller *PermasalahanRekinControllerImpl) Update(writer http.ResponseWr
 
 	permasalahanRekinResponse, err := controller.PermasalahanRekinService.Update(request.Context(), permasalahanRekinUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PersetujuanRekinController interface {
+	Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByRekinId(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindMenunggu(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/persetujuanrekin"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PersetujuanRekinControllerImpl struct {
+	PersetujuanRekinService service.PersetujuanRekinService
+}
+
+func NewPersetujuanRekinControllerImpl(persetujuanRekinService service.PersetujuanRekinService) *PersetujuanRekinControllerImpl {
+	return &PersetujuanRekinControllerImpl{PersetujuanRekinService: persetujuanRekinService}
+}
+
+// Transisi godoc
+// @Summary      Ubah status persetujuan rencana kinerja
+// @Description  Aksi: ajukan (pemilik), setujui/kembalikan (atasan dari struktur organisasi), finalkan/buka (admin). Catatan wajib untuk kembalikan dan buka.
+// @Tags         Persetujuan Rencana Kinerja
+// @Accept       json
+// @Produce      json
+// @Param        rencana_kinerja_id  path      string                                     true  "Id rencana kinerja"
+// @Param        request             body      persetujuanrekin.PersetujuanRekinRequest  true  "Aksi persetujuan"
+// @Success      200                 {object}  web.WebResponse{data=persetujuanrekin.StatusRekinResponse}
+// @Failure      400                 {object}  web.WebResponse
+// @Failure      403                 {object}  web.WebResponse
+// @Failure      404                 {object}  web.WebResponse
+// @Failure      409                 {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /persetujuan_rekin/{rencana_kinerja_id} [post]
+func (controller *PersetujuanRekinControllerImpl) Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	transisiRequest := persetujuanrekin.PersetujuanRekinRequest{}
+	helper.ReadFromRequestBody(request, &transisiRequest)
+	transisiRequest.RekinId = params.ByName("rencana_kinerja_id")
+
+	response, err := controller.PersetujuanRekinService.Transisi(request.Context(), transisiRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindByRekinId godoc
+// @Summary      Status dan riwayat persetujuan rencana kinerja
+// @Tags         Persetujuan Rencana Kinerja
+// @Produce      json
+// @Param        rencana_kinerja_id  path      string  true  "Id rencana kinerja"
+// @Success      200                 {object}  web.WebResponse{data=persetujuanrekin.StatusRekinResponse}
+// @Failure      404                 {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /persetujuan_rekin/detail/{rencana_kinerja_id} [get]
+func (controller *PersetujuanRekinControllerImpl) FindByRekinId(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.PersetujuanRekinService.FindByRekinId(request.Context(), params.ByName("rencana_kinerja_id"))
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindMenunggu godoc
+// @Summary      Rencana kinerja bawahan yang menunggu persetujuan
+// @Description  Daftar rencana kinerja berstatus diajukan dari bawahan user yang sedang login
+// @Tags         Persetujuan Rencana Kinerja
+// @Produce      json
+// @Param        tahun  path      string  true  "Tahun"
+// @Success      200    {object}  web.WebResponse{data=[]persetujuanrekin.StatusRekinResponse}
+// @Failure      403    {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /persetujuan_rekin/menunggu/{tahun} [get]
+func (controller *PersetujuanRekinControllerImpl) FindMenunggu(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.PersetujuanRekinService.FindMenunggu(request.Context(), params.ByName("tahun"))
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pkopd"
@@ -49,12 +51,7 @@ func (controller *PkControllerImpl) FindAllPkOpdTahunan(w http.ResponseWriter, r
 
 	response, err := controller.pkOpdService.FindByKodeOpdTahun(r.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "[ERROR] PK OPD sedang tidak dapat diakses",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		exception.WriteError(w, r, err)
 		return
 	}
 
@@ -73,12 +70,7 @@ func (controller *PkControllerImpl) HubungkanRekin(w http.ResponseWriter, r *htt
 
 	hubungkanResponse, err := controller.pkOpdService.HubungkanRekin(r.Context(), hubungkanRekinRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "[ERROR] Terjadi kesalahan di hubungkan rekin",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		exception.WriteError(w, r, err)
 		return
 	}
 
@@ -97,12 +89,7 @@ func (controller *PkControllerImpl) HubungkanAtasan(w http.ResponseWriter, r *ht
 
 	hubungkanResponse, err := controller.pkOpdService.HubungkanAtasan(r.Context(), hubungkanAtasanRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "[ERROR] Terjadi kesalahan di hubungkan atasan",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		exception.WriteError(w, r, err)
 		return
 	}
 
@@ -114,3 +101,42 @@ func (controller *PkControllerImpl) HubungkanAtasan(w http.ResponseWriter, r *ht
 
 	helper.WriteToResponseBody(w, webResponse)
 }
+
+// CetakPdfPegawai mengunduh dokumen PK satu pegawai dalam format PDF
+func (controller *PkControllerImpl) CetakPdfPegawai(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	kodeOpd := params.ByName("kode_opd")
+	nip := params.ByName("nip")
+	tahun, err := strconv.Atoi(params.ByName("tahun"))
+	if err != nil {
+		exception.WriteError(w, r, web.NewBadRequestError("Tahun tidak sesuai"))
+		return
+	}
+
+	content, err := controller.pkOpdService.GeneratePdfPegawai(r.Context(), kodeOpd, tahun, nip)
+	if err != nil {
+		exception.WriteError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("PK_%d_%s.pdf", tahun, nip)
+	helper.WriteFileResponse(w, helper.ContentTypePdf, filename, content)
+}
+
+// CetakPdfOpd mengunduh dokumen PK seluruh pegawai OPD sebagai zip berisi PDF per pegawai
+func (controller *PkControllerImpl) CetakPdfOpd(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	kodeOpd := params.ByName("kode_opd")
+	tahun, err := strconv.Atoi(params.ByName("tahun"))
+	if err != nil {
+		exception.WriteError(w, r, web.NewBadRequestError("Tahun tidak sesuai"))
+		return
+	}
+
+	content, err := controller.pkOpdService.GeneratePdfOpdZip(r.Context(), kodeOpd, tahun)
+	if err != nil {
+		exception.WriteError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("PK_%s_%d.zip", kodeOpd, tahun)
+	helper.WriteFileResponse(w, helper.ContentTypeZip, filename, content)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
@@ -27,6 +28,9 @@ func (controller *PohonKinerjaAdminControllerImpl) Create(writer http.ResponseWr
 	// Panggil service create
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.Create(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -58,6 +62,9 @@ func (controller *PohonKinerjaAdminControllerImpl) Update(writer http.ResponseWr
 	// Panggil service update
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.Update(request.Context(), pohonKinerjaUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -155,12 +162,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindAll(writer http.ResponseW
 	// Panggil service findAll
 	result, err := controller.pohonKinerjaAdminService.FindAll(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -180,12 +182,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindSubTematik(writer http.Re
 	// Panggil service findAll
 	result, err := controller.pohonKinerjaAdminService.FindSubTematik(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -216,12 +213,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinAdminByIdHierarki(wr
 	// Panggil service findAll
 	result, err := controller.pohonKinerjaAdminService.FindPokinAdminByIdHierarki(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -242,6 +234,9 @@ func (controller *PohonKinerjaAdminControllerImpl) CreateStrategicAdmin(writer h
 	// Panggil service create
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.CreateStrategicAdmin(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -266,12 +261,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByTematik(writer htt
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByTematik(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -290,12 +280,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByStrategic(writer h
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByStrategic(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -314,12 +299,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByTactical(writer ht
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByTactical(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -338,12 +318,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByOperational(writer
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByOperational(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -362,12 +337,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByStatus(writer http
 
 	pokinResponse, err := controller.pohonKinerjaAdminService.FindPokinByStatus(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -387,6 +357,9 @@ func (controller *PohonKinerjaAdminControllerImpl) CloneStrategiFromPemda(writer
 	// Panggil service create
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.CloneStrategiFromPemda(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -425,6 +398,9 @@ func (controller *PohonKinerjaAdminControllerImpl) UpdatePokinStatusTolak(writer
 
 	err = controller.pohonKinerjaAdminService.TolakPokin(request.Context(), pohonKinerjaUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -450,6 +426,9 @@ func (controller *PohonKinerjaAdminControllerImpl) CrosscuttingOpd(writer http.R
 	// Panggil service create
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.CrosscuttingOpd(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -475,12 +454,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByCrosscuttingStatus
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByCrosscuttingStatus(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -620,12 +594,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinFromOpd(writer http.
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinFromOpd(request.Context(), kodeOpd, tahun, levelPohon)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -747,8 +716,11 @@ func (controller *PohonKinerjaAdminControllerImpl) ClonePokinPemda(writer http.R
 
 	pohonKinerjaCloneRequest.IdPokinSource = id
 
-	result, err := controller.pohonKinerjaAdminService.ClonePokinPemda(request.Context(), pohonKinerjaCloneRequest)
+	cloneJob, err := controller.pohonKinerjaAdminService.ClonePokinPemda(request.Context(), pohonKinerjaCloneRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -759,10 +731,42 @@ func (controller *PohonKinerjaAdminControllerImpl) ClonePokinPemda(writer http.R
 	}
 
 	webResponse := web.WebResponse{
-		Code:   http.StatusOK,
-		Status: "Success Clone Pokin Pemda",
-		Data:   result,
+		Code:   http.StatusAccepted,
+		Status: "Clone Pokin Pemda masuk antrian",
+		Data:   cloneJob,
 	}
 
+	helper.WriteToResponseBodyWstatus(writer, webResponse)
+}
+
+// FindTransisi godoc
+// @Summary      Aksi status dan riwayat transisi pohon kinerja
+// @Description  Status pohon kinerja, tombol aksi status (terima, tolak, clone) yang boleh dijalankan user yang sedang login dan riwayat transisi beserta alasannya
+// @Tags         Pohon Kinerja Admin
+// @Produce      json
+// @Param        id   path      int  true  "Id pohon kinerja"
+// @Success      200  {object}  web.WebResponse{data=pohonkinerja.TransisiPokinResponse}
+// @Failure      400  {object}  web.WebResponse
+// @Failure      404  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /pohon_kinerja/transisi/{id} [get]
+func (controller *PohonKinerjaAdminControllerImpl) FindTransisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id pohon kinerja harus berupa angka"))
+		return
+	}
+
+	response, err := controller.pohonKinerjaAdminService.FindTransisi(request.Context(), id)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"database/sql"
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
@@ -28,6 +29,9 @@ func (controller *PohonKinerjaOpdControllerImpl) Create(writer http.ResponseWrit
 
 	response, err := controller.PohonKinerjaOpdService.Create(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -66,6 +70,9 @@ func (controller *PohonKinerjaOpdControllerImpl) Update(writer http.ResponseWrit
 	// Panggil service Update
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.Update(request.Context(), pohonKinerjaUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -100,6 +107,9 @@ func (controller *PohonKinerjaOpdControllerImpl) Delete(writer http.ResponseWrit
 
 	err = controller.PohonKinerjaOpdService.Delete(request.Context(), id)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "Error",
@@ -137,6 +147,9 @@ func (controller *PohonKinerjaOpdControllerImpl) FindById(writer http.ResponseWr
 	// Panggil service FindById
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindById(request.Context(), id)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   404,
 			Status: "Not Found",
@@ -351,13 +364,7 @@ func (controller *PohonKinerjaOpdControllerImpl) FindStrategicNoParent(writer ht
 func (controller *PohonKinerjaOpdControllerImpl) DeletePelaksana(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	err := controller.PohonKinerjaOpdService.DeletePelaksana(request.Context(), params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusInternalServerError)
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -470,6 +477,9 @@ func (controller *PohonKinerjaOpdControllerImpl) UpdateParent(writer http.Respon
 
 	_, err = controller.PohonKinerjaOpdService.UpdateParent(request.Context(), pohonKinerjaUpdateParentRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "Error",
@@ -519,12 +529,12 @@ func (controller *PohonKinerjaOpdControllerImpl) FindidPokinWithAllTema(writer h
 }
 
 // @Summary      Clone Pohon Kinerja opd
-// @Description  Melakukan cloning pohon kinerja dari tahun sumber ke tahun tujuan untuk OPD tertentu.
+// @Description  Memasukkan cloning pohon kinerja dari tahun sumber ke tahun tujuan untuk OPD tertentu ke antrian job. Status dipantau lewat /jobs/{id}.
 // @Tags         Clone Pohon Kinerja Opd
 // @Accept       json
 // @Produce      json
 // @Param        pohon_kinerja_clone_request  body     pohonkinerja.PohonKinerjaCloneRequest  true  "Data untuk cloning pohon kinerja"
-// @Success      200  {object}  web.WebResponse{data=string}
+// @Success      202  {object}  web.WebResponse{data=job.JobResponse}
 // @Failure      400  {object}  web.WebResponse
 // @Security     BearerAuth
 // @Router       /pohon_kinerja_opd/clone [post]
@@ -532,8 +542,11 @@ func (controller *PohonKinerjaOpdControllerImpl) Clone(writer http.ResponseWrite
 	cloneRequest := pohonkinerja.PohonKinerjaCloneRequest{}
 	helper.ReadFromRequestBody(request, &cloneRequest)
 
-	err := controller.PohonKinerjaOpdService.CloneByKodeOpdAndTahun(request.Context(), cloneRequest)
+	cloneJob, err := controller.PohonKinerjaOpdService.CloneByKodeOpdAndTahun(request.Context(), cloneRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -544,12 +557,12 @@ func (controller *PohonKinerjaOpdControllerImpl) Clone(writer http.ResponseWrite
 	}
 
 	webResponse := web.WebResponse{
-		Code:   http.StatusOK,
-		Status: "OK",
-		Data:   "Berhasil cloning pohon kinerja dari tahun " + cloneRequest.TahunSumber + " ke tahun " + cloneRequest.TahunTujuan + " untuk OPD " + cloneRequest.KodeOpd,
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   cloneJob,
 	}
 
-	helper.WriteToResponseBody(writer, webResponse)
+	helper.WriteToResponseBodyWstatus(writer, webResponse)
 }
 
 func (controller *PohonKinerjaOpdControllerImpl) CheckPokinExistsByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
@@ -679,12 +692,7 @@ func (controller *PohonKinerjaOpdControllerImpl) LeaderboardPokinOpd(writer http
 
 	response, err := controller.PohonKinerjaOpdService.LeaderboardPokinOpd(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -773,6 +781,9 @@ func (controller *PohonKinerjaOpdControllerImpl) UpdateParentClone(writer http.R
 
 	updateCloneResponse, err := controller.PohonKinerjaOpdService.UpdateParentClone(request.Context(), pohonKinerjaUpdateParentCloneRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "Error",
@@ -806,6 +817,9 @@ func (controller *PohonKinerjaOpdControllerImpl) UpsertLeaderboardHidden(writer
 
 	err := controller.PohonKinerjaOpdService.UpsertLeaderboardHidden(request.Context(), leaderboardHiddenUpsertRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "Error",
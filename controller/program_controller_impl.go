@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
@@ -26,12 +27,7 @@ func (controller *ProgramControllerImpl) Create(writer http.ResponseWriter, requ
 
 	programResponse, err := controller.ProgramService.Create(request.Context(), programCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -50,12 +46,7 @@ func (controller *ProgramControllerImpl) Update(writer http.ResponseWriter, requ
 	programUpdateRequest.Id = params.ByName("programId")
 	programResponse, err := controller.ProgramService.Update(request.Context(), programUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -71,12 +62,7 @@ func (controller *ProgramControllerImpl) Delete(writer http.ResponseWriter, requ
 	programId := params.ByName("id")
 	err := controller.ProgramService.Delete(request.Context(), programId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -110,21 +96,23 @@ func (controller *ProgramControllerImpl) FindById(writer http.ResponseWriter, re
 }
 
 func (controller *ProgramControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	programResponses, err := controller.ProgramService.FindAll(request.Context())
+	listQuery, err := helper.ParseListQuery(request)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	programResponses, total, err := controller.ProgramService.FindAll(request.Context(), listQuery)
+	if err != nil {
+		exception.WriteError(writer, request, err)
 		return
 	}
 
 	webResponse := web.WebResponse{
-		Code:   200,
-		Status: "Success",
-		Data:   programResponses,
+		Code:       200,
+		Status:     "Success",
+		Data:       programResponses,
+		Pagination: helper.NewPagination(listQuery, total),
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programprioritaspusat"
@@ -29,15 +30,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) Create(writer http.Respon
 
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.Create(request.Context(), programPrioritasPusatCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			// TODO: CODE: AMBIL DARI http
-			Code: http.StatusInternalServerError,
-			// TODO: STATUS: TERJEMAHKAN DARI code
-			Status: http.StatusText(http.StatusInternalServerError),
-			// TODO: buat nil saja
-			Data: err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -69,12 +62,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) Update(writer http.Respon
 
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.Update(request.Context(), programPrioritasPusatUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -101,12 +89,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) Delete(writer http.Respon
 
 	err = controller.ProgramPrioritasPusatService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -132,12 +115,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) FindById(writer http.Resp
 
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -191,12 +169,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) FindAll(
 		)
 
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -211,12 +184,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) FindAll(
 func (controller *ProgramPrioritasPusatControllerImpl) FindByKodeProgramPrioritasPusat(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.FindByKodeProgramPrioritasPusat(request.Context(), params.ByName("kode_program_prioritas_pusat"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -229,12 +197,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) FindByKodeProgramPriorita
 func (controller *ProgramPrioritasPusatControllerImpl) FindByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.FindByTahun(request.Context(), params.ByName("tahun"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -248,12 +211,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) FindByTahun(writer http.R
 func (controller *ProgramPrioritasPusatControllerImpl) FindUnusedByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.FindUnusedByTahun(request.Context(), params.ByName("tahun"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -270,12 +228,7 @@ func (controller *ProgramPrioritasPusatControllerImpl) FindByIdTerkait(writer ht
 
 	programPrioritasPusatResponse, err := controller.ProgramPrioritasPusatService.FindByIdTerkait(request.Context(), findByIdTerkaitRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
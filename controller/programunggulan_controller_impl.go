@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programunggulan"
@@ -26,12 +27,7 @@ func (controller *ProgramUnggulanControllerImpl) Create(writer http.ResponseWrit
 
 	programUnggulanResponse, err := controller.ProgramUnggulanService.Create(request.Context(), programUnggulanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -62,12 +58,7 @@ func (controller *ProgramUnggulanControllerImpl) Update(writer http.ResponseWrit
 
 	programUnggulanResponse, err := controller.ProgramUnggulanService.Update(request.Context(), programUnggulanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -94,12 +85,7 @@ func (controller *ProgramUnggulanControllerImpl) Delete(writer http.ResponseWrit
 
 	err = controller.ProgramUnggulanService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -125,12 +111,7 @@ func (controller *ProgramUnggulanControllerImpl) FindById(writer http.ResponseWr
 
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -145,12 +126,7 @@ func (controller *ProgramUnggulanControllerImpl) FindById(writer http.ResponseWr
 func (controller *ProgramUnggulanControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindAll(request.Context(), params.ByName("tahun_awal"), params.ByName("tahun_akhir"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -164,12 +140,7 @@ func (controller *ProgramUnggulanControllerImpl) FindAll(writer http.ResponseWri
 func (controller *ProgramUnggulanControllerImpl) FindByKodeProgramUnggulan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindByKodeProgramUnggulan(request.Context(), params.ByName("kode_program_unggulan"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -182,12 +153,7 @@ func (controller *ProgramUnggulanControllerImpl) FindByKodeProgramUnggulan(write
 func (controller *ProgramUnggulanControllerImpl) FindByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindByTahun(request.Context(), params.ByName("tahun"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -201,12 +167,7 @@ func (controller *ProgramUnggulanControllerImpl) FindByTahun(writer http.Respons
 func (controller *ProgramUnggulanControllerImpl) FindUnusedByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindUnusedByTahun(request.Context(), params.ByName("tahun"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -223,12 +184,7 @@ func (controller *ProgramUnggulanControllerImpl) FindByIdTerkait(writer http.Res
 
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindByIdTerkait(request.Context(), findByIdTerkaitRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	webResponse := web.WebResponse{
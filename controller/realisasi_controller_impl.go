@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RealisasiControllerImpl struct {
+	RealisasiService service.RealisasiService
+}
+
+func NewRealisasiControllerImpl(realisasiService service.RealisasiService) *RealisasiControllerImpl {
+	return &RealisasiControllerImpl{RealisasiService: realisasiService}
+}
+
+// Create godoc
+// @Summary      Isi realisasi indikator
+// @Description  Mengisi realisasi kumulatif indikator per bulan atau triwulan. Periode yang sudah diisi akan diperbarui. Indikator pemda hanya dapat diisi super_admin.
+// @Tags         Realisasi Kinerja
+// @Accept       json
+// @Produce      json
+// @Param        request  body      realisasi.RealisasiIndikatorCreateRequest  true  "Realisasi indikator"
+// @Success      200      {object}  web.WebResponse{data=realisasi.RealisasiIndikatorResponse}
+// @Failure      400      {object}  web.WebResponse
+// @Failure      409      {object}  web.WebResponse
+// @Failure      423      {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_indikator/create [post]
+func (controller *RealisasiControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	createRequest := realisasi.RealisasiIndikatorCreateRequest{}
+	helper.ReadFromRequestBody(request, &createRequest)
+
+	response, err := controller.RealisasiService.Create(request.Context(), createRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Update godoc
+// @Summary      Ubah realisasi indikator
+// @Tags         Realisasi Kinerja
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                        true  "Id realisasi"
+// @Param        request  body      realisasi.RealisasiIndikatorUpdateRequest  true  "Realisasi indikator"
+// @Success      200      {object}  web.WebResponse{data=realisasi.RealisasiIndikatorResponse}
+// @Failure      400      {object}  web.WebResponse
+// @Failure      404      {object}  web.WebResponse
+// @Failure      423      {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_indikator/update/{id} [put]
+func (controller *RealisasiControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id realisasi harus berupa angka"))
+		return
+	}
+	updateRequest := realisasi.RealisasiIndikatorUpdateRequest{}
+	helper.ReadFromRequestBody(request, &updateRequest)
+	updateRequest.Id = id
+
+	response, err := controller.RealisasiService.Update(request.Context(), updateRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Delete godoc
+// @Summary      Hapus realisasi indikator
+// @Tags         Realisasi Kinerja
+// @Produce      json
+// @Param        id   path      int  true  "Id realisasi"
+// @Success      200  {object}  web.WebResponse
+// @Failure      404  {object}  web.WebResponse
+// @Failure      423  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_indikator/delete/{id} [delete]
+func (controller *RealisasiControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id realisasi harus berupa angka"))
+		return
+	}
+
+	if err := controller.RealisasiService.Delete(request.Context(), id); err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "realisasi berhasil dihapus",
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindByIndikator godoc
+// @Summary      Realisasi dan capaian satu indikator
+// @Description  Menampilkan target, realisasi per periode dan capaian tahunan (dari periode terakhir yang diisi) satu indikator.
+// @Tags         Realisasi Kinerja
+// @Produce      json
+// @Param        indikator_id  path      string  true  "Id indikator"
+// @Param        tahun         path      string  true  "Tahun"
+// @Success      200           {object}  web.WebResponse{data=realisasi.CapaianIndikatorResponse}
+// @Failure      404           {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_indikator/findall/{indikator_id}/{tahun} [get]
+func (controller *RealisasiControllerImpl) FindByIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.RealisasiService.FindByIndikator(request.Context(), params.ByName("indikator_id"), params.ByName("tahun"))
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// UpdatePolaritas godoc
+// @Summary      Ubah polaritas indikator
+// @Description  positif: realisasi makin tinggi makin baik, negatif: realisasi makin rendah makin baik.
+// @Tags         Realisasi Kinerja
+// @Accept       json
+// @Produce      json
+// @Param        indikator_id  path      string                               true  "Id indikator"
+// @Param        request       body      realisasi.PolaritasIndikatorRequest  true  "Polaritas"
+// @Success      200           {object}  web.WebResponse{data=realisasi.PolaritasIndikatorResponse}
+// @Failure      400           {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_indikator/polaritas/{indikator_id} [put]
+func (controller *RealisasiControllerImpl) UpdatePolaritas(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	polaritasRequest := realisasi.PolaritasIndikatorRequest{}
+	helper.ReadFromRequestBody(request, &polaritasRequest)
+	polaritasRequest.IndikatorId = params.ByName("indikator_id")
+
+	response, err := controller.RealisasiService.UpdatePolaritas(request.Context(), polaritasRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// CapaianOpd godoc
+// @Summary      Capaian kinerja OPD
+// @Description  Capaian indikator rencana kinerja yang dirangkum ke pohon kinerja dan sasaran OPD pada tahun tertentu.
+// @Tags         Realisasi Kinerja
+// @Produce      json
+// @Param        kode_opd  path      string  true  "Kode OPD"
+// @Param        tahun     path      string  true  "Tahun"
+// @Success      200       {object}  web.WebResponse{data=realisasi.CapaianOpdResponse}
+// @Failure      403       {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /capaian_kinerja/opd/{kode_opd}/{tahun} [get]
+func (controller *RealisasiControllerImpl) CapaianOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.RealisasiService.CapaianOpd(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"))
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// CapaianPemda godoc
+// @Summary      Capaian kinerja pemda
+// @Description  Capaian sasaran pemda pada tahun tertentu, dari indikator sasaran pemda atau rangkuman pohon kinerja seluruh OPD.
+// @Tags         Realisasi Kinerja
+// @Produce      json
+// @Param        tahun  path      string  true  "Tahun"
+// @Success      200    {object}  web.WebResponse{data=realisasi.CapaianPemdaResponse}
+// @Security     BearerAuth
+// @Router       /capaian_kinerja/pemda/{tahun} [get]
+func (controller *RealisasiControllerImpl) CapaianPemda(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	response, err := controller.RealisasiService.CapaianPemda(request.Context(), params.ByName("tahun"))
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
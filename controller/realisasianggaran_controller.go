@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RealisasiAnggaranController interface {
+	Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByRencanaAksi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Laporan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Deviasi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasianggaran"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RealisasiAnggaranControllerImpl struct {
+	RealisasiAnggaranService service.RealisasiAnggaranService
+}
+
+func NewRealisasiAnggaranControllerImpl(realisasiAnggaranService service.RealisasiAnggaranService) *RealisasiAnggaranControllerImpl {
+	return &RealisasiAnggaranControllerImpl{RealisasiAnggaranService: realisasiAnggaranService}
+}
+
+// Create godoc
+// @Summary      Isi realisasi anggaran
+// @Description  Mengisi realisasi belanja rencana aksi pada satu bulan (bukan kumulatif). Bulan yang sudah diisi akan diperbarui.
+// @Tags         Realisasi Anggaran
+// @Accept       json
+// @Produce      json
+// @Param        request  body      realisasianggaran.RealisasiAnggaranCreateRequest  true  "Realisasi anggaran"
+// @Success      200      {object}  web.WebResponse{data=realisasianggaran.RealisasiAnggaranResponse}
+// @Failure      400      {object}  web.WebResponse
+// @Failure      404      {object}  web.WebResponse
+// @Failure      423      {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_anggaran/create [post]
+func (controller *RealisasiAnggaranControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	createRequest := realisasianggaran.RealisasiAnggaranCreateRequest{}
+	helper.ReadFromRequestBody(request, &createRequest)
+
+	response, err := controller.RealisasiAnggaranService.Create(request.Context(), createRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Update godoc
+// @Summary      Ubah realisasi anggaran
+// @Tags         Realisasi Anggaran
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                               true  "Id realisasi anggaran"
+// @Param        request  body      realisasianggaran.RealisasiAnggaranUpdateRequest  true  "Realisasi anggaran"
+// @Success      200      {object}  web.WebResponse{data=realisasianggaran.RealisasiAnggaranResponse}
+// @Failure      400      {object}  web.WebResponse
+// @Failure      404      {object}  web.WebResponse
+// @Failure      423      {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_anggaran/update/{id} [put]
+func (controller *RealisasiAnggaranControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id realisasi anggaran harus berupa angka"))
+		return
+	}
+	updateRequest := realisasianggaran.RealisasiAnggaranUpdateRequest{}
+	helper.ReadFromRequestBody(request, &updateRequest)
+	updateRequest.Id = id
+
+	response, err := controller.RealisasiAnggaranService.Update(request.Context(), updateRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Delete godoc
+// @Summary      Hapus realisasi anggaran
+// @Tags         Realisasi Anggaran
+// @Produce      json
+// @Param        id   path      int  true  "Id realisasi anggaran"
+// @Success      200  {object}  web.WebResponse
+// @Failure      404  {object}  web.WebResponse
+// @Failure      423  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_anggaran/delete/{id} [delete]
+func (controller *RealisasiAnggaranControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		exception.WriteError(writer, request, web.NewBadRequestError("id realisasi anggaran harus berupa angka"))
+		return
+	}
+
+	if err := controller.RealisasiAnggaranService.Delete(request.Context(), id); err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "realisasi anggaran berhasil dihapus",
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// FindByRencanaAksi godoc
+// @Summary      Realisasi anggaran satu rencana aksi
+// @Description  Realisasi per bulan, serapan kumulatif dan rencana serapan dari bobot pelaksanaan rencana aksi.
+// @Tags         Realisasi Anggaran
+// @Produce      json
+// @Param        renaksi_id  path      string  true   "Id rencana aksi"
+// @Param        bulan       query     int     false  "Bulan laporan 1-12 (default bulan berjalan)"
+// @Success      200         {object}  web.WebResponse{data=realisasianggaran.RencanaAksiSerapanResponse}
+// @Failure      400         {object}  web.WebResponse
+// @Failure      404         {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_anggaran/rencana_aksi/{renaksi_id} [get]
+func (controller *RealisasiAnggaranControllerImpl) FindByRencanaAksi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	bulan, err := queryBulanSerapan(request)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	response, err := controller.RealisasiAnggaranService.FindByRencanaAksi(request.Context(), params.ByName("renaksi_id"), bulan)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Laporan godoc
+// @Summary      Laporan serapan anggaran OPD
+// @Description  Serapan anggaran rencana aksi yang dirangkum ke subkegiatan, kegiatan dan program sampai bulan laporan.
+// @Tags         Realisasi Anggaran
+// @Produce      json
+// @Param        kode_opd  path      string  true   "Kode OPD"
+// @Param        tahun     path      string  true   "Tahun"
+// @Param        bulan     query     int     false  "Bulan laporan 1-12 (default bulan berjalan)"
+// @Success      200       {object}  web.WebResponse{data=realisasianggaran.LaporanSerapanResponse}
+// @Failure      400       {object}  web.WebResponse
+// @Failure      403       {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_anggaran/laporan/{kode_opd}/{tahun} [get]
+func (controller *RealisasiAnggaranControllerImpl) Laporan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	bulan, err := queryBulanSerapan(request)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	response, err := controller.RealisasiAnggaranService.Laporan(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"), bulan)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Deviasi godoc
+// @Summary      Deviasi serapan anggaran OPD
+// @Description  Rencana aksi yang serapannya tertinggal dari jadwal bobot pelaksanaan lebih dari toleransi, belum ada realisasi pada bulan yang dijadwalkan, atau melebihi anggaran.
+// @Tags         Realisasi Anggaran
+// @Produce      json
+// @Param        kode_opd   path      string  true   "Kode OPD"
+// @Param        tahun      path      string  true   "Tahun"
+// @Param        bulan      query     int     false  "Bulan laporan 1-12 (default bulan berjalan)"
+// @Param        toleransi  query     number  false  "Toleransi deviasi dalam persen poin (default 10)"
+// @Success      200        {object}  web.WebResponse{data=realisasianggaran.DeviasiSerapanResponse}
+// @Failure      400        {object}  web.WebResponse
+// @Failure      403        {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /realisasi_anggaran/deviasi/{kode_opd}/{tahun} [get]
+func (controller *RealisasiAnggaranControllerImpl) Deviasi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	bulan, err := queryBulanSerapan(request)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+	toleransi := domain.ToleransiDeviasiSerapan
+	if value := request.URL.Query().Get("toleransi"); value != "" {
+		toleransi, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			exception.WriteError(writer, request, web.NewBadRequestError("toleransi harus berupa angka"))
+			return
+		}
+	}
+
+	response, err := controller.RealisasiAnggaranService.Deviasi(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"), bulan, toleransi)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   response,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// queryBulanSerapan query bulan kosong dikirim sebagai 0 (bulan berjalan)
+func queryBulanSerapan(request *http.Request) (int, error) {
+	value := request.URL.Query().Get("bulan")
+	if value == "" {
+		return 0, nil
+	}
+	bulan, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, web.NewBadRequestError("bulan harus berupa angka")
+	}
+	return bulan, nil
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rencanaaksi"
@@ -32,6 +33,9 @@ func (controller *RencanaAksiControllerImpl) Create(writer http.ResponseWriter,
 
 	rencanaAksiResponse, err := controller.RencanaAksiService.Create(request.Context(), rencanaAksiCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebRencanaAksiResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -59,6 +63,9 @@ func (controller *RencanaAksiControllerImpl) Update(writer http.ResponseWriter,
 
 	rencanaAksiResponse, err := controller.RencanaAksiService.Update(request.Context(), rencanaAksiUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebRencanaAksiResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -82,12 +89,7 @@ func (controller *RencanaAksiControllerImpl) FindAll(writer http.ResponseWriter,
 
 	rencanaAksiResponses, err := controller.RencanaAksiService.FindAll(request.Context(), rencanaKinerjaId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -128,12 +130,7 @@ func (controller *RencanaAksiControllerImpl) Delete(writer http.ResponseWriter,
 
 	err := controller.RencanaAksiService.Delete(request.Context(), rencanaAksiId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -151,12 +148,7 @@ func (controller *RencanaAksiControllerImpl) FindAllByRekin(writer http.Response
 
 	rencanaAksiResponses, err := controller.RencanaAksiService.FindAll(request.Context(), rencanaKinerjaId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
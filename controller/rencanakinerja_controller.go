@@ -14,6 +14,7 @@ type RencanaKinerjaController interface {
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindAllRencanaKinerja(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindAllRincianKak(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	CetakKak(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	// FindRekinSasaranOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	CreateRekinLevel1(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpdateRekinLevel1(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
@@ -1,10 +1,12 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rencanakinerja"
 	"ekak_kabupaten_madiun/service"
+	"fmt"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -26,6 +28,15 @@ func (controller *RencanaKinerjaControllerImpl) Create(writer http.ResponseWrite
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.Create(request.Context(), rencanaKinerjaCreateRequest)
 	if err != nil {
+		if customErr, ok := err.(*web.CustomError); ok {
+			webResponse := web.WebRencanaKinerjaResponse{
+				Code:   customErr.Code,
+				Status: http.StatusText(customErr.Code),
+				Data:   customErr.ResponseData(),
+			}
+			helper.WriteToResponseBody(writer, webResponse)
+			return
+		}
 		webResponse := web.WebRencanaKinerjaResponse{
 			Code:   400,
 			Status: "failed create rencana kinerja",
@@ -51,6 +62,15 @@ func (controller *RencanaKinerjaControllerImpl) Update(writer http.ResponseWrite
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.Update(request.Context(), rencanaKinerjaUpdateRequest)
 	if err != nil {
+		if customErr, ok := err.(*web.CustomError); ok {
+			webResponse := web.WebRencanaKinerjaResponse{
+				Code:   customErr.Code,
+				Status: http.StatusText(customErr.Code),
+				Data:   customErr.ResponseData(),
+			}
+			helper.WriteToResponseBody(writer, webResponse)
+			return
+		}
 		webResponse := web.WebRencanaKinerjaResponse{
 			Code:   400,
 			Status: "failed update rencana kinerja",
@@ -114,6 +134,15 @@ func (controller *RencanaKinerjaControllerImpl) FindById(writer http.ResponseWri
 
 	result, err := controller.rencanaKinerjaService.FindById(request.Context(), id, kodeOPD, tahun)
 	if err != nil {
+		if customErr, ok := err.(*web.CustomError); ok {
+			webResponse := web.WebRencanaKinerjaResponse{
+				Code:   customErr.Code,
+				Status: http.StatusText(customErr.Code),
+				Data:   customErr.ResponseData(),
+			}
+			helper.WriteToResponseBody(writer, webResponse)
+			return
+		}
 		// Handle error
 		webResponse := web.WebRencanaKinerjaResponse{
 			Code:   404,
@@ -136,7 +165,16 @@ func (controller *RencanaKinerjaControllerImpl) FindById(writer http.ResponseWri
 func (controller *RencanaKinerjaControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	rencanaKinerjaId := params.ByName("id")
 
-	controller.rencanaKinerjaService.Delete(request.Context(), rencanaKinerjaId)
+	err := controller.rencanaKinerjaService.Delete(request.Context(), rencanaKinerjaId)
+	if customErr, ok := err.(*web.CustomError); ok {
+		webResponse := web.WebRencanaKinerjaResponse{
+			Code:   customErr.Code,
+			Status: http.StatusText(customErr.Code),
+			Data:   customErr.ResponseData(),
+		}
+		helper.WriteToResponseBody(writer, webResponse)
+		return
+	}
 	webResponse := web.WebRencanaKinerjaResponse{
 		Code:   200,
 		Status: "success delete rencana kinerja",
@@ -217,6 +255,25 @@ func (controller *RencanaKinerjaControllerImpl) FindAllRincianKak(writer http.Re
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+// CetakKak mengunduh dokumen KAK satu rencana kinerja, format docx atau pdf
+func (controller *RencanaKinerjaControllerImpl) CetakKak(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	rencanaKinerjaId := params.ByName("rencana_kinerja_id")
+	pegawaiId := params.ByName("pegawai_id")
+	format := params.ByName("format")
+
+	content, err := controller.rencanaKinerjaService.GenerateKak(request.Context(), pegawaiId, rencanaKinerjaId, format)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	contentType := helper.ContentTypePdf
+	if format == "docx" {
+		contentType = helper.ContentTypeDocx
+	}
+	helper.WriteFileResponse(writer, contentType, fmt.Sprintf("KAK_%s.%s", rencanaKinerjaId, format), content)
+}
+
 // func (controller *RencanaKinerjaControllerImpl) FindRekinSasaranOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 // 	pegawaiId := params.ByName("pegawai_id")
 // 	tahun := params.ByName("tahun")
@@ -248,6 +305,15 @@ func (controller *RencanaKinerjaControllerImpl) CreateRekinLevel1(writer http.Re
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.CreateRekinLevel1(request.Context(), rencanaKinerjaCreateRequest)
 	if err != nil {
+		if customErr, ok := err.(*web.CustomError); ok {
+			webResponse := web.WebRencanaKinerjaResponse{
+				Code:   customErr.Code,
+				Status: http.StatusText(customErr.Code),
+				Data:   customErr.ResponseData(),
+			}
+			helper.WriteToResponseBody(writer, webResponse)
+			return
+		}
 		webResponse := web.WebRencanaKinerjaResponse{
 			Code:   400,
 			Status: "failed create rekin level 1",
@@ -273,6 +339,15 @@ func (controller *RencanaKinerjaControllerImpl) UpdateRekinLevel1(writer http.Re
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.UpdateRekinLevel1(request.Context(), rencanaKinerjaUpdateRequest)
 	if err != nil {
+		if customErr, ok := err.(*web.CustomError); ok {
+			webResponse := web.WebRencanaKinerjaResponse{
+				Code:   customErr.Code,
+				Status: http.StatusText(customErr.Code),
+				Data:   customErr.ResponseData(),
+			}
+			helper.WriteToResponseBody(writer, webResponse)
+			return
+		}
 		webResponse := web.WebRencanaKinerjaResponse{
 			Code:   400,
 			Status: "failed update rekin level 1",
@@ -389,25 +464,31 @@ func (controller *RencanaKinerjaControllerImpl) CloneRencanaKinerja(writer http.
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+// CloneRencanaKinerjaByKodeOpd godoc
+// @Summary      Clone rencana kinerja OPD
+// @Description  Memasukkan clone seluruh rencana kinerja OPD dari tahun sumber ke tahun tujuan ke antrian job. Status, progress dan warning clone dipantau lewat /jobs/{id}.
+// @Tags         Rencana Kinerja
+// @Accept       json
+// @Produce      json
+// @Param        clone_request  body      rencanakinerja.RekinByOpdCloneRequest  true  "Data clone rencana kinerja"
+// @Success      202            {object}  web.WebResponse{data=job.JobResponse}
+// @Failure      409            {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /rencana_kinerja/clone_by_kode_opd [post]
 func (controller *RencanaKinerjaControllerImpl) CloneRencanaKinerjaByKodeOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	cloneRequest := rencanakinerja.RekinByOpdCloneRequest{}
 	helper.ReadFromRequestBody(request, &cloneRequest)
 
-	err := controller.rencanaKinerjaService.CloneRekinByKodeOpdAndTahun(request.Context(), cloneRequest)
+	cloneJob, err := controller.rencanaKinerjaService.CloneRekinByKodeOpdAndTahun(request.Context(), cloneRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "gagal clone rencana kinerja",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBodyWstatus(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
 	webResponse := web.WebResponse{
-		Code:   http.StatusOK,
-		Status: "success clone rencana kinerja",
-		Data:   "Berhasil clone rencana kinerja dari tahun " + cloneRequest.TahunSumber + " ke tahun " + cloneRequest.TahunTujuan + " untuk OPD " + cloneRequest.KodeOpd,
+		Code:   http.StatusAccepted,
+		Status: "clone rencana kinerja masuk antrian",
+		Data:   cloneJob,
 	}
 	helper.WriteToResponseBodyWstatus(writer, webResponse)
 }
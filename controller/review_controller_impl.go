@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
@@ -37,11 +38,7 @@ func (controller *ReviewControllerImpl) Create(writer http.ResponseWriter, reque
 
 	reviewResponse, err := controller.ReviewService.Create(ctx, reviewCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -70,11 +67,7 @@ func (controller *ReviewControllerImpl) Update(writer http.ResponseWriter, reque
 
 	reviewResponse, err := controller.ReviewService.Update(request.Context(), reviewUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -118,11 +111,7 @@ func (controller *ReviewControllerImpl) FindAll(writer http.ResponseWriter, requ
 
 	reviewResponse, err := controller.ReviewService.FindAll(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -142,15 +131,13 @@ func (controller *ReviewControllerImpl) FindById(writer http.ResponseWriter, req
 			Status: "BAD REQUEST",
 			Data:   err.Error(),
 		})
+		return
 	}
 
 	reviewResponse, err := controller.ReviewService.FindById(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
+		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -165,11 +152,8 @@ func (controller *ReviewControllerImpl) FindAllReviewByTematik(writer http.Respo
 
 	reviewResponse, err := controller.ReviewService.FindAllReviewByTematik(request.Context(), tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
+		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -185,11 +169,8 @@ func (controller *ReviewControllerImpl) FindAllReviewOpd(writer http.ResponseWri
 
 	reviewResponse, err := controller.ReviewService.FindAllReviewOpd(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
+		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rincianbelanja"
@@ -26,11 +27,7 @@ func (controller *RincianBelanjaControllerImpl) Create(writer http.ResponseWrite
 
 	rincianBelanjaResponse, err := controller.rincianBelanjaService.Create(request.Context(), rincianBelanjaCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -48,12 +45,7 @@ func (controller *RincianBelanjaControllerImpl) Update(writer http.ResponseWrite
 	rincianBelanjaUpdateRequest.RenaksiId = params.ByName("renaksiId")
 	rincianBelanjaResponse, err := controller.rincianBelanjaService.Update(request.Context(), rincianBelanjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -141,6 +133,9 @@ func (controller *RincianBelanjaControllerImpl) Upsert(writer http.ResponseWrite
 
 	rincianBelanjaResponse, err := controller.rincianBelanjaService.Upsert(request.Context(), rincianBelanjaUpsertRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "failed upsert rincian belanja",
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/user"
@@ -27,6 +28,9 @@ func (controller *RoleControllerImpl) Create(writer http.ResponseWriter, request
 
 	roleResponse, err := controller.RoleService.Create(request.Context(), roleCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create role",
@@ -63,6 +67,9 @@ func (controller *RoleControllerImpl) Update(writer http.ResponseWriter, request
 
 	roleResponse, err := controller.RoleService.Update(request.Context(), roleUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed update role",
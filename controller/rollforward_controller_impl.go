@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/exception"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/rollforward"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RollForwardControllerImpl struct {
+	RollForwardService service.RollForwardService
+}
+
+func NewRollForwardControllerImpl(rollForwardService service.RollForwardService) *RollForwardControllerImpl {
+	return &RollForwardControllerImpl{RollForwardService: rollForwardService}
+}
+
+// RollForwardOpd godoc
+// @Summary      Roll forward perencanaan OPD
+// @Description  Menyalin pohon kinerja, indikator, target, pelaksana, tagging, CSF, rencana kinerja, rencana aksi dan sub kegiatan terpilih OPD dari tahun sumber ke tahun berikutnya dalam satu job. Laporan data yang disalin, dilewati dan konflik ada di result job (/jobs/{id}).
+// @Tags         Roll Forward
+// @Accept       json
+// @Produce      json
+// @Param        data  body      rollforward.RollForwardOpdRequest  true  "OPD dan tahun sumber"
+// @Success      202   {object}  web.WebResponse{data=job.JobResponse}
+// @Failure      400   {object}  web.WebResponse
+// @Failure      423   {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /roll_forward/opd [post]
+func (controller *RollForwardControllerImpl) RollForwardOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	rollForwardRequest := rollforward.RollForwardOpdRequest{}
+	helper.ReadFromRequestBody(request, &rollForwardRequest)
+
+	response, err := controller.RollForwardService.RollForwardOpd(request.Context(), rollForwardRequest)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   response,
+	}
+	helper.WriteToResponseBodyWstatus(writer, webResponse)
+}
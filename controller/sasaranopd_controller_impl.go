@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/sasaranopd"
@@ -84,6 +85,9 @@ func (controller *SasaranOpdControllerImpl) Create(writer http.ResponseWriter, r
 
 	sasaranOpdCreateResponse, err := controller.SasaranOpdService.Create(request.Context(), sasaranOpdCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create sasaran opd",
@@ -132,6 +136,9 @@ func (controller *SasaranOpdControllerImpl) Update(writer http.ResponseWriter, r
 	// Panggil service Update
 	sasaranOpdResponse, err := controller.SasaranOpdService.Update(request.Context(), sasaranOpdUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "BAD REQUEST",
@@ -166,6 +173,9 @@ func (controller *SasaranOpdControllerImpl) Delete(writer http.ResponseWriter, r
 
 	err := controller.SasaranOpdService.Delete(request.Context(), id)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed delete sasaran opd",
@@ -434,6 +444,9 @@ func (controller *SasaranOpdControllerImpl) CreateIndikatorRanwal(writer http.Re
 
 	indikatorCreateResponses, err := controller.SasaranOpdService.CreateRenjaIndikator(request.Context(), sasaranopdIdInt, "ranwal", indikatorCreateRequests)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create indikator",
@@ -469,12 +482,7 @@ func (controller *SasaranOpdControllerImpl) UpdateIndikatorRanwal(writer http.Re
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
 	indikatorUpdateResponses, err := controller.SasaranOpdService.UpdateRenjaIndikator(request.Context(), kodeIndikator, "ranwal", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -513,6 +521,9 @@ func (controller *SasaranOpdControllerImpl) CreateIndikatorRankhir(writer http.R
 
 	indikatorCreateResponses, err := controller.SasaranOpdService.CreateRenjaIndikator(request.Context(), sasaranopdIdInt, "rankhir", indikatorCreateRequests)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create indikator",
@@ -548,12 +559,7 @@ func (controller *SasaranOpdControllerImpl) UpdateIndikatorRankhir(writer http.R
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
 	indikatorUpdateResponses, err := controller.SasaranOpdService.UpdateRenjaIndikator(request.Context(), kodeIndikator, "rankhir", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -579,12 +585,7 @@ func (controller *SasaranOpdControllerImpl) DeleteIndikatorTargetRenja(writer ht
 	kodeIndikator := params.ByName("kodeIndikator")
 	err := controller.SasaranOpdService.DeleteRenjaIndikator(request.Context(), kodeIndikator)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -658,6 +659,9 @@ func (controller *SasaranOpdControllerImpl) CreateIndikatorPenetapan(writer http
 
 	indikatorCreateResponses, err := controller.SasaranOpdService.CreateRenjaIndikator(request.Context(), sasaranopdIdInt, "penetapan", indikatorCreateRequests)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create indikator",
@@ -693,12 +697,7 @@ func (controller *SasaranOpdControllerImpl) UpdateIndikatorPenetapan(writer http
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
 	indikatorUpdateResponses, err := controller.SasaranOpdService.UpdateRenjaIndikator(request.Context(), kodeIndikator, "penetapan", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
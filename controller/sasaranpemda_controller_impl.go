@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/sasaranpemda"
@@ -29,12 +30,7 @@ func (controller *SasaranPemdaControllerImpl) Create(writer http.ResponseWriter,
 	// Panggil service create
 	sasaranPemdaResponse, err := controller.sasaranPemdaService.Create(request.Context(), sasaranPemdaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -66,12 +62,7 @@ func (controller *SasaranPemdaControllerImpl) Update(writer http.ResponseWriter,
 	// Panggil service update
 	sasaranPemdaResponse, err := controller.sasaranPemdaService.Update(request.Context(), sasaranPemdaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -98,12 +89,7 @@ func (controller *SasaranPemdaControllerImpl) Delete(writer http.ResponseWriter,
 
 	err = controller.sasaranPemdaService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -152,12 +138,7 @@ func (controller *SasaranPemdaControllerImpl) FindAll(writer http.ResponseWriter
 	tahun := params.ByName("tahun")
 	sasaranPemdaResponses, err := controller.sasaranPemdaService.FindAll(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -176,12 +157,7 @@ func (controller *SasaranPemdaControllerImpl) FindAllWithPokin(writer http.Respo
 
 	sasaranPemdaResponses, err := controller.sasaranPemdaService.FindAllWithPokin(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/subkegiatan"
 	"ekak_kabupaten_madiun/service"
@@ -29,11 +31,7 @@ func (controller *SubKegiatanControllerImpl) Create(writer http.ResponseWriter,
 
 	subKegiatanResponse, err := controller.SubKegiatanService.Create(request.Context(), subKegiatanCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -73,11 +71,7 @@ func (controller *SubKegiatanControllerImpl) Update(writer http.ResponseWriter,
 	// Panggil service untuk update gambaran umum
 	subKegiatanResponse, err := controller.SubKegiatanService.Update(request.Context(), subKegiatanUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -118,21 +112,23 @@ func (controller *SubKegiatanControllerImpl) FindById(writer http.ResponseWriter
 }
 
 func (controller *SubKegiatanControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	subKegiatanResponses, err := controller.SubKegiatanService.FindAll(request.Context())
+	listQuery, err := helper.ParseListQuery(request)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
 
+	subKegiatanResponses, total, err := controller.SubKegiatanService.FindAll(request.Context(), listQuery)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-		Code:   http.StatusOK,
-		Status: "success get data sub kegiatan",
-		Data:   subKegiatanResponses,
+		Code:       http.StatusOK,
+		Status:     "success get data sub kegiatan",
+		Data:       subKegiatanResponses,
+		Pagination: helper.NewPagination(listQuery, total),
 	})
 }
 
@@ -141,11 +137,7 @@ func (controller *SubKegiatanControllerImpl) Delete(writer http.ResponseWriter,
 
 	err := controller.SubKegiatanService.Delete(request.Context(), subKegiatanId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -158,14 +150,10 @@ func (controller *SubKegiatanControllerImpl) Delete(writer http.ResponseWriter,
 
 func (controller *SubKegiatanControllerImpl) FindAllByRekin(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	// Panggil service untuk mendapatkan data sub kegiatan
-	subKegiatanResponses, err := controller.SubKegiatanService.FindAll(request.Context())
+	subKegiatanResponses, _, err := controller.SubKegiatanService.FindAll(request.Context(), domain.ListQuery{})
 
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -216,11 +204,7 @@ func (controller *SubKegiatanControllerImpl) FindSubKegiatanKAK(writer http.Resp
 
 	subKegiatanKAKResponse, err := controller.SubKegiatanService.FindSubKegiatanKAK(request.Context(), kodeOpd, kodeSubKegiatan, tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 
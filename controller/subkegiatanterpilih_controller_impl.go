@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/subkegiatan"
@@ -30,12 +31,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) Update(writer http.Response
 	// Panggil service untuk membuat SubKegiatanTerpilih
 	subKegiatanTerpilihResponse, err := controller.SubKegiatanTerpilihService.Update(request.Context(), subKegiatanTerpilihUpdateRequest)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -54,12 +50,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) Delete(writer http.Response
 
 	err := controller.SubKegiatanTerpilihService.Delete(request.Context(), rencanaKinerjaId, kodeSubKegiatan)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -75,12 +66,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) FindByKodeSubKegiatan(write
 
 	subKegiatanTerpilihResponse, err := controller.SubKegiatanTerpilihService.FindByKodeSubKegiatan(request.Context(), kodeSubKegiatan)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -101,6 +87,9 @@ func (controller *SubKegiatanTerpilihControllerImpl) CreateRekin(writer http.Res
 
 	subKegiatanResponse, err := controller.SubKegiatanTerpilihService.CreateRekin(request.Context(), subKegiatanCreateRekinRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebSubKegiatanResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -145,6 +134,9 @@ func (controller *SubKegiatanTerpilihControllerImpl) CreateOpd(writer http.Respo
 
 	subKegiatanOpdResponse, err := controller.SubKegiatanTerpilihService.CreateOpdMultiple(request.Context(), SubKegiatanOpdMultipleCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -173,6 +165,9 @@ func (controller *SubKegiatanTerpilihControllerImpl) UpdateOpd(writer http.Respo
 
 	subKegiatanOpdResponse, err := controller.SubKegiatanTerpilihService.UpdateOpd(request.Context(), SubKegiatanOpdUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanInisiatifResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -251,12 +246,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) DeleteOpd(writer http.Respo
 	id, _ := strconv.Atoi(idstr)
 	err := controller.SubKegiatanTerpilihService.DeleteOpd(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/tujuanopd"
@@ -43,12 +44,7 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanOpdRenstra(writer http.Re
 	// Panggil service Create
 	tujuanOpdResponse, err := controller.TujuanOpdService.Create(request.Context(), tujuanOpdCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -101,12 +97,7 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanOpdRenstra(writer http.Re
 	// Panggil service Update
 	tujuanOpdResponse, err := controller.TujuanOpdService.Update(request.Context(), tujuanOpdUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -134,12 +125,7 @@ func (controller *TujuanOpdControllerImpl) Delete(writer http.ResponseWriter, re
 
 	err = controller.TujuanOpdService.Delete(request.Context(), tujuanOpdIdInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -177,12 +163,7 @@ func (controller *TujuanOpdControllerImpl) FindById(writer http.ResponseWriter,
 
 	tujuanOpdResponse, err := controller.TujuanOpdService.FindById(request.Context(), tujuanOpdIdInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -202,12 +183,7 @@ func (controller *TujuanOpdControllerImpl) FindAll(writer http.ResponseWriter, r
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindAll(request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -227,12 +203,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdOnlyName(writer http.Res
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanOpdOnlyName(request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -251,12 +222,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdByTahun(writer http.Resp
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanOpdByTahun(request.Context(), kodeOpd, tahun, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -294,11 +260,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdRenstra(
 		request.Context(), kodeOpd, tahunAwal, tahunAkhir, "RPJMD",
 	)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -326,11 +288,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdRanwal(writer http.Respo
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanRanwal(request.Context(), kodeOpd, tahun, "RPJMD")
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -360,11 +318,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdRankhir(writer http.Resp
 		request.Context(), kodeOpd, tahun, jenisPeriode,
 	)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -402,12 +356,7 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanRenjaRanwalIndikator(writ
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
 	indikatorResponses, err := controller.TujuanOpdService.CreateTujuanRenjaIndikator(request.Context(), tujuanOpdIdInt, "ranwal", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -436,12 +385,7 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanRenjaRanwalIndikator(writ
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
 	indikatorResponses, err := controller.TujuanOpdService.UpdateTujuanRenjaIndikator(request.Context(), kodeIndikator, "ranwal", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -479,12 +423,7 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanRenjaRankhirIndikator(wri
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
 	indikatorResponses, err := controller.TujuanOpdService.CreateTujuanRenjaIndikator(request.Context(), tujuanOpdIdInt, "rankhir", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -513,12 +452,7 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanRenjaRankhirIndikator(wri
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
 	indikatorResponses, err := controller.TujuanOpdService.UpdateTujuanRenjaIndikator(request.Context(), kodeIndikator, "rankhir", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -546,12 +480,7 @@ func (controller *TujuanOpdControllerImpl) DeleteTujuanRenjaIndikator(writer htt
 	kodeIndikator := params.ByName("kodeIndikator")
 	err := controller.TujuanOpdService.DeleteTujuanRenjaIndikator(request.Context(), kodeIndikator)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -581,11 +510,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdPenetapan(writer http.Re
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanPenetapan(request.Context(), kodeOpd, tahun, "RPJMD")
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -623,12 +548,7 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanRenjaPenetapanIndikator(w
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
 	indikatorResponses, err := controller.TujuanOpdService.CreateTujuanRenjaIndikator(request.Context(), tujuanOpdIdInt, "penetapan", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -657,12 +577,7 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanRenjaPenetapanIndikator(w
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
 	indikatorResponses, err := controller.TujuanOpdService.UpdateTujuanRenjaIndikator(request.Context(), kodeIndikator, "penetapan", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -690,11 +605,7 @@ func (controller *TujuanOpdControllerImpl) TujuanOpdPenetapan(writer http.Respon
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.TujuanOpdPenetapan(request.Context(), kodeOpd, tahun, "RPJMD")
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		exception.WriteError(writer, request, err)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
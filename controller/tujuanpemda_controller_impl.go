@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/tujuanpemda"
@@ -29,12 +30,7 @@ func (controller *TujuanPemdaControllerImpl) Create(writer http.ResponseWriter,
 	// Panggil service create
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.Create(request.Context(), tujuanPemdaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -66,12 +62,7 @@ func (controller *TujuanPemdaControllerImpl) Update(writer http.ResponseWriter,
 	// Panggil service update
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.Update(request.Context(), tujuanPemdaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -98,12 +89,7 @@ func (controller *TujuanPemdaControllerImpl) Delete(writer http.ResponseWriter,
 
 	err = controller.TujuanPemdaService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -152,12 +138,7 @@ func (controller *TujuanPemdaControllerImpl) FindAll(writer http.ResponseWriter,
 	jenisPeriode := params.ByName("jenis_periode")
 	tujuanPemdaResponses, err := controller.TujuanPemdaService.FindAll(request.Context(), tahun, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -189,12 +170,7 @@ func (controller *TujuanPemdaControllerImpl) UpdatePeriode(writer http.ResponseW
 	// Panggil service update
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.UpdatePeriode(request.Context(), tujuanPemdaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -212,12 +188,7 @@ func (controller *TujuanPemdaControllerImpl) FindAllWithPokin(writer http.Respon
 	jenisPeriode := params.ByName("jenis_periode")
 	tujuanPemdaResponses, err := controller.TujuanPemdaService.FindAllWithPokin(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -245,12 +216,7 @@ func (controller *TujuanPemdaControllerImpl) FindPokinWithPeriode(writer http.Re
 
 	pokinWithPeriodeResponse, err := controller.TujuanPemdaService.FindPokinWithPeriode(request.Context(), id, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
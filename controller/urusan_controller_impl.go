@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/urusanrespon"
@@ -26,12 +27,7 @@ func (controller *UrusanControllerImpl) Create(writer http.ResponseWriter, reque
 
 	urusanResponse, err := controller.UrusanService.Create(request.Context(), urusanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -51,12 +47,7 @@ func (controller *UrusanControllerImpl) Update(writer http.ResponseWriter, reque
 
 	urusanResponse, err := controller.UrusanService.Update(request.Context(), urusanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -73,12 +64,7 @@ func (controller *UrusanControllerImpl) FindById(writer http.ResponseWriter, req
 
 	urusanResponse, err := controller.UrusanService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -93,12 +79,7 @@ func (controller *UrusanControllerImpl) FindById(writer http.ResponseWriter, req
 func (controller *UrusanControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	urusanResponses, err := controller.UrusanService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -115,12 +96,7 @@ func (controller *UrusanControllerImpl) Delete(writer http.ResponseWriter, reque
 
 	err := controller.UrusanService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -136,12 +112,7 @@ func (controller *UrusanControllerImpl) FindByKodeOpd(writer http.ResponseWriter
 
 	urusanResponses, err := controller.UrusanService.FindByKodeOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -158,12 +129,7 @@ func (controller *UrusanControllerImpl) FindUrusanAndBidangByKodeOpd(writer http
 
 	urusanResponses, err := controller.UrusanService.FindUrusanAndBidangByKodeOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -13,6 +13,8 @@ type UserController interface {
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	Login(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Refresh(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Logout(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByKodeOpdAndRole(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByNip(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	CekAdminOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/user"
@@ -27,6 +28,9 @@ func (controller *UserControllerImpl) Create(writer http.ResponseWriter, request
 
 	userResponse, err := controller.userService.Create(request.Context(), userCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed create user",
@@ -65,6 +69,9 @@ func (controller *UserControllerImpl) Update(writer http.ResponseWriter, request
 
 	userResponse, err := controller.userService.Update(request.Context(), userUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed update user",
@@ -107,9 +114,17 @@ func (controller *UserControllerImpl) Delete(writer http.ResponseWriter, request
 }
 
 func (controller *UserControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	kodeOpd := request.URL.Query().Get("kode_opd")
-	userResponses, err := controller.userService.FindAll(request.Context(), kodeOpd)
+	listQuery, err := helper.ParseListQuery(request)
+	if err != nil {
+		exception.WriteError(writer, request, err)
+		return
+	}
+
+	userResponses, total, err := controller.userService.FindAll(request.Context(), listQuery)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebResponse{
 			Code:   400,
 			Status: "failed find all user",
@@ -120,9 +135,10 @@ func (controller *UserControllerImpl) FindAll(writer http.ResponseWriter, reques
 	}
 
 	webResponse := web.WebResponse{
-		Code:   200,
-		Status: "success find all user",
-		Data:   userResponses,
+		Code:       200,
+		Status:     "success find all user",
+		Data:       userResponses,
+		Pagination: helper.NewPagination(listQuery, total),
 	}
 
 	helper.WriteToResponseBody(writer, webResponse)
@@ -190,13 +206,92 @@ func (controller *UserControllerImpl) Login(writer http.ResponseWriter, request
 		Code:   http.StatusOK,
 		Status: "OK",
 		Data: map[string]interface{}{
-			"token": loginResponse.Token,
+			"token":         loginResponse.Token,
+			"refresh_token": loginResponse.RefreshToken,
+			"expires_in":    loginResponse.ExpiresIn,
+		},
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Refresh
+// @Summary      Refresh Token
+// @Description  Menukar refresh token dengan access token dan refresh token baru (refresh token lama tidak berlaku lagi)
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      user.UserRefreshRequest  true  "Refresh Token"
+// @Success      200      {object}  web.WebResponse          "Berhasil Refresh"
+// @Failure      401      {object}  web.WebResponse          "Refresh token tidak valid atau kedaluwarsa"
+// @Router       /user/refresh [post]
+func (controller *UserControllerImpl) Refresh(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	refreshRequest := user.UserRefreshRequest{}
+	helper.ReadFromRequestBody(request, &refreshRequest)
+
+	loginResponse, err := controller.userService.Refresh(request.Context(), refreshRequest)
+	if err != nil {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+		webResponse := web.WebResponse{
+			Code:   http.StatusUnauthorized,
+			Status: "UNAUTHORIZED",
+			Data:   err.Error(),
+		}
+		helper.WriteToResponseBody(writer, webResponse)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"token":         loginResponse.Token,
+			"refresh_token": loginResponse.RefreshToken,
+			"expires_in":    loginResponse.ExpiresIn,
 		},
 	}
 
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+// Logout
+// @Summary      Logout User
+// @Description  Mencabut sesi login saat ini (access token dan refresh token)
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      user.UserLogoutRequest  false  "Refresh Token"
+// @Success      200      {object}  web.WebResponse         "Berhasil Logout"
+// @Failure      400      {object}  web.WebResponse         "Sesi tidak ditemukan"
+// @Security     BearerAuth
+// @Router       /user/logout [post]
+func (controller *UserControllerImpl) Logout(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	logoutRequest := user.UserLogoutRequest{}
+	if request.ContentLength > 0 {
+		helper.ReadFromRequestBody(request, &logoutRequest)
+	}
+
+	err := controller.userService.Logout(request.Context(), logoutRequest)
+	if err != nil {
+		webResponse := web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err.Error(),
+		}
+		helper.WriteToResponseBody(writer, webResponse)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "berhasil logout",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
 func (controller *UserControllerImpl) FindByKodeOpdAndRole(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	kodeOpd := request.URL.Query().Get("kode_opd")
 	roleName := request.URL.Query().Get("role")
@@ -247,12 +342,7 @@ func (controller *UserControllerImpl) FindByNip(writer http.ResponseWriter, requ
 func (controller *UserControllerImpl) CekAdminOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	response, err := controller.userService.CekAdminOpd(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
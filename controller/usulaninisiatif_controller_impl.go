@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
@@ -45,6 +46,9 @@ func (controller *UsulanInisiatifControllerImpl) Create(writer http.ResponseWrit
 
 	usulanInovasiResponse, err := controller.UsulanInisiatifService.Create(request.Context(), usulanInovasiCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanInisiatifResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -93,6 +97,9 @@ func (controller *UsulanInisiatifControllerImpl) Update(writer http.ResponseWrit
 
 	usulanInovasiResponse, err := controller.UsulanInisiatifService.Update(request.Context(), usulanInovasiUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanInisiatifResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
@@ -45,6 +46,9 @@ func (controller *UsulanMandatoriControllerImpl) Create(writer http.ResponseWrit
 
 	usulanMandatoriResponse, err := controller.UsulanMandatoriService.Create(request.Context(), usulanMandatoriCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanMandatoriResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -93,6 +97,9 @@ func (controller *UsulanMandatoriControllerImpl) Update(writer http.ResponseWrit
 
 	usulanMandatoriResponse, err := controller.UsulanMandatoriService.Update(request.Context(), usulanMandatoriUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanMandatoriResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
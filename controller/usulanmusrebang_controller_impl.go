@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
@@ -8,7 +9,6 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"strconv"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -29,6 +29,9 @@ func (controller *UsulanMusrebangControllerImpl) Create(writer http.ResponseWrit
 
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.Create(request.Context(), usulanMusrebangCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanMusrebangResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -66,6 +69,9 @@ func (controller *UsulanMusrebangControllerImpl) Update(writer http.ResponseWrit
 	// Lakukan update
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.Update(request.Context(), usulanMusrebangUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanMusrebangResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -84,42 +90,24 @@ func (controller *UsulanMusrebangControllerImpl) Update(writer http.ResponseWrit
 }
 
 func (controller *UsulanMusrebangControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	kodeOpd := params.ByName("kode_opd")
-	rekinID := params.ByName("rencana_kinerja_id")
-	isActive := request.URL.Query().Get("is_active")
-	status := request.URL.Query().Get("status")
-
-	var kodeOpdPtr *string
-	if kodeOpd != "" {
-		kodeOpdPtr = &kodeOpd
-	}
-
-	var rekinIDPtr *string
-	if rekinID != "" {
-		rekinIDPtr = &rekinID
-	}
-
-	var isActivePtr *bool
-	if isActive != "" {
-		isActiveBool, err := strconv.ParseBool(isActive)
-		if err != nil {
-			webResponse := web.WebUsulanMusrebangResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
-			return
+	listQuery, err := helper.ParseListQuery(request)
+	if err != nil {
+		webResponse := web.WebUsulanMusrebangResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err.Error(),
 		}
-		isActivePtr = &isActiveBool
+		helper.WriteToResponseBody(writer, webResponse)
+		return
 	}
-
-	var statusPtr *string
-	if status != "" {
-		statusPtr = &status
+	if kodeOpd := params.ByName("kode_opd"); kodeOpd != "" {
+		listQuery = listQuery.WithFilter("kode_opd", kodeOpd)
+	}
+	if rekinID := params.ByName("rencana_kinerja_id"); rekinID != "" {
+		listQuery = listQuery.WithFilter("rekin_id", rekinID)
 	}
 
-	usulanMusrebangResponses, err := controller.UsulanMusrebangService.FindAll(request.Context(), kodeOpdPtr, isActivePtr, rekinIDPtr, statusPtr)
+	usulanMusrebangResponses, total, err := controller.UsulanMusrebangService.FindAll(request.Context(), listQuery)
 	if err != nil {
 		webResponse := web.WebUsulanMusrebangResponse{
 			Code:   http.StatusBadRequest,
@@ -131,9 +119,10 @@ func (controller *UsulanMusrebangControllerImpl) FindAll(writer http.ResponseWri
 	}
 
 	webResponse := web.WebUsulanMusrebangResponse{
-		Code:   http.StatusOK,
-		Status: "success find all usulan musrebang",
-		Data:   usulanMusrebangResponses,
+		Code:       http.StatusOK,
+		Status:     "success find all usulan musrebang",
+		Data:       usulanMusrebangResponses,
+		Pagination: helper.NewPagination(listQuery, total),
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
@@ -192,42 +181,24 @@ func (controller *UsulanMusrebangControllerImpl) Delete(writer http.ResponseWrit
 }
 
 func (controller *UsulanMusrebangControllerImpl) FindAllRekin(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	pegawaiID := params.ByName("pegawai_id")
-	rekinID := params.ByName("rencana_kinerja_id")
-	isActive := request.URL.Query().Get("is_active")
-	status := request.URL.Query().Get("status")
-
-	var pegawaiIDPtr *string
-	if pegawaiID != "" {
-		pegawaiIDPtr = &pegawaiID
-	}
-
-	var rekinIDPtr *string
-	if rekinID != "" {
-		rekinIDPtr = &rekinID
-	}
-
-	var isActivePtr *bool
-	if isActive != "" {
-		isActiveBool, err := strconv.ParseBool(isActive)
-		if err != nil {
-			webResponse := web.WebUsulanMusrebangResponse{
-				Code:        http.StatusBadRequest,
-				Status:      "BAD REQUEST",
-				DataPilihan: "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
-			return
+	listQuery, err := helper.ParseListQuery(request)
+	if err != nil {
+		webResponse := web.WebUsulanMusrebangResponse{
+			Code:        http.StatusBadRequest,
+			Status:      "BAD REQUEST",
+			DataPilihan: err.Error(),
 		}
-		isActivePtr = &isActiveBool
+		helper.WriteToResponseBody(writer, webResponse)
+		return
 	}
-
-	var statusPtr *string
-	if status != "" {
-		statusPtr = &status
+	if pegawaiID := params.ByName("pegawai_id"); pegawaiID != "" {
+		listQuery = listQuery.WithFilter("kode_opd", pegawaiID)
+	}
+	if rekinID := params.ByName("rencana_kinerja_id"); rekinID != "" {
+		listQuery = listQuery.WithFilter("rekin_id", rekinID)
 	}
 
-	usulanMusrebangResponses, err := controller.UsulanMusrebangService.FindAll(request.Context(), pegawaiIDPtr, isActivePtr, rekinIDPtr, statusPtr)
+	usulanMusrebangResponses, _, err := controller.UsulanMusrebangService.FindAll(request.Context(), listQuery)
 	if err != nil {
 		webResponse := web.WebUsulanMusrebangResponse{
 			Code:        http.StatusBadRequest,
@@ -288,6 +259,9 @@ func (controller *UsulanMusrebangControllerImpl) CreateRekin(writer http.Respons
 
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.CreateRekin(request.Context(), usulanMusrebangCreateRekinRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanMusrebangResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
@@ -27,6 +28,9 @@ func (controller *UsulanPokokPikiranControllerImpl) Create(writer http.ResponseW
 
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.Create(request.Context(), usulanPokokPikiranCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanPokokPikiranResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -62,6 +66,9 @@ func (controller *UsulanPokokPikiranControllerImpl) Update(writer http.ResponseW
 
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.Update(request.Context(), usulanPokokPikiranUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanPokokPikiranResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -250,6 +257,9 @@ func (controller *UsulanPokokPikiranControllerImpl) CreateRekin(writer http.Resp
 
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.CreateRekin(request.Context(), usulanPokokPikiranCreateRekinRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		webResponse := web.WebUsulanPokokPikiranResponse{
 			Code:   http.StatusBadRequest,
 			Status: "BAD REQUEST",
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
@@ -24,12 +25,7 @@ func (controller *UsulanTerpilihControllerImpl) Create(writer http.ResponseWrite
 
 	usulanTerpilihResponse, err := controller.UsulanTerpilihService.Create(request.Context(), usulanTerpilihCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
@@ -47,12 +43,7 @@ func (controller *UsulanTerpilihControllerImpl) Delete(writer http.ResponseWrite
 
 	err := controller.UsulanTerpilihService.Delete(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		exception.WriteError(writer, request, err)
 		return
 	}
 
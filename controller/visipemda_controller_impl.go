@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"ekak_kabupaten_madiun/exception"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web"
 	visimisipemda "ekak_kabupaten_madiun/model/web/visimisi"
@@ -25,6 +26,9 @@ func (controller *VisiPemdaControllerImpl) Create(writer http.ResponseWriter, re
 
 	visiPemdaResponse, err := controller.VisiPemdaService.Create(request.Context(), visiPemdaCreateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		helper.WriteToResponseBody(writer, err.Error())
 		return
 	}
@@ -57,6 +61,9 @@ func (controller *VisiPemdaControllerImpl) Update(writer http.ResponseWriter, re
 
 	visiPemdaResponse, err := controller.VisiPemdaService.Update(request.Context(), visiPemdaUpdateRequest)
 	if err != nil {
+		if exception.WriteCustomError(writer, err) {
+			return
+		}
 		helper.WriteToResponseBody(writer, err.Error())
 		return
 	}
@@ -0,0 +1,10 @@
+package dataseeder
+
+import (
+	"context"
+	"database/sql"
+)
+
+type OpdSeeder interface {
+	Seed(ctx context.Context, tx *sql.Tx, data SeedData) error
+}
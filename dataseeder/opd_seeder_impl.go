@@ -0,0 +1,66 @@
+package dataseeder
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+type OpdSeederImpl struct {
+	OpdRepository repository.OpdRepository
+}
+
+func NewOpdSeederImpl(opdRepository repository.OpdRepository) *OpdSeederImpl {
+	return &OpdSeederImpl{
+		OpdRepository: opdRepository,
+	}
+}
+
+// Seed upsert OPD berdasarkan kode_opd
+func (seeder *OpdSeederImpl) Seed(ctx context.Context, tx *sql.Tx, data SeedData) error {
+	created, updated := 0, 0
+	for _, seed := range data.Opd {
+		opd := domainmaster.Opd{
+			KodeOpd:       seed.KodeOpd,
+			NamaOpd:       seed.NamaOpd,
+			Singkatan:     seed.Singkatan,
+			Alamat:        seed.Alamat,
+			Telepon:       seed.Telepon,
+			Fax:           seed.Fax,
+			Email:         seed.Email,
+			Website:       seed.Website,
+			NamaKepalaOpd: seed.NamaKepalaOpd,
+			NIPKepalaOpd:  seed.NipKepalaOpd,
+			PangkatKepala: seed.PangkatKepala,
+			IdLembaga:     seed.IdLembaga,
+		}
+
+		existing, err := seeder.OpdRepository.FindByKodeOpd(ctx, tx, seed.KodeOpd)
+		if err != nil {
+			return err
+		}
+		if existing.Id == "" {
+			opd.Id = fmt.Sprintf("OPD-%s", uuid.New().String()[:4])
+			if _, err := seeder.OpdRepository.Create(ctx, tx, opd); err != nil {
+				return err
+			}
+			created++
+			continue
+		}
+
+		opd.Id = existing.Id
+		if opd != existing {
+			if _, err := seeder.OpdRepository.Update(ctx, tx, opd); err != nil {
+				return err
+			}
+			updated++
+		}
+	}
+	log.Printf("OPD: %d baru, %d diperbarui", created, updated)
+	return nil
+}
@@ -6,5 +6,5 @@ import (
 )
 
 type PegawaiSeeder interface {
-	Seed(ctx context.Context, tx *sql.Tx) error
+	Seed(ctx context.Context, tx *sql.Tx, data SeedData) error
 }
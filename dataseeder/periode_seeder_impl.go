@@ -0,0 +1,83 @@
+package dataseeder
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+)
+
+type PeriodeSeederImpl struct {
+	PeriodeRepository repository.PeriodeRepository
+}
+
+func NewPeriodeSeederImpl(periodeRepository repository.PeriodeRepository) *PeriodeSeederImpl {
+	return &PeriodeSeederImpl{
+		PeriodeRepository: periodeRepository,
+	}
+}
+
+// Seed membuat periode yang belum ada beserta tahun-tahunnya.
+// Periode sama (tahun awal, tahun akhir, jenis) dilewati, periode yang beririsan dianggap konflik data.
+func (seeder *PeriodeSeederImpl) Seed(ctx context.Context, tx *sql.Tx, data SeedData) error {
+	created := 0
+	for _, seed := range data.Periode {
+		tahunAwal, err := strconv.Atoi(seed.TahunAwal)
+		if err != nil {
+			return fmt.Errorf("periode %s-%s: tahun awal tidak valid", seed.TahunAwal, seed.TahunAkhir)
+		}
+		tahunAkhir, err := strconv.Atoi(seed.TahunAkhir)
+		if err != nil || tahunAkhir < tahunAwal {
+			return fmt.Errorf("periode %s-%s: tahun akhir tidak valid", seed.TahunAwal, seed.TahunAkhir)
+		}
+
+		overlapping, err := seeder.PeriodeRepository.FindOverlappingPeriodes(ctx, tx, seed.TahunAwal, seed.TahunAkhir, seed.JenisPeriode)
+		if err != nil {
+			return err
+		}
+		if len(overlapping) > 0 {
+			existing := overlapping[0]
+			if len(overlapping) == 1 && existing.TahunAwal == seed.TahunAwal && existing.TahunAkhir == seed.TahunAkhir {
+				continue
+			}
+			return fmt.Errorf("periode %s %s-%s beririsan dengan periode %s-%s yang sudah ada",
+				seed.JenisPeriode, seed.TahunAwal, seed.TahunAkhir, existing.TahunAwal, existing.TahunAkhir)
+		}
+
+		periode, err := seeder.PeriodeRepository.Save(ctx, tx, domain.Periode{
+			Id:           seeder.generateRandomId(ctx, tx),
+			TahunAwal:    seed.TahunAwal,
+			TahunAkhir:   seed.TahunAkhir,
+			JenisPeriode: seed.JenisPeriode,
+		})
+		if err != nil {
+			return err
+		}
+		for tahun := tahunAwal; tahun <= tahunAkhir; tahun++ {
+			err := seeder.PeriodeRepository.SaveTahunPeriode(ctx, tx, domain.TahunPeriode{
+				IdPeriode: periode.Id,
+				Tahun:     strconv.Itoa(tahun),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		created++
+	}
+	log.Printf("Periode: %d baru, %d sudah ada", created, len(data.Periode)-created)
+	return nil
+}
+
+// generateRandomId id periode 5 digit seperti yang dibuat PeriodeService
+func (seeder *PeriodeSeederImpl) generateRandomId(ctx context.Context, tx *sql.Tx) int {
+	for {
+		id := rand.Intn(90000) + 10000
+		if !seeder.PeriodeRepository.IsIdExists(ctx, tx, id) {
+			return id
+		}
+	}
+}
@@ -18,53 +18,30 @@ func NewRoleSeederImpl(roleRepository repository.RoleRepository) *RoleSeederImpl
 	}
 }
 
-func (seeder *RoleSeederImpl) Seed(ctx context.Context, tx *sql.Tx) error {
+// Seed menambahkan role yang belum ada, role lama tidak diubah
+func (seeder *RoleSeederImpl) Seed(ctx context.Context, tx *sql.Tx, data SeedData) error {
 	roles, err := seeder.RoleRepository.FindAll(ctx, tx)
 	if err != nil {
 		return err
 	}
 
-	if len(roles) > 0 {
-		log.Println("Roles sudah ada, skip seeding roles")
-		return nil
+	existing := make(map[string]bool)
+	for _, role := range roles {
+		existing[role.Role] = true
 	}
 
-	defaultRoles := []domain.Roles{
-		{
-			Role: "super_admin",
-		},
-		{
-			Role: "admin_opd",
-		},
-		{
-			Role: "admin_kecamatan",
-		},
-		{
-			Role: "eselon_1",
-		},
-		{
-			Role: "eselon_2",
-		},
-		{
-			Role: "eselon_3",
-		},
-		{
-			Role: "eselon_4",
-		},
-		{
-			Role: "staff",
-		},
-		{
-			Role: "reviewer",
-		},
-	}
-
-	for _, role := range defaultRoles {
-		_, err := seeder.RoleRepository.Create(ctx, tx, role)
+	created := 0
+	for _, role := range data.Roles {
+		if existing[role.Role] {
+			continue
+		}
+		_, err := seeder.RoleRepository.Create(ctx, tx, domain.Roles{Role: role.Role})
 		if err != nil {
 			return err
 		}
+		existing[role.Role] = true
+		created++
 	}
-	log.Println("Roles berhasil di-seed")
+	log.Printf("Roles: %d baru, %d sudah ada", created, len(data.Roles)-created)
 	return nil
 }
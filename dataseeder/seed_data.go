@@ -0,0 +1,313 @@
+package dataseeder
+
+import (
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// data bawaan: data/<profil> untuk file khusus profil, data/common dipakai jika profil tidak punya file yang sama
+//
+//go:embed data
+var bundledData embed.FS
+
+const (
+	commonDataDir = "common"
+	// csvListSeparator pemisah nilai list dalam satu kolom CSV, contoh roles "super_admin|admin_opd"
+	csvListSeparator = "|"
+)
+
+// SeedConfig profil (dev, demo, prod) dan lokasi data seeder
+type SeedConfig struct {
+	Profile string
+	// Dir direktori data dengan struktur yang sama seperti data bawaan, kosong berarti data bawaan
+	Dir string
+	// Output tempat menampilkan password acak user baru pada profil produksi
+	Output io.Writer
+}
+
+type SeedProfile struct {
+	Production      bool   `yaml:"production"`
+	DefaultPassword string `yaml:"default_password"`
+}
+
+type RoleSeed struct {
+	Role string `yaml:"role"`
+}
+
+type OpdSeed struct {
+	KodeOpd       string `yaml:"kode_opd"`
+	NamaOpd       string `yaml:"nama_opd"`
+	Singkatan     string `yaml:"singkatan"`
+	Alamat        string `yaml:"alamat"`
+	Telepon       string `yaml:"telepon"`
+	Fax           string `yaml:"fax"`
+	Email         string `yaml:"email"`
+	Website       string `yaml:"website"`
+	NamaKepalaOpd string `yaml:"nama_kepala_opd"`
+	NipKepalaOpd  string `yaml:"nip_kepala_opd"`
+	PangkatKepala string `yaml:"pangkat_kepala"`
+	IdLembaga     string `yaml:"id_lembaga"`
+}
+
+type PegawaiSeed struct {
+	Nip     string `yaml:"nip"`
+	Nama    string `yaml:"nama"`
+	KodeOpd string `yaml:"kode_opd"`
+	// IdPrefix prefix id pegawai baru, default "PEG-"
+	IdPrefix string `yaml:"id_prefix"`
+}
+
+type UserSeed struct {
+	Nip      string   `yaml:"nip"`
+	Email    string   `yaml:"email"`
+	Roles    []string `yaml:"roles"`
+	IsActive *bool    `yaml:"is_active"`
+	// Password hanya untuk profil non produksi, kosong berarti default_password profil
+	Password string `yaml:"password"`
+}
+
+type PeriodeSeed struct {
+	TahunAwal    string `yaml:"tahun_awal"`
+	TahunAkhir   string `yaml:"tahun_akhir"`
+	JenisPeriode string `yaml:"jenis_periode"`
+}
+
+// SeedData seluruh data satu profil, dataset yang filenya tidak ada dibiarkan kosong
+type SeedData struct {
+	Profile SeedProfile
+	Roles   []RoleSeed
+	Opd     []OpdSeed
+	Periode []PeriodeSeed
+	Pegawai []PegawaiSeed
+	Users   []UserSeed
+}
+
+// LoadSeedData membaca data profil dari file YAML (.yaml/.yml) atau CSV dengan header sesuai tag yaml
+func LoadSeedData(config SeedConfig) (SeedData, error) {
+	var files fs.FS = bundledData
+	root := "data"
+	if config.Dir != "" {
+		files = os.DirFS(config.Dir)
+		root = "."
+	}
+	if config.Profile == "" || strings.ContainsAny(config.Profile, `/\.`) {
+		return SeedData{}, fmt.Errorf("profil seeder %q tidak valid", config.Profile)
+	}
+
+	loader := seedLoader{
+		files: files,
+		dirs:  []string{path.Join(root, config.Profile), path.Join(root, commonDataDir)},
+	}
+
+	var data SeedData
+	var profiles []SeedProfile
+	found, err := loader.load("profile", &profiles, false)
+	if err != nil {
+		return data, err
+	}
+	if !found {
+		return data, fmt.Errorf("profil seeder %q tidak ditemukan (%s/profile.yaml)", config.Profile, loader.dirs[0])
+	}
+	if len(profiles) > 0 {
+		data.Profile = profiles[0]
+	}
+
+	datasets := []struct {
+		name string
+		dest interface{}
+	}{
+		{"roles", &data.Roles},
+		{"opd", &data.Opd},
+		{"periode", &data.Periode},
+		{"pegawai", &data.Pegawai},
+		{"users", &data.Users},
+	}
+	for _, dataset := range datasets {
+		if _, err := loader.load(dataset.name, dataset.dest, true); err != nil {
+			return data, err
+		}
+	}
+
+	return data, data.validate()
+}
+
+func (data SeedData) validate() error {
+	if data.Profile.Production && data.Profile.DefaultPassword != "" {
+		return errors.New("profil produksi tidak boleh memiliki default_password")
+	}
+
+	nips := make(map[string]bool)
+	for _, pegawai := range data.Pegawai {
+		if pegawai.Nip == "" || pegawai.Nama == "" {
+			return fmt.Errorf("pegawai %q: nip dan nama wajib diisi", pegawai.Nip)
+		}
+		if nips[pegawai.Nip] {
+			return fmt.Errorf("nip pegawai %s duplikat", pegawai.Nip)
+		}
+		nips[pegawai.Nip] = true
+	}
+
+	nips = make(map[string]bool)
+	for _, user := range data.Users {
+		if user.Nip == "" || user.Email == "" {
+			return fmt.Errorf("user %q: nip dan email wajib diisi", user.Nip)
+		}
+		if nips[user.Nip] {
+			return fmt.Errorf("nip user %s duplikat", user.Nip)
+		}
+		nips[user.Nip] = true
+		if data.Profile.Production && user.Password != "" {
+			return fmt.Errorf("user %s: password tidak boleh ditulis di data profil produksi", user.Nip)
+		}
+	}
+
+	kodeOpd := make(map[string]bool)
+	for _, opd := range data.Opd {
+		if opd.KodeOpd == "" || opd.NamaOpd == "" {
+			return fmt.Errorf("opd %q: kode_opd dan nama_opd wajib diisi", opd.KodeOpd)
+		}
+		if kodeOpd[opd.KodeOpd] {
+			return fmt.Errorf("kode opd %s duplikat", opd.KodeOpd)
+		}
+		kodeOpd[opd.KodeOpd] = true
+	}
+	return nil
+}
+
+// seedLoader mencari file dataset di direktori profil lalu di direktori common
+type seedLoader struct {
+	files fs.FS
+	dirs  []string
+}
+
+func (loader seedLoader) load(name string, dest interface{}, fallback bool) (bool, error) {
+	dirs := loader.dirs
+	if !fallback {
+		dirs = dirs[:1]
+	}
+	for _, dir := range dirs {
+		for _, ext := range []string{".yaml", ".yml", ".csv"} {
+			file := path.Join(dir, name+ext)
+			content, err := fs.ReadFile(loader.files, file)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+
+			if ext == ".csv" {
+				err = decodeCsv(content, dest)
+			} else {
+				err = decodeYaml(content, dest)
+			}
+			if err != nil {
+				return false, fmt.Errorf("gagal membaca %s: %w", file, err)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// decodeYaml profile.yaml berupa satu objek, dataset lain berupa list
+func decodeYaml(content []byte, dest interface{}) error {
+	slice := reflect.ValueOf(dest).Elem()
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return err
+	}
+	if len(node.Content) == 0 {
+		return nil
+	}
+	if node.Content[0].Kind == yaml.MappingNode {
+		item := reflect.New(slice.Type().Elem())
+		if err := node.Content[0].Decode(item.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, item.Elem()))
+		return nil
+	}
+	return node.Decode(dest)
+}
+
+// decodeCsv mengisi slice struct dari CSV, kolom dicocokkan dengan tag yaml field
+func decodeCsv(content []byte, dest interface{}) error {
+	reader := csv.NewReader(strings.NewReader(string(content)))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	slice := reflect.ValueOf(dest).Elem()
+	itemType := slice.Type().Elem()
+	fields := make(map[string]int)
+	for i := 0; i < itemType.NumField(); i++ {
+		fields[itemType.Field(i).Tag.Get("yaml")] = i
+	}
+
+	header := records[0]
+	for _, column := range header {
+		if _, ok := fields[column]; !ok {
+			return fmt.Errorf("kolom %q tidak dikenal", column)
+		}
+	}
+
+	for line, record := range records[1:] {
+		item := reflect.New(itemType).Elem()
+		for i, column := range header {
+			if err := setCsvField(item.Field(fields[column]), strings.TrimSpace(record[i])); err != nil {
+				return fmt.Errorf("baris %d kolom %s: %w", line+2, column, err)
+			}
+		}
+		slice.Set(reflect.Append(slice, item))
+	}
+	return nil
+}
+
+func setCsvField(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case []string:
+		var list []string
+		for _, item := range strings.Split(value, csvListSeparator) {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		field.Set(reflect.ValueOf(list))
+	case bool, *bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		if field.Kind() == reflect.Ptr {
+			field.Set(reflect.ValueOf(&parsed))
+		} else {
+			field.SetBool(parsed)
+		}
+	default:
+		return fmt.Errorf("tipe %s tidak didukung", field.Type())
+	}
+	return nil
+}
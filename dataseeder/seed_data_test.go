@@ -0,0 +1,119 @@
+package dataseeder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSeedDataBundled(t *testing.T) {
+	tests := []struct {
+		profile        string
+		wantProduction bool
+		wantPegawai    int
+		wantOpd        int
+	}{
+		{profile: "dev", wantPegawai: 90},
+		{profile: "prod", wantProduction: true, wantPegawai: 90},
+		{profile: "demo", wantPegawai: 6, wantOpd: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			data, err := LoadSeedData(SeedConfig{Profile: tt.profile})
+			if err != nil {
+				t.Fatalf("LoadSeedData() error = %v", err)
+			}
+			if data.Profile.Production != tt.wantProduction {
+				t.Errorf("production = %t, want %t", data.Profile.Production, tt.wantProduction)
+			}
+			if len(data.Roles) == 0 {
+				t.Error("roles kosong, seharusnya diambil dari common")
+			}
+			if len(data.Pegawai) != tt.wantPegawai || len(data.Opd) != tt.wantOpd {
+				t.Errorf("pegawai = %d, opd = %d, want %d, %d", len(data.Pegawai), len(data.Opd), tt.wantPegawai, tt.wantOpd)
+			}
+			for _, user := range data.Users {
+				if len(user.Roles) == 0 {
+					t.Errorf("user %s tanpa role", user.Nip)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadSeedDataValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{
+			name: "csv dengan list dan bool",
+			files: map[string]string{
+				"x/profile.yaml": "default_password: rahasia\n",
+				"x/users.csv":    "nip,email,roles,is_active\nadmin,admin@local,super_admin|admin_opd,false\n",
+			},
+		},
+		{
+			name: "produksi dengan default password",
+			files: map[string]string{
+				"x/profile.yaml": "production: true\ndefault_password: rahasia\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "produksi dengan password user",
+			files: map[string]string{
+				"x/profile.yaml": "production: true\n",
+				"x/users.yaml":   "- nip: admin\n  email: admin@local\n  password: rahasia\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "nip pegawai duplikat",
+			files: map[string]string{
+				"x/profile.yaml": "production: false\n",
+				"x/pegawai.csv":  "nip,nama\n1,satu\n1,dua\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "kolom csv tidak dikenal",
+			files: map[string]string{
+				"x/profile.yaml": "production: false\n",
+				"x/pegawai.csv":  "nip,nama,jabatan\n1,satu,staf\n",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "profil tidak ada",
+			files:   map[string]string{"common/roles.yaml": "- role: staff\n"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				file := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			data, err := LoadSeedData(SeedConfig{Profile: "x", Dir: dir})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadSeedData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(data.Users) == 1 {
+				user := data.Users[0]
+				if len(user.Roles) != 2 || user.IsActive == nil || *user.IsActive {
+					t.Errorf("user = %+v", user)
+				}
+			}
+		})
+	}
+}
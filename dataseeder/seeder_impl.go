@@ -3,51 +3,70 @@ package dataseeder
 import (
 	"context"
 	"database/sql"
-
-	"ekak_kabupaten_madiun/helper"
 	"log"
 )
 
 type SeederImpl struct {
 	DB            *sql.DB
+	Config        SeedConfig
 	RoleSeeder    RoleSeeder
-	UserSeeder    UserSeeder
+	OpdSeeder     OpdSeeder
+	PeriodeSeeder PeriodeSeeder
 	PegawaiSeeder PegawaiSeeder
+	UserSeeder    UserSeeder
 }
 
-func NewSeederImpl(db *sql.DB, roleSeeder RoleSeeder, userSeeder UserSeeder, pegawaiSeeder PegawaiSeeder) *SeederImpl {
+func NewSeederImpl(db *sql.DB, config SeedConfig, roleSeeder RoleSeeder, opdSeeder OpdSeeder, periodeSeeder PeriodeSeeder, pegawaiSeeder PegawaiSeeder, userSeeder UserSeeder) *SeederImpl {
 	return &SeederImpl{
 		DB:            db,
+		Config:        config,
 		RoleSeeder:    roleSeeder,
-		UserSeeder:    userSeeder,
+		OpdSeeder:     opdSeeder,
+		PeriodeSeeder: periodeSeeder,
 		PegawaiSeeder: pegawaiSeeder,
+		UserSeeder:    userSeeder,
 	}
 }
 
+// SeedAll menjalankan semua seeder dalam satu transaksi, aman dijalankan berulang (upsert)
 func (seeder *SeederImpl) SeedAll() {
-	tx, err := seeder.DB.Begin()
+	data, err := LoadSeedData(seeder.Config)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer helper.CommitOrRollback(tx)
-
-	ctx := context.Background()
+	log.Printf("Profil seeder %s (produksi: %t)", seeder.Config.Profile, data.Profile.Production)
 
-	// Seed roles dulu
-	err = seeder.RoleSeeder.Seed(ctx, tx)
+	tx, err := seeder.DB.Begin()
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer tx.Rollback()
 
-	// Kemudian seed users
-	err = seeder.UserSeeder.Seed(ctx, tx)
-	if err != nil {
-		log.Fatal(err)
-	}
+	ctx := context.Background()
 
-	// Seed pegawai
-	err = seeder.PegawaiSeeder.Seed(ctx, tx)
-	if err != nil {
-		log.Fatal(err)
+	// role dan master data dulu, user terakhir karena membutuhkan role dan pegawai
+	steps := []struct {
+		name   string
+		seeder interface {
+			Seed(ctx context.Context, tx *sql.Tx, data SeedData) error
+		}
+	}{
+		{"roles", seeder.RoleSeeder},
+		{"opd", seeder.OpdSeeder},
+		{"periode", seeder.PeriodeSeeder},
+		{"pegawai", seeder.PegawaiSeeder},
+		{"users", seeder.UserSeeder},
+	}
+	for _, step := range steps {
+		if err := step.seeder.Seed(ctx, tx, data); err != nil {
+			tx.Rollback()
+			log.Fatalf("Seeder %s gagal: %v", step.name, err)
+		}
 	}
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("Commit seeder gagal: %v", err)
+	}
+
+	// password acak baru ditampilkan setelah commit agar tidak ada password untuk user yang batal dibuat
+	seeder.UserSeeder.PrintGeneratedPasswords()
 }
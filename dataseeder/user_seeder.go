@@ -6,5 +6,7 @@ import (
 )
 
 type UserSeeder interface {
-	Seed(ctx context.Context, tx *sql.Tx) error
+	Seed(ctx context.Context, tx *sql.Tx, data SeedData) error
+	// PrintGeneratedPasswords menampilkan password acak hasil Seed, dipanggil setelah transaksi seeder di-commit
+	PrintGeneratedPasswords()
 }
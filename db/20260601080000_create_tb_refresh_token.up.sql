@@ -0,0 +1,12 @@
+CREATE TABLE tb_refresh_token (
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    session_id VARCHAR(36)  NOT NULL,
+    user_id    INT          NOT NULL,
+    token_hash CHAR(64)     NOT NULL,
+    expired_at DATETIME     NOT NULL,
+    revoked_at DATETIME     NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_refresh_token_hash (token_hash),
+    INDEX idx_refresh_token_session (session_id),
+    INDEX idx_refresh_token_user (user_id)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE tb_audit_log (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id     INT          NOT NULL DEFAULT 0,
+    nip         VARCHAR(255) NOT NULL DEFAULT '',
+    action      VARCHAR(50)  NOT NULL,
+    entity_type VARCHAR(50)  NOT NULL,
+    entity_id   VARCHAR(255) NOT NULL,
+    kode_opd    VARCHAR(255) NOT NULL DEFAULT '',
+    tahun       VARCHAR(4)   NOT NULL DEFAULT '',
+    before_data JSON NULL,
+    after_data  JSON NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_log_entity (entity_type, entity_id),
+    INDEX idx_audit_log_opd (kode_opd, tahun),
+    INDEX idx_audit_log_user (user_id),
+    INDEX idx_audit_log_created (created_at)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE tb_urusan DROP COLUMN is_active;
+
+ALTER TABLE tb_bidang_urusan DROP COLUMN is_active;
+
+ALTER TABLE tb_master_kegiatan DROP COLUMN is_active;
+
+ALTER TABLE tb_subkegiatan DROP COLUMN is_active;
@@ -0,0 +1,7 @@
+ALTER TABLE tb_urusan ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE tb_bidang_urusan ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE tb_master_kegiatan ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE tb_subkegiatan ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
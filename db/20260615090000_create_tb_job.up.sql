@@ -0,0 +1,30 @@
+CREATE TABLE tb_job (
+    id               BIGINT AUTO_INCREMENT PRIMARY KEY,
+    jenis            VARCHAR(50)  NOT NULL,
+    payload          JSON NOT NULL,
+    status           VARCHAR(30)  NOT NULL DEFAULT 'PENDING',
+    progress         INT          NOT NULL DEFAULT 0,
+    attempts         INT          NOT NULL DEFAULT 0,
+    max_attempts     INT          NOT NULL DEFAULT 3,
+    cancel_requested BOOLEAN      NOT NULL DEFAULT FALSE,
+    warnings         JSON NULL,
+    result           JSON NULL,
+    error_message    TEXT NULL,
+    kode_opd         VARCHAR(255) NOT NULL DEFAULT '',
+    tahun            VARCHAR(4)   NOT NULL DEFAULT '',
+    user_id          INT          NOT NULL DEFAULT 0,
+    nip              VARCHAR(255) NOT NULL DEFAULT '',
+    run_after        TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    heartbeat_at     TIMESTAMP NULL,
+    started_at       TIMESTAMP NULL,
+    finished_at      TIMESTAMP NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_job_antrian (status, run_after),
+    INDEX idx_job_opd (kode_opd, tahun)
+);
+
+-- clone rekin yang tertinggal dari goroutine lama tidak akan pernah selesai
+UPDATE clone_record
+SET status = 'FAILED', error_message = 'proses clone terhenti sebelum job runner tersedia, silakan ulangi clone'
+WHERE status IN ('PENDING', 'PROCESS');
@@ -0,0 +1,2 @@
+ALTER TABLE tb_indikator DROP COLUMN polaritas;
+DROP TABLE IF EXISTS tb_realisasi_indikator;
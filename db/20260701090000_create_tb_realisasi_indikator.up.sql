@@ -0,0 +1,18 @@
+CREATE TABLE tb_realisasi_indikator (
+    id            INT AUTO_INCREMENT PRIMARY KEY,
+    indikator_id  VARCHAR(255)   NOT NULL,
+    tahun         VARCHAR(4)     NOT NULL,
+    jenis_periode VARCHAR(20)    NOT NULL,
+    periode       INT            NOT NULL,
+    realisasi     DECIMAL(20, 4) NOT NULL,
+    bukti         TEXT NULL,
+    kode_opd      VARCHAR(255)   NOT NULL DEFAULT '',
+    created_by    VARCHAR(255)   NOT NULL DEFAULT '',
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_realisasi_periode (indikator_id, tahun, jenis_periode, periode),
+    INDEX idx_realisasi_opd (kode_opd, tahun)
+) ENGINE=InnoDB;
+
+-- positif: realisasi makin tinggi makin baik, negatif: realisasi makin rendah makin baik
+ALTER TABLE tb_indikator ADD COLUMN polaritas VARCHAR(20) NOT NULL DEFAULT 'positif';
@@ -0,0 +1,14 @@
+CREATE TABLE tb_realisasi_anggaran (
+    id          INT AUTO_INCREMENT PRIMARY KEY,
+    renaksi_id  VARCHAR(255)    NOT NULL,
+    bulan       INT             NOT NULL,
+    realisasi   BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    keterangan  TEXT NULL,
+    kode_opd    VARCHAR(255)    NOT NULL DEFAULT '',
+    tahun       VARCHAR(4)      NOT NULL DEFAULT '',
+    created_by  VARCHAR(255)    NOT NULL DEFAULT '',
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_realisasi_anggaran_bulan (renaksi_id, bulan),
+    INDEX idx_realisasi_anggaran_opd (kode_opd, tahun)
+) ENGINE=InnoDB;
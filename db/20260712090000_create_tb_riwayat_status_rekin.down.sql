@@ -0,0 +1,2 @@
+ALTER TABLE tb_rencana_kinerja MODIFY status_rencana_kinerja VARCHAR(255);
+DROP TABLE IF EXISTS tb_riwayat_status_rekin;
@@ -0,0 +1,18 @@
+CREATE TABLE tb_riwayat_status_rekin (
+    id            INT AUTO_INCREMENT PRIMARY KEY,
+    rekin_id      VARCHAR(255) NOT NULL,
+    aksi          VARCHAR(20)  NOT NULL,
+    status_awal   VARCHAR(20)  NOT NULL,
+    status_akhir  VARCHAR(20)  NOT NULL,
+    catatan       TEXT NULL,
+    nip_aktor     VARCHAR(255) NOT NULL DEFAULT '',
+    nip_atasan    VARCHAR(255) NOT NULL DEFAULT '',
+    kode_opd      VARCHAR(255) NOT NULL DEFAULT '',
+    tahun         VARCHAR(20)  NOT NULL DEFAULT '',
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_riwayat_status_rekin (rekin_id, created_at),
+    INDEX idx_riwayat_status_rekin_atasan (nip_atasan, tahun)
+) ENGINE=InnoDB;
+
+-- status lama di luar alur persetujuan tetap dibaca sebagai draft, rekin baru langsung draft
+ALTER TABLE tb_rencana_kinerja MODIFY status_rencana_kinerja VARCHAR(255) DEFAULT 'draft';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_riwayat_status_pokin;
@@ -0,0 +1,13 @@
+CREATE TABLE tb_riwayat_status_pokin (
+    id            INT AUTO_INCREMENT PRIMARY KEY,
+    pokin_id      INT NOT NULL,
+    aksi          VARCHAR(50)  NOT NULL,
+    status_awal   VARCHAR(255) NOT NULL DEFAULT '',
+    status_akhir  VARCHAR(255) NOT NULL DEFAULT '',
+    alasan        TEXT NULL,
+    nip_aktor     VARCHAR(255) NOT NULL DEFAULT '',
+    kode_opd      VARCHAR(255) NOT NULL DEFAULT '',
+    tahun         VARCHAR(20)  NOT NULL DEFAULT '',
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_riwayat_status_pokin (pokin_id, created_at)
+) ENGINE=InnoDB;
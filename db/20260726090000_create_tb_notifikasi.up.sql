@@ -0,0 +1,15 @@
+CREATE TABLE tb_notifikasi (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id      INT NOT NULL,
+    jenis        VARCHAR(50)  NOT NULL,
+    judul        VARCHAR(255) NOT NULL,
+    pesan        TEXT NULL,
+    entity_type  VARCHAR(50)  NOT NULL DEFAULT '',
+    entity_id    VARCHAR(255) NOT NULL DEFAULT '',
+    kode_opd     VARCHAR(255) NOT NULL DEFAULT '',
+    tahun        VARCHAR(20)  NOT NULL DEFAULT '',
+    url          VARCHAR(255) NOT NULL DEFAULT '',
+    dibaca_at    TIMESTAMP NULL DEFAULT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_notifikasi_user (user_id, dibaca_at, id)
+) ENGINE=InnoDB;
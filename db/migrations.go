@@ -0,0 +1,7 @@
+// Package db berisi file migrasi schema (format golang-migrate) yang ikut di-embed ke binary
+package db
+
+import "embed"
+
+//go:embed *.sql
+var Migrations embed.FS
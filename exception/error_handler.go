@@ -0,0 +1,88 @@
+package exception
+
+import (
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strings"
+)
+
+// ErrorHandler dipasang sebagai httprouter.PanicHandler. Panic dari helper.ReadFromRequestBody,
+// helper.PanicIfError atau helper.CommitOrRollback diubah menjadi WebResponse, bukan memutus koneksi.
+func ErrorHandler(writer http.ResponseWriter, request *http.Request, recovered interface{}) {
+	if recovered == http.ErrAbortHandler {
+		panic(recovered)
+	}
+
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("%v", recovered)
+	}
+
+	if ToWebResponse(err).Code == http.StatusInternalServerError {
+		log.Printf("panic %s %s: %v\n%s", request.Method, request.URL.Path, recovered, debug.Stack())
+	}
+
+	helper.WriteToResponseBodyWstatus(writer, ToWebResponse(err))
+}
+
+// WriteError menulis error sebagai WebResponse dengan HTTP status sesuai jenis error.
+// Error yang berakhir sebagai 500 dicatat ke log karena pesannya tidak dikirim ke client.
+func WriteError(writer http.ResponseWriter, request *http.Request, err error) {
+	response := ToWebResponse(err)
+	if response.Code == http.StatusInternalServerError {
+		log.Printf("error %s %s: %v", request.Method, request.URL.Path, err)
+	}
+	helper.WriteToResponseBodyWstatus(writer, response)
+}
+
+// WriteCustomError menulis error jika error bertipe web.CustomError (atau parameter list tidak valid)
+// dan mengembalikan true, error lain dibiarkan untuk ditangani controller
+func WriteCustomError(writer http.ResponseWriter, err error) bool {
+	var customErr *web.CustomError
+	if !errors.As(err, &customErr) && !errors.Is(err, domain.ErrInvalidListQuery) {
+		return false
+	}
+	helper.WriteToResponseBodyWstatus(writer, ToWebResponse(err))
+	return true
+}
+
+// ToWebResponse adalah satu-satunya pemetaan error ke WebResponse
+func ToWebResponse(err error) web.WebResponse {
+	code, data := errorStatus(err)
+	return web.WebResponse{
+		Code:   code,
+		Status: strings.ToUpper(http.StatusText(code)),
+		Data:   data,
+	}
+}
+
+func errorStatus(err error) (int, interface{}) {
+	var customErr *web.CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Code, customErr.ResponseData()
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return http.StatusBadRequest, "format request body tidak valid"
+	case errors.As(err, &typeErr):
+		return http.StatusBadRequest, fmt.Sprintf("tipe data field %s tidak valid", typeErr.Field)
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound, "data tidak ditemukan"
+	case errors.Is(err, domain.ErrInvalidListQuery):
+		return http.StatusBadRequest, err.Error()
+	}
+
+	return http.StatusInternalServerError, "terjadi kesalahan pada server"
+}
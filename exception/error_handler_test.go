@@ -0,0 +1,144 @@
+package exception
+
+import (
+	"bytes"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  httprouter.Handle
+		body     string
+		expected int
+	}{
+		{
+			name: "body json rusak",
+			handler: func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				var result map[string]interface{}
+				helper.ReadFromRequestBody(request, &result)
+			},
+			body:     `{"nama":`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "tipe field salah",
+			handler: func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				var result struct {
+					Tahun int `json:"tahun"`
+				}
+				helper.ReadFromRequestBody(request, &result)
+			},
+			body:     `{"tahun":"dua ribu"}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "custom error dibungkus",
+			handler: func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				helper.PanicIfError(fmt.Errorf("simpan rekin: %w", web.NewLockedError("data terkunci")))
+			},
+			expected: http.StatusLocked,
+		},
+		{
+			name: "conflict",
+			handler: func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				panic(web.NewConflictError("data sudah ada"))
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			name: "sql no rows",
+			handler: func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				helper.PanicIfError(sql.ErrNoRows)
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			name: "panic bukan error",
+			handler: func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				panic("index out of range")
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := httprouter.New()
+			router.PanicHandler = ErrorHandler
+			router.POST("/test", tt.handler)
+
+			request := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.expected {
+				t.Fatalf("status = %d; want %d", recorder.Code, tt.expected)
+			}
+			var response web.WebResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+				t.Fatalf("response bukan web.WebResponse: %v", err)
+			}
+			if response.Code != tt.expected {
+				t.Errorf("response.Code = %d; want %d", response.Code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteCustomError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	if WriteCustomError(recorder, errors.New("error biasa")) {
+		t.Fatal("error biasa tidak boleh ditulis oleh WriteCustomError")
+	}
+	if recorder.Body.Len() != 0 {
+		t.Errorf("body = %q; want kosong", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	if !WriteCustomError(recorder, web.NewForbiddenError("bukan opd anda")) {
+		t.Fatal("custom error harus ditulis oleh WriteCustomError")
+	}
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("status = %d; want %d", recorder.Code, http.StatusForbidden)
+	}
+}
+
+func TestWriteErrorLog(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	request := httptest.NewRequest(http.MethodGet, "/tujuan_opd", nil)
+
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, request, web.NewBadRequestError("tahun target 2025 duplikat"))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want %d", recorder.Code, http.StatusBadRequest)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("error 400 tidak perlu dicatat, log = %q", buf.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	WriteError(recorder, request, errors.New("koneksi database terputus"))
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d; want %d", recorder.Code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(buf.String(), "/tujuan_opd") || !strings.Contains(buf.String(), "koneksi database terputus") {
+		t.Errorf("log = %q; want path dan error", buf.String())
+	}
+}
@@ -2,10 +2,18 @@ package helper
 
 import "regexp"
 
+// NIP PNS terdiri dari 18 digit angka
+var nipPattern = regexp.MustCompile(`^\d{18}$`)
+
+// nipPegawaiPattern identitas pegawai: NIP PNS atau akun non-PNS seperti admin1 dan admin_dindik
+// yang sudah dipakai sebagai nip di tb_pegawai dan tb_users
+var nipPegawaiPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,255}$`)
+
 func IsValidNIP(nip string) bool {
-	// Sesuaikan pattern regex dengan format NIP yang valid
-	// Contoh: NIP harus 18 digit angka
-	pattern := `^\d{16}$`
-	match, _ := regexp.MatchString(pattern, nip)
-	return match
+	return nipPattern.MatchString(nip)
+}
+
+// IsValidNIPPegawai memvalidasi nip pegawai yang tidak harus berupa NIP PNS, cukup tanpa spasi dan karakter khusus
+func IsValidNIPPegawai(nip string) bool {
+	return nipPegawaiPattern.MatchString(nip)
 }
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -42,16 +42,20 @@ func GetFromCache(ctx context.Context, client *redis.Client, key string, dest in
 	val, err := client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
+			RecordCacheResult(key, CacheMiss)
 			return fmt.Errorf("cache miss")
 		}
+		RecordCacheResult(key, CacheError)
 		return fmt.Errorf("error getting from cache: %w", err)
 	}
 
 	err = json.Unmarshal([]byte(val), dest)
 	if err != nil {
+		RecordCacheResult(key, CacheError)
 		return fmt.Errorf("error unmarshaling cache: %w", err)
 	}
 
+	RecordCacheResult(key, CacheHit)
 	return nil
 }
 
@@ -118,18 +122,18 @@ func InvalidatePohonKinerjaCache(ctx context.Context, client *redis.Client, kode
 	cacheKey := GenerateCacheKey(CacheKeyPohonKinerjaOpdAll, kodeOpd, tahun)
 	err := DeleteCache(ctx, client, cacheKey)
 	if err != nil {
-		log.Printf("Warning: Failed to invalidate cache for key %s: %v", cacheKey, err)
+		slog.WarnContext(ctx, "gagal invalidasi cache", "key", cacheKey, "error", err)
 	} else {
-		log.Printf("Cache invalidated for key: %s", cacheKey)
+		slog.DebugContext(ctx, "cache diinvalidasi", "key", cacheKey)
 	}
 
 	// Juga invalidate cascading opd cache untuk kodeOpd dan tahun yang sama
 	cascadingCacheKey := GenerateCacheKey(CacheKeyCascadingOpdAll, kodeOpd, tahun)
 	err = DeleteCache(ctx, client, cascadingCacheKey)
 	if err != nil {
-		log.Printf("Warning: Failed to invalidate cascading cache for key %s: %v", cascadingCacheKey, err)
+		slog.WarnContext(ctx, "gagal invalidasi cache", "key", cascadingCacheKey, "error", err)
 	} else {
-		log.Printf("Cache invalidated for key: %s", cascadingCacheKey)
+		slog.DebugContext(ctx, "cache diinvalidasi", "key", cascadingCacheKey)
 	}
 
 	return nil
@@ -145,7 +149,7 @@ func InvalidatePohonKinerjaCacheByPattern(ctx context.Context, client *redis.Cli
 	pattern := fmt.Sprintf("%s:%s:*", CacheKeyPohonKinerjaOpdAll, kodeOpd)
 	err := DeleteCacheByPattern(ctx, client, pattern)
 	if err != nil {
-		log.Printf("Warning: Failed to invalidate cache by pattern %s: %v", pattern, err)
+		slog.WarnContext(ctx, "gagal invalidasi cache", "pattern", pattern, "error", err)
 		return err
 	}
 
@@ -153,9 +157,9 @@ func InvalidatePohonKinerjaCacheByPattern(ctx context.Context, client *redis.Cli
 	cascadingPattern := fmt.Sprintf("%s:%s:*", CacheKeyCascadingOpdAll, kodeOpd)
 	err = DeleteCacheByPattern(ctx, client, cascadingPattern)
 	if err != nil {
-		log.Printf("Warning: Failed to invalidate cascading cache by pattern %s: %v", cascadingPattern, err)
+		slog.WarnContext(ctx, "gagal invalidasi cache", "pattern", cascadingPattern, "error", err)
 	}
 
-	log.Printf("Cache invalidated by pattern: %s and %s", pattern, cascadingPattern)
+	slog.DebugContext(ctx, "cache diinvalidasi", "pattern", pattern, "cascading_pattern", cascadingPattern)
 	return nil
 }
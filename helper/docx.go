@@ -0,0 +1,150 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+// docxLebarHalaman adalah lebar area tulis A4 dalam twip (lebar halaman dikurangi margin kiri dan kanan)
+const docxLebarHalaman = 11906 - 2*1134
+
+// Docx menyusun dokumen Word (.docx) sederhana berisi judul, paragraf dan tabel tanpa dependensi eksternal
+type Docx struct {
+	body strings.Builder
+}
+
+func NewDocx() *Docx {
+	return &Docx{}
+}
+
+// Title menulis judul dokumen rata tengah dan tebal
+func (docx *Docx) Title(text string) {
+	docx.paragraph(text, `<w:jc w:val="center"/>`, `<w:b/><w:sz w:val="28"/>`)
+}
+
+// Heading menulis judul bagian
+func (docx *Docx) Heading(text string) {
+	docx.paragraph(text, `<w:spacing w:before="240" w:after="120"/>`, `<w:b/><w:sz w:val="24"/>`)
+}
+
+// Paragraph menulis paragraf biasa rata kiri-kanan
+func (docx *Docx) Paragraph(text string) {
+	docx.paragraph(text, `<w:jc w:val="both"/>`, "")
+}
+
+// Table menulis tabel dengan header tebal, widths dalam persen lebar halaman
+func (docx *Docx) Table(headers []string, rows [][]string, widths []int) {
+	docx.body.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(&docx.body, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="000000"/>`, side)
+	}
+	docx.body.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid>`)
+	for _, width := range widths {
+		fmt.Fprintf(&docx.body, `<w:gridCol w:w="%d"/>`, width*docxLebarHalaman/100)
+	}
+	docx.body.WriteString(`</w:tblGrid>`)
+
+	docx.tableRow(headers, widths, true)
+	for _, row := range rows {
+		docx.tableRow(row, widths, false)
+	}
+	docx.body.WriteString(`</w:tbl><w:p/>`)
+}
+
+// Bytes mengemas dokumen menjadi file .docx
+func (docx *Docx) Bytes() ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		docx.body.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="709" w:footer="709" w:gutter="0"/></w:sectPr>` +
+		`</w:body></w:document>`
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"word/document.xml", document},
+	}
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(file.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (docx *Docx) paragraph(text, paragraphProperties, runProperties string) {
+	docx.body.WriteString(`<w:p>`)
+	if paragraphProperties != "" {
+		docx.body.WriteString(`<w:pPr>` + paragraphProperties + `</w:pPr>`)
+	}
+	docx.run(text, runProperties)
+	docx.body.WriteString(`</w:p>`)
+}
+
+func (docx *Docx) tableRow(cells []string, widths []int, header bool) {
+	docx.body.WriteString(`<w:tr>`)
+	for i, cell := range cells {
+		docx.body.WriteString(`<w:tc><w:tcPr>`)
+		if i < len(widths) {
+			fmt.Fprintf(&docx.body, `<w:tcW w:w="%d" w:type="pct"/>`, widths[i]*50)
+		}
+		if header {
+			docx.body.WriteString(`<w:shd w:val="clear" w:color="auto" w:fill="D9D9D9"/>`)
+		}
+		docx.body.WriteString(`</w:tcPr><w:p>`)
+		runProperties := `<w:sz w:val="18"/>`
+		if header {
+			docx.body.WriteString(`<w:pPr><w:jc w:val="center"/></w:pPr>`)
+			runProperties = `<w:b/><w:sz w:val="18"/>`
+		}
+		docx.run(cell, runProperties)
+		docx.body.WriteString(`</w:p></w:tc>`)
+	}
+	docx.body.WriteString(`</w:tr>`)
+}
+
+// run menulis teks, baris baru pada teks menjadi line break
+func (docx *Docx) run(text, runProperties string) {
+	docx.body.WriteString(`<w:r>`)
+	if runProperties != "" {
+		docx.body.WriteString(`<w:rPr>` + runProperties + `</w:rPr>`)
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			docx.body.WriteString(`<w:br/>`)
+		}
+		docx.body.WriteString(`<w:t xml:space="preserve">`)
+		_ = xml.EscapeText(&docx.body, []byte(line))
+		docx.body.WriteString(`</w:t>`)
+	}
+	docx.body.WriteString(`</w:r>`)
+}
@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	ContentTypeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	ContentTypePdf  = "application/pdf"
+	ContentTypeDocx = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	ContentTypeZip  = "application/zip"
+)
+
+// WriteFileResponse mengirim file sebagai attachment untuk diunduh
+func WriteFileResponse(writer http.ResponseWriter, contentType, filename string, content []byte) {
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	writer.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	writer.WriteHeader(http.StatusOK)
+	_, err := writer.Write(content)
+	PanicIfError(err)
+}
+
+// ReadUploadedFile membaca file dari form multipart, ukuran file dibatasi maxBytes
+func ReadUploadedFile(request *http.Request, field string, maxBytes int64) (string, []byte, error) {
+	if err := request.ParseMultipartForm(maxBytes); err != nil {
+		return "", nil, web.NewBadRequestError(fmt.Sprintf("form upload tidak valid: %v", err))
+	}
+	file, header, err := request.FormFile(field)
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			return "", nil, web.NewBadRequestError(fmt.Sprintf("file %s wajib diupload", field))
+		}
+		return "", nil, web.NewBadRequestError(fmt.Sprintf("file %s tidak valid: %v", field, err))
+	}
+	defer file.Close()
+
+	if header.Size > maxBytes {
+		return "", nil, web.NewBadRequestError(fmt.Sprintf("ukuran file maksimal %d MB", maxBytes>>20))
+	}
+	content, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return "", nil, err
+	}
+	if int64(len(content)) > maxBytes {
+		return "", nil, web.NewBadRequestError(fmt.Sprintf("ukuran file maksimal %d MB", maxBytes>>20))
+	}
+	return header.Filename, content, nil
+}
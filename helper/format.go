@@ -0,0 +1,26 @@
+package helper
+
+import "strconv"
+
+// FormatRupiah memformat angka dengan pemisah ribuan titik, contoh 1250000 menjadi "Rp 1.250.000"
+func FormatRupiah(nilai int64) string {
+	angka := strconv.FormatInt(nilai, 10)
+	negatif := false
+	if nilai < 0 {
+		negatif = true
+		angka = angka[1:]
+	}
+
+	var hasil []byte
+	for i := range angka {
+		if i > 0 && (len(angka)-i)%3 == 0 {
+			hasil = append(hasil, '.')
+		}
+		hasil = append(hasil, angka[i])
+	}
+
+	if negatif {
+		return "Rp -" + string(hasil)
+	}
+	return "Rp " + string(hasil)
+}
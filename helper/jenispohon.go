@@ -1,5 +1,14 @@
 package helper
 
+// Level 0-3 adalah pohon kinerja pemda (tematik sampai super sub tematik),
+// level 4 ke atas adalah pohon kinerja OPD (strategic, tactical, operational, operational N)
+const (
+	LevelPohonTematik     = 0
+	LevelPohonStrategic   = 4
+	LevelPohonTactical    = 5
+	LevelPohonOperational = 6
+)
+
 func GetJenisPohon(level int) string {
 	switch level {
 	case 4:
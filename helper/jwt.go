@@ -1,9 +1,14 @@
 package helper
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
 	"ekak_kabupaten_madiun/model/web"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,17 +17,39 @@ import (
 var jwtSecretKey = []byte(os.Getenv("JWT_SECRET_KEY"))
 var jwtIssuer = os.Getenv("JWT_ISSUER")
 var jwtExpiration = os.Getenv("JWT_EXPIRATION")
+var jwtAccessExpiration = os.Getenv("JWT_ACCESS_EXPIRATION")
 
-func CreateNewJWT(userId int, pegawaiId string, email string, nip string, kodeOpd string, namaOpd string, namaPegawai string, roles []string) string {
-	exp := 24 * time.Hour
+// AccessTokenTTL masa berlaku access token, JWT_ACCESS_EXPIRATION dalam menit (default 15 menit)
+func AccessTokenTTL() time.Duration {
+	if jwtAccessExpiration != "" {
+		if minutes, err := strconv.Atoi(jwtAccessExpiration); err == nil && minutes > 0 {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return 15 * time.Minute
+}
+
+// RefreshTokenTTL masa berlaku refresh token (lama sesi login), JWT_EXPIRATION dalam jam (default 24 jam)
+func RefreshTokenTTL() time.Duration {
 	if jwtExpiration != "" {
 		if duration, err := time.ParseDuration(jwtExpiration + "h"); err == nil {
-			exp = duration
+			return duration
 		}
 	}
+	return 24 * time.Hour
+}
+
+func CreateNewJWT(userId int, pegawaiId string, email string, nip string, kodeOpd string, namaOpd string, namaPegawai string, roles []string) string {
+	return CreateAccessToken("", userId, pegawaiId, email, nip, kodeOpd, namaOpd, namaPegawai, roles)
+}
+
+// CreateAccessToken membuat access token berumur pendek yang terikat ke sesi refresh token (sid)
+func CreateAccessToken(sessionId string, userId int, pegawaiId string, email string, nip string, kodeOpd string, namaOpd string, namaPegawai string, roles []string) string {
+	exp := AccessTokenTTL()
 
 	claims := jwt.MapClaims{
 		"iss":          jwtIssuer,
+		"sid":          sessionId,
 		"user_id":      userId,
 		"pegawai_id":   pegawaiId,
 		"email":        email,
@@ -44,6 +71,22 @@ func CreateNewJWT(userId int, pegawaiId string, email string, nip string, kodeOp
 	return signedToken
 }
 
+// GenerateRefreshToken menghasilkan refresh token acak beserta hash yang disimpan di database
+func GenerateRefreshToken() (string, string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(bytes)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken refresh token tidak disimpan mentah, hanya sha256-nya
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func ValidateJWT(tokenString string) web.JWTClaim {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -114,8 +157,14 @@ func ValidateJWT(tokenString string) web.JWTClaim {
 			namaOpd = opd
 		}
 
+		sessionId := ""
+		if sid, ok := claims["sid"].(string); ok {
+			sessionId = sid
+		}
+
 		return web.JWTClaim{
 			Issuer:    issuer,
+			SessionId: sessionId,
 			UserId:    userId,
 			PegawaiId: pegawaiId,
 			KodeOpd:   kodeOpd,
@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ListDefaultPageSize = 20
+	ListMaxPageSize     = 100
+)
+
+// parameter query string yang bukan filter
+var listReservedParams = map[string]bool{"page": true, "page_size": true, "sort": true, "q": true}
+
+// ParseListQuery membaca page, page_size, sort (awalan "-" untuk descending), q dan filter dari query string.
+// Parameter lain dianggap filter, repository hanya memakai filter yang dikenalnya.
+// Tanpa page dan page_size seluruh data dikembalikan seperti perilaku lama.
+func ParseListQuery(request *http.Request) (domain.ListQuery, error) {
+	values := request.URL.Query()
+	query := domain.ListQuery{
+		Search:  strings.TrimSpace(values.Get("q")),
+		Filters: make(map[string]string),
+	}
+
+	page, pageSize := values.Get("page"), values.Get("page_size")
+	if page != "" || pageSize != "" {
+		query.Page, query.PageSize = 1, ListDefaultPageSize
+		if page != "" {
+			value, err := strconv.Atoi(page)
+			if err != nil || value < 1 {
+				return query, web.NewBadRequestError("page harus berupa angka minimal 1")
+			}
+			query.Page = value
+		}
+		if pageSize != "" {
+			value, err := strconv.Atoi(pageSize)
+			if err != nil || value < 1 {
+				return query, web.NewBadRequestError("page_size harus berupa angka minimal 1")
+			}
+			query.PageSize = min(value, ListMaxPageSize)
+		}
+	}
+
+	if sort := strings.TrimSpace(values.Get("sort")); sort != "" {
+		query.SortDesc = strings.HasPrefix(sort, "-")
+		query.Sort = strings.TrimPrefix(sort, "-")
+	}
+
+	for key, value := range values {
+		if listReservedParams[key] || len(value) == 0 || value[0] == "" {
+			continue
+		}
+		query.Filters[key] = value[0]
+	}
+	return query, nil
+}
+
+// NewPagination menyusun metadata paginasi dari query dan total data hasil filter
+func NewPagination(query domain.ListQuery, total int) *web.Pagination {
+	pagination := &web.Pagination{Page: 1, PageSize: total, TotalData: total, TotalPage: 1}
+	if query.Paginated() {
+		pagination.Page = query.Page
+		pagination.PageSize = query.PageSize
+		pagination.TotalPage = (total + query.PageSize - 1) / query.PageSize
+	}
+	return pagination
+}
@@ -0,0 +1,93 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseListQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		wantErr      bool
+		wantPage     int
+		wantPageSize int
+		wantSort     string
+		wantDesc     bool
+		wantSearch   string
+		wantFilters  map[string]string
+	}{
+		{
+			name:        "tanpa paginasi tetap membaca filter",
+			url:         "/pegawai/findall?kode_opd=1.01&nip=",
+			wantFilters: map[string]string{"kode_opd": "1.01"},
+		},
+		{
+			name:         "page tanpa page_size memakai default",
+			url:          "/pegawai/findall?page=3&sort=-nama&q=%20budi%20",
+			wantPage:     3,
+			wantPageSize: ListDefaultPageSize,
+			wantSort:     "nama",
+			wantDesc:     true,
+			wantSearch:   "budi",
+			wantFilters:  map[string]string{},
+		},
+		{
+			name:         "page_size dibatasi maksimal",
+			url:          "/pegawai/findall?page_size=1000",
+			wantPage:     1,
+			wantPageSize: ListMaxPageSize,
+			wantFilters:  map[string]string{},
+		},
+		{
+			name:    "page bukan angka",
+			url:     "/pegawai/findall?page=satu",
+			wantErr: true,
+		},
+		{
+			name:    "page_size nol",
+			url:     "/pegawai/findall?page_size=0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ParseListQuery(httptest.NewRequest("GET", tt.url, nil))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseListQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if query.Page != tt.wantPage || query.PageSize != tt.wantPageSize {
+				t.Errorf("page/page_size = %d/%d; want %d/%d", query.Page, query.PageSize, tt.wantPage, tt.wantPageSize)
+			}
+			if query.Sort != tt.wantSort || query.SortDesc != tt.wantDesc || query.Search != tt.wantSearch {
+				t.Errorf("sort/desc/q = %s/%v/%s", query.Sort, query.SortDesc, query.Search)
+			}
+			if len(query.Filters) != len(tt.wantFilters) {
+				t.Fatalf("filters = %v; want %v", query.Filters, tt.wantFilters)
+			}
+			for key, value := range tt.wantFilters {
+				if query.Filters[key] != value {
+					t.Errorf("filter %s = %s; want %s", key, query.Filters[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	query, _ := ParseListQuery(httptest.NewRequest("GET", "/program/findall?page=2&page_size=10", nil))
+	pagination := NewPagination(query, 25)
+	if pagination.Page != 2 || pagination.PageSize != 10 || pagination.TotalData != 25 || pagination.TotalPage != 3 {
+		t.Errorf("pagination = %+v", pagination)
+	}
+
+	query, _ = ParseListQuery(httptest.NewRequest("GET", "/program/findall", nil))
+	pagination = NewPagination(query, 25)
+	if pagination.Page != 1 || pagination.PageSize != 25 || pagination.TotalPage != 1 {
+		t.Errorf("pagination tanpa paginasi = %+v", pagination)
+	}
+}
@@ -0,0 +1,77 @@
+package helper
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// RequestInfo data request untuk log, dibuat access log middleware lalu
+// UserId dan KodeOpd diisi auth middleware setelah token valid
+type RequestInfo struct {
+	RequestId string
+	UserId    int
+	KodeOpd   string
+}
+
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, RequestInfoKey, info)
+}
+
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(RequestInfoKey).(*RequestInfo)
+	return info
+}
+
+// SetRequestUser mencatat user pemilik request ke RequestInfo (jika ada) agar ikut tertulis di access log
+func SetRequestUser(ctx context.Context, userId int, kodeOpd string) {
+	if info := RequestInfoFromContext(ctx); info != nil {
+		info.UserId = userId
+		info.KodeOpd = kodeOpd
+	}
+}
+
+// InitLogger memasang logger JSON sebagai default slog, output package log ikut menjadi JSON.
+// Level diatur lewat env LOG_LEVEL (debug, info, warn, error), default info.
+func InitLogger() {
+	slog.SetDefault(NewLogger(os.Stdout, parseLogLevel(os.Getenv("LOG_LEVEL"))))
+}
+
+func NewLogger(writer io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(requestContextHandler{slog.NewJSONHandler(writer, &slog.HandlerOptions{Level: level})})
+}
+
+func parseLogLevel(value string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// requestContextHandler menambahkan request_id ke setiap log yang ditulis dengan context request
+type requestContextHandler struct {
+	slog.Handler
+}
+
+func (handler requestContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if info := RequestInfoFromContext(ctx); info != nil {
+		record.AddAttrs(slog.String("request_id", info.RequestId))
+	}
+	return handler.Handler.Handle(ctx, record)
+}
+
+func (handler requestContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestContextHandler{handler.Handler.WithAttrs(attrs)}
+}
+
+func (handler requestContextHandler) WithGroup(name string) slog.Handler {
+	return requestContextHandler{handler.Handler.WithGroup(name)}
+}
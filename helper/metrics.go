@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Label hasil pembacaan cache
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ekak_http_request_duration_seconds",
+		Help:    "Latency request HTTP per route template.",
+		Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "route", "status"})
+
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ekak_cache_requests_total",
+		Help: "Jumlah pembacaan Redis cache per prefix key dan hasil (hit, miss, error).",
+	}, []string{"prefix", "result"})
+
+	jobsEnqueued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ekak_jobs_enqueued_total",
+		Help: "Jumlah job background yang masuk antrian.",
+	}, []string{"jenis"})
+
+	jobsFinished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ekak_jobs_finished_total",
+		Help: "Jumlah job background yang selesai per status akhir.",
+	}, []string{"jenis", "status"})
+
+	jobsRunning = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ekak_jobs_running",
+		Help: "Jumlah job background yang sedang dijalankan di instance ini.",
+	}, []string{"jenis"})
+)
+
+// ObserveHTTPRequest mencatat latency satu request, route berupa template httprouter agar label tidak meledak
+func ObserveHTTPRequest(method string, route string, status int, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// RecordCacheResult mencatat hit/miss cache berdasarkan prefix key (bagian sebelum ':' pertama)
+func RecordCacheResult(key string, result string) {
+	prefix, _, _ := strings.Cut(key, ":")
+	cacheRequests.WithLabelValues(prefix, result).Inc()
+}
+
+func RecordJobEnqueued(jenis string) {
+	jobsEnqueued.WithLabelValues(jenis).Inc()
+}
+
+func RecordJobFinished(jenis string, status string) {
+	jobsFinished.WithLabelValues(jenis, status).Inc()
+}
+
+// RecordJobRunning menambah (delta 1) atau mengurangi (delta -1) jumlah job yang sedang jalan
+func RecordJobRunning(jenis string, delta float64) {
+	jobsRunning.WithLabelValues(jenis).Add(delta)
+}
@@ -0,0 +1,13 @@
+package helper
+
+import "os"
+
+const defaultNamaPemda = "Kabupaten Madiun"
+
+// NamaPemda mengembalikan nama pemerintah daerah untuk kop dokumen cetak, dapat diubah lewat env NAMA_PEMDA
+func NamaPemda() string {
+	if nama := os.Getenv("NAMA_PEMDA"); nama != "" {
+		return nama
+	}
+	return defaultNamaPemda
+}
@@ -0,0 +1,339 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// ReadCacheTTL TTL default data baca berat (matrix renja, renstra, PK, rekap).
+	// Invalidasi utama lewat tag, TTL hanya pengaman untuk perubahan dari modul lain.
+	ReadCacheTTL = 5 * time.Minute
+
+	// memoryCacheMaxTTL cache memori tidak ikut diinvalidasi instance lain, jadi umurnya dibatasi
+	memoryCacheMaxTTL = time.Minute
+	// redisRetryInterval setelah Redis gagal, Redis tidak dicoba lagi selama interval ini
+	redisRetryInterval = 10 * time.Second
+	cacheTagKeyPrefix  = "cache_tag:"
+	// cacheTagExtraTTL tag set hidup sedikit lebih lama dari key di dalamnya
+	cacheTagExtraTTL = time.Minute
+)
+
+// Entitas cache, dipakai sebagai prefix key dan tag entitas
+const (
+	CacheEntityMatrixRenja       = "matrix_renja"
+	CacheEntityTujuanOpd         = "tujuan_opd"
+	CacheEntitySasaranOpd        = "sasaran_opd"
+	CacheEntityPk                = "pk_opd"
+	CacheEntityPokinPemda        = "pohon_kinerja_pemda"
+	CacheEntityRekapOutcome      = "rekap_outcome"
+	CacheEntityRekapIntermediate = "rekap_intermediate"
+)
+
+// CacheTag penanda yang dipakai untuk menghapus sekumpulan key sekaligus tanpa SCAN
+type CacheTag string
+
+func CacheTagEntity(entity string) CacheTag {
+	return CacheTag("entity:" + entity)
+}
+
+func CacheTagOpd(kodeOpd string) CacheTag {
+	return CacheTag("opd:" + kodeOpd)
+}
+
+// CacheTagOpdTahun semua data baca satu OPD di satu tahun, dipakai saat perubahan data
+// bisa mempengaruhi beberapa tampilan sekaligus (rekin → matrix renja dan PK)
+func CacheTagOpdTahun(kodeOpd string, tahun string) CacheTag {
+	return CacheTag("opd:" + kodeOpd + ":tahun:" + tahun)
+}
+
+// CacheKey key cache bertipe: Entity menjadi prefix key (label metrics), Params pembeda, Tags untuk invalidasi
+type CacheKey struct {
+	Entity string
+	Params []string
+	Tags   []CacheTag
+}
+
+// NewCacheKey key dengan tag entitas, tambahkan tag lain lewat WithTags
+func NewCacheKey(entity string, params ...string) CacheKey {
+	return CacheKey{
+		Entity: entity,
+		Params: params,
+		Tags:   []CacheTag{CacheTagEntity(entity)},
+	}
+}
+
+func (key CacheKey) WithTags(tags ...CacheTag) CacheKey {
+	key.Tags = append(append([]CacheTag{}, key.Tags...), tags...)
+	return key
+}
+
+func (key CacheKey) String() string {
+	return GenerateCacheKey(key.Entity, key.Params...)
+}
+
+// Cache read-through cache di Redis dengan tag set, singleflight per key,
+// dan cache memori sebagai cadangan saat Redis tidak bisa diakses
+type Cache struct {
+	redis  *redis.Client
+	memory *memoryCache
+	group  singleflight.Group
+
+	redisDownUntil atomic.Int64
+	// epoch naik setiap invalidasi, hasil load yang dimulai sebelum invalidasi tidak disimpan
+	epoch atomic.Uint64
+
+	pendingMu sync.Mutex
+	// pending tag yang gagal diinvalidasi di Redis, diulang saat Redis kembali
+	pending map[CacheTag]struct{}
+}
+
+func NewCache(client *redis.Client) *Cache {
+	return &Cache{
+		redis:   client,
+		memory:  newMemoryCache(),
+		pending: make(map[CacheTag]struct{}),
+	}
+}
+
+// Remember mengambil data dari cache, jika tidak ada load dipanggil sekali
+// (request lain dengan key sama menunggu hasil yang sama) lalu hasilnya disimpan.
+// Cache nil berarti cache dimatikan dan load selalu dipanggil.
+func Remember[T any](ctx context.Context, cache *Cache, key CacheKey, ttl time.Duration, load func() (T, error)) (T, error) {
+	var result T
+	if cache == nil {
+		return load()
+	}
+
+	keyString := key.String()
+	if data, ok := cache.get(ctx, keyString); ok {
+		if err := json.Unmarshal(data, &result); err == nil {
+			RecordCacheResult(keyString, CacheHit)
+			return result, nil
+		}
+		RecordCacheResult(keyString, CacheError)
+	} else {
+		RecordCacheResult(keyString, CacheMiss)
+	}
+
+	shared, err, _ := cache.group.Do(keyString, func() (interface{}, error) {
+		epoch := cache.epoch.Load()
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if cache.epoch.Load() == epoch {
+			cache.set(ctx, keyString, data, ttl, key.Tags)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// setiap pemanggil mendapat salinan sendiri agar slice/map tidak dipakai bersama
+	if err := json.Unmarshal(shared.([]byte), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Invalidate menghapus semua key yang memiliki salah satu tag
+func (cache *Cache) Invalidate(ctx context.Context, tags ...CacheTag) {
+	if cache == nil || len(tags) == 0 {
+		return
+	}
+	cache.epoch.Add(1)
+	cache.memory.invalidate(tags)
+
+	if !cache.redisAvailable() {
+		cache.addPending(tags)
+		return
+	}
+	if err := cache.invalidateRedis(ctx, tags); err != nil {
+		cache.markRedisDown(err)
+		cache.addPending(tags)
+	}
+}
+
+func (cache *Cache) get(ctx context.Context, key string) ([]byte, bool) {
+	if cache.redisAvailable() {
+		data, err := cache.redis.Get(ctx, key).Bytes()
+		if err == nil {
+			return data, true
+		}
+		if errors.Is(err, redis.Nil) {
+			return nil, false
+		}
+		cache.markRedisDown(err)
+	}
+	return cache.memory.get(key)
+}
+
+func (cache *Cache) set(ctx context.Context, key string, data []byte, ttl time.Duration, tags []CacheTag) {
+	if cache.redisAvailable() {
+		pipe := cache.redis.TxPipeline()
+		pipe.Set(ctx, key, data, ttl)
+		for _, tag := range tags {
+			tagKey := cacheTagKeyPrefix + string(tag)
+			pipe.SAdd(ctx, tagKey, key)
+			pipe.Expire(ctx, tagKey, ttl+cacheTagExtraTTL)
+		}
+		_, err := pipe.Exec(ctx)
+		if err == nil {
+			return
+		}
+		cache.markRedisDown(err)
+	}
+
+	if ttl > memoryCacheMaxTTL {
+		ttl = memoryCacheMaxTTL
+	}
+	cache.memory.set(key, data, ttl, tags)
+}
+
+func (cache *Cache) invalidateRedis(ctx context.Context, tags []CacheTag) error {
+	for _, tag := range tags {
+		tagKey := cacheTagKeyPrefix + string(tag)
+		keys, err := cache.redis.SMembers(ctx, tagKey).Result()
+		if err != nil {
+			return err
+		}
+		if err := cache.redis.Del(ctx, append(keys, tagKey)...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redisAvailable false jika Redis tidak dikonfigurasi atau baru saja gagal.
+// Saat Redis kembali, invalidasi yang tertunda dijalankan lebih dulu agar tidak ada data basi.
+func (cache *Cache) redisAvailable() bool {
+	if cache.redis == nil || time.Now().UnixNano() < cache.redisDownUntil.Load() {
+		return false
+	}
+
+	tags := cache.takePending()
+	if len(tags) == 0 {
+		return true
+	}
+	if err := cache.invalidateRedis(context.Background(), tags); err != nil {
+		cache.markRedisDown(err)
+		cache.addPending(tags)
+		return false
+	}
+	return true
+}
+
+func (cache *Cache) markRedisDown(err error) {
+	if cache.redisDownUntil.Swap(time.Now().Add(redisRetryInterval).UnixNano()) < time.Now().UnixNano() {
+		slog.Warn("redis tidak bisa diakses, cache memakai memori", "error", err, "retry_in", redisRetryInterval.String())
+	}
+}
+
+func (cache *Cache) addPending(tags []CacheTag) {
+	cache.pendingMu.Lock()
+	defer cache.pendingMu.Unlock()
+	for _, tag := range tags {
+		cache.pending[tag] = struct{}{}
+	}
+}
+
+func (cache *Cache) takePending() []CacheTag {
+	cache.pendingMu.Lock()
+	defer cache.pendingMu.Unlock()
+	if len(cache.pending) == 0 {
+		return nil
+	}
+	tags := make([]CacheTag, 0, len(cache.pending))
+	for tag := range cache.pending {
+		tags = append(tags, tag)
+	}
+	cache.pending = make(map[CacheTag]struct{})
+	return tags
+}
+
+type memoryCacheEntry struct {
+	data      []byte
+	expiresAt time.Time
+	tags      []CacheTag
+}
+
+// memoryCache penyimpanan cadangan di memori proses dengan indeks tag yang sama seperti di Redis
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	tags    map[CacheTag]map[string]struct{}
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		entries: make(map[string]memoryCacheEntry),
+		tags:    make(map[CacheTag]map[string]struct{}),
+	}
+}
+
+func (memory *memoryCache) get(key string) ([]byte, bool) {
+	memory.mu.Lock()
+	defer memory.mu.Unlock()
+	entry, ok := memory.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		memory.deleteLocked(key)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (memory *memoryCache) set(key string, data []byte, ttl time.Duration, tags []CacheTag) {
+	memory.mu.Lock()
+	defer memory.mu.Unlock()
+	memory.deleteLocked(key)
+	memory.entries[key] = memoryCacheEntry{data: data, expiresAt: time.Now().Add(ttl), tags: tags}
+	for _, tag := range tags {
+		if memory.tags[tag] == nil {
+			memory.tags[tag] = make(map[string]struct{})
+		}
+		memory.tags[tag][key] = struct{}{}
+	}
+}
+
+func (memory *memoryCache) invalidate(tags []CacheTag) {
+	memory.mu.Lock()
+	defer memory.mu.Unlock()
+	for _, tag := range tags {
+		for key := range memory.tags[tag] {
+			memory.deleteLocked(key)
+		}
+		delete(memory.tags, tag)
+	}
+}
+
+func (memory *memoryCache) deleteLocked(key string) {
+	entry, ok := memory.entries[key]
+	if !ok {
+		return
+	}
+	delete(memory.entries, key)
+	for _, tag := range entry.tags {
+		keys := memory.tags[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(memory.tags, tag)
+		}
+	}
+}
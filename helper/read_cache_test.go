@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRememberTagInvalidation(t *testing.T) {
+	ctx := context.Background()
+	// tanpa redis cache memakai memori
+	cache := NewCache(nil)
+
+	loads := 0
+	load := func() ([]string, error) {
+		loads++
+		return []string{"data"}, nil
+	}
+	keyA := NewCacheKey(CacheEntityMatrixRenja, "ranwal", "1.01", "2025").WithTags(CacheTagOpdTahun("1.01", "2025"))
+	keyB := NewCacheKey(CacheEntityMatrixRenja, "ranwal", "1.02", "2025").WithTags(CacheTagOpdTahun("1.02", "2025"))
+
+	tests := []struct {
+		name       string
+		invalidate []CacheTag
+		key        CacheKey
+		wantLoads  int
+	}{
+		{name: "miss pertama memanggil load", key: keyA, wantLoads: 1},
+		{name: "hit tidak memanggil load", key: keyA, wantLoads: 1},
+		{name: "key lain tetap miss", key: keyB, wantLoads: 2},
+		{name: "tag opd lain tidak menghapus", invalidate: []CacheTag{CacheTagOpdTahun("1.02", "2025")}, key: keyA, wantLoads: 2},
+		{name: "tag opd tahun menghapus key", invalidate: []CacheTag{CacheTagOpdTahun("1.01", "2025")}, key: keyA, wantLoads: 3},
+		{name: "tag entitas menghapus semua key", invalidate: []CacheTag{CacheTagEntity(CacheEntityMatrixRenja)}, key: keyB, wantLoads: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache.Invalidate(ctx, tt.invalidate...)
+			got, err := Remember(ctx, cache, tt.key, time.Minute, load)
+			if err != nil {
+				t.Fatalf("Remember() error = %v", err)
+			}
+			if len(got) != 1 || got[0] != "data" {
+				t.Errorf("Remember() = %v", got)
+			}
+			if loads != tt.wantLoads {
+				t.Errorf("load dipanggil %d kali, want %d", loads, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestRememberSingleflight(t *testing.T) {
+	cache := NewCache(nil)
+	key := NewCacheKey(CacheEntityPk, "1.01", "2025")
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = Remember(context.Background(), cache, key, time.Minute, load)
+		}(i)
+	}
+	// beri waktu semua goroutine masuk ke singleflight sebelum load selesai
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Errorf("load dipanggil %d kali, want 1", got)
+	}
+	for i, result := range results {
+		if result != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, result)
+		}
+	}
+}
+
+func TestRememberErrorNotCached(t *testing.T) {
+	cache := NewCache(nil)
+	key := NewCacheKey(CacheEntityRekapOutcome, "2025")
+
+	if _, err := Remember(context.Background(), cache, key, time.Minute, func() (int, error) {
+		return 0, errors.New("db error")
+	}); err == nil {
+		t.Fatal("error load harus diteruskan")
+	}
+	got, err := Remember(context.Background(), cache, key, time.Minute, func() (int, error) {
+		return 7, nil
+	})
+	if err != nil || got != 7 {
+		t.Errorf("Remember() = %d, %v, want 7", got, err)
+	}
+}
+
+func TestRememberNilCache(t *testing.T) {
+	loads := 0
+	for i := 0; i < 2; i++ {
+		if _, err := Remember(context.Background(), nil, NewCacheKey(CacheEntityPk), time.Minute, func() (int, error) {
+			loads++
+			return loads, nil
+		}); err != nil {
+			t.Fatalf("Remember() error = %v", err)
+		}
+	}
+	if loads != 2 {
+		t.Errorf("cache nil harus selalu memanggil load, dipanggil %d kali", loads)
+	}
+}
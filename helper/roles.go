@@ -0,0 +1,26 @@
+package helper
+
+// Nama role sesuai isi tb_role (lihat dataseeder/role_seeder_impl.go)
+const (
+	RoleSuperAdmin     = "super_admin"
+	RoleAdminOpd       = "admin_opd"
+	RoleAdminKecamatan = "admin_kecamatan"
+	RoleEselon1        = "eselon_1"
+	RoleEselon2        = "eselon_2"
+	RoleEselon3        = "eselon_3"
+	RoleEselon4        = "eselon_4"
+	RoleStaff          = "staff"
+	RoleReviewer       = "reviewer"
+)
+
+// HasAnyRole mengecek apakah salah satu role user ada di daftar role yang diizinkan
+func HasAnyRole(userRoles []string, allowedRoles ...string) bool {
+	for _, userRole := range userRoles {
+		for _, allowed := range allowedRoles {
+			if userRole == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
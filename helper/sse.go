@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SSEWriter menulis response text/event-stream (Server-Sent Events) dan flush setiap event
+type SSEWriter struct {
+	writer  http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewSSEWriter menulis header event stream, error jika ResponseWriter tidak mendukung flush
+func NewSSEWriter(writer http.ResponseWriter) (*SSEWriter, error) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		return nil, errors.New("response writer tidak mendukung streaming")
+	}
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	// nginx tidak menahan response stream di buffer
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &SSEWriter{writer: writer, flusher: flusher}, nil
+}
+
+// Event mengirim satu event dengan data JSON. id dipakai browser sebagai Last-Event-ID saat reconnect.
+func (sse *SSEWriter) Event(id string, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := sse.writer.Write([]byte(FormatSSEEvent(id, event, string(payload)))); err != nil {
+		return err
+	}
+	sse.flusher.Flush()
+	return nil
+}
+
+// Comment mengirim baris komentar, dipakai sebagai heartbeat agar proxy tidak menutup koneksi idle
+func (sse *SSEWriter) Comment(text string) error {
+	if _, err := fmt.Fprintf(sse.writer, ": %s\n\n", text); err != nil {
+		return err
+	}
+	sse.flusher.Flush()
+	return nil
+}
+
+// FormatSSEEvent menyusun satu event SSE, data multi baris dipecah menjadi beberapa baris data:
+func FormatSSEEvent(id string, event string, data string) string {
+	var builder strings.Builder
+	if id != "" {
+		builder.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		builder.WriteString("event: " + event + "\n")
+	}
+	for _, line := range strings.Split(data, "\n") {
+		builder.WriteString("data: " + line + "\n")
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
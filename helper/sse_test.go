@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormatSSEEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		event string
+		data  string
+		want  string
+	}{
+		{name: "lengkap", id: "12", event: "notifikasi", data: `{"id":12}`, want: "id: 12\nevent: notifikasi\ndata: {\"id\":12}\n\n"},
+		{name: "tanpa id dan event", data: "halo", want: "data: halo\n\n"},
+		{name: "data multi baris", event: "pesan", data: "a\nb", want: "event: pesan\ndata: a\ndata: b\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSSEEvent(tt.id, tt.event, tt.data); got != tt.want {
+				t.Errorf("FormatSSEEvent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSEWriter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	sse, err := NewSSEWriter(recorder)
+	if err != nil {
+		t.Fatalf("NewSSEWriter() error = %v", err)
+	}
+	if err := sse.Comment("ping"); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+	if err := sse.Event("1", "notifikasi", map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Event() error = %v", err)
+	}
+
+	if got := recorder.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	want := ": ping\n\nid: 1\nevent: notifikasi\ndata: {\"id\":1}\n\n"
+	if got := recorder.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+	if !recorder.Flushed {
+		t.Error("response tidak di-flush")
+	}
+}
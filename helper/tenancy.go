@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web"
+)
+
+const pesanAksesOpdDitolak = "Anda tidak memiliki akses ke data OPD lain"
+
+// CanAccessAllOpd hanya super_admin yang boleh mengakses data lintas OPD
+func CanAccessAllOpd(claims web.JWTClaim) bool {
+	return HasAnyRole(claims.Roles, RoleSuperAdmin)
+}
+
+// IsKodeOpdAllowed membandingkan kode_opd data dengan kode_opd di token
+func IsKodeOpdAllowed(claims web.JWTClaim, kodeOpd string) bool {
+	if CanAccessAllOpd(claims) {
+		return true
+	}
+	return kodeOpd != "" && kodeOpd == claims.KodeOpd
+}
+
+// ValidateKodeOpdAccess dipakai service untuk memeriksa kode_opd dari body request
+// atau kode_opd pemilik data yang diambil berdasarkan id.
+// Context tanpa claims (proses internal / background) tidak dibatasi.
+func ValidateKodeOpdAccess(ctx context.Context, kodeOpd string) error {
+	claims, ok := ctx.Value(UserInfoKey).(web.JWTClaim)
+	if !ok {
+		return nil
+	}
+	if !IsKodeOpdAllowed(claims, kodeOpd) {
+		return web.NewForbiddenError(pesanAksesOpdDitolak)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Access token bersifat stateless, jadi pencabutannya dicatat di Redis selama umur access token.
+// Jika Redis tidak tersedia, access token tetap berlaku sampai kedaluwarsa (maksimal AccessTokenTTL),
+// sedangkan refresh token di MySQL tetap dicabut sehingga sesi tidak bisa diperpanjang.
+const (
+	CacheKeyRevokedUser    = "auth:revoked_user"
+	CacheKeyRevokedSession = "auth:revoked_session"
+)
+
+// RevokeUserAccessTokens menandai semua access token user yang terbit sebelum saat ini sebagai tidak berlaku
+func RevokeUserAccessTokens(ctx context.Context, client *redis.Client, userId int) error {
+	if client == nil {
+		return nil
+	}
+
+	key := GenerateCacheKey(CacheKeyRevokedUser, strconv.Itoa(userId))
+	err := client.Set(ctx, key, time.Now().Unix(), AccessTokenTTL()).Err()
+	if err != nil {
+		return fmt.Errorf("error revoke access token user: %w", err)
+	}
+	return nil
+}
+
+// RevokeSessionAccessTokens menandai access token dari satu sesi login (sid) sebagai tidak berlaku
+func RevokeSessionAccessTokens(ctx context.Context, client *redis.Client, sessionId string) error {
+	if client == nil || sessionId == "" {
+		return nil
+	}
+
+	key := GenerateCacheKey(CacheKeyRevokedSession, sessionId)
+	err := client.Set(ctx, key, time.Now().Unix(), AccessTokenTTL()).Err()
+	if err != nil {
+		return fmt.Errorf("error revoke access token sesi: %w", err)
+	}
+	return nil
+}
+
+// IsAccessTokenRevoked mengecek apakah access token sudah dicabut lewat logout atau perubahan user
+func IsAccessTokenRevoked(ctx context.Context, client *redis.Client, claims web.JWTClaim) bool {
+	if client == nil {
+		return false
+	}
+
+	if claims.SessionId != "" {
+		exists, err := client.Exists(ctx, GenerateCacheKey(CacheKeyRevokedSession, claims.SessionId)).Result()
+		if err == nil && exists > 0 {
+			return true
+		}
+	}
+
+	revokedAt, err := client.Get(ctx, GenerateCacheKey(CacheKeyRevokedUser, strconv.Itoa(claims.UserId))).Int64()
+	if err != nil {
+		// redis.Nil berarti tidak ada pencabutan, error lain diabaikan agar login tetap jalan
+		return false
+	}
+	return claims.Iat < revokedAt
+}
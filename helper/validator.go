@@ -1,8 +1,15 @@
 package helper
 
 import (
+	"ekak_kabupaten_madiun/model/web"
 	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
 )
 
 func ValidateTahunRange(awalStr, akhirStr string) (int, int, error) {
@@ -23,3 +30,154 @@ func ValidateTahunRange(awalStr, akhirStr string) (int, int, error) {
 
 	return awal, akhir, nil
 }
+
+var tahunPattern = regexp.MustCompile(`^\d{4}$`)
+
+// requestValidator dipakai ValidateRequest, aturannya sama dengan validator yang diinject lewat wire
+var requestValidator = NewValidator()
+
+// NewValidator membuat validator dengan nama field diambil dari tag json serta aturan domain:
+//   - tahun: string 4 digit angka atau int 1000-9999
+//   - nip: NIP PNS 18 digit (IsValidNIP), hanya untuk data baru yang wajib berisi NIP PNS
+//   - nip_pegawai: nip pegawai/akun termasuk akun non-PNS seperti admin1 (IsValidNIPPegawai), juga dipakai
+//     nip_kepala_opd dan nip_kepala_pemda karena data lama di kolom tersebut belum tentu NIP 18 digit
+//   - level_pohon: level pohon kinerja, level_pohon=pemda untuk level 0-3 dan level_pohon=opd untuk level 4 ke atas
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	PanicIfError(validate.RegisterValidation("tahun", validateTahun))
+	PanicIfError(validate.RegisterValidation("nip", func(fl validator.FieldLevel) bool {
+		return IsValidNIP(fl.Field().String())
+	}))
+	PanicIfError(validate.RegisterValidation("nip_pegawai", func(fl validator.FieldLevel) bool {
+		return IsValidNIPPegawai(fl.Field().String())
+	}))
+	PanicIfError(validate.RegisterValidation("level_pohon", validateLevelPohon))
+
+	return validate
+}
+
+func validateTahun(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.String:
+		return tahunPattern.MatchString(fl.Field().String())
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		tahun := fl.Field().Int()
+		return tahun >= 1000 && tahun <= 9999
+	default:
+		return false
+	}
+}
+
+func validateLevelPohon(fl validator.FieldLevel) bool {
+	level := int(fl.Field().Int())
+	switch fl.Param() {
+	case "pemda":
+		return level >= LevelPohonTematik && level < LevelPohonStrategic
+	case "opd":
+		return level >= LevelPohonStrategic
+	default:
+		return level >= LevelPohonTematik
+	}
+}
+
+// ValidateRequest memvalidasi struct request memakai tag validate dan mengembalikan
+// *web.CustomError berisi pesan per field jika ada yang tidak valid
+func ValidateRequest(request interface{}) error {
+	return ValidationError(requestValidator.Struct(request))
+}
+
+// ValidationError mengubah validator.ValidationErrors menjadi *web.CustomError dengan pesan per field,
+// error lain dikembalikan apa adanya
+func ValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	fieldErrors := make([]web.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		field := fieldError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		fieldErrors = append(fieldErrors, web.FieldError{
+			Field:   field,
+			Message: validationMessage(field, fieldError),
+		})
+	}
+	return web.NewFieldValidationError(fieldErrors)
+}
+
+func validationMessage(field string, fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	kind := fieldError.Kind()
+	isLength := kind == reflect.String || kind == reflect.Slice || kind == reflect.Map || kind == reflect.Array
+	satuan := "karakter"
+	if kind != reflect.String {
+		satuan = "data"
+	}
+
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s wajib diisi", field)
+	case "min":
+		if isLength {
+			return fmt.Sprintf("%s minimal %s %s", field, param, satuan)
+		}
+		return fmt.Sprintf("%s minimal %s", field, param)
+	case "max":
+		if isLength {
+			return fmt.Sprintf("%s maksimal %s %s", field, param, satuan)
+		}
+		return fmt.Sprintf("%s maksimal %s", field, param)
+	case "len":
+		return fmt.Sprintf("%s harus %s %s", field, param, satuan)
+	case "gt":
+		return fmt.Sprintf("%s harus lebih dari %s", field, param)
+	case "gte":
+		return fmt.Sprintf("%s minimal %s", field, param)
+	case "lt":
+		return fmt.Sprintf("%s harus kurang dari %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s maksimal %s", field, param)
+	case "numeric", "number":
+		return fmt.Sprintf("%s harus berupa angka", field)
+	case "email":
+		return fmt.Sprintf("%s harus berupa alamat email yang valid", field)
+	case "oneof":
+		return fmt.Sprintf("%s harus salah satu dari: %s", field, strings.Join(strings.Fields(param), ", "))
+	case "tahun":
+		return fmt.Sprintf("%s harus berupa tahun 4 digit", field)
+	case "nip":
+		return fmt.Sprintf("%s harus berupa NIP 18 digit angka", field)
+	case "nip_pegawai":
+		return fmt.Sprintf("%s hanya boleh berisi huruf, angka, titik, garis bawah atau tanda hubung tanpa spasi", field)
+	case "level_pohon":
+		switch param {
+		case "pemda":
+			return fmt.Sprintf("%s untuk pohon kinerja pemda harus antara %d dan %d", field, LevelPohonTematik, LevelPohonStrategic-1)
+		case "opd":
+			return fmt.Sprintf("%s untuk pohon kinerja OPD minimal %d", field, LevelPohonStrategic)
+		default:
+			return fmt.Sprintf("%s tidak boleh negatif", field)
+		}
+	default:
+		return fmt.Sprintf("%s tidak valid", field)
+	}
+}
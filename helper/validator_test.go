@@ -0,0 +1,122 @@
+package helper
+
+import (
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/pegawai"
+	"testing"
+)
+
+type indikatorTestRequest struct {
+	Indikator string `json:"indikator" validate:"required"`
+	Tahun     string `json:"tahun" validate:"required,tahun"`
+}
+
+type pohonTestRequest struct {
+	NamaPohon  string                 `json:"nama_pohon" validate:"required"`
+	LevelPohon int                    `json:"level_pohon" validate:"level_pohon=opd"`
+	Nip        string                 `json:"nip" validate:"omitempty,nip"`
+	Indikator  []indikatorTestRequest `json:"indikator" validate:"dive"`
+}
+
+func TestValidateRequest(t *testing.T) {
+	valid := pohonTestRequest{
+		NamaPohon:  "Meningkatnya kualitas layanan",
+		LevelPohon: LevelPohonStrategic,
+		Nip:        "199001012020011001",
+		Indikator:  []indikatorTestRequest{{Indikator: "Indeks kepuasan", Tahun: "2025"}},
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(request *pohonTestRequest)
+		field   string
+		message string
+	}{
+		{
+			name:    "field wajib kosong",
+			mutate:  func(request *pohonTestRequest) { request.NamaPohon = "" },
+			field:   "nama_pohon",
+			message: "nama_pohon wajib diisi",
+		},
+		{
+			name:    "level pohon pemda pada pohon opd",
+			mutate:  func(request *pohonTestRequest) { request.LevelPohon = 2 },
+			field:   "level_pohon",
+			message: "level_pohon untuk pohon kinerja OPD minimal 4",
+		},
+		{
+			name:    "nip 16 digit",
+			mutate:  func(request *pohonTestRequest) { request.Nip = "1990010120200110" },
+			field:   "nip",
+			message: "nip harus berupa NIP 18 digit angka",
+		},
+		{
+			name:    "tahun bukan 4 digit pada indikator",
+			mutate:  func(request *pohonTestRequest) { request.Indikator[0].Tahun = "25" },
+			field:   "indikator[0].tahun",
+			message: "indikator[0].tahun harus berupa tahun 4 digit",
+		},
+	}
+
+	if err := ValidateRequest(valid); err != nil {
+		t.Fatalf("request valid error = %v; want nil", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := valid
+			request.Indikator = append([]indikatorTestRequest(nil), valid.Indikator...)
+			tt.mutate(&request)
+
+			err := ValidateRequest(request)
+			customErr, ok := err.(*web.CustomError)
+			if !ok {
+				t.Fatalf("error = %v; want *web.CustomError", err)
+			}
+			if customErr.Code != 400 {
+				t.Errorf("code = %d; want 400", customErr.Code)
+			}
+			if len(customErr.Errors) != 1 {
+				t.Fatalf("jumlah error = %d; want 1 (%v)", len(customErr.Errors), customErr.Errors)
+			}
+			if customErr.Errors[0].Field != tt.field {
+				t.Errorf("field = %q; want %q", customErr.Errors[0].Field, tt.field)
+			}
+			if customErr.Errors[0].Message != tt.message {
+				t.Errorf("message = %q; want %q", customErr.Errors[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestValidateRequestNipPegawai(t *testing.T) {
+	tests := []struct {
+		name  string
+		nip   string
+		valid bool
+	}{
+		{name: "nip PNS 18 digit", nip: "199001012020011001", valid: true},
+		{name: "akun super admin dari seeder", nip: "admin1", valid: true},
+		{name: "akun admin OPD dari seeder", nip: "admin_dindik", valid: true},
+		{name: "mengandung spasi", nip: "admin dindik", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := pegawai.PegawaiUpdateRequest{
+				Id:          "PEG-001",
+				NamaPegawai: "admin dindik",
+				Nip:         tt.nip,
+				KodeOpd:     "1.01.2.22.0.00.01.0000",
+			}
+
+			err := ValidateRequest(request)
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateRequest(%q) = %v; want nil", tt.nip, err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatalf("ValidateRequest(%q) = nil; want error", tt.nip)
+			}
+		})
+	}
+}
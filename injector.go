@@ -7,12 +7,12 @@ import (
 	"ekak_kabupaten_madiun/app"
 	"ekak_kabupaten_madiun/controller"
 	"ekak_kabupaten_madiun/dataseeder"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/middleware"
 	"ekak_kabupaten_madiun/repository"
 	"ekak_kabupaten_madiun/service"
 	"net/http"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"github.com/julienschmidt/httprouter"
 )
@@ -235,6 +235,8 @@ var roleSet = wire.NewSet(
 var userSet = wire.NewSet(
 	repository.NewUserRepositoryImpl,
 	wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)),
+	repository.NewRefreshTokenRepositoryImpl,
+	wire.Bind(new(repository.RefreshTokenRepository), new(*repository.RefreshTokenRepositoryImpl)),
 	service.NewUserServiceImpl,
 	wire.Bind(new(service.UserService), new(*service.UserServiceImpl)),
 	controller.NewUserControllerImpl,
@@ -250,6 +252,10 @@ var seederProviderSet = wire.NewSet(
 	wire.Bind(new(dataseeder.UserSeeder), new(*dataseeder.UserSeederImpl)),
 	dataseeder.NewPegawaiSeederImpl,
 	wire.Bind(new(dataseeder.PegawaiSeeder), new(*dataseeder.PegawaiSeederImpl)),
+	dataseeder.NewOpdSeederImpl,
+	wire.Bind(new(dataseeder.OpdSeeder), new(*dataseeder.OpdSeederImpl)),
+	dataseeder.NewPeriodeSeederImpl,
+	wire.Bind(new(dataseeder.PeriodeSeeder), new(*dataseeder.PeriodeSeederImpl)),
 )
 
 var tujuanOpdSet = wire.NewSet(
@@ -444,18 +450,104 @@ var cloneRecordSet = wire.NewSet(
 	wire.Bind(new(repository.CloneRecordRepository), new(*repository.CloneRecordRepositoryImpl)),
 )
 
-var lockDataRepository = wire.NewSet(
+var lockDataSet = wire.NewSet(
 	repository.NewLockDataRepositoryImpl,
 	wire.Bind(new(repository.LockDataRepository), new(*repository.LockDataRepositoryImpl)),
-)
-
-func InitializeServer() *http.Server {
+	service.NewLockDataServiceImpl,
+	wire.Bind(new(service.LockDataService), new(*service.LockDataServiceImpl)),
+	controller.NewLockDataControllerImpl,
+	wire.Bind(new(controller.LockDataController), new(*controller.LockDataControllerImpl)),
+)
+
+var auditLogSet = wire.NewSet(
+	repository.NewAuditLogRepositoryImpl,
+	wire.Bind(new(repository.AuditLogRepository), new(*repository.AuditLogRepositoryImpl)),
+	service.NewAuditLogServiceImpl,
+	wire.Bind(new(service.AuditLogService), new(*service.AuditLogServiceImpl)),
+	controller.NewAuditLogControllerImpl,
+	wire.Bind(new(controller.AuditLogController), new(*controller.AuditLogControllerImpl)),
+)
+
+var nomenklaturSet = wire.NewSet(
+	repository.NewNomenklaturRepositoryImpl,
+	wire.Bind(new(repository.NomenklaturRepository), new(*repository.NomenklaturRepositoryImpl)),
+	service.NewNomenklaturServiceImpl,
+	wire.Bind(new(service.NomenklaturService), new(*service.NomenklaturServiceImpl)),
+	controller.NewNomenklaturControllerImpl,
+	wire.Bind(new(controller.NomenklaturController), new(*controller.NomenklaturControllerImpl)),
+)
+
+var jobSet = wire.NewSet(
+	repository.NewJobRepositoryImpl,
+	wire.Bind(new(repository.JobRepository), new(*repository.JobRepositoryImpl)),
+	service.NewJobServiceImpl,
+	wire.Bind(new(service.JobService), new(*service.JobServiceImpl)),
+	wire.Bind(new(service.JobRunner), new(*service.JobServiceImpl)),
+	controller.NewJobControllerImpl,
+	wire.Bind(new(controller.JobController), new(*controller.JobControllerImpl)),
+)
+
+var realisasiSet = wire.NewSet(
+	repository.NewRealisasiRepositoryImpl,
+	wire.Bind(new(repository.RealisasiRepository), new(*repository.RealisasiRepositoryImpl)),
+	service.NewRealisasiServiceImpl,
+	wire.Bind(new(service.RealisasiService), new(*service.RealisasiServiceImpl)),
+	controller.NewRealisasiControllerImpl,
+	wire.Bind(new(controller.RealisasiController), new(*controller.RealisasiControllerImpl)),
+)
+
+var realisasiAnggaranSet = wire.NewSet(
+	repository.NewRealisasiAnggaranRepositoryImpl,
+	wire.Bind(new(repository.RealisasiAnggaranRepository), new(*repository.RealisasiAnggaranRepositoryImpl)),
+	service.NewRealisasiAnggaranServiceImpl,
+	wire.Bind(new(service.RealisasiAnggaranService), new(*service.RealisasiAnggaranServiceImpl)),
+	controller.NewRealisasiAnggaranControllerImpl,
+	wire.Bind(new(controller.RealisasiAnggaranController), new(*controller.RealisasiAnggaranControllerImpl)),
+)
+
+var persetujuanRekinSet = wire.NewSet(
+	repository.NewPersetujuanRekinRepositoryImpl,
+	wire.Bind(new(repository.PersetujuanRekinRepository), new(*repository.PersetujuanRekinRepositoryImpl)),
+	service.NewPersetujuanRekinServiceImpl,
+	wire.Bind(new(service.PersetujuanRekinService), new(*service.PersetujuanRekinServiceImpl)),
+	controller.NewPersetujuanRekinControllerImpl,
+	wire.Bind(new(controller.PersetujuanRekinController), new(*controller.PersetujuanRekinControllerImpl)),
+)
+
+var notifikasiSet = wire.NewSet(
+	repository.NewNotifikasiRepositoryImpl,
+	wire.Bind(new(repository.NotifikasiRepository), new(*repository.NotifikasiRepositoryImpl)),
+	service.NewNotifikasiServiceImpl,
+	wire.Bind(new(service.NotifikasiService), new(*service.NotifikasiServiceImpl)),
+	controller.NewNotifikasiControllerImpl,
+	wire.Bind(new(controller.NotifikasiController), new(*controller.NotifikasiControllerImpl)),
+)
+
+var rollForwardSet = wire.NewSet(
+	repository.NewRollForwardRepositoryImpl,
+	wire.Bind(new(repository.RollForwardRepository), new(*repository.RollForwardRepositoryImpl)),
+	service.NewRollForwardServiceImpl,
+	wire.Bind(new(service.RollForwardService), new(*service.RollForwardServiceImpl)),
+	controller.NewRollForwardControllerImpl,
+	wire.Bind(new(controller.RollForwardController), new(*controller.RollForwardControllerImpl)),
+)
+
+var healthSet = wire.NewSet(
+	service.NewHealthServiceImpl,
+	wire.Bind(new(service.HealthService), new(*service.HealthServiceImpl)),
+	controller.NewHealthControllerImpl,
+	wire.Bind(new(controller.HealthController), new(*controller.HealthControllerImpl)),
+	controller.NewMetricsControllerImpl,
+	wire.Bind(new(controller.MetricsController), new(*controller.MetricsControllerImpl)),
+)
+
+func InitializeServer() *Server {
 
 	wire.Build(
 		app.GetConnection,
 		app.GetRedisClient,
-		wire.Value([]validator.Option{}),
-		validator.New,
+		helper.NewCache,
+		helper.NewValidator,
 		rencanaKinerjaSet,
 		rencanaAksiSet,
 		pelaksanaanRencanaAksiSet,
@@ -504,7 +596,16 @@ func InitializeServer() *http.Server {
 		strukturOrganisasiSet,
 		jabatanPegawaiSet,
 		cloneRecordSet,
-		lockDataRepository,
+		lockDataSet,
+		auditLogSet,
+		nomenklaturSet,
+		jobSet,
+		rollForwardSet,
+		realisasiSet,
+		realisasiAnggaranSet,
+		persetujuanRekinSet,
+		notifikasiSet,
+		healthSet,
 		app.NewRouter,
 		wire.Bind(new(http.Handler), new(*httprouter.Router)),
 		middleware.NewAuthMiddleware,
@@ -514,12 +615,16 @@ func InitializeServer() *http.Server {
 	return nil
 }
 
-func InitializeSeeder() dataseeder.Seeder {
+func InitializeSeeder(config dataseeder.SeedConfig) dataseeder.Seeder {
 	wire.Build(
 		app.GetConnection,
 		roleSet,
 		userSet,
 		pegawaiSet,
+		repository.NewOpdRepositoryImpl,
+		wire.Bind(new(repository.OpdRepository), new(*repository.OpdRepositoryImpl)),
+		repository.NewPeriodeRepositoryImpl,
+		wire.Bind(new(repository.PeriodeRepository), new(*repository.PeriodeRepositoryImpl)),
 		seederProviderSet,
 	)
 	return nil
@@ -0,0 +1,99 @@
+//go:build integration
+
+package main
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// kirimCrosscutting Dinkes mengirim crosscutting dari pokin tactical ke Bappeda, mengembalikan id tb_crosscutting
+func kirimCrosscutting(t *testing.T, h *integrationHarness, keterangan string) int {
+	t.Helper()
+	token := h.token(helper.RoleAdminOpd, fixtureKodeOpdDinkes, fixtureNipDinkes)
+
+	var dikirim pohonkinerja.CrosscuttingDikirimResponse
+	h.mustOk(t, http.MethodPost, fmt.Sprintf("/crosscutting_opd/create/%d", fixturePokinDinkesTactical), pohonkinerja.CrosscuttingOpdCreateRequest{
+		ParentId:   fixturePokinDinkesTactical,
+		NamaPohon:  "Tersedianya Data Perencanaan Kesehatan Ibu",
+		JenisPohon: "Tactical",
+		LevelPohon: 5,
+		KodeOpd:    fixtureKodeOpdBappeda,
+		Keterangan: keterangan,
+		Tahun:      fixtureTahun,
+		Status:     "crosscutting_menunggu",
+	}, token, &dikirim)
+	if dikirim.IdCrosscutting == 0 {
+		t.Fatal("id crosscutting kosong")
+	}
+	if dikirim.KodeOpdTujuan != fixtureKodeOpdBappeda {
+		t.Errorf("kode opd tujuan = %s, want %s", dikirim.KodeOpdTujuan, fixtureKodeOpdBappeda)
+	}
+	return dikirim.IdCrosscutting
+}
+
+func TestIntegrationCrosscuttingApproveReject(t *testing.T) {
+	h := requireHarness(t)
+	bappeda := h.token(helper.RoleAdminOpd, fixtureKodeOpdBappeda, fixtureNipBappeda)
+
+	tests := []struct {
+		name       string
+		request    pohonkinerja.CrosscuttingApproveRequest
+		wantStatus string
+		wantPokin  int
+	}{
+		{
+			name: "disetujui dengan pohon baru",
+			request: pohonkinerja.CrosscuttingApproveRequest{
+				Approve:    true,
+				CreateNew:  true,
+				ParentId:   fixturePokinBappedaStrategic,
+				LevelPohon: 5,
+				JenisPohon: "Tactical",
+				NipPegawai: fixtureNipBappeda,
+			},
+			wantStatus: "crosscutting_disetujui",
+			wantPokin:  1,
+		},
+		{
+			name: "ditolak",
+			request: pohonkinerja.CrosscuttingApproveRequest{
+				Approve:    false,
+				NipPegawai: fixtureNipBappeda,
+			},
+			wantStatus: "crosscutting_ditolak",
+			wantPokin:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crosscuttingId := kirimCrosscutting(t, h, tt.name)
+
+			var response pohonkinerja.CrosscuttingApproveResponse
+			h.mustOk(t, http.MethodPost, fmt.Sprintf("/crosscutting/%d/permission", crosscuttingId), tt.request, bappeda, &response)
+			if response.Status != tt.wantStatus {
+				t.Errorf("status response = %s, want %s", response.Status, tt.wantStatus)
+			}
+			if got := h.count(t, "SELECT COUNT(*) FROM tb_crosscutting WHERE id = ? AND status = ?", crosscuttingId, tt.wantStatus); got != 1 {
+				t.Errorf("status tb_crosscutting bukan %s", tt.wantStatus)
+			}
+			got := h.count(t, `
+				SELECT COUNT(*) FROM tb_pohon_kinerja pk
+				JOIN tb_crosscutting cc ON cc.crosscutting_to = pk.id
+				WHERE cc.id = ? AND pk.kode_opd = ? AND pk.parent = ? AND pk.status = 'crosscutting_disetujui'`,
+				crosscuttingId, fixtureKodeOpdBappeda, fixturePokinBappedaStrategic)
+			if got != tt.wantPokin {
+				t.Errorf("pohon kinerja bappeda dari crosscutting = %d, want %d", got, tt.wantPokin)
+			}
+		})
+	}
+
+	var daftar []pohonkinerja.CrosscuttingOpdResponse
+	h.mustOk(t, http.MethodGet, fmt.Sprintf("/crosscutting_opd/findall/%d", fixturePokinDinkesTactical), nil, bappeda, &daftar)
+	if len(daftar) < len(tests) {
+		t.Errorf("crosscutting dari pokin %d = %d, want minimal %d", fixturePokinDinkesTactical, len(daftar), len(tests))
+	}
+}
@@ -0,0 +1,336 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/app"
+	"ekak_kabupaten_madiun/db"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/job"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Test integrasi menjalankan aplikasi lengkap (InitializeServer) terhadap database MySQL sekali pakai
+// yang schema-nya dibuat dari migrasi embed (db.Migrations).
+//
+//	go test -tags integration -run Integration ./...
+//
+// Server MySQL diambil dari TEST_MYSQL_DSN (tanpa nama database, contoh "root:secret@tcp(127.0.0.1:3306)/").
+// Jika kosong dan docker tersedia, container TEST_MYSQL_IMAGE (default mysql:8.0) dijalankan lalu dihapus setelah test.
+// Jika keduanya tidak tersedia seluruh test integrasi di-skip.
+
+const (
+	fixtureFile     = "testdata/integration/fixtures.sql"
+	mysqlReadyLimit = 2 * time.Minute
+	jobWaitLimit    = time.Minute
+)
+
+// data fixture, lihat testdata/integration/fixtures.sql
+const (
+	fixtureTahun          = "2025"
+	fixtureKodeOpdDinkes  = "1.02.0.00.0.00.01.0000"
+	fixtureKodeOpdBappeda = "5.01.5.05.0.00.01.0000"
+	fixturePegawaiDinkes  = "PEG-IT-002"
+	fixtureNipDinkes      = "198505052010012004"
+	fixtureNipBappeda     = "197202022000031002"
+
+	fixturePokinPemdaSuperSubTematik = 104
+	fixturePokinDinkesStrategic      = 201
+	fixturePokinDinkesTactical       = 202
+	fixturePokinBappedaStrategic     = 301
+	fixtureJumlahRekinDinkes         = 2
+)
+
+type integrationHarness struct {
+	DB      *sql.DB
+	Handler http.Handler
+
+	server   *Server
+	rootDB   *sql.DB
+	dbName   string
+	shutdown []func()
+}
+
+var (
+	harness *integrationHarness
+	// harnessSkip alasan test integrasi di-skip (tidak ada MySQL)
+	harnessSkip string
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	harness, err = newIntegrationHarness()
+	if err != nil {
+		if harness == nil && harnessSkip != "" {
+			log.Printf("test integrasi di-skip: %s", harnessSkip)
+		} else {
+			log.Printf("gagal menyiapkan test integrasi: %v", err)
+			if harness != nil {
+				harness.close()
+			}
+			os.Exit(1)
+		}
+	}
+
+	code := m.Run()
+	if harness != nil {
+		harness.close()
+	}
+	os.Exit(code)
+}
+
+// requireHarness harness yang sudah siap, test di-skip jika MySQL tidak tersedia
+func requireHarness(t *testing.T) *integrationHarness {
+	t.Helper()
+	if harness == nil {
+		t.Skip(harnessSkip)
+	}
+	return harness
+}
+
+func newIntegrationHarness() (*integrationHarness, error) {
+	h := &integrationHarness{}
+
+	serverDsn, err := h.startMysql()
+	if err != nil {
+		return h, err
+	}
+	if serverDsn == "" {
+		return nil, errors.New(harnessSkip)
+	}
+
+	config, err := mysql.ParseDSN(serverDsn)
+	if err != nil {
+		return h, fmt.Errorf("TEST_MYSQL_DSN tidak valid: %w", err)
+	}
+	config.ParseTime = true
+	config.MultiStatements = true
+
+	h.rootDB, err = sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		return h, err
+	}
+	if err := waitMysql(h.rootDB); err != nil {
+		return h, err
+	}
+
+	h.dbName = fmt.Sprintf("ekak_it_%d", time.Now().UnixNano())
+	if _, err := h.rootDB.Exec("CREATE DATABASE " + h.dbName); err != nil {
+		return h, fmt.Errorf("gagal membuat database %s: %w", h.dbName, err)
+	}
+	h.shutdown = append(h.shutdown, func() {
+		if _, err := h.rootDB.Exec("DROP DATABASE IF EXISTS " + h.dbName); err != nil {
+			log.Printf("gagal menghapus database %s: %v", h.dbName, err)
+		}
+	})
+
+	config.DBName = h.dbName
+	h.DB, err = sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		return h, err
+	}
+	// migrasi dijalankan dengan migrator yang sama dengan -migrate up
+	migrator, err := app.NewMigrator(h.DB, db.Migrations, io.Discard)
+	if err != nil {
+		return h, err
+	}
+	if err := migrator.Up(context.Background(), false); err != nil {
+		return h, err
+	}
+	if err := execSqlFile(h.DB, fixtureFile); err != nil {
+		return h, err
+	}
+
+	// aplikasi dibangun dari wiring yang sama dengan main, koneksi diarahkan ke database test
+	config.MultiStatements = false
+	os.Setenv("DB_URL", config.FormatDSN())
+	h.server = InitializeServer()
+	h.Handler = h.server.Handler
+	h.server.jobRunner.Start()
+	return h, nil
+}
+
+// startMysql DSN server MySQL dari TEST_MYSQL_DSN atau container docker baru.
+// DSN kosong tanpa error berarti MySQL tidak tersedia dan test di-skip.
+func (h *integrationHarness) startMysql() (string, error) {
+	if dsn := os.Getenv("TEST_MYSQL_DSN"); dsn != "" {
+		return dsn, nil
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		harnessSkip = "TEST_MYSQL_DSN kosong dan docker tidak tersedia"
+		return "", nil
+	}
+
+	image := os.Getenv("TEST_MYSQL_IMAGE")
+	if image == "" {
+		image = "mysql:8.0"
+	}
+	output, err := exec.Command("docker", "run", "-d", "--rm",
+		"-e", "MYSQL_ROOT_PASSWORD=ekak",
+		"-p", "127.0.0.1::3306",
+		image,
+	).Output()
+	if err != nil {
+		harnessSkip = fmt.Sprintf("gagal menjalankan container %s: %v", image, err)
+		return "", nil
+	}
+	containerId := strings.TrimSpace(string(output))
+	h.shutdown = append(h.shutdown, func() {
+		exec.Command("docker", "rm", "-f", containerId).Run()
+	})
+
+	output, err = exec.Command("docker", "port", containerId, "3306/tcp").Output()
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca port container mysql: %w", err)
+	}
+	// docker port bisa mengembalikan beberapa baris (ipv4/ipv6), pakai yang pertama
+	address := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	return fmt.Sprintf("root:ekak@tcp(%s)/", address), nil
+}
+
+func waitMysql(db *sql.DB) error {
+	deadline := time.Now().Add(mysqlReadyLimit)
+	for {
+		err := db.Ping()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("mysql tidak siap dalam %s: %w", mysqlReadyLimit, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func execSqlFile(db *sql.DB, file string) error {
+	script, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(script)) == "" {
+		return nil
+	}
+	if _, err := db.Exec(string(script)); err != nil {
+		return fmt.Errorf("gagal menjalankan %s: %w", file, err)
+	}
+	return nil
+}
+
+func (h *integrationHarness) close() {
+	if h.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		h.server.jobRunner.Stop(ctx)
+		cancel()
+	}
+	if h.DB != nil {
+		h.DB.Close()
+	}
+	// dijalankan terbalik: database dihapus sebelum container dimatikan
+	for i := len(h.shutdown) - 1; i >= 0; i-- {
+		h.shutdown[i]()
+	}
+	if h.rootDB != nil {
+		h.rootDB.Close()
+	}
+}
+
+// token access token untuk pegawai fixture dengan role tertentu
+func (h *integrationHarness) token(role string, kodeOpd string, nip string) string {
+	return helper.CreateNewJWT(1, nip, nip+"@madiunkab.go.id", nip, kodeOpd, "", "", []string{role})
+}
+
+type integrationResponse struct {
+	StatusCode int
+	Code       int             `json:"code"`
+	Status     string          `json:"status"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// do mengirim request melalui seluruh middleware (access log, CORS, auth) ke router
+func (h *integrationHarness) do(t *testing.T, method string, path string, body interface{}, token string) integrationResponse {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal body %s %s: %v", method, path, err)
+		}
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	request := httptest.NewRequest(method, path, reader)
+	request.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	h.Handler.ServeHTTP(recorder, request)
+
+	response := integrationResponse{StatusCode: recorder.Code}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s %s: response bukan JSON (%d): %s", method, path, recorder.Code, recorder.Body.String())
+	}
+	return response
+}
+
+// mustOk request yang harus berhasil (code 2xx), data di-decode ke dest jika tidak nil
+func (h *integrationHarness) mustOk(t *testing.T, method string, path string, body interface{}, token string, dest interface{}) {
+	t.Helper()
+	response := h.do(t, method, path, body, token)
+	if response.Code < 200 || response.Code >= 300 {
+		t.Fatalf("%s %s: code %d (%s): %s", method, path, response.Code, response.Status, string(response.Data))
+	}
+	if dest != nil {
+		if err := json.Unmarshal(response.Data, dest); err != nil {
+			t.Fatalf("%s %s: decode data: %v", method, path, err)
+		}
+	}
+}
+
+// waitJob menunggu job background selesai (DONE, DONE_WITH_WARNING, FAILED atau CANCELLED)
+func (h *integrationHarness) waitJob(t *testing.T, jobId int64, token string) job.JobResponse {
+	t.Helper()
+	deadline := time.Now().Add(jobWaitLimit)
+	for {
+		var current job.JobResponse
+		h.mustOk(t, http.MethodGet, fmt.Sprintf("/jobs/%d", jobId), nil, token, &current)
+		switch current.Status {
+		case domain.JobStatusDone, domain.JobStatusDoneWithWarning, domain.JobStatusFailed, domain.JobStatusCancelled:
+			return current
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %d belum selesai setelah %s, status %s", jobId, jobWaitLimit, current.Status)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// count hasil query COUNT(*) langsung ke database test
+func (h *integrationHarness) count(t *testing.T, query string, args ...interface{}) int {
+	t.Helper()
+	var total int
+	if err := h.DB.QueryRow(query, args...).Scan(&total); err != nil {
+		t.Fatalf("query %q: %v", query, err)
+	}
+	return total
+}
@@ -0,0 +1,98 @@
+//go:build integration
+
+package main
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIntegrationPohonKinerjaOpdCrud(t *testing.T) {
+	h := requireHarness(t)
+	token := h.token(helper.RoleAdminOpd, fixtureKodeOpdDinkes, fixtureNipDinkes)
+
+	var created pohonkinerja.PohonKinerjaOpdResponse
+	h.mustOk(t, http.MethodPost, "/pohon_kinerja_opd/create", pohonkinerja.PohonKinerjaCreateRequest{
+		Parent:     fixturePokinDinkesStrategic,
+		NamaPohon:  "Meningkatnya Cakupan Imunisasi Dasar Lengkap",
+		JenisPohon: "Tactical",
+		LevelPohon: 5,
+		KodeOpd:    fixtureKodeOpdDinkes,
+		Tahun:      fixtureTahun,
+		PelaksanaId: []pohonkinerja.PelaksanaCreateRequest{
+			{PegawaiId: fixturePegawaiDinkes},
+		},
+		Indikator: []pohonkinerja.IndikatorCreateRequest{
+			{
+				NamaIndikator: "Persentase bayi dengan imunisasi dasar lengkap",
+				Target:        []pohonkinerja.TargetCreateRequest{{Target: "95", Satuan: "%"}},
+			},
+		},
+	}, token, &created)
+	if created.Id == 0 {
+		t.Fatal("id pohon kinerja baru kosong")
+	}
+	detailPath := fmt.Sprintf("/pohon_kinerja_opd/detail/%d", created.Id)
+
+	var detail pohonkinerja.PohonKinerjaOpdResponse
+	h.mustOk(t, http.MethodGet, detailPath, nil, token, &detail)
+	if detail.NamaPohon != "Meningkatnya Cakupan Imunisasi Dasar Lengkap" || detail.LevelPohon != 5 {
+		t.Errorf("detail = %+v", detail)
+	}
+
+	h.mustOk(t, http.MethodPut, fmt.Sprintf("/pohon_kinerja_opd/update/%d", created.Id), pohonkinerja.PohonKinerjaUpdateRequest{
+		Id:          created.Id,
+		Parent:      fixturePokinDinkesStrategic,
+		NamaPohon:   "Meningkatnya Cakupan Imunisasi Rutin Lengkap",
+		JenisPohon:  "Tactical",
+		LevelPohon:  5,
+		KodeOpd:     fixtureKodeOpdDinkes,
+		Tahun:       fixtureTahun,
+		UpdatedBy:   fixtureNipDinkes,
+		PelaksanaId: []pohonkinerja.PelaksanaUpdateRequest{{PegawaiId: fixturePegawaiDinkes}},
+	}, token, nil)
+	if got := h.count(t, "SELECT COUNT(*) FROM tb_pohon_kinerja WHERE id = ? AND nama_pohon = ?", created.Id, "Meningkatnya Cakupan Imunisasi Rutin Lengkap"); got != 1 {
+		t.Errorf("nama pohon tidak berubah setelah update")
+	}
+
+	h.mustOk(t, http.MethodGet, fmt.Sprintf("/pohon_kinerja_opd/findall/%s/%s", fixtureKodeOpdDinkes, fixtureTahun), nil, token, nil)
+
+	h.mustOk(t, http.MethodDelete, fmt.Sprintf("/pohon_kinerja_opd/delete/%d", created.Id), nil, token, nil)
+	if got := h.count(t, "SELECT COUNT(*) FROM tb_pohon_kinerja WHERE id = ?", created.Id); got != 0 {
+		t.Errorf("pohon kinerja %d masih ada setelah delete", created.Id)
+	}
+	if response := h.do(t, http.MethodGet, detailPath, nil, token); response.Code == http.StatusOK {
+		t.Errorf("detail pohon kinerja yang sudah dihapus masih ditemukan")
+	}
+}
+
+func TestIntegrationPohonKinerjaOpdAkses(t *testing.T) {
+	h := requireHarness(t)
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		{name: "tanpa token", token: "", wantCode: http.StatusUnauthorized},
+		{name: "admin opd lain", token: h.token(helper.RoleAdminOpd, fixtureKodeOpdBappeda, fixtureNipBappeda), wantCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := h.do(t, http.MethodPost, "/pohon_kinerja_opd/create", pohonkinerja.PohonKinerjaCreateRequest{
+				Parent:     fixturePokinDinkesStrategic,
+				NamaPohon:  "Pohon kinerja dari OPD lain",
+				JenisPohon: "Tactical",
+				LevelPohon: 5,
+				KodeOpd:    fixtureKodeOpdDinkes,
+				Tahun:      fixtureTahun,
+			}, tt.token)
+			if response.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d (%s)", response.Code, tt.wantCode, string(response.Data))
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+//go:build integration
+
+package main
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/job"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestIntegrationCloneRencanaKinerjaByKodeOpd(t *testing.T) {
+	h := requireHarness(t)
+	token := h.token(helper.RoleAdminOpd, fixtureKodeOpdDinkes, fixtureNipDinkes)
+	request := rencanakinerja.RekinByOpdCloneRequest{
+		KodeOpd:     fixtureKodeOpdDinkes,
+		TahunSumber: fixtureTahun,
+		TahunTujuan: "2026",
+		UpdatedBy:   fixtureNipDinkes,
+	}
+
+	response := h.do(t, http.MethodPost, "/rencana_kinerja/clone_by_kode_opd", request, token)
+	if response.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d: %s", response.StatusCode, http.StatusAccepted, string(response.Data))
+	}
+	var queued job.JobResponse
+	if err := json.Unmarshal(response.Data, &queued); err != nil || queued.Id == 0 {
+		t.Fatalf("response bukan job: %s", string(response.Data))
+	}
+
+	finished := h.waitJob(t, queued.Id, token)
+	if finished.Status != domain.JobStatusDone && finished.Status != domain.JobStatusDoneWithWarning {
+		t.Fatalf("job selesai dengan status %s: %s", finished.Status, finished.ErrorMessage)
+	}
+	if got := h.count(t, "SELECT COUNT(*) FROM tb_rencana_kinerja WHERE kode_opd = ? AND tahun = ?", fixtureKodeOpdDinkes, "2026"); got != fixtureJumlahRekinDinkes {
+		t.Errorf("rekin hasil clone = %d, want %d", got, fixtureJumlahRekinDinkes)
+	}
+
+	// clone kedua untuk pasangan tahun yang sama ditolak
+	if response := h.do(t, http.MethodPost, "/rencana_kinerja/clone_by_kode_opd", request, token); response.StatusCode != http.StatusConflict {
+		t.Errorf("clone ulang status = %d, want %d", response.StatusCode, http.StatusConflict)
+	}
+}
@@ -11,20 +11,45 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/dataseeder"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/middleware"
+	"ekak_kabupaten_madiun/service"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	_ "ekak_kabupaten_madiun/docs"
 
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 )
 
-func NewServer(authMiddleware *middleware.AuthMiddleware) *http.Server {
+// shutdownTimeout batas waktu menunggu request dan job background selesai saat server berhenti
+const shutdownTimeout = 30 * time.Second
+
+// defaultShutdownDrainDelay jeda antara /readyz mulai mengembalikan 503 dan server berhenti menerima koneksi
+const defaultShutdownDrainDelay = 5 * time.Second
+
+// Server http.Server beserta resource yang harus ditutup saat shutdown
+type Server struct {
+	*http.Server
+	jobRunner     service.JobRunner
+	healthService service.HealthService
+	db            *sql.DB
+	redisClient   *redis.Client
+}
+
+func NewServer(authMiddleware *middleware.AuthMiddleware, jobRunner service.JobRunner, healthService service.HealthService, notifikasiService service.NotifikasiService, db *sql.DB, redisClient *redis.Client) *Server {
 	host := os.Getenv("host")
 	port := os.Getenv("port")
 	addr := fmt.Sprintf("%s:%s", host, port)
@@ -35,31 +60,141 @@ func NewServer(authMiddleware *middleware.AuthMiddleware) *http.Server {
 		addr = "localhost:8080"
 	}
 
-	return &http.Server{
+	httpServer := &http.Server{
 		Addr:    addr,
-		Handler: cors.Handler(authMiddleware),
+		Handler: middleware.NewAccessLogMiddleware(cors.Handler(authMiddleware)),
+	}
+	// stream notifikasi tidak pernah selesai sendiri, tanpa ini Shutdown menunggu sampai timeout
+	httpServer.RegisterOnShutdown(notifikasiService.TutupStream)
+
+	return &Server{
+		Server:        httpServer,
+		jobRunner:     jobRunner,
+		healthService: healthService,
+		db:            db,
+		redisClient:   redisClient,
 	}
 }
 
+// Run menjalankan server dan job runner sampai menerima SIGINT/SIGTERM,
+// lalu menunggu request yang sedang diproses dan job background selesai sebelum keluar
+func (server *Server) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server.jobRunner.Start()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		server.jobRunner.Stop(context.Background())
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.Println("Sinyal berhenti diterima, menunggu request dan job yang sedang berjalan...")
+	server.healthService.SetShuttingDown()
+
+	// beri waktu load balancer membaca /readyz 503 dan berhenti mengirim request baru sebelum listener ditutup
+	if delay := shutdownDrainDelay(); delay > 0 {
+		log.Printf("Menunggu %s agar load balancer berhenti mengirim request", delay)
+		time.Sleep(delay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Gagal menunggu request selesai: %v", err)
+	}
+	server.jobRunner.Stop(shutdownCtx)
+
+	if closeErr := server.db.Close(); closeErr != nil {
+		log.Printf("Gagal menutup koneksi database: %v", closeErr)
+	}
+	if closeErr := server.redisClient.Close(); closeErr != nil {
+		log.Printf("Gagal menutup koneksi redis: %v", closeErr)
+	}
+	log.Println("Server berhenti")
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
 func main() {
 	runSeeder := flag.Bool("seed", false, "Jalankan database seeder")
+	seedProfile := flag.String("seed-profile", "", "Profil data seeder: dev, demo, prod (wajib, atau lewat SEED_PROFILE)")
+	seedDir := flag.String("seed-dir", os.Getenv("SEED_DIR"), "Direktori data seeder, kosong berarti data bawaan")
+	migrateCommand := flag.String("migrate", "", "Jalankan migrasi schema: up, down, status, version")
+	dryRun := flag.Bool("dry-run", false, "Tampilkan statement migrasi tanpa menjalankannya")
 	flag.Parse()
 	// Load environment variables
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatal(err)
 	}
+	helper.InitLogger()
 	// Cek flag seeder
 	if *runSeeder {
+		profile, err := resolveSeedProfile(*seedProfile)
+		if err != nil {
+			log.Fatal(err)
+		}
 		log.Println("Menjalankan database seeder...")
-		seeder := InitializeSeeder()
+		seeder := InitializeSeeder(dataseeder.SeedConfig{
+			Profile: profile,
+			Dir:     *seedDir,
+			Output:  os.Stdout,
+		})
 		seeder.SeedAll()
 		log.Println("Seeder selesai dijalankan")
 		return
 	}
+	if *migrateCommand != "" {
+		if err := runMigration(*migrateCommand, *dryRun); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	// Initialize dan jalankan server
 	server := InitializeServer()
+	if err := checkSchema(server); err != nil {
+		log.Fatal(err)
+	}
 	log.Printf("Server berjalan di %s", server.Addr)
-	err = server.ListenAndServe()
+	err = server.Run()
 	helper.PanicIfError(err)
 }
+
+// shutdownDrainDelay SHUTDOWN_DRAIN_DELAY dalam detik (default 5 detik, 0 untuk langsung berhenti)
+func shutdownDrainDelay() time.Duration {
+	if value := os.Getenv("SHUTDOWN_DRAIN_DELAY"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultShutdownDrainDelay
+}
+
+// resolveSeedProfile profil seeder harus dipilih eksplisit lewat -seed-profile atau SEED_PROFILE,
+// dan profil dev (password default) ditolak jika APP_ENV=production
+func resolveSeedProfile(flagValue string) (string, error) {
+	profile := flagValue
+	if profile == "" {
+		profile = os.Getenv("SEED_PROFILE")
+	}
+	if profile == "" {
+		return "", errors.New("profil seeder wajib diisi lewat -seed-profile atau SEED_PROFILE (dev, demo, prod)")
+	}
+	if profile == "dev" && os.Getenv("APP_ENV") == "production" {
+		return "", errors.New("profil seeder dev tidak boleh dijalankan saat APP_ENV=production")
+	}
+	return profile, nil
+}
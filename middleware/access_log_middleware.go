@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const requestIdHeader = "X-Request-Id"
+
+// route probe yang tidak perlu ditulis ke access log selama sukses
+var accessLogSkipRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// AccessLogMiddleware middleware terluar: memberi request id, menulis access log JSON
+// dan mencatat latency per route template ke metrics
+type AccessLogMiddleware struct {
+	Handler http.Handler
+}
+
+func NewAccessLogMiddleware(handler http.Handler) *AccessLogMiddleware {
+	return &AccessLogMiddleware{Handler: handler}
+}
+
+func (middleware *AccessLogMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	start := time.Now()
+
+	requestId := request.Header.Get(requestIdHeader)
+	if requestId == "" || len(requestId) > 64 {
+		requestId = uuid.NewString()
+	}
+	writer.Header().Set(requestIdHeader, requestId)
+
+	info := &helper.RequestInfo{RequestId: requestId}
+	request = request.WithContext(helper.WithRequestInfo(request.Context(), info))
+
+	recorder := &statusRecorder{ResponseWriter: writer, status: http.StatusOK}
+	middleware.Handler.ServeHTTP(recorder, request)
+
+	duration := time.Since(start)
+	route := routeTemplate(request.Method, request.URL.Path)
+	helper.ObserveHTTPRequest(request.Method, route, recorder.status, duration)
+
+	if accessLogSkipRoutes[route] && recorder.status < http.StatusInternalServerError {
+		return
+	}
+
+	level := slog.LevelInfo
+	if recorder.status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	slog.LogAttrs(request.Context(), level, "request",
+		slog.String("method", request.Method),
+		slog.String("route", route),
+		slog.String("path", request.URL.Path),
+		slog.Int("status", recorder.status),
+		slog.Int("bytes", recorder.bytes),
+		slog.Float64("latency_ms", float64(duration.Microseconds())/1000),
+		slog.Int("user_id", info.UserId),
+		slog.String("kode_opd", info.KodeOpd),
+		slog.String("remote_addr", request.RemoteAddr),
+	)
+}
+
+// routeTemplate template httprouter untuk request, dipakai sebagai label metrics agar id di path tidak membuat label baru
+func routeTemplate(method string, path string) string {
+	if permission, found := FindRoutePermission(method, path); found {
+		return permission.Path
+	}
+	return "unmatched"
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.status = status
+		recorder.wroteHeader = true
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(data []byte) (int, error) {
+	recorder.wroteHeader = true
+	n, err := recorder.ResponseWriter.Write(data)
+	recorder.bytes += n
+	return n, err
+}
+
+// Flush diteruskan agar response streaming tetap berjalan di balik middleware
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
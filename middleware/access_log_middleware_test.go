@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/helper"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	var buffer bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(helper.NewLogger(&buffer, slog.LevelInfo))
+	defer slog.SetDefault(previous)
+
+	handler := NewAccessLogMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		// diisi auth middleware setelah token valid
+		helper.SetRequestUser(request.Context(), 7, "1.01.2.22.0.00.01.0000")
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+
+	request := httptest.NewRequest(http.MethodDelete, "/pohon_kinerja_admin/delete/123", nil)
+	request.Header.Set(requestIdHeader, "req-abc")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if got := recorder.Header().Get(requestIdHeader); got != "req-abc" {
+		t.Errorf("header %s = %q, want req-abc", requestIdHeader, got)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &entry); err != nil {
+		t.Fatalf("access log bukan JSON: %v (%s)", err, buffer.String())
+	}
+	want := map[string]interface{}{
+		"request_id": "req-abc",
+		"route":      "/pohon_kinerja_admin/delete/:pohonKinerjaId",
+		"status":     float64(http.StatusNotFound),
+		"user_id":    float64(7),
+		"kode_opd":   "1.01.2.22.0.00.01.0000",
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("log[%s] = %v, want %v", key, entry[key], value)
+		}
+	}
+}
+
+func TestAccessLogMiddlewareSkipsProbe(t *testing.T) {
+	var buffer bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(helper.NewLogger(&buffer, slog.LevelInfo))
+	defer slog.SetDefault(previous)
+
+	handler := NewAccessLogMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if buffer.Len() != 0 {
+		t.Errorf("probe /healthz tidak boleh ditulis ke access log: %s", buffer.String())
+	}
+	if recorder.Header().Get(requestIdHeader) == "" {
+		t.Error("request id tidak dibuat")
+	}
+}
@@ -6,14 +6,17 @@ import (
 	"ekak_kabupaten_madiun/model/web"
 	"net/http"
 	"strings"
+
+	"github.com/redis/go-redis/v9"
 )
 
 type AuthMiddleware struct {
-	Handler http.Handler
+	Handler     http.Handler
+	RedisClient *redis.Client
 }
 
-func NewAuthMiddleware(handler http.Handler) *AuthMiddleware {
-	return &AuthMiddleware{Handler: handler}
+func NewAuthMiddleware(handler http.Handler, redisClient *redis.Client) *AuthMiddleware {
+	return &AuthMiddleware{Handler: handler, RedisClient: redisClient}
 }
 
 func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
@@ -22,6 +25,7 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 		pattern string
 	}{
 		{"/user/login", "^/user/login$"},
+		{"/user/refresh", "^/user/refresh$"},
 		{"/swagger/*", "^/swagger/.*$"},
 		{"/api/pokin_opd/findall/", "^/api/pokin_opd/findall/[^/]+/[^/]+$"},
 		{"/api/pokin_pemda/subtematik/", "^/api/pokin_pemda/subtematik/[^/]+$"},
@@ -31,6 +35,9 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 		{"/tujuan_opd/penetapan", "^/tujuan_opd/penetapan$"},
 		{"/sasaran_opd/penetapan", "^/sasaran_opd/penetapan$"},
 		{"/matrix_renja/penetapan", "^/matrix_renja/penetapan$"},
+		{"/healthz", "^/healthz$"},
+		{"/readyz", "^/readyz$"},
+		{"/metrics", "^/metrics$"},
 	}
 
 	currentPath := request.URL.Path
@@ -42,6 +49,10 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 	}
 
 	tokenString := request.Header.Get("Authorization")
+	// EventSource browser tidak dapat mengirim header, token stream notifikasi boleh lewat query string
+	if tokenString == "" && currentPath == "/notifikasi/stream" {
+		tokenString = request.URL.Query().Get("access_token")
+	}
 	if tokenString == "" {
 		writer.Header().Set("Content-Type", "application/json")
 		writer.WriteHeader(http.StatusUnauthorized)
@@ -59,7 +70,7 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
 	claims := helper.ValidateJWT(tokenString)
-	if claims.UserId == 0 {
+	if claims.UserId == 0 || helper.IsAccessTokenRevoked(request.Context(), middleware.RedisClient, claims) {
 		writer.Header().Set("Content-Type", "application/json")
 		writer.WriteHeader(http.StatusUnauthorized)
 
@@ -73,8 +84,58 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 		return
 	}
 
+	permission, found := FindRoutePermission(request.Method, currentPath)
+	if found && !permission.IsAllowed(claims.Roles) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusForbidden)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusForbidden,
+			Status: "FORBIDDEN",
+			Data:   "Anda tidak memiliki akses ke resource ini",
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return
+	}
+
+	if !isKodeOpdRequestAllowed(request, permission, claims) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusForbidden)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusForbidden,
+			Status: "FORBIDDEN",
+			Data:   "Anda tidak memiliki akses ke data OPD lain",
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return
+	}
+
+	helper.SetRequestUser(request.Context(), claims.UserId, claims.KodeOpd)
 	ctx := context.WithValue(request.Context(), helper.UserInfoKey, claims)
 	request = request.WithContext(ctx)
 
 	middleware.Handler.ServeHTTP(writer, request)
 }
+
+// isKodeOpdRequestAllowed mengecek kode_opd pada path (:kode_opd) dan query string (?kode_opd=)
+// terhadap kode_opd di token. Pengecekan kode_opd di body dan data berdasarkan id dilakukan di service.
+func isKodeOpdRequestAllowed(request *http.Request, permission RoutePermission, claims web.JWTClaim) bool {
+	if helper.CanAccessAllOpd(claims) {
+		return true
+	}
+
+	kodeOpdPath := permission.PathParam(request.URL.Path, "kode_opd")
+	if kodeOpdPath != "" && kodeOpdPath != claims.KodeOpd {
+		return false
+	}
+
+	kodeOpdQuery := request.URL.Query().Get("kode_opd")
+	if kodeOpdQuery != "" && kodeOpdQuery != claims.KodeOpd {
+		return false
+	}
+
+	return true
+}
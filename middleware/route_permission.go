@@ -0,0 +1,648 @@
+package middleware
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"net/http"
+	"strings"
+)
+
+// RoutePermission memetakan route (method + template path httprouter) ke role yang diizinkan.
+// Roles kosong berarti route cukup membutuhkan token yang valid.
+type RoutePermission struct {
+	Method string
+	Path   string
+	Roles  []string
+}
+
+var (
+	semuaRole       []string
+	hanyaSuperAdmin = []string{helper.RoleSuperAdmin}
+	adminOpd        = []string{helper.RoleSuperAdmin, helper.RoleAdminOpd}
+	reviewerPokin   = []string{helper.RoleSuperAdmin, helper.RoleReviewer}
+)
+
+// RoutePermissions wajib memuat setiap route yang didaftarkan di app.NewRouter
+// (dicek oleh route_permission_test.go)
+var RoutePermissions = []RoutePermission{
+	{http.MethodGet, "/swagger/*any", semuaRole},
+	//rencana_kinerja
+	{http.MethodPost, "/rencana_kinerja/create", semuaRole},
+	{http.MethodGet, "/get_rencana_kinerja/pegawai/:pegawai_id", semuaRole},
+	{http.MethodGet, "/detail-rencana_kinerja/:rencana_kinerja_id", semuaRole},
+	{http.MethodPut, "/rencana_kinerja/update/:id", semuaRole},
+	{http.MethodDelete, "/rencana_kinerja/delete/:id", semuaRole},
+	{http.MethodGet, "/rencana_kinerja_pokin/pokin_by_pelaksana/:pegawai_id/:tahun", semuaRole},
+	{http.MethodPost, "/rencana_kinerja/create_level1", semuaRole},
+	{http.MethodPut, "/rencana_kinerja/update_level1/:id", semuaRole},
+	{http.MethodGet, "/rencana_kinerja_level1/:id", semuaRole},
+	{http.MethodGet, "/rencana_kinerja_level3/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/rencana_kinerja_opd/findall", semuaRole},
+
+	//rencana_aksi
+	{http.MethodGet, "/rencana_aksi/findall/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/detail-rencana_aksi/:rencanaaksiId", semuaRole},
+	{http.MethodPost, "/rencana_aksi/create/rencanaaksi/:rekin_id", semuaRole},
+	{http.MethodPut, "/rencana_aksi/update/rencanaaksi/:rencanaaksiId", semuaRole},
+	{http.MethodDelete, "/rencana_aksi/delete/rencanaaksi/:rencanaaksiId", semuaRole},
+
+	//pelaksanaan_rencana_aksi
+	{http.MethodPost, "/pelaksanaan_rencana_aksi/create/:rencanaAksiId", semuaRole},
+	{http.MethodPut, "/pelaksanaan_rencana_aksi/update/:pelaksanaanRencanaAksiId", semuaRole},
+	{http.MethodGet, "/pelaksanaan_rencana_aksi/detail/:id", semuaRole},
+	{http.MethodDelete, "/pelaksanaan_rencana_aksi/delete/:id", semuaRole},
+
+	//usulan musrebang
+	{http.MethodPost, "/usulan_musrebang/create", semuaRole},
+	{http.MethodPut, "/usulan_musrebang/update/:id", semuaRole},
+	{http.MethodPut, "/usulan_musrebang/update/:id/:pegawai_id", semuaRole},
+	{http.MethodGet, "/usulan_musrebang/detail/:id", semuaRole},
+	{http.MethodDelete, "/usulan_musrebang/delete/:id", semuaRole},
+	{http.MethodGet, "/usulan_musrebang/pilihan", semuaRole},
+	{http.MethodGet, "/usulan_musrebang/findall", semuaRole},
+	{http.MethodGet, "/usulan_musrebang/opd/:kode_opd", semuaRole},
+	{http.MethodPost, "/usulan_musrebang/create_rekin/:rencana_kinerja_id", semuaRole},
+	{http.MethodDelete, "/usulan_musrebang/delete_usulan_terpilih/:id", semuaRole},
+
+	//usulan mandatori
+	{http.MethodPost, "/usulan_mandatori/create", semuaRole},
+	{http.MethodPost, "/usulan_mandatori/create/:pegawai_id", semuaRole},
+	{http.MethodPut, "/usulan_mandatori/update/:id", semuaRole},
+	{http.MethodPut, "/usulan_mandatori/update/:id/:pegawai_id", semuaRole},
+	{http.MethodGet, "/usulan_mandatori/detail/:id", semuaRole},
+	{http.MethodDelete, "/usulan_mandatori/delete/:id", semuaRole},
+	{http.MethodGet, "/usulan_mandatori/findall", semuaRole},
+	{http.MethodGet, "/usulan_mandatori/pilihan", semuaRole},
+	{http.MethodGet, "/usulan_mandatori/pegawai/:pegawai_id", semuaRole},
+
+	//usulan pokok pikiran
+	{http.MethodPost, "/usulan_pokok_pikiran/create", semuaRole},
+	{http.MethodPost, "/usulan_pokok_pikiran/create/:pegawai_id", semuaRole},
+	{http.MethodPut, "/usulan_pokok_pikiran/update/:id", semuaRole},
+	{http.MethodPut, "/usulan_pokok_pikiran/update/:id/:pegawai_id", semuaRole},
+	{http.MethodGet, "/usulan_pokok_pikiran/detail/:id", semuaRole},
+	{http.MethodDelete, "/usulan_pokok_pikiran/delete/:id", semuaRole},
+	{http.MethodGet, "/usulan_pokok_pikiran/findall", semuaRole},
+	{http.MethodGet, "/usulan_pokok_pikiran/pilihan", semuaRole},
+	{http.MethodGet, "/usulan_pokok_pikiran/opd/:kode_opd", semuaRole},
+	{http.MethodPost, "/usulan_pokok_pikiran/create_rekin/:rencana_kinerja_id", semuaRole},
+	{http.MethodDelete, "/usulan_pokok_pikiran/delete_usulan_terpilih/:id", semuaRole},
+
+	//usulan inisiatif
+	{http.MethodPost, "/usulan_inisiatif/create", semuaRole},
+	{http.MethodPost, "/usulan_inisiatif/create/:pegawai_id", semuaRole},
+	{http.MethodPut, "/usulan_inisiatif/update/:id", semuaRole},
+	{http.MethodPut, "/usulan_inisiatif/update/:id/:pegawai_id", semuaRole},
+	{http.MethodGet, "/usulan_inisiatif/detail/:id", semuaRole},
+	{http.MethodDelete, "/usulan_inisiatif/delete/:id", semuaRole},
+	{http.MethodGet, "/usulan_inisiatif/findall", semuaRole},
+	{http.MethodGet, "/usulan_inisiatif/pilihan", semuaRole},
+	{http.MethodGet, "/usulan_inisiatif/pegawai/:pegawai_id", semuaRole},
+
+	//gambaran umum
+	{http.MethodPost, "/gambaran_umum/create/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/gambaran_umum/findall/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/gambaran_umum/detail/:id", semuaRole},
+	{http.MethodPut, "/gambaran_umum/update/:id", semuaRole},
+	{http.MethodDelete, "/gambaran_umum/delete/:id", semuaRole},
+
+	//dasar hukum
+	{http.MethodPost, "/dasar_hukum/create/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/dasar_hukum/findall/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/dasar_hukum/detail/:id", semuaRole},
+	{http.MethodPut, "/dasar_hukum/update/:id", semuaRole},
+	{http.MethodDelete, "/dasar_hukum/delete/:id", semuaRole},
+
+	//inovasi
+	{http.MethodPost, "/inovasi/create/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/inovasi/findall/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/inovasi/detail/:id", semuaRole},
+	{http.MethodPut, "/inovasi/update/:id", semuaRole},
+	{http.MethodDelete, "/inovasi/delete/:id", semuaRole},
+
+	//sub kegiatan
+	{http.MethodPost, "/sub_kegiatan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/sub_kegiatan/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/sub_kegiatan/detail/:id", semuaRole},
+	{http.MethodGet, "/sub_kegiatan/findall", semuaRole},
+	{http.MethodGet, "/sub_kegiatan/pilihan/:kode_opd", semuaRole},
+	{http.MethodGet, "/sub_kegiatan/byrekinid/:rencana_kinerja_id", semuaRole},
+	{http.MethodDelete, "/sub_kegiatan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/sub_kegiatan/kak/:kode_opd/:kode_subkegiatan/:tahun", semuaRole},
+
+	//sub kegiatan terpilih
+	{http.MethodPost, "/sub_kegiatan/create_rekin/:rencana_kinerja_id", semuaRole},
+	{http.MethodDelete, "/sub_kegiatan/delete_subkegiatan_terpilih/:id", semuaRole},
+	{http.MethodPut, "/subkegiatanterpilih/create/:rencana_kinerja_id", semuaRole},
+	{http.MethodDelete, "/subkegiatanterpilih/delete/:rencana_kinerja_id/:kode_subkegiatan", semuaRole},
+	{http.MethodGet, "/subkegiatanterpilih/findbykodesubkegiatan/:kode_subkegiatan", semuaRole},
+
+	//pohon kinerja opd
+	{http.MethodPost, "/pohon_kinerja_opd/create", adminOpd},
+	{http.MethodPut, "/pohon_kinerja_opd/update/:id", adminOpd},
+	{http.MethodGet, "/pohon_kinerja_opd/detail/:id", semuaRole},
+	{http.MethodDelete, "/pohon_kinerja_opd/delete/:id", adminOpd},
+	{http.MethodGet, "/pohon_kinerja_opd/findall/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja_opd/strategic_no_parent/:kode_opd/:tahun", semuaRole},
+	{http.MethodDelete, "/pohon_kinerja_opd/delete_pelaksana/:id", adminOpd},
+	{http.MethodDelete, "/pohon_kinerja_opd/delete_pokin_pemda/:id", adminOpd},
+	{http.MethodPut, "/pohon_kinerja_opd/pindah_parent/:id", adminOpd},
+	{http.MethodGet, "/pohon_kinerja_opd/pokin_clone_pokin_opd_statistik/:kode_opd/:tahun/:level_pohon", semuaRole},
+	{http.MethodPut, "/pohon_kinerja_opd/update_parent_clone/:id", adminOpd},
+
+	// strategic arah kebijakan opd
+	{http.MethodGet, "/strategi_arah_kebijakan_opd/:kode_opd/:tahun", semuaRole},
+
+	// strategic arah kebijakan pemda
+	{http.MethodGet, "/strategi_arah_kebijakan_pemda/:tahun_awal/:tahun_akhir", semuaRole},
+
+	//pohon kinerja admin
+	{http.MethodPost, "/pohon_kinerja_admin/create", hanyaSuperAdmin},
+	{http.MethodPut, "/pohon_kinerja_admin/update/:pohonKinerjaId", hanyaSuperAdmin},
+	{http.MethodGet, "/pohon_kinerja_admin/detail/:id", semuaRole},
+	{http.MethodDelete, "/pohon_kinerja_admin/delete/:pohonKinerjaId", hanyaSuperAdmin},
+	{http.MethodGet, "/pohon_kinerja_admin/findall/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja_admin/tematik/:idPokin", semuaRole},
+	{http.MethodPost, "/pohon_kinerja_admin/clone_strategic/create", hanyaSuperAdmin},
+	{http.MethodPost, "/pohon_kinerja_admin/clone_pokin_pemda/create", hanyaSuperAdmin},
+	{http.MethodPut, "/pohon_kinerja_admin/tolak_pokin/:pohonKinerjaId", hanyaSuperAdmin},
+	{http.MethodGet, "/pohon_kinerja_admin/crosscutting/:kode_opd/:tahun", semuaRole},
+	{http.MethodPost, "/pokin/activation_tematik/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/pokin_tematik/list_opd/:tahun", semuaRole},
+	{http.MethodPost, "/clone_pokin_pemda/:id", hanyaSuperAdmin},
+
+	//pohon kinerja for dropdown
+	{http.MethodGet, "/pohon_kinerja/tematik/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/strategic/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/tactical/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/operational/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/status/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/pemda/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/pilih_parent/:kode_opd/:tahun/:level_pohon", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/transisi/:id", semuaRole},
+	{http.MethodGet, "/pohon_kinerja_opd/pokinpemda_review/:id", semuaRole},
+
+	// isustrategis - csf
+	{http.MethodGet, "/isustrategis/csfs/:tahun", semuaRole},
+	{http.MethodGet, "/isustrategis/csf/detail/:id", semuaRole},
+
+	// data master
+	//pegawai
+	{http.MethodPost, "/pegawai/create", hanyaSuperAdmin},
+	{http.MethodPut, "/pegawai/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/pegawai/detail/:id", semuaRole},
+	{http.MethodDelete, "/pegawai/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/pegawai/findall", semuaRole},
+	{http.MethodPost, "/pegawai/tambahJabatan", adminOpd},
+
+	//lembaga
+	{http.MethodPost, "/lembaga/create", hanyaSuperAdmin},
+	{http.MethodPut, "/lembaga/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/lembaga/detail/:id", semuaRole},
+	{http.MethodDelete, "/lembaga/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/lembaga/findall", semuaRole},
+
+	//jabatan
+	{http.MethodPost, "/jabatan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/jabatan/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/jabatan/detail/:id", semuaRole},
+	{http.MethodDelete, "/jabatan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/jabatan/findall/:kode_opd", semuaRole},
+	{http.MethodGet, "/jabatan/findall/:kode_opd/:tahun", semuaRole},
+
+	//opd
+	{http.MethodPost, "/opd/create", hanyaSuperAdmin},
+	{http.MethodPut, "/opd/update/:opdId", hanyaSuperAdmin},
+	{http.MethodGet, "/opd/detail/:opdId", semuaRole},
+	{http.MethodDelete, "/opd/delete/:opdId", hanyaSuperAdmin},
+	{http.MethodGet, "/opd/findall", semuaRole},
+
+	//program
+	{http.MethodPost, "/program_kegiatan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/program_kegiatan/update/:programId", hanyaSuperAdmin},
+	{http.MethodGet, "/program_kegiatan/detail/:id", semuaRole},
+	{http.MethodDelete, "/program_kegiatan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/program_kegiatan/findall", semuaRole},
+
+	//urusan
+	{http.MethodPost, "/urusan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/urusan/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/urusan/detail/:id", semuaRole},
+	{http.MethodDelete, "/urusan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/urusan/findall", semuaRole},
+	{http.MethodGet, "/urusan/findall/:kode_opd/urusan_bidang", semuaRole},
+
+	//bidang urusan
+	{http.MethodPost, "/bidang_urusan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/bidang_urusan/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/bidang_urusan/detail/:id", semuaRole},
+	{http.MethodDelete, "/bidang_urusan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/bidang_urusan/findall", semuaRole},
+	{http.MethodGet, "/bidang_urusan/findall/:kode_opd", semuaRole},
+
+	//kegiatan
+	{http.MethodPost, "/kegiatan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/kegiatan/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/kegiatan/detail/:id", semuaRole},
+	{http.MethodDelete, "/kegiatan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/kegiatan/findall", semuaRole},
+
+	//rincian kak
+	{http.MethodGet, "/rencana_kinerja/:rencana_kinerja_id/pegawai/:pegawai_id/input_rincian_kak", semuaRole},
+	{http.MethodGet, "/rencana_kinerja/:rencana_kinerja_id/pegawai/:pegawai_id/kak/:format", semuaRole},
+
+	//role
+	{http.MethodPost, "/role/create", hanyaSuperAdmin},
+	{http.MethodPut, "/role/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/role/detail/:id", semuaRole},
+	{http.MethodDelete, "/role/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/role/findall", semuaRole},
+
+	//user
+	{http.MethodPost, "/user/create", hanyaSuperAdmin},
+	{http.MethodPut, "/user/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/user/detail/:id", semuaRole},
+	{http.MethodDelete, "/user/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/user/findall", hanyaSuperAdmin},
+	{http.MethodPost, "/user/login", semuaRole},
+	{http.MethodPost, "/user/refresh", semuaRole},
+	{http.MethodPost, "/user/logout", semuaRole},
+	{http.MethodGet, "/user/findbykodeopdandrole", adminOpd},
+	{http.MethodGet, "/user/findpegawai/:nip", semuaRole},
+
+	//tujuan opd renstra
+	{http.MethodPost, "/tujuan_opd/renstra/create", adminOpd},
+	{http.MethodPut, "/tujuan_opd/renstra/update/:tujuanOpdId", adminOpd},
+	{http.MethodGet, "/tujuan_opd/detail/:tujuanOpdId", semuaRole},
+	{http.MethodDelete, "/tujuan_opd/delete/:tujuanOpdId", adminOpd},
+	{http.MethodGet, "/tujuan_opd/findall/:kode_opd/tahunawal/:tahun_awal/tahunakhir/:tahun_akhir/jenisperiode/:jenis_periode", semuaRole},
+	{http.MethodGet, "/tujuan_opd/findall_only_name/:kode_opd/tahunawal/:tahun_awal/tahunakhir/:tahun_akhir/jenisperiode/:jenis_periode", semuaRole},
+	{http.MethodGet, "/tujuan_opd/renja/:kode_opd/:tahun/:jenis_periode", semuaRole},
+
+	//crosscutting opd
+	{http.MethodPost, "/crosscutting_opd/create/:parentId", adminOpd},
+	{http.MethodPut, "/crosscutting_opd/update/:crosscuttingId", adminOpd},
+	{http.MethodDelete, "/crosscutting_opd/delete/:crosscuttingId", adminOpd},
+	{http.MethodGet, "/crosscutting_opd/findall/:parentId", semuaRole},
+	{http.MethodPost, "/crosscutting/:crosscuttingId/permission", adminOpd},
+	{http.MethodDelete, "/crosscutting/:crosscuttingId/unused", adminOpd},
+	{http.MethodGet, "/crosscutting_menunggu/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/crosscutting_opd/opd-from/:crosscuttingTo", semuaRole},
+
+	//manual ik
+	{http.MethodPost, "/manual_ik/create/:indikatorId", semuaRole},
+	{http.MethodPut, "/manual_ik/update/:indikatorId", semuaRole},
+	{http.MethodGet, "/manual_ik/detail/:indikatorId", semuaRole},
+	{http.MethodGet, "/manual_ik/sasaran_opd/:indikatorId/:tahun", semuaRole},
+
+	//review
+	{http.MethodPost, "/review_pokin/create/:pokinId", reviewerPokin},
+	{http.MethodPut, "/review_pokin/update/:id", reviewerPokin},
+	{http.MethodDelete, "/review_pokin/delete/:id", reviewerPokin},
+	{http.MethodGet, "/review_pokin/findall/:pokin_id", semuaRole},
+	{http.MethodGet, "/review_pokin/detail/:id", semuaRole},
+	{http.MethodGet, "/review_pokin/tematik/:tahun", semuaRole},
+	{http.MethodGet, "/review_pokin/opd/:kode_opd/:tahun", semuaRole},
+
+	//periode
+	{http.MethodPost, "/periode/create", hanyaSuperAdmin},
+	{http.MethodPut, "/periode/update/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/periode/tahun/:tahun", semuaRole},
+	{http.MethodGet, "/periode/findall", semuaRole},
+	{http.MethodGet, "/periode/detail/:id", semuaRole},
+	{http.MethodDelete, "/periode/delete/:id", hanyaSuperAdmin},
+
+	//tujuan pemda
+	{http.MethodPost, "/tujuan_pemda/create", hanyaSuperAdmin},
+	{http.MethodPut, "/tujuan_pemda/update/:id", hanyaSuperAdmin},
+	{http.MethodDelete, "/tujuan_pemda/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/tujuan_pemda/detail/:id", semuaRole},
+	{http.MethodGet, "/tujuan_pemda/findall/:tahun/:jenis_periode", semuaRole},
+	{http.MethodPut, "/tujuan_pemda/update_periode/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/tujuan_pemda/findall_with_pokin/:tahun_awal/:tahun_akhir/:jenis_periode", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/pokin_with_periode/:pokin_id/:jenis_periode", semuaRole},
+
+	//sasaran pemda
+	{http.MethodPost, "/sasaran_pemda/create", hanyaSuperAdmin},
+	{http.MethodPut, "/sasaran_pemda/update/:id", hanyaSuperAdmin},
+	{http.MethodDelete, "/sasaran_pemda/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/sasaran_pemda/detail/:id", semuaRole},
+	{http.MethodGet, "/sasaran_pemda/findall/tahun_awal/:tahun_awal/tahun_akhir/:tahun_akhir/jenis_periode/:jenis_periode", semuaRole},
+
+	//permasalahan rekin
+	{http.MethodPost, "/permasalahan_rekin/create", semuaRole},
+	{http.MethodPut, "/permasalahan_rekin/update/:id", semuaRole},
+	{http.MethodGet, "/permasalahan_rekin/findall/:rekinId", semuaRole},
+	{http.MethodGet, "/permasalahan_rekin/detail/:id", semuaRole},
+	{http.MethodDelete, "/permasalahan_rekin/delete/:id", semuaRole},
+
+	//iku
+	{http.MethodGet, "/indikator_utama/periode/:tahun_awal/:tahun_akhir/:jenis_periode", semuaRole},
+	{http.MethodGet, "/indikator_utama/opd/:kode_opd/:tahun_awal/:tahun_akhir/:jenis_periode", semuaRole},
+	{http.MethodPut, "/indikator_utama/status/:indikator_id", hanyaSuperAdmin},
+	{http.MethodPut, "/indikator_utama/opd/status/:kode_indikator", adminOpd},
+
+	//sasaran opd
+	{http.MethodGet, "/sasaran_opd/detail/:id", semuaRole},
+	{http.MethodPost, "/sasaran_opd/create", adminOpd},
+	{http.MethodPut, "/sasaran_opd/update/:id", adminOpd},
+	{http.MethodDelete, "/sasaran_opd/delete/:id", adminOpd},
+	{http.MethodGet, "/sasaran_opd/pokin/:id_pokin/tahun/:tahun", semuaRole},
+	{http.MethodGet, "/sasaran_opd/renja/:kode_opd/:tahun/:jenis_periode", semuaRole},
+
+	//visi pemda
+	{http.MethodPost, "/visi_pemda/create", hanyaSuperAdmin},
+	{http.MethodPut, "/visi_pemda/update/:id", hanyaSuperAdmin},
+	{http.MethodDelete, "/visi_pemda/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/visi_pemda/findall/tahun/:tahun_awal/jenisperiode/:jenis_periode", semuaRole},
+	{http.MethodGet, "/visi_pemda/detail/:id", semuaRole},
+
+	//misi pemda
+	{http.MethodPost, "/misi_pemda/create", hanyaSuperAdmin},
+	{http.MethodPut, "/misi_pemda/update/:id", hanyaSuperAdmin},
+	{http.MethodDelete, "/misi_pemda/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/misi_pemda/findall/tahun/:tahun_awal/jenisperiode/:jenis_periode", semuaRole},
+	{http.MethodGet, "/misi_pemda/detail/:id", semuaRole},
+	{http.MethodGet, "/misi_pemda/findbyvisi/:id_visi", semuaRole},
+
+	//subkegiatan opd
+	{http.MethodPost, "/subkegiatanopd/create", adminOpd},
+	{http.MethodDelete, "/subkegiatanopd/delete/:id", adminOpd},
+	{http.MethodPut, "/subkegiatanopd/update/:id", adminOpd},
+	{http.MethodGet, "/subkegiatanopd/findall/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/subkegiatanopd/detail/:id", semuaRole},
+	{http.MethodGet, "/subkegiatanopd/bidangurusan/:kode_opd", semuaRole},
+
+	//matrix renstra
+	{http.MethodGet, "/matrix_renstra/opd/:kode_opd", semuaRole},
+	{http.MethodPost, "/matrix_renstra/upsert_anggaran", adminOpd},
+	{http.MethodDelete, "/matrix_renstra/indikator/delete/:kode_indikator", adminOpd},
+	{http.MethodPost, "/matrix_renstra/indikator/upsert", adminOpd},
+
+	//cascading opd
+	{http.MethodGet, "/cascading_opd/findall/:kode_opd/:tahun", semuaRole},
+
+	//rincian belanja
+	{http.MethodGet, "/rincian_belanja/asn/:pegawai_id/:tahun", semuaRole},
+	{http.MethodPost, "/rincian_belanja/create", semuaRole},
+	{http.MethodPut, "/rincian_belanja/update/:renaksiId", semuaRole},
+	{http.MethodGet, "/rincian_belanja/pegawai/:pegawai_id/:tahun", semuaRole},
+	{http.MethodPost, "/rincian_belanja/upsert", semuaRole},
+
+	{http.MethodGet, "/rincian_belanja/laporan", semuaRole},
+
+	//kelompok anggaran
+	{http.MethodPost, "/kelompok_anggaran/create", hanyaSuperAdmin},
+	{http.MethodGet, "/kelompok_anggaran/findall", semuaRole},
+	{http.MethodGet, "/kelompok_anggaran/detail/:id", semuaRole},
+
+	//clonning pohon kinerja opd
+	{http.MethodPost, "/pohon_kinerja_opd/clone", adminOpd},
+	{http.MethodGet, "/pohon_kinerja_opd/check_pokin/:kode_opd/:tahun", semuaRole},
+
+	//count pokin pemda in opd
+	{http.MethodGet, "/pohon_kinerja_opd/count_pokin_pemda/:kode_opd/:tahun", semuaRole},
+
+	//Isustrategis pemda in perencanaan
+	{http.MethodGet, "/tematik_pemda/:tahun", semuaRole},
+	{http.MethodGet, "/rekap_outcome/:tahun", semuaRole},
+	{http.MethodGet, "/rekap_intermediate/:tahun", semuaRole},
+
+	//Master Program Unggulan
+	{http.MethodGet, "/program_unggulan/findall", semuaRole},
+	{http.MethodGet, "/program_unggulan/detail/:id", semuaRole},
+	{http.MethodPost, "/program_unggulan/create", hanyaSuperAdmin},
+	{http.MethodPut, "/program_unggulan/update/:id", hanyaSuperAdmin},
+	{http.MethodDelete, "/program_unggulan/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/program_unggulan/findall/:tahun_awal/:tahun_akhir", semuaRole},
+	{http.MethodGet, "/program_unggulan/findbykodeprogramunggulan/:kode_program_unggulan", semuaRole},
+	{http.MethodGet, "/program_unggulan/findbytahun/:tahun", semuaRole},
+	{http.MethodGet, "/program_unggulan/findunusedbytahun/:tahun", semuaRole},
+	{http.MethodPost, "/program_unggulan/findbyidterkait", semuaRole},
+
+	//Master Program Prioritas Pusat
+	{http.MethodGet, "/program_prioritas_pusat/findall", semuaRole},
+	{http.MethodGet, "/program_prioritas_pusat/detail/:id", semuaRole},
+	{http.MethodPost, "/program_prioritas_pusat/create", hanyaSuperAdmin},
+	{http.MethodPut, "/program_prioritas_pusat/update/:id", hanyaSuperAdmin},
+	{http.MethodDelete, "/program_prioritas_pusat/delete/:id", hanyaSuperAdmin},
+	{http.MethodGet, "/program_prioritas_pusat/findall/:tahun_awal/:tahun_akhir", semuaRole},
+	{http.MethodGet, "/program_prioritas_pusat/findbykodeprogramprioritaspusat/:kode_program_prioritas_pusat", semuaRole},
+	{http.MethodGet, "/program_prioritas_pusat/findbytahun/:tahun", semuaRole},
+	{http.MethodGet, "/program_prioritas_pusat/findunusedbytahun/:tahun", semuaRole},
+	{http.MethodPost, "/program_prioritas_pusat/findbyidterkait", semuaRole},
+
+	//matrix renja
+	{http.MethodGet, "/matrix_renja/ranwal/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/matrix_renja/rankhir/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/matrix_renja/penetapan/:kode_opd/:tahun", semuaRole},
+	{http.MethodPost, "/matrix_renja/indikator/ranwal/upsert", adminOpd},
+	{http.MethodPost, "/matrix_renja/indikator/rankhir/upsert", adminOpd},
+	{http.MethodPost, "/matrix_renja/indikator/penetapan/upsert", adminOpd},
+	{http.MethodPost, "/matrix_renja/anggaran_penetapan/upsert", adminOpd},
+	{http.MethodGet, "/matrix_renja/ranwal/:kode_opd/:tahun/excel", semuaRole},
+	{http.MethodGet, "/matrix_renja/rankhir/:kode_opd/:tahun/excel", semuaRole},
+	{http.MethodGet, "/matrix_renja/penetapan/:kode_opd/:tahun/excel", semuaRole},
+
+	//Api Internal Consume
+	{http.MethodGet, "/api/pokin_opd/findall/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/api/pokin_pemda/subtematik/:tahun", semuaRole},
+	{http.MethodGet, "/pohon_kinerja/pokin_atasan/:id", semuaRole},
+	{http.MethodGet, "/rekin/atasan/:rekin_id", semuaRole},
+	{http.MethodGet, "/api_internal/rencana_kinerja/findall", semuaRole},
+
+	//findcascadingopd by
+	{http.MethodGet, "/cascading_opd/findbyrekin/:rekin_id", semuaRole},
+	{http.MethodGet, "/cascading_opd/findbypokin/:pokin_id", semuaRole},
+	{http.MethodGet, "/cascading_opd/findbynip/:nip/:tahun", semuaRole},
+	{http.MethodPost, "/cascading_opd/findbymultiplerekin", semuaRole},
+
+	//control pokin opd
+	{http.MethodGet, "/pohon_kinerja_opd/control_pokin_opd/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/user/cek_admin_opd", adminOpd},
+	{http.MethodGet, "/pohon_kinerja_opd/leaderboard_pokin_opd/:tahun", semuaRole},
+
+	//bidang urusan terpilih opd
+	{http.MethodPost, "/bidang_urusan_opd/create", adminOpd},
+	{http.MethodDelete, "/bidang_urusan_opd/delete/:id", adminOpd},
+	{http.MethodGet, "/bidang_urusan_opd/findall/:kode_opd", semuaRole},
+
+	// PK
+	{http.MethodGet, "/pk_opd/:kode_opd/:tahun", semuaRole},
+	{http.MethodPost, "/pk_opd/hubungkan", adminOpd},
+	{http.MethodPost, "/pk_opd/hubungkan_atasan", adminOpd},
+	{http.MethodGet, "/pk_opd/:kode_opd/:tahun/pdf", semuaRole},
+	{http.MethodGet, "/pk_opd/:kode_opd/:tahun/pdf/:nip", semuaRole},
+
+	//clone rekin
+	{http.MethodPost, "/rencana_kinerja/clone/:rekin_id/:tahun_tujuan", semuaRole},
+	{http.MethodPost, "/rencana_kinerja/clone_by_kode_opd", adminOpd},
+
+	//tujuan OPD NEW
+	{http.MethodGet, "/tujuan_opd/renstra/:kode_opd/:tahun_awal/:tahun_akhir", semuaRole},
+	//tujuan opd renja
+	{http.MethodPost, "/tujuan_opd/renja/ranwal/indikator/create/:tujuanOpdId", adminOpd},
+	{http.MethodPut, "/tujuan_opd/renja/ranwal/indikator/update/:kodeIndikator", adminOpd},
+	{http.MethodDelete, "/tujuan_opd/renja/indikator/delete/:kodeIndikator", adminOpd},
+	{http.MethodPost, "/tujuan_opd/renja/rankhir/indikator/create/:tujuanOpdId", adminOpd},
+	{http.MethodPut, "/tujuan_opd/renja/rankhir/indikator/update/:kodeIndikator", adminOpd},
+	{http.MethodGet, "/tujuan_opd/ranwal/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/tujuan_opd/rankhir/:kode_opd/:tahun", semuaRole},
+	{http.MethodPost, "/tujuan_opd/renja/penetapan/indikator/create/:tujuanOpdId", adminOpd},
+	{http.MethodPut, "/tujuan_opd/renja/penetapan/indikator/update/:kodeIndikator", adminOpd},
+
+	// Sasaran OPD - Renstra & Renja
+	{http.MethodGet, "/sasaran_opd/renstra/:kode_opd/:tahun_awal/:tahun_akhir/:jenis_periode", semuaRole},
+	{http.MethodGet, "/sasaran_opd/ranwal/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/sasaran_opd/rankhir/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/sasaran_opd/penetapan/:kode_opd/:tahun", semuaRole},
+
+	//sasaran renja
+	{http.MethodPost, "/sasaran_opd/renja/ranwal/indikator/create/:sasaranopdId", adminOpd},
+	{http.MethodPut, "/sasaran_opd/renja/ranwal/indikator/update/:kodeIndikator", adminOpd},
+	{http.MethodDelete, "/sasaran_opd/renja/indikator/delete/:kodeIndikator", adminOpd},
+	{http.MethodPost, "/sasaran_opd/renja/rankhir/indikator/create/:sasaranopdId", adminOpd},
+	{http.MethodPut, "/sasaran_opd/renja/rankhir/indikator/update/:kodeIndikator", adminOpd},
+	{http.MethodPost, "/sasaran_opd/renja/penetapan/indikator/create/:sasaranopdId", adminOpd},
+	{http.MethodPut, "/sasaran_opd/renja/penetapan/indikator/update/:kodeIndikator", adminOpd},
+
+	// IKU Renja Opd
+	{http.MethodGet, "/iku_renja_opd/ranwal/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/iku_renja_opd/rankhir/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/iku_renja_opd/penetapan/:kode_opd/:tahun", semuaRole},
+
+	// Leaderboard Hidden
+	{http.MethodPost, "/leaderboard_rekin_hidden/upsert", hanyaSuperAdmin},
+	{http.MethodGet, "/leaderboard_rekin_hidden/findall/:tahun", semuaRole},
+
+	//delete crosscutting opd
+	{http.MethodDelete, "/crosscutting_opd/delete_crosscutting_diterima/:crosscuttingId", adminOpd},
+
+	//tujuan opd penetapan
+	{http.MethodGet, "/tujuan_opd/penetapan/:kode_opd/:tahun", semuaRole},
+
+	//lock data
+	{http.MethodPost, "/lock_data/lock", hanyaSuperAdmin},
+	{http.MethodPost, "/lock_data/unlock", hanyaSuperAdmin},
+	{http.MethodGet, "/lock_data/findall", semuaRole},
+
+	//audit log
+	{http.MethodGet, "/audit_log/findall", adminOpd},
+	{http.MethodGet, "/audit_log/entity/:entity_type/:entity_id", adminOpd},
+
+	//import nomenklatur kemendagri
+	{http.MethodPost, "/nomenklatur/import/preview", hanyaSuperAdmin},
+	{http.MethodPost, "/nomenklatur/import/apply", hanyaSuperAdmin},
+
+	//job background
+	{http.MethodGet, "/jobs/:id", adminOpd},
+	{http.MethodPost, "/jobs/:id/cancel", adminOpd},
+
+	//roll forward perencanaan OPD ke tahun berikutnya
+	{http.MethodPost, "/roll_forward/opd", adminOpd},
+
+	//realisasi dan capaian kinerja (akses kode_opd dicek di service)
+	{http.MethodPost, "/realisasi_indikator/create", semuaRole},
+	{http.MethodPut, "/realisasi_indikator/update/:id", semuaRole},
+	{http.MethodDelete, "/realisasi_indikator/delete/:id", semuaRole},
+	{http.MethodGet, "/realisasi_indikator/findall/:indikator_id/:tahun", semuaRole},
+	{http.MethodPut, "/realisasi_indikator/polaritas/:indikator_id", adminOpd},
+	{http.MethodGet, "/capaian_kinerja/opd/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/capaian_kinerja/pemda/:tahun", semuaRole},
+
+	//realisasi dan serapan anggaran (akses kode_opd dicek di service)
+	{http.MethodPost, "/realisasi_anggaran/create", semuaRole},
+	{http.MethodPut, "/realisasi_anggaran/update/:id", semuaRole},
+	{http.MethodDelete, "/realisasi_anggaran/delete/:id", semuaRole},
+	{http.MethodGet, "/realisasi_anggaran/rencana_aksi/:renaksi_id", semuaRole},
+	{http.MethodGet, "/realisasi_anggaran/laporan/:kode_opd/:tahun", semuaRole},
+	{http.MethodGet, "/realisasi_anggaran/deviasi/:kode_opd/:tahun", semuaRole},
+
+	//persetujuan rencana kinerja (pemilik, atasan dan admin dicek di service)
+	{http.MethodPost, "/persetujuan_rekin/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/persetujuan_rekin/detail/:rencana_kinerja_id", semuaRole},
+	{http.MethodGet, "/persetujuan_rekin/menunggu/:tahun", semuaRole},
+
+	//notifikasi (inbox milik user yang sedang login)
+	{http.MethodGet, "/notifikasi", semuaRole},
+	{http.MethodGet, "/notifikasi/belum_dibaca/jumlah", semuaRole},
+	{http.MethodPut, "/notifikasi/baca/:id", semuaRole},
+	{http.MethodPut, "/notifikasi/baca_semua", semuaRole},
+	{http.MethodGet, "/notifikasi/stream", semuaRole},
+
+	//health check (public)
+	{http.MethodGet, "/healthz", semuaRole},
+	{http.MethodGet, "/readyz", semuaRole},
+	{http.MethodGet, "/metrics", semuaRole},
+}
+
+// FindRoutePermission mencari aturan untuk method dan path request.
+// Jika lebih dari satu template cocok, dipilih yang segmen statisnya paling banyak
+// (sama seperti prioritas httprouter).
+func FindRoutePermission(method string, path string) (RoutePermission, bool) {
+	pathSegments := splitPath(path)
+
+	var found RoutePermission
+	bestScore := -1
+	for _, permission := range RoutePermissions {
+		if permission.Method != method {
+			continue
+		}
+		score, ok := matchTemplate(splitPath(permission.Path), pathSegments)
+		if ok && score > bestScore {
+			found = permission
+			bestScore = score
+		}
+	}
+
+	return found, bestScore >= 0
+}
+
+// IsAllowed mengecek apakah role user memenuhi aturan permission
+func (permission RoutePermission) IsAllowed(userRoles []string) bool {
+	if len(permission.Roles) == 0 {
+		return true
+	}
+	return helper.HasAnyRole(userRoles, permission.Roles...)
+}
+
+// PathParam mengambil nilai parameter (mis. "kode_opd") dari path request berdasarkan template route
+func (permission RoutePermission) PathParam(path string, name string) string {
+	pathSegments := splitPath(path)
+	for i, segment := range splitPath(permission.Path) {
+		if segment == ":"+name && i < len(pathSegments) {
+			return pathSegments[i]
+		}
+	}
+	return ""
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchTemplate mengembalikan jumlah segmen statis yang cocok
+func matchTemplate(templateSegments []string, pathSegments []string) (int, bool) {
+	score := 0
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "*") {
+			return score, true
+		}
+		if i >= len(pathSegments) {
+			return 0, false
+		}
+		if strings.HasPrefix(segment, ":") {
+			if pathSegments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if segment != pathSegments[i] {
+			return 0, false
+		}
+		score++
+	}
+	if len(templateSegments) != len(pathSegments) {
+		return 0, false
+	}
+	return score, true
+}
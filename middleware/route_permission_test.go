@@ -0,0 +1,322 @@
+package middleware
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type registeredRoute struct {
+	method string
+	path   string
+}
+
+// registeredRoutes membaca semua pemanggilan router.GET/POST/PUT/DELETE di app/router.go
+func registeredRoutes(t *testing.T) []registeredRoute {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "../app/router.go", nil, 0)
+	if err != nil {
+		t.Fatalf("gagal parse app/router.go: %v", err)
+	}
+
+	var routes []registeredRoute
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		receiver, ok := selector.X.(*ast.Ident)
+		if !ok || receiver.Name != "router" {
+			return true
+		}
+		literal, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+		path, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			t.Fatalf("path tidak valid %s: %v", literal.Value, err)
+		}
+		routes = append(routes, registeredRoute{method: selector.Sel.Name, path: path})
+		return true
+	})
+
+	if len(routes) == 0 {
+		t.Fatal("tidak ada route yang terbaca dari app/router.go")
+	}
+	return routes
+}
+
+func TestRoutePermissionsCoverAllRoutes(t *testing.T) {
+	routes := registeredRoutes(t)
+
+	declared := make(map[registeredRoute]bool)
+	for _, permission := range RoutePermissions {
+		key := registeredRoute{method: permission.Method, path: permission.Path}
+		if declared[key] {
+			t.Errorf("permission duplikat: %s %s", permission.Method, permission.Path)
+		}
+		declared[key] = true
+	}
+
+	registered := make(map[registeredRoute]bool)
+	for _, route := range routes {
+		registered[route] = true
+		if !declared[route] {
+			t.Errorf("route %s %s belum ada di RoutePermissions", route.method, route.path)
+		}
+	}
+
+	for key := range declared {
+		if !registered[key] {
+			t.Errorf("permission %s %s tidak terdaftar di app.NewRouter", key.method, key.path)
+		}
+	}
+}
+
+func TestRoutePermissionsResolveToThemselves(t *testing.T) {
+	for _, route := range registeredRoutes(t) {
+		concretePath := concretePath(route.path)
+		permission, found := FindRoutePermission(route.method, concretePath)
+		if !found {
+			t.Errorf("%s %s tidak ditemukan", route.method, concretePath)
+			continue
+		}
+		if permission.Path != route.path && !samePattern(permission.Path, route.path) {
+			t.Errorf("%s %s resolve ke %s, seharusnya %s", route.method, concretePath, permission.Path, route.path)
+		}
+	}
+}
+
+// concretePath mengganti parameter httprouter dengan nilai contoh
+func concretePath(template string) string {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "1"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// samePattern true jika dua template hanya berbeda pada nama parameter
+func samePattern(a, b string) bool {
+	return concretePath(a) == concretePath(b)
+}
+
+func TestFindRoutePermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+		found    bool
+	}{
+		{
+			name:     "route statis",
+			method:   http.MethodPost,
+			path:     "/user/create",
+			expected: "/user/create",
+			found:    true,
+		},
+		{
+			name:     "route dengan parameter",
+			method:   http.MethodDelete,
+			path:     "/pohon_kinerja_admin/delete/123",
+			expected: "/pohon_kinerja_admin/delete/:pohonKinerjaId",
+			found:    true,
+		},
+		{
+			name:     "segmen statis lebih diprioritaskan",
+			method:   http.MethodPost,
+			path:     "/sub_kegiatan/create_rekin/REKIN-1",
+			expected: "/sub_kegiatan/create_rekin/:rencana_kinerja_id",
+			found:    true,
+		},
+		{
+			name:     "wildcard",
+			method:   http.MethodGet,
+			path:     "/swagger/index.html",
+			expected: "/swagger/*any",
+			found:    true,
+		},
+		{
+			name:   "method berbeda",
+			method: http.MethodPatch,
+			path:   "/user/create",
+			found:  false,
+		},
+		{
+			name:   "route tidak terdaftar",
+			method: http.MethodGet,
+			path:   "/tidak_ada",
+			found:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permission, found := FindRoutePermission(tt.method, tt.path)
+			if found != tt.found {
+				t.Fatalf("FindRoutePermission(%q, %q) found = %v; want %v", tt.method, tt.path, found, tt.found)
+			}
+			if found && permission.Path != tt.expected {
+				t.Errorf("FindRoutePermission(%q, %q) = %q; want %q", tt.method, tt.path, permission.Path, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareRoleCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		roles    []string
+		expected int
+	}{
+		{
+			name:     "admin opd tidak boleh hapus pokin pemda",
+			method:   http.MethodDelete,
+			path:     "/pohon_kinerja_admin/delete/10",
+			roles:    []string{helper.RoleAdminOpd},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "super admin boleh hapus pokin pemda",
+			method:   http.MethodDelete,
+			path:     "/pohon_kinerja_admin/delete/10",
+			roles:    []string{helper.RoleSuperAdmin},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "asn tidak boleh membuat user",
+			method:   http.MethodPost,
+			path:     "/user/create",
+			roles:    []string{helper.RoleStaff},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "asn boleh membuat rencana kinerja",
+			method:   http.MethodPost,
+			path:     "/rencana_kinerja/create",
+			roles:    []string{helper.RoleStaff},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "reviewer boleh membuat review",
+			method:   http.MethodPost,
+			path:     "/review_pokin/create/1",
+			roles:    []string{helper.RoleReviewer},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "multi role",
+			method:   http.MethodPost,
+			path:     "/pohon_kinerja_opd/create",
+			roles:    []string{helper.RoleStaff, helper.RoleAdminOpd},
+			expected: http.StatusOK,
+		},
+	}
+
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	authMiddleware := NewAuthMiddleware(next, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := helper.CreateNewJWT(1, "PEG-1", "", "199001012020011001", "1.01.0.00.0.00.01.0000", "Dinas", "Pegawai", tt.roles)
+
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			recorder := httptest.NewRecorder()
+
+			authMiddleware.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.expected {
+				t.Fatalf("status = %d; want %d", recorder.Code, tt.expected)
+			}
+			if tt.expected == http.StatusForbidden {
+				var response web.WebResponse
+				if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+					t.Fatalf("response bukan web.WebResponse: %v", err)
+				}
+				if response.Code != http.StatusForbidden || response.Status != "FORBIDDEN" {
+					t.Errorf("response = %+v; want code 403 FORBIDDEN", response)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareKodeOpdCheck(t *testing.T) {
+	const kodeOpdUser = "1.01.0.00.0.00.01.0000"
+
+	tests := []struct {
+		name     string
+		path     string
+		roles    []string
+		expected int
+	}{
+		{
+			name:     "kode_opd path milik sendiri",
+			path:     "/pohon_kinerja_opd/findall/" + kodeOpdUser + "/2025",
+			roles:    []string{helper.RoleAdminOpd},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "kode_opd path opd lain",
+			path:     "/pohon_kinerja_opd/findall/1.02.0.00.0.00.01.0000/2025",
+			roles:    []string{helper.RoleAdminOpd},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "kode_opd query opd lain",
+			path:     "/rencana_kinerja_opd/findall?kode_opd=1.02.0.00.0.00.01.0000&tahun=2025",
+			roles:    []string{helper.RoleStaff},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "super admin boleh akses opd lain",
+			path:     "/pohon_kinerja_opd/findall/1.02.0.00.0.00.01.0000/2025",
+			roles:    []string{helper.RoleSuperAdmin},
+			expected: http.StatusOK,
+		},
+	}
+
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	authMiddleware := NewAuthMiddleware(next, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := helper.CreateNewJWT(1, "PEG-1", "", "199001012020011001", kodeOpdUser, "Dinas", "Pegawai", tt.roles)
+
+			request := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			recorder := httptest.NewRecorder()
+
+			authMiddleware.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.expected {
+				t.Fatalf("status = %d; want %d", recorder.Code, tt.expected)
+			}
+		})
+	}
+}
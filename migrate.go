@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/app"
+	"ekak_kabupaten_madiun/db"
+	"fmt"
+	"os"
+)
+
+// runMigration perintah -migrate: up, down (satu langkah), status, version
+func runMigration(command string, dryRun bool) error {
+	connection := app.GetMigrationConnection()
+	defer connection.Close()
+
+	migrator, err := app.NewMigrator(connection, db.Migrations, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		return migrator.Up(ctx, dryRun)
+	case "down":
+		return migrator.Down(ctx, dryRun)
+	case "status":
+		statuses, current, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			mark := "pending"
+			if status.Applied {
+				mark = "applied"
+			}
+			if status.Current && current.Dirty {
+				mark = "dirty"
+			}
+			fmt.Printf("%-8s %d_%s\n", mark, status.Version, status.Name)
+		}
+		return nil
+	case "version":
+		current, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("versi schema: %d (terbaru %d)", current.Version, migrator.Latest())
+		if current.Dirty {
+			fmt.Print(" dirty")
+		}
+		fmt.Println()
+		return nil
+	default:
+		return fmt.Errorf("perintah migrasi %q tidak dikenal, gunakan up, down, status atau version", command)
+	}
+}
+
+// checkSchema server tidak dijalankan jika schema database tertinggal dari migrasi di binary
+func checkSchema(server *Server) error {
+	migrator, err := app.NewMigrator(server.db, db.Migrations, os.Stdout)
+	if err != nil {
+		return err
+	}
+	return migrator.CheckUpToDate(context.Background())
+}
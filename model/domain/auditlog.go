@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuditLog satu baris riwayat perubahan data (tb_audit_log, append-only)
+type AuditLog struct {
+	Id         int64
+	UserId     int
+	Nip        string
+	Action     string
+	EntityType string
+	EntityId   string
+	KodeOpd    string
+	Tahun      string
+	BeforeData sql.NullString
+	AfterData  sql.NullString
+	CreatedAt  time.Time
+}
+
+// AuditLogFilter filter pencarian audit log, field kosong = tanpa filter
+type AuditLogFilter struct {
+	EntityType   string
+	EntityId     string
+	KodeOpd      string
+	Tahun        string
+	UserId       int
+	Nip          string
+	TanggalAwal  *time.Time
+	TanggalAkhir *time.Time
+	Limit        int
+}
+
+// Aksi yang dicatat di audit log
+const (
+	AuditActionCreate  = "CREATE"
+	AuditActionUpdate  = "UPDATE"
+	AuditActionDelete  = "DELETE"
+	AuditActionClone   = "CLONE"
+	AuditActionApprove = "APPROVE"
+	AuditActionReject  = "REJECT"
+	AuditActionImport  = "IMPORT"
+)
+
+// Jenis entitas yang dicatat di audit log
+const (
+	AuditEntityPohonKinerja      = "pohon_kinerja"
+	AuditEntityPelaksanaPokin    = "pelaksana_pokin"
+	AuditEntityRencanaKinerja    = "rencana_kinerja"
+	AuditEntityIndikator         = "indikator"
+	AuditEntityCrosscutting      = "crosscutting"
+	AuditEntityReview            = "review"
+	AuditEntityNomenklatur       = "nomenklatur"
+	AuditEntityRollForward       = "roll_forward"
+	AuditEntityRealisasi         = "realisasi_indikator"
+	AuditEntityRealisasiAnggaran = "realisasi_anggaran"
+)
@@ -19,9 +19,9 @@ type CloneRecord struct {
 type WarningType string
 
 type CloneWarning struct {
-	Type    WarningType // "indikator", "manual_ik", "renaksi", dll
-	Count   int
-	Message string
+	Type    WarningType `json:"type"` // "indikator", "manual_ik", "renaksi", dll
+	Count   int         `json:"count"`
+	Message string      `json:"message"`
 }
 
 type CloneResult struct {
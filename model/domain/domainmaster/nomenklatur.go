@@ -0,0 +1,19 @@
+package domainmaster
+
+const (
+	NomenklaturUrusan       = "urusan"
+	NomenklaturBidangUrusan = "bidang_urusan"
+	NomenklaturProgram      = "program"
+	NomenklaturKegiatan     = "kegiatan"
+	NomenklaturSubKegiatan  = "subkegiatan"
+)
+
+// Nomenklatur satu baris master nomenklatur kemendagri dari salah satu tabel
+// tb_urusan, tb_bidang_urusan, tb_master_program, tb_master_kegiatan atau tb_subkegiatan
+type Nomenklatur struct {
+	Level    string
+	Id       string
+	Kode     string
+	Nama     string
+	IsActive bool
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Status job, sama dengan status di clone_record
+const (
+	JobStatusPending         = "PENDING"
+	JobStatusProcess         = "PROCESS"
+	JobStatusDone            = "DONE"
+	JobStatusDoneWithWarning = "DONE_WITH_WARNING"
+	JobStatusFailed          = "FAILED"
+	JobStatusCancelled       = "CANCELLED"
+)
+
+// Jenis job yang dijalankan job runner
+const (
+	JobCloneRencanaKinerja = "clone_rencana_kinerja"
+	JobClonePokinOpd       = "clone_pokin_opd"
+	JobClonePokinPemda     = "clone_pokin_pemda"
+	JobRollForwardOpd      = "roll_forward_opd"
+)
+
+// Job satu antrian proses background di tb_job.
+// Payload dan Result disimpan sebagai JSON, isinya tergantung Jenis.
+type Job struct {
+	Id              int64
+	Jenis           string
+	Payload         []byte
+	Status          string
+	Progress        int
+	Attempts        int
+	MaxAttempts     int
+	CancelRequested bool
+	Warnings        []CloneWarning
+	Result          []byte
+	ErrorMessage    string
+	KodeOpd         string
+	Tahun           string
+	UserId          int
+	Nip             string
+	RunAfter        time.Time
+	HeartbeatAt     sql.NullTime
+	StartedAt       sql.NullTime
+	FinishedAt      sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IsFinished job sudah di status akhir dan tidak akan dijalankan lagi
+func (job Job) IsFinished() bool {
+	switch job.Status {
+	case JobStatusDone, JobStatusDoneWithWarning, JobStatusFailed, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// JobResult hasil yang dikembalikan handler job, Data disimpan ke kolom result
+type JobResult struct {
+	Warnings []CloneWarning
+	Data     interface{}
+}
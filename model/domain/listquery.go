@@ -0,0 +1,39 @@
+package domain
+
+import "errors"
+
+// ErrInvalidListQuery dipakai repository untuk sort/filter yang tidak dikenal, dipetakan ke 400 oleh exception
+var ErrInvalidListQuery = errors.New("parameter list tidak valid")
+
+// ListQuery parameter list endpoint: halaman, urutan, pencarian bebas (q) dan filter per kolom.
+// PageSize 0 berarti tanpa paginasi (seluruh data), dipakai untuk request lama yang tidak mengirim page/page_size.
+type ListQuery struct {
+	Page     int
+	PageSize int
+	Sort     string
+	SortDesc bool
+	Search   string
+	Filters  map[string]string
+}
+
+func (query ListQuery) Paginated() bool {
+	return query.PageSize > 0
+}
+
+func (query ListQuery) Offset() int {
+	if query.Page <= 1 {
+		return 0
+	}
+	return (query.Page - 1) * query.PageSize
+}
+
+// WithFilter mengembalikan salinan query dengan filter tambahan, biasanya dari path param
+func (query ListQuery) WithFilter(key, value string) ListQuery {
+	filters := make(map[string]string, len(query.Filters)+1)
+	for k, v := range query.Filters {
+		filters[k] = v
+	}
+	filters[key] = value
+	query.Filters = filters
+	return query
+}
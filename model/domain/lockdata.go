@@ -1,8 +1,39 @@
 package domain
 
+import "time"
+
 type LockData struct {
 	Id        int
 	JenisData string
 	KodeOpd   string
 	Tahun     string
+	LockedAt  time.Time
+}
+
+// Jenis data yang bisa dikunci per kode_opd + tahun (kolom jenis_data di tb_lock_data)
+const (
+	JenisLockTujuanOpd            = "tujuan_opd"
+	JenisLockSasaranOpd           = "sasaran_opd"
+	JenisLockMatrixRenjaRanwal    = "matrix_renja_ranwal"
+	JenisLockMatrixRenjaRankhir   = "matrix_renja_rankhir"
+	JenisLockMatrixRenjaPenetapan = "matrix_renja_penetapan"
+	JenisLockRencanaKinerja       = "rencana_kinerja"
+	JenisLockRincianBelanja       = "rincian_belanja"
+	JenisLockPk                   = "pk"
+	JenisLockRealisasiKinerja     = "realisasi_kinerja"
+	JenisLockRealisasiAnggaran    = "realisasi_anggaran"
+)
+
+// NamaJenisLockData nama dokumen untuk pesan error dan response
+var NamaJenisLockData = map[string]string{
+	JenisLockTujuanOpd:            "Tujuan OPD",
+	JenisLockSasaranOpd:           "Sasaran OPD",
+	JenisLockMatrixRenjaRanwal:    "Matrix Renja Ranwal",
+	JenisLockMatrixRenjaRankhir:   "Matrix Renja Rankhir",
+	JenisLockMatrixRenjaPenetapan: "Matrix Renja Penetapan",
+	JenisLockRencanaKinerja:       "Rencana Kinerja",
+	JenisLockRincianBelanja:       "Rincian Belanja",
+	JenisLockPk:                   "Perjanjian Kinerja",
+	JenisLockRealisasiKinerja:     "Realisasi Kinerja",
+	JenisLockRealisasiAnggaran:    "Realisasi Anggaran",
 }
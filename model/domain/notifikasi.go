@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Jenis notifikasi
+const (
+	JenisNotifikasiCrosscutting     = "crosscutting"
+	JenisNotifikasiReview           = "review"
+	JenisNotifikasiPokinDitolak     = "pokin_ditolak"
+	JenisNotifikasiPersetujuanRekin = "persetujuan_rekin"
+)
+
+// Notifikasi satu pesan di inbox satu user. EntityType memakai konstanta AuditEntity.
+type Notifikasi struct {
+	Id         int64
+	UserId     int
+	Jenis      string
+	Judul      string
+	Pesan      string
+	EntityType string
+	EntityId   string
+	KodeOpd    string
+	Tahun      string
+	Url        string
+	DibacaAt   sql.NullTime
+	CreatedAt  time.Time
+}
+
+// PenerimaNotifikasi user penerima: user dengan salah satu Roles pada OPD KodeOpd dan user dengan NIP pada Nips.
+// Hanya user aktif yang menerima notifikasi.
+type PenerimaNotifikasi struct {
+	KodeOpd string
+	Roles   []string
+	Nips    []string
+}
@@ -0,0 +1,115 @@
+package domain
+
+import "time"
+
+// Status rencana kinerja pada alur persetujuan
+const (
+	StatusRekinDraft        = "draft"
+	StatusRekinDiajukan     = "diajukan"
+	StatusRekinDisetujui    = "disetujui"
+	StatusRekinDikembalikan = "dikembalikan"
+	StatusRekinFinal        = "final"
+)
+
+// Aksi perubahan status rencana kinerja
+const (
+	AksiRekinAjukan     = "ajukan"
+	AksiRekinSetujui    = "setujui"
+	AksiRekinKembalikan = "kembalikan"
+	AksiRekinFinalkan   = "finalkan"
+	AksiRekinBuka       = "buka"
+)
+
+// Pihak yang boleh menjalankan aksi
+const (
+	AktorRekinPemilik = "pemilik"
+	AktorRekinAtasan  = "atasan"
+	AktorRekinAdmin   = "admin"
+)
+
+// TransisiRekin aturan satu aksi: status asal yang diizinkan, status tujuan dan pihak yang boleh menjalankan
+type TransisiRekin struct {
+	Dari         []string
+	Ke           string
+	Aktor        []string
+	CatatanWajib bool
+}
+
+// TransisiStatusRekin draft → diajukan → disetujui / dikembalikan → final.
+// Rekin final hanya bisa dibuka kembali oleh admin dan kembali ke dikembalikan.
+var TransisiStatusRekin = map[string]TransisiRekin{
+	AksiRekinAjukan: {
+		Dari:  []string{StatusRekinDraft, StatusRekinDikembalikan},
+		Ke:    StatusRekinDiajukan,
+		Aktor: []string{AktorRekinPemilik},
+	},
+	AksiRekinSetujui: {
+		Dari:  []string{StatusRekinDiajukan},
+		Ke:    StatusRekinDisetujui,
+		Aktor: []string{AktorRekinAtasan},
+	},
+	AksiRekinKembalikan: {
+		Dari:         []string{StatusRekinDiajukan, StatusRekinDisetujui},
+		Ke:           StatusRekinDikembalikan,
+		Aktor:        []string{AktorRekinAtasan},
+		CatatanWajib: true,
+	},
+	AksiRekinFinalkan: {
+		Dari:  []string{StatusRekinDisetujui},
+		Ke:    StatusRekinFinal,
+		Aktor: []string{AktorRekinAdmin},
+	},
+	AksiRekinBuka: {
+		Dari:         []string{StatusRekinFinal},
+		Ke:           StatusRekinDikembalikan,
+		Aktor:        []string{AktorRekinAdmin},
+		CatatanWajib: true,
+	},
+}
+
+// NormalisasiStatusRekin status lama (kosong, "aktif", dll) yang belum masuk alur persetujuan dianggap draft
+func NormalisasiStatusRekin(status string) string {
+	switch status {
+	case StatusRekinDiajukan, StatusRekinDisetujui, StatusRekinDikembalikan, StatusRekinFinal:
+		return status
+	default:
+		return StatusRekinDraft
+	}
+}
+
+// StatusRekinTerkunci rekin yang sedang diajukan, sudah disetujui atau final tidak boleh diubah
+func StatusRekinTerkunci(status string) bool {
+	switch NormalisasiStatusRekin(status) {
+	case StatusRekinDiajukan, StatusRekinDisetujui, StatusRekinFinal:
+		return true
+	default:
+		return false
+	}
+}
+
+// StatusRekinPersetujuan data rencana kinerja yang dibutuhkan untuk menjalankan transisi
+type StatusRekinPersetujuan struct {
+	Id                 string
+	NamaRencanaKinerja string
+	Status             string
+	KodeOpd            string
+	Tahun              string
+	PegawaiId          string
+	NamaPegawai        string
+}
+
+// RiwayatStatusRekin satu transisi status rencana kinerja (tb_riwayat_status_rekin, append-only)
+type RiwayatStatusRekin struct {
+	Id          int
+	RekinId     string
+	Aksi        string
+	StatusAwal  string
+	StatusAkhir string
+	Catatan     string
+	NipAktor    string
+	NamaAktor   string
+	NipAtasan   string
+	KodeOpd     string
+	Tahun       string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// RealisasiIndikator realisasi kumulatif satu indikator sampai akhir periode (bulan/triwulan) di tahun tersebut
+type RealisasiIndikator struct {
+	Id           int
+	IndikatorId  string
+	Tahun        string
+	JenisPeriode string
+	Periode      int
+	Realisasi    float64
+	Bukti        string
+	KodeOpd      string
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Jenis periode realisasi, satu indikator hanya memakai satu jenis periode per tahun
+const (
+	JenisPeriodeBulan    = "bulan"
+	JenisPeriodeTriwulan = "triwulan"
+)
+
+// JumlahPeriodeRealisasi jumlah periode dalam satu tahun per jenis periode
+var JumlahPeriodeRealisasi = map[string]int{
+	JenisPeriodeBulan:    12,
+	JenisPeriodeTriwulan: 4,
+}
+
+// Polaritas indikator (kolom polaritas di tb_indikator)
+const (
+	// PolaritasPositif realisasi makin tinggi makin baik
+	PolaritasPositif = "positif"
+	// PolaritasNegatif realisasi makin rendah makin baik, contoh angka kemiskinan
+	PolaritasNegatif = "negatif"
+)
+
+// IndikatorRealisasi pemilik indikator untuk pengecekan akses dan lock,
+// KodeOpd kosong berarti indikator pemda (sasaran/tujuan pemda)
+type IndikatorRealisasi struct {
+	Id        string
+	Indikator string
+	Polaritas string
+	KodeOpd   string
+}
+
+// CapaianIndikator indikator beserta target tahun berjalan, sumber perhitungan capaian.
+// Relasi yang kosong bernilai "" / 0.
+type CapaianIndikator struct {
+	Id               string
+	Indikator        string
+	Polaritas        string
+	Target           string
+	Satuan           string
+	RencanaKinerjaId string
+	PokinId          int
+	SasaranOpdId     int
+	SasaranPemdaId   int
+}
+
+// CapaianPokin pohon kinerja yang dihitung capaiannya
+type CapaianPokin struct {
+	Id         int
+	Parent     int
+	NamaPohon  string
+	JenisPohon string
+	LevelPohon int
+}
+
+// CapaianRekin rencana kinerja yang dihitung capaiannya
+type CapaianRekin struct {
+	Id                 string
+	NamaRencanaKinerja string
+	PegawaiId          string
+	IdPohon            int
+}
+
+// CapaianSasaran sasaran opd (PokinId) atau sasaran pemda (PokinId = subtema_id pohon pemda)
+type CapaianSasaran struct {
+	Id      int
+	Nama    string
+	PokinId int
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// RealisasiAnggaran belanja satu rencana aksi pada satu bulan (bukan kumulatif).
+// KodeOpd dan Tahun disalin dari rencana kinerja pemilik rencana aksi.
+type RealisasiAnggaran struct {
+	Id         int
+	RenaksiId  string
+	Bulan      int
+	Realisasi  int64
+	Keterangan string
+	KodeOpd    string
+	Tahun      string
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// RencanaAksiAnggaran rencana aksi OPD beserta anggaran rincian belanja dan hierarki program/kegiatan/subkegiatan.
+// Kode yang tidak ditemukan di master bernilai "".
+type RencanaAksiAnggaran struct {
+	Id                 string
+	NamaRencanaAksi    string
+	Urutan             int
+	RencanaKinerjaId   string
+	NamaRencanaKinerja string
+	PegawaiId          string
+	KodeOpd            string
+	Tahun              string
+	KodeSubkegiatan    string
+	NamaSubkegiatan    string
+	KodeKegiatan       string
+	NamaKegiatan       string
+	KodeProgram        string
+	NamaProgram        string
+	Anggaran           int64
+}
+
+// Status serapan rencana aksi terhadap jadwal bobot pelaksanaan rencana aksi
+const (
+	StatusSerapanSesuai           = "sesuai"
+	StatusSerapanTerlambat        = "terlambat"
+	StatusSerapanBelumRealisasi   = "belum_realisasi"
+	StatusSerapanMelebihiAnggaran = "melebihi_anggaran"
+	StatusSerapanTanpaJadwal      = "tanpa_jadwal"
+)
+
+// ToleransiDeviasiSerapan selisih rencana serapan dan serapan (persen poin) yang masih dianggap sesuai jadwal
+const ToleransiDeviasiSerapan = 10.0
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+type RefreshToken struct {
+	Id        int
+	SessionId string
+	UserId    int
+	TokenHash string
+	ExpiredAt time.Time
+	RevokedAt sql.NullTime
+	CreatedAt time.Time
+}
@@ -0,0 +1,47 @@
+package domain
+
+// Entitas yang disalin roll forward, dipakai di ringkasan laporan
+const (
+	RollForwardPohonKinerja        = "pohon_kinerja"
+	RollForwardIndikatorPokin      = "indikator_pohon_kinerja"
+	RollForwardTargetPokin         = "target_pohon_kinerja"
+	RollForwardPelaksanaPokin      = "pelaksana_pohon_kinerja"
+	RollForwardTagging             = "tagging_pohon_kinerja"
+	RollForwardKeteranganTagging   = "keterangan_tagging"
+	RollForwardCsf                 = "csf"
+	RollForwardRencanaKinerja      = "rencana_kinerja"
+	RollForwardIndikatorRekin      = "indikator_rencana_kinerja"
+	RollForwardTargetRekin         = "target_rencana_kinerja"
+	RollForwardRencanaAksi         = "rencana_aksi"
+	RollForwardSubKegiatanTerpilih = "subkegiatan_terpilih"
+	// RollForwardRelasiPohon data tetap disalin tetapi relasi ke pohon kinerja induknya dilepas
+	RollForwardRelasiPohon = "relasi_pohon_kinerja"
+)
+
+// RollForwardPokin pohon kinerja OPD di tahun sumber
+type RollForwardPokin struct {
+	Id         int
+	Parent     int
+	NamaPohon  string
+	JenisPohon string
+	LevelPohon int
+	Status     string
+}
+
+// RollForwardRekin rencana kinerja OPD, IdPohon dan KodeSubKegiatan di-remap ke tahun tujuan
+type RollForwardRekin struct {
+	Id              string
+	Nama            string
+	PegawaiId       string
+	IdPohon         int
+	KodeSubKegiatan string
+}
+
+// RollForwardItem satu data yang dilewati atau konflik di laporan roll forward
+type RollForwardItem struct {
+	Entitas  string
+	IdSumber string
+	IdTujuan string
+	Nama     string
+	Alasan   string
+}
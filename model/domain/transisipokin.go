@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Status pohon kinerja pada alur pokin pemda ke OPD, crosscutting dan clone
+const (
+	StatusPokinMenungguDisetujui     = "menunggu_disetujui"
+	StatusPokinDisetujui             = "disetujui"
+	StatusPokinDitolak               = "ditolak"
+	StatusPokinTarikOpd              = "tarik pokin opd"
+	StatusPokinDariPemda             = "pokin dari pemda"
+	StatusPokinCrosscuttingMenunggu  = "crosscutting_menunggu"
+	StatusPokinCrosscuttingDisetujui = "crosscutting_disetujui"
+	StatusPokinCrosscuttingDitolak   = "crosscutting_ditolak"
+)
+
+// Aksi alur status pohon kinerja
+const (
+	AksiPokinTerima              = "terima"
+	AksiPokinTolak               = "tolak"
+	AksiPokinSetujuiCrosscutting = "setujui_crosscutting"
+	AksiPokinTolakCrosscutting   = "tolak_crosscutting"
+	AksiPokinClone               = "clone"
+	AksiPokinBatalTerima         = "batal_terima"
+)
+
+// Peran user pada alur status pohon kinerja
+const (
+	AktorPokinAdminPemda = "admin_pemda"
+)
+
+// TransisiPokin satu aksi pada alur status pohon kinerja.
+// Dari kosong berarti semua status kecuali KecualiDari, Ke kosong berarti status tidak berubah.
+// Method dan Path adalah endpoint aksi untuk tombol aksi, Path kosong jika endpoint tidak dibuka.
+type TransisiPokin struct {
+	Nama        string
+	KataKerja   string
+	Dari        []string
+	KecualiDari []string
+	Ke          string
+	Aktor       []string
+	Method      string
+	Path        string
+}
+
+// BolehDari status pohon kinerja memenuhi syarat status asal aksi
+func (transisi TransisiPokin) BolehDari(status string) bool {
+	if len(transisi.Dari) > 0 {
+		return slices.Contains(transisi.Dari, status)
+	}
+	return !slices.Contains(transisi.KecualiDari, status)
+}
+
+// StatusPokinDalamAlur pohon kinerja yang masih atau sudah diproses pada alur pokin pemda ke OPD dan crosscutting.
+// Pohon dengan status ini tidak dipakai sebagai pohon OPD biasa (pilihan parent, roll forward).
+func StatusPokinDalamAlur(status string) bool {
+	switch status {
+	case StatusPokinMenungguDisetujui, StatusPokinTarikOpd, StatusPokinDisetujui, StatusPokinDitolak,
+		StatusPokinCrosscuttingMenunggu, StatusPokinCrosscuttingDitolak:
+		return true
+	}
+	return false
+}
+
+// UrutanAksiPokin urutan tombol aksi pohon kinerja
+var UrutanAksiPokin = []string{
+	AksiPokinTerima,
+	AksiPokinTolak,
+	AksiPokinSetujuiCrosscutting,
+	AksiPokinTolakCrosscutting,
+	AksiPokinClone,
+	AksiPokinBatalTerima,
+}
+
+// TransisiStatusPokin tabel transisi status pohon kinerja, satu-satunya sumber aturan perubahan status
+var TransisiStatusPokin = map[string]TransisiPokin{
+	AksiPokinTerima: {
+		Nama:      "Terima Pohon Kinerja Pemda",
+		KataKerja: "diclone",
+		Dari:      []string{StatusPokinMenungguDisetujui, StatusPokinDitolak},
+		Ke:        StatusPokinDisetujui,
+		Aktor:     []string{AktorPokinAdminPemda},
+		Method:    "POST",
+		Path:      "/pohon_kinerja_admin/clone_pokin_pemda/create",
+	},
+	AksiPokinTolak: {
+		Nama:      "Tolak Pohon Kinerja Pemda",
+		KataKerja: "ditolak",
+		Dari:      []string{StatusPokinMenungguDisetujui},
+		Ke:        StatusPokinDitolak,
+		Aktor:     []string{AktorPokinAdminPemda},
+		Method:    "PUT",
+		Path:      "/pohon_kinerja_admin/tolak_pokin/:pohonKinerjaId",
+	},
+	// endpoint admin untuk crosscutting sedang dinonaktifkan, persetujuan lewat /crosscutting/:crosscuttingId/permission
+	AksiPokinSetujuiCrosscutting: {
+		Nama:      "Setujui Crosscutting",
+		KataKerja: "disetujui",
+		Dari:      []string{StatusPokinCrosscuttingMenunggu},
+		Ke:        StatusPokinCrosscuttingDisetujui,
+		Aktor:     []string{AktorPokinAdminPemda},
+	},
+	AksiPokinTolakCrosscutting: {
+		Nama:      "Tolak Crosscutting",
+		KataKerja: "ditolak",
+		Dari:      []string{StatusPokinCrosscuttingMenunggu},
+		Ke:        StatusPokinCrosscuttingDitolak,
+		Aktor:     []string{AktorPokinAdminPemda},
+	},
+	AksiPokinClone: {
+		Nama:        "Clone Pohon Kinerja Pemda",
+		KataKerja:   "diclone",
+		KecualiDari: []string{StatusPokinTarikOpd},
+		Aktor:       []string{AktorPokinAdminPemda},
+		Method:      "POST",
+		Path:        "/clone_pokin_pemda/:id",
+	},
+	// dijalankan saat OPD menghapus hasil clone pokin pemda, tidak punya tombol aksi
+	AksiPokinBatalTerima: {
+		Nama:      "Batal Terima Pohon Kinerja Pemda",
+		KataKerja: "dibatalkan",
+		Dari:      []string{StatusPokinDisetujui},
+		Ke:        StatusPokinMenungguDisetujui,
+	},
+}
+
+// RiwayatStatusPokin satu transisi status pohon kinerja beserta alasannya
+type RiwayatStatusPokin struct {
+	Id          int
+	PokinId     int
+	Aksi        string
+	StatusAwal  string
+	StatusAkhir string
+	Alasan      string
+	NipAktor    string
+	NamaAktor   string
+	KodeOpd     string
+	Tahun       string
+	CreatedAt   time.Time
+}
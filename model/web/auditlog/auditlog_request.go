@@ -0,0 +1,14 @@
+package auditlog
+
+// AuditLogFilterRequest diisi dari query string, tanggal memakai format YYYY-MM-DD
+type AuditLogFilterRequest struct {
+	EntityType   string `json:"entity_type"`
+	EntityId     string `json:"entity_id"`
+	KodeOpd      string `json:"kode_opd"`
+	Tahun        string `json:"tahun"`
+	UserId       int    `json:"user_id"`
+	Nip          string `json:"nip"`
+	TanggalAwal  string `json:"tanggal_awal"`
+	TanggalAkhir string `json:"tanggal_akhir"`
+	Limit        int    `json:"limit"`
+}
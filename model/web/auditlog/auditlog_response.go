@@ -0,0 +1,17 @@
+package auditlog
+
+import "encoding/json"
+
+type AuditLogResponse struct {
+	Id         int64           `json:"id"`
+	UserId     int             `json:"user_id"`
+	Nip        string          `json:"nip"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityId   string          `json:"entity_id"`
+	KodeOpd    string          `json:"kode_opd"`
+	Tahun      string          `json:"tahun"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt  string          `json:"created_at"`
+}
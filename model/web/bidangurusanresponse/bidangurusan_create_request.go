@@ -1,12 +1,12 @@
 package bidangurusanresponse
 
 type BidangUrusanCreateRequest struct {
-	KodeBidangUrusan string `json:"kode_bidang_urusan"`
-	NamaBidangUrusan string `json:"nama_bidang_urusan"`
-	Tahun            string `json:"tahun"`
+	KodeBidangUrusan string `json:"kode_bidang_urusan" validate:"required"`
+	NamaBidangUrusan string `json:"nama_bidang_urusan" validate:"required"`
+	Tahun            string `json:"tahun" validate:"omitempty,tahun"`
 }
 
 type BidangUrusanOPDCreateRequest struct {
-	KodeBidangUrusan string `json:"kode_bidang_urusan"`
-	KodeOpd          string `json:"kode_opd"`
+	KodeBidangUrusan string `json:"kode_bidang_urusan" validate:"required"`
+	KodeOpd          string `json:"kode_opd" validate:"required"`
 }
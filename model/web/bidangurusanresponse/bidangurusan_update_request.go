@@ -1,14 +1,14 @@
 package bidangurusanresponse
 
 type BidangUrusanUpdateRequest struct {
-	Id               string `json:"id"`
-	KodeBidangUrusan string `json:"kode_bidang_urusan"`
-	NamaBidangUrusan string `json:"nama_bidang_urusan"`
-	Tahun            string `json:"tahun"`
+	Id               string `json:"id" validate:"required"`
+	KodeBidangUrusan string `json:"kode_bidang_urusan" validate:"required"`
+	NamaBidangUrusan string `json:"nama_bidang_urusan" validate:"required"`
+	Tahun            string `json:"tahun" validate:"omitempty,tahun"`
 }
 
 type BidangUrusanOPDUpdateRequest struct {
-	Id               int    `json:"id"`
-	KodeBidangUrusan string `json:"kode_bidang_urusan"`
-	KodeOpd          string `json:"kode_opd"`
+	Id               int    `json:"id" validate:"required"`
+	KodeBidangUrusan string `json:"kode_bidang_urusan" validate:"required"`
+	KodeOpd          string `json:"kode_opd" validate:"required"`
 }
@@ -1,15 +1,34 @@
 package web
 
-// CustomError untuk menandai error yang harus dikembalikan sebagai Bad Request
+import "strings"
+
+// CustomError adalah error bertipe yang dikembalikan service, Code berisi HTTP status
+// yang dipakai exception.WriteError saat mengubahnya menjadi WebResponse
 type CustomError struct {
 	Code    int
 	Message string
+	Errors  []FieldError
+}
+
+// FieldError adalah pesan validasi untuk satu field request, Field memakai nama json
+// lengkap dengan posisi elemen, misalnya indikator[0].target[1].tahun
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func (e *CustomError) Error() string {
 	return e.Message
 }
 
+// ResponseData mengembalikan daftar FieldError jika ada, selain itu pesan error
+func (e *CustomError) ResponseData() interface{} {
+	if len(e.Errors) > 0 {
+		return e.Errors
+	}
+	return e.Message
+}
+
 func NewBadRequestError(message string) *CustomError {
 	return &CustomError{
 		Code:    400,
@@ -17,9 +36,59 @@ func NewBadRequestError(message string) *CustomError {
 	}
 }
 
+// NewValidationError untuk input yang tidak lolos validasi
+func NewValidationError(message string) *CustomError {
+	return &CustomError{
+		Code:    400,
+		Message: message,
+	}
+}
+
+// NewFieldValidationError untuk hasil validasi struct request, setiap field yang gagal punya pesannya sendiri
+func NewFieldValidationError(errors []FieldError) *CustomError {
+	messages := make([]string, 0, len(errors))
+	for _, fieldError := range errors {
+		messages = append(messages, fieldError.Message)
+	}
+	return &CustomError{
+		Code:    400,
+		Message: "validasi gagal: " + strings.Join(messages, "; "),
+		Errors:  errors,
+	}
+}
+
 func NewNotFoundError(message string) *CustomError {
 	return &CustomError{
 		Code:    404,
 		Message: message,
 	}
 }
+
+func NewUnauthorizedError(message string) *CustomError {
+	return &CustomError{
+		Code:    401,
+		Message: message,
+	}
+}
+
+func NewForbiddenError(message string) *CustomError {
+	return &CustomError{
+		Code:    403,
+		Message: message,
+	}
+}
+
+func NewLockedError(message string) *CustomError {
+	return &CustomError{
+		Code:    423,
+		Message: message,
+	}
+}
+
+// NewConflictError untuk data yang bentrok dengan data yang sudah ada (duplikat, status tidak sesuai)
+func NewConflictError(message string) *CustomError {
+	return &CustomError{
+		Code:    409,
+		Message: message,
+	}
+}
@@ -1,9 +1,9 @@
 package dasarhukum
 
 type DasarHukumCreateRequest struct {
-	RekinId          string `json:"rencana_kinerja_id"`
+	RekinId          string `json:"rencana_kinerja_id" validate:"required"`
 	KodeOpd          string `json:"kode_opd"`
 	Urutan           int    `json:"urutan"`
-	PeraturanTerkait string `json:"peraturan_terkait"`
+	PeraturanTerkait string `json:"peraturan_terkait" validate:"required"`
 	Uraian           string `json:"uraian"`
 }
@@ -1,9 +1,9 @@
 package dasarhukum
 
 type DasarHukumUpdateRequest struct {
-	Id               string `json:"id"`
+	Id               string `json:"id" validate:"required"`
 	RekinId          string `json:"rencana_kinerja_id"`
 	Urutan           int    `json:"urutan"`
-	PeraturanTerkait string `json:"peraturan_terkait"`
+	PeraturanTerkait string `json:"peraturan_terkait" validate:"required"`
 	Uraian           string `json:"uraian"`
 }
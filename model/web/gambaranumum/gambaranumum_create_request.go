@@ -1,8 +1,8 @@
 package gambaranumum
 
 type GambaranUmumCreateRequest struct {
-	RekinId      string `json:"rekin_id"`
+	RekinId      string `json:"rekin_id" validate:"required"`
 	KodeOpd      string `json:"kode_opd"`
 	Urutan       int    `json:"urutan"`
-	GambaranUmum string `json:"gambaran_umum"`
+	GambaranUmum string `json:"gambaran_umum" validate:"required"`
 }
@@ -1,7 +1,7 @@
 package gambaranumum
 
 type GambaranUmumUpdateRequest struct {
-	Id           string `json:"id"`
+	Id           string `json:"id" validate:"required"`
 	Urutan       int    `json:"urutan"`
-	GambaranUmum string `json:"gambaran_umum"`
+	GambaranUmum string `json:"gambaran_umum" validate:"required"`
 }
@@ -0,0 +1,30 @@
+package health
+
+// Status health check
+const (
+	StatusUp       = "UP"
+	StatusDegraded = "DEGRADED"
+	StatusDown     = "DOWN"
+)
+
+type HealthCheckResponse struct {
+	Name      string      `json:"name"`
+	Status    string      `json:"status"`
+	LatencyMs int64       `json:"latency_ms"`
+	Error     string      `json:"error,omitempty"`
+	Detail    interface{} `json:"detail,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string                `json:"status"`
+	Checks []HealthCheckResponse `json:"checks"`
+}
+
+// MysqlPoolResponse statistik connection pool database
+type MysqlPoolResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+}
@@ -1,9 +1,9 @@
 package inovasi
 
 type InovasiCreateRequest struct {
-	RekinId               string `json:"rencana_kinerja_id"`
+	RekinId               string `json:"rencana_kinerja_id" validate:"required"`
 	KodeOpd               string `json:"kode_opd"`
-	JudulInovasi          string `json:"judul_inovasi"`
+	JudulInovasi          string `json:"judul_inovasi" validate:"required"`
 	JenisInovasi          string `json:"jenis_inovasi"`
 	GambaranNilaiKebaruan string `json:"gambaran_nilai_kebaruan"`
 }
@@ -1,9 +1,9 @@
 package inovasi
 
 type InovasiUpdateRequest struct {
-	Id                    string `json:"id"`
+	Id                    string `json:"id" validate:"required"`
 	RekinId               string `json:"rencana_kinerja_id"`
-	JudulInovasi          string `json:"judul_inovasi"`
+	JudulInovasi          string `json:"judul_inovasi" validate:"required"`
 	JenisInovasi          string `json:"jenis_inovasi"`
 	GambaranNilaiKebaruan string `json:"gambaran_nilai_kebaruan"`
 }
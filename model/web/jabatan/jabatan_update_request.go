@@ -1,14 +1,14 @@
 package jabatan
 
 type JabatanUpdateRequest struct {
-	Id           string `json:"id"`
+	Id           string `json:"id" validate:"required"`
 	KodeJabatan  string `json:"kode_jabatan"`
-	NamaJabatan  string `json:"nama_jabatan"`
+	NamaJabatan  string `json:"nama_jabatan" validate:"required"`
 	KelasJabatan string `json:"kelas_jabatan"`
 	JenisJabatan string `json:"jenis_jabatan"`
 	NilaiJabatan int    `json:"nilai_jabatan"`
-	KodeOpd      string `json:"kode_opd"`
+	KodeOpd      string `json:"kode_opd" validate:"required"`
 	IndexJabatan int    `json:"index_jabatan"`
-	Tahun        string `json:"tahun"`
+	Tahun        string `json:"tahun" validate:"omitempty,tahun"`
 	Esselon      string `json:"esselon"`
 }
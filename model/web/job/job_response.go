@@ -0,0 +1,28 @@
+package job
+
+import "encoding/json"
+
+type JobWarningResponse struct {
+	Type    string `json:"type"`
+	Count   int    `json:"count"`
+	Message string `json:"message"`
+}
+
+type JobResponse struct {
+	Id              int64                `json:"id"`
+	Jenis           string               `json:"jenis"`
+	Status          string               `json:"status"`
+	Progress        int                  `json:"progress"`
+	Attempts        int                  `json:"attempts"`
+	MaxAttempts     int                  `json:"max_attempts"`
+	CancelRequested bool                 `json:"cancel_requested"`
+	KodeOpd         string               `json:"kode_opd"`
+	Tahun           string               `json:"tahun"`
+	Warnings        []JobWarningResponse `json:"warnings"`
+	Result          json.RawMessage      `json:"result,omitempty" swaggertype:"object"`
+	ErrorMessage    string               `json:"error_message,omitempty"`
+	CreatedAt       string               `json:"created_at"`
+	StartedAt       string               `json:"started_at,omitempty"`
+	HeartbeatAt     string               `json:"heartbeat_at,omitempty"`
+	FinishedAt      string               `json:"finished_at,omitempty"`
+}
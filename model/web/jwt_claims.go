@@ -3,6 +3,7 @@ package web
 type JWTClaim struct {
 	Issuer    string   `json:"iss"`
 	Subject   string   `json:"sub"`
+	SessionId string   `json:"sid"`
 	UserId    int      `json:"user_id"`
 	PegawaiId string   `json:"pegawai_id"`
 	KodeOpd   string   `json:"kode_opd"`
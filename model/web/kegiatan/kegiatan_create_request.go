@@ -1,20 +1,20 @@
 package kegiatan
 
 type KegiatanCreateRequest struct {
-	NamaKegiatan string `json:"nama_kegiatan"`
-	KodeKegiatan string `json:"kode_kegiatan"`
-	Indikator    []IndikatorCreateRequest
+	NamaKegiatan string                   `json:"nama_kegiatan" validate:"required"`
+	KodeKegiatan string                   `json:"kode_kegiatan" validate:"required"`
+	Indikator    []IndikatorCreateRequest `validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
-	Indikator string `json:"indikator"`
-	Tahun     string `json:"tahun"`
-	Target    []TargetCreateRequest
+	Indikator string                `json:"indikator" validate:"required"`
+	Tahun     string                `json:"tahun" validate:"omitempty,tahun"`
+	Target    []TargetCreateRequest `validate:"dive"`
 }
 
 type TargetCreateRequest struct {
 	IndikatorId string `json:"indikator_id"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	Target      string `json:"target"`
 	Satuan      string `json:"satuan"`
 }
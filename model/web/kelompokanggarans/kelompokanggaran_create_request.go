@@ -1,7 +1,7 @@
 package kelompokanggarans
 
 type KelompokAnggaranCreateRequest struct {
-	Tahun        string `json:"tahun" validate:"required"`
+	Tahun        string `json:"tahun" validate:"required,tahun"`
 	Kelompok     string `json:"kelompok" validate:"required"`
 	KodeKelompok string `json:"kode_kelompok" `
 }
@@ -2,7 +2,7 @@ package kelompokanggarans
 
 type KelompokAnggaranUpdateRequest struct {
 	Id           int    `json:"id" validate:"required"`
-	Tahun        string `json:"tahun" validate:"required"`
+	Tahun        string `json:"tahun" validate:"required,tahun"`
 	Kelompok     string `json:"kelompok" validate:"required"`
 	KodeKelompok string `json:"kode_kelompok"`
 }
@@ -1,11 +1,11 @@
 package lembaga
 
 type LembagaUpdateRequest struct {
-	Id                 string `json:"id"`
+	Id                 string `json:"id" validate:"required"`
 	KodeLembaga        string `json:"kode_lembaga" validate:"required"`
 	NamaLembaga        string `json:"nama_lembaga" validate:"required"`
 	NamaKepalaPemda    string `json:"nama_kepala_pemda"`
 	JabatanKepalaPemda string `json:"jabatan_kepala_pemda"`
-	NipKepalaPemda     string `json:"nip_kepala_pemda"`
+	NipKepalaPemda     string `json:"nip_kepala_pemda" validate:"omitempty,nip_pegawai"`
 	IsActive           bool   `json:"is_active"`
 }
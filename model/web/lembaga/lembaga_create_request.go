@@ -5,5 +5,5 @@ type LembagaCreateRequest struct {
 	NamaLembaga        string `json:"nama_lembaga" validate:"required"`
 	NamaKepalaPemda    string `json:"nama_kepala_pemda"`
 	JabatanKepalaPemda string `json:"jabatan_kepala_pemda"`
-	NipKepalaPemda     string `json:"nip_kepala_pemda"`
+	NipKepalaPemda     string `json:"nip_kepala_pemda" validate:"omitempty,nip_pegawai"`
 }
@@ -0,0 +1,7 @@
+package lockdata
+
+type LockDataRequest struct {
+	JenisData string `json:"jenis_data" validate:"required"`
+	KodeOpd   string `json:"kode_opd" validate:"required"`
+	Tahun     string `json:"tahun" validate:"required,tahun"`
+}
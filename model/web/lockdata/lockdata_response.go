@@ -0,0 +1,11 @@
+package lockdata
+
+type LockDataResponse struct {
+	Id        int    `json:"id,omitempty"`
+	JenisData string `json:"jenis_data"`
+	NamaData  string `json:"nama_data"`
+	KodeOpd   string `json:"kode_opd"`
+	Tahun     string `json:"tahun"`
+	IsLocked  bool   `json:"is_locked"`
+	LockedAt  string `json:"locked_at,omitempty"`
+}
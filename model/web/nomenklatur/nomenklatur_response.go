@@ -0,0 +1,22 @@
+package nomenklatur
+
+// NomenklaturPerubahan satu baris perubahan hasil import, Baris adalah nomor baris di file (0 untuk retire)
+type NomenklaturPerubahan struct {
+	Baris    int    `json:"baris,omitempty"`
+	Level    string `json:"level"`
+	Kode     string `json:"kode"`
+	NamaLama string `json:"nama_lama,omitempty"`
+	NamaBaru string `json:"nama_baru,omitempty"`
+}
+
+// NomenklaturImportResponse ringkasan change set import, DryRun true berarti belum ada data yang diubah
+type NomenklaturImportResponse struct {
+	DryRun       bool                   `json:"dry_run"`
+	TotalBaris   int                    `json:"total_baris"`
+	JumlahInsert int                    `json:"jumlah_insert"`
+	JumlahUpdate int                    `json:"jumlah_update"`
+	JumlahRetire int                    `json:"jumlah_retire"`
+	Insert       []NomenklaturPerubahan `json:"insert"`
+	Update       []NomenklaturPerubahan `json:"update"`
+	Retire       []NomenklaturPerubahan `json:"retire"`
+}
@@ -0,0 +1,25 @@
+package notifikasi
+
+type NotifikasiResponse struct {
+	Id         int64  `json:"id"`
+	Jenis      string `json:"jenis"`
+	Judul      string `json:"judul"`
+	Pesan      string `json:"pesan"`
+	EntityType string `json:"entity_type"`
+	EntityId   string `json:"entity_id"`
+	KodeOpd    string `json:"kode_opd"`
+	Tahun      string `json:"tahun"`
+	// Url halaman atau endpoint terkait notifikasi, kosong jika tidak ada
+	Url       string `json:"url"`
+	Dibaca    bool   `json:"dibaca"`
+	DibacaAt  string `json:"dibaca_at,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type JumlahBelumDibacaResponse struct {
+	BelumDibaca int `json:"belum_dibaca"`
+}
+
+type BacaSemuaResponse struct {
+	Ditandai int64 `json:"ditandai"`
+}
@@ -7,10 +7,10 @@ type OpdCreateRequest struct {
 	Alamat        string `json:"alamat"`
 	Telepon       string `json:"telepon"`
 	Fax           string `json:"fax"`
-	Email         string `json:"email"`
+	Email         string `json:"email" validate:"omitempty,email"`
 	Website       string `json:"website"`
 	NamaKepalaOpd string `json:"nama_kepala_opd" validate:"required"`
-	NIPKepalaOpd  string `json:"nip_kepala_opd" validate:"required"`
+	NIPKepalaOpd  string `json:"nip_kepala_opd" validate:"required,nip_pegawai"`
 	PangkatKepala string `json:"pangkat_kepala" validate:"required"`
 	IdLembaga     string `json:"id_lembaga" validate:"required"`
 }
@@ -1,17 +1,17 @@
 package opdmaster
 
 type OpdUpdateRequest struct {
-	Id            string `json:"id"`
+	Id            string `json:"id" validate:"required"`
 	KodeOpd       string `json:"kode_opd" validate:"required"`
 	NamaOpd       string `json:"nama_opd" validate:"required"`
 	Singkatan     string `json:"singkatan"`
 	Alamat        string `json:"alamat"`
 	Telepon       string `json:"telepon"`
 	Fax           string `json:"fax"`
-	Email         string `json:"email"`
+	Email         string `json:"email" validate:"omitempty,email"`
 	Website       string `json:"website"`
 	NamaKepalaOpd string `json:"nama_kepala_opd" validate:"required"`
-	NIPKepalaOpd  string `json:"nip_kepala_opd" validate:"required"`
+	NIPKepalaOpd  string `json:"nip_kepala_opd" validate:"required,nip_pegawai"`
 	PangkatKepala string `json:"pangkat_kepala" validate:"required"`
 	IdLembaga     string `json:"id_lembaga" validate:"required"`
 }
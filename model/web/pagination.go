@@ -0,0 +1,9 @@
+package web
+
+// Pagination metadata list yang dikirim bersama data di WebResponse
+type Pagination struct {
+	Page      int `json:"page"`
+	PageSize  int `json:"page_size"`
+	TotalData int `json:"total_data"`
+	TotalPage int `json:"total_page"`
+}
@@ -1,15 +1,15 @@
 package pegawai
 
 type PegawaiCreateRequest struct {
-	NamaPegawai string `json:"nama_pegawai"`
-	Nip         string `json:"nip"`
-	KodeOpd     string `json:"kode_opd"`
+	NamaPegawai string `json:"nama_pegawai" validate:"required"`
+	Nip         string `json:"nip" validate:"required,nip_pegawai"`
+	KodeOpd     string `json:"kode_opd" validate:"required"`
 }
 
 type TambahJabatanRequest struct {
-	Nip       string `json:"nip" validate:"required"`
+	Nip       string `json:"nip" validate:"required,nip_pegawai"`
 	IdJabatan string `json:"id_jabatan" validate:"required"`
-	Bulan     int    `json:"bulan" validate:"required"`
-	Tahun     int    `json:"tahun" validate:"required"`
+	Bulan     int    `json:"bulan" validate:"required,min=1,max=12"`
+	Tahun     int    `json:"tahun" validate:"required,tahun"`
 	KodeOpd   string `json:"kode_opd" validate:"required"`
 }
@@ -1,8 +1,8 @@
 package pegawai
 
 type PegawaiUpdateRequest struct {
-	Id          string `json:"id"`
-	NamaPegawai string `json:"nama_pegawai"`
-	Nip         string `json:"nip"`
-	KodeOpd     string `json:"kode_opd"`
+	Id          string `json:"id" validate:"required"`
+	NamaPegawai string `json:"nama_pegawai" validate:"required"`
+	Nip         string `json:"nip" validate:"required,nip_pegawai"`
+	KodeOpd     string `json:"kode_opd" validate:"required"`
 }
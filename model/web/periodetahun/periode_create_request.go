@@ -2,12 +2,12 @@ package periodetahun
 
 type PeriodeCreateRequest struct {
 	Id           int      `json:"id"`
-	TahunAwal    string   `json:"tahun_awal"`
-	TahunAkhir   string   `json:"tahun_akhir"`
-	JenisPeriode string   `json:"jenis_periode"`
-	TahunList    []string `json:"tahun_list"`
+	TahunAwal    string   `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir   string   `json:"tahun_akhir" validate:"required,tahun"`
+	JenisPeriode string   `json:"jenis_periode" validate:"required"`
+	TahunList    []string `json:"tahun_list" validate:"omitempty,dive,tahun"`
 }
 
 type TahunPeriodeCreateRequest struct {
-	Tahun string `json:"tahun"`
+	Tahun string `json:"tahun" validate:"required,tahun"`
 }
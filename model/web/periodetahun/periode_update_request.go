@@ -1,8 +1,8 @@
 package periodetahun
 
 type PeriodeUpdateRequest struct {
-	Id           int    `json:"id"`
-	TahunAwal    string `json:"tahun_awal"`
-	TahunAkhir   string `json:"tahun_akhir"`
-	JenisPeriode string `json:"jenis_periode"`
+	Id           int    `json:"id" validate:"required"`
+	TahunAwal    string `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir   string `json:"tahun_akhir" validate:"required,tahun"`
+	JenisPeriode string `json:"jenis_periode" validate:"required"`
 }
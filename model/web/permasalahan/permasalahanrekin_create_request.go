@@ -2,8 +2,8 @@ package permasalahan
 
 type PermasalahanRekinCreateRequest struct {
 	Id                int    `json:"id"`
-	RekinId           string `json:"rekin_id"`
-	Permasalahan      string `json:"permasalahan"`
+	RekinId           string `json:"rekin_id" validate:"required"`
+	Permasalahan      string `json:"permasalahan" validate:"required"`
 	PenyebabInternal  string `json:"penyebab_internal"`
 	PenyebabEksternal string `json:"penyebab_eksternal"`
 	JenisPermasalahan string `json:"jenis_permasalahan"`
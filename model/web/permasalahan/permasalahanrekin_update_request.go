@@ -1,8 +1,8 @@
 package permasalahan
 
 type PermasalahanRekinUpdateRequest struct {
-	Id                int    `json:"id"`
-	Permasalahan      string `json:"permasalahan"`
+	Id                int    `json:"id" validate:"required"`
+	Permasalahan      string `json:"permasalahan" validate:"required"`
 	PenyebabInternal  string `json:"penyebab_internal"`
 	PenyebabEksternal string `json:"penyebab_eksternal"`
 }
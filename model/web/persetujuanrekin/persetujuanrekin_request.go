@@ -0,0 +1,7 @@
+package persetujuanrekin
+
+type PersetujuanRekinRequest struct {
+	RekinId string `json:"-"`
+	Aksi    string `json:"aksi" validate:"required,oneof=ajukan setujui kembalikan finalkan buka"`
+	Catatan string `json:"catatan"`
+}
@@ -0,0 +1,29 @@
+package persetujuanrekin
+
+type StatusRekinResponse struct {
+	RekinId            string `json:"rekin_id"`
+	NamaRencanaKinerja string `json:"nama_rencana_kinerja"`
+	Status             string `json:"status"`
+	KodeOpd            string `json:"kode_opd"`
+	Tahun              string `json:"tahun"`
+	PegawaiId          string `json:"pegawai_id"`
+	NamaPegawai        string `json:"nama_pegawai"`
+	NipAtasan          string `json:"nip_atasan"`
+	// Terkunci indikator, rencana aksi dan rincian belanja tidak dapat diubah
+	Terkunci bool `json:"terkunci"`
+	// AksiTersedia aksi yang boleh dijalankan user yang sedang login
+	AksiTersedia []string                     `json:"aksi_tersedia"`
+	Riwayat      []RiwayatStatusRekinResponse `json:"riwayat,omitempty"`
+}
+
+type RiwayatStatusRekinResponse struct {
+	Id          int    `json:"id"`
+	Aksi        string `json:"aksi"`
+	StatusAwal  string `json:"status_awal"`
+	StatusAkhir string `json:"status_akhir"`
+	Catatan     string `json:"catatan"`
+	NipAktor    string `json:"nip_aktor"`
+	NamaAktor   string `json:"nama_aktor"`
+	NipAtasan   string `json:"nip_atasan"`
+	CreatedAt   string `json:"created_at"`
+}
@@ -14,7 +14,7 @@ type PkOpdRequest struct {
 
 type HubungkanAtasanRequest struct {
 	KodeOpd    string `json:"kode_opd" validate:"required"`
-	Tahun      int    `json:"tahun" validate:"required"`
+	Tahun      int    `json:"tahun" validate:"required,tahun"`
 	NipBawahan string `json:"nip_bawahan" validate:"required"`
 	NipAtasan  string `json:"nip_atasan" validate:"required"`
 }
@@ -5,10 +5,10 @@ type CrosscuttingOpdUpdateRequest struct {
 	ParentId   int                      `json:"parent_id" validate:"required"`
 	NamaPohon  string                   `json:"nama_pohon" validate:"required"`
 	JenisPohon string                   `json:"jenis_pohon" validate:"required"`
-	LevelPohon int                      `json:"level_pohon" validate:"required"`
+	LevelPohon int                      `json:"level_pohon" validate:"required,level_pohon"`
 	KodeOpd    string                   `json:"kode_opd" validate:"required"`
 	Keterangan string                   `json:"keterangan"`
-	Tahun      string                   `json:"tahun" validate:"required"`
+	Tahun      string                   `json:"tahun" validate:"required,tahun"`
 	Status     string                   `json:"status" validate:"required"`
-	Indikator  []IndikatorUpdateRequest `json:"indikator"`
+	Indikator  []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
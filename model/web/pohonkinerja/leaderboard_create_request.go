@@ -2,6 +2,6 @@ package pohonkinerja
 
 type LeaderboardHiddenUpsertRequest struct {
 	KodeOpd  string `json:"kode_opd" validate:"required"`
-	Tahun    string `json:"tahun" validate:"required,len=4"`
+	Tahun    string `json:"tahun" validate:"required,tahun"`
 	IsHidden bool   `json:"is_hidden"`
 }
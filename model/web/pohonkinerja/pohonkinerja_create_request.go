@@ -2,36 +2,36 @@ package pohonkinerja
 
 type PohonKinerjaCreateRequest struct {
 	Parent       int                      `json:"parent"`
-	NamaPohon    string                   `json:"nama_pohon"`
+	NamaPohon    string                   `json:"nama_pohon" validate:"required"`
 	JenisPohon   string                   `json:"jenis_pohon"`
-	LevelPohon   int                      `json:"level_pohon"`
-	KodeOpd      string                   `json:"kode_opd"`
+	LevelPohon   int                      `json:"level_pohon" validate:"level_pohon=opd"`
+	KodeOpd      string                   `json:"kode_opd" validate:"required"`
 	Keterangan   string                   `json:"keterangan"`
-	Tahun        string                   `json:"tahun"`
+	Tahun        string                   `json:"tahun" validate:"required,tahun"`
 	Status       string                   `json:"status"`
-	PelaksanaId  []PelaksanaCreateRequest `json:"pelaksana"`
-	Indikator    []IndikatorCreateRequest `json:"indikator"`
+	PelaksanaId  []PelaksanaCreateRequest `json:"pelaksana" validate:"dive"`
+	Indikator    []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 	TaggingPokin []TaggingCreateRequest   `json:"tagging"`
 }
 
 type PelaksanaCreateRequest struct {
 	IdPelaksana string `json:"id_pelaksana"`
-	PegawaiId   string `json:"pegawai_id"`
+	PegawaiId   string `json:"pegawai_id" validate:"required"`
 }
 
 type PohonKinerjaAdminCreateRequest struct {
 	CSFRequest   `json:",inline"`
 	Parent       int                      `json:"parent"`
-	NamaPohon    string                   `json:"nama_pohon"`
+	NamaPohon    string                   `json:"nama_pohon" validate:"required"`
 	JenisPohon   string                   `json:"jenis_pohon"`
 	KodeOpd      string                   `json:"kode_opd,omitempty"`
-	LevelPohon   int                      `json:"level_pohon"`
+	LevelPohon   int                      `json:"level_pohon" validate:"level_pohon"`
 	Keterangan   string                   `json:"keterangan"`
-	Tahun        string                   `json:"tahun"`
+	Tahun        string                   `json:"tahun" validate:"required,tahun"`
 	Status       string                   `json:"status"`
 	TaggingPokin []TaggingCreateRequest   `json:"tagging"`
-	Pelaksana    []PelaksanaCreateRequest `json:"pelaksana"`
-	Indikator    []IndikatorCreateRequest `json:"indikator"`
+	Pelaksana    []PelaksanaCreateRequest `json:"pelaksana" validate:"dive"`
+	Indikator    []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type CSFRequest struct {
@@ -42,7 +42,7 @@ type CSFRequest struct {
 	KondisiWujud               string `json:"kondisi_yang_ingin_diwujudkan"`
 }
 type PohonKinerjaAdminStrategicCreateRequest struct {
-	IdToClone int `json:"id"`
+	IdToClone int `json:"id" validate:"required"`
 	Parent    int `json:"parent"`
 	// LevelPohon int `json:"level_pohon"`
 	JenisPohon string `json:"jenis_pohon"`
@@ -51,7 +51,7 @@ type PohonKinerjaAdminStrategicCreateRequest struct {
 
 type IndikatorCreateRequest struct {
 	PohonKinerjaId int                   `json:"pohon_id"`
-	NamaIndikator  string                `json:"indikator"`
+	NamaIndikator  string                `json:"indikator" validate:"required"`
 	Target         []TargetCreateRequest `json:"target"`
 }
 
@@ -68,8 +68,8 @@ type TematikStatusRequest struct {
 
 type PohonKinerjaCloneRequest struct {
 	KodeOpd     string `json:"kode_opd" validate:"required"`
-	TahunSumber string `json:"tahun_sumber" validate:"required,min=4,max=4"`
-	TahunTujuan string `json:"tahun_tujuan" validate:"required,min=4,max=4"`
+	TahunSumber string `json:"tahun_sumber" validate:"required,tahun"`
+	TahunTujuan string `json:"tahun_tujuan" validate:"required,tahun"`
 }
 
 type TaggingCreateRequest struct {
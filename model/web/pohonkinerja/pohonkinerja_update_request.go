@@ -1,38 +1,38 @@
 package pohonkinerja
 
 type PohonKinerjaUpdateRequest struct {
-	Id           int                      `json:"id"`
+	Id           int                      `json:"id" validate:"required"`
 	Parent       int                      `json:"parent"`
-	NamaPohon    string                   `json:"nama_pohon"`
+	NamaPohon    string                   `json:"nama_pohon" validate:"required"`
 	JenisPohon   string                   `json:"jenis_pohon"`
-	LevelPohon   int                      `json:"level_pohon"`
+	LevelPohon   int                      `json:"level_pohon" validate:"omitempty,level_pohon=opd"`
 	KodeOpd      string                   `json:"kode_opd"`
 	Keterangan   string                   `json:"keterangan"`
-	Tahun        string                   `json:"tahun"`
+	Tahun        string                   `json:"tahun" validate:"omitempty,tahun"`
 	Status       string                   `json:"status"`
 	UpdatedBy    string                   `json:"updated_by"`
-	PelaksanaId  []PelaksanaUpdateRequest `json:"pelaksana"`
-	Indikator    []IndikatorUpdateRequest `json:"indikator"`
+	PelaksanaId  []PelaksanaUpdateRequest `json:"pelaksana" validate:"dive"`
+	Indikator    []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 	TaggingPokin []TaggingUpdateRequest   `json:"tagging"`
 }
 
 type PelaksanaUpdateRequest struct {
-	PegawaiId string `json:"pegawai_id"`
+	PegawaiId string `json:"pegawai_id" validate:"required"`
 }
 
 type PohonKinerjaAdminUpdateRequest struct {
-	Id           int                      `json:"id"`
+	Id           int                      `json:"id" validate:"required"`
 	Parent       int                      `json:"parent"`
-	NamaPohon    string                   `json:"nama_pohon"`
+	NamaPohon    string                   `json:"nama_pohon" validate:"required"`
 	JenisPohon   string                   `json:"jenis_pohon"`
 	KodeOpd      string                   `json:"kode_opd,omitempty"`
-	LevelPohon   int                      `json:"level_pohon"`
+	LevelPohon   int                      `json:"level_pohon" validate:"level_pohon"`
 	Keterangan   string                   `json:"keterangan"`
-	Tahun        string                   `json:"tahun"`
+	Tahun        string                   `json:"tahun" validate:"omitempty,tahun"`
 	Status       string                   `json:"status"`
 	TaggingPokin []TaggingUpdateRequest   `json:"tagging"`
-	Pelaksana    []PelaksanaUpdateRequest `json:"pelaksana"`
-	Indikator    []IndikatorUpdateRequest `json:"indikator"`
+	Pelaksana    []PelaksanaUpdateRequest `json:"pelaksana" validate:"dive"`
+	Indikator    []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 	CSFRequest   `json:",inline"`
 	UpdatedBy    string `json:"updated_by"`
 }
@@ -40,7 +40,7 @@ type PohonKinerjaAdminUpdateRequest struct {
 type IndikatorUpdateRequest struct {
 	Id             string                `json:"id"`
 	PohonKinerjaId int                   `json:"pohon_id"`
-	NamaIndikator  string                `json:"indikator"`
+	NamaIndikator  string                `json:"indikator" validate:"required"`
 	Target         []TargetUpdateRequest `json:"target"`
 }
 
@@ -53,6 +53,8 @@ type TargetUpdateRequest struct {
 
 type PohonKinerjaAdminTolakRequest struct {
 	Id int `json:"id" validate:"required"`
+	// Alasan dicatat pada riwayat status pohon kinerja
+	Alasan string `json:"alasan"`
 }
 
 type TaggingUpdateRequest struct {
@@ -68,6 +70,6 @@ type KeteranganTaggingUpdateRequest struct {
 }
 
 type PohonKinerjaUpdateParentRequest struct {
-	Id     int `json:"id"`
+	Id     int `json:"id" validate:"required"`
 	Parent int `json:"parent"`
 }
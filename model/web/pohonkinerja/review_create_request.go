@@ -2,8 +2,8 @@ package pohonkinerja
 
 type ReviewCreateRequest struct {
 	Id             int    `json:"id"`
-	IdPohonKinerja int    `json:"id_pohon_kinerja"`
-	Review         string `json:"review"`
+	IdPohonKinerja int    `json:"id_pohon_kinerja" validate:"required"`
+	Review         string `json:"review" validate:"required"`
 	Keterangan     string `json:"keterangan"`
 	CreatedBy      string `json:"created_by"`
 	JenisPokin     string `json:"jenis_pokin"`
@@ -1,8 +1,8 @@
 package pohonkinerja
 
 type ReviewUpdateRequest struct {
-	Id             int    `json:"id"`
+	Id             int    `json:"id" validate:"required"`
 	IdPohonKinerja int    `json:"id_pohon_kinerja"`
-	Review         string `json:"review"`
+	Review         string `json:"review" validate:"required"`
 	Keterangan     string `json:"keterangan"`
 }
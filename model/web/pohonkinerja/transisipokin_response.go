@@ -0,0 +1,22 @@
+package pohonkinerja
+
+import "ekak_kabupaten_madiun/model/web"
+
+type TransisiPokinResponse struct {
+	PokinId int    `json:"pokin_id"`
+	Status  string `json:"status"`
+	// Action aksi status yang boleh dijalankan user yang sedang login
+	Action  []web.ActionButton           `json:"action"`
+	Riwayat []RiwayatStatusPokinResponse `json:"riwayat"`
+}
+
+type RiwayatStatusPokinResponse struct {
+	Id          int    `json:"id"`
+	Aksi        string `json:"aksi"`
+	StatusAwal  string `json:"status_awal"`
+	StatusAkhir string `json:"status_akhir"`
+	Alasan      string `json:"alasan"`
+	NipAktor    string `json:"nip_aktor"`
+	NamaAktor   string `json:"nama_aktor"`
+	CreatedAt   string `json:"created_at"`
+}
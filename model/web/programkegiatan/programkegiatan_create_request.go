@@ -2,26 +2,26 @@ package programkegiatan
 
 type ProgramKegiatanCreateRequest struct {
 	Id          string                   `json:"id"`
-	KodeProgram string                   `json:"kode_program"`
-	NamaProgram string                   `json:"nama_program"`
+	KodeProgram string                   `json:"kode_program" validate:"required"`
+	NamaProgram string                   `json:"nama_program" validate:"required"`
 	KodeOPD     string                   `json:"kode_opd"`
-	Tahun       string                   `json:"tahun"`
+	Tahun       string                   `json:"tahun" validate:"omitempty,tahun"`
 	IsActive    bool                     `json:"is_active"`
-	Indikator   []IndikatorCreateRequest `json:"indikator"`
+	Indikator   []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
 	Id        string                `json:"id"`
 	ProgramId string                `json:"program_id"`
-	Indikator string                `json:"indikator"`
-	Tahun     string                `json:"tahun"`
-	Target    []TargetCreateRequest `json:"target"`
+	Indikator string                `json:"indikator" validate:"required"`
+	Tahun     string                `json:"tahun" validate:"omitempty,tahun"`
+	Target    []TargetCreateRequest `json:"target" validate:"dive"`
 }
 
 type TargetCreateRequest struct {
 	Id          string `json:"id"`
 	IndikatorId string `json:"indikator_id"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	Target      string `json:"target"`
 	Satuan      string `json:"satuan"`
 }
@@ -44,15 +44,15 @@ type IndikatorRenstraCreateRequest struct {
 type AnggaranRenstraRequest struct {
 	KodeSubKegiatan string `json:"kode_subkegiatan" validate:"required"`
 	KodeOpd         string `json:"kode_opd"         validate:"required"`
-	Tahun           string `json:"tahun"            validate:"required"`
-	Pagu            int64  `json:"pagu_indikatif" validate:"required"`
+	Tahun           string `json:"tahun"            validate:"required,tahun"`
+	Pagu            int64  `json:"pagu_indikatif" validate:"min=0"`
 }
 
 type AnggaranRenjaRequest struct {
 	KodeSubKegiatan string `json:"kode_subkegiatan" validate:"required"`
 	KodeOpd         string `json:"kode_opd"         validate:"required"`
-	Tahun           string `json:"tahun"            validate:"required"`
-	Pagu            int64  `json:"pagu_indikatif" validate:"required"`
+	Tahun           string `json:"tahun"            validate:"required,tahun"`
+	Pagu            int64  `json:"pagu_indikatif" validate:"min=0"`
 }
 
 type TargetRenjaRequest struct {
@@ -65,9 +65,9 @@ type TargetRenjaRequest struct {
 type BatchIndikatorRenjaRequest struct {
 	Kode      string               `json:"kode" validate:"required"`
 	KodeOpd   string               `json:"kode_opd" validate:"required"`
-	Tahun     string               `json:"tahun" validate:"required"` // ← wajib ada
-	Jenis     string               `json:"jenis" validate:"required"` // "ranwal"/"rankhir"
-	Indikator []IndikatorRenjaItem `json:"indikator" validate:"required,min=1"`
+	Tahun     string               `json:"tahun" validate:"required,tahun"` // ← wajib ada
+	Jenis     string               `json:"jenis" validate:"required"`       // "ranwal"/"rankhir"
+	Indikator []IndikatorRenjaItem `json:"indikator" validate:"required,min=1,dive"`
 }
 
 type IndikatorRenjaItem struct {
@@ -81,7 +81,7 @@ type IndikatorRenjaCreateRequest struct {
 	Kode          string `json:"kode" validate:"required"`
 	KodeOpd       string `json:"kode_opd" validate:"required"`
 	Indikator     string `json:"indikator" validate:"required"`
-	Tahun         string `json:"tahun" validate:"required"`
+	Tahun         string `json:"tahun" validate:"required,tahun"`
 	Jenis         string `json:"jenis"`
 	Target        string `json:"target" validate:"required"`
 	Satuan        string `json:"satuan" validate:"required"`
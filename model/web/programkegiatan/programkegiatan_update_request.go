@@ -1,27 +1,27 @@
 package programkegiatan
 
 type ProgramKegiatanUpdateRequest struct {
-	Id          string                   `json:"id"`
-	KodeProgram string                   `json:"kode_program"`
-	NamaProgram string                   `json:"nama_program"`
+	Id          string                   `json:"id" validate:"required"`
+	KodeProgram string                   `json:"kode_program" validate:"required"`
+	NamaProgram string                   `json:"nama_program" validate:"required"`
 	KodeOPD     string                   `json:"kode_opd"`
-	Tahun       string                   `json:"tahun"`
+	Tahun       string                   `json:"tahun" validate:"omitempty,tahun"`
 	IsActive    bool                     `json:"is_active"`
-	Indikator   []IndikatorUpdateRequest `json:"indikator"`
+	Indikator   []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
 	Id        string                `json:"id"`
 	ProgramId string                `json:"program_id"`
-	Indikator string                `json:"indikator"`
-	Tahun     string                `json:"tahun"`
-	Target    []TargetUpdateRequest `json:"target"`
+	Indikator string                `json:"indikator" validate:"required"`
+	Tahun     string                `json:"tahun" validate:"omitempty,tahun"`
+	Target    []TargetUpdateRequest `json:"target" validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id          string `json:"id"`
 	IndikatorId string `json:"indikator_id"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	Target      string `json:"target"`
 	Satuan      string `json:"satuan"`
 }
@@ -31,7 +31,7 @@ type UpdateIndikatorRequest struct {
 	Kode      string `json:"kode" validate:"required"`
 	KodeOpd   string `json:"kode_opd" validate:"required"`
 	Indikator string `json:"indikator" validate:"required"`
-	Tahun     string `json:"tahun" validate:"required"`
+	Tahun     string `json:"tahun" validate:"required,tahun"`
 	Target    string `json:"target" validate:"required"`
 	Satuan    string `json:"satuan" validate:"required"`
 }
@@ -1,10 +1,10 @@
 package programprioritaspusat
 
 type ProgramPrioritasPusatUpdateRequest struct {
-	Id                              int    `json:"id"`
-	NamaTagging                     string `json:"nama_program_prioritas_pusat"`
+	Id                              int    `json:"id" validate:"required"`
+	NamaTagging                     string `json:"nama_program_prioritas_pusat" validate:"required"`
 	KeteranganProgramPrioritasPusat string `json:"rencana_implementasi"`
 	Keterangan                      string `json:"keterangan"`
-	TahunAwal                       string `json:"tahun_awal" validate:"required"`
-	TahunAkhir                      string `json:"tahun_akhir" validate:"required"`
-}
\ No newline at end of file
+	TahunAwal                       string `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir                      string `json:"tahun_akhir" validate:"required,tahun"`
+}
@@ -1,10 +1,10 @@
 package programunggulan
 
 type ProgramUnggulanUpdateRequest struct {
-	Id                        int    `json:"id"`
-	NamaTagging               string `json:"nama_program_unggulan"`
+	Id                        int    `json:"id" validate:"required"`
+	NamaTagging               string `json:"nama_program_unggulan" validate:"required"`
 	KeteranganProgramUnggulan string `json:"rencana_implementasi"`
 	Keterangan                string `json:"keterangan"`
-	TahunAwal                 string `json:"tahun_awal" validate:"required"`
-	TahunAkhir                string `json:"tahun_akhir" validate:"required"`
+	TahunAwal                 string `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir                string `json:"tahun_akhir" validate:"required,tahun"`
 }
@@ -0,0 +1,22 @@
+package realisasi
+
+// RealisasiIndikatorCreateRequest realisasi kumulatif sampai akhir periode, periode yang sudah ada akan diperbarui
+type RealisasiIndikatorCreateRequest struct {
+	IndikatorId  string   `json:"indikator_id" validate:"required"`
+	Tahun        string   `json:"tahun" validate:"required,tahun"`
+	JenisPeriode string   `json:"jenis_periode" validate:"required,oneof=bulan triwulan"`
+	Periode      int      `json:"periode" validate:"required,min=1,max=12"`
+	Realisasi    *float64 `json:"realisasi" validate:"required"`
+	Bukti        string   `json:"bukti"`
+}
+
+type RealisasiIndikatorUpdateRequest struct {
+	Id        int      `json:"-"`
+	Realisasi *float64 `json:"realisasi" validate:"required"`
+	Bukti     string   `json:"bukti"`
+}
+
+type PolaritasIndikatorRequest struct {
+	IndikatorId string `json:"-"`
+	Polaritas   string `json:"polaritas" validate:"required,oneof=positif negatif"`
+}
@@ -0,0 +1,85 @@
+package realisasi
+
+// RealisasiIndikatorResponse capaian dalam persen (2 desimal),
+// null jika target kosong/bukan angka atau belum ada realisasi
+type RealisasiIndikatorResponse struct {
+	Id           int      `json:"id"`
+	IndikatorId  string   `json:"indikator_id"`
+	Tahun        string   `json:"tahun"`
+	JenisPeriode string   `json:"jenis_periode"`
+	Periode      int      `json:"periode"`
+	Realisasi    float64  `json:"realisasi"`
+	Capaian      *float64 `json:"capaian"`
+	Bukti        string   `json:"bukti"`
+	KodeOpd      string   `json:"kode_opd"`
+	CreatedBy    string   `json:"created_by"`
+	UpdatedAt    string   `json:"updated_at,omitempty"`
+}
+
+type PolaritasIndikatorResponse struct {
+	IndikatorId string `json:"indikator_id"`
+	Indikator   string `json:"indikator"`
+	Polaritas   string `json:"polaritas"`
+}
+
+// CapaianIndikatorResponse capaian tahunan dihitung dari realisasi periode terakhir yang diisi
+type CapaianIndikatorResponse struct {
+	Id                string                       `json:"id"`
+	Indikator         string                       `json:"indikator"`
+	Polaritas         string                       `json:"polaritas"`
+	Target            string                       `json:"target"`
+	Satuan            string                       `json:"satuan"`
+	JenisPeriode      string                       `json:"jenis_periode,omitempty"`
+	PeriodeTerakhir   int                          `json:"periode_terakhir,omitempty"`
+	RealisasiTerakhir *float64                     `json:"realisasi_terakhir"`
+	Capaian           *float64                     `json:"capaian"`
+	Realisasi         []RealisasiIndikatorResponse `json:"realisasi"`
+}
+
+type CapaianRekinResponse struct {
+	Id                 string                     `json:"id"`
+	NamaRencanaKinerja string                     `json:"nama_rencana_kinerja"`
+	PegawaiId          string                     `json:"pegawai_id"`
+	Capaian            *float64                   `json:"capaian"`
+	Indikator          []CapaianIndikatorResponse `json:"indikator"`
+}
+
+// CapaianPokinResponse capaian = capaian_langsung (indikator pohon sendiri),
+// jika tidak ada memakai capaian_turunan (rata-rata pohon anak dan rencana kinerja)
+type CapaianPokinResponse struct {
+	Id              int                        `json:"id"`
+	Parent          int                        `json:"parent"`
+	NamaPohon       string                     `json:"nama_pohon"`
+	JenisPohon      string                     `json:"jenis_pohon"`
+	LevelPohon      int                        `json:"level_pohon"`
+	Capaian         *float64                   `json:"capaian"`
+	CapaianLangsung *float64                   `json:"capaian_langsung"`
+	CapaianTurunan  *float64                   `json:"capaian_turunan"`
+	Indikator       []CapaianIndikatorResponse `json:"indikator"`
+	RencanaKinerja  []CapaianRekinResponse     `json:"rencana_kinerja"`
+	Childs          []CapaianPokinResponse     `json:"childs"`
+}
+
+// CapaianSasaranResponse sumber_capaian "indikator" atau "pohon_kinerja" (capaian pohon kinerja sasaran)
+type CapaianSasaranResponse struct {
+	Id            int                        `json:"id"`
+	NamaSasaran   string                     `json:"nama_sasaran"`
+	PokinId       int                        `json:"pokin_id"`
+	Capaian       *float64                   `json:"capaian"`
+	SumberCapaian string                     `json:"sumber_capaian,omitempty"`
+	Indikator     []CapaianIndikatorResponse `json:"indikator"`
+}
+
+type CapaianOpdResponse struct {
+	KodeOpd      string                   `json:"kode_opd"`
+	Tahun        string                   `json:"tahun"`
+	Capaian      *float64                 `json:"capaian"`
+	SasaranOpd   []CapaianSasaranResponse `json:"sasaran_opd"`
+	PohonKinerja []CapaianPokinResponse   `json:"pohon_kinerja"`
+}
+
+type CapaianPemdaResponse struct {
+	Tahun        string                   `json:"tahun"`
+	Capaian      *float64                 `json:"capaian"`
+	SasaranPemda []CapaianSasaranResponse `json:"sasaran_pemda"`
+}
@@ -0,0 +1,15 @@
+package realisasianggaran
+
+// RealisasiAnggaranCreateRequest belanja satu bulan, bulan yang sudah diisi akan diperbarui
+type RealisasiAnggaranCreateRequest struct {
+	RenaksiId  string `json:"renaksi_id" validate:"required"`
+	Bulan      int    `json:"bulan" validate:"required,min=1,max=12"`
+	Realisasi  *int64 `json:"realisasi" validate:"required,min=0"`
+	Keterangan string `json:"keterangan"`
+}
+
+type RealisasiAnggaranUpdateRequest struct {
+	Id         int    `json:"-"`
+	Realisasi  *int64 `json:"realisasi" validate:"required,min=0"`
+	Keterangan string `json:"keterangan"`
+}
@@ -0,0 +1,92 @@
+package realisasianggaran
+
+type RealisasiAnggaranResponse struct {
+	Id         int    `json:"id"`
+	RenaksiId  string `json:"renaksi_id"`
+	Bulan      int    `json:"bulan"`
+	Realisasi  int64  `json:"realisasi"`
+	Keterangan string `json:"keterangan"`
+	KodeOpd    string `json:"kode_opd"`
+	Tahun      string `json:"tahun"`
+	CreatedBy  string `json:"created_by"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// RingkasanSerapan serapan dan rencana serapan kumulatif sampai bulan laporan dalam persen.
+// Serapan dihitung terhadap pagu penetapan subkegiatan, atau total anggaran rincian belanja jika pagu belum ada.
+// Deviasi = serapan - rencana_serapan (persen poin), negatif berarti tertinggal dari jadwal.
+type RingkasanSerapan struct {
+	Pagu           int64    `json:"pagu"`
+	Anggaran       int64    `json:"anggaran"`
+	Realisasi      int64    `json:"realisasi"`
+	Serapan        *float64 `json:"serapan"`
+	RencanaSerapan *float64 `json:"rencana_serapan"`
+	Deviasi        *float64 `json:"deviasi"`
+}
+
+type RealisasiBulanResponse struct {
+	Id                 int      `json:"id,omitempty"`
+	Bulan              int      `json:"bulan"`
+	Bobot              int      `json:"bobot"`
+	Realisasi          int64    `json:"realisasi"`
+	Keterangan         string   `json:"keterangan,omitempty"`
+	RealisasiKumulatif int64    `json:"realisasi_kumulatif"`
+	Serapan            *float64 `json:"serapan"`
+	RencanaSerapan     *float64 `json:"rencana_serapan"`
+}
+
+type RencanaAksiSerapanResponse struct {
+	RenaksiId          string `json:"renaksi_id"`
+	NamaRencanaAksi    string `json:"nama_rencana_aksi"`
+	RencanaKinerjaId   string `json:"rencana_kinerja_id"`
+	NamaRencanaKinerja string `json:"nama_rencana_kinerja"`
+	PegawaiId          string `json:"pegawai_id"`
+	KodeSubkegiatan    string `json:"kode_subkegiatan"`
+	RingkasanSerapan
+	Status string `json:"status"`
+	// BulanRencana bulan yang memiliki bobot pelaksanaan
+	BulanRencana []int `json:"bulan_rencana"`
+	// Bulan rincian per bulan, hanya di detail satu rencana aksi
+	Bulan []RealisasiBulanResponse `json:"bulan,omitempty"`
+}
+
+type SubkegiatanSerapanResponse struct {
+	KodeSubkegiatan string `json:"kode_subkegiatan"`
+	NamaSubkegiatan string `json:"nama_subkegiatan"`
+	RingkasanSerapan
+	RencanaAksi []RencanaAksiSerapanResponse `json:"rencana_aksi"`
+}
+
+type KegiatanSerapanResponse struct {
+	KodeKegiatan string `json:"kode_kegiatan"`
+	NamaKegiatan string `json:"nama_kegiatan"`
+	RingkasanSerapan
+	Subkegiatan []SubkegiatanSerapanResponse `json:"subkegiatan"`
+}
+
+type ProgramSerapanResponse struct {
+	KodeProgram string `json:"kode_program"`
+	NamaProgram string `json:"nama_program"`
+	RingkasanSerapan
+	Kegiatan []KegiatanSerapanResponse `json:"kegiatan"`
+}
+
+type LaporanSerapanResponse struct {
+	KodeOpd string `json:"kode_opd"`
+	Tahun   string `json:"tahun"`
+	Bulan   int    `json:"bulan"`
+	RingkasanSerapan
+	Program []ProgramSerapanResponse `json:"program"`
+}
+
+// DeviasiSerapanResponse rencana aksi yang serapannya tertinggal lebih dari toleransi,
+// belum ada realisasi padahal sudah dijadwalkan, atau melebihi anggaran
+type DeviasiSerapanResponse struct {
+	KodeOpd           string                       `json:"kode_opd"`
+	Tahun             string                       `json:"tahun"`
+	Bulan             int                          `json:"bulan"`
+	Toleransi         float64                      `json:"toleransi"`
+	JumlahRencanaAksi int                          `json:"jumlah_rencana_aksi"`
+	JumlahDeviasi     int                          `json:"jumlah_deviasi"`
+	RencanaAksi       []RencanaAksiSerapanResponse `json:"rencana_aksi"`
+}
@@ -4,7 +4,7 @@ import "github.com/google/uuid"
 
 type PelaksanaanRencanaAksiCreateRequest struct {
 	Id            uuid.UUID `json:"id"`
-	RencanaAksiId string    `json:"rencana_aksi_id"`
-	Bobot         int       `json:"bobot"`
-	Bulan         int       `json:"bulan"`
+	RencanaAksiId string    `json:"rencana_aksi_id" validate:"required"`
+	Bobot         int       `json:"bobot" validate:"min=0"`
+	Bulan         int       `json:"bulan" validate:"required,min=1,max=12"`
 }
@@ -1,7 +1,7 @@
 package rencanaaksi
 
 type PelaksanaanRencanaAksiUpdateRequest struct {
-	Id    string `json:"id"`
-	Bobot int    `json:"bobot"`
-	Bulan int    `json:"bulan"`
+	Id    string `json:"id" validate:"required"`
+	Bobot int    `json:"bobot" validate:"min=0"`
+	Bulan int    `json:"bulan" validate:"required,min=1,max=12"`
 }
@@ -1,8 +1,8 @@
 package rencanaaksi
 
 type RencanaAksiCreateRequest struct {
-	RencanaKinerjaId string `json:"rekin_id"`
+	RencanaKinerjaId string `json:"rekin_id" validate:"required"`
 	KodeOpd          string `json:"kode_opd"`
 	Urutan           int    `json:"urutan"`
-	NamaRencanaAksi  string `json:"nama_rencana_aksi"`
+	NamaRencanaAksi  string `json:"nama_rencana_aksi" validate:"required"`
 }
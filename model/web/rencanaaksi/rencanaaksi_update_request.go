@@ -1,7 +1,7 @@
 package rencanaaksi
 
 type RencanaAksiUpdateRequest struct {
-	Id              string `json:"id"`
+	Id              string `json:"id" validate:"required"`
 	Urutan          int    `json:"urutan"`
-	NamaRencanaAksi string `json:"nama_rencana_aksi"`
+	NamaRencanaAksi string `json:"nama_rencana_aksi" validate:"required"`
 }
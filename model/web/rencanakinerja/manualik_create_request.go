@@ -26,7 +26,7 @@ type OutputDataCreateRequest struct {
 
 type RekinByOpdCloneRequest struct {
 	KodeOpd     string `json:"kode_opd" validate:"required"`
-	TahunSumber string `json:"tahun_sumber" validate:"required,min=4,max=4"`
-	TahunTujuan string `json:"tahun_tujuan" validate:"required,min=4,max=4"`
+	TahunSumber string `json:"tahun_sumber" validate:"required,tahun"`
+	TahunTujuan string `json:"tahun_tujuan" validate:"required,tahun"`
 	UpdatedBy   string `json:"updated_by" validate:"required"`
 }
@@ -1,31 +1,30 @@
 package rencanakinerja
 
 type RencanaKinerjaCreateRequest struct {
-	IdPohon              int                      `json:"id_pohon"`
-	SasaranOpdId         int                      `json:"sasaranopd_id"`
-	NamaRencanaKinerja   string                   `json:"nama_rencana_kinerja"`
-	Tahun                string                   `json:"tahun"`
-	StatusRencanaKinerja string                   `json:"status_rencana_kinerja"`
-	Catatan              string                   `json:"catatan"`
-	KodeOpd              string                   `json:"kode_opd"`
-	PegawaiId            string                   `json:"pegawai_id"`
-	PeriodeId            int                      `json:"periode_id"`
-	TahunAwal            string                   `json:"tahun_awal"`
-	TahunAkhir           string                   `json:"tahun_akhir"`
-	JenisPeriode         string                   `json:"jenis_periode"`
-	Indikator            []IndikatorCreateRequest `json:"indikator"`
+	IdPohon            int                      `json:"id_pohon"`
+	SasaranOpdId       int                      `json:"sasaranopd_id"`
+	NamaRencanaKinerja string                   `json:"nama_rencana_kinerja" validate:"required"`
+	Tahun              string                   `json:"tahun" validate:"required,tahun"`
+	Catatan            string                   `json:"catatan"`
+	KodeOpd            string                   `json:"kode_opd" validate:"required"`
+	PegawaiId          string                   `json:"pegawai_id" validate:"required"`
+	PeriodeId          int                      `json:"periode_id"`
+	TahunAwal          string                   `json:"tahun_awal" validate:"omitempty,tahun"`
+	TahunAkhir         string                   `json:"tahun_akhir" validate:"omitempty,tahun"`
+	JenisPeriode       string                   `json:"jenis_periode"`
+	Indikator          []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
-	NamaIndikator string                `json:"nama_indikator"`
+	NamaIndikator string                `json:"nama_indikator" validate:"required"`
 	Formula       string                `json:"rumus_perhitungan"`
 	SumberData    string                `json:"sumber_data"`
-	Tahun         string                `json:"tahun"`
-	Target        []TargetCreateRequest `json:"target"`
+	Tahun         string                `json:"tahun" validate:"omitempty,tahun"`
+	Target        []TargetCreateRequest `json:"target" validate:"dive"`
 }
 
 type TargetCreateRequest struct {
-	Tahun           string `json:"tahun"`
+	Tahun           string `json:"tahun" validate:"omitempty,tahun"`
 	Target          string `json:"target"`
 	SatuanIndikator string `json:"satuan"`
 }
@@ -1,20 +1,19 @@
 package rencanakinerja
 
 type RencanaKinerjaUpdateRequest struct {
-	Id                   string                   `json:"id"`
-	SasaranOpdId         int                      `json:"sasaranopd_id"`
-	IdPohon              int                      `json:"id_pohon" validate:"required"`
-	NamaRencanaKinerja   string                   `json:"nama_rencana_kinerja" validate:"required"`
-	Tahun                string                   `json:"tahun" validate:"required"`
-	StatusRencanaKinerja string                   `json:"status_rencana_kinerja" validate:"required"`
-	Catatan              string                   `json:"catatan"`
-	KodeOpd              string                   `json:"kode_opd" validate:"required"`
-	PegawaiId            string                   `json:"pegawai_id" validate:"required"`
-	PeriodeId            int                      `json:"periode_id"`
-	TahunAwal            string                   `json:"tahun_awal"`
-	TahunAkhir           string                   `json:"tahun_akhir"`
-	JenisPeriode         string                   `json:"jenis_periode"`
-	Indikator            []IndikatorUpdateRequest `json:"indikator"`
+	Id                 string                   `json:"id" validate:"required"`
+	SasaranOpdId       int                      `json:"sasaranopd_id"`
+	IdPohon            int                      `json:"id_pohon" validate:"required"`
+	NamaRencanaKinerja string                   `json:"nama_rencana_kinerja" validate:"required"`
+	Tahun              string                   `json:"tahun" validate:"required,tahun"`
+	Catatan            string                   `json:"catatan"`
+	KodeOpd            string                   `json:"kode_opd" validate:"required"`
+	PegawaiId          string                   `json:"pegawai_id" validate:"required"`
+	PeriodeId          int                      `json:"periode_id"`
+	TahunAwal          string                   `json:"tahun_awal" validate:"omitempty,tahun"`
+	TahunAkhir         string                   `json:"tahun_akhir" validate:"omitempty,tahun"`
+	JenisPeriode       string                   `json:"jenis_periode"`
+	Indikator          []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
@@ -22,15 +21,15 @@ type IndikatorUpdateRequest struct {
 	RencanaKinerjaId string                `json:"rencana_kinerja_id"`
 	Formula          string                `json:"rumus_perhitungan,omitempty"`
 	SumberData       string                `json:"sumber_data,omitempty"`
-	Indikator        string                `json:"nama_indikator"`
-	Tahun            string                `json:"tahun"`
-	Target           []TargetUpdateRequest `json:"target"`
+	Indikator        string                `json:"nama_indikator" validate:"required"`
+	Tahun            string                `json:"tahun" validate:"omitempty,tahun"`
+	Target           []TargetUpdateRequest `json:"target" validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id              string `json:"id_target"`
 	IndikatorId     string `json:"indikator_id"`
-	Tahun           string `json:"tahun"`
+	Tahun           string `json:"tahun" validate:"omitempty,tahun"`
 	Target          string `json:"target"`
 	SatuanIndikator string `json:"satuan"`
 }
@@ -1,6 +1,6 @@
 package rincianbelanja
 
 type RincianBelanjaCreateRequest struct {
-	RenaksiId string `json:"renaksi_id"`
-	Anggaran  int64  `json:"anggaran"`
+	RenaksiId string `json:"renaksi_id" validate:"required"`
+	Anggaran  int64  `json:"anggaran" validate:"min=0"`
 }
@@ -0,0 +1,6 @@
+package rollforward
+
+type RollForwardOpdRequest struct {
+	KodeOpd     string `json:"kode_opd" validate:"required"`
+	TahunSumber string `json:"tahun_sumber" validate:"required,tahun"`
+}
@@ -0,0 +1,26 @@
+package rollforward
+
+type RollForwardRingkasanResponse struct {
+	Entitas  string `json:"entitas"`
+	Disalin  int    `json:"disalin"`
+	Dilewati int    `json:"dilewati"`
+	Konflik  int    `json:"konflik"`
+}
+
+type RollForwardItemResponse struct {
+	Entitas  string `json:"entitas"`
+	IdSumber string `json:"id_sumber"`
+	IdTujuan string `json:"id_tujuan,omitempty"`
+	Nama     string `json:"nama"`
+	Alasan   string `json:"alasan"`
+}
+
+// RollForwardReportResponse laporan roll forward, disimpan sebagai result job
+type RollForwardReportResponse struct {
+	KodeOpd     string                         `json:"kode_opd"`
+	TahunSumber string                         `json:"tahun_sumber"`
+	TahunTujuan string                         `json:"tahun_tujuan"`
+	Ringkasan   []RollForwardRingkasanResponse `json:"ringkasan"`
+	Dilewati    []RollForwardItemResponse      `json:"dilewati"`
+	Konflik     []RollForwardItemResponse      `json:"konflik"`
+}
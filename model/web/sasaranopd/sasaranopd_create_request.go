@@ -4,26 +4,26 @@ type SasaranOpdCreateRequest struct {
 	IdPohon      int                      `json:"id_pohon"`
 	NamaSasaran  string                   `json:"nama_sasaran" validate:"required"`
 	IdTujuanOpd  int                      `json:"id_tujuan_opd" validate:"required"`
-	TahunAwal    string                   `json:"tahun_awal" validate:"required"`
-	TahunAkhir   string                   `json:"tahun_akhir" validate:"required"`
+	TahunAwal    string                   `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir   string                   `json:"tahun_akhir" validate:"required,tahun"`
 	JenisPeriode string                   `json:"jenis_periode" validate:"required"`
-	Indikator    []IndikatorCreateRequest `json:"indikator" `
+	Indikator    []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
 	Id                  string                `json:"id"`
-	Indikator           string                `json:"indikator"`
+	Indikator           string                `json:"indikator" validate:"required"`
 	KodeIndikator       string                `json:"kode_indikator"`
 	Jenis               string                `json:"jenis"`
 	DefinisiOperasional string                `json:"definisi_operasional"`
 	RumusPerhitungan    string                `json:"rumus_perhitungan"`
 	SumberData          string                `json:"sumber_data"`
-	Target              []TargetCreateRequest `json:"target"`
+	Target              []TargetCreateRequest `json:"target" validate:"dive"`
 }
 
 type TargetCreateRequest struct {
 	Id     string `json:"id"`
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
 }
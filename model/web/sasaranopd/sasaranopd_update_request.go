@@ -1,28 +1,28 @@
 package sasaranopd
 
 type SasaranOpdUpdateRequest struct {
-	IdSasaranOpd int                      `json:"id"`
+	IdSasaranOpd int                      `json:"id" validate:"required"`
 	NamaSasaran  string                   `json:"nama_sasaran" validate:"required"`
-	TahunAwal    string                   `json:"tahun_awal" validate:"required"`
-	TahunAkhir   string                   `json:"tahun_akhir" validate:"required"`
+	TahunAwal    string                   `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir   string                   `json:"tahun_akhir" validate:"required,tahun"`
 	IdTujuanOpd  int                      `json:"id_tujuan_opd" validate:"required"`
 	JenisPeriode string                   `json:"jenis_periode" validate:"required"`
-	Indikator    []IndikatorUpdateRequest `json:"indikator"`
+	Indikator    []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
 	Id                  string                `json:"id"`
 	KodeIndikator       string                `json:"kode_indikator"`
 	DefinisiOperasional string                `json:"definisi_operasional"`
-	Indikator           string                `json:"indikator"`
+	Indikator           string                `json:"indikator" validate:"required"`
 	RumusPerhitungan    string                `json:"rumus_perhitungan"`
 	SumberData          string                `json:"sumber_data"`
-	Target              []TargetUpdateRequest `json:"target"`
+	Target              []TargetUpdateRequest `json:"target" validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id     string `json:"id"`
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
 }
@@ -3,23 +3,23 @@ package sasaranpemda
 type SasaranPemdaCreateRequest struct {
 	SubtemaId     int                      `json:"subtema_id"`
 	TujuanPemdaId int                      `json:"tujuan_pemda_id"`
-	SasaranPemda  string                   `json:"sasaran_pemda"`
+	SasaranPemda  string                   `json:"sasaran_pemda" validate:"required"`
 	PeriodeId     int                      `json:"periode_id"`
-	TahunAwal     string                   `json:"tahun_awal"`
-	TahunAkhir    string                   `json:"tahun_akhir"`
+	TahunAwal     string                   `json:"tahun_awal" validate:"omitempty,tahun"`
+	TahunAkhir    string                   `json:"tahun_akhir" validate:"omitempty,tahun"`
 	JenisPeriode  string                   `json:"jenis_periode"`
-	Indikator     []IndikatorCreateRequest `json:"indikator"`
+	Indikator     []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
-	Indikator        string                `json:"indikator"`
+	Indikator        string                `json:"indikator" validate:"required"`
 	RumusPerhitungan string                `json:"rumus_perhitungan"`
 	SumberData       string                `json:"sumber_data"`
-	Target           []TargetCreateRequest `json:"target"`
+	Target           []TargetCreateRequest `json:"target" validate:"dive"`
 }
 
 type TargetCreateRequest struct {
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 }
@@ -4,12 +4,12 @@ type SubKegiatanCreateRequest struct {
 	Status          string                   `json:"status"`
 	KodeSubkegiatan string                   `json:"kode_subkegiatan"`
 	NamaSubKegiatan string                   `json:"nama_subkegiatan" validate:"required"`
-	Indikator       []IndikatorCreateRequest `json:"indikator"`
+	Indikator       []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
 	Id            string                `json:"id_indikator"`
-	NamaIndikator string                `json:"indikator"`
+	NamaIndikator string                `json:"indikator" validate:"required"`
 	Target        []TargetCreateRequest `json:"targets"`
 }
 
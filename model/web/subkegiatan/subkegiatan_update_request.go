@@ -1,16 +1,16 @@
 package subkegiatan
 
 type SubKegiatanUpdateRequest struct {
-	Id              string                   `json:"id"`
+	Id              string                   `json:"id" validate:"required"`
 	KodeSubkegiatan string                   `json:"kode_subkegiatan"`
-	NamaSubKegiatan string                   `json:"nama_subkegiatan"`
-	Indikator       []IndikatorUpdateRequest `json:"indikator"`
+	NamaSubKegiatan string                   `json:"nama_subkegiatan" validate:"required"`
+	Indikator       []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
 	Id               string                `json:"id_indikator"`
 	RencanaKinerjaId string                `json:"rencana_kinerja_id"`
-	NamaIndikator    string                `json:"nama_indikator"`
+	NamaIndikator    string                `json:"nama_indikator" validate:"required"`
 	Target           []TargetUpdateRequest `json:"targets"`
 }
 
@@ -25,5 +25,5 @@ type SubKegiatanOpdUpdateRequest struct {
 	Id              int    `json:"id"`
 	KodeSubkegiatan string `json:"kode_subkegiatan" validate:"required"`
 	KodeOpd         string `json:"kode_opd" validate:"required"`
-	Tahun           string `json:"tahun" validate:"required"`
+	Tahun           string `json:"tahun" validate:"required,tahun"`
 }
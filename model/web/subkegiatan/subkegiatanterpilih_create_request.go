@@ -1,19 +1,19 @@
 package subkegiatan
 
 type SubKegiatanTerpilihUpdateRequest struct {
-	Id              string `json:"id"`
-	KodeSubKegiatan string `json:"kode_subkegiatan"`
+	Id              string `json:"id" validate:"required"`
+	KodeSubKegiatan string `json:"kode_subkegiatan" validate:"required"`
 }
 
 type SubKegiatanOpdMultipleCreateRequest struct {
-	KodeSubkegiatan []string `json:"kode_subkegiatan" validate:"required,min=1"`
+	KodeSubkegiatan []string `json:"kode_subkegiatan" validate:"required,min=1,dive,required"`
 	KodeOpd         string   `json:"kode_opd" validate:"required"`
-	Tahun           string   `json:"tahun" validate:"required"`
+	Tahun           string   `json:"tahun" validate:"required,tahun"`
 }
 
 // Tetap pertahankan yang lama untuk backward compatibility
 type SubKegiatanOpdCreateRequest struct {
 	KodeSubkegiatan string `json:"kode_subkegiatan" validate:"required"`
 	KodeOpd         string `json:"kode_opd" validate:"required"`
-	Tahun           string `json:"tahun" validate:"required"`
+	Tahun           string `json:"tahun" validate:"required,tahun"`
 }
@@ -1,7 +1,7 @@
 package taggingpokin
 
 type TaggingPokinUpdateRequest struct {
-	Id                int     `json:"id"`
-	NamaTagging       string  `json:"nama_tagging"`
+	Id                int     `json:"id" validate:"required"`
+	NamaTagging       string  `json:"nama_tagging" validate:"required"`
 	KeteranganTagging *string `json:"keterangan_tagging"`
 }
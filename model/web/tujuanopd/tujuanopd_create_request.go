@@ -1,44 +1,44 @@
 package tujuanopd
 
 type TujuanOpdCreateRequest struct {
-	KodeOpd          string                   `json:"kode_opd"`
+	KodeOpd          string                   `json:"kode_opd" validate:"required"`
 	KodeBidangUrusan string                   `json:"kode_bidang_urusan"`
-	Tujuan           string                   `json:"tujuan"`
+	Tujuan           string                   `json:"tujuan" validate:"required"`
 	PeriodeId        int                      `json:"periode_id"`
-	TahunAwal        string                   `json:"tahun_awal"`
-	TahunAkhir       string                   `json:"tahun_akhir"`
+	TahunAwal        string                   `json:"tahun_awal" validate:"omitempty,tahun"`
+	TahunAkhir       string                   `json:"tahun_akhir" validate:"omitempty,tahun"`
 	JenisPeriode     string                   `json:"jenis_periode"`
-	Indikator        []IndikatorCreateRequest `json:"indikator"`
+	Indikator        []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
 	IdTujuanOpd         string                `json:"id_tujuan_opd"`
-	Indikator           string                `json:"indikator"`
+	Indikator           string                `json:"indikator" validate:"required"`
 	RumusPerhitungan    string                `json:"rumus_perhitungan"`
 	SumberData          string                `json:"sumber_data"`
 	Jenis               string                `json:"jenis"`
 	DefinisiOperasional string                `json:"definisi_operasional"`
-	Target              []TargetCreateRequest `json:"target"`
+	Target              []TargetCreateRequest `json:"target" validate:"dive"`
 }
 
 type TargetCreateRequest struct {
 	IndikatorId string `json:"indikator_id"`
 	Target      string `json:"target"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	Satuan      string `json:"satuan"`
 }
 
 type IndikatorUpsertRequest struct {
 	KodeIndikator       string                `json:"kode_indikator"` // kosong = CREATE baru
-	Indikator           string                `json:"indikator"`
+	Indikator           string                `json:"indikator" validate:"required"`
 	DefinisiOperasional string                `json:"definisi_operasional"`
 	RumusPerhitungan    string                `json:"rumus_perhitungan"`
 	SumberData          string                `json:"sumber_data"`
-	Target              []TargetUpsertRequest `json:"target"`
+	Target              []TargetUpsertRequest `json:"target" validate:"dive"`
 }
 type TargetUpsertRequest struct {
 	Id     string `json:"id"` // kosong = CREATE baru
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
 }
@@ -1,15 +1,15 @@
 package tujuanopd
 
 type TujuanOpdUpdateRequest struct {
-	Id               int                      `json:"id"`
-	KodeOpd          string                   `json:"kode_opd"`
+	Id               int                      `json:"id" validate:"required"`
+	KodeOpd          string                   `json:"kode_opd" validate:"required"`
 	KodeBidangUrusan string                   `json:"kode_bidang_urusan"`
-	Tujuan           string                   `json:"tujuan"`
+	Tujuan           string                   `json:"tujuan" validate:"required"`
 	PeriodeId        int                      `json:"periode_id"`
-	TahunAwal        string                   `json:"tahun_awal"`
-	TahunAkhir       string                   `json:"tahun_akhir"`
+	TahunAwal        string                   `json:"tahun_awal" validate:"omitempty,tahun"`
+	TahunAkhir       string                   `json:"tahun_akhir" validate:"omitempty,tahun"`
 	JenisPeriode     string                   `json:"jenis_periode"`
-	Indikator        []IndikatorUpdateRequest `json:"indikator"`
+	Indikator        []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
@@ -18,16 +18,16 @@ type IndikatorUpdateRequest struct {
 	KodeIndikator       string                `json:"kode_indikator"`
 	Jenis               string                `json:"jenis"`
 	DefinisiOperasional string                `json:"definisi_operasional"`
-	Indikator           string                `json:"indikator"`
+	Indikator           string                `json:"indikator" validate:"required"`
 	RumusPerhitungan    string                `json:"rumus_perhitungan"`
 	SumberData          string                `json:"sumber_data"`
-	Target              []TargetUpdateRequest `json:"target"`
+	Target              []TargetUpdateRequest `json:"target" validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id          string `json:"id"`
 	IndikatorId string `json:"indikator_id"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	Target      string `json:"target"`
 	Satuan      string `json:"satuan"`
 }
@@ -3,24 +3,24 @@ package tujuanpemda
 type TujuanPemdaCreateRequest struct {
 	IdVisi            int                      `json:"id_visi"`
 	IdMisi            int                      `json:"id_misi"`
-	TujuanPemda       string                   `json:"tujuan_pemda"`
+	TujuanPemda       string                   `json:"tujuan_pemda" validate:"required"`
 	TematikId         int                      `json:"tema_id"`
 	PeriodeId         int                      `json:"periode_id"`
-	TahunAwalPeriode  string                   `json:"tahun_awal_periode"`
-	TahunAkhirPeriode string                   `json:"tahun_akhir_periode"`
+	TahunAwalPeriode  string                   `json:"tahun_awal_periode" validate:"omitempty,tahun"`
+	TahunAkhirPeriode string                   `json:"tahun_akhir_periode" validate:"omitempty,tahun"`
 	JenisPeriode      string                   `json:"jenis_periode"`
-	Indikator         []IndikatorCreateRequest `json:"indikator"`
+	Indikator         []IndikatorCreateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorCreateRequest struct {
-	Indikator        string                `json:"indikator"`
+	Indikator        string                `json:"indikator" validate:"required"`
 	RumusPerhitungan string                `json:"rumus_perhitungan"`
 	SumberData       string                `json:"sumber_data"`
-	Target           []TargetCreateRequest `json:"target"`
+	Target           []TargetCreateRequest `json:"target" validate:"dive"`
 }
 
 type TargetCreateRequest struct {
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 }
@@ -1,6 +1,6 @@
 package urusanrespon
 
 type UrusanCreateRequest struct {
-	KodeUrusan string `json:"kode_urusan"`
-	NamaUrusan string `json:"nama_urusan"`
+	KodeUrusan string `json:"kode_urusan" validate:"required"`
+	NamaUrusan string `json:"nama_urusan" validate:"required"`
 }
@@ -1,7 +1,7 @@
 package urusanrespon
 
 type UrusanUpdateRequest struct {
-	Id         string `json:"id"`
-	KodeUrusan string `json:"kode_urusan"`
-	NamaUrusan string `json:"nama_urusan"`
+	Id         string `json:"id" validate:"required"`
+	KodeUrusan string `json:"kode_urusan" validate:"required"`
+	NamaUrusan string `json:"nama_urusan" validate:"required"`
 }
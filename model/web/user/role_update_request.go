@@ -1,6 +1,6 @@
 package user
 
 type RoleUpdateRequest struct {
-	Id   int    `json:"id"`
+	Id   int    `json:"id" validate:"required"`
 	Role string `json:"role" validate:"required"`
 }
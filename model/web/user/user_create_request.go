@@ -1,13 +1,13 @@
 package user
 
 type UserCreateRequest struct {
-	Nip      string                  `json:"nip"`
-	Email    string                  `json:"email"`
-	Password string                  `json:"password"`
+	Nip      string                  `json:"nip" validate:"required,nip_pegawai"`
+	Email    string                  `json:"email" validate:"omitempty,email"`
+	Password string                  `json:"password" validate:"required"`
 	IsActive bool                    `json:"is_active"`
-	Role     []UserRoleCreateRequest `json:"role"`
+	Role     []UserRoleCreateRequest `json:"role" validate:"dive"`
 }
 
 type UserRoleCreateRequest struct {
-	RoleId int `json:"role_id"`
+	RoleId int `json:"role_id" validate:"required"`
 }
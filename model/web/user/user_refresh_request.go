@@ -0,0 +1,9 @@
+package user
+
+type UserRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserLogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
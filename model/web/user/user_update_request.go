@@ -1,14 +1,14 @@
 package user
 
 type UserUpdateRequest struct {
-	Id       int                     `json:"id"`
-	Nip      string                  `json:"nip"`
-	Email    string                  `json:"email"`
+	Id       int                     `json:"id" validate:"required"`
+	Nip      string                  `json:"nip" validate:"required,nip_pegawai"`
+	Email    string                  `json:"email" validate:"omitempty,email"`
 	Password string                  `json:"password"`
 	IsActive bool                    `json:"is_active"`
-	Role     []UserRoleUpdateRequest `json:"role"`
+	Role     []UserRoleUpdateRequest `json:"role" validate:"dive"`
 }
 
 type UserRoleUpdateRequest struct {
-	RoleId int `json:"role_id"`
+	RoleId int `json:"role_id" validate:"required"`
 }
@@ -1,9 +1,9 @@
 package usulan
 
 type UsulanMandatoriCreateRequest struct {
-	Usulan           string `json:"usulan"`
+	Usulan           string `json:"usulan" validate:"required"`
 	Uraian           string `json:"uraian"`
-	Tahun            string `json:"tahun"`
+	Tahun            string `json:"tahun" validate:"omitempty,tahun"`
 	RekinId          string `json:"rencana_kinerja_id"`
 	PegawaiId        string `json:"pegawai_id"`
 	KodeOpd          string `json:"kode_opd"`
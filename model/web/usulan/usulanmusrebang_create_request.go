@@ -1,10 +1,10 @@
 package usulan
 
 type UsulanMusrebangCreateRequest struct {
-	Usulan  string `json:"usulan"`
+	Usulan  string `json:"usulan" validate:"required"`
 	Alamat  string `json:"alamat"`
 	Uraian  string `json:"uraian"`
-	Tahun   string `json:"tahun"`
+	Tahun   string `json:"tahun" validate:"omitempty,tahun"`
 	RekinId string `json:"rencana_kinerja_id"`
 	KodeOpd string `json:"kode_opd"`
 	Status  string `json:"status"`
@@ -1,11 +1,11 @@
 package usulan
 
 type UsulanMusrebangUpdateRequest struct {
-	Id      string `json:"id"`
-	Usulan  string `json:"usulan"`
+	Id      string `json:"id" validate:"required"`
+	Usulan  string `json:"usulan" validate:"required"`
 	Alamat  string `json:"alamat"`
 	Uraian  string `json:"uraian"`
-	Tahun   string `json:"tahun"`
+	Tahun   string `json:"tahun" validate:"omitempty,tahun"`
 	KodeOpd string `json:"kode_opd"`
 	Status  string `json:"status"`
 }
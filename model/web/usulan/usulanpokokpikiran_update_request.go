@@ -1,11 +1,11 @@
 package usulan
 
 type UsulanPokokPikiranUpdateRequest struct {
-	Id        string `json:"id"`
-	Usulan    string `json:"usulan"`
+	Id        string `json:"id" validate:"required"`
+	Usulan    string `json:"usulan" validate:"required"`
 	Alamat    string `json:"alamat"`
 	Uraian    string `json:"uraian"`
-	Tahun     string `json:"tahun"`
+	Tahun     string `json:"tahun" validate:"omitempty,tahun"`
 	PegawaiId string `json:"pegawai_id"`
 	KodeOpd   string `json:"kode_opd"`
 	Status    string `json:"status"`
@@ -1,10 +1,10 @@
 package usulan
 
 type UsulanTerpilihCreateRequest struct {
-	JenisUsulan string `json:"jenis_usulan"`
-	UsulanId    string `json:"usulan_id"`
+	JenisUsulan string `json:"jenis_usulan" validate:"required"`
+	UsulanId    string `json:"usulan_id" validate:"required"`
 	RekinId     string `json:"rekin_id"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	KodeOpd     string `json:"kode_opd"`
 	Keterangan  string `json:"keterangan"`
 }
@@ -2,8 +2,8 @@ package visimisipemda
 
 type VisiPemdaCreateRequest struct {
 	Visi              string `json:"visi" validate:"required"`
-	TahunAwalPeriode  string `json:"tahun_awal_periode" validate:"required"`
-	TahunAkhirPeriode string `json:"tahun_akhir_periode" validate:"required"`
+	TahunAwalPeriode  string `json:"tahun_awal_periode" validate:"required,tahun"`
+	TahunAkhirPeriode string `json:"tahun_akhir_periode" validate:"required,tahun"`
 	JenisPeriode      string `json:"jenis_periode" validate:"required"`
 	Keterangan        string `json:"keterangan"`
 }
@@ -1,9 +1,10 @@
 package web
 
 type WebResponse struct {
-	Code   int         `json:"code"`
-	Status string      `json:"status"`
-	Data   interface{} `json:"data"`
+	Code       int         `json:"code"`
+	Status     string      `json:"status"`
+	Data       interface{} `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
 }
 
 type WebRencanaKinerjaResponse struct {
@@ -32,6 +33,7 @@ type WebUsulanMusrebangResponse struct {
 	Data        interface{}    `json:"usulan_musrebang,omitempty"`
 	Action      []ActionButton `json:"pilihan_action,omitempty"`
 	DataPilihan interface{}    `json:"usulan_terpilih_musrebang,omitempty"`
+	Pagination  *Pagination    `json:"pagination,omitempty"`
 }
 
 type WebUsulanMandatoriResponse struct {
@@ -86,10 +88,11 @@ type WebInovasiResponse struct {
 }
 
 type WebSubKegiatanResponse struct {
-	Code   int            `json:"code"`
-	Status string         `json:"status"`
-	Action []ActionButton `json:"pilihan_subkegiatan_action,omitempty"`
-	Data   interface{}    `json:"sub_kegiatan"`
+	Code       int            `json:"code"`
+	Status     string         `json:"status"`
+	Action     []ActionButton `json:"pilihan_subkegiatan_action,omitempty"`
+	Data       interface{}    `json:"sub_kegiatan"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
 }
 
 type WebSubKegiatanTerpilihResponse struct {
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+// AuditLogRepository hanya menyediakan insert dan select, audit log tidak pernah diubah atau dihapus
+type AuditLogRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, auditLog domain.AuditLog) (domain.AuditLog, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter domain.AuditLogFilter) ([]domain.AuditLog, error)
+}
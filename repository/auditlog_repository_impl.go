@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type AuditLogRepositoryImpl struct {
+}
+
+func NewAuditLogRepositoryImpl() *AuditLogRepositoryImpl {
+	return &AuditLogRepositoryImpl{}
+}
+
+func (repository *AuditLogRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, auditLog domain.AuditLog) (domain.AuditLog, error) {
+	script := `
+		INSERT INTO tb_audit_log(user_id, nip, action, entity_type, entity_id, kode_opd, tahun, before_data, after_data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.ExecContext(ctx, script,
+		auditLog.UserId,
+		auditLog.Nip,
+		auditLog.Action,
+		auditLog.EntityType,
+		auditLog.EntityId,
+		auditLog.KodeOpd,
+		auditLog.Tahun,
+		auditLog.BeforeData,
+		auditLog.AfterData,
+	)
+	if err != nil {
+		return auditLog, fmt.Errorf("AuditLogRepository.Create: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return auditLog, fmt.Errorf("AuditLogRepository.Create: %w", err)
+	}
+	auditLog.Id = id
+
+	return auditLog, nil
+}
+
+func (repository *AuditLogRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	script := `
+		SELECT id, user_id, nip, action, entity_type, entity_id, kode_opd, tahun, before_data, after_data, created_at
+		FROM tb_audit_log
+		WHERE 1=1`
+	var params []interface{}
+	if filter.EntityType != "" {
+		script += ` AND entity_type = ?`
+		params = append(params, filter.EntityType)
+	}
+	if filter.EntityId != "" {
+		script += ` AND entity_id = ?`
+		params = append(params, filter.EntityId)
+	}
+	if filter.KodeOpd != "" {
+		script += ` AND kode_opd = ?`
+		params = append(params, filter.KodeOpd)
+	}
+	if filter.Tahun != "" {
+		script += ` AND tahun = ?`
+		params = append(params, filter.Tahun)
+	}
+	if filter.UserId != 0 {
+		script += ` AND user_id = ?`
+		params = append(params, filter.UserId)
+	}
+	if filter.Nip != "" {
+		script += ` AND nip = ?`
+		params = append(params, filter.Nip)
+	}
+	if filter.TanggalAwal != nil {
+		script += ` AND created_at >= ?`
+		params = append(params, *filter.TanggalAwal)
+	}
+	if filter.TanggalAkhir != nil {
+		script += ` AND created_at < ?`
+		params = append(params, *filter.TanggalAkhir)
+	}
+	script += ` ORDER BY created_at DESC, id DESC`
+	if filter.Limit > 0 {
+		script += ` LIMIT ?`
+		params = append(params, filter.Limit)
+	}
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("AuditLogRepository.FindAll: %w", err)
+	}
+	defer rows.Close()
+
+	var auditLogs []domain.AuditLog
+	for rows.Next() {
+		var auditLog domain.AuditLog
+		err := rows.Scan(
+			&auditLog.Id,
+			&auditLog.UserId,
+			&auditLog.Nip,
+			&auditLog.Action,
+			&auditLog.EntityType,
+			&auditLog.EntityId,
+			&auditLog.KodeOpd,
+			&auditLog.Tahun,
+			&auditLog.BeforeData,
+			&auditLog.AfterData,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("AuditLogRepository.FindAll: %w", err)
+		}
+		auditLogs = append(auditLogs, auditLog)
+	}
+	return auditLogs, rows.Err()
+}
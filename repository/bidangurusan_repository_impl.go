@@ -56,7 +56,8 @@ func (repository *BidangUrusanRepositoryImpl) FindById(ctx context.Context, tx *
 }
 
 func (repository *BidangUrusanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.BidangUrusan, error) {
-	script := "SELECT id, kode_bidang_urusan, nama_bidang_urusan, tahun FROM tb_bidang_urusan"
+	// bidang urusan yang sudah dinonaktifkan ApplyImport nomenklatur tidak ditampilkan
+	script := "SELECT id, kode_bidang_urusan, nama_bidang_urusan, tahun FROM tb_bidang_urusan WHERE is_active = TRUE"
 	rows, err := tx.QueryContext(ctx, script)
 	if err != nil {
 		return []domainmaster.BidangUrusan{}, err
@@ -109,7 +110,7 @@ func (repository *BidangUrusanRepositoryImpl) FindByKodeOpd(ctx context.Context,
 	}
 
 	// Membuat query dengan IN clause
-	query := "SELECT id, kode_bidang_urusan, nama_bidang_urusan FROM tb_bidang_urusan WHERE kode_bidang_urusan IN ("
+	query := "SELECT id, kode_bidang_urusan, nama_bidang_urusan FROM tb_bidang_urusan WHERE is_active = TRUE AND kode_bidang_urusan IN ("
 	params := make([]interface{}, len(kodeBidangUrusans))
 	for i := range kodeBidangUrusans {
 		if i > 0 {
@@ -264,9 +265,9 @@ func (repository *BidangUrusanRepositoryImpl) FindBidangUrusanTerpilihByKodeOpd(
 	return results, nil
 }
 
-// Method untuk validasi apakah kode bidang urusan ada di master tb_bidang_urusan
+// Method untuk validasi apakah kode bidang urusan ada di master tb_bidang_urusan dan masih aktif
 func (repository *BidangUrusanRepositoryImpl) IsBidangUrusanMasterExists(ctx context.Context, tx *sql.Tx, kodeBidangUrusan string) (bool, error) {
-	script := "SELECT COUNT(id) FROM tb_bidang_urusan WHERE kode_bidang_urusan = ?"
+	script := "SELECT COUNT(id) FROM tb_bidang_urusan WHERE kode_bidang_urusan = ? AND is_active = TRUE"
 	var count int
 	err := tx.QueryRowContext(ctx, script, kodeBidangUrusan).Scan(&count)
 	if err != nil {
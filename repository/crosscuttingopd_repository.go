@@ -31,4 +31,6 @@ type CrosscuttingOpdRepository interface {
 	DeleteCrosscuttingDiterima(ctx context.Context, tx *sql.Tx, crosscuttingId int) error
 	// Plan B: jika ref tunggal → hanya lepas tautan, pohon kinerja tidak dihapus
 	UnlinkCrosscuttingDiterima(ctx context.Context, tx *sql.Tx, crosscuttingId int) error
+	// FindById satu baris tb_crosscutting, dipakai untuk snapshot audit log
+	FindById(ctx context.Context, tx *sql.Tx, crosscuttingId int) (domain.Crosscutting, error)
 }
@@ -1045,3 +1045,24 @@ func (repository *CrosscuttingOpdRepositoryImpl) FindCrosscuttingFromByPokinIdsB
 	}
 	return result, rows.Err()
 }
+
+func (repository *CrosscuttingOpdRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, crosscuttingId int) (domain.Crosscutting, error) {
+	script := `
+		SELECT id, COALESCE(crosscutting_from, 0), COALESCE(crosscutting_to, 0),
+			COALESCE(keterangan_crosscutting, ''), COALESCE(kode_opd, ''), COALESCE(status, ''), COALESCE(tahun, '')
+		FROM tb_crosscutting WHERE id = ?`
+	var crosscutting domain.Crosscutting
+	err := tx.QueryRowContext(ctx, script, crosscuttingId).Scan(
+		&crosscutting.Id,
+		&crosscutting.CrosscuttingFrom,
+		&crosscutting.CrosscuttingTo,
+		&crosscutting.Keterangan,
+		&crosscutting.KodeOpd,
+		&crosscutting.Status,
+		&crosscutting.Tahun,
+	)
+	if err != nil {
+		return crosscutting, fmt.Errorf("CrosscuttingOpdRepository.FindById: %w", err)
+	}
+	return crosscutting, nil
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"time"
+)
+
+type JobRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, job domain.Job) (domain.Job, error)
+	FindById(ctx context.Context, tx *sql.Tx, id int64) (domain.Job, error)
+	// ClaimNext mengambil satu job PENDING yang sudah waktunya jalan dan menandainya PROCESS.
+	// found false jika antrian kosong atau job sudah diambil worker lain.
+	ClaimNext(ctx context.Context, tx *sql.Tx) (job domain.Job, found bool, err error)
+	// Heartbeat memperbarui heartbeat_at dan progress, sekaligus mengembalikan apakah job diminta batal
+	Heartbeat(ctx context.Context, tx *sql.Tx, id int64, progress int) (cancelRequested bool, err error)
+	Finish(ctx context.Context, tx *sql.Tx, job domain.Job) error
+	// Retry mengembalikan job ke antrian untuk dicoba lagi setelah jeda delay
+	Retry(ctx context.Context, tx *sql.Tx, id int64, errMsg string, delay time.Duration) error
+	// Release mengembalikan job yang terputus karena server berhenti tanpa menghitungnya sebagai percobaan
+	Release(ctx context.Context, tx *sql.Tx, id int64) error
+	RequestCancel(ctx context.Context, tx *sql.Tx, id int64) error
+	// FindStale job PROCESS yang tidak mengirim heartbeat lebih lama dari timeout (worker mati)
+	FindStale(ctx context.Context, tx *sql.Tx, timeout time.Duration) ([]domain.Job, error)
+}
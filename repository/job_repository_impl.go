@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type JobRepositoryImpl struct {
+}
+
+func NewJobRepositoryImpl() *JobRepositoryImpl {
+	return &JobRepositoryImpl{}
+}
+
+const jobColumns = `id, jenis, payload, status, progress, attempts, max_attempts, cancel_requested, warnings, result,
+	error_message, kode_opd, tahun, user_id, nip, run_after, heartbeat_at, started_at, finished_at, created_at, updated_at`
+
+func (repository *JobRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, job domain.Job) (domain.Job, error) {
+	script := `
+		INSERT INTO tb_job(jenis, payload, status, max_attempts, kode_opd, tahun, user_id, nip)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.ExecContext(ctx, script,
+		job.Jenis,
+		job.Payload,
+		domain.JobStatusPending,
+		job.MaxAttempts,
+		job.KodeOpd,
+		job.Tahun,
+		job.UserId,
+		job.Nip,
+	)
+	if err != nil {
+		return job, fmt.Errorf("JobRepository.Create: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return job, fmt.Errorf("JobRepository.Create: %w", err)
+	}
+	job.Id = id
+	job.Status = domain.JobStatusPending
+
+	return job, nil
+}
+
+func (repository *JobRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int64) (domain.Job, error) {
+	script := `SELECT ` + jobColumns + ` FROM tb_job WHERE id = ?`
+	job, err := scanJob(tx.QueryRowContext(ctx, script, id))
+	if err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+func (repository *JobRepositoryImpl) ClaimNext(ctx context.Context, tx *sql.Tx) (domain.Job, bool, error) {
+	script := `SELECT ` + jobColumns + `
+		FROM tb_job
+		WHERE status = ? AND cancel_requested = FALSE AND run_after <= NOW()
+		ORDER BY run_after, id
+		LIMIT 1`
+	job, err := scanJob(tx.QueryRowContext(ctx, script, domain.JobStatusPending))
+	if err == sql.ErrNoRows {
+		return job, false, nil
+	}
+	if err != nil {
+		return job, false, fmt.Errorf("JobRepository.ClaimNext: %w", err)
+	}
+
+	// update bersyarat status PENDING, jika worker lain lebih dulu mengambil job ini maka tidak ada baris yang berubah
+	result, err := tx.ExecContext(ctx, `
+		UPDATE tb_job
+		SET status = ?, attempts = attempts + 1, heartbeat_at = NOW(), started_at = COALESCE(started_at, NOW())
+		WHERE id = ? AND status = ? AND cancel_requested = FALSE`,
+		domain.JobStatusProcess, job.Id, domain.JobStatusPending,
+	)
+	if err != nil {
+		return job, false, fmt.Errorf("JobRepository.ClaimNext: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return job, false, fmt.Errorf("JobRepository.ClaimNext: %w", err)
+	}
+	if affected == 0 {
+		return job, false, nil
+	}
+
+	job.Status = domain.JobStatusProcess
+	job.Attempts++
+	return job, true, nil
+}
+
+func (repository *JobRepositoryImpl) Heartbeat(ctx context.Context, tx *sql.Tx, id int64, progress int) (bool, error) {
+	_, err := tx.ExecContext(ctx, `UPDATE tb_job SET heartbeat_at = NOW(), progress = ? WHERE id = ? AND status = ?`,
+		progress, id, domain.JobStatusProcess)
+	if err != nil {
+		return false, fmt.Errorf("JobRepository.Heartbeat: %w", err)
+	}
+
+	var cancelRequested bool
+	err = tx.QueryRowContext(ctx, `SELECT cancel_requested FROM tb_job WHERE id = ?`, id).Scan(&cancelRequested)
+	if err != nil {
+		return false, fmt.Errorf("JobRepository.Heartbeat: %w", err)
+	}
+	return cancelRequested, nil
+}
+
+func (repository *JobRepositoryImpl) Finish(ctx context.Context, tx *sql.Tx, job domain.Job) error {
+	var warnings interface{}
+	if len(job.Warnings) > 0 {
+		data, err := json.Marshal(job.Warnings)
+		if err != nil {
+			return fmt.Errorf("JobRepository.Finish: %w", err)
+		}
+		warnings = data
+	}
+	var result interface{}
+	if len(job.Result) > 0 {
+		result = job.Result
+	}
+
+	script := `
+		UPDATE tb_job
+		SET status = ?, progress = ?, warnings = ?, result = ?, error_message = ?, heartbeat_at = NULL, finished_at = NOW()
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, script, job.Status, job.Progress, warnings, result, nullIfEmpty(job.ErrorMessage), job.Id)
+	if err != nil {
+		return fmt.Errorf("JobRepository.Finish: %w", err)
+	}
+	return nil
+}
+
+func (repository *JobRepositoryImpl) Retry(ctx context.Context, tx *sql.Tx, id int64, errMsg string, delay time.Duration) error {
+	script := `
+		UPDATE tb_job
+		SET status = ?, error_message = ?, heartbeat_at = NULL, run_after = NOW() + INTERVAL ? SECOND
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, script, domain.JobStatusPending, errMsg, int(delay.Seconds()), id)
+	if err != nil {
+		return fmt.Errorf("JobRepository.Retry: %w", err)
+	}
+	return nil
+}
+
+func (repository *JobRepositoryImpl) Release(ctx context.Context, tx *sql.Tx, id int64) error {
+	script := `
+		UPDATE tb_job
+		SET status = ?, attempts = GREATEST(attempts - 1, 0), heartbeat_at = NULL, run_after = NOW()
+		WHERE id = ? AND status = ?
+	`
+	_, err := tx.ExecContext(ctx, script, domain.JobStatusPending, id, domain.JobStatusProcess)
+	if err != nil {
+		return fmt.Errorf("JobRepository.Release: %w", err)
+	}
+	return nil
+}
+
+func (repository *JobRepositoryImpl) RequestCancel(ctx context.Context, tx *sql.Tx, id int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE tb_job SET cancel_requested = TRUE WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("JobRepository.RequestCancel: %w", err)
+	}
+	return nil
+}
+
+func (repository *JobRepositoryImpl) FindStale(ctx context.Context, tx *sql.Tx, timeout time.Duration) ([]domain.Job, error) {
+	script := `SELECT ` + jobColumns + `
+		FROM tb_job
+		WHERE status = ? AND (heartbeat_at IS NULL OR heartbeat_at < NOW() - INTERVAL ? SECOND)
+		ORDER BY id`
+	rows, err := tx.QueryContext(ctx, script, domain.JobStatusProcess, int(timeout.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("JobRepository.FindStale: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("JobRepository.FindStale: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+type jobScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanJob(row jobScanner) (domain.Job, error) {
+	var job domain.Job
+	var warnings, result, errorMessage sql.NullString
+	err := row.Scan(
+		&job.Id,
+		&job.Jenis,
+		&job.Payload,
+		&job.Status,
+		&job.Progress,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.CancelRequested,
+		&warnings,
+		&result,
+		&errorMessage,
+		&job.KodeOpd,
+		&job.Tahun,
+		&job.UserId,
+		&job.Nip,
+		&job.RunAfter,
+		&job.HeartbeatAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return job, err
+	}
+
+	if warnings.Valid && warnings.String != "" {
+		if err := json.Unmarshal([]byte(warnings.String), &job.Warnings); err != nil {
+			return job, err
+		}
+	}
+	if result.Valid {
+		job.Result = []byte(result.String)
+	}
+	job.ErrorMessage = errorMessage.String
+	return job, nil
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
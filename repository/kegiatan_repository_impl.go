@@ -103,7 +103,8 @@ func (repository *KegiatanRepositoryImpl) FindById(ctx context.Context, tx *sql.
 }
 
 func (repository *KegiatanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.Kegiatan, error) {
-	scriptKegiatan := "SELECT id, nama_kegiatan, kode_kegiatan FROM tb_master_kegiatan"
+	// kegiatan yang sudah dinonaktifkan ApplyImport nomenklatur tidak ditampilkan
+	scriptKegiatan := "SELECT id, nama_kegiatan, kode_kegiatan FROM tb_master_kegiatan WHERE is_active = TRUE"
 	rows, err := tx.QueryContext(ctx, scriptKegiatan)
 	if err != nil {
 		return []domainmaster.Kegiatan{}, err
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listFilter kolom SQL untuk satu filter, boolean untuk kolom seperti is_active yang dikirim sebagai true/false
+type listFilter struct {
+	column  string
+	boolean bool
+}
+
+// listColumns memetakan nama sort dan filter di query string ke kolom SQL.
+// Hanya kolom yang terdaftar yang masuk ke SQL sehingga nilai dari request tidak pernah menjadi bagian query.
+type listColumns struct {
+	sort   map[string]string
+	filter map[string]listFilter
+	// defaultFilter nilai filter yang dipakai jika query string tidak mengirim filter tersebut
+	defaultFilter map[string]string
+	search        []string
+	defaultSort   string
+	// tieBreaker kolom unik yang ditambahkan di akhir ORDER BY agar urutan antar halaman stabil
+	tieBreaker string
+}
+
+// where menyusun kondisi filter dan pencarian q yang ditambahkan setelah "WHERE 1=1".
+// Filter yang tidak dikenal diabaikan karena query string juga membawa parameter lain.
+func (columns listColumns) where(query domain.ListQuery) (string, []interface{}, error) {
+	var script strings.Builder
+	var params []interface{}
+
+	filters := make(map[string]string, len(query.Filters)+len(columns.defaultFilter))
+	for key, value := range columns.defaultFilter {
+		filters[key] = value
+	}
+	for key, value := range query.Filters {
+		filters[key] = value
+	}
+
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		filter, ok := columns.filter[key]
+		if !ok {
+			continue
+		}
+		value := filters[key]
+		if filter.boolean {
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				return "", nil, fmt.Errorf("%w: filter %s harus berupa boolean", domain.ErrInvalidListQuery, key)
+			}
+			script.WriteString(" AND " + filter.column + " = ?")
+			params = append(params, boolValue)
+			continue
+		}
+		script.WriteString(" AND " + filter.column + " = ?")
+		params = append(params, value)
+	}
+
+	if query.Search != "" && len(columns.search) > 0 {
+		pattern := "%" + escapeLike(query.Search) + "%"
+		conditions := make([]string, len(columns.search))
+		for i, column := range columns.search {
+			conditions[i] = column + " LIKE ?"
+			params = append(params, pattern)
+		}
+		script.WriteString(" AND (" + strings.Join(conditions, " OR ") + ")")
+	}
+
+	return script.String(), params, nil
+}
+
+// orderLimit menyusun ORDER BY dan LIMIT/OFFSET, tanpa LIMIT jika query tidak dipaginasi
+func (columns listColumns) orderLimit(query domain.ListQuery) (string, []interface{}, error) {
+	order := columns.defaultSort
+	if query.Sort != "" {
+		column, ok := columns.sort[query.Sort]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: sort %s tidak dikenal", domain.ErrInvalidListQuery, query.Sort)
+		}
+		order = column
+		if query.SortDesc {
+			order += " DESC"
+		}
+	}
+	if columns.tieBreaker != "" && columns.tieBreaker != order {
+		order += ", " + columns.tieBreaker
+	}
+
+	script := " ORDER BY " + order
+	if !query.Paginated() {
+		return script, nil, nil
+	}
+	return script + " LIMIT ? OFFSET ?", []interface{}{query.PageSize, query.Offset()}, nil
+}
+
+// countList menghitung total data hasil filter dari query select yang sama
+func countList(ctx context.Context, tx *sql.Tx, script string, params []interface{}) (int, error) {
+	var total int
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+script+") AS list", params...).Scan(&total)
+	return total, err
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
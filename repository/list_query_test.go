@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"errors"
+	"testing"
+)
+
+func TestListColumns(t *testing.T) {
+	columns := listColumns{
+		sort:        map[string]string{"nama": "p.nama"},
+		filter:      map[string]listFilter{"kode_opd": {column: "p.kode_opd"}, "is_active": {column: "u.is_active", boolean: true}},
+		search:      []string{"p.nama", "u.nip"},
+		defaultSort: "u.id",
+		tieBreaker:  "u.id",
+	}
+
+	where, params, err := columns.where(domain.ListQuery{
+		Search:  "50%_a",
+		Filters: map[string]string{"kode_opd": "1.01", "is_active": "true", "_": "123"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantWhere := " AND u.is_active = ? AND p.kode_opd = ? AND (p.nama LIKE ? OR u.nip LIKE ?)"
+	if where != wantWhere {
+		t.Errorf("where = %q; want %q", where, wantWhere)
+	}
+	if len(params) != 4 || params[0] != true || params[1] != "1.01" || params[2] != `%50\%\_a%` {
+		t.Errorf("params = %v", params)
+	}
+
+	order, limitParams, err := columns.orderLimit(domain.ListQuery{Page: 3, PageSize: 10, Sort: "nama", SortDesc: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order != " ORDER BY p.nama DESC, u.id LIMIT ? OFFSET ?" || limitParams[0] != 10 || limitParams[1] != 20 {
+		t.Errorf("orderLimit = %q %v", order, limitParams)
+	}
+
+	if order, _, _ := columns.orderLimit(domain.ListQuery{}); order != " ORDER BY u.id" {
+		t.Errorf("orderLimit tanpa paginasi = %q", order)
+	}
+
+	if _, _, err := columns.orderLimit(domain.ListQuery{Sort: "password"}); !errors.Is(err, domain.ErrInvalidListQuery) {
+		t.Errorf("sort tidak dikenal error = %v; want ErrInvalidListQuery", err)
+	}
+	if _, _, err := columns.where(domain.ListQuery{Filters: map[string]string{"is_active": "ya"}}); !errors.Is(err, domain.ErrInvalidListQuery) {
+		t.Errorf("filter boolean tidak valid error = %v; want ErrInvalidListQuery", err)
+	}
+
+	columns.defaultFilter = map[string]string{"is_active": "true"}
+	if where, params, _ := columns.where(domain.ListQuery{}); where != " AND u.is_active = ?" || len(params) != 1 || params[0] != true {
+		t.Errorf("defaultFilter = %q %v", where, params)
+	}
+	if where, params, _ := columns.where(domain.ListQuery{Filters: map[string]string{"is_active": "false"}}); where != " AND u.is_active = ?" || len(params) != 1 || params[0] != false {
+		t.Errorf("defaultFilter ditimpa query = %q %v", where, params)
+	}
+}
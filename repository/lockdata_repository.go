@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
 )
 
 type LockDataRepository interface {
@@ -12,4 +13,6 @@ type LockDataRepository interface {
 	Lock(ctx context.Context, tx *sql.Tx, jenisData, kodeOpd, tahun string) error
 	// Unlock: hapus baris lock
 	Unlock(ctx context.Context, tx *sql.Tx, jenisData, kodeOpd, tahun string) error
+	// FindAll: daftar lock, filter kodeOpd/tahun opsional (string kosong = semua)
+	FindAll(ctx context.Context, tx *sql.Tx, kodeOpd, tahun string) ([]domain.LockData, error)
 }
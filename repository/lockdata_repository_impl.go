@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
 	"fmt"
 )
 
@@ -49,3 +50,34 @@ func (r *LockDataRepositoryImpl) Unlock(
 	}
 	return nil
 }
+func (r *LockDataRepositoryImpl) FindAll(
+	ctx context.Context, tx *sql.Tx, kodeOpd, tahun string,
+) ([]domain.LockData, error) {
+	script := `SELECT id, jenis_data, kode_opd, tahun, locked_at FROM tb_lock_data WHERE 1=1`
+	var params []interface{}
+	if kodeOpd != "" {
+		script += ` AND kode_opd=?`
+		params = append(params, kodeOpd)
+	}
+	if tahun != "" {
+		script += ` AND tahun=?`
+		params = append(params, tahun)
+	}
+	script += ` ORDER BY tahun DESC, kode_opd, jenis_data`
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("LockDataRepository.FindAll: %w", err)
+	}
+	defer rows.Close()
+
+	var locks []domain.LockData
+	for rows.Next() {
+		var lock domain.LockData
+		if err := rows.Scan(&lock.Id, &lock.JenisData, &lock.KodeOpd, &lock.Tahun, &lock.LockedAt); err != nil {
+			return nil, fmt.Errorf("LockDataRepository.FindAll: %w", err)
+		}
+		locks = append(locks, lock)
+	}
+	return locks, rows.Err()
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+)
+
+// NomenklaturRepository akses sempit ke tabel master nomenklatur untuk import nomenklatur kemendagri.
+// Update hanya mengubah nama dan status aktif sehingga indikator dan target yang sudah ada tidak tersentuh.
+type NomenklaturRepository interface {
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.Nomenklatur, error)
+	Create(ctx context.Context, tx *sql.Tx, nomenklatur domainmaster.Nomenklatur) error
+	Update(ctx context.Context, tx *sql.Tx, nomenklatur domainmaster.Nomenklatur) error
+}
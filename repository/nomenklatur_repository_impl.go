@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"fmt"
+)
+
+type nomenklaturTable struct {
+	level      string
+	table      string
+	kodeColumn string
+	namaColumn string
+}
+
+// nomenklaturTables urut dari level teratas sampai sub kegiatan
+var nomenklaturTables = []nomenklaturTable{
+	{domainmaster.NomenklaturUrusan, "tb_urusan", "kode_urusan", "nama_urusan"},
+	{domainmaster.NomenklaturBidangUrusan, "tb_bidang_urusan", "kode_bidang_urusan", "nama_bidang_urusan"},
+	{domainmaster.NomenklaturProgram, "tb_master_program", "kode_program", "nama_program"},
+	{domainmaster.NomenklaturKegiatan, "tb_master_kegiatan", "kode_kegiatan", "nama_kegiatan"},
+	{domainmaster.NomenklaturSubKegiatan, "tb_subkegiatan", "kode_subkegiatan", "nama_subkegiatan"},
+}
+
+func findNomenklaturTable(level string) (nomenklaturTable, error) {
+	for _, table := range nomenklaturTables {
+		if table.level == level {
+			return table, nil
+		}
+	}
+	return nomenklaturTable{}, fmt.Errorf("level nomenklatur %s tidak dikenal", level)
+}
+
+type NomenklaturRepositoryImpl struct {
+}
+
+func NewNomenklaturRepositoryImpl() *NomenklaturRepositoryImpl {
+	return &NomenklaturRepositoryImpl{}
+}
+
+func (repository *NomenklaturRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.Nomenklatur, error) {
+	var result []domainmaster.Nomenklatur
+	for _, table := range nomenklaturTables {
+		script := fmt.Sprintf("SELECT id, %s, COALESCE(%s, ''), COALESCE(is_active, TRUE) FROM %s ORDER BY %s",
+			table.kodeColumn, table.namaColumn, table.table, table.kodeColumn)
+
+		rows, err := tx.QueryContext(ctx, script)
+		if err != nil {
+			return nil, fmt.Errorf("NomenklaturRepository.FindAll %s: %w", table.table, err)
+		}
+		for rows.Next() {
+			nomenklatur := domainmaster.Nomenklatur{Level: table.level}
+			if err := rows.Scan(&nomenklatur.Id, &nomenklatur.Kode, &nomenklatur.Nama, &nomenklatur.IsActive); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("NomenklaturRepository.FindAll %s: %w", table.table, err)
+			}
+			result = append(result, nomenklatur)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("NomenklaturRepository.FindAll %s: %w", table.table, err)
+		}
+	}
+	return result, nil
+}
+
+func (repository *NomenklaturRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, nomenklatur domainmaster.Nomenklatur) error {
+	table, err := findNomenklaturTable(nomenklatur.Level)
+	if err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf("INSERT INTO %s (id, %s, %s, is_active) VALUES (?, ?, ?, ?)", table.table, table.kodeColumn, table.namaColumn)
+	if _, err := tx.ExecContext(ctx, script, nomenklatur.Id, nomenklatur.Kode, nomenklatur.Nama, nomenklatur.IsActive); err != nil {
+		return fmt.Errorf("NomenklaturRepository.Create %s: %w", nomenklatur.Kode, err)
+	}
+	return nil
+}
+
+func (repository *NomenklaturRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, nomenklatur domainmaster.Nomenklatur) error {
+	table, err := findNomenklaturTable(nomenklatur.Level)
+	if err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf("UPDATE %s SET %s = ?, is_active = ? WHERE id = ?", table.table, table.namaColumn)
+	if _, err := tx.ExecContext(ctx, script, nomenklatur.Nama, nomenklatur.IsActive, nomenklatur.Id); err != nil {
+		return fmt.Errorf("NomenklaturRepository.Update %s: %w", nomenklatur.Kode, err)
+	}
+	return nil
+}
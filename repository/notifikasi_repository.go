@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type NotifikasiRepository interface {
+	// FindPenerima id user aktif yang cocok dengan penerima, tanpa duplikat
+	FindPenerima(ctx context.Context, tx *sql.Tx, penerima domain.PenerimaNotifikasi) ([]int, error)
+	// Create menyimpan satu baris notifikasi untuk setiap user
+	Create(ctx context.Context, tx *sql.Tx, notifikasi domain.Notifikasi, userIds []int) error
+	FindByUser(ctx context.Context, tx *sql.Tx, userId int, query domain.ListQuery) ([]domain.Notifikasi, int, error)
+	// FindSetelah notifikasi user dengan id lebih besar dari lastId, urut dari yang terlama
+	FindSetelah(ctx context.Context, tx *sql.Tx, userId int, lastId int64, limit int) ([]domain.Notifikasi, error)
+	FindLastId(ctx context.Context, tx *sql.Tx, userId int) (int64, error)
+	CountBelumDibaca(ctx context.Context, tx *sql.Tx, userId int) (int, error)
+	// TandaiDibaca mengembalikan sql.ErrNoRows jika notifikasi bukan milik user
+	TandaiDibaca(ctx context.Context, tx *sql.Tx, id int64, userId int) error
+	TandaiSemuaDibaca(ctx context.Context, tx *sql.Tx, userId int) (int64, error)
+}
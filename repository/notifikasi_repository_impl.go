@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+	"strings"
+)
+
+type NotifikasiRepositoryImpl struct{}
+
+func NewNotifikasiRepositoryImpl() *NotifikasiRepositoryImpl {
+	return &NotifikasiRepositoryImpl{}
+}
+
+var notifikasiListColumns = listColumns{
+	sort: map[string]string{
+		"created_at": "n.created_at",
+		"jenis":      "n.jenis",
+	},
+	filter: map[string]listFilter{
+		"jenis":    {column: "n.jenis"},
+		"kode_opd": {column: "n.kode_opd"},
+		"tahun":    {column: "n.tahun"},
+		"dibaca":   {column: "(n.dibaca_at IS NOT NULL)", boolean: true},
+	},
+	search:      []string{"n.judul", "n.pesan"},
+	defaultSort: "n.id DESC",
+	tieBreaker:  "n.id DESC",
+}
+
+const notifikasiColumns = `n.id, n.user_id, n.jenis, n.judul, COALESCE(n.pesan, ''), n.entity_type, n.entity_id,
+	n.kode_opd, n.tahun, n.url, n.dibaca_at, n.created_at`
+
+func (repository *NotifikasiRepositoryImpl) FindPenerima(ctx context.Context, tx *sql.Tx, penerima domain.PenerimaNotifikasi) ([]int, error) {
+	var conditions []string
+	var params []interface{}
+	if penerima.KodeOpd != "" && len(penerima.Roles) > 0 {
+		conditions = append(conditions, `u.id IN (
+			SELECT ur.user_id FROM tb_user_role ur
+			INNER JOIN tb_role r ON r.id = ur.role_id
+			INNER JOIN tb_pegawai p ON p.nip = u.nip
+			WHERE p.kode_opd = ? AND r.role IN (`+placeholders(len(penerima.Roles))+`))`)
+		params = append(params, penerima.KodeOpd)
+		for _, role := range penerima.Roles {
+			params = append(params, role)
+		}
+	}
+	if len(penerima.Nips) > 0 {
+		conditions = append(conditions, "u.nip IN ("+placeholders(len(penerima.Nips))+")")
+		for _, nip := range penerima.Nips {
+			params = append(params, nip)
+		}
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	script := "SELECT u.id FROM tb_users u WHERE u.is_active = TRUE AND (" + strings.Join(conditions, " OR ") + ") ORDER BY u.id"
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("NotifikasiRepository.FindPenerima: %w", err)
+	}
+	defer rows.Close()
+
+	var userIds []int
+	for rows.Next() {
+		var userId int
+		if err := rows.Scan(&userId); err != nil {
+			return nil, err
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, rows.Err()
+}
+
+func (repository *NotifikasiRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, notifikasi domain.Notifikasi, userIds []int) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(userIds))
+	params := make([]interface{}, 0, len(userIds)*10)
+	for _, userId := range userIds {
+		values = append(values, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		params = append(params, userId, notifikasi.Jenis, notifikasi.Judul, notifikasi.Pesan, notifikasi.EntityType,
+			notifikasi.EntityId, notifikasi.KodeOpd, notifikasi.Tahun, notifikasi.Url, notifikasi.CreatedAt)
+	}
+	script := `INSERT INTO tb_notifikasi (user_id, jenis, judul, pesan, entity_type, entity_id, kode_opd, tahun, url, created_at)
+		VALUES ` + strings.Join(values, ", ")
+	if _, err := tx.ExecContext(ctx, script, params...); err != nil {
+		return fmt.Errorf("NotifikasiRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (repository *NotifikasiRepositoryImpl) FindByUser(ctx context.Context, tx *sql.Tx, userId int, query domain.ListQuery) ([]domain.Notifikasi, int, error) {
+	script := "SELECT " + notifikasiColumns + " FROM tb_notifikasi n WHERE n.user_id = ?"
+	where, params, err := notifikasiListColumns.where(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	script += where
+	params = append([]interface{}{userId}, params...)
+
+	total, err := countList(ctx, tx, script, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("NotifikasiRepository.FindByUser: %w", err)
+	}
+
+	orderLimit, limitParams, err := notifikasiListColumns.orderLimit(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.QueryContext(ctx, script+orderLimit, append(params, limitParams...)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("NotifikasiRepository.FindByUser: %w", err)
+	}
+	defer rows.Close()
+
+	notifikasis, err := scanNotifikasi(rows)
+	return notifikasis, total, err
+}
+
+func (repository *NotifikasiRepositoryImpl) FindSetelah(ctx context.Context, tx *sql.Tx, userId int, lastId int64, limit int) ([]domain.Notifikasi, error) {
+	script := "SELECT " + notifikasiColumns + " FROM tb_notifikasi n WHERE n.user_id = ? AND n.id > ? ORDER BY n.id LIMIT ?"
+	rows, err := tx.QueryContext(ctx, script, userId, lastId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("NotifikasiRepository.FindSetelah: %w", err)
+	}
+	defer rows.Close()
+	return scanNotifikasi(rows)
+}
+
+func (repository *NotifikasiRepositoryImpl) FindLastId(ctx context.Context, tx *sql.Tx, userId int) (int64, error) {
+	var lastId int64
+	err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM tb_notifikasi WHERE user_id = ?", userId).Scan(&lastId)
+	if err != nil {
+		return 0, fmt.Errorf("NotifikasiRepository.FindLastId: %w", err)
+	}
+	return lastId, nil
+}
+
+func (repository *NotifikasiRepositoryImpl) CountBelumDibaca(ctx context.Context, tx *sql.Tx, userId int) (int, error) {
+	var total int
+	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM tb_notifikasi WHERE user_id = ? AND dibaca_at IS NULL", userId).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("NotifikasiRepository.CountBelumDibaca: %w", err)
+	}
+	return total, nil
+}
+
+func (repository *NotifikasiRepositoryImpl) TandaiDibaca(ctx context.Context, tx *sql.Tx, id int64, userId int) error {
+	var dibacaAt sql.NullTime
+	err := tx.QueryRowContext(ctx, "SELECT dibaca_at FROM tb_notifikasi WHERE id = ? AND user_id = ?", id, userId).Scan(&dibacaAt)
+	if err != nil {
+		return err
+	}
+	if dibacaAt.Valid {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE tb_notifikasi SET dibaca_at = CURRENT_TIMESTAMP WHERE id = ? AND user_id = ?", id, userId)
+	if err != nil {
+		return fmt.Errorf("NotifikasiRepository.TandaiDibaca: %w", err)
+	}
+	return nil
+}
+
+func (repository *NotifikasiRepositoryImpl) TandaiSemuaDibaca(ctx context.Context, tx *sql.Tx, userId int) (int64, error) {
+	result, err := tx.ExecContext(ctx, "UPDATE tb_notifikasi SET dibaca_at = CURRENT_TIMESTAMP WHERE user_id = ? AND dibaca_at IS NULL", userId)
+	if err != nil {
+		return 0, fmt.Errorf("NotifikasiRepository.TandaiSemuaDibaca: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func scanNotifikasi(rows *sql.Rows) ([]domain.Notifikasi, error) {
+	var result []domain.Notifikasi
+	for rows.Next() {
+		var notifikasi domain.Notifikasi
+		err := rows.Scan(
+			&notifikasi.Id, &notifikasi.UserId, &notifikasi.Jenis, &notifikasi.Judul, &notifikasi.Pesan,
+			&notifikasi.EntityType, &notifikasi.EntityId, &notifikasi.KodeOpd, &notifikasi.Tahun, &notifikasi.Url,
+			&notifikasi.DibacaAt, &notifikasi.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, notifikasi)
+	}
+	return result, rows.Err()
+}
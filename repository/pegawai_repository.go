@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
 )
 
@@ -11,7 +12,7 @@ type PegawaiRepository interface {
 	Update(ctx context.Context, tx *sql.Tx, pegawai domainmaster.Pegawai) domainmaster.Pegawai
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
 	FindById(ctx context.Context, tx *sql.Tx, id string) (domainmaster.Pegawai, error)
-	FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string, nip string) ([]domainmaster.Pegawai, error)
+	FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domainmaster.Pegawai, int, error)
 	FindByNip(ctx context.Context, tx *sql.Tx, nip string) (domainmaster.Pegawai, error)
 	FindByNipWithJabatan(ctx context.Context, tx *sql.Tx, nip string) (domainmaster.Pegawai, error)
 	FindPegawaiByNipsBatch(ctx context.Context, tx *sql.Tx, nips []string) (map[string]*domainmaster.Pegawai, error)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
 	"fmt"
 	"strings"
@@ -53,7 +54,23 @@ func (repository *PegawaiRepositoryImpl) FindById(ctx context.Context, tx *sql.T
 	return pegawai, nil
 }
 
-func (repository *PegawaiRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string, nip string) ([]domainmaster.Pegawai, error) {
+var pegawaiListColumns = listColumns{
+	sort: map[string]string{
+		"nama":     "peg.nama",
+		"nip":      "peg.nip",
+		"kode_opd": "peg.kode_opd",
+		"nama_opd": "opd.nama_opd",
+	},
+	filter: map[string]listFilter{
+		"kode_opd": {column: "peg.kode_opd"},
+		"nip":      {column: "peg.nip"},
+	},
+	search:      []string{"peg.nama", "peg.nip", "jab.nama_jabatan"},
+	defaultSort: "peg.nama ASC",
+	tieBreaker:  "peg.id",
+}
+
+func (repository *PegawaiRepositoryImpl) FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domainmaster.Pegawai, int, error) {
 	script := `SELECT
             peg.id,
             peg.nama,
@@ -71,23 +88,25 @@ func (repository *PegawaiRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 					ORDER BY jp.tahun DESC, jp.bulan DESC
 					LIMIT 1
 				)
-            WHERE 1=1 `
-	var params []any
-
-	if kodeOpd != "" {
-		script += " AND peg.kode_opd = ?"
-		params = append(params, kodeOpd)
+            WHERE 1=1`
+	where, params, err := pegawaiListColumns.where(query)
+	if err != nil {
+		return nil, 0, err
 	}
+	script += where
 
-	if nip != "" {
-		script += " AND peg.nip = ?"
-		params = append(params, nip)
+	total, err := countList(ctx, tx, script, params)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	script += " ORDER BY peg.nama ASC"
-	rows, err := tx.QueryContext(ctx, script, params...)
+	orderLimit, limitParams, err := pegawaiListColumns.orderLimit(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.QueryContext(ctx, script+orderLimit, append(params, limitParams...)...)
 	if err != nil {
-		return []domainmaster.Pegawai{}, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 	var pegawais []domainmaster.Pegawai
@@ -106,7 +125,7 @@ func (repository *PegawaiRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 			&namaJabatan,
 		)
 		if err != nil {
-			return []domainmaster.Pegawai{}, err
+			return nil, 0, err
 		}
 
 		if kodeOpd.Valid {
@@ -120,7 +139,7 @@ func (repository *PegawaiRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 		}
 		pegawais = append(pegawais, pegawai)
 	}
-	return pegawais, nil
+	return pegawais, total, rows.Err()
 }
 
 func (repository *PegawaiRepositoryImpl) FindByNip(ctx context.Context, tx *sql.Tx, nip string) (domainmaster.Pegawai, error) {
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type PersetujuanRekinRepository interface {
+	// FindRekin mengunci baris rekin (FOR UPDATE) agar transisi tidak berjalan bersamaan
+	FindRekin(ctx context.Context, tx *sql.Tx, rekinId string) (domain.StatusRekinPersetujuan, error)
+	FindRekinIdByRenaksiId(ctx context.Context, tx *sql.Tx, renaksiId string) (string, error)
+	FindNipAtasan(ctx context.Context, tx *sql.Tx, nipBawahan string, kodeOpd string, tahun string) (string, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, rekinId string, status string) error
+	CreateRiwayat(ctx context.Context, tx *sql.Tx, riwayat domain.RiwayatStatusRekin) (domain.RiwayatStatusRekin, error)
+	FindRiwayat(ctx context.Context, tx *sql.Tx, rekinId string) ([]domain.RiwayatStatusRekin, error)
+	FindByAtasan(ctx context.Context, tx *sql.Tx, nipAtasan string, tahun string, status string) ([]domain.StatusRekinPersetujuan, error)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"errors"
+	"fmt"
+)
+
+type PersetujuanRekinRepositoryImpl struct{}
+
+func NewPersetujuanRekinRepositoryImpl() *PersetujuanRekinRepositoryImpl {
+	return &PersetujuanRekinRepositoryImpl{}
+}
+
+func (repository *PersetujuanRekinRepositoryImpl) FindRekin(ctx context.Context, tx *sql.Tx, rekinId string) (domain.StatusRekinPersetujuan, error) {
+	script := `
+		SELECT rk.id, rk.nama_rencana_kinerja, COALESCE(rk.status_rencana_kinerja, ''),
+			COALESCE(rk.kode_opd, ''), COALESCE(rk.tahun, ''), COALESCE(rk.pegawai_id, ''),
+			COALESCE((SELECT p.nama FROM tb_pegawai p WHERE p.nip = rk.pegawai_id LIMIT 1), '')
+		FROM tb_rencana_kinerja rk
+		WHERE rk.id = ?
+		FOR UPDATE`
+	var rekin domain.StatusRekinPersetujuan
+	err := tx.QueryRowContext(ctx, script, rekinId).Scan(
+		&rekin.Id, &rekin.NamaRencanaKinerja, &rekin.Status,
+		&rekin.KodeOpd, &rekin.Tahun, &rekin.PegawaiId, &rekin.NamaPegawai,
+	)
+	return rekin, err
+}
+
+func (repository *PersetujuanRekinRepositoryImpl) FindRekinIdByRenaksiId(ctx context.Context, tx *sql.Tx, renaksiId string) (string, error) {
+	var rekinId string
+	err := tx.QueryRowContext(ctx, "SELECT rencana_kinerja_id FROM tb_rencana_aksi WHERE id = ?", renaksiId).Scan(&rekinId)
+	return rekinId, err
+}
+
+// FindNipAtasan atasan dari struktur organisasi OPD pada tahun rekin, "" jika belum ditetapkan
+func (repository *PersetujuanRekinRepositoryImpl) FindNipAtasan(ctx context.Context, tx *sql.Tx, nipBawahan string, kodeOpd string, tahun string) (string, error) {
+	var nipAtasan string
+	err := tx.QueryRowContext(ctx,
+		"SELECT nip_atasan FROM struktur_organisasi WHERE nip_bawahan = ? AND kode_opd = ? AND tahun = ?",
+		nipBawahan, kodeOpd, tahun,
+	).Scan(&nipAtasan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("PersetujuanRekinRepository.FindNipAtasan: %w", err)
+	}
+	return nipAtasan, nil
+}
+
+func (repository *PersetujuanRekinRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, rekinId string, status string) error {
+	_, err := tx.ExecContext(ctx, "UPDATE tb_rencana_kinerja SET status_rencana_kinerja = ? WHERE id = ?", status, rekinId)
+	if err != nil {
+		return fmt.Errorf("PersetujuanRekinRepository.UpdateStatus: %w", err)
+	}
+	return nil
+}
+
+func (repository *PersetujuanRekinRepositoryImpl) CreateRiwayat(ctx context.Context, tx *sql.Tx, riwayat domain.RiwayatStatusRekin) (domain.RiwayatStatusRekin, error) {
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO tb_riwayat_status_rekin (rekin_id, aksi, status_awal, status_akhir, catatan, nip_aktor, nip_atasan, kode_opd, tahun)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		riwayat.RekinId, riwayat.Aksi, riwayat.StatusAwal, riwayat.StatusAkhir, riwayat.Catatan,
+		riwayat.NipAktor, riwayat.NipAtasan, riwayat.KodeOpd, riwayat.Tahun,
+	)
+	if err != nil {
+		return riwayat, fmt.Errorf("PersetujuanRekinRepository.CreateRiwayat: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return riwayat, err
+	}
+	riwayat.Id = int(id)
+	return riwayat, nil
+}
+
+func (repository *PersetujuanRekinRepositoryImpl) FindRiwayat(ctx context.Context, tx *sql.Tx, rekinId string) ([]domain.RiwayatStatusRekin, error) {
+	script := `
+		SELECT r.id, r.rekin_id, r.aksi, r.status_awal, r.status_akhir, COALESCE(r.catatan, ''),
+			r.nip_aktor, COALESCE((SELECT p.nama FROM tb_pegawai p WHERE p.nip = r.nip_aktor LIMIT 1), ''),
+			r.nip_atasan, r.kode_opd, r.tahun, r.created_at
+		FROM tb_riwayat_status_rekin r
+		WHERE r.rekin_id = ?
+		ORDER BY r.created_at, r.id`
+	rows, err := tx.QueryContext(ctx, script, rekinId)
+	if err != nil {
+		return nil, fmt.Errorf("PersetujuanRekinRepository.FindRiwayat: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.RiwayatStatusRekin
+	for rows.Next() {
+		var riwayat domain.RiwayatStatusRekin
+		err := rows.Scan(
+			&riwayat.Id, &riwayat.RekinId, &riwayat.Aksi, &riwayat.StatusAwal, &riwayat.StatusAkhir, &riwayat.Catatan,
+			&riwayat.NipAktor, &riwayat.NamaAktor, &riwayat.NipAtasan, &riwayat.KodeOpd, &riwayat.Tahun, &riwayat.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, riwayat)
+	}
+	return result, rows.Err()
+}
+
+// FindByAtasan rekin bawahan langsung (struktur organisasi tahun yang sama) dengan status tertentu
+func (repository *PersetujuanRekinRepositoryImpl) FindByAtasan(ctx context.Context, tx *sql.Tx, nipAtasan string, tahun string, status string) ([]domain.StatusRekinPersetujuan, error) {
+	script := `
+		SELECT rk.id, rk.nama_rencana_kinerja, COALESCE(rk.status_rencana_kinerja, ''),
+			COALESCE(rk.kode_opd, ''), COALESCE(rk.tahun, ''), COALESCE(rk.pegawai_id, ''),
+			COALESCE((SELECT p.nama FROM tb_pegawai p WHERE p.nip = rk.pegawai_id LIMIT 1), '')
+		FROM tb_rencana_kinerja rk
+		INNER JOIN struktur_organisasi so
+			ON so.nip_bawahan = rk.pegawai_id AND so.kode_opd = rk.kode_opd AND so.tahun = rk.tahun
+		WHERE so.nip_atasan = ? AND rk.tahun = ? AND rk.status_rencana_kinerja = ?
+		ORDER BY rk.pegawai_id, rk.id`
+	rows, err := tx.QueryContext(ctx, script, nipAtasan, tahun, status)
+	if err != nil {
+		return nil, fmt.Errorf("PersetujuanRekinRepository.FindByAtasan: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.StatusRekinPersetujuan
+	for rows.Next() {
+		var rekin domain.StatusRekinPersetujuan
+		err := rows.Scan(
+			&rekin.Id, &rekin.NamaRencanaKinerja, &rekin.Status,
+			&rekin.KodeOpd, &rekin.Tahun, &rekin.PegawaiId, &rekin.NamaPegawai,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rekin)
+	}
+	return result, rows.Err()
+}
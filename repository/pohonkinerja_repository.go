@@ -16,7 +16,6 @@ type PohonKinerjaRepository interface {
 	FindStrategicNoParent(ctx context.Context, tx *sql.Tx, levelPohon, parent int, kodeOpd, tahun string) ([]domain.PohonKinerja, error)
 	FindPelaksanaPokin(ctx context.Context, tx *sql.Tx, pohonKinerjaId string) ([]domain.PelaksanaPokin, error)
 	DeletePelaksanaPokin(ctx context.Context, tx *sql.Tx, pelaksanaId string) error
-	UpdatePokinStatusFromApproved(ctx context.Context, tx *sql.Tx, id int) error
 	UpdateParent(ctx context.Context, tx *sql.Tx, pohonKinerja domain.PohonKinerja) (domain.PohonKinerja, error)
 	FindidPokinWithAllTema(ctx context.Context, tx *sql.Tx, id int) ([]domain.PohonKinerja, error)
 	CheckAsalPokin(ctx context.Context, tx *sql.Tx, id int) (int, error)
@@ -40,6 +39,9 @@ type PohonKinerjaRepository interface {
 	InsertClonedTarget(ctx context.Context, tx *sql.Tx, targetId string, indikatorId string, target domain.Target) error
 	UpdatePokinStatus(ctx context.Context, tx *sql.Tx, id int, status string) error
 	CheckPokinStatus(ctx context.Context, tx *sql.Tx, id int) (string, error)
+	FindStatusPokin(ctx context.Context, tx *sql.Tx, id int) (domain.PohonKinerja, error)
+	CreateRiwayatStatus(ctx context.Context, tx *sql.Tx, riwayat domain.RiwayatStatusPokin) error
+	FindRiwayatStatus(ctx context.Context, tx *sql.Tx, pokinId int) ([]domain.RiwayatStatusPokin, error)
 	InsertClonedPokinWithStatus(ctx context.Context, tx *sql.Tx, pokin domain.PohonKinerja) (int64, error)
 	UpdatePokinStatusTolak(ctx context.Context, tx *sql.Tx, id int, status string) error
 	CheckCloneFrom(ctx context.Context, tx *sql.Tx, id int) (int, error)
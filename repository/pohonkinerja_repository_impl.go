@@ -2464,6 +2464,56 @@ func (repository *PohonKinerjaRepositoryImpl) CheckPokinStatus(ctx context.Conte
 	return status, nil
 }
 
+// FindStatusPokin status, OPD dan tahun pohon kinerja untuk alur transisi status, sql.ErrNoRows jika tidak ada
+func (repository *PohonKinerjaRepositoryImpl) FindStatusPokin(ctx context.Context, tx *sql.Tx, id int) (domain.PohonKinerja, error) {
+	script := "SELECT id, COALESCE(status, ''), COALESCE(kode_opd, ''), COALESCE(tahun, '') FROM tb_pohon_kinerja WHERE id = ?"
+	var pokin domain.PohonKinerja
+	err := tx.QueryRowContext(ctx, script, id).Scan(&pokin.Id, &pokin.Status, &pokin.KodeOpd, &pokin.Tahun)
+	return pokin, err
+}
+
+func (repository *PohonKinerjaRepositoryImpl) CreateRiwayatStatus(ctx context.Context, tx *sql.Tx, riwayat domain.RiwayatStatusPokin) error {
+	script := `INSERT INTO tb_riwayat_status_pokin (pokin_id, aksi, status_awal, status_akhir, alasan, nip_aktor, kode_opd, tahun)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := tx.ExecContext(ctx, script,
+		riwayat.PokinId, riwayat.Aksi, riwayat.StatusAwal, riwayat.StatusAkhir, riwayat.Alasan,
+		riwayat.NipAktor, riwayat.KodeOpd, riwayat.Tahun,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal mencatat riwayat status: %v", err)
+	}
+	return nil
+}
+
+func (repository *PohonKinerjaRepositoryImpl) FindRiwayatStatus(ctx context.Context, tx *sql.Tx, pokinId int) ([]domain.RiwayatStatusPokin, error) {
+	script := `
+		SELECT r.id, r.pokin_id, r.aksi, r.status_awal, r.status_akhir, COALESCE(r.alasan, ''),
+			r.nip_aktor, COALESCE((SELECT p.nama FROM tb_pegawai p WHERE p.nip = r.nip_aktor LIMIT 1), ''),
+			r.kode_opd, r.tahun, r.created_at
+		FROM tb_riwayat_status_pokin r
+		WHERE r.pokin_id = ?
+		ORDER BY r.created_at, r.id`
+	rows, err := tx.QueryContext(ctx, script, pokinId)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil riwayat status: %v", err)
+	}
+	defer rows.Close()
+
+	var result []domain.RiwayatStatusPokin
+	for rows.Next() {
+		var riwayat domain.RiwayatStatusPokin
+		err := rows.Scan(
+			&riwayat.Id, &riwayat.PokinId, &riwayat.Aksi, &riwayat.StatusAwal, &riwayat.StatusAkhir, &riwayat.Alasan,
+			&riwayat.NipAktor, &riwayat.NamaAktor, &riwayat.KodeOpd, &riwayat.Tahun, &riwayat.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, riwayat)
+	}
+	return result, rows.Err()
+}
+
 func (repository *PohonKinerjaRepositoryImpl) InsertClonedPokinWithStatus(ctx context.Context, tx *sql.Tx, pokin domain.PohonKinerja) (int64, error) {
 	script := `INSERT INTO tb_pohon_kinerja 
         (nama_pohon, parent, jenis_pohon, level_pohon, kode_opd, keterangan, tahun, status, clone_from) 
@@ -2720,31 +2770,6 @@ func (repository *PohonKinerjaRepositoryImpl) InsertClonedPelaksana(ctx context.
 	return err
 }
 
-func (repository *PohonKinerjaRepositoryImpl) UpdatePokinStatusFromApproved(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `
-        UPDATE tb_pohon_kinerja 
-        SET status = 'menunggu_disetujui' 
-        WHERE id = ? 
-        AND status = 'disetujui'
-    `
-
-	result, err := tx.ExecContext(ctx, SQL, id)
-	if err != nil {
-		return err
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rows == 0 {
-		return fmt.Errorf("tidak ada data yang diupdate untuk ID %d", id)
-	}
-
-	return nil
-}
-
 func (repository *PohonKinerjaRepositoryImpl) UpdateParent(ctx context.Context, tx *sql.Tx, pohonKinerja domain.PohonKinerja) (domain.PohonKinerja, error) {
 	script := `UPDATE tb_pohon_kinerja SET parent = ? WHERE id = ?`
 	_, err := tx.ExecContext(ctx, script, pohonKinerja.Parent, pohonKinerja.Id)
@@ -13,7 +13,7 @@ type ProgramRepository interface {
 	Update(ctx context.Context, tx *sql.Tx, program domainmaster.ProgramKegiatan) (domainmaster.ProgramKegiatan, error)
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
 	FindById(ctx context.Context, tx *sql.Tx, id string) (domainmaster.ProgramKegiatan, error)
-	FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.ProgramKegiatan, error)
+	FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domainmaster.ProgramKegiatan, int, error)
 	FindIndikatorByProgramId(ctx context.Context, tx *sql.Tx, programId string) ([]domain.Indikator, error)
 	FindTargetByIndikatorId(ctx context.Context, tx *sql.Tx, indikatorId string) ([]domain.Target, error)
 	FindByKodeProgram(ctx context.Context, tx *sql.Tx, kodeProgram string) (domainmaster.ProgramKegiatan, error)
@@ -169,12 +169,43 @@ func (repository *ProgramRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
-func (repository *ProgramRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.ProgramKegiatan, error) {
-	script := "SELECT id, kode_program, nama_program, tahun, is_active FROM tb_master_program ORDER BY id"
+var programListColumns = listColumns{
+	sort: map[string]string{
+		"kode":  "kode_program",
+		"nama":  "nama_program",
+		"tahun": "tahun",
+	},
+	filter: map[string]listFilter{
+		"tahun":     {column: "tahun"},
+		"is_active": {column: "COALESCE(is_active, TRUE)", boolean: true},
+	},
+	// program yang dinonaktifkan ApplyImport nomenklatur hanya tampil jika diminta dengan ?is_active=false
+	defaultFilter: map[string]string{"is_active": "true"},
+	search:        []string{"kode_program", "nama_program"},
+	defaultSort:   "id",
+	tieBreaker:    "id",
+}
 
-	rows, err := tx.QueryContext(ctx, script)
+func (repository *ProgramRepositoryImpl) FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domainmaster.ProgramKegiatan, int, error) {
+	script := "SELECT id, kode_program, nama_program, tahun, is_active FROM tb_master_program WHERE 1=1"
+	where, params, err := programListColumns.where(query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	script += where
+
+	total, err := countList(ctx, tx, script, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderLimit, limitParams, err := programListColumns.orderLimit(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.QueryContext(ctx, script+orderLimit, append(params, limitParams...)...)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -189,12 +220,12 @@ func (repository *ProgramRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 			&program.IsActive,
 		)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		programs = append(programs, program)
 	}
 
-	return programs, nil
+	return programs, total, rows.Err()
 }
 
 func (repository *ProgramRepositoryImpl) FindByKodeProgram(ctx context.Context, tx *sql.Tx, kodeProgram string) (domainmaster.ProgramKegiatan, error) {
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type RealisasiRepository interface {
+	// FindIndikator indikator beserta kode_opd pemiliknya, sql.ErrNoRows jika tidak ada
+	FindIndikator(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.IndikatorRealisasi, error)
+	// FindTarget target dan satuan indikator di tahun tersebut, kosong jika belum ada target
+	FindTarget(ctx context.Context, tx *sql.Tx, indikatorId string, tahun string) (target string, satuan string, err error)
+	UpdatePolaritas(ctx context.Context, tx *sql.Tx, indikatorId string, polaritas string) error
+
+	Create(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) (domain.RealisasiIndikator, error)
+	Update(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) error
+	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	FindById(ctx context.Context, tx *sql.Tx, id int) (domain.RealisasiIndikator, error)
+	// FindByIndikator realisasi satu indikator di tahun tersebut, urut periode
+	FindByIndikator(ctx context.Context, tx *sql.Tx, indikatorId string, tahun string) ([]domain.RealisasiIndikator, error)
+	// FindByTahun realisasi seluruh indikator OPD di tahun tersebut, kodeOpd kosong = semua OPD dan pemda
+	FindByTahun(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.RealisasiIndikator, error)
+
+	// Data perhitungan capaian, kodeOpd kosong = seluruh OPD dan pemda
+	FindCapaianIndikator(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianIndikator, error)
+	FindCapaianPokin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianPokin, error)
+	FindCapaianRekin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianRekin, error)
+	FindSasaranOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianSasaran, error)
+	FindSasaranPemda(ctx context.Context, tx *sql.Tx, tahun string) ([]domain.CapaianSasaran, error)
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"errors"
+	"fmt"
+)
+
+// realisasiIndikatorJoin relasi indikator ke pemiliknya (rencana kinerja, pohon kinerja, sasaran opd, sasaran pemda)
+const realisasiIndikatorJoin = `
+	FROM tb_indikator i
+	LEFT JOIN tb_rencana_kinerja rk ON rk.id = i.rencana_kinerja_id
+	LEFT JOIN tb_pohon_kinerja pk ON pk.id = i.pokin_id
+	LEFT JOIN tb_sasaran_opd so ON so.id = i.sasaran_opd_id
+	LEFT JOIN tb_pohon_kinerja pso ON pso.id = so.pokin_id
+	LEFT JOIN tb_sasaran_pemda sp ON sp.id = i.sasaran_pemda_id`
+
+// realisasiKodeOpdIndikator kode_opd pemilik indikator, kosong berarti indikator pemda
+const realisasiKodeOpdIndikator = `COALESCE(NULLIF(rk.kode_opd, ''), NULLIF(pk.kode_opd, ''), NULLIF(pso.kode_opd, ''), NULLIF(i.kode_opd, ''), '')`
+
+// realisasiStatusPokinDilewati status pohon kinerja yang tidak ikut dihitung, sama dengan PohonKinerjaRepository.FindAll
+const realisasiStatusPokinDilewati = `('menunggu_disetujui', 'tarik pokin opd', 'disetujui', 'ditolak', 'crosscutting_menunggu', 'crosscutting_ditolak')`
+
+type RealisasiRepositoryImpl struct{}
+
+func NewRealisasiRepositoryImpl() *RealisasiRepositoryImpl {
+	return &RealisasiRepositoryImpl{}
+}
+
+func (repository *RealisasiRepositoryImpl) FindIndikator(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.IndikatorRealisasi, error) {
+	script := `SELECT i.id, COALESCE(i.indikator, ''), i.polaritas, ` + realisasiKodeOpdIndikator +
+		realisasiIndikatorJoin + ` WHERE i.id = ?`
+
+	var indikator domain.IndikatorRealisasi
+	err := tx.QueryRowContext(ctx, script, indikatorId).Scan(&indikator.Id, &indikator.Indikator, &indikator.Polaritas, &indikator.KodeOpd)
+	if err != nil {
+		return indikator, err
+	}
+	return indikator, nil
+}
+
+func (repository *RealisasiRepositoryImpl) FindTarget(ctx context.Context, tx *sql.Tx, indikatorId string, tahun string) (string, string, error) {
+	var target, satuan string
+	err := tx.QueryRowContext(ctx,
+		`SELECT COALESCE(target, ''), COALESCE(satuan, '') FROM tb_target
+		WHERE indikator_id = ? AND tahun = ? ORDER BY jenis = '' DESC, id LIMIT 1`,
+		indikatorId, tahun,
+	).Scan(&target, &satuan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("RealisasiRepository.FindTarget: %w", err)
+	}
+	return target, satuan, nil
+}
+
+func (repository *RealisasiRepositoryImpl) UpdatePolaritas(ctx context.Context, tx *sql.Tx, indikatorId string, polaritas string) error {
+	_, err := tx.ExecContext(ctx, "UPDATE tb_indikator SET polaritas = ? WHERE id = ?", polaritas, indikatorId)
+	if err != nil {
+		return fmt.Errorf("RealisasiRepository.UpdatePolaritas: %w", err)
+	}
+	return nil
+}
+
+func (repository *RealisasiRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) (domain.RealisasiIndikator, error) {
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO tb_realisasi_indikator (indikator_id, tahun, jenis_periode, periode, realisasi, bukti, kode_opd, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		realisasi.IndikatorId, realisasi.Tahun, realisasi.JenisPeriode, realisasi.Periode,
+		realisasi.Realisasi, realisasi.Bukti, realisasi.KodeOpd, realisasi.CreatedBy,
+	)
+	if err != nil {
+		return realisasi, fmt.Errorf("RealisasiRepository.Create: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return realisasi, err
+	}
+	realisasi.Id = int(id)
+	return realisasi, nil
+}
+
+func (repository *RealisasiRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE tb_realisasi_indikator SET realisasi = ?, bukti = ?, kode_opd = ? WHERE id = ?`,
+		realisasi.Realisasi, realisasi.Bukti, realisasi.KodeOpd, realisasi.Id,
+	)
+	if err != nil {
+		return fmt.Errorf("RealisasiRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (repository *RealisasiRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM tb_realisasi_indikator WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("RealisasiRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+const realisasiColumns = `SELECT id, indikator_id, tahun, jenis_periode, periode, realisasi, COALESCE(bukti, ''), kode_opd, created_by, created_at, updated_at
+	FROM tb_realisasi_indikator`
+
+func (repository *RealisasiRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (domain.RealisasiIndikator, error) {
+	var realisasi domain.RealisasiIndikator
+	err := scanRealisasi(tx.QueryRowContext(ctx, realisasiColumns+" WHERE id = ?", id), &realisasi)
+	return realisasi, err
+}
+
+func (repository *RealisasiRepositoryImpl) FindByIndikator(ctx context.Context, tx *sql.Tx, indikatorId string, tahun string) ([]domain.RealisasiIndikator, error) {
+	return repository.findRealisasi(ctx, tx, realisasiColumns+" WHERE indikator_id = ? AND tahun = ? ORDER BY periode", indikatorId, tahun)
+}
+
+func (repository *RealisasiRepositoryImpl) FindByTahun(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.RealisasiIndikator, error) {
+	script := realisasiColumns + " WHERE tahun = ?"
+	params := []interface{}{tahun}
+	if kodeOpd != "" {
+		script += " AND kode_opd = ?"
+		params = append(params, kodeOpd)
+	}
+	return repository.findRealisasi(ctx, tx, script+" ORDER BY indikator_id, periode", params...)
+}
+
+func (repository *RealisasiRepositoryImpl) findRealisasi(ctx context.Context, tx *sql.Tx, script string, params ...interface{}) ([]domain.RealisasiIndikator, error) {
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.findRealisasi: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.RealisasiIndikator
+	for rows.Next() {
+		var realisasi domain.RealisasiIndikator
+		if err := scanRealisasi(rows, &realisasi); err != nil {
+			return nil, err
+		}
+		result = append(result, realisasi)
+	}
+	return result, rows.Err()
+}
+
+func scanRealisasi(row interface{ Scan(...interface{}) error }, realisasi *domain.RealisasiIndikator) error {
+	return row.Scan(
+		&realisasi.Id, &realisasi.IndikatorId, &realisasi.Tahun, &realisasi.JenisPeriode, &realisasi.Periode,
+		&realisasi.Realisasi, &realisasi.Bukti, &realisasi.KodeOpd, &realisasi.CreatedBy, &realisasi.CreatedAt, &realisasi.UpdatedAt,
+	)
+}
+
+// FindCapaianIndikator indikator rencana kinerja dan pohon kinerja tahun tersebut, serta indikator sasaran opd/pemda
+// yang periodenya mencakup tahun tersebut. Target diambil dari target tahun yang sama, target tanpa jenis (bukan matrix renja) didahulukan.
+func (repository *RealisasiRepositoryImpl) FindCapaianIndikator(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianIndikator, error) {
+	script := `
+	SELECT
+		i.id,
+		COALESCE(i.indikator, ''),
+		i.polaritas,
+		COALESCE((SELECT t.target FROM tb_target t WHERE t.indikator_id = i.id AND t.tahun = ? ORDER BY t.jenis = '' DESC, t.id LIMIT 1), ''),
+		COALESCE((SELECT t.satuan FROM tb_target t WHERE t.indikator_id = i.id AND t.tahun = ? ORDER BY t.jenis = '' DESC, t.id LIMIT 1), ''),
+		COALESCE(rk.id, ''),
+		COALESCE(pk.id, 0),
+		COALESCE(so.id, 0),
+		COALESCE(sp.id, 0)` + realisasiIndikatorJoin + `
+	WHERE (rk.tahun = ? OR pk.tahun = ?
+		OR (so.tahun_awal <= ? AND so.tahun_akhir >= ?)
+		OR (sp.tahun_awal <= ? AND sp.tahun_akhir >= ?))`
+	params := []interface{}{tahun, tahun, tahun, tahun, tahun, tahun, tahun, tahun}
+	if kodeOpd != "" {
+		script += ` AND ` + realisasiKodeOpdIndikator + ` = ?`
+		params = append(params, kodeOpd)
+	}
+	script += ` ORDER BY i.id`
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindCapaianIndikator: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CapaianIndikator
+	for rows.Next() {
+		var indikator domain.CapaianIndikator
+		err := rows.Scan(&indikator.Id, &indikator.Indikator, &indikator.Polaritas, &indikator.Target, &indikator.Satuan,
+			&indikator.RencanaKinerjaId, &indikator.PokinId, &indikator.SasaranOpdId, &indikator.SasaranPemdaId)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, indikator)
+	}
+	return result, rows.Err()
+}
+
+// FindCapaianPokin pohon kinerja OPD (level 4 ke atas), kodeOpd kosong = seluruh pohon termasuk pohon pemda
+func (repository *RealisasiRepositoryImpl) FindCapaianPokin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianPokin, error) {
+	script := `
+	SELECT id, COALESCE(parent, 0), COALESCE(nama_pohon, ''), COALESCE(jenis_pohon, ''), COALESCE(level_pohon, 0)
+	FROM tb_pohon_kinerja
+	WHERE tahun = ? AND COALESCE(status, '') NOT IN ` + realisasiStatusPokinDilewati
+	params := []interface{}{tahun}
+	if kodeOpd != "" {
+		script += ` AND kode_opd = ? AND level_pohon >= 4`
+		params = append(params, kodeOpd)
+	}
+	script += ` ORDER BY level_pohon, id`
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindCapaianPokin: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CapaianPokin
+	for rows.Next() {
+		var pokin domain.CapaianPokin
+		if err := rows.Scan(&pokin.Id, &pokin.Parent, &pokin.NamaPohon, &pokin.JenisPohon, &pokin.LevelPohon); err != nil {
+			return nil, err
+		}
+		result = append(result, pokin)
+	}
+	return result, rows.Err()
+}
+
+func (repository *RealisasiRepositoryImpl) FindCapaianRekin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianRekin, error) {
+	script := `
+	SELECT id, COALESCE(nama_rencana_kinerja, ''), COALESCE(pegawai_id, ''), COALESCE(id_pohon, 0)
+	FROM tb_rencana_kinerja
+	WHERE tahun = ?`
+	params := []interface{}{tahun}
+	if kodeOpd != "" {
+		script += ` AND kode_opd = ?`
+		params = append(params, kodeOpd)
+	}
+	script += ` ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindCapaianRekin: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CapaianRekin
+	for rows.Next() {
+		var rekin domain.CapaianRekin
+		if err := rows.Scan(&rekin.Id, &rekin.NamaRencanaKinerja, &rekin.PegawaiId, &rekin.IdPohon); err != nil {
+			return nil, err
+		}
+		result = append(result, rekin)
+	}
+	return result, rows.Err()
+}
+
+func (repository *RealisasiRepositoryImpl) FindSasaranOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.CapaianSasaran, error) {
+	script := `
+	SELECT so.id, COALESCE(so.nama_sasaran_opd, ''), so.pokin_id
+	FROM tb_sasaran_opd so
+	JOIN tb_pohon_kinerja pk ON pk.id = so.pokin_id
+	WHERE pk.kode_opd = ? AND so.tahun_awal <= ? AND so.tahun_akhir >= ?
+	ORDER BY so.id`
+	return repository.findSasaran(ctx, tx, script, kodeOpd, tahun, tahun)
+}
+
+func (repository *RealisasiRepositoryImpl) FindSasaranPemda(ctx context.Context, tx *sql.Tx, tahun string) ([]domain.CapaianSasaran, error) {
+	script := `
+	SELECT id, COALESCE(sasaran_pemda, ''), COALESCE(subtema_id, 0)
+	FROM tb_sasaran_pemda
+	WHERE tahun_awal <= ? AND tahun_akhir >= ?
+	ORDER BY id`
+	return repository.findSasaran(ctx, tx, script, tahun, tahun)
+}
+
+func (repository *RealisasiRepositoryImpl) findSasaran(ctx context.Context, tx *sql.Tx, script string, params ...interface{}) ([]domain.CapaianSasaran, error) {
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.findSasaran: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CapaianSasaran
+	for rows.Next() {
+		var sasaran domain.CapaianSasaran
+		if err := rows.Scan(&sasaran.Id, &sasaran.Nama, &sasaran.PokinId); err != nil {
+			return nil, err
+		}
+		result = append(result, sasaran)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+// RealisasiAnggaranRepository filter renaksiId kosong berarti seluruh rencana aksi OPD di tahun tersebut
+type RealisasiAnggaranRepository interface {
+	// FindKodeOpdTahun kode_opd dan tahun rencana kinerja pemilik rencana aksi, sql.ErrNoRows jika rencana aksi tidak ada
+	FindKodeOpdTahun(ctx context.Context, tx *sql.Tx, renaksiId string) (kodeOpd string, tahun string, err error)
+
+	Create(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiAnggaran) (domain.RealisasiAnggaran, error)
+	Update(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiAnggaran) error
+	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	FindById(ctx context.Context, tx *sql.Tx, id int) (domain.RealisasiAnggaran, error)
+	FindByRenaksiBulan(ctx context.Context, tx *sql.Tx, renaksiId string, bulan int) (domain.RealisasiAnggaran, error)
+	FindRealisasi(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) ([]domain.RealisasiAnggaran, error)
+
+	FindRencanaAksi(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) ([]domain.RencanaAksiAnggaran, error)
+	// FindBobot jadwal bobot pelaksanaan rencana aksi: renaksi_id → bulan → bobot
+	FindBobot(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) (map[string]map[int]int, error)
+	// FindPagu pagu penetapan per kode_subkegiatan
+	FindPagu(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) (map[string]int64, error)
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type RealisasiAnggaranRepositoryImpl struct{}
+
+func NewRealisasiAnggaranRepositoryImpl() *RealisasiAnggaranRepositoryImpl {
+	return &RealisasiAnggaranRepositoryImpl{}
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) FindKodeOpdTahun(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	script := `
+		SELECT COALESCE(rk.kode_opd, ''), COALESCE(rk.tahun, '')
+		FROM tb_rencana_aksi ra
+		INNER JOIN tb_rencana_kinerja rk ON rk.id = ra.rencana_kinerja_id
+		WHERE ra.id = ?`
+	var kodeOpd, tahun string
+	err := tx.QueryRowContext(ctx, script, renaksiId).Scan(&kodeOpd, &tahun)
+	return kodeOpd, tahun, err
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiAnggaran) (domain.RealisasiAnggaran, error) {
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO tb_realisasi_anggaran (renaksi_id, bulan, realisasi, keterangan, kode_opd, tahun, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		realisasi.RenaksiId, realisasi.Bulan, realisasi.Realisasi, realisasi.Keterangan,
+		realisasi.KodeOpd, realisasi.Tahun, realisasi.CreatedBy,
+	)
+	if err != nil {
+		return realisasi, fmt.Errorf("RealisasiAnggaranRepository.Create: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return realisasi, err
+	}
+	realisasi.Id = int(id)
+	return realisasi, nil
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiAnggaran) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE tb_realisasi_anggaran SET realisasi = ?, keterangan = ?, kode_opd = ?, tahun = ? WHERE id = ?`,
+		realisasi.Realisasi, realisasi.Keterangan, realisasi.KodeOpd, realisasi.Tahun, realisasi.Id,
+	)
+	if err != nil {
+		return fmt.Errorf("RealisasiAnggaranRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM tb_realisasi_anggaran WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("RealisasiAnggaranRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+const realisasiAnggaranColumns = `SELECT id, renaksi_id, bulan, realisasi, COALESCE(keterangan, ''), kode_opd, tahun, created_by, created_at, updated_at
+	FROM tb_realisasi_anggaran`
+
+func (repository *RealisasiAnggaranRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (domain.RealisasiAnggaran, error) {
+	var realisasi domain.RealisasiAnggaran
+	err := scanRealisasiAnggaran(tx.QueryRowContext(ctx, realisasiAnggaranColumns+" WHERE id = ?", id), &realisasi)
+	return realisasi, err
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) FindByRenaksiBulan(ctx context.Context, tx *sql.Tx, renaksiId string, bulan int) (domain.RealisasiAnggaran, error) {
+	var realisasi domain.RealisasiAnggaran
+	err := scanRealisasiAnggaran(tx.QueryRowContext(ctx, realisasiAnggaranColumns+" WHERE renaksi_id = ? AND bulan = ?", renaksiId, bulan), &realisasi)
+	return realisasi, err
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) FindRealisasi(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) ([]domain.RealisasiAnggaran, error) {
+	script := realisasiAnggaranColumns + " WHERE kode_opd = ? AND tahun = ?"
+	params := []interface{}{kodeOpd, tahun}
+	if renaksiId != "" {
+		script += " AND renaksi_id = ?"
+		params = append(params, renaksiId)
+	}
+	script += " ORDER BY renaksi_id, bulan"
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiAnggaranRepository.FindRealisasi: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.RealisasiAnggaran
+	for rows.Next() {
+		var realisasi domain.RealisasiAnggaran
+		if err := scanRealisasiAnggaran(rows, &realisasi); err != nil {
+			return nil, err
+		}
+		result = append(result, realisasi)
+	}
+	return result, rows.Err()
+}
+
+func scanRealisasiAnggaran(row interface{ Scan(...interface{}) error }, realisasi *domain.RealisasiAnggaran) error {
+	return row.Scan(
+		&realisasi.Id, &realisasi.RenaksiId, &realisasi.Bulan, &realisasi.Realisasi, &realisasi.Keterangan,
+		&realisasi.KodeOpd, &realisasi.Tahun, &realisasi.CreatedBy, &realisasi.CreatedAt, &realisasi.UpdatedAt,
+	)
+}
+
+// FindRencanaAksi subkegiatan diambil dari subkegiatan terpilih pertama rencana kinerja (sama dengan laporan rincian belanja),
+// kegiatan dan program dicocokkan dari prefix kode seperti matrix renja
+func (repository *RealisasiAnggaranRepositoryImpl) FindRencanaAksi(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) ([]domain.RencanaAksiAnggaran, error) {
+	script := `
+	SELECT
+		x.id, x.nama_rencana_aksi, x.urutan,
+		x.rekin_id, x.nama_rencana_kinerja, x.pegawai_id, x.kode_opd, x.tahun,
+		x.kode_subkegiatan,
+		COALESCE(s.nama_subkegiatan, ''),
+		COALESCE(k.kode_kegiatan, ''),
+		COALESCE(k.nama_kegiatan, ''),
+		COALESCE(p.kode_program, ''),
+		COALESCE(p.nama_program, ''),
+		x.anggaran
+	FROM (
+		SELECT
+			ra.id,
+			COALESCE(ra.nama_rencana_aksi, '') AS nama_rencana_aksi,
+			COALESCE(ra.urutan, 999) AS urutan,
+			rk.id AS rekin_id,
+			COALESCE(rk.nama_rencana_kinerja, '') AS nama_rencana_kinerja,
+			COALESCE(rk.pegawai_id, '') AS pegawai_id,
+			rk.kode_opd,
+			rk.tahun,
+			COALESCE((SELECT st.kode_subkegiatan FROM tb_subkegiatan_terpilih st
+				WHERE st.rekin_id = rk.id AND st.kode_subkegiatan IS NOT NULL AND st.kode_subkegiatan != ''
+				LIMIT 1), '') AS kode_subkegiatan,
+			COALESCE((SELECT SUM(rb.anggaran) FROM tb_rincian_belanja rb WHERE rb.renaksi_id = ra.id), 0) AS anggaran
+		FROM tb_rencana_aksi ra
+		INNER JOIN tb_rencana_kinerja rk ON rk.id = ra.rencana_kinerja_id
+		WHERE rk.kode_opd = ? AND rk.tahun = ?`
+	params := []interface{}{kodeOpd, tahun}
+	if renaksiId != "" {
+		script += ` AND ra.id = ?`
+		params = append(params, renaksiId)
+	}
+	script += `
+	) x
+	LEFT JOIN tb_subkegiatan s ON s.kode_subkegiatan = x.kode_subkegiatan
+	LEFT JOIN tb_master_kegiatan k
+		ON x.kode_subkegiatan != '' AND LEFT(x.kode_subkegiatan, LENGTH(k.kode_kegiatan)) = k.kode_kegiatan
+	LEFT JOIN tb_master_program p
+		ON LEFT(k.kode_kegiatan, LENGTH(p.kode_program)) = p.kode_program
+	ORDER BY x.kode_subkegiatan, x.rekin_id, x.urutan, x.id`
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiAnggaranRepository.FindRencanaAksi: %w", err)
+	}
+	defer rows.Close()
+
+	// master kegiatan/program bisa cocok lebih dari satu baris, dipakai baris pertama
+	seen := make(map[string]bool)
+	var result []domain.RencanaAksiAnggaran
+	for rows.Next() {
+		var renaksi domain.RencanaAksiAnggaran
+		err := rows.Scan(
+			&renaksi.Id, &renaksi.NamaRencanaAksi, &renaksi.Urutan,
+			&renaksi.RencanaKinerjaId, &renaksi.NamaRencanaKinerja, &renaksi.PegawaiId, &renaksi.KodeOpd, &renaksi.Tahun,
+			&renaksi.KodeSubkegiatan, &renaksi.NamaSubkegiatan,
+			&renaksi.KodeKegiatan, &renaksi.NamaKegiatan,
+			&renaksi.KodeProgram, &renaksi.NamaProgram,
+			&renaksi.Anggaran,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if seen[renaksi.Id] {
+			continue
+		}
+		seen[renaksi.Id] = true
+		result = append(result, renaksi)
+	}
+	return result, rows.Err()
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) FindBobot(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) (map[string]map[int]int, error) {
+	script := `
+	SELECT pra.rencana_aksi_id, pra.bulan, COALESCE(SUM(pra.bobot), 0)
+	FROM tb_pelaksanaan_rencana_aksi pra
+	INNER JOIN tb_rencana_aksi ra ON ra.id = pra.rencana_aksi_id
+	INNER JOIN tb_rencana_kinerja rk ON rk.id = ra.rencana_kinerja_id
+	WHERE rk.kode_opd = ? AND rk.tahun = ? AND pra.bulan BETWEEN 1 AND 12`
+	params := []interface{}{kodeOpd, tahun}
+	if renaksiId != "" {
+		script += ` AND pra.rencana_aksi_id = ?`
+		params = append(params, renaksiId)
+	}
+	script += ` GROUP BY pra.rencana_aksi_id, pra.bulan`
+
+	rows, err := tx.QueryContext(ctx, script, params...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiAnggaranRepository.FindBobot: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]map[int]int)
+	for rows.Next() {
+		var renaksi string
+		var bulan, bobot int
+		if err := rows.Scan(&renaksi, &bulan, &bobot); err != nil {
+			return nil, err
+		}
+		if result[renaksi] == nil {
+			result[renaksi] = make(map[int]int)
+		}
+		result[renaksi][bulan] = bobot
+	}
+	return result, rows.Err()
+}
+
+func (repository *RealisasiAnggaranRepositoryImpl) FindPagu(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) (map[string]int64, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT kode_subkegiatan, COALESCE(pagu, 0) FROM tb_pagu
+		WHERE kode_opd = ? AND tahun = ? AND jenis = 'penetapan' AND kode_subkegiatan IS NOT NULL`,
+		kodeOpd, tahun,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiAnggaranRepository.FindPagu: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]int64)
+	for rows.Next() {
+		var kode string
+		var pagu int64
+		if err := rows.Scan(&kode, &pagu); err != nil {
+			return nil, err
+		}
+		result[kode] = pagu
+	}
+	return result, rows.Err()
+}
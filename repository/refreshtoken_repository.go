@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, refreshToken domain.RefreshToken) (domain.RefreshToken, error)
+	// FindByTokenHash: mengunci baris token (FOR UPDATE) sampai transaksi selesai agar refresh paralel
+	// dengan token yang sama menunggu rotasi pertama dan melihat revoked_at yang sudah terisi
+	FindByTokenHash(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.RefreshToken, error)
+	// RevokeById: mencabut satu refresh token (dipakai saat rotasi)
+	RevokeById(ctx context.Context, tx *sql.Tx, id int) error
+	// RevokeBySessionId: mencabut semua refresh token dalam satu sesi login
+	RevokeBySessionId(ctx context.Context, tx *sql.Tx, sessionId string) error
+	// RevokeByUserId: mencabut semua sesi milik user
+	RevokeByUserId(ctx context.Context, tx *sql.Tx, userId int) error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type RefreshTokenRepositoryImpl struct {
+}
+
+func NewRefreshTokenRepositoryImpl() *RefreshTokenRepositoryImpl {
+	return &RefreshTokenRepositoryImpl{}
+}
+
+func (repository *RefreshTokenRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, refreshToken domain.RefreshToken) (domain.RefreshToken, error) {
+	script := "INSERT INTO tb_refresh_token(session_id, user_id, token_hash, expired_at) VALUES (?, ?, ?, ?)"
+	result, err := tx.ExecContext(ctx, script, refreshToken.SessionId, refreshToken.UserId, refreshToken.TokenHash, refreshToken.ExpiredAt)
+	if err != nil {
+		return refreshToken, fmt.Errorf("RefreshTokenRepository.Create: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return refreshToken, fmt.Errorf("RefreshTokenRepository.Create: %w", err)
+	}
+	refreshToken.Id = int(id)
+
+	return refreshToken, nil
+}
+
+func (repository *RefreshTokenRepositoryImpl) FindByTokenHash(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.RefreshToken, error) {
+	script := `
+		SELECT id, session_id, user_id, token_hash, expired_at, revoked_at, created_at
+		FROM tb_refresh_token
+		WHERE token_hash = ?
+		FOR UPDATE
+	`
+	var refreshToken domain.RefreshToken
+	err := tx.QueryRowContext(ctx, script, tokenHash).Scan(
+		&refreshToken.Id,
+		&refreshToken.SessionId,
+		&refreshToken.UserId,
+		&refreshToken.TokenHash,
+		&refreshToken.ExpiredAt,
+		&refreshToken.RevokedAt,
+		&refreshToken.CreatedAt,
+	)
+	if err != nil {
+		return refreshToken, err
+	}
+
+	return refreshToken, nil
+}
+
+func (repository *RefreshTokenRepositoryImpl) RevokeById(ctx context.Context, tx *sql.Tx, id int) error {
+	script := "UPDATE tb_refresh_token SET revoked_at = NOW() WHERE id = ? AND revoked_at IS NULL"
+	_, err := tx.ExecContext(ctx, script, id)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepository.RevokeById: %w", err)
+	}
+	return nil
+}
+
+func (repository *RefreshTokenRepositoryImpl) RevokeBySessionId(ctx context.Context, tx *sql.Tx, sessionId string) error {
+	script := "UPDATE tb_refresh_token SET revoked_at = NOW() WHERE session_id = ? AND revoked_at IS NULL"
+	_, err := tx.ExecContext(ctx, script, sessionId)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepository.RevokeBySessionId: %w", err)
+	}
+	return nil
+}
+
+func (repository *RefreshTokenRepositoryImpl) RevokeByUserId(ctx context.Context, tx *sql.Tx, userId int) error {
+	script := "UPDATE tb_refresh_token SET revoked_at = NOW() WHERE user_id = ? AND revoked_at IS NULL"
+	_, err := tx.ExecContext(ctx, script, userId)
+	if err != nil {
+		return fmt.Errorf("RefreshTokenRepository.RevokeByUserId: %w", err)
+	}
+	return nil
+}
@@ -44,4 +44,10 @@ type RencanaKinerjaRepository interface {
 	IndikatorTargetSasaranByRekinIds(ctx context.Context, tx *sql.Tx, rekinIds []string) (map[string][]domain.Indikator, error)
 	GetByKodeOpdAndTahun(ctx context.Context, tx *sql.Tx, kodeOpd string, tahunAsal string) ([]domain.RencanaKinerja, error)
 	CreateBatch(ctx context.Context, tx *sql.Tx, rencanaKinerjas []domain.RencanaKinerja) error
+	// FindKodeOpdById dan FindKodeOpdTahunByRenaksiId OPD (dan tahun) rekin pemilik data turunan rekin
+	// untuk cek akses dan lock, sql.ErrNoRows jika rekin tidak ditemukan
+	FindKodeOpdById(ctx context.Context, tx *sql.Tx, rekinId string) (string, error)
+	FindKodeOpdTahunByRenaksiId(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error)
+	// FindKodeOpdTahunById kode_opd dan tahun rekin untuk invalidasi cache, string kosong jika rekin tidak ditemukan
+	FindKodeOpdTahunById(ctx context.Context, tx *sql.Tx, rekinId string) (string, string, error)
 }
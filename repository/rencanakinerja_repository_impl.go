@@ -1789,3 +1789,29 @@ func (repository *RencanaKinerjaRepositoryImpl) batchInsertTarget(
 
 	return nil
 }
+
+func (repository *RencanaKinerjaRepositoryImpl) FindKodeOpdById(ctx context.Context, tx *sql.Tx, rekinId string) (string, error) {
+	var kodeOpd string
+	err := tx.QueryRowContext(ctx, "SELECT COALESCE(kode_opd, '') FROM tb_rencana_kinerja WHERE id = ?", rekinId).Scan(&kodeOpd)
+	return kodeOpd, err
+}
+
+func (repository *RencanaKinerjaRepositoryImpl) FindKodeOpdTahunByRenaksiId(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	script := `
+		SELECT COALESCE(rk.kode_opd, ''), COALESCE(rk.tahun, '')
+		FROM tb_rencana_aksi ra
+		INNER JOIN tb_rencana_kinerja rk ON rk.id = ra.rencana_kinerja_id
+		WHERE ra.id = ?`
+	var kodeOpd, tahun string
+	err := tx.QueryRowContext(ctx, script, renaksiId).Scan(&kodeOpd, &tahun)
+	return kodeOpd, tahun, err
+}
+
+func (repository *RencanaKinerjaRepositoryImpl) FindKodeOpdTahunById(ctx context.Context, tx *sql.Tx, rekinId string) (string, string, error) {
+	var kodeOpd, tahun string
+	err := tx.QueryRowContext(ctx, "SELECT COALESCE(kode_opd, ''), COALESCE(tahun, '') FROM tb_rencana_kinerja WHERE id = ?", rekinId).Scan(&kodeOpd, &tahun)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	return kodeOpd, tahun, err
+}
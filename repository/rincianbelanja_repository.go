@@ -18,4 +18,5 @@ type RincianBelanjaRepository interface {
 	LaporanRincianBelanjaPegawai(ctx context.Context, tx *sql.Tx, pegawaiId string, tahun string) ([]domain.RincianBelanjaAsn, error)
 	Upsert(ctx context.Context, tx *sql.Tx, rincianBelanja domain.RincianBelanja) (domain.RincianBelanja, error)
 	TotalAnggaranByIdRekins(ctx context.Context, tx *sql.Tx, rekinIds []string) (map[string]int64, error)
+	FindKodeOpdTahunByRenaksiId(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error)
 }
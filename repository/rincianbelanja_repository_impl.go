@@ -776,3 +776,20 @@ func (repository *RincianBelanjaRepositoryImpl) TotalAnggaranByIdRekins(ctx cont
 
 	return results, nil
 }
+
+// FindKodeOpdTahunByRenaksiId mengambil kode_opd dan tahun rencana kinerja pemilik rencana aksi,
+// sql.ErrNoRows dikembalikan apa adanya jika rencana aksi tidak ditemukan
+func (repository *RincianBelanjaRepositoryImpl) FindKodeOpdTahunByRenaksiId(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	script := `
+		SELECT rk.kode_opd, rk.tahun
+		FROM tb_rencana_aksi ra
+		INNER JOIN tb_rencana_kinerja rk ON rk.id = ra.rencana_kinerja_id
+		WHERE ra.id = ?
+	`
+	var kodeOpd, tahun string
+	err := tx.QueryRowContext(ctx, script, renaksiId).Scan(&kodeOpd, &tahun)
+	if err != nil {
+		return "", "", err
+	}
+	return kodeOpd, tahun, nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+// RollForwardRepository query salin data perencanaan OPD dari tahun N ke N+1.
+// Map pokinIds/rekinIds berisi id sumber → id tujuan yang baru dibuat di transaksi yang sama.
+type RollForwardRepository interface {
+	FindPokin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.RollForwardPokin, error)
+	// FindPokinCloneMap id pohon sumber → id pohon tahun tujuan yang sudah pernah disalin (keterangan_clone_dari)
+	FindPokinCloneMap(ctx context.Context, tx *sql.Tx, kodeOpd string, tahunTujuan string) (map[int]int, error)
+	InsertPokin(ctx context.Context, tx *sql.Tx, sourceId int, parent int, tahunSumber string, tahunTujuan string) (int, error)
+	CloneIndikatorPokin(ctx context.Context, tx *sql.Tx, pokinIds map[int]int, tahunTujuan string) (indikator int, target int, err error)
+	ClonePelaksanaPokin(ctx context.Context, tx *sql.Tx, pokinIds map[int]int) (int, error)
+	// CloneTagging menyalin tagging beserta keterangan program unggulan / prioritas pusat yang periodenya masih mencakup tahun tujuan
+	CloneTagging(ctx context.Context, tx *sql.Tx, pokinIds map[int]int, tahunTujuan string) (tagging int, keterangan int, keteranganDilewati int, err error)
+	CloneCsf(ctx context.Context, tx *sql.Tx, pokinIds map[int]int, tahunTujuan string) (int, error)
+
+	FindRekin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.RollForwardRekin, error)
+	InsertRekin(ctx context.Context, tx *sql.Tx, sourceId string, newId string, idPohon int, tahunTujuan string) error
+	CloneIndikatorRekin(ctx context.Context, tx *sql.Tx, rekinIds map[string]string, tahunTujuan string) (indikator int, target int, err error)
+	CloneRencanaAksi(ctx context.Context, tx *sql.Tx, rekinIds map[string]string) (int, error)
+	// CloneSubKegiatanTerpilih melewati sub kegiatan yang tidak ada atau sudah nonaktif di master nomenklatur
+	CloneSubKegiatanTerpilih(ctx context.Context, tx *sql.Tx, rekinIds map[string]string) (int, []domain.RollForwardItem, error)
+}
@@ -0,0 +1,434 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RollForwardRepositoryImpl struct {
+}
+
+func NewRollForwardRepositoryImpl() *RollForwardRepositoryImpl {
+	return &RollForwardRepositoryImpl{}
+}
+
+func (repository *RollForwardRepositoryImpl) FindPokin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.RollForwardPokin, error) {
+	// urut level agar induk selalu diproses sebelum anaknya
+	script := `
+		SELECT id, COALESCE(parent, 0), COALESCE(nama_pohon, ''), COALESCE(jenis_pohon, ''), COALESCE(level_pohon, 0), COALESCE(status, '')
+		FROM tb_pohon_kinerja
+		WHERE kode_opd = ? AND tahun = ?
+		ORDER BY level_pohon ASC, id ASC
+	`
+	rows, err := tx.QueryContext(ctx, script, kodeOpd, tahun)
+	if err != nil {
+		return nil, fmt.Errorf("RollForwardRepository.FindPokin: %w", err)
+	}
+	defer rows.Close()
+
+	var pokins []domain.RollForwardPokin
+	for rows.Next() {
+		var pokin domain.RollForwardPokin
+		if err := rows.Scan(&pokin.Id, &pokin.Parent, &pokin.NamaPohon, &pokin.JenisPohon, &pokin.LevelPohon, &pokin.Status); err != nil {
+			return nil, fmt.Errorf("RollForwardRepository.FindPokin: %w", err)
+		}
+		pokins = append(pokins, pokin)
+	}
+	return pokins, rows.Err()
+}
+
+func (repository *RollForwardRepositoryImpl) FindPokinCloneMap(ctx context.Context, tx *sql.Tx, kodeOpd string, tahunTujuan string) (map[int]int, error) {
+	script := `
+		SELECT keterangan_clone_dari, id
+		FROM tb_pohon_kinerja
+		WHERE kode_opd = ? AND tahun = ? AND keterangan_clone_dari IS NOT NULL AND keterangan_clone_dari > 0
+		ORDER BY id ASC
+	`
+	rows, err := tx.QueryContext(ctx, script, kodeOpd, tahunTujuan)
+	if err != nil {
+		return nil, fmt.Errorf("RollForwardRepository.FindPokinCloneMap: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]int)
+	for rows.Next() {
+		var sourceId, targetId int
+		if err := rows.Scan(&sourceId, &targetId); err != nil {
+			return nil, fmt.Errorf("RollForwardRepository.FindPokinCloneMap: %w", err)
+		}
+		if _, ok := result[sourceId]; !ok {
+			result[sourceId] = targetId
+		}
+	}
+	return result, rows.Err()
+}
+
+func (repository *RollForwardRepositoryImpl) InsertPokin(ctx context.Context, tx *sql.Tx, sourceId int, parent int, tahunSumber string, tahunTujuan string) (int, error) {
+	script := `
+		INSERT INTO tb_pohon_kinerja (
+			nama_pohon, parent, jenis_pohon, level_pohon, kode_opd, keterangan, keterangan_crosscutting,
+			tahun, status, is_active, keterangan_tahun_clone, keterangan_clone_dari
+		)
+		SELECT nama_pohon, ?, jenis_pohon, level_pohon, kode_opd, keterangan, keterangan_crosscutting,
+			?, '', is_active, ?, id
+		FROM tb_pohon_kinerja
+		WHERE id = ?
+	`
+	result, err := tx.ExecContext(ctx, script, parent, tahunTujuan, tahunSumber, sourceId)
+	if err != nil {
+		return 0, fmt.Errorf("RollForwardRepository.InsertPokin %d: %w", sourceId, err)
+	}
+	newId, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("RollForwardRepository.InsertPokin %d: %w", sourceId, err)
+	}
+	return int(newId), nil
+}
+
+func (repository *RollForwardRepositoryImpl) CloneIndikatorPokin(ctx context.Context, tx *sql.Tx, pokinIds map[int]int, tahunTujuan string) (int, int, error) {
+	var indikatorCount, targetCount int
+	for _, sourceId := range sortedIntKeys(pokinIds) {
+		indikatorIds, err := queryStrings(ctx, tx, `SELECT id FROM tb_indikator WHERE pokin_id = ? ORDER BY id`, sourceId)
+		if err != nil {
+			return 0, 0, fmt.Errorf("RollForwardRepository.CloneIndikatorPokin: %w", err)
+		}
+		for _, indikatorId := range indikatorIds {
+			newIndikatorId := "IND-POKIN-" + uuid.New().String()
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO tb_indikator (id, pokin_id, indikator, tahun, clone_from)
+				SELECT ?, ?, indikator, ?, id
+				FROM tb_indikator WHERE id = ?
+			`, newIndikatorId, pokinIds[sourceId], tahunTujuan, indikatorId)
+			if err != nil {
+				return 0, 0, fmt.Errorf("RollForwardRepository.CloneIndikatorPokin %s: %w", indikatorId, err)
+			}
+			indikatorCount++
+
+			copied, err := cloneTarget(ctx, tx, indikatorId, newIndikatorId, "TRGT-IND-POKIN-", tahunTujuan)
+			if err != nil {
+				return 0, 0, fmt.Errorf("RollForwardRepository.CloneIndikatorPokin %s: %w", indikatorId, err)
+			}
+			targetCount += copied
+		}
+	}
+	return indikatorCount, targetCount, nil
+}
+
+func (repository *RollForwardRepositoryImpl) ClonePelaksanaPokin(ctx context.Context, tx *sql.Tx, pokinIds map[int]int) (int, error) {
+	var count int
+	for _, sourceId := range sortedIntKeys(pokinIds) {
+		pegawaiIds, err := queryStrings(ctx, tx, `SELECT pegawai_id FROM tb_pelaksana_pokin WHERE pohon_kinerja_id = ? ORDER BY id`, strconv.Itoa(sourceId))
+		if err != nil {
+			return 0, fmt.Errorf("RollForwardRepository.ClonePelaksanaPokin: %w", err)
+		}
+		for _, pegawaiId := range pegawaiIds {
+			_, err := tx.ExecContext(ctx, `INSERT INTO tb_pelaksana_pokin (id, pohon_kinerja_id, pegawai_id) VALUES (?, ?, ?)`,
+				"PLKS-"+uuid.New().String()[:8], strconv.Itoa(pokinIds[sourceId]), pegawaiId)
+			if err != nil {
+				return 0, fmt.Errorf("RollForwardRepository.ClonePelaksanaPokin %d: %w", sourceId, err)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// keteranganTaggingTables tabel keterangan tagging beserta master program yang menentukan periode berlakunya
+var keteranganTaggingTables = []struct {
+	table      string
+	master     string
+	kodeColumn string
+}{
+	{"tb_keterangan_tagging_program_unggulan", "tb_program_unggulan", "kode_program_unggulan"},
+	{"tb_keterangan_tagging_program_prioritas_pusat", "tb_program_prioritas_pusat", "kode_program_prioritas_pusat"},
+}
+
+func (repository *RollForwardRepositoryImpl) CloneTagging(ctx context.Context, tx *sql.Tx, pokinIds map[int]int, tahunTujuan string) (int, int, int, error) {
+	var taggingCount, keteranganCount, dilewatiCount int
+	for _, sourceId := range sortedIntKeys(pokinIds) {
+		taggingIds, err := queryStrings(ctx, tx, `SELECT id FROM tb_tagging_pokin WHERE id_pokin = ? ORDER BY id`, sourceId)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("RollForwardRepository.CloneTagging: %w", err)
+		}
+		for _, taggingId := range taggingIds {
+			result, err := tx.ExecContext(ctx, `
+				INSERT INTO tb_tagging_pokin (id_pokin, nama_tagging, keterangan_tagging, clone_from)
+				SELECT ?, nama_tagging, keterangan_tagging, id
+				FROM tb_tagging_pokin WHERE id = ?
+			`, pokinIds[sourceId], taggingId)
+			if err != nil {
+				return 0, 0, 0, fmt.Errorf("RollForwardRepository.CloneTagging %s: %w", taggingId, err)
+			}
+			newTaggingId, err := result.LastInsertId()
+			if err != nil {
+				return 0, 0, 0, fmt.Errorf("RollForwardRepository.CloneTagging %s: %w", taggingId, err)
+			}
+			taggingCount++
+
+			for _, keterangan := range keteranganTaggingTables {
+				var total int
+				err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+keterangan.table+` WHERE id_tagging = ?`, taggingId).Scan(&total)
+				if err != nil {
+					return 0, 0, 0, fmt.Errorf("RollForwardRepository.CloneTagging %s: %w", keterangan.table, err)
+				}
+				if total == 0 {
+					continue
+				}
+				result, err := tx.ExecContext(ctx, `
+					INSERT INTO `+keterangan.table+` (id_tagging, `+keterangan.kodeColumn+`, tahun)
+					SELECT DISTINCT ?, k.`+keterangan.kodeColumn+`, ?
+					FROM `+keterangan.table+` k
+					JOIN `+keterangan.master+` m ON m.`+keterangan.kodeColumn+` = k.`+keterangan.kodeColumn+`
+					WHERE k.id_tagging = ?
+					  AND CAST(m.tahun_awal AS UNSIGNED) <= ? AND CAST(m.tahun_akhir AS UNSIGNED) >= ?
+				`, newTaggingId, tahunTujuan, taggingId, tahunTujuan, tahunTujuan)
+				if err != nil {
+					return 0, 0, 0, fmt.Errorf("RollForwardRepository.CloneTagging %s: %w", keterangan.table, err)
+				}
+				copied, err := result.RowsAffected()
+				if err != nil {
+					return 0, 0, 0, fmt.Errorf("RollForwardRepository.CloneTagging %s: %w", keterangan.table, err)
+				}
+				keteranganCount += int(copied)
+				if total > int(copied) {
+					dilewatiCount += total - int(copied)
+				}
+			}
+		}
+	}
+	return taggingCount, keteranganCount, dilewatiCount, nil
+}
+
+func (repository *RollForwardRepositoryImpl) CloneCsf(ctx context.Context, tx *sql.Tx, pokinIds map[int]int, tahunTujuan string) (int, error) {
+	var count int
+	for _, sourceId := range sortedIntKeys(pokinIds) {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO tb_csf (pohon_id, pernyataan_kondisi_strategis, alasan_kondisi_strategis, data_terukur, kondisi_terukur, kondisi_wujud, tahun)
+			SELECT ?, pernyataan_kondisi_strategis, alasan_kondisi_strategis, data_terukur, kondisi_terukur, kondisi_wujud, ?
+			FROM tb_csf WHERE pohon_id = ?
+		`, pokinIds[sourceId], tahunTujuan, sourceId)
+		if err != nil {
+			return 0, fmt.Errorf("RollForwardRepository.CloneCsf %d: %w", sourceId, err)
+		}
+		copied, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("RollForwardRepository.CloneCsf %d: %w", sourceId, err)
+		}
+		count += int(copied)
+	}
+	return count, nil
+}
+
+func (repository *RollForwardRepositoryImpl) FindRekin(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.RollForwardRekin, error) {
+	script := `
+		SELECT id, nama_rencana_kinerja, COALESCE(pegawai_id, ''), COALESCE(id_pohon, 0), COALESCE(kode_subkegiatan, '')
+		FROM tb_rencana_kinerja
+		WHERE kode_opd = ? AND tahun = ?
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := tx.QueryContext(ctx, script, kodeOpd, tahun)
+	if err != nil {
+		return nil, fmt.Errorf("RollForwardRepository.FindRekin: %w", err)
+	}
+	defer rows.Close()
+
+	var rekins []domain.RollForwardRekin
+	for rows.Next() {
+		var rekin domain.RollForwardRekin
+		if err := rows.Scan(&rekin.Id, &rekin.Nama, &rekin.PegawaiId, &rekin.IdPohon, &rekin.KodeSubKegiatan); err != nil {
+			return nil, fmt.Errorf("RollForwardRepository.FindRekin: %w", err)
+		}
+		rekins = append(rekins, rekin)
+	}
+	return rekins, rows.Err()
+}
+
+// InsertRekin status rekin tahun tujuan kembali draft karena persetujuan atasan berlaku per tahun
+func (repository *RollForwardRepositoryImpl) InsertRekin(ctx context.Context, tx *sql.Tx, sourceId string, newId string, idPohon int, tahunTujuan string) error {
+	script := `
+		INSERT INTO tb_rencana_kinerja (
+			id, id_pohon, nama_rencana_kinerja, tahun, status_rencana_kinerja, catatan, kode_opd, pegawai_id,
+			kode_subkegiatan, tahun_awal, tahun_akhir, jenis_periode, periode_id, sasaranopd_id
+		)
+		SELECT ?, ?, nama_rencana_kinerja, ?, 'draft', catatan, kode_opd, pegawai_id,
+			kode_subkegiatan, tahun_awal, tahun_akhir, jenis_periode, periode_id, sasaranopd_id
+		FROM tb_rencana_kinerja
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, script, newId, idPohon, tahunTujuan, sourceId)
+	if err != nil {
+		return fmt.Errorf("RollForwardRepository.InsertRekin %s: %w", sourceId, err)
+	}
+	return nil
+}
+
+func (repository *RollForwardRepositoryImpl) CloneIndikatorRekin(ctx context.Context, tx *sql.Tx, rekinIds map[string]string, tahunTujuan string) (int, int, error) {
+	var indikatorCount, targetCount int
+	for _, sourceId := range sortedStringKeys(rekinIds) {
+		indikatorIds, err := queryStrings(ctx, tx, `SELECT id FROM tb_indikator WHERE rencana_kinerja_id = ? ORDER BY id`, sourceId)
+		if err != nil {
+			return 0, 0, fmt.Errorf("RollForwardRepository.CloneIndikatorRekin: %w", err)
+		}
+		for _, indikatorId := range indikatorIds {
+			newIndikatorId := fmt.Sprintf("IND-REKIN-%s-%s", time.Now().Format("20060102"), uuid.New().String())
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO tb_indikator (id, rencana_kinerja_id, indikator, tahun, rumus_perhitungan, sumber_data, clone_from)
+				SELECT ?, ?, indikator, ?, rumus_perhitungan, sumber_data, id
+				FROM tb_indikator WHERE id = ?
+			`, newIndikatorId, rekinIds[sourceId], tahunTujuan, indikatorId)
+			if err != nil {
+				return 0, 0, fmt.Errorf("RollForwardRepository.CloneIndikatorRekin %s: %w", indikatorId, err)
+			}
+			indikatorCount++
+
+			copied, err := cloneTarget(ctx, tx, indikatorId, newIndikatorId, fmt.Sprintf("TRGT-IND-REKIN-%s-", time.Now().Format("20060102")), tahunTujuan)
+			if err != nil {
+				return 0, 0, fmt.Errorf("RollForwardRepository.CloneIndikatorRekin %s: %w", indikatorId, err)
+			}
+			targetCount += copied
+		}
+	}
+	return indikatorCount, targetCount, nil
+}
+
+func (repository *RollForwardRepositoryImpl) CloneRencanaAksi(ctx context.Context, tx *sql.Tx, rekinIds map[string]string) (int, error) {
+	var count int
+	for _, sourceId := range sortedStringKeys(rekinIds) {
+		renaksiIds, err := queryStrings(ctx, tx, `SELECT id FROM tb_rencana_aksi WHERE rencana_kinerja_id = ? ORDER BY urutan, id`, sourceId)
+		if err != nil {
+			return 0, fmt.Errorf("RollForwardRepository.CloneRencanaAksi: %w", err)
+		}
+		for _, renaksiId := range renaksiIds {
+			newId := fmt.Sprintf("RENAKSI-REKIN-%s-%s", time.Now().Format("20060102"), uuid.New().String())
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO tb_rencana_aksi (id, rencana_kinerja_id, kode_opd, urutan, nama_rencana_aksi)
+				SELECT ?, ?, kode_opd, urutan, nama_rencana_aksi
+				FROM tb_rencana_aksi WHERE id = ?
+			`, newId, rekinIds[sourceId], renaksiId)
+			if err != nil {
+				return 0, fmt.Errorf("RollForwardRepository.CloneRencanaAksi %s: %w", renaksiId, err)
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (repository *RollForwardRepositoryImpl) CloneSubKegiatanTerpilih(ctx context.Context, tx *sql.Tx, rekinIds map[string]string) (int, []domain.RollForwardItem, error) {
+	var count int
+	var dilewati []domain.RollForwardItem
+	for _, sourceId := range sortedStringKeys(rekinIds) {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT st.id, COALESCE(st.subkegiatan_id, ''), COALESCE(st.kode_subkegiatan, ''), s.id IS NOT NULL, COALESCE(s.is_active, TRUE)
+			FROM tb_subkegiatan_terpilih st
+			LEFT JOIN tb_subkegiatan s ON s.kode_subkegiatan = st.kode_subkegiatan
+			WHERE st.rekin_id = ?
+			ORDER BY st.id
+		`, sourceId)
+		if err != nil {
+			return 0, nil, fmt.Errorf("RollForwardRepository.CloneSubKegiatanTerpilih: %w", err)
+		}
+
+		type terpilih struct {
+			id, subKegiatanId, kode string
+			ada, aktif              bool
+		}
+		var items []terpilih
+		for rows.Next() {
+			var item terpilih
+			if err := rows.Scan(&item.id, &item.subKegiatanId, &item.kode, &item.ada, &item.aktif); err != nil {
+				rows.Close()
+				return 0, nil, fmt.Errorf("RollForwardRepository.CloneSubKegiatanTerpilih: %w", err)
+			}
+			items = append(items, item)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, nil, fmt.Errorf("RollForwardRepository.CloneSubKegiatanTerpilih: %w", err)
+		}
+
+		for _, item := range items {
+			if !item.ada || !item.aktif {
+				alasan := "sub kegiatan tidak ada di master nomenklatur"
+				if item.ada {
+					alasan = "sub kegiatan sudah nonaktif di master nomenklatur"
+				}
+				dilewati = append(dilewati, domain.RollForwardItem{
+					Entitas:  domain.RollForwardSubKegiatanTerpilih,
+					IdSumber: item.id,
+					Nama:     item.kode,
+					Alasan:   alasan,
+				})
+				continue
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO tb_subkegiatan_terpilih (id, subkegiatan_id, rekin_id, kode_subkegiatan) VALUES (?, ?, ?, ?)`,
+				uuid.New().String(), item.subKegiatanId, rekinIds[sourceId], item.kode)
+			if err != nil {
+				return 0, nil, fmt.Errorf("RollForwardRepository.CloneSubKegiatanTerpilih %s: %w", item.id, err)
+			}
+			count++
+		}
+	}
+	return count, dilewati, nil
+}
+
+func cloneTarget(ctx context.Context, tx *sql.Tx, indikatorId string, newIndikatorId string, idPrefix string, tahunTujuan string) (int, error) {
+	targetIds, err := queryStrings(ctx, tx, `SELECT id FROM tb_target WHERE indikator_id = ? ORDER BY id`, indikatorId)
+	if err != nil {
+		return 0, err
+	}
+	for _, targetId := range targetIds {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO tb_target (id, indikator_id, target, satuan, tahun, clone_from)
+			SELECT ?, ?, target, satuan, ?, id
+			FROM tb_target WHERE id = ?
+		`, idPrefix+uuid.New().String(), newIndikatorId, tahunTujuan, targetId)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(targetIds), nil
+}
+
+func queryStrings(ctx context.Context, tx *sql.Tx, script string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+func sortedIntKeys(values map[int]int) []int {
+	keys := make([]int, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func sortedStringKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
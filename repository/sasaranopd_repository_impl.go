@@ -1735,11 +1735,13 @@ func (r *SasaranOpdRepositoryImpl) FindIndikatorByKodeIndikator(
 ) (domain.Indikator, error) {
 	row := tx.QueryRowContext(ctx, `
         SELECT kode_indikator,
+               COALESCE(sasaran_opd_id, 0),
                COALESCE(indikator, ''),
                COALESCE(rumus_perhitungan, ''),
                COALESCE(sumber_data, ''),
                COALESCE(definisi_operasional, ''),
-               COALESCE(jenis, '')
+               COALESCE(jenis, ''),
+               COALESCE((SELECT t.tahun FROM tb_target t WHERE t.indikator_id = kode_indikator LIMIT 1), '')
         FROM tb_indikator_matrix
         WHERE kode_indikator = ?`,
 		kodeIndikator,
@@ -1747,11 +1749,13 @@ func (r *SasaranOpdRepositoryImpl) FindIndikatorByKodeIndikator(
 	var indikator domain.Indikator
 	err := row.Scan(
 		&indikator.KodeIndikator,
+		&indikator.SasaranOpdId,
 		&indikator.Indikator,
 		&indikator.RumusPerhitungan,
 		&indikator.SumberData,
 		&indikator.DefinisiOperasional,
 		&indikator.Jenis,
+		&indikator.Tahun,
 	)
 	if err != nil {
 		return domain.Indikator{}, err
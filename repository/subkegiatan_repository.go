@@ -8,7 +8,7 @@ import (
 
 type SubKegiatanRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, subKegiatan domain.SubKegiatan) (domain.SubKegiatan, error)
-	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.SubKegiatan, error)
+	FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domain.SubKegiatan, int, error)
 	Update(ctx context.Context, tx *sql.Tx, subKegiatan domain.SubKegiatan) (domain.SubKegiatan, error)
 	FindById(ctx context.Context, tx *sql.Tx, subKegiatanId string) (domain.SubKegiatan, error)
 	Delete(ctx context.Context, tx *sql.Tx, subKegiatanId string) error
@@ -128,29 +128,59 @@ func (repository *SubKegiatanRepositoryImpl) Update(ctx context.Context, tx *sql
 	return subKegiatan, nil
 }
 
-func (repository *SubKegiatanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.SubKegiatan, error) {
-	script := `SELECT id, kode_subkegiatan, nama_subkegiatan, created_at FROM tb_subkegiatan ORDER BY kode_subkegiatan ASC`
+var subKegiatanListColumns = listColumns{
+	sort: map[string]string{
+		"kode":       "kode_subkegiatan",
+		"nama":       "nama_subkegiatan",
+		"created_at": "created_at",
+	},
+	filter: map[string]listFilter{
+		"is_active": {column: "is_active", boolean: true},
+	},
+	// sub kegiatan yang dinonaktifkan ApplyImport nomenklatur hanya tampil jika diminta dengan ?is_active=false
+	defaultFilter: map[string]string{"is_active": "true"},
+	search:        []string{"kode_subkegiatan", "nama_subkegiatan"},
+	defaultSort:   "kode_subkegiatan ASC",
+	tieBreaker:    "id",
+}
 
-	rows, err := tx.QueryContext(ctx, script)
+func (repository *SubKegiatanRepositoryImpl) FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domain.SubKegiatan, int, error) {
+	script := `SELECT id, kode_subkegiatan, nama_subkegiatan, created_at FROM tb_subkegiatan WHERE 1=1`
+	where, params, err := subKegiatanListColumns.where(query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	script += where
+
+	total, err := countList(ctx, tx, script, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderLimit, limitParams, err := subKegiatanListColumns.orderLimit(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.QueryContext(ctx, script+orderLimit, append(params, limitParams...)...)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer rows.Close()
 
-	subKegiatans := make([]domain.SubKegiatan, 0) // Bisa tambahkan kapasitas awal jika ada perkiraan jumlah data
+	subKegiatans := make([]domain.SubKegiatan, 0)
 	for rows.Next() {
 		var subKegiatan domain.SubKegiatan
 		if err := rows.Scan(&subKegiatan.Id, &subKegiatan.KodeSubKegiatan, &subKegiatan.NamaSubKegiatan, &subKegiatan.CreatedAt); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		subKegiatans = append(subKegiatans, subKegiatan)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return subKegiatans, nil
+	return subKegiatans, total, nil
 }
 
 func (repository *SubKegiatanRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, subKegiatanId string) (domain.SubKegiatan, error) {
@@ -13,6 +13,8 @@ type SubKegiatanTerpilihRepository interface {
 	CreateRekin(ctx context.Context, tx *sql.Tx, idSubKegiatan string, rekinId string, kodeSubKegiatan string) error
 	DeleteSubKegiatanTerpilih(ctx context.Context, tx *sql.Tx, idSubKegiatan string) error
 	FindAll(ctx context.Context, tx *sql.Tx, rekinId string) ([]domain.SubKegiatanTerpilih, error)
+	// FindKodeOpdTahunById kode_opd dan tahun rekin pemilik subkegiatan terpilih, string kosong jika tidak ditemukan
+	FindKodeOpdTahunById(ctx context.Context, tx *sql.Tx, idSubKegiatan string) (string, string, error)
 	//subkegiatan opd
 	CreateOPD(ctx context.Context, tx *sql.Tx, subkegiatanOpd domain.SubKegiatanOpd) (domain.SubKegiatanOpd, error)
 	UpdateOPD(ctx context.Context, tx *sql.Tx, subkegiatanOpd domain.SubKegiatanOpd) (domain.SubKegiatanOpd, error)
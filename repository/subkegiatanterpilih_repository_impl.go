@@ -104,6 +104,20 @@ func (repository *SubKegiatanTerpilihRepositoryImpl) DeleteSubKegiatanTerpilih(c
 	return nil
 }
 
+func (repository *SubKegiatanTerpilihRepositoryImpl) FindKodeOpdTahunById(ctx context.Context, tx *sql.Tx, idSubKegiatan string) (string, string, error) {
+	script := `
+		SELECT COALESCE(rk.kode_opd, ''), COALESCE(rk.tahun, '')
+		FROM tb_subkegiatan_terpilih st
+		INNER JOIN tb_rencana_kinerja rk ON rk.id = st.rekin_id
+		WHERE st.id = ?`
+	var kodeOpd, tahun string
+	err := tx.QueryRowContext(ctx, script, idSubKegiatan).Scan(&kodeOpd, &tahun)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	return kodeOpd, tahun, err
+}
+
 func (repository *SubKegiatanTerpilihRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, rekinId string) ([]domain.SubKegiatanTerpilih, error) {
 	script := "SELECT id, subkegiatan_id, rekin_id, kode_subkegiatan FROM tb_subkegiatan_terpilih WHERE rekin_id = ?"
 	rows, err := tx.QueryContext(ctx, script, rekinId)
@@ -1436,11 +1436,13 @@ func (r *TujuanOpdRepositoryImpl) FindIndikatorByKodeIndikator(
 ) (domain.Indikator, error) {
 	row := tx.QueryRowContext(ctx, `
         SELECT kode_indikator,
+               COALESCE(tujuan_opd_id, 0),
                COALESCE(indikator, ''),
                COALESCE(rumus_perhitungan, ''),
                COALESCE(sumber_data, ''),
                COALESCE(definisi_operasional, ''),
-               COALESCE(jenis, '')
+               COALESCE(jenis, ''),
+               COALESCE((SELECT t.tahun FROM tb_target t WHERE t.indikator_id = kode_indikator LIMIT 1), '')
         FROM tb_indikator_matrix
         WHERE kode_indikator = ?`,
 		kodeIndikator,
@@ -1448,11 +1450,13 @@ func (r *TujuanOpdRepositoryImpl) FindIndikatorByKodeIndikator(
 	var indikator domain.Indikator
 	err := row.Scan(
 		&indikator.KodeIndikator, // ← tidak scan id sama sekali
+		&indikator.TujuanOpdId,
 		&indikator.Indikator,
 		&indikator.RumusPerhitungan,
 		&indikator.SumberData,
 		&indikator.DefinisiOperasional,
 		&indikator.Jenis,
+		&indikator.Tahun,
 	)
 	if err != nil {
 		return domain.Indikator{}, err
@@ -36,7 +36,8 @@ func (repository *UrusanRepositoryImpl) Update(ctx context.Context, tx *sql.Tx,
 }
 
 func (repository *UrusanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.Urusan, error) {
-	script := "SELECT id, kode_urusan, nama_urusan, created_at FROM tb_urusan"
+	// urusan yang sudah tidak ada di nomenklatur terbaru (is_active = FALSE hasil ApplyImport) tidak ditampilkan
+	script := "SELECT id, kode_urusan, nama_urusan, created_at FROM tb_urusan WHERE is_active = TRUE"
 	rows, err := tx.QueryContext(ctx, script)
 	if err != nil {
 		return []domainmaster.Urusan{}, err
@@ -114,7 +115,7 @@ func (repository *UrusanRepositoryImpl) FindByKodeOpd(ctx context.Context, tx *s
 		return []domainmaster.Urusan{}, nil
 	}
 
-	query := "SELECT id, kode_urusan, nama_urusan FROM tb_urusan WHERE kode_urusan IN ("
+	query := "SELECT id, kode_urusan, nama_urusan FROM tb_urusan WHERE is_active = TRUE AND kode_urusan IN ("
 	params := make([]interface{}, len(kodeUrusans))
 	for i := range kodeUrusans {
 		if i > 0 {
@@ -202,7 +203,9 @@ func (repository *UrusanRepositoryImpl) FindUrusanAndBidangByKodeOpd(ctx context
             tb_urusan u
             INNER JOIN tb_bidang_urusan bu ON u.kode_urusan = LEFT(bu.kode_bidang_urusan, 1)
         WHERE 
-            bu.kode_bidang_urusan IN (%s)
+            u.is_active = TRUE
+            AND bu.is_active = TRUE
+            AND bu.kode_bidang_urusan IN (%s)
         ORDER BY 
             FIELD(bu.kode_bidang_urusan, %s)
         LIMIT 3
@@ -6,6 +6,7 @@ import (
 	"ekak_kabupaten_madiun/model/domain"
 	"log"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -137,6 +138,98 @@ func (repository *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, k
 	return users, nil
 }
 
+var userListColumns = listColumns{
+	sort: map[string]string{
+		"id":    "u.id",
+		"nip":   "u.nip",
+		"email": "u.email",
+		"nama":  "p.nama",
+	},
+	filter: map[string]listFilter{
+		"kode_opd":  {column: "p.kode_opd"},
+		"is_active": {column: "u.is_active", boolean: true},
+	},
+	search:      []string{"u.nip", "u.email", "p.nama"},
+	defaultSort: "p.nama ASC",
+	tieBreaker:  "u.id",
+}
+
+// FindAllPaged memaginasi user lebih dulu lalu mengambil role user di halaman tersebut,
+// sehingga LIMIT berlaku per user bukan per baris join role
+func (repository *UserRepositoryImpl) FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domain.Users, int, error) {
+	script := `
+        SELECT u.id, u.nip, u.email, u.is_active
+        FROM tb_users u
+        INNER JOIN tb_pegawai p ON u.nip = p.nip
+        WHERE 1=1`
+	where, params, err := userListColumns.where(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	script += where
+
+	total, err := countList(ctx, tx, script, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderLimit, limitParams, err := userListColumns.orderLimit(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.QueryContext(ctx, script+orderLimit, append(params, limitParams...)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var users []domain.Users
+	userIndex := make(map[int]int)
+	for rows.Next() {
+		user := domain.Users{Role: []domain.Roles{}}
+		if err := rows.Scan(&user.Id, &user.Nip, &user.Email, &user.IsActive); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		userIndex[user.Id] = len(users)
+		users = append(users, user)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil || len(users) == 0 {
+		return users, total, err
+	}
+
+	placeholders := make([]string, 0, len(users))
+	roleParams := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		placeholders = append(placeholders, "?")
+		roleParams = append(roleParams, user.Id)
+	}
+	roleScript := `
+        SELECT ur.user_id, r.id, r.role
+        FROM tb_user_role ur
+        INNER JOIN tb_role r ON ur.role_id = r.id
+        WHERE ur.user_id IN (` + strings.Join(placeholders, ",") + `)
+        ORDER BY ur.user_id, r.id`
+	roleRows, err := tx.QueryContext(ctx, roleScript, roleParams...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer roleRows.Close()
+
+	for roleRows.Next() {
+		var userId int
+		var role domain.Roles
+		if err := roleRows.Scan(&userId, &role.Id, &role.Role); err != nil {
+			return nil, 0, err
+		}
+		if i, ok := userIndex[userId]; ok {
+			users[i].Role = append(users[i].Role, role)
+		}
+	}
+	return users, total, roleRows.Err()
+}
+
 func (repository *UserRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int) (domain.Users, error) {
 	script := `
 		SELECT u.id, u.nip, u.email, u.password, u.is_active, ur.role_id, r.role 
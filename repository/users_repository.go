@@ -10,6 +10,7 @@ type UserRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, users domain.Users) (domain.Users, error)
 	Update(ctx context.Context, tx *sql.Tx, users domain.Users) (domain.Users, error)
 	FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string) ([]domain.Users, error)
+	FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domain.Users, int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (domain.Users, error)
 	FindByNip(ctx context.Context, tx *sql.Tx, nip string) (domain.Users, error)
 	Delete(ctx context.Context, tx *sql.Tx, id int) error
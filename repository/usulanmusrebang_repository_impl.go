@@ -88,6 +88,60 @@ func (repository *UsulanMusrebangRepositoryImpl) FindAll(ctx context.Context, tx
 	return usulanMusrebang, nil
 }
 
+var usulanMusrebangListColumns = listColumns{
+	sort: map[string]string{
+		"created_at": "created_at",
+		"usulan":     "usulan",
+		"tahun":      "tahun",
+		"status":     "status",
+	},
+	filter: map[string]listFilter{
+		"kode_opd":  {column: "kode_opd"},
+		"is_active": {column: "is_active", boolean: true},
+		"rekin_id":  {column: "rekin_id"},
+		"status":    {column: "status"},
+		"tahun":     {column: "tahun"},
+	},
+	search:      []string{"usulan", "alamat", "uraian"},
+	defaultSort: "created_at ASC",
+	tieBreaker:  "id",
+}
+
+func (repository *UsulanMusrebangRepositoryImpl) FindAllPaged(ctx context.Context, tx *sql.Tx, query domain.ListQuery) ([]domain.UsulanMusrebang, int, error) {
+	script := "SELECT id, usulan, alamat, uraian, tahun, rekin_id, kode_opd, is_active, status, created_at FROM tb_usulan_musrebang WHERE 1=1"
+	where, params, err := usulanMusrebangListColumns.where(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	script += where
+
+	total, err := countList(ctx, tx, script, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error saat menghitung usulan musrebang: %w", err)
+	}
+
+	orderLimit, limitParams, err := usulanMusrebangListColumns.orderLimit(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := tx.QueryContext(ctx, script+orderLimit, append(params, limitParams...)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error saat mencari usulan musrebang: %w", err)
+	}
+	defer rows.Close()
+
+	var usulanMusrebang []domain.UsulanMusrebang
+	for rows.Next() {
+		var usulan domain.UsulanMusrebang
+		err := rows.Scan(&usulan.Id, &usulan.Usulan, &usulan.Alamat, &usulan.Uraian, &usulan.Tahun, &usulan.RekinId, &usulan.KodeOpd, &usulan.IsActive, &usulan.Status, &usulan.CreatedAt)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error saat memindai usulan musrebang: %w", err)
+		}
+		usulanMusrebang = append(usulanMusrebang, usulan)
+	}
+	return usulanMusrebang, total, rows.Err()
+}
+
 func (repository *UsulanMusrebangRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, idUsulan string) error {
 	script := "DELETE FROM tb_usulan_musrebang WHERE id = ?"
 	_, err := tx.ExecContext(ctx, script, idUsulan)
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/auditlog"
+)
+
+type AuditLogService interface {
+	FindAll(ctx context.Context, request auditlog.AuditLogFilterRequest) ([]auditlog.AuditLogResponse, error)
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/auditlog"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	auditLogDefaultLimit = 100
+	auditLogMaxLimit     = 1000
+)
+
+type AuditLogServiceImpl struct {
+	AuditLogRepository repository.AuditLogRepository
+	DB                 *sql.DB
+}
+
+func NewAuditLogServiceImpl(auditLogRepository repository.AuditLogRepository, DB *sql.DB) *AuditLogServiceImpl {
+	return &AuditLogServiceImpl{
+		AuditLogRepository: auditLogRepository,
+		DB:                 DB,
+	}
+}
+
+func (service *AuditLogServiceImpl) FindAll(ctx context.Context, request auditlog.AuditLogFilterRequest) ([]auditlog.AuditLogResponse, error) {
+	filter, err := toAuditLogFilter(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// selain super_admin hanya boleh melihat riwayat OPD-nya sendiri
+	if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok && !helper.CanAccessAllOpd(claims) {
+		if filter.KodeOpd == "" {
+			filter.KodeOpd = claims.KodeOpd
+		}
+		if err := helper.ValidateKodeOpdAccess(ctx, filter.KodeOpd); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	auditLogs, err := service.AuditLogRepository.FindAll(ctx, tx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]auditlog.AuditLogResponse, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		responses = append(responses, auditlog.AuditLogResponse{
+			Id:         auditLog.Id,
+			UserId:     auditLog.UserId,
+			Nip:        auditLog.Nip,
+			Action:     auditLog.Action,
+			EntityType: auditLog.EntityType,
+			EntityId:   auditLog.EntityId,
+			KodeOpd:    auditLog.KodeOpd,
+			Tahun:      auditLog.Tahun,
+			Before:     rawAuditData(auditLog.BeforeData),
+			After:      rawAuditData(auditLog.AfterData),
+			CreatedAt:  auditLog.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return responses, nil
+}
+
+func toAuditLogFilter(request auditlog.AuditLogFilterRequest) (domain.AuditLogFilter, error) {
+	filter := domain.AuditLogFilter{
+		EntityType: request.EntityType,
+		EntityId:   request.EntityId,
+		KodeOpd:    request.KodeOpd,
+		Tahun:      request.Tahun,
+		UserId:     request.UserId,
+		Nip:        request.Nip,
+		Limit:      request.Limit,
+	}
+
+	if request.TanggalAwal != "" {
+		tanggalAwal, err := time.ParseInLocation("2006-01-02", request.TanggalAwal, time.Local)
+		if err != nil {
+			return filter, web.NewBadRequestError("format tanggal_awal harus YYYY-MM-DD")
+		}
+		filter.TanggalAwal = &tanggalAwal
+	}
+	if request.TanggalAkhir != "" {
+		tanggalAkhir, err := time.ParseInLocation("2006-01-02", request.TanggalAkhir, time.Local)
+		if err != nil {
+			return filter, web.NewBadRequestError("format tanggal_akhir harus YYYY-MM-DD")
+		}
+		// tanggal_akhir inklusif sampai akhir hari
+		tanggalAkhir = tanggalAkhir.AddDate(0, 0, 1)
+		filter.TanggalAkhir = &tanggalAkhir
+	}
+	if filter.TanggalAwal != nil && filter.TanggalAkhir != nil && !filter.TanggalAwal.Before(*filter.TanggalAkhir) {
+		return filter, web.NewBadRequestError("tanggal_awal tidak boleh setelah tanggal_akhir")
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = auditLogDefaultLimit
+	}
+	if filter.Limit > auditLogMaxLimit {
+		filter.Limit = auditLogMaxLimit
+	}
+	return filter, nil
+}
+
+func rawAuditData(data sql.NullString) json.RawMessage {
+	if !data.Valid {
+		return nil
+	}
+	return json.RawMessage(data.String)
+}
+
+// recordAuditLog dipanggil service lain di dalam transaksinya setelah data berhasil diubah.
+// Actor diambil dari JWT claims di context, proses tanpa claims (background) dicatat dengan user_id 0.
+// before/after berisi snapshot data sebelum dan sesudah perubahan, nil untuk create/delete.
+func recordAuditLog(ctx context.Context, tx *sql.Tx, auditLogRepository repository.AuditLogRepository, entry domain.AuditLog, before, after interface{}) error {
+	if auditLogRepository == nil {
+		return nil
+	}
+
+	if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok {
+		entry.UserId = claims.UserId
+		entry.Nip = claims.Nip
+	}
+
+	var err error
+	entry.BeforeData, err = auditData(before)
+	if err != nil {
+		return fmt.Errorf("audit log %s %s: %w", entry.EntityType, entry.EntityId, err)
+	}
+	entry.AfterData, err = auditData(after)
+	if err != nil {
+		return fmt.Errorf("audit log %s %s: %w", entry.EntityType, entry.EntityId, err)
+	}
+
+	_, err = auditLogRepository.Create(ctx, tx, entry)
+	return err
+}
+
+func auditData(data interface{}) (sql.NullString, error) {
+	if data == nil {
+		return sql.NullString{}, nil
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	if string(bytes) == "null" {
+		return sql.NullString{}, nil
+	}
+	return sql.NullString{String: string(bytes), Valid: true}, nil
+}
+
+// pohonKinerjaAuditSnapshot mengambil pohon kinerja beserta indikator, target dan pelaksananya
+// untuk disimpan di audit log. Mengembalikan nil jika pohon kinerja tidak ditemukan (sudah dihapus).
+func pohonKinerjaAuditSnapshot(ctx context.Context, tx *sql.Tx, pohonKinerjaRepository repository.PohonKinerjaRepository, id int) *domain.PohonKinerja {
+	pokin, err := pohonKinerjaRepository.FindById(ctx, tx, id)
+	if err != nil || pokin.Id == 0 {
+		return nil
+	}
+
+	indikators, err := pohonKinerjaRepository.FindIndikatorByPokinId(ctx, tx, strconv.Itoa(id))
+	if err == nil {
+		for i := range indikators {
+			targets, err := pohonKinerjaRepository.FindTargetByIndikatorId(ctx, tx, indikators[i].Id)
+			if err == nil {
+				indikators[i].Target = targets
+			}
+		}
+		pokin.Indikator = indikators
+	}
+
+	pelaksanas, err := pohonKinerjaRepository.FindPelaksanaPokin(ctx, tx, strconv.Itoa(id))
+	if err == nil {
+		pokin.Pelaksana = pelaksanas
+	}
+	return &pokin
+}
+
+// recordPohonKinerjaAudit mencatat perubahan pohon kinerja. Snapshot sesudah diambil ulang dari database
+// sehingga perubahan indikator/target ikut tercatat, kosong jika pohon kinerja sudah dihapus.
+func recordPohonKinerjaAudit(ctx context.Context, tx *sql.Tx, auditLogRepository repository.AuditLogRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, action string, id int, before *domain.PohonKinerja) error {
+	entry := domain.AuditLog{
+		Action:     action,
+		EntityType: domain.AuditEntityPohonKinerja,
+		EntityId:   strconv.Itoa(id),
+	}
+
+	var beforeData, afterData interface{}
+	if before != nil {
+		beforeData = before
+		entry.KodeOpd = before.KodeOpd
+		entry.Tahun = before.Tahun
+	}
+	if after := pohonKinerjaAuditSnapshot(ctx, tx, pohonKinerjaRepository, id); after != nil {
+		afterData = after
+		entry.KodeOpd = after.KodeOpd
+		entry.Tahun = after.Tahun
+	}
+	return recordAuditLog(ctx, tx, auditLogRepository, entry, beforeData, afterData)
+}
+
+// rencanaKinerjaAuditSnapshot mengambil rencana kinerja beserta indikator dan targetnya untuk audit log.
+// Mengembalikan nil jika rencana kinerja tidak ditemukan (sudah dihapus).
+func rencanaKinerjaAuditSnapshot(ctx context.Context, tx *sql.Tx, rencanaKinerjaRepository repository.RencanaKinerjaRepository, id string) *domain.RencanaKinerja {
+	rencanaKinerja, err := rencanaKinerjaRepository.FindById(ctx, tx, id, "", "")
+	if err != nil {
+		return nil
+	}
+
+	indikators, err := rencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, id)
+	if err == nil {
+		for i := range indikators {
+			targets, err := rencanaKinerjaRepository.FindTargetByIndikatorId(ctx, tx, indikators[i].Id)
+			if err == nil {
+				indikators[i].Target = targets
+			}
+		}
+		rencanaKinerja.Indikator = indikators
+	}
+	return &rencanaKinerja
+}
+
+// recordRencanaKinerjaAudit mencatat perubahan rencana kinerja, snapshot sesudah diambil ulang dari database
+func recordRencanaKinerjaAudit(ctx context.Context, tx *sql.Tx, auditLogRepository repository.AuditLogRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, action string, id string, before *domain.RencanaKinerja) error {
+	entry := domain.AuditLog{
+		Action:     action,
+		EntityType: domain.AuditEntityRencanaKinerja,
+		EntityId:   id,
+	}
+
+	var beforeData, afterData interface{}
+	if before != nil {
+		beforeData = before
+		entry.KodeOpd = before.KodeOpd
+		entry.Tahun = before.Tahun
+	}
+	if after := rencanaKinerjaAuditSnapshot(ctx, tx, rencanaKinerjaRepository, id); after != nil {
+		afterData = after
+		entry.KodeOpd = after.KodeOpd
+		entry.Tahun = after.Tahun
+	}
+	return recordAuditLog(ctx, tx, auditLogRepository, entry, beforeData, afterData)
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/auditlog"
+	"testing"
+)
+
+// fakeAuditLogRepository menyimpan audit log yang dicatat di memori
+type fakeAuditLogRepository struct {
+	entries []domain.AuditLog
+}
+
+func (repository *fakeAuditLogRepository) Create(ctx context.Context, tx *sql.Tx, auditLog domain.AuditLog) (domain.AuditLog, error) {
+	auditLog.Id = int64(len(repository.entries) + 1)
+	repository.entries = append(repository.entries, auditLog)
+	return auditLog, nil
+}
+
+func (repository *fakeAuditLogRepository) FindAll(ctx context.Context, tx *sql.Tx, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	return repository.entries, nil
+}
+
+func TestRecordAuditLog(t *testing.T) {
+	auditLogRepository := &fakeAuditLogRepository{}
+	ctx := context.WithValue(context.Background(), helper.UserInfoKey, web.JWTClaim{UserId: 7, Nip: "199001012020011001"})
+
+	var before *domain.PohonKinerja
+	after := &domain.PohonKinerja{Id: 10, NamaPohon: "Meningkatnya kualitas layanan"}
+
+	err := recordAuditLog(ctx, nil, auditLogRepository, domain.AuditLog{
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityPohonKinerja,
+		EntityId:   "10",
+	}, before, after)
+	if err != nil {
+		t.Fatalf("recordAuditLog error = %v", err)
+	}
+
+	if len(auditLogRepository.entries) != 1 {
+		t.Fatalf("jumlah audit log = %d; want 1", len(auditLogRepository.entries))
+	}
+	entry := auditLogRepository.entries[0]
+	if entry.UserId != 7 || entry.Nip != "199001012020011001" {
+		t.Errorf("actor = %d/%s; want 7/199001012020011001", entry.UserId, entry.Nip)
+	}
+	if entry.BeforeData.Valid {
+		t.Errorf("before_data = %s; want NULL", entry.BeforeData.String)
+	}
+	if !entry.AfterData.Valid {
+		t.Fatal("after_data NULL; want snapshot")
+	}
+
+	if err := recordAuditLog(ctx, nil, nil, domain.AuditLog{}, nil, nil); err != nil {
+		t.Errorf("recordAuditLog tanpa repository error = %v; want nil", err)
+	}
+}
+
+func TestToAuditLogFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		request auditlog.AuditLogFilterRequest
+		limit   int
+		wantErr bool
+	}{
+		{
+			name:    "limit default",
+			request: auditlog.AuditLogFilterRequest{},
+			limit:   auditLogDefaultLimit,
+		},
+		{
+			name:    "limit dibatasi maksimal",
+			request: auditlog.AuditLogFilterRequest{Limit: auditLogMaxLimit + 1},
+			limit:   auditLogMaxLimit,
+		},
+		{
+			name:    "tanggal sama dalam satu hari",
+			request: auditlog.AuditLogFilterRequest{TanggalAwal: "2025-01-31", TanggalAkhir: "2025-01-31"},
+			limit:   auditLogDefaultLimit,
+		},
+		{
+			name:    "format tanggal salah",
+			request: auditlog.AuditLogFilterRequest{TanggalAwal: "31-01-2025"},
+			wantErr: true,
+		},
+		{
+			name:    "tanggal awal setelah tanggal akhir",
+			request: auditlog.AuditLogFilterRequest{TanggalAwal: "2025-02-01", TanggalAkhir: "2025-01-31"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := toAuditLogFilter(tt.request)
+			if tt.wantErr {
+				if _, ok := err.(*web.CustomError); !ok {
+					t.Fatalf("error = %v; want *web.CustomError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v; want nil", err)
+			}
+			if filter.Limit != tt.limit {
+				t.Errorf("limit = %d; want %d", filter.Limit, tt.limit)
+			}
+		})
+	}
+}
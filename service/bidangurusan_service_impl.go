@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/bidangurusanresponse"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -23,6 +24,10 @@ func NewBidangUrusanServiceImpl(bidangUrusanRepository repository.BidangUrusanRe
 }
 
 func (service *BidangUrusanServiceImpl) Create(ctx context.Context, request bidangurusanresponse.BidangUrusanCreateRequest) (bidangurusanresponse.BidangUrusanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return bidangurusanresponse.BidangUrusanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return bidangurusanresponse.BidangUrusanResponse{}, err
@@ -49,6 +54,10 @@ func (service *BidangUrusanServiceImpl) Create(ctx context.Context, request bida
 }
 
 func (service *BidangUrusanServiceImpl) Update(ctx context.Context, request bidangurusanresponse.BidangUrusanUpdateRequest) (bidangurusanresponse.BidangUrusanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return bidangurusanresponse.BidangUrusanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return bidangurusanresponse.BidangUrusanResponse{}, err
@@ -156,6 +165,10 @@ func (service *BidangUrusanServiceImpl) FindByKodeOpd(ctx context.Context, kodeO
 }
 
 func (service *BidangUrusanServiceImpl) CreateOPD(ctx context.Context, request bidangurusanresponse.BidangUrusanOPDCreateRequest) (bidangurusanresponse.BidangUrusanOpdsResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return bidangurusanresponse.BidangUrusanOpdsResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return bidangurusanresponse.BidangUrusanOpdsResponse{}, err
@@ -168,7 +181,7 @@ func (service *BidangUrusanServiceImpl) CreateOPD(ctx context.Context, request b
 		return bidangurusanresponse.BidangUrusanOpdsResponse{}, err
 	}
 	if !masterExists {
-		return bidangurusanresponse.BidangUrusanOpdsResponse{}, fmt.Errorf("kode bidang urusan %s tidak ditemukan di data master", request.KodeBidangUrusan)
+		return bidangurusanresponse.BidangUrusanOpdsResponse{}, web.NewNotFoundError(fmt.Sprintf("kode bidang urusan %s tidak ditemukan di data master", request.KodeBidangUrusan))
 	}
 
 	// 2. Deduplikasi: Cek apakah sudah pernah dipilih untuk OPD ini
@@ -177,7 +190,7 @@ func (service *BidangUrusanServiceImpl) CreateOPD(ctx context.Context, request b
 		return bidangurusanresponse.BidangUrusanOpdsResponse{}, err
 	}
 	if alreadySelected {
-		return bidangurusanresponse.BidangUrusanOpdsResponse{}, fmt.Errorf("bidang urusan %s sudah terpilih untuk OPD ini", request.KodeBidangUrusan)
+		return bidangurusanresponse.BidangUrusanOpdsResponse{}, web.NewConflictError(fmt.Sprintf("bidang urusan %s sudah terpilih untuk OPD ini", request.KodeBidangUrusan))
 	}
 
 	// 3. Eksekusi Create
@@ -6,10 +6,10 @@ import (
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
 	"fmt"
 	"log"
 	"sort"
@@ -86,7 +86,7 @@ func (service *CascadingOpdServiceImpl) FindAll(ctx context.Context, kodeOpd, ta
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 	if err != nil {
 		log.Printf("Error: OPD not found for kode_opd=%s: %v", kodeOpd, err)
-		return pohonkinerja.CascadingOpdResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.CascadingOpdResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// Inisialisasi response dasar
@@ -909,7 +909,7 @@ func (service *CascadingOpdServiceImpl) buildOperationalNCascadingResponse(
 // 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 // 	if err != nil {
 // 		log.Printf("Error: OPD not found for kode_opd=%s: %v", kodeOpd, err)
-// 		return pohonkinerja.CascadingOpdResponse{}, errors.New("kode opd tidak ditemukan")
+// 		return pohonkinerja.CascadingOpdResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 // 	}
 
 // 	// Inisialisasi response dasar
@@ -2609,21 +2609,21 @@ func (service *CascadingOpdServiceImpl) FindByRekinPegawaiAndId(ctx context.Cont
 	pokinRekin, err := service.cascadingOpdRepository.FindPokinByRekinId(ctx, tx, rekinId)
 	if err != nil {
 		log.Printf("Error: Pohon kinerja not found for rekin_id=%s: %v", rekinId, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("rencana kinerja tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("rencana kinerja tidak ditemukan")
 	}
 
 	// 2. Ambil data rencana kinerja
 	rekin, err := service.rencanaKinerjaRepository.FindById(ctx, tx, rekinId, "", "")
 	if err != nil {
 		log.Printf("Error: Rencana kinerja not found: %v", err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("rencana kinerja tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("rencana kinerja tidak ditemukan")
 	}
 
 	// 3. Validasi pegawai
 	pegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, rekin.PegawaiId)
 	if err != nil {
 		log.Printf("Error: Pegawai not found for NIP=%s: %v", rekin.PegawaiId, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("pegawai tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("pegawai tidak ditemukan")
 	}
 
 	// 4. CHECK: Apakah pegawai adalah pelaksana di pohon ini?
@@ -2635,7 +2635,7 @@ func (service *CascadingOpdServiceImpl) FindByRekinPegawaiAndId(ctx context.Cont
 
 	if !isPelaksana {
 		log.Printf("Error: Pegawai ID %s (NIP %s) bukan pelaksana di pohon %d", pegawai.Id, pegawai.Nip, pokinRekin.Id)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("rencana kinerja tidak dipakai di cascading opd")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewBadRequestError("rencana kinerja tidak dipakai di cascading opd")
 	}
 
 	log.Printf("Pegawai ID %s is pelaksana of pokin %d", pegawai.Id, pokinRekin.Id)
@@ -2644,7 +2644,7 @@ func (service *CascadingOpdServiceImpl) FindByRekinPegawaiAndId(ctx context.Cont
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, pokinRekin.KodeOpd)
 	if err != nil {
 		log.Printf("Error: OPD not found for kode_opd=%s: %v", pokinRekin.KodeOpd, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// 6. Hitung total anggaran berdasarkan level - DENGAN FILTER PELAKSANA
@@ -3159,14 +3159,14 @@ func (service *CascadingOpdServiceImpl) FindByIdPokin(ctx context.Context, pokin
 	pokin, err := service.cascadingOpdRepository.FindPokinById(ctx, tx, pokinId)
 	if err != nil {
 		log.Printf("Error: Pohon kinerja not found for pokin_id=%d: %v", pokinId, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("pohon kinerja tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("pohon kinerja tidak ditemukan")
 	}
 
 	// 2. Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, pokin.KodeOpd)
 	if err != nil {
 		log.Printf("Error: OPD not found for kode_opd=%s: %v", pokin.KodeOpd, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// 3. Hitung total anggaran berdasarkan level - DENGAN FILTER PELAKSANA
@@ -3214,7 +3214,7 @@ func (service *CascadingOpdServiceImpl) FindByNip(ctx context.Context, nip strin
 	pegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, nip)
 	if err != nil {
 		log.Printf("Error: Pegawai not found for NIP=%s: %v", nip, err)
-		return []pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("pegawai tidak ditemukan")
+		return []pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("pegawai tidak ditemukan")
 	}
 
 	log.Printf("Found pegawai: ID=%s, NIP=%s, Nama=%s", pegawai.Id, pegawai.Nip, pegawai.NamaPegawai)
@@ -3694,7 +3694,7 @@ func (service *CascadingOpdServiceImpl) FindByMultipleRekinPegawai(ctx context.C
 
 	// Validasi request
 	if len(request.RekinIds) == 0 {
-		return nil, errors.New("rekin_ids tidak boleh kosong")
+		return nil, web.NewBadRequestError("rekin_ids tidak boleh kosong")
 	}
 
 	var responses []pohonkinerja.CascadingRekinPegawaiResponse
@@ -3742,21 +3742,21 @@ func (service *CascadingOpdServiceImpl) processSingleRekin(
 	pokinRekin, err := service.cascadingOpdRepository.FindPokinByRekinId(ctx, tx, rekinId)
 	if err != nil {
 		log.Printf("Error: Pohon kinerja not found for rekin_id=%s: %v", rekinId, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("rencana kinerja tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("rencana kinerja tidak ditemukan")
 	}
 
 	// 2. Ambil data rencana kinerja
 	rekin, err := service.rencanaKinerjaRepository.FindById(ctx, tx, rekinId, "", "")
 	if err != nil {
 		log.Printf("Error: Rencana kinerja not found: %v", err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("rencana kinerja tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("rencana kinerja tidak ditemukan")
 	}
 
 	// 3. Validasi pegawai
 	pegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, rekin.PegawaiId)
 	if err != nil {
 		log.Printf("Error: Pegawai not found for NIP=%s: %v", rekin.PegawaiId, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("pegawai tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("pegawai tidak ditemukan")
 	}
 
 	// 4. CHECK: Apakah pegawai adalah pelaksana di pohon ini?
@@ -3768,7 +3768,7 @@ func (service *CascadingOpdServiceImpl) processSingleRekin(
 
 	if !isPelaksana {
 		log.Printf("Error: Pegawai ID %s (NIP %s) bukan pelaksana di pohon %d", pegawai.Id, pegawai.Nip, pokinRekin.Id)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("rencana kinerja tidak dipakai di cascading opd")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewBadRequestError("rencana kinerja tidak dipakai di cascading opd")
 	}
 
 	log.Printf("Pegawai ID %s is pelaksana of pokin %d", pegawai.Id, pokinRekin.Id)
@@ -3777,7 +3777,7 @@ func (service *CascadingOpdServiceImpl) processSingleRekin(
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, pokinRekin.KodeOpd)
 	if err != nil {
 		log.Printf("Error: OPD not found for kode_opd=%s: %v", pokinRekin.KodeOpd, err)
-		return pohonkinerja.CascadingRekinPegawaiResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.CascadingRekinPegawaiResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// 6. Hitung total anggaran berdasarkan level - DENGAN FILTER PELAKSANA
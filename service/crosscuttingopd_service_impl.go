@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -21,24 +21,31 @@ type CrosscuttingOpdServiceImpl struct {
 	PegawaiRepository         repository.PegawaiRepository
 	OpdRepository             repository.OpdRepository
 	DB                        *sql.DB
+	AuditLogRepository        repository.AuditLogRepository
+	NotifikasiService         NotifikasiService
 }
 
-func NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepository repository.CrosscuttingOpdRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, DB *sql.DB) *CrosscuttingOpdServiceImpl {
+func NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepository repository.CrosscuttingOpdRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, DB *sql.DB, auditLogRepository repository.AuditLogRepository, notifikasiService NotifikasiService) *CrosscuttingOpdServiceImpl {
 	return &CrosscuttingOpdServiceImpl{
 		CrosscuttingOpdRepository: crosscuttingOpdRepository,
 		PohonKinerjaRepository:    pohonKinerjaRepository,
 		PegawaiRepository:         pegawaiRepository,
 		OpdRepository:             opdRepository,
 		DB:                        DB,
+		AuditLogRepository:        auditLogRepository,
+		NotifikasiService:         notifikasiService,
 	}
 }
 
 func (service *CrosscuttingOpdServiceImpl) Create(ctx context.Context, request pohonkinerja.CrosscuttingOpdCreateRequest, parentId int) (pohonkinerja.CrosscuttingDikirimResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.CrosscuttingDikirimResponse{}, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.CrosscuttingDikirimResponse{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer tx.Rollback()
 
 	// Konversi request ke domain
 	pokin := domain.PohonKinerja{
@@ -48,7 +55,7 @@ func (service *CrosscuttingOpdServiceImpl) Create(ctx context.Context, request p
 		KodeOpd:    request.KodeOpd,
 		Keterangan: request.Keterangan,
 		Tahun:      request.Tahun,
-		Status:     "crosscutting_menunggu",
+		Status:     domain.StatusPokinCrosscuttingMenunggu,
 	}
 
 	for _, indikatorReq := range request.Indikator {
@@ -82,10 +89,39 @@ func (service *CrosscuttingOpdServiceImpl) Create(ctx context.Context, request p
 	if err != nil {
 		return pohonkinerja.CrosscuttingDikirimResponse{}, err
 	}
+
+	err = service.recordCrosscuttingAudit(ctx, tx, domain.AuditActionCreate, result.Id, nil)
+	if err != nil {
+		return pohonkinerja.CrosscuttingDikirimResponse{}, err
+	}
 	namaOpdTujuan := ""
 	if opd, err := service.OpdRepository.FindByKodeOpd(ctx, tx, result.KodeOpd); err == nil {
 		namaOpdTujuan = opd.NamaOpd
 	}
+
+	pengirim := "OPD lain"
+	if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok && claims.KodeOpd != "" {
+		pengirim = claims.KodeOpd
+		if opd, err := service.OpdRepository.FindByKodeOpd(ctx, tx, claims.KodeOpd); err == nil {
+			pengirim = opd.NamaOpd
+		}
+	}
+	err = service.NotifikasiService.Kirim(ctx, tx, domain.Notifikasi{
+		Jenis:      domain.JenisNotifikasiCrosscutting,
+		Judul:      "Permintaan crosscutting baru",
+		Pesan:      fmt.Sprintf("%s mengirim crosscutting \"%s\" yang menunggu persetujuan", pengirim, request.NamaPohon),
+		EntityType: domain.AuditEntityCrosscutting,
+		EntityId:   strconv.Itoa(result.Id),
+		KodeOpd:    result.KodeOpd,
+		Tahun:      request.Tahun,
+		Url:        fmt.Sprintf("/crosscutting_menunggu/%s/%s", result.KodeOpd, request.Tahun),
+	}, domain.PenerimaNotifikasi{KodeOpd: result.KodeOpd, Roles: []string{helper.RoleAdminOpd}})
+	if err != nil {
+		return pohonkinerja.CrosscuttingDikirimResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.CrosscuttingDikirimResponse{}, err
+	}
 	response := pohonkinerja.CrosscuttingDikirimResponse{
 		IdCrosscutting:         result.Id,
 		KeteranganCrosscutting: result.Keterangan,
@@ -98,11 +134,15 @@ func (service *CrosscuttingOpdServiceImpl) Create(ctx context.Context, request p
 }
 
 func (service *CrosscuttingOpdServiceImpl) Update(ctx context.Context, request pohonkinerja.CrosscuttingOpdUpdateRequest) (pohonkinerja.CrosscuttingOpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.CrosscuttingOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.CrosscuttingOpdResponse{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer tx.Rollback()
 
 	// Konversi request ke domain
 	pokin := domain.PohonKinerja{
@@ -115,12 +155,22 @@ func (service *CrosscuttingOpdServiceImpl) Update(ctx context.Context, request p
 		Tahun:      request.Tahun,
 	}
 
+	before := service.findCrosscuttingSnapshot(ctx, tx, request.Id)
+
 	// Update data
 	result, err := service.CrosscuttingOpdRepository.UpdateCrosscutting(ctx, tx, pokin)
 	if err != nil {
 		return pohonkinerja.CrosscuttingOpdResponse{}, err
 	}
 
+	err = service.recordCrosscuttingAudit(ctx, tx, domain.AuditActionUpdate, request.Id, before)
+	if err != nil {
+		return pohonkinerja.CrosscuttingOpdResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.CrosscuttingOpdResponse{}, err
+	}
+
 	// Konversi ke response
 	response := pohonkinerja.CrosscuttingOpdResponse{
 		Id:         result.Id,
@@ -256,26 +306,40 @@ func (service *CrosscuttingOpdServiceImpl) ApproveOrReject(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer tx.Rollback()
 
 	// Validasi request
 	if request.Approve {
 		if !request.CreateNew && !request.UseExisting {
-			return nil, errors.New("harus memilih create new atau use existing untuk approval")
+			return nil, web.NewBadRequestError("harus memilih create new atau use existing untuk approval")
 		}
 		if request.CreateNew && request.UseExisting {
-			return nil, errors.New("tidak bisa memilih create new dan use existing sekaligus")
+			return nil, web.NewBadRequestError("tidak bisa memilih create new dan use existing sekaligus")
 		}
 		if request.UseExisting && request.ExistingId == 0 {
-			return nil, errors.New("existing_id harus diisi jika menggunakan pohon kinerja yang sudah ada")
+			return nil, web.NewBadRequestError("existing_id harus diisi jika menggunakan pohon kinerja yang sudah ada")
 		}
 	}
 
+	before := service.findCrosscuttingSnapshot(ctx, tx, crosscuttingId)
+
 	err = service.CrosscuttingOpdRepository.ApproveOrRejectCrosscutting(ctx, tx, crosscuttingId, request)
 	if err != nil {
 		return nil, err
 	}
 
+	action := domain.AuditActionReject
+	if request.Approve {
+		action = domain.AuditActionApprove
+	}
+	err = service.recordCrosscuttingAudit(ctx, tx, action, crosscuttingId, before)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	currentTime := time.Now()
 	response := &pohonkinerja.CrosscuttingApproveResponse{
 		Id:      crosscuttingId,
@@ -304,8 +368,17 @@ func (service *CrosscuttingOpdServiceImpl) Delete(ctx context.Context, crosscutt
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
-	defer helper.CommitOrRollback(tx)
-	return service.CrosscuttingOpdRepository.DeleteCrosscutting(ctx, tx, crosscuttingId, nipPegawai)
+	defer tx.Rollback()
+
+	before := service.findCrosscuttingSnapshot(ctx, tx, crosscuttingId)
+	err = service.CrosscuttingOpdRepository.DeleteCrosscutting(ctx, tx, crosscuttingId, nipPegawai)
+	if err != nil {
+		return err
+	}
+	if err := service.recordCrosscuttingAudit(ctx, tx, domain.AuditActionDelete, crosscuttingId, before); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (service *CrosscuttingOpdServiceImpl) DeleteUnused(ctx context.Context, crosscuttingId int) error {
@@ -313,14 +386,18 @@ func (service *CrosscuttingOpdServiceImpl) DeleteUnused(ctx context.Context, cro
 	if err != nil {
 		return err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer tx.Rollback()
 
+	before := service.findCrosscuttingSnapshot(ctx, tx, crosscuttingId)
 	err = service.CrosscuttingOpdRepository.DeleteUnused(ctx, tx, crosscuttingId)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if err := service.recordCrosscuttingAudit(ctx, tx, domain.AuditActionDelete, crosscuttingId, before); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (service *CrosscuttingOpdServiceImpl) FindPokinByCrosscuttingStatus(ctx context.Context, kodeOpd string, tahun string) ([]pohonkinerja.CrosscuttingOpdResponse, error) {
@@ -402,8 +479,17 @@ func (service *CrosscuttingOpdServiceImpl) DeleteCrosscuttingDiterima(ctx contex
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
-	defer helper.CommitOrRollback(tx)
-	return service.CrosscuttingOpdRepository.DeleteCrosscuttingDiterima(ctx, tx, crosscuttingId)
+	defer tx.Rollback()
+
+	before := service.findCrosscuttingSnapshot(ctx, tx, crosscuttingId)
+	err = service.CrosscuttingOpdRepository.DeleteCrosscuttingDiterima(ctx, tx, crosscuttingId)
+	if err != nil {
+		return err
+	}
+	if err := service.recordCrosscuttingAudit(ctx, tx, domain.AuditActionDelete, crosscuttingId, before); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // Plan B
@@ -414,6 +500,47 @@ func (service *CrosscuttingOpdServiceImpl) UnlinkCrosscuttingDiterima(
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
-	defer helper.CommitOrRollback(tx)
-	return service.CrosscuttingOpdRepository.UnlinkCrosscuttingDiterima(ctx, tx, crosscuttingId)
+	defer tx.Rollback()
+
+	before := service.findCrosscuttingSnapshot(ctx, tx, crosscuttingId)
+	err = service.CrosscuttingOpdRepository.UnlinkCrosscuttingDiterima(ctx, tx, crosscuttingId)
+	if err != nil {
+		return err
+	}
+	if err := service.recordCrosscuttingAudit(ctx, tx, domain.AuditActionDelete, crosscuttingId, before); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// findCrosscuttingSnapshot data tb_crosscutting sebelum diubah, nil jika tidak ditemukan
+func (service *CrosscuttingOpdServiceImpl) findCrosscuttingSnapshot(ctx context.Context, tx *sql.Tx, crosscuttingId int) *domain.Crosscutting {
+	crosscutting, err := service.CrosscuttingOpdRepository.FindById(ctx, tx, crosscuttingId)
+	if err != nil {
+		return nil
+	}
+	return &crosscutting
+}
+
+// recordCrosscuttingAudit mencatat perubahan crosscutting, snapshot sesudah diambil ulang dari tb_crosscutting
+// (kosong jika baris sudah dihapus)
+func (service *CrosscuttingOpdServiceImpl) recordCrosscuttingAudit(ctx context.Context, tx *sql.Tx, action string, crosscuttingId int, before *domain.Crosscutting) error {
+	entry := domain.AuditLog{
+		Action:     action,
+		EntityType: domain.AuditEntityCrosscutting,
+		EntityId:   strconv.Itoa(crosscuttingId),
+	}
+
+	var beforeData, afterData interface{}
+	if before != nil {
+		beforeData = before
+		entry.KodeOpd = before.KodeOpd
+		entry.Tahun = before.Tahun
+	}
+	if after := service.findCrosscuttingSnapshot(ctx, tx, crosscuttingId); after != nil {
+		afterData = after
+		entry.KodeOpd = after.KodeOpd
+		entry.Tahun = after.Tahun
+	}
+	return recordAuditLog(ctx, tx, service.AuditLogRepository, entry, beforeData, afterData)
 }
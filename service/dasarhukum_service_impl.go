@@ -13,24 +13,39 @@ import (
 )
 
 type DasarHukumServiceImpl struct {
-	DasarHukumRepository repository.DasarHukumRepository
-	DB                   *sql.DB
+	DasarHukumRepository     repository.DasarHukumRepository
+	RencanaKinerjaRepository repository.RencanaKinerjaRepository
+	LockDataRepository       repository.LockDataRepository
+	DB                       *sql.DB
 }
 
-func NewDasarHukumServiceImpl(dasarHukumRepository repository.DasarHukumRepository, DB *sql.DB) *DasarHukumServiceImpl {
+func NewDasarHukumServiceImpl(dasarHukumRepository repository.DasarHukumRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB) *DasarHukumServiceImpl {
 	return &DasarHukumServiceImpl{
-		DasarHukumRepository: dasarHukumRepository,
-		DB:                   DB,
+		DasarHukumRepository:     dasarHukumRepository,
+		RencanaKinerjaRepository: rencanaKinerjaRepository,
+		LockDataRepository:       lockDataRepository,
+		DB:                       DB,
 	}
 }
 
 func (service *DasarHukumServiceImpl) Create(ctx context.Context, request dasarhukum.DasarHukumCreateRequest) (dasarhukum.DasarHukumResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return dasarhukum.DasarHukumResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, request.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, request.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
+
 	// Membuat UUID dengan format yang diinginkan
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	uuId := fmt.Sprintf("DASHU-REKIN-%s", randomDigits)
@@ -64,6 +79,10 @@ func (service *DasarHukumServiceImpl) Create(ctx context.Context, request dasarh
 }
 
 func (service *DasarHukumServiceImpl) Update(ctx context.Context, request dasarhukum.DasarHukumUpdateRequest) (dasarhukum.DasarHukumResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return dasarhukum.DasarHukumResponse{}, err
@@ -74,6 +93,12 @@ func (service *DasarHukumServiceImpl) Update(ctx context.Context, request dasarh
 	if err != nil {
 		return dasarhukum.DasarHukumResponse{}, err
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, dasarHukum.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, dasarHukum.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
 
 	dasarHukum.PeraturanTerkait = request.PeraturanTerkait
 	dasarHukum.Uraian = request.Uraian
@@ -91,6 +116,17 @@ func (service *DasarHukumServiceImpl) Delete(ctx context.Context, id string) err
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	dasarHukum, err := service.DasarHukumRepository.FindById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, dasarHukum.RekinId); err != nil {
+		return err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, dasarHukum.RekinId); err != nil {
+		return err
+	}
+
 	err = service.DasarHukumRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return err
@@ -104,6 +140,10 @@ func (service *DasarHukumServiceImpl) FindAll(ctx context.Context, rekinId strin
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, rekinId); err != nil {
+		return []dasarhukum.DasarHukumResponse{}, err
+	}
+
 	dasarHukums, err := service.DasarHukumRepository.FindAll(ctx, tx, rekinId)
 	if err != nil {
 		return []dasarhukum.DasarHukumResponse{}, err
@@ -121,6 +161,9 @@ func (service *DasarHukumServiceImpl) FindById(ctx context.Context, id string) (
 	if err != nil {
 		return dasarhukum.DasarHukumResponse{}, err
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, dasarHukum.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
 
 	return helper.ToDasarHukumResponse(dasarHukum), nil
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/gambaranumum"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -13,24 +14,39 @@ import (
 )
 
 type GambaranUmumServiceImpl struct {
-	gambaranUmumRepository repository.GambaranUmumRepository
-	DB                     *sql.DB
+	gambaranUmumRepository   repository.GambaranUmumRepository
+	rencanaKinerjaRepository repository.RencanaKinerjaRepository
+	lockDataRepository       repository.LockDataRepository
+	DB                       *sql.DB
 }
 
-func NewGambaranUmumServiceImpl(gambaranUmumRepository repository.GambaranUmumRepository, DB *sql.DB) *GambaranUmumServiceImpl {
+func NewGambaranUmumServiceImpl(gambaranUmumRepository repository.GambaranUmumRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB) *GambaranUmumServiceImpl {
 	return &GambaranUmumServiceImpl{
-		gambaranUmumRepository: gambaranUmumRepository,
-		DB:                     DB,
+		gambaranUmumRepository:   gambaranUmumRepository,
+		rencanaKinerjaRepository: rencanaKinerjaRepository,
+		lockDataRepository:       lockDataRepository,
+		DB:                       DB,
 	}
 }
 
 func (service *GambaranUmumServiceImpl) Create(ctx context.Context, request gambaranumum.GambaranUmumCreateRequest) (gambaranumum.GambaranUmumResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return gambaranumum.GambaranUmumResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, request.RekinId); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.rencanaKinerjaRepository, service.lockDataRepository, request.RekinId); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+
 	// Membuat UUID dengan format yang diinginkan
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	uuId := fmt.Sprintf("GMBRUMUM-REKIN-%s", randomDigits)
@@ -61,10 +77,18 @@ func (service *GambaranUmumServiceImpl) Create(ctx context.Context, request gamb
 }
 
 func (service *GambaranUmumServiceImpl) Update(ctx context.Context, request gambaranumum.GambaranUmumUpdateRequest) (gambaranumum.GambaranUmumResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	if err := service.checkGambaranUmumAccess(ctx, tx, request.Id); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+
 	gambaranUmum := domain.GambaranUmum{
 		Id:           request.Id,
 		Urutan:       request.Urutan,
@@ -84,8 +108,10 @@ func (service *GambaranUmumServiceImpl) Delete(ctx context.Context, id string) e
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	if err := service.checkGambaranUmumAccess(ctx, tx, id); err != nil {
+		return err
+	}
 	return service.gambaranUmumRepository.Delete(ctx, tx, id)
-
 }
 
 func (service *GambaranUmumServiceImpl) FindAll(ctx context.Context, rekinId string) ([]gambaranumum.GambaranUmumResponse, error) {
@@ -95,16 +121,20 @@ func (service *GambaranUmumServiceImpl) FindAll(ctx context.Context, rekinId str
 	}
 	defer tx.Rollback() // Hanya melakukan rollback jika belum di-commit
 
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, rekinId); err != nil {
+		return nil, err
+	}
+
 	gambaranUmums, err := service.gambaranUmumRepository.FindAll(ctx, tx, rekinId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("rekin dengan ID %s tidak ditemukan", rekinId)
+			return nil, web.NewNotFoundError(fmt.Sprintf("rekin dengan ID %s tidak ditemukan", rekinId))
 		}
 		return nil, fmt.Errorf("gagal mengambil data: %v", err)
 	}
 
 	// if len(gambaranUmums) == 0 {
-	// 	return nil, fmt.Errorf("tidak ada gambaran umum untuk rekin dengan ID %s", rekinId)
+	// 	return nil, web.NewNotFoundError(fmt.Sprintf("tidak ada gambaran umum untuk rekin dengan ID %s", rekinId))
 	// }
 
 	// Commit transaksi jika berhasil
@@ -123,10 +153,28 @@ func (service *GambaranUmumServiceImpl) FindById(ctx context.Context, id string)
 	gambaranUmum, err := service.gambaranUmumRepository.FindById(ctx, tx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return gambaranumum.GambaranUmumResponse{}, fmt.Errorf("gambaran umum dengan ID %s tidak ditemukan", id)
+			return gambaranumum.GambaranUmumResponse{}, web.NewNotFoundError(fmt.Sprintf("gambaran umum dengan ID %s tidak ditemukan", id))
 		}
 		return gambaranumum.GambaranUmumResponse{}, err
 	}
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, gambaranUmum.RekinId); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
 
 	return helper.ToGambaranUmumResponse(gambaranUmum), nil
 }
+
+// checkGambaranUmumAccess cek akses OPD dan kunci rekin pemilik gambaran umum, id yang tidak ditemukan dibiarkan ke repository
+func (service *GambaranUmumServiceImpl) checkGambaranUmumAccess(ctx context.Context, tx *sql.Tx, id string) error {
+	gambaranUmum, err := service.gambaranUmumRepository.FindById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, gambaranUmum.RekinId); err != nil {
+		return err
+	}
+	return checkRekinLock(ctx, tx, service.rencanaKinerjaRepository, service.lockDataRepository, gambaranUmum.RekinId)
+}
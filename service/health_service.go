@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/health"
+)
+
+type HealthService interface {
+	// Check memeriksa MySQL dan Redis. MySQL wajib (DOWN jika gagal),
+	// Redis hanya cache sehingga kegagalannya dilaporkan sebagai DEGRADED.
+	Check(ctx context.Context) health.HealthResponse
+	// Ready false setelah server mulai shutdown agar load balancer berhenti mengirim request
+	Ready() bool
+	SetShuttingDown()
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/web/health"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+type HealthServiceImpl struct {
+	DB          *sql.DB
+	RedisClient *redis.Client
+
+	shuttingDown atomic.Bool
+}
+
+func NewHealthServiceImpl(DB *sql.DB, redisClient *redis.Client) *HealthServiceImpl {
+	return &HealthServiceImpl{
+		DB:          DB,
+		RedisClient: redisClient,
+	}
+}
+
+func (service *HealthServiceImpl) Check(ctx context.Context) health.HealthResponse {
+	checks := []health.HealthCheckResponse{
+		service.checkMysql(ctx),
+		service.checkRedis(ctx),
+	}
+	return health.HealthResponse{
+		Status: healthStatus(checks),
+		Checks: checks,
+	}
+}
+
+func (service *HealthServiceImpl) Ready() bool {
+	return !service.shuttingDown.Load()
+}
+
+func (service *HealthServiceImpl) SetShuttingDown() {
+	service.shuttingDown.Store(true)
+}
+
+func (service *HealthServiceImpl) checkMysql(ctx context.Context) health.HealthCheckResponse {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := service.DB.PingContext(ctx)
+	stats := service.DB.Stats()
+	check := health.HealthCheckResponse{
+		Name:      "mysql",
+		Status:    health.StatusUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Detail: health.MysqlPoolResponse{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+		},
+	}
+	if err != nil {
+		check.Status = health.StatusDown
+		check.Error = err.Error()
+	}
+	return check
+}
+
+func (service *HealthServiceImpl) checkRedis(ctx context.Context) health.HealthCheckResponse {
+	check := health.HealthCheckResponse{
+		Name:   "redis",
+		Status: health.StatusUp,
+	}
+	if service.RedisClient == nil {
+		check.Status = health.StatusDegraded
+		check.Error = "redis tidak dikonfigurasi"
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := service.RedisClient.Ping(ctx).Err()
+	check.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		// cache bersifat opsional, aplikasi tetap jalan tanpa redis
+		check.Status = health.StatusDegraded
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// healthStatus status keseluruhan mengikuti check terburuk
+func healthStatus(checks []health.HealthCheckResponse) string {
+	status := health.StatusUp
+	for _, check := range checks {
+		switch check.Status {
+		case health.StatusDown:
+			return health.StatusDown
+		case health.StatusDegraded:
+			status = health.StatusDegraded
+		}
+	}
+	return status
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/health"
+	"testing"
+)
+
+func TestHealthStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []string
+		want   string
+	}{
+		{name: "semua up", checks: []string{health.StatusUp, health.StatusUp}, want: health.StatusUp},
+		{name: "redis mati", checks: []string{health.StatusUp, health.StatusDegraded}, want: health.StatusDegraded},
+		{name: "mysql mati", checks: []string{health.StatusDown, health.StatusDegraded}, want: health.StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checks []health.HealthCheckResponse
+			for _, status := range tt.checks {
+				checks = append(checks, health.HealthCheckResponse{Status: status})
+			}
+			if got := healthStatus(checks); got != tt.want {
+				t.Errorf("healthStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthCheckRedisTanpaClient(t *testing.T) {
+	service := NewHealthServiceImpl(nil, nil)
+	if got := service.checkRedis(context.Background()); got.Status != health.StatusDegraded {
+		t.Errorf("checkRedis() status = %s, want %s", got.Status, health.StatusDegraded)
+	}
+
+	if !service.Ready() {
+		t.Fatal("Ready() = false sebelum shutdown")
+	}
+	service.SetShuttingDown()
+	if service.Ready() {
+		t.Error("Ready() = true setelah shutdown")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/inovasi"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -13,24 +14,39 @@ import (
 )
 
 type InovasiServiceImpl struct {
-	InovasiRepository repository.InovasiRepository
-	DB                *sql.DB
+	InovasiRepository        repository.InovasiRepository
+	RencanaKinerjaRepository repository.RencanaKinerjaRepository
+	LockDataRepository       repository.LockDataRepository
+	DB                       *sql.DB
 }
 
-func NewInovasiServiceImpl(inovasiRepository repository.InovasiRepository, DB *sql.DB) *InovasiServiceImpl {
+func NewInovasiServiceImpl(inovasiRepository repository.InovasiRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB) *InovasiServiceImpl {
 	return &InovasiServiceImpl{
-		InovasiRepository: inovasiRepository,
-		DB:                DB,
+		InovasiRepository:        inovasiRepository,
+		RencanaKinerjaRepository: rencanaKinerjaRepository,
+		LockDataRepository:       lockDataRepository,
+		DB:                       DB,
 	}
 }
 
 func (service *InovasiServiceImpl) Create(ctx context.Context, request inovasi.InovasiCreateRequest) (inovasi.InovasiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return inovasi.InovasiResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, request.RekinId); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, request.RekinId); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	uuId := fmt.Sprintf("INOV-REKIN-%s", randomDigits)
 
@@ -53,12 +69,39 @@ func (service *InovasiServiceImpl) Create(ctx context.Context, request inovasi.I
 }
 
 func (service *InovasiServiceImpl) Update(ctx context.Context, request inovasi.InovasiUpdateRequest) (inovasi.InovasiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return inovasi.InovasiResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer helper.CommitOrRollback(tx)
 
+	existing, err := service.InovasiRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return inovasi.InovasiResponse{}, web.NewNotFoundError("id tidak ditemukan")
+		}
+		return inovasi.InovasiResponse{}, err
+	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, existing.RekinId); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, existing.RekinId); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+	// inovasi yang dipindah ke rekin lain juga harus milik OPD sendiri dan tidak terkunci
+	if request.RekinId != existing.RekinId {
+		if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, request.RekinId); err != nil {
+			return inovasi.InovasiResponse{}, err
+		}
+		if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, request.RekinId); err != nil {
+			return inovasi.InovasiResponse{}, err
+		}
+	}
+
 	domainInovasi := domain.Inovasi{
 		Id:                    request.Id,
 		RekinId:               request.RekinId,
@@ -83,6 +126,10 @@ func (service *InovasiServiceImpl) FindAll(ctx context.Context, rekinId string)
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, rekinId); err != nil {
+		return []inovasi.InovasiResponse{}, err
+	}
+
 	inovasis, err := service.InovasiRepository.FindAll(ctx, tx, rekinId)
 	if err != nil {
 		return []inovasi.InovasiResponse{}, err
@@ -102,10 +149,13 @@ func (service *InovasiServiceImpl) FindById(ctx context.Context, inovasiId strin
 	inovasis, err := service.InovasiRepository.FindById(ctx, tx, inovasiId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return inovasi.InovasiResponse{}, fmt.Errorf("id tidak ditemukan")
+			return inovasi.InovasiResponse{}, web.NewNotFoundError("id tidak ditemukan")
 		}
 		return inovasi.InovasiResponse{}, err
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, inovasis.RekinId); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
 
 	response := helper.ToInovasiResponse(inovasis)
 	return response, nil
@@ -119,13 +169,19 @@ func (service *InovasiServiceImpl) Delete(ctx context.Context, inovasiId string)
 	defer helper.CommitOrRollback(tx)
 
 	// Periksa apakah inovasi dengan ID tersebut ada
-	_, err = service.InovasiRepository.FindById(ctx, tx, inovasiId)
+	existing, err := service.InovasiRepository.FindById(ctx, tx, inovasiId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("id tidak ditemukan")
+			return web.NewNotFoundError("id tidak ditemukan")
 		}
 		return err
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, existing.RekinId); err != nil {
+		return err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, existing.RekinId); err != nil {
+		return err
+	}
 
 	err = service.InovasiRepository.Delete(ctx, tx, inovasiId)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/jabatan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -28,6 +29,10 @@ func NewJabatanServiceImpl(jabatanRepository repository.JabatanRepository, opdRe
 }
 
 func (service *JabatanServiceImpl) Create(ctx context.Context, request jabatan.JabatanCreateRequest) (jabatan.JabatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return jabatan.JabatanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
@@ -36,7 +41,7 @@ func (service *JabatanServiceImpl) Create(ctx context.Context, request jabatan.J
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Kode OPD %s tidak ditemukan", request.KodeOpd)
-			return jabatan.JabatanResponse{}, fmt.Errorf("kode OPD %s tidak ditemukan", request.KodeOpd)
+			return jabatan.JabatanResponse{}, web.NewNotFoundError(fmt.Sprintf("kode OPD %s tidak ditemukan", request.KodeOpd))
 		}
 		log.Printf("Gagal memeriksa kode OPD: %v", err)
 		return jabatan.JabatanResponse{}, fmt.Errorf("gagal memeriksa kode OPD: %v", err)
@@ -44,7 +49,7 @@ func (service *JabatanServiceImpl) Create(ctx context.Context, request jabatan.J
 
 	if opd.KodeOpd == "" {
 		log.Printf("Kode OPD %s tidak valid", request.KodeOpd)
-		return jabatan.JabatanResponse{}, fmt.Errorf("kode OPD %s tidak valid", request.KodeOpd)
+		return jabatan.JabatanResponse{}, web.NewBadRequestError(fmt.Sprintf("kode OPD %s tidak valid", request.KodeOpd))
 	}
 
 	uuid := uuid.New().String()
@@ -70,6 +75,10 @@ func (service *JabatanServiceImpl) Create(ctx context.Context, request jabatan.J
 }
 
 func (service *JabatanServiceImpl) Update(ctx context.Context, request jabatan.JabatanUpdateRequest) (jabatan.JabatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return jabatan.JabatanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
@@ -78,7 +87,7 @@ func (service *JabatanServiceImpl) Update(ctx context.Context, request jabatan.J
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Kode OPD %s tidak ditemukan", request.KodeOpd)
-			return jabatan.JabatanResponse{}, fmt.Errorf("kode OPD %s tidak ditemukan", request.KodeOpd)
+			return jabatan.JabatanResponse{}, web.NewNotFoundError(fmt.Sprintf("kode OPD %s tidak ditemukan", request.KodeOpd))
 		}
 		log.Printf("Gagal memeriksa kode OPD: %v", err)
 		return jabatan.JabatanResponse{}, fmt.Errorf("gagal memeriksa kode OPD: %v", err)
@@ -86,7 +95,7 @@ func (service *JabatanServiceImpl) Update(ctx context.Context, request jabatan.J
 
 	if opd.KodeOpd == "" {
 		log.Printf("Kode OPD %s tidak valid", request.KodeOpd)
-		return jabatan.JabatanResponse{}, fmt.Errorf("kode OPD %s tidak valid", request.KodeOpd)
+		return jabatan.JabatanResponse{}, web.NewBadRequestError(fmt.Sprintf("kode OPD %s tidak valid", request.KodeOpd))
 	}
 
 	jabatanDomain := domainmaster.Jabatan{
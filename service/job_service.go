@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/job"
+)
+
+// JobProgressFunc dipanggil handler untuk melaporkan progress job dalam persen (0-100)
+type JobProgressFunc func(percent int)
+
+// JobHandler proses yang dijalankan job runner untuk satu jenis job
+type JobHandler struct {
+	// Run menjalankan job. ctx dibatalkan jika job diminta batal atau server berhenti,
+	// sehingga perubahan data harus dalam transaksi yang di-rollback saat Run mengembalikan error.
+	Run func(ctx context.Context, job domain.Job, progress JobProgressFunc) (domain.JobResult, error)
+	// OnFinish opsional, dipanggil setelah status akhir job tersimpan,
+	// termasuk saat job dibatalkan sebelum jalan atau digagalkan karena worker berhenti tanpa heartbeat
+	OnFinish func(ctx context.Context, job domain.Job)
+}
+
+type JobService interface {
+	RegisterHandler(jenis string, handler JobHandler)
+	// Enqueue menyimpan job baru di transaksi pemanggil sehingga job hanya masuk antrian jika transaksi di-commit
+	Enqueue(ctx context.Context, tx *sql.Tx, jenis string, payload interface{}, kodeOpd string, tahun string) (domain.Job, error)
+	FindById(ctx context.Context, id int64) (job.JobResponse, error)
+	Cancel(ctx context.Context, id int64) (job.JobResponse, error)
+}
+
+// JobRunner worker pool yang mengambil job dari tb_job, dijalankan sekali saat server start
+type JobRunner interface {
+	Start()
+	// Stop berhenti mengambil job baru lalu menunggu job yang sedang jalan selesai.
+	// Jika ctx habis lebih dulu, job yang masih jalan dibatalkan dan dikembalikan ke antrian.
+	Stop(ctx context.Context)
+}
@@ -0,0 +1,563 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/job"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	jobWorkerCount        = 2
+	jobPollInterval       = 2 * time.Second
+	jobHeartbeatInterval  = 10 * time.Second
+	jobStaleTimeout       = time.Minute
+	jobDefaultMaxAttempts = 3
+	jobRetryBaseDelay     = 30 * time.Second
+	jobRetryMaxDelay      = 10 * time.Minute
+)
+
+type JobServiceImpl struct {
+	JobRepository repository.JobRepository
+	DB            *sql.DB
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+	stopPoll context.CancelFunc
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewJobServiceImpl(jobRepository repository.JobRepository, DB *sql.DB) *JobServiceImpl {
+	return &JobServiceImpl{
+		JobRepository: jobRepository,
+		DB:            DB,
+		handlers:      make(map[string]JobHandler),
+	}
+}
+
+func (service *JobServiceImpl) RegisterHandler(jenis string, handler JobHandler) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	service.handlers[jenis] = handler
+}
+
+func (service *JobServiceImpl) handler(jenis string) (JobHandler, bool) {
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+	handler, ok := service.handlers[jenis]
+	return handler, ok
+}
+
+func (service *JobServiceImpl) Enqueue(ctx context.Context, tx *sql.Tx, jenis string, payload interface{}, kodeOpd string, tahun string) (domain.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("payload job %s: %w", jenis, err)
+	}
+
+	newJob := domain.Job{
+		Jenis:       jenis,
+		Payload:     data,
+		MaxAttempts: jobDefaultMaxAttempts,
+		KodeOpd:     kodeOpd,
+		Tahun:       tahun,
+	}
+	if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok {
+		newJob.UserId = claims.UserId
+		newJob.Nip = claims.Nip
+	}
+	created, err := service.JobRepository.Create(ctx, tx, newJob)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	helper.RecordJobEnqueued(jenis)
+	return created, nil
+}
+
+func (service *JobServiceImpl) FindById(ctx context.Context, id int64) (job.JobResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	existing, err := service.findAccessibleJob(ctx, tx, id)
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	return toJobResponse(existing), nil
+}
+
+// Cancel job PENDING langsung berstatus CANCELLED, job PROCESS ditandai cancel_requested
+// dan dihentikan worker pada heartbeat berikutnya
+func (service *JobServiceImpl) Cancel(ctx context.Context, id int64) (job.JobResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	defer tx.Rollback()
+
+	existing, err := service.findAccessibleJob(ctx, tx, id)
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	if existing.IsFinished() {
+		return job.JobResponse{}, web.NewConflictError(fmt.Sprintf("job %d sudah selesai dengan status %s", id, existing.Status))
+	}
+
+	if err := service.JobRepository.RequestCancel(ctx, tx, id); err != nil {
+		return job.JobResponse{}, err
+	}
+	existing.CancelRequested = true
+
+	// claim worker mensyaratkan cancel_requested = FALSE, jadi job PENDING aman langsung diakhiri
+	cancelledBeforeRun := existing.Status == domain.JobStatusPending
+	if cancelledBeforeRun {
+		existing.Status = domain.JobStatusCancelled
+		existing.ErrorMessage = "job dibatalkan sebelum dijalankan"
+		if err := service.JobRepository.Finish(ctx, tx, existing); err != nil {
+			return job.JobResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return job.JobResponse{}, err
+	}
+	if cancelledBeforeRun {
+		service.notifyFinish(existing)
+	}
+	return toJobResponse(existing), nil
+}
+
+func (service *JobServiceImpl) findAccessibleJob(ctx context.Context, tx *sql.Tx, id int64) (domain.Job, error) {
+	existing, err := service.JobRepository.FindById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return domain.Job{}, web.NewNotFoundError(fmt.Sprintf("job %d tidak ditemukan", id))
+	}
+	if err != nil {
+		return domain.Job{}, err
+	}
+	if existing.KodeOpd != "" {
+		if err := helper.ValidateKodeOpdAccess(ctx, existing.KodeOpd); err != nil {
+			return domain.Job{}, err
+		}
+		return existing, nil
+	}
+	// job tingkat pemda (clone pokin pemda) tidak terikat OPD, hanya untuk super_admin
+	if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok && !helper.CanAccessAllOpd(claims) {
+		return domain.Job{}, web.NewForbiddenError("Anda tidak memiliki akses ke job ini")
+	}
+	return existing, nil
+}
+
+func (service *JobServiceImpl) Start() {
+	// runCtx membatalkan job yang sedang jalan, pollCtx hanya menghentikan pengambilan job baru
+	runCtx, cancel := context.WithCancel(context.Background())
+	pollCtx, stopPoll := context.WithCancel(runCtx)
+	service.cancel = cancel
+	service.stopPoll = stopPoll
+
+	service.recoverStale(runCtx)
+
+	service.wg.Add(jobWorkerCount + 1)
+	for i := 0; i < jobWorkerCount; i++ {
+		go service.worker(pollCtx, runCtx)
+	}
+	go service.watchStale(pollCtx)
+	log.Printf("job runner berjalan dengan %d worker", jobWorkerCount)
+}
+
+func (service *JobServiceImpl) Stop(ctx context.Context) {
+	if service.cancel == nil {
+		return
+	}
+	service.stopPoll()
+
+	drained := make(chan struct{})
+	go func() {
+		service.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		log.Println("job runner: batas waktu drain habis, job yang masih jalan dikembalikan ke antrian")
+		service.cancel()
+		<-drained
+	}
+	service.cancel()
+	log.Println("job runner berhenti")
+}
+
+func (service *JobServiceImpl) worker(pollCtx context.Context, runCtx context.Context) {
+	defer service.wg.Done()
+	for {
+		if service.runNext(pollCtx, runCtx) {
+			continue
+		}
+		select {
+		case <-pollCtx.Done():
+			return
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+// watchStale memeriksa job milik worker yang mati (server lain atau restart) secara berkala
+func (service *JobServiceImpl) watchStale(ctx context.Context) {
+	defer service.wg.Done()
+	ticker := time.NewTicker(jobStaleTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			service.recoverStale(ctx)
+		}
+	}
+}
+
+// recoverStale melanjutkan (kembali ke antrian) atau menggagalkan job PROCESS yang berhenti mengirim heartbeat
+func (service *JobServiceImpl) recoverStale(ctx context.Context) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("job runner: gagal memulai transaksi recovery: %v", err)
+		return
+	}
+	defer tx.Rollback()
+
+	staleJobs, err := service.JobRepository.FindStale(ctx, tx, jobStaleTimeout)
+	if err != nil {
+		log.Printf("job runner: gagal mencari job terhenti: %v", err)
+		return
+	}
+
+	var failed []domain.Job
+	for _, staleJob := range staleJobs {
+		if staleJob.Attempts < staleJob.MaxAttempts && !staleJob.CancelRequested {
+			err = service.JobRepository.Retry(ctx, tx, staleJob.Id, "worker berhenti tanpa heartbeat, job dijalankan ulang", 0)
+			if err != nil {
+				log.Printf("job runner: gagal mengembalikan job %d ke antrian: %v", staleJob.Id, err)
+			}
+			continue
+		}
+
+		staleJob.Status = domain.JobStatusFailed
+		staleJob.ErrorMessage = "worker berhenti tanpa heartbeat"
+		if staleJob.CancelRequested {
+			staleJob.Status = domain.JobStatusCancelled
+			staleJob.ErrorMessage = "job dibatalkan"
+		}
+		if err := service.JobRepository.Finish(ctx, tx, staleJob); err != nil {
+			log.Printf("job runner: gagal menggagalkan job %d: %v", staleJob.Id, err)
+			continue
+		}
+		failed = append(failed, staleJob)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("job runner: gagal commit recovery: %v", err)
+		return
+	}
+	for _, failedJob := range failed {
+		service.notifyFinish(failedJob)
+	}
+}
+
+func (service *JobServiceImpl) runNext(pollCtx context.Context, runCtx context.Context) bool {
+	if pollCtx.Err() != nil {
+		return false
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("job runner: gagal memulai transaksi: %v", err)
+		return false
+	}
+	claimed, found, err := service.JobRepository.ClaimNext(pollCtx, tx)
+	if err != nil || !found {
+		tx.Rollback()
+		if err != nil {
+			log.Printf("job runner: gagal mengambil job: %v", err)
+		}
+		return false
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("job runner: gagal commit claim job %d: %v", claimed.Id, err)
+		return false
+	}
+
+	service.execute(runCtx, claimed)
+	return true
+}
+
+func (service *JobServiceImpl) execute(ctx context.Context, current domain.Job) {
+	handler, ok := service.handler(current.Jenis)
+	if !ok {
+		current.Status = domain.JobStatusFailed
+		current.ErrorMessage = fmt.Sprintf("jenis job %s tidak dikenal", current.Jenis)
+		service.finish(current)
+		return
+	}
+
+	helper.RecordJobRunning(current.Jenis, 1)
+	defer helper.RecordJobRunning(current.Jenis, -1)
+
+	// handler berjalan atas nama user yang membuat job agar audit log tetap mencatat pelakunya
+	jobCtx, cancelJob := context.WithCancel(context.WithValue(ctx, helper.UserInfoKey, web.JWTClaim{
+		UserId:  current.UserId,
+		Nip:     current.Nip,
+		KodeOpd: current.KodeOpd,
+	}))
+	defer cancelJob()
+
+	var progress atomic.Int32
+	var cancelled atomic.Bool
+	done := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(jobHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if service.heartbeat(current.Id, int(progress.Load())) {
+					cancelled.Store(true)
+					cancelJob()
+				}
+			}
+		}
+	}()
+
+	result, err := runJobHandler(jobCtx, handler, current, func(percent int) {
+		progress.Store(int32(clampProgress(percent)))
+	})
+	close(done)
+	<-heartbeatDone
+	current.Progress = int(progress.Load())
+
+	switch {
+	case err == nil:
+		current.Progress = 100
+		current.Warnings = result.Warnings
+		current.Status = domain.JobStatusDone
+		if len(result.Warnings) > 0 {
+			current.Status = domain.JobStatusDoneWithWarning
+		}
+		if result.Data != nil {
+			data, marshalErr := json.Marshal(result.Data)
+			if marshalErr != nil {
+				log.Printf("job runner: gagal menyimpan hasil job %d: %v", current.Id, marshalErr)
+			}
+			current.Result = data
+		}
+	case cancelled.Load():
+		current.Status = domain.JobStatusCancelled
+		current.ErrorMessage = "job dibatalkan"
+	case ctx.Err() != nil:
+		// server berhenti, job dilanjutkan saat server jalan lagi
+		service.release(current.Id)
+		return
+	case isRetryableJobError(err) && current.Attempts < current.MaxAttempts:
+		delay := jobRetryDelay(current.Attempts)
+		log.Printf("job runner: job %d (%s) gagal percobaan ke-%d, diulang %s lagi: %v", current.Id, current.Jenis, current.Attempts, delay, err)
+		service.retry(current.Id, err.Error(), delay)
+		return
+	default:
+		log.Printf("job runner: job %d (%s) gagal: %v", current.Id, current.Jenis, err)
+		current.Status = domain.JobStatusFailed
+		current.ErrorMessage = err.Error()
+	}
+
+	service.finish(current)
+}
+
+// runJobHandler mengubah panic di handler menjadi error agar worker tetap hidup
+func runJobHandler(ctx context.Context, handler JobHandler, current domain.Job, progress JobProgressFunc) (result domain.JobResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler.Run(ctx, current, progress)
+}
+
+// Operasi status di bawah memakai context.Background karena tetap harus tersimpan
+// walaupun context job sudah dibatalkan
+
+func (service *JobServiceImpl) heartbeat(id int64, progress int) bool {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("job runner: heartbeat job %d: %v", id, err)
+		return false
+	}
+	defer helper.CommitOrRollback(tx)
+
+	cancelRequested, err := service.JobRepository.Heartbeat(context.Background(), tx, id, progress)
+	if err != nil {
+		log.Printf("job runner: heartbeat job %d: %v", id, err)
+		return false
+	}
+	return cancelRequested
+}
+
+func (service *JobServiceImpl) finish(current domain.Job) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("job runner: gagal menyimpan status job %d: %v", current.Id, err)
+		return
+	}
+	err = service.JobRepository.Finish(context.Background(), tx, current)
+	if err != nil {
+		tx.Rollback()
+		log.Printf("job runner: gagal menyimpan status job %d: %v", current.Id, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("job runner: gagal menyimpan status job %d: %v", current.Id, err)
+		return
+	}
+	service.notifyFinish(current)
+}
+
+func (service *JobServiceImpl) retry(id int64, errMsg string, delay time.Duration) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("job runner: gagal menjadwalkan ulang job %d: %v", id, err)
+		return
+	}
+	defer helper.CommitOrRollback(tx)
+
+	if err := service.JobRepository.Retry(context.Background(), tx, id, errMsg, delay); err != nil {
+		log.Printf("job runner: gagal menjadwalkan ulang job %d: %v", id, err)
+	}
+}
+
+func (service *JobServiceImpl) release(id int64) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("job runner: gagal mengembalikan job %d ke antrian: %v", id, err)
+		return
+	}
+	defer helper.CommitOrRollback(tx)
+
+	if err := service.JobRepository.Release(context.Background(), tx, id); err != nil {
+		log.Printf("job runner: gagal mengembalikan job %d ke antrian: %v", id, err)
+	}
+}
+
+func (service *JobServiceImpl) notifyFinish(current domain.Job) {
+	helper.RecordJobFinished(current.Jenis, current.Status)
+
+	handler, ok := service.handler(current.Jenis)
+	if !ok || handler.OnFinish == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("job runner: panic OnFinish job %d: %v", current.Id, r)
+		}
+	}()
+	handler.OnFinish(context.Background(), current)
+}
+
+// isRetryableJobError error validasi, akses, konflik dan sejenisnya tidak akan berubah jika diulang
+func isRetryableJobError(err error) bool {
+	var customErr *web.CustomError
+	return !errors.As(err, &customErr)
+}
+
+// jobRetryDelay backoff eksponensial mulai jobRetryBaseDelay, dibatasi jobRetryMaxDelay
+func jobRetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := jobRetryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= jobRetryMaxDelay {
+			return jobRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+func clampProgress(percent int) int {
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// cloneWarnings mengubah hitungan warning clone menjadi daftar yang urut berdasarkan jenis
+func cloneWarnings(warnings map[domain.WarningType]int) []domain.CloneWarning {
+	result := make([]domain.CloneWarning, 0, len(warnings))
+	for warningType, count := range warnings {
+		result = append(result, domain.CloneWarning{
+			Type:    warningType,
+			Count:   count,
+			Message: fmt.Sprintf("%d %s", count, warningType),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+func toJobResponse(current domain.Job) job.JobResponse {
+	response := job.JobResponse{
+		Id:              current.Id,
+		Jenis:           current.Jenis,
+		Status:          current.Status,
+		Progress:        current.Progress,
+		Attempts:        current.Attempts,
+		MaxAttempts:     current.MaxAttempts,
+		CancelRequested: current.CancelRequested,
+		KodeOpd:         current.KodeOpd,
+		Tahun:           current.Tahun,
+		Warnings:        []job.JobWarningResponse{},
+		ErrorMessage:    current.ErrorMessage,
+		CreatedAt:       formatJobTime(sql.NullTime{Time: current.CreatedAt, Valid: !current.CreatedAt.IsZero()}),
+		StartedAt:       formatJobTime(current.StartedAt),
+		HeartbeatAt:     formatJobTime(current.HeartbeatAt),
+		FinishedAt:      formatJobTime(current.FinishedAt),
+	}
+	for _, warning := range current.Warnings {
+		response.Warnings = append(response.Warnings, job.JobWarningResponse{
+			Type:    string(warning.Type),
+			Count:   warning.Count,
+			Message: warning.Message,
+		})
+	}
+	if len(current.Result) > 0 {
+		response.Result = json.RawMessage(current.Result)
+	}
+	return response
+}
+
+func formatJobTime(value sql.NullTime) string {
+	if !value.Valid {
+		return ""
+	}
+	return value.Time.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJobRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 30 * time.Second},
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 3, want: 2 * time.Minute},
+		{attempt: 6, want: jobRetryMaxDelay},
+		{attempt: 50, want: jobRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := jobRetryDelay(tt.attempt); got != tt.want {
+			t.Errorf("jobRetryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryableJobError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "error database", err: errors.New("driver: bad connection"), want: true},
+		{name: "konflik", err: web.NewConflictError("sudah di-clone"), want: false},
+		{name: "validasi dibungkus", err: fmt.Errorf("clone: %w", web.NewBadRequestError("payload tidak valid")), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableJobError(tt.err); got != tt.want {
+				t.Errorf("isRetryableJobError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneWarnings(t *testing.T) {
+	got := cloneWarnings(map[domain.WarningType]int{
+		"renaksi_missing":  1,
+		"indikator_orphan": 3,
+	})
+	want := []domain.CloneWarning{
+		{Type: "indikator_orphan", Count: 3, Message: "3 indikator_orphan"},
+		{Type: "renaksi_missing", Count: 1, Message: "1 renaksi_missing"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cloneWarnings() = %+v, want %+v", got, want)
+	}
+
+	if got := cloneWarnings(nil); len(got) != 0 {
+		t.Errorf("cloneWarnings(nil) = %+v, want kosong", got)
+	}
+}
+
+func TestRunJobHandlerRecoversPanic(t *testing.T) {
+	handler := JobHandler{
+		Run: func(ctx context.Context, job domain.Job, progress JobProgressFunc) (domain.JobResult, error) {
+			progress(50)
+			panic("nil map")
+		},
+	}
+
+	var reported int
+	_, err := runJobHandler(context.Background(), handler, domain.Job{Id: 1}, func(percent int) { reported = percent })
+	if err == nil || err.Error() != "panic: nil map" {
+		t.Fatalf("runJobHandler() error = %v, want panic: nil map", err)
+	}
+	if reported != 50 {
+		t.Errorf("progress = %d, want 50", reported)
+	}
+}
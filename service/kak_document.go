@@ -0,0 +1,288 @@
+package service
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+var namaBulanSingkat = []string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"}
+
+// kakTabel adalah tabel pada dokumen KAK, lebar kolom dalam persen
+type kakTabel struct {
+	header []string
+	baris  [][]string
+	lebar  []int
+	align  string
+}
+
+type kakBagian struct {
+	judul    string
+	paragraf []string
+	tabel    *kakTabel
+	penutup  []string
+}
+
+// kakDocument adalah isi KAK yang sudah tersusun, dipakai bersama oleh renderer DOCX dan PDF
+type kakDocument struct {
+	judul     []string
+	identitas [][]string
+	bagian    []kakBagian
+}
+
+// newKakDocument menyusun KAK dari rincian kak satu rencana kinerja dengan urutan bagian baku:
+// dasar hukum, gambaran umum, permasalahan, usulan, inovasi, sub kegiatan, indikator kinerja lalu rencana aksi dan jadwal
+func newKakDocument(data rencanakinerja.DataRincianKerja) kakDocument {
+	rekin := data.RencanaKinerja
+	document := kakDocument{
+		judul: []string{
+			"KERANGKA ACUAN KERJA (KAK)",
+			strings.ToUpper(rekin.NamaRencanaKinerja),
+			"TAHUN " + rekin.Tahun,
+		},
+		identitas: [][]string{
+			{"Perangkat Daerah", rekin.KodeOpd.NamaOpd},
+			{"Pemilik Rencana Kinerja", rekin.NamaPegawai},
+			{"NIP", rekin.PegawaiId},
+			{"Pohon Kinerja", rekin.NamaPohon},
+			{"Rencana Kinerja", rekin.NamaRencanaKinerja},
+		},
+	}
+
+	dasarHukum := kakBagian{judul: "A. DASAR HUKUM"}
+	for i, item := range data.DasarHukum {
+		teks := fmt.Sprintf("%d. %s", i+1, item.PeraturanTerkait)
+		if item.Uraian != "" {
+			teks += "\n" + item.Uraian
+		}
+		dasarHukum.paragraf = append(dasarHukum.paragraf, teks)
+	}
+
+	gambaranUmum := kakBagian{judul: "B. GAMBARAN UMUM"}
+	for _, item := range data.GambaranUmum {
+		gambaranUmum.paragraf = append(gambaranUmum.paragraf, item.GambaranUmum)
+	}
+
+	permasalahan := kakBagian{judul: "C. PERMASALAHAN"}
+	if len(data.Permasalahan) > 0 {
+		permasalahan.tabel = &kakTabel{
+			header: []string{"No", "Permasalahan", "Penyebab Internal", "Penyebab Eksternal", "Jenis"},
+			lebar:  []int{6, 30, 25, 25, 14},
+			align:  "CLLLC",
+		}
+		for i, item := range data.Permasalahan {
+			permasalahan.tabel.baris = append(permasalahan.tabel.baris, []string{strconv.Itoa(i + 1), item.Permasalahan, item.PenyebabInternal, item.PenyebabEksternal, item.JenisPermasalahan})
+		}
+	}
+
+	usulan := kakBagian{judul: "D. USULAN"}
+	if len(data.Usulan) > 0 {
+		usulan.tabel = &kakTabel{
+			header: []string{"No", "Usulan", "Uraian", "Sumber"},
+			lebar:  []int{6, 35, 39, 20},
+			align:  "CLLC",
+		}
+		for i, item := range data.Usulan {
+			usulan.tabel.baris = append(usulan.tabel.baris, []string{strconv.Itoa(i + 1), item.Usulan, item.Uraian, kakJenisUsulan(item.JenisUsulan)})
+		}
+	}
+
+	inovasi := kakBagian{judul: "E. INOVASI"}
+	if len(data.Inovasi) > 0 {
+		inovasi.tabel = &kakTabel{
+			header: []string{"No", "Judul Inovasi", "Jenis Inovasi", "Gambaran Nilai Kebaruan"},
+			lebar:  []int{6, 30, 20, 44},
+			align:  "CLLL",
+		}
+		for i, item := range data.Inovasi {
+			inovasi.tabel.baris = append(inovasi.tabel.baris, []string{strconv.Itoa(i + 1), item.JudulInovasi, item.JenisInovasi, item.GambaranNilaiKebaruan})
+		}
+	}
+
+	subKegiatan := kakBagian{judul: "F. SUB KEGIATAN"}
+	if len(data.SubKegiatan) > 0 {
+		subKegiatan.tabel = &kakTabel{
+			header: []string{"No", "Kode", "Sub Kegiatan", "Indikator", "Target"},
+			lebar:  []int{6, 18, 32, 28, 16},
+			align:  "CLLLC",
+		}
+		for i, item := range data.SubKegiatan {
+			var indikator, target []string
+			for _, ind := range item.Indikator {
+				indikator = append(indikator, ind.NamaIndikator)
+				for _, t := range ind.Target {
+					target = append(target, strings.TrimSpace(t.TargetIndikator+" "+t.SatuanIndikator))
+				}
+			}
+			subKegiatan.tabel.baris = append(subKegiatan.tabel.baris, []string{strconv.Itoa(i + 1), item.KodeSubKegiatan, item.NamaSubKegiatan, strings.Join(indikator, "\n"), strings.Join(target, "\n")})
+		}
+	}
+
+	indikator := kakBagian{judul: "G. INDIKATOR KINERJA"}
+	if len(rekin.Indikator) > 0 {
+		indikator.tabel = &kakTabel{
+			header: []string{"No", "Indikator", "Target", "Satuan"},
+			lebar:  []int{6, 54, 20, 20},
+			align:  "CLCC",
+		}
+		for i, item := range rekin.Indikator {
+			target, satuan := "-", "-"
+			if len(item.Target) > 0 {
+				target, satuan = item.Target[0].TargetIndikator, item.Target[0].SatuanIndikator
+			}
+			indikator.tabel.baris = append(indikator.tabel.baris, []string{strconv.Itoa(i + 1), item.NamaIndikator, target, satuan})
+		}
+	}
+
+	document.bagian = append(document.bagian, dasarHukum, gambaranUmum, permasalahan, usulan, inovasi, subKegiatan, indikator, newKakJadwal(data))
+	for i := range document.bagian {
+		if len(document.bagian[i].paragraf) == 0 && document.bagian[i].tabel == nil {
+			document.bagian[i].paragraf = []string{"-"}
+		}
+	}
+	return document
+}
+
+// newKakJadwal menyusun tabel rencana aksi dengan bobot pelaksanaan per bulan
+func newKakJadwal(data rencanakinerja.DataRincianKerja) kakBagian {
+	bagian := kakBagian{judul: "H. RENCANA AKSI DAN JADWAL PELAKSANAAN"}
+	if len(data.RencanaAksi.RencanaAksi) == 0 {
+		return bagian
+	}
+
+	tabel := &kakTabel{
+		header: append(append([]string{"No", "Rencana Aksi"}, namaBulanSingkat...), "Total"),
+		lebar:  []int{5, 27},
+		align:  "CL" + strings.Repeat("C", len(namaBulanSingkat)+1),
+	}
+	for range namaBulanSingkat {
+		tabel.lebar = append(tabel.lebar, 5)
+	}
+	tabel.lebar = append(tabel.lebar, 8)
+
+	for i, aksi := range data.RencanaAksi.RencanaAksi {
+		bobot := make([]string, len(namaBulanSingkat))
+		for _, pelaksanaan := range aksi.PelaksanaanRencanaAksi {
+			if pelaksanaan.Bulan >= 1 && pelaksanaan.Bulan <= len(namaBulanSingkat) && pelaksanaan.Bobot > 0 {
+				bobot[pelaksanaan.Bulan-1] = strconv.Itoa(pelaksanaan.Bobot)
+			}
+		}
+		baris := append(append([]string{strconv.Itoa(i + 1), aksi.NamaRencanaAksi}, bobot...), strconv.Itoa(aksi.TotalBobotRencanaAksi))
+		tabel.baris = append(tabel.baris, baris)
+	}
+
+	total := make([]string, len(namaBulanSingkat))
+	for _, bulan := range data.RencanaAksi.TotalPerBulan {
+		if bulan.Bulan >= 1 && bulan.Bulan <= len(namaBulanSingkat) && bulan.TotalBobot > 0 {
+			total[bulan.Bulan-1] = strconv.Itoa(bulan.TotalBobot)
+		}
+	}
+	tabel.baris = append(tabel.baris, append(append([]string{"", "Total"}, total...), strconv.Itoa(data.RencanaAksi.TotalKeseluruhan)))
+
+	bagian.tabel = tabel
+	bagian.penutup = []string{fmt.Sprintf("Waktu yang dibutuhkan: %d bulan", data.RencanaAksi.WaktuDibutuhkan)}
+	return bagian
+}
+
+func kakJenisUsulan(jenis string) string {
+	switch jenis {
+	case "usulan_musrebang":
+		return "Musrenbang"
+	case "usulan_pokok_pikiran":
+		return "Pokok Pikiran"
+	case "usulan_mandatori":
+		return "Mandatori"
+	case "usulan_inisiatif":
+		return "Inisiatif"
+	default:
+		return jenis
+	}
+}
+
+func buildKakDocx(document kakDocument) ([]byte, error) {
+	docx := helper.NewDocx()
+	for _, judul := range document.judul {
+		docx.Title(judul)
+	}
+	docx.Table([]string{"Uraian", "Keterangan"}, document.identitas, []int{30, 70})
+
+	for _, bagian := range document.bagian {
+		docx.Heading(bagian.judul)
+		for _, paragraf := range bagian.paragraf {
+			docx.Paragraph(paragraf)
+		}
+		if bagian.tabel != nil {
+			docx.Table(bagian.tabel.header, bagian.tabel.baris, bagian.tabel.lebar)
+		}
+		for _, paragraf := range bagian.penutup {
+			docx.Paragraph(paragraf)
+		}
+	}
+	return docx.Bytes()
+}
+
+func buildKakPdf(document kakDocument) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	lebarHalaman := pageWidth - left - right
+	lebarKolom := func(persen []int) []float64 {
+		widths := make([]float64, len(persen))
+		for i, p := range persen {
+			widths[i] = lebarHalaman * float64(p) / 100
+		}
+		return widths
+	}
+
+	pdf.SetFont("Times", "B", 13)
+	for _, judul := range document.judul {
+		pdf.MultiCell(0, 6, tr(judul), "", "C", false)
+	}
+	pdf.Ln(5)
+
+	pdf.SetFont("Times", "", 10)
+	writePdfTabelHeader(pdf, tr, lebarKolom([]int{30, 70}), []string{"Uraian", "Keterangan"})
+	for _, row := range document.identitas {
+		writePdfTabelBaris(pdf, tr, lebarKolom([]int{30, 70}), row, "LL")
+	}
+
+	for _, bagian := range document.bagian {
+		pdf.Ln(4)
+		pdf.SetFont("Times", "B", 11)
+		pdf.MultiCell(0, 6, tr(bagian.judul), "", "L", false)
+		pdf.SetFont("Times", "", 10)
+		for _, paragraf := range bagian.paragraf {
+			pdf.MultiCell(0, pdfLineHeight, tr(paragraf), "", "J", false)
+		}
+		if bagian.tabel != nil {
+			if len(bagian.tabel.header) > 8 {
+				pdf.SetFont("Times", "", 8)
+			}
+			widths := lebarKolom(bagian.tabel.lebar)
+			writePdfTabelHeader(pdf, tr, widths, bagian.tabel.header)
+			for _, row := range bagian.tabel.baris {
+				writePdfTabelBaris(pdf, tr, widths, row, bagian.tabel.align)
+			}
+			pdf.SetFont("Times", "", 10)
+		}
+		for _, paragraf := range bagian.penutup {
+			pdf.MultiCell(0, pdfLineHeight, tr(paragraf), "", "L", false)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"ekak_kabupaten_madiun/model/web/dasarhukum"
+	"ekak_kabupaten_madiun/model/web/rencanaaksi"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestKakDocument(t *testing.T) {
+	data := rencanakinerja.DataRincianKerja{
+		RencanaKinerja: rencanakinerja.RencanaKinerjaResponse{
+			NamaRencanaKinerja: "Tersusunnya dokumen perencanaan",
+			Tahun:              "2025",
+			PegawaiId:          "198001012005011001",
+		},
+		DasarHukum: []dasarhukum.DasarHukumResponse{
+			{PeraturanTerkait: "UU No. 23 Tahun 2014", Uraian: "Pemerintahan Daerah & perubahannya"},
+		},
+		RencanaAksi: rencanaaksi.RencanaAksiTableResponse{
+			RencanaAksi: []rencanaaksi.RencanaAksiResponse{{
+				NamaRencanaAksi: "Penyusunan draft",
+				PelaksanaanRencanaAksi: []rencanaaksi.PelaksanaanRencanaAksiResponse{
+					{Bulan: 1, Bobot: 10},
+					{Bulan: 3, Bobot: 20},
+				},
+				TotalBobotRencanaAksi: 30,
+			}},
+			TotalPerBulan:    []rencanaaksi.BobotBulanan{{Bulan: 1, TotalBobot: 10}, {Bulan: 3, TotalBobot: 20}},
+			TotalKeseluruhan: 30,
+			WaktuDibutuhkan:  2,
+		},
+	}
+
+	document := newKakDocument(data)
+
+	var judul []string
+	for _, bagian := range document.bagian {
+		judul = append(judul, bagian.judul[:2])
+	}
+	if got := strings.Join(judul, ""); got != "A.B.C.D.E.F.G.H." {
+		t.Errorf("urutan bagian = %s; want A.B.C.D.E.F.G.H.", got)
+	}
+
+	jadwal := document.bagian[len(document.bagian)-1].tabel
+	if jadwal == nil || len(jadwal.baris) != 2 {
+		t.Fatalf("tabel jadwal = %+v; want 1 rencana aksi + baris total", jadwal)
+	}
+	baris := jadwal.baris[0]
+	if baris[2] != "10" || baris[3] != "" || baris[4] != "20" || baris[len(baris)-1] != "30" {
+		t.Errorf("bobot per bulan = %v", baris)
+	}
+	if len(jadwal.header) != len(jadwal.lebar) || len(jadwal.header) != len(jadwal.align) {
+		t.Errorf("jumlah kolom header/lebar/align tidak sama: %d/%d/%d", len(jadwal.header), len(jadwal.lebar), len(jadwal.align))
+	}
+
+	docx, err := buildKakDocx(document)
+	if err != nil {
+		t.Fatalf("buildKakDocx error = %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	if err != nil {
+		t.Fatalf("hasil docx bukan zip: %v", err)
+	}
+	var content string
+	for _, file := range reader.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := io.ReadAll(rc)
+		rc.Close()
+		content = string(raw)
+	}
+	if !strings.Contains(content, "Pemerintahan Daerah &amp; perubahannya") {
+		t.Error("word/document.xml tidak memuat dasar hukum yang di-escape")
+	}
+
+	pdf, err := buildKakPdf(document)
+	if err != nil {
+		t.Fatalf("buildKakPdf error = %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Error("hasil buildKakPdf bukan PDF")
+	}
+}
@@ -26,6 +26,10 @@ func NewKegiatanServiceImpl(kegiatanRepository repository.KegiatanRepository, DB
 }
 
 func (service *KegiatanServiceImpl) Create(ctx context.Context, request kegiatan.KegiatanCreateRequest) (kegiatan.KegiatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return kegiatan.KegiatanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return kegiatan.KegiatanResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
@@ -104,6 +108,10 @@ func (service *KegiatanServiceImpl) Create(ctx context.Context, request kegiatan
 }
 
 func (service *KegiatanServiceImpl) Update(ctx context.Context, request kegiatan.KegiatanUpdateRequest) (kegiatan.KegiatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return kegiatan.KegiatanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return kegiatan.KegiatanResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
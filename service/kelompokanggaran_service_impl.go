@@ -27,7 +27,7 @@ func NewKelompokAnggaranServiceImpl(kelompokAnggaranRepository repository.Kelomp
 }
 
 func (service *KelompokAnggaranServiceImpl) Create(ctx context.Context, request kelompokanggarans.KelompokAnggaranCreateRequest) (kelompokanggarans.KelompokAnggaranResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return kelompokanggarans.KelompokAnggaranResponse{}, err
 	}
@@ -60,7 +60,7 @@ func (service *KelompokAnggaranServiceImpl) Create(ctx context.Context, request
 }
 
 func (service *KelompokAnggaranServiceImpl) Update(ctx context.Context, request kelompokanggarans.KelompokAnggaranUpdateRequest) (kelompokanggarans.KelompokAnggaranResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return kelompokanggarans.KelompokAnggaranResponse{}, err
 	}
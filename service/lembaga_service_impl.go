@@ -34,7 +34,7 @@ func (service *LembagaServiceImpl) Create(ctx context.Context, request lembaga.L
 	}
 	defer helper.CommitOrRollback(tx)
 
-	err = service.Validator.Struct(request)
+	err = helper.ValidationError(service.Validator.Struct(request))
 	if err != nil {
 		return lembaga.LembagaResponse{}, err
 	}
@@ -76,7 +76,7 @@ func (service *LembagaServiceImpl) Update(ctx context.Context, request lembaga.L
 	}
 	defer helper.CommitOrRollback(tx)
 
-	err = service.Validator.Struct(request)
+	err = helper.ValidationError(service.Validator.Struct(request))
 	if err != nil {
 		return lembaga.LembagaResponse{}, err
 	}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/lockdata"
+)
+
+type LockDataService interface {
+	Lock(ctx context.Context, request lockdata.LockDataRequest) (lockdata.LockDataResponse, error)
+	Unlock(ctx context.Context, request lockdata.LockDataRequest) (lockdata.LockDataResponse, error)
+	FindAll(ctx context.Context, kodeOpd string, tahun string) ([]lockdata.LockDataResponse, error)
+}
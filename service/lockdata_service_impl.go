@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/lockdata"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type LockDataServiceImpl struct {
+	LockDataRepository repository.LockDataRepository
+	DB                 *sql.DB
+	Validate           *validator.Validate
+}
+
+func NewLockDataServiceImpl(lockDataRepository repository.LockDataRepository, DB *sql.DB, validate *validator.Validate) *LockDataServiceImpl {
+	return &LockDataServiceImpl{
+		LockDataRepository: lockDataRepository,
+		DB:                 DB,
+		Validate:           validate,
+	}
+}
+
+func (service *LockDataServiceImpl) Lock(ctx context.Context, request lockdata.LockDataRequest) (lockdata.LockDataResponse, error) {
+	if err := service.validateRequest(request); err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	err = service.LockDataRepository.Lock(ctx, tx, request.JenisData, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+	log.Printf("[LockData] %s kode_opd=%s tahun=%s dikunci", request.JenisData, request.KodeOpd, request.Tahun)
+
+	return toLockDataResponse(request, true), nil
+}
+
+func (service *LockDataServiceImpl) Unlock(ctx context.Context, request lockdata.LockDataRequest) (lockdata.LockDataResponse, error) {
+	if err := service.validateRequest(request); err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	err = service.LockDataRepository.Unlock(ctx, tx, request.JenisData, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+	log.Printf("[LockData] %s kode_opd=%s tahun=%s dibuka", request.JenisData, request.KodeOpd, request.Tahun)
+
+	return toLockDataResponse(request, false), nil
+}
+
+func (service *LockDataServiceImpl) FindAll(ctx context.Context, kodeOpd string, tahun string) ([]lockdata.LockDataResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	locks, err := service.LockDataRepository.FindAll(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]lockdata.LockDataResponse, 0, len(locks))
+	for _, lock := range locks {
+		responses = append(responses, lockdata.LockDataResponse{
+			Id:        lock.Id,
+			JenisData: lock.JenisData,
+			NamaData:  domain.NamaJenisLockData[lock.JenisData],
+			KodeOpd:   lock.KodeOpd,
+			Tahun:     lock.Tahun,
+			IsLocked:  true,
+			LockedAt:  lock.LockedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return responses, nil
+}
+
+func (service *LockDataServiceImpl) validateRequest(request lockdata.LockDataRequest) error {
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return err
+	}
+	if _, ok := domain.NamaJenisLockData[request.JenisData]; !ok {
+		return web.NewBadRequestError(fmt.Sprintf("jenis_data %s tidak dikenal", request.JenisData))
+	}
+	return nil
+}
+
+func toLockDataResponse(request lockdata.LockDataRequest, isLocked bool) lockdata.LockDataResponse {
+	return lockdata.LockDataResponse{
+		JenisData: request.JenisData,
+		NamaData:  domain.NamaJenisLockData[request.JenisData],
+		KodeOpd:   request.KodeOpd,
+		Tahun:     request.Tahun,
+		IsLocked:  isLocked,
+	}
+}
+
+// checkLockData dipanggil service lain di dalam transaksinya sebelum mengubah data.
+// Mengembalikan error 423 (Locked) jika jenisData untuk kodeOpd+tahun sudah dikunci.
+func checkLockData(ctx context.Context, tx *sql.Tx, lockDataRepository repository.LockDataRepository, jenisData, kodeOpd, tahun string) error {
+	if kodeOpd == "" || tahun == "" {
+		return nil
+	}
+	isLocked, err := lockDataRepository.IsLocked(ctx, tx, jenisData, kodeOpd, tahun)
+	if err != nil {
+		return err
+	}
+	if isLocked {
+		return web.NewLockedError(fmt.Sprintf("%s OPD %s tahun %s sudah dikunci dan tidak dapat diubah", domain.NamaJenisLockData[jenisData], kodeOpd, tahun))
+	}
+	return nil
+}
+
+// checkLockDataPeriode sama dengan checkLockData untuk setiap tahun dalam rentang periode (renstra)
+func checkLockDataPeriode(ctx context.Context, tx *sql.Tx, lockDataRepository repository.LockDataRepository, jenisData, kodeOpd, tahunAwal, tahunAkhir string) error {
+	awal, errAwal := strconv.Atoi(tahunAwal)
+	akhir, errAkhir := strconv.Atoi(tahunAkhir)
+	if errAwal != nil || errAkhir != nil {
+		return nil
+	}
+	for tahun := awal; tahun <= akhir; tahun++ {
+		if err := checkLockData(ctx, tx, lockDataRepository, jenisData, kodeOpd, strconv.Itoa(tahun)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"net/http"
+	"testing"
+)
+
+// fakeLockDataRepository menyimpan lock di memori dengan key jenisData|kodeOpd|tahun
+type fakeLockDataRepository struct {
+	locked map[string]bool
+}
+
+func (repository *fakeLockDataRepository) IsLocked(ctx context.Context, tx *sql.Tx, jenisData, kodeOpd, tahun string) (bool, error) {
+	return repository.locked[jenisData+"|"+kodeOpd+"|"+tahun], nil
+}
+
+func (repository *fakeLockDataRepository) Lock(ctx context.Context, tx *sql.Tx, jenisData, kodeOpd, tahun string) error {
+	repository.locked[jenisData+"|"+kodeOpd+"|"+tahun] = true
+	return nil
+}
+
+func (repository *fakeLockDataRepository) Unlock(ctx context.Context, tx *sql.Tx, jenisData, kodeOpd, tahun string) error {
+	delete(repository.locked, jenisData+"|"+kodeOpd+"|"+tahun)
+	return nil
+}
+
+func (repository *fakeLockDataRepository) FindAll(ctx context.Context, tx *sql.Tx, kodeOpd, tahun string) ([]domain.LockData, error) {
+	return nil, nil
+}
+
+func TestCheckLockData(t *testing.T) {
+	const kodeOpd = "5.01.5.05.0.00.01.0000"
+	lockDataRepository := &fakeLockDataRepository{locked: map[string]bool{
+		domain.JenisLockRencanaKinerja + "|" + kodeOpd + "|2025": true,
+	}}
+
+	tests := []struct {
+		name       string
+		jenisData  string
+		kodeOpd    string
+		tahunAwal  string
+		tahunAkhir string
+		locked     bool
+	}{
+		{
+			name:       "tahun terkunci",
+			jenisData:  domain.JenisLockRencanaKinerja,
+			kodeOpd:    kodeOpd,
+			tahunAwal:  "2025",
+			tahunAkhir: "2025",
+			locked:     true,
+		},
+		{
+			name:       "tahun lain tidak terkunci",
+			jenisData:  domain.JenisLockRencanaKinerja,
+			kodeOpd:    kodeOpd,
+			tahunAwal:  "2026",
+			tahunAkhir: "2026",
+			locked:     false,
+		},
+		{
+			name:       "jenis data lain tidak terkunci",
+			jenisData:  domain.JenisLockSasaranOpd,
+			kodeOpd:    kodeOpd,
+			tahunAwal:  "2025",
+			tahunAkhir: "2025",
+			locked:     false,
+		},
+		{
+			name:       "periode mencakup tahun terkunci",
+			jenisData:  domain.JenisLockRencanaKinerja,
+			kodeOpd:    kodeOpd,
+			tahunAwal:  "2024",
+			tahunAkhir: "2028",
+			locked:     true,
+		},
+		{
+			name:       "kode opd kosong dilewati",
+			jenisData:  domain.JenisLockRencanaKinerja,
+			kodeOpd:    "",
+			tahunAwal:  "2025",
+			tahunAkhir: "2025",
+			locked:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLockDataPeriode(context.Background(), nil, lockDataRepository, tt.jenisData, tt.kodeOpd, tt.tahunAwal, tt.tahunAkhir)
+			if !tt.locked {
+				if err != nil {
+					t.Fatalf("error = %v; want nil", err)
+				}
+				return
+			}
+			customErr, ok := err.(*web.CustomError)
+			if !ok {
+				t.Fatalf("error = %v; want *web.CustomError", err)
+			}
+			if customErr.Code != http.StatusLocked {
+				t.Errorf("code = %d; want %d", customErr.Code, http.StatusLocked)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rencanakinerja"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -30,7 +31,7 @@ func NewManualIKServiceImpl(manualIKRepository repository.ManualIKRepository, db
 }
 
 func (service *ManualIKServiceImpl) Create(ctx context.Context, request rencanakinerja.ManualIKCreateRequest, indikatorId string) (rencanakinerja.ManualIKResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return rencanakinerja.ManualIKResponse{}, err
 	}
@@ -71,7 +72,7 @@ func (service *ManualIKServiceImpl) Create(ctx context.Context, request rencanak
 		// Check untuk MySQL error
 		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
 			if mysqlErr.Number == 1062 {
-				return rencanakinerja.ManualIKResponse{}, fmt.Errorf("duplicate entry: indikator ID %s sudah ada", indikatorId)
+				return rencanakinerja.ManualIKResponse{}, web.NewConflictError(fmt.Sprintf("duplicate entry: indikator ID %s sudah ada", indikatorId))
 			}
 		}
 		return rencanakinerja.ManualIKResponse{}, err
@@ -81,7 +82,7 @@ func (service *ManualIKServiceImpl) Create(ctx context.Context, request rencanak
 }
 
 func (service *ManualIKServiceImpl) Update(ctx context.Context, request rencanakinerja.ManualIKUpdateRequest, indikatorId string) (rencanakinerja.ManualIKResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return rencanakinerja.ManualIKResponse{}, err
 	}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/model/web/programkegiatan"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	matrixRenjaSheet     = "Matrix Renja"
+	matrixRenjaHeaderRow = 5
+)
+
+var matrixRenjaJudul = map[string]string{
+	"ranwal":    "RANCANGAN AWAL",
+	"rankhir":   "RANCANGAN AKHIR",
+	"penetapan": "PENETAPAN",
+}
+
+var matrixRenjaBorder = []excelize.Border{
+	{Type: "left", Color: "000000", Style: 1},
+	{Type: "top", Color: "000000", Style: 1},
+	{Type: "right", Color: "000000", Style: 1},
+	{Type: "bottom", Color: "000000", Style: 1},
+}
+
+var matrixRenjaKolom = []string{"Kode", "Urusan / Bidang Urusan / Program / Kegiatan / Sub Kegiatan", "Indikator", "Target", "Satuan", "Pagu Indikatif (Rp)", "Penanggung Jawab"}
+
+// matrixRenjaBaris adalah satu node hierarki matrix renja yang ditulis ke excel
+type matrixRenjaBaris struct {
+	level       int
+	kode        string
+	nama        string
+	indikator   []programkegiatan.IndikatorMatrixResponse
+	pagu        int64
+	namaPegawai string
+}
+
+type matrixRenjaExcel struct {
+	file           *excelize.File
+	row            int
+	styles         map[int]int
+	paguStyle      int
+	indikatorStyle int
+}
+
+// buildMatrixRenjaExcel menyusun file .xlsx matrix renja urusan → bidang urusan → program → kegiatan → subkegiatan.
+// Kode, nama, pagu dan penanggung jawab di-merge sepanjang baris indikator, pagu tiap level adalah subtotal level di bawahnya.
+func buildMatrixRenjaExcel(data []programkegiatan.UrusanDetailResponse, jenis, kodeOpd, tahun string) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName("Sheet1", matrixRenjaSheet); err != nil {
+		return nil, err
+	}
+
+	excel := &matrixRenjaExcel{file: file, row: matrixRenjaHeaderRow + 1}
+	if err := excel.initStyles(); err != nil {
+		return nil, err
+	}
+	if err := excel.writeHeader(jenis, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, detail := range data {
+		total += totalPagu(detail.PaguAnggaranTotal)
+		for _, urusan := range detail.Urusan {
+			if err := excel.writeUrusan(urusan); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := excel.writeTotal(total); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := file.Write(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (excel *matrixRenjaExcel) initStyles() error {
+	alignment := &excelize.Alignment{Vertical: "top", WrapText: true}
+
+	// warna latar per level: urusan, bidang urusan, program, kegiatan, subkegiatan
+	fills := []string{"BDD7EE", "DDEBF7", "E2EFDA", "FFF2CC", ""}
+	excel.styles = make(map[int]int, len(fills))
+	for level, color := range fills {
+		style := &excelize.Style{
+			Border:    matrixRenjaBorder,
+			Alignment: alignment,
+			Font:      &excelize.Font{Bold: level < len(fills)-1},
+		}
+		if color != "" {
+			style.Fill = excelize.Fill{Type: "pattern", Color: []string{color}, Pattern: 1}
+		}
+		id, err := excel.file.NewStyle(style)
+		if err != nil {
+			return err
+		}
+		excel.styles[level] = id
+	}
+
+	pagu, err := excel.file.NewStyle(&excelize.Style{Border: matrixRenjaBorder, Alignment: alignment, NumFmt: 3})
+	if err != nil {
+		return err
+	}
+	excel.paguStyle = pagu
+
+	indik, err := excel.file.NewStyle(&excelize.Style{Border: matrixRenjaBorder, Alignment: alignment})
+	if err != nil {
+		return err
+	}
+	excel.indikatorStyle = indik
+	return nil
+}
+
+func (excel *matrixRenjaExcel) writeHeader(jenis, kodeOpd, tahun string) error {
+	file := excel.file
+	judul, err := file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Size: 14},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	if err != nil {
+		return err
+	}
+	header, err := file.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"D9D9D9"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Border:    matrixRenjaBorder,
+	})
+	if err != nil {
+		return err
+	}
+
+	lastCol, _ := excelize.ColumnNumberToName(len(matrixRenjaKolom))
+	_ = file.SetCellValue(matrixRenjaSheet, "A1", "MATRIKS RENCANA KERJA (RENJA) "+matrixRenjaJudul[jenis])
+	_ = file.MergeCell(matrixRenjaSheet, "A1", lastCol+"1")
+	_ = file.SetCellStyle(matrixRenjaSheet, "A1", lastCol+"1", judul)
+	_ = file.SetCellValue(matrixRenjaSheet, "A2", "Kode OPD")
+	_ = file.SetCellValue(matrixRenjaSheet, "B2", kodeOpd)
+	_ = file.SetCellValue(matrixRenjaSheet, "A3", "Tahun")
+	_ = file.SetCellValue(matrixRenjaSheet, "B3", tahun)
+
+	for i, kolom := range matrixRenjaKolom {
+		cell, _ := excelize.CoordinatesToCellName(i+1, matrixRenjaHeaderRow)
+		if err := file.SetCellValue(matrixRenjaSheet, cell, kolom); err != nil {
+			return err
+		}
+	}
+	if err := file.SetCellStyle(matrixRenjaSheet, fmt.Sprintf("A%d", matrixRenjaHeaderRow), fmt.Sprintf("%s%d", lastCol, matrixRenjaHeaderRow), header); err != nil {
+		return err
+	}
+
+	widths := []float64{24, 50, 50, 12, 14, 20, 28}
+	for i, width := range widths {
+		col, _ := excelize.ColumnNumberToName(i + 1)
+		if err := file.SetColWidth(matrixRenjaSheet, col, col, width); err != nil {
+			return err
+		}
+	}
+	return file.SetPanes(matrixRenjaSheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      matrixRenjaHeaderRow,
+		TopLeftCell: fmt.Sprintf("A%d", matrixRenjaHeaderRow+1),
+		ActivePane:  "bottomLeft",
+	})
+}
+
+func (excel *matrixRenjaExcel) writeUrusan(urusan programkegiatan.UrusanResponse) error {
+	if err := excel.writeBaris(matrixRenjaBaris{level: 0, kode: urusan.Kode, nama: urusan.Nama, indikator: urusan.Indikator, pagu: totalPagu(urusan.Anggaran)}); err != nil {
+		return err
+	}
+	for _, bidang := range urusan.BidangUrusan {
+		if err := excel.writeBaris(matrixRenjaBaris{level: 1, kode: bidang.Kode, nama: bidang.Nama, indikator: bidang.Indikator, pagu: totalPagu(bidang.Anggaran)}); err != nil {
+			return err
+		}
+		for _, program := range bidang.Program {
+			if err := excel.writeBaris(matrixRenjaBaris{level: 2, kode: program.Kode, nama: program.Nama, indikator: program.Indikator, pagu: totalPagu(program.Anggaran)}); err != nil {
+				return err
+			}
+			for _, kegiatan := range program.Kegiatan {
+				if err := excel.writeBaris(matrixRenjaBaris{level: 3, kode: kegiatan.Kode, nama: kegiatan.Nama, indikator: kegiatan.Indikator, pagu: totalPagu(kegiatan.Anggaran)}); err != nil {
+					return err
+				}
+				for _, subkegiatan := range kegiatan.SubKegiatan {
+					pagu := totalPagu(subkegiatan.Anggaran)
+					if pagu == 0 {
+						pagu = subkegiatan.TotalAnggaran
+					}
+					if err := excel.writeBaris(matrixRenjaBaris{level: 4, kode: subkegiatan.Kode, nama: subkegiatan.Nama, indikator: subkegiatan.Indikator, pagu: pagu, namaPegawai: subkegiatan.NamaPegawai}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeBaris menulis satu node beserta indikatornya, satu indikator per baris
+func (excel *matrixRenjaExcel) writeBaris(baris matrixRenjaBaris) error {
+	file := excel.file
+	indikator := append([]programkegiatan.IndikatorMatrixResponse(nil), baris.indikator...)
+	sort.SliceStable(indikator, func(i, j int) bool {
+		return indikator[i].Id < indikator[j].Id
+	})
+
+	start := excel.row
+	end := start + len(indikator) - 1
+	if len(indikator) == 0 {
+		end = start
+	}
+
+	nama := strings.Repeat("    ", baris.level) + baris.nama
+	cells := map[string]interface{}{"A": baris.kode, "B": nama, "F": baris.pagu, "G": baris.namaPegawai}
+	for col, value := range cells {
+		if err := file.SetCellValue(matrixRenjaSheet, fmt.Sprintf("%s%d", col, start), value); err != nil {
+			return err
+		}
+		if end > start {
+			if err := file.MergeCell(matrixRenjaSheet, fmt.Sprintf("%s%d", col, start), fmt.Sprintf("%s%d", col, end)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, ind := range indikator {
+		row := start + i
+		values := []interface{}{ind.Indikator, ind.Target, ind.Satuan}
+		if err := file.SetSheetRow(matrixRenjaSheet, fmt.Sprintf("C%d", row), &values); err != nil {
+			return err
+		}
+	}
+
+	style := excel.styles[baris.level]
+	for _, area := range [][2]string{{"A", "B"}, {"G", "G"}} {
+		if err := file.SetCellStyle(matrixRenjaSheet, fmt.Sprintf("%s%d", area[0], start), fmt.Sprintf("%s%d", area[1], end), style); err != nil {
+			return err
+		}
+	}
+	if err := file.SetCellStyle(matrixRenjaSheet, fmt.Sprintf("C%d", start), fmt.Sprintf("E%d", end), excel.indikatorStyle); err != nil {
+		return err
+	}
+	if err := file.SetCellStyle(matrixRenjaSheet, fmt.Sprintf("F%d", start), fmt.Sprintf("F%d", end), excel.paguStyle); err != nil {
+		return err
+	}
+
+	excel.row = end + 1
+	return nil
+}
+
+func (excel *matrixRenjaExcel) writeTotal(total int64) error {
+	file := excel.file
+	row := excel.row
+	if err := file.SetCellValue(matrixRenjaSheet, fmt.Sprintf("A%d", row), "TOTAL PAGU"); err != nil {
+		return err
+	}
+	if err := file.MergeCell(matrixRenjaSheet, fmt.Sprintf("A%d", row), fmt.Sprintf("E%d", row)); err != nil {
+		return err
+	}
+	if err := file.SetCellValue(matrixRenjaSheet, fmt.Sprintf("F%d", row), total); err != nil {
+		return err
+	}
+	style, err := file.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true},
+		NumFmt: 3,
+		Border: matrixRenjaBorder,
+	})
+	if err != nil {
+		return err
+	}
+	return file.SetCellStyle(matrixRenjaSheet, fmt.Sprintf("A%d", row), fmt.Sprintf("G%d", row), style)
+}
+
+func totalPagu(anggaran []programkegiatan.PaguAnggaranTotalResponse) int64 {
+	var total int64
+	for _, pagu := range anggaran {
+		total += pagu.PaguAnggaran
+	}
+	return total
+}
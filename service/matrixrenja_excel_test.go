@@ -0,0 +1,100 @@
+package service
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/model/web/programkegiatan"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestBuildMatrixRenjaExcel(t *testing.T) {
+	anggaran := func(pagu int64) []programkegiatan.PaguAnggaranTotalResponse {
+		return []programkegiatan.PaguAnggaranTotalResponse{{Tahun: "2025", PaguAnggaran: pagu}}
+	}
+	indikator := func(nama, target string) programkegiatan.IndikatorMatrixResponse {
+		return programkegiatan.IndikatorMatrixResponse{Id: nama, Indikator: nama, Target: target, Satuan: "%"}
+	}
+
+	data := []programkegiatan.UrusanDetailResponse{{
+		KodeOpd:           "1.01.0.00.0.00.01.0000",
+		PaguAnggaranTotal: anggaran(300),
+		Urusan: []programkegiatan.UrusanResponse{{
+			Kode:     "1",
+			Nama:     "Urusan Wajib",
+			Anggaran: anggaran(300),
+			BidangUrusan: []programkegiatan.BidangUrusanResponse{{
+				Kode:     "1.01",
+				Nama:     "Pendidikan",
+				Anggaran: anggaran(300),
+				Program: []programkegiatan.ProgramResponse{{
+					Kode:      "1.01.01",
+					Nama:      "Program Penunjang",
+					Anggaran:  anggaran(300),
+					Indikator: []programkegiatan.IndikatorMatrixResponse{indikator("a", "90"), indikator("b", "80")},
+					Kegiatan: []programkegiatan.KegiatanResponse{{
+						Kode:     "1.01.01.2.01",
+						Nama:     "Perencanaan",
+						Anggaran: anggaran(300),
+						SubKegiatan: []programkegiatan.SubKegiatanResponse{
+							{Kode: "1.01.01.2.01.0001", Nama: "Penyusunan Renja", Anggaran: anggaran(100), NamaPegawai: "Budi"},
+							{Kode: "1.01.01.2.01.0002", Nama: "Evaluasi Renja", Anggaran: anggaran(200)},
+						},
+					}},
+				}},
+			}},
+		}},
+	}}
+
+	content, err := buildMatrixRenjaExcel(data, "ranwal", "1.01.0.00.0.00.01.0000", "2025")
+	if err != nil {
+		t.Fatalf("buildMatrixRenjaExcel error = %v", err)
+	}
+
+	file, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("file bukan xlsx valid: %v", err)
+	}
+	defer file.Close()
+
+	// baris 6 urusan, 7 bidang, 8-9 program (2 indikator), 10 kegiatan, 11-12 subkegiatan, 13 total
+	tests := []struct {
+		cell     string
+		expected string
+	}{
+		{"A6", "1"},
+		{"A8", "1.01.01"},
+		{"C8", "a"},
+		{"C9", "b"},
+		{"D9", "80"},
+		{"F8", "300"},
+		{"A11", "1.01.01.2.01.0001"},
+		{"G11", "Budi"},
+		{"F12", "200"},
+		{"A13", "TOTAL PAGU"},
+		{"F13", "300"},
+	}
+	for _, tt := range tests {
+		value, err := file.GetCellValue(matrixRenjaSheet, tt.cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			t.Fatalf("GetCellValue %s error = %v", tt.cell, err)
+		}
+		if value != tt.expected {
+			t.Errorf("%s = %q; want %q", tt.cell, value, tt.expected)
+		}
+	}
+
+	merged, err := file.GetMergeCells(matrixRenjaSheet)
+	if err != nil {
+		t.Fatalf("GetMergeCells error = %v", err)
+	}
+	found := false
+	for _, cell := range merged {
+		if cell.GetStartAxis() == "A8" && cell.GetEndAxis() == "A9" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("kode program tidak di-merge sepanjang baris indikator A8:A9")
+	}
+}
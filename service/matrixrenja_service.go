@@ -12,4 +12,5 @@ type MatrixRenjaService interface {
 	UpsertBatchIndikatorRenjaPenetapan(ctx context.Context, requests []programkegiatan.IndikatorRenjaCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error)
 	UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenjaRequest) (programkegiatan.AnggaranRenjaResponse, error)
 	GetRenjaPenetapan(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error)
+	ExportRenjaExcel(ctx context.Context, kodeOpd, tahun, jenis string) ([]byte, error)
 }
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -16,56 +18,80 @@ type MatrixRenjaServiceImpl struct {
 	MatrixRenjaRepository repository.MatrixRenjaRepository
 	PeriodeRepository     repository.PeriodeRepository
 	PegawaiRepository     repository.PegawaiRepository
+	LockDataRepository    repository.LockDataRepository
 	DB                    *sql.DB
+	Cache                 *helper.Cache
 }
 
 func NewMatrixRenjaServiceImpl(
 	matrixRenjaRepository repository.MatrixRenjaRepository,
 	periodeRepository repository.PeriodeRepository,
 	pegawaiRepository repository.PegawaiRepository,
+	lockDataRepository repository.LockDataRepository,
 	db *sql.DB,
+	cache *helper.Cache,
 ) *MatrixRenjaServiceImpl {
 	return &MatrixRenjaServiceImpl{
 		MatrixRenjaRepository: matrixRenjaRepository,
 		PeriodeRepository:     periodeRepository,
 		PegawaiRepository:     pegawaiRepository,
+		LockDataRepository:    lockDataRepository,
 		DB:                    db,
+		Cache:                 cache,
 	}
 }
 
-func (service *MatrixRenjaServiceImpl) GetRenja(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return nil, err
+// jenisLockMatrixRenja memetakan jenis indikator renja (ranwal/rankhir/penetapan) ke jenis_data lock
+func jenisLockMatrixRenja(jenis string) string {
+	switch strings.ToLower(jenis) {
+	case "ranwal":
+		return domain.JenisLockMatrixRenjaRanwal
+	case "rankhir":
+		return domain.JenisLockMatrixRenjaRankhir
+	default:
+		return domain.JenisLockMatrixRenjaPenetapan
 	}
-	defer tx.Rollback()
-	// Service yang menentukan jenis indikator dan jenis pagu
-	data, err := service.MatrixRenjaRepository.GetRenja(ctx, tx, kodeOpd, tahun, jenisPagu)
-	if err != nil {
-		return nil, err
-	}
-	result := service.transformToResponse(data, kodeOpd, tahun)
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
-	return result, nil
+}
+
+func (service *MatrixRenjaServiceImpl) GetRenja(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error) {
+	key := helper.NewCacheKey(helper.CacheEntityMatrixRenja, "ranwal", kodeOpd, tahun, jenisPagu).WithTags(helper.CacheTagOpdTahun(kodeOpd, tahun))
+	return helper.Remember(ctx, service.Cache, key, helper.ReadCacheTTL, func() ([]programkegiatan.UrusanDetailResponse, error) {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		// Service yang menentukan jenis indikator dan jenis pagu
+		data, err := service.MatrixRenjaRepository.GetRenja(ctx, tx, kodeOpd, tahun, jenisPagu)
+		if err != nil {
+			return nil, err
+		}
+		result := service.transformToResponse(data, kodeOpd, tahun)
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
 }
 func (service *MatrixRenjaServiceImpl) GetRenjaRankhir(ctx context.Context, kodeOpd, tahun string) ([]programkegiatan.UrusanDetailResponse, error) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-	// Rankhir: sumber subkegiatan dari cascading OPD, pagu dari rincian_belanja
-	data, err := service.MatrixRenjaRepository.GetRenjaRankhir(ctx, tx, kodeOpd, tahun)
-	if err != nil {
-		return nil, err
-	}
-	result := service.transformToResponse(data, kodeOpd, tahun)
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
-	return result, nil
+	key := helper.NewCacheKey(helper.CacheEntityMatrixRenja, "rankhir", kodeOpd, tahun).WithTags(helper.CacheTagOpdTahun(kodeOpd, tahun))
+	return helper.Remember(ctx, service.Cache, key, helper.ReadCacheTTL, func() ([]programkegiatan.UrusanDetailResponse, error) {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		// Rankhir: sumber subkegiatan dari cascading OPD, pagu dari rincian_belanja
+		data, err := service.MatrixRenjaRepository.GetRenjaRankhir(ctx, tx, kodeOpd, tahun)
+		if err != nil {
+			return nil, err
+		}
+		result := service.transformToResponse(data, kodeOpd, tahun)
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
 }
 
 // transformToResponse: unified untuk ranwal & rankhir.
@@ -584,11 +610,25 @@ func (service *MatrixRenjaServiceImpl) transformToResponse(data []domain.SubKegi
 // }
 
 func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenja(ctx context.Context, requests []programkegiatan.IndikatorRenjaCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error) {
+	for _, request := range requests {
+		if err := helper.ValidateRequest(request); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range requests {
+		if err := helper.ValidateKodeOpdAccess(ctx, item.KodeOpd); err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
+	if err := checkLockData(ctx, tx, service.LockDataRepository, jenisLockMatrixRenja(requests[0].Jenis), requests[0].KodeOpd, requests[0].Tahun); err != nil {
+		return nil, err
+	}
 	prefixBase := fmt.Sprintf("RENJA-RANKHIR-%s-%s-%s-", requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun)
 	existingCount, err := service.MatrixRenjaRepository.CountIndikatorMatrixByScope(
 		ctx, tx,
@@ -657,15 +697,30 @@ func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenja(ctx context.Con
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	service.Cache.Invalidate(ctx, helper.CacheTagOpdTahun(requests[0].KodeOpd, requests[0].Tahun))
 	return respItems, nil
 }
 
 func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenjaPenetapan(ctx context.Context, requests []programkegiatan.IndikatorRenjaCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error) {
+	for _, request := range requests {
+		if err := helper.ValidateRequest(request); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range requests {
+		if err := helper.ValidateKodeOpdAccess(ctx, item.KodeOpd); err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
+	if err := checkLockData(ctx, tx, service.LockDataRepository, domain.JenisLockMatrixRenjaPenetapan, requests[0].KodeOpd, requests[0].Tahun); err != nil {
+		return nil, err
+	}
 	prefixBase := fmt.Sprintf("RENJA-PENETAPAN-%s-%s-%s-", requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun)
 	existingCount, err := service.MatrixRenjaRepository.CountIndikatorMatrixByScope(
 		ctx, tx,
@@ -734,15 +789,27 @@ func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenjaPenetapan(ctx co
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	service.Cache.Invalidate(ctx, helper.CacheTagOpdTahun(requests[0].KodeOpd, requests[0].Tahun))
 	return respItems, nil
 }
 
 func (service *MatrixRenjaServiceImpl) UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenjaRequest) (programkegiatan.AnggaranRenjaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return programkegiatan.AnggaranRenjaResponse{}, err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return programkegiatan.AnggaranRenjaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return programkegiatan.AnggaranRenjaResponse{}, err
 	}
 	defer tx.Rollback()
+	// pagu yang disimpan adalah pagu penetapan (tb_pagu jenis='penetapan')
+	if err := checkLockData(ctx, tx, service.LockDataRepository, domain.JenisLockMatrixRenjaPenetapan, request.KodeOpd, request.Tahun); err != nil {
+		return programkegiatan.AnggaranRenjaResponse{}, err
+	}
 	err = service.MatrixRenjaRepository.UpsertAnggaran(
 		ctx, tx,
 		request.KodeSubKegiatan,
@@ -757,6 +824,7 @@ func (service *MatrixRenjaServiceImpl) UpsertAnggaran(ctx context.Context, reque
 	if err = tx.Commit(); err != nil {
 		return programkegiatan.AnggaranRenjaResponse{}, err
 	}
+	service.Cache.Invalidate(ctx, helper.CacheTagOpdTahun(request.KodeOpd, request.Tahun))
 	return programkegiatan.AnggaranRenjaResponse{
 		KodeSubKegiatan: request.KodeSubKegiatan,
 		KodeOpd:         request.KodeOpd,
@@ -766,18 +834,54 @@ func (service *MatrixRenjaServiceImpl) UpsertAnggaran(ctx context.Context, reque
 }
 
 func (service *MatrixRenjaServiceImpl) GetRenjaPenetapan(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return nil, err
+	key := helper.NewCacheKey(helper.CacheEntityMatrixRenja, "penetapan", kodeOpd, tahun, jenisPagu).WithTags(helper.CacheTagOpdTahun(kodeOpd, tahun))
+	return helper.Remember(ctx, service.Cache, key, helper.ReadCacheTTL, func() ([]programkegiatan.UrusanDetailResponse, error) {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		data, err := service.MatrixRenjaRepository.GetRenjaPenetapan(ctx, tx, kodeOpd, tahun, jenisPagu)
+		if err != nil {
+			return nil, err
+		}
+		result := service.transformToResponse(data, kodeOpd, tahun)
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// ExportRenjaExcel membuat file .xlsx matrix renja sesuai jenis (ranwal, rankhir, penetapan)
+// dengan sumber data yang sama seperti endpoint GET matrix renja
+func (service *MatrixRenjaServiceImpl) ExportRenjaExcel(ctx context.Context, kodeOpd, tahun, jenis string) ([]byte, error) {
+	var data []programkegiatan.UrusanDetailResponse
+	var err error
+	switch jenis {
+	case "ranwal":
+		data, err = service.GetRenja(ctx, kodeOpd, tahun, "renstra")
+	case "rankhir":
+		data, err = service.GetRenjaRankhir(ctx, kodeOpd, tahun)
+	case "penetapan":
+		data, err = service.GetRenjaPenetapan(ctx, kodeOpd, tahun, "penetapan")
+	default:
+		return nil, web.NewBadRequestError(fmt.Sprintf("jenis matrix renja %s tidak dikenal", jenis))
 	}
-	defer tx.Rollback()
-	data, err := service.MatrixRenjaRepository.GetRenjaPenetapan(ctx, tx, kodeOpd, tahun, jenisPagu)
 	if err != nil {
 		return nil, err
 	}
-	result := service.transformToResponse(data, kodeOpd, tahun)
-	if err := tx.Commit(); err != nil {
-		return nil, err
+	return buildMatrixRenjaExcel(data, jenis, kodeOpd, tahun)
+}
+
+// invalidateOpdTahunCache dipanggil lewat defer oleh perubahan data turunan rekin (rencana aksi, rincian belanja,
+// subkegiatan terpilih) yang ikut dijumlahkan matrix renja. kodeOpd kosong berarti rekin tidak ditemukan.
+// Defer invalidasi cache (termasuk invalidateRenstraCache dan Invalidate langsung di rencana kinerja) didaftarkan
+// sebelum defer CommitOrRollback, sehingga karena urutan LIFO cache baru dihapus setelah transaksi di-commit
+// dan request lain tidak mengisi ulang cache dengan data lama.
+func invalidateOpdTahunCache(ctx context.Context, cache *helper.Cache, kodeOpd *string, tahun *string) {
+	if *kodeOpd == "" {
+		return
 	}
-	return result, nil
+	cache.Invalidate(ctx, helper.CacheTagOpdTahun(*kodeOpd, *tahun))
 }
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 	"ekak_kabupaten_madiun/repository"
 	"encoding/binary"
@@ -332,7 +334,7 @@ func (service *MatrixRenstraServiceImpl) DeleteIndikator(ctx context.Context, ko
 	_, err = service.MatrixRenstraRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("indikator %s tidak ditemukan", kodeIndikator)
+			return web.NewNotFoundError(fmt.Sprintf("indikator %s tidak ditemukan", kodeIndikator))
 		}
 		return err
 	}
@@ -354,7 +356,7 @@ func (service *MatrixRenstraServiceImpl) FindIndikatorByKodeIndikator(ctx contex
 	ind, err := service.MatrixRenstraRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return programkegiatan.IndikatorResponse{}, fmt.Errorf("indikator %s tidak ditemukan", kodeIndikator)
+			return programkegiatan.IndikatorResponse{}, web.NewNotFoundError(fmt.Sprintf("indikator %s tidak ditemukan", kodeIndikator))
 		}
 		return programkegiatan.IndikatorResponse{}, err
 	}
@@ -378,6 +380,9 @@ func (service *MatrixRenstraServiceImpl) FindIndikatorByKodeIndikator(ctx contex
 }
 
 func (service *MatrixRenstraServiceImpl) UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenstraRequest) (programkegiatan.AnggaranRenstraResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return programkegiatan.AnggaranRenstraResponse{}, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return programkegiatan.AnggaranRenstraResponse{}, err
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	visimisipemda "ekak_kabupaten_madiun/model/web/visimisi"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -31,6 +32,10 @@ func NewMisiPemdaServiceImpl(misiPemdaRepository repository.MisiPemdaRepository,
 }
 
 func (service *MisiPemdaServiceImpl) Create(ctx context.Context, request visimisipemda.MisiPemdaCreateRequest) (visimisipemda.MisiPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return visimisipemda.MisiPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return visimisipemda.MisiPemdaResponse{}, err
@@ -43,7 +48,7 @@ func (service *MisiPemdaServiceImpl) Create(ctx context.Context, request visimis
 		return visimisipemda.MisiPemdaResponse{}, err
 	}
 	if exists {
-		return visimisipemda.MisiPemdaResponse{}, fmt.Errorf("urutan %d sudah digunakan untuk visi ini", request.Urutan)
+		return visimisipemda.MisiPemdaResponse{}, web.NewConflictError(fmt.Sprintf("urutan %d sudah digunakan untuk visi ini", request.Urutan))
 	}
 
 	_, err = service.VisiPemdaRepository.FindById(ctx, tx, request.IdVisi)
@@ -68,6 +73,10 @@ func (service *MisiPemdaServiceImpl) Create(ctx context.Context, request visimis
 }
 
 func (service *MisiPemdaServiceImpl) Update(ctx context.Context, request visimisipemda.MisiPemdaUpdateRequest) (visimisipemda.MisiPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return visimisipemda.MisiPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return visimisipemda.MisiPemdaResponse{}, err
@@ -80,7 +89,7 @@ func (service *MisiPemdaServiceImpl) Update(ctx context.Context, request visimis
 		return visimisipemda.MisiPemdaResponse{}, err
 	}
 	if exists {
-		return visimisipemda.MisiPemdaResponse{}, fmt.Errorf("urutan %d sudah digunakan untuk visi ini", request.Urutan)
+		return visimisipemda.MisiPemdaResponse{}, web.NewConflictError(fmt.Sprintf("urutan %d sudah digunakan untuk visi ini", request.Urutan))
 	}
 
 	visiPemda, err := service.VisiPemdaRepository.FindById(ctx, tx, request.IdVisi)
@@ -126,13 +135,13 @@ func (service *MisiPemdaServiceImpl) FindAll(ctx context.Context, tahunAwal stri
 	if tahunAwal != "" {
 		_, err := strconv.Atoi(tahunAwal)
 		if err != nil {
-			return nil, fmt.Errorf("format tahun awal tidak valid")
+			return nil, web.NewBadRequestError("format tahun awal tidak valid")
 		}
 	}
 	if tahunAkhir != "" {
 		_, err := strconv.Atoi(tahunAkhir)
 		if err != nil {
-			return nil, fmt.Errorf("format tahun akhir tidak valid")
+			return nil, web.NewBadRequestError("format tahun akhir tidak valid")
 		}
 	}
 
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/nomenklatur"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// panjang digit tiap segmen kode nomenklatur kemendagri, contoh sub kegiatan 1.01.02.2.01.0001
+var nomenklaturSegmen = []int{1, 2, 2, 1, 2, 4}
+
+// nomenklaturBaris satu baris nomenklatur yang sudah lolos parsing, Baris adalah nomor baris di file
+type nomenklaturBaris struct {
+	Baris int
+	Level string
+	Kode  string
+	Nama  string
+}
+
+// nomenklaturChangeSet hasil perbandingan isi file dengan data di database
+type nomenklaturChangeSet struct {
+	insert []domainmaster.Nomenklatur
+	update []domainmaster.Nomenklatur
+	retire []domainmaster.Nomenklatur
+	// response memuat detail yang ditampilkan ke pengguna, termasuk nama lama untuk update
+	response nomenklatur.NomenklaturImportResponse
+}
+
+// parseNomenklaturFile membaca file CSV atau XLSX (sheet pertama) nomenklatur kemendagri.
+// Baris header dikenali dari kolom "nomenklatur"/"nama"/"uraian", semua kolom di kirinya adalah kode
+// sehingga format satu kolom kode maupun kode yang dipecah per level (urusan, bidang, program, ...) sama-sama bisa dibaca.
+func parseNomenklaturFile(filename string, content []byte) ([]nomenklaturBaris, error) {
+	var records [][]string
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, web.NewBadRequestError(fmt.Sprintf("file CSV tidak valid: %v", err))
+		}
+		records = rows
+	case ".xlsx":
+		file, err := excelize.OpenReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, web.NewBadRequestError(fmt.Sprintf("file XLSX tidak valid: %v", err))
+		}
+		defer file.Close()
+		rows, err := file.GetRows(file.GetSheetName(0))
+		if err != nil {
+			return nil, web.NewBadRequestError(fmt.Sprintf("file XLSX tidak valid: %v", err))
+		}
+		records = rows
+	default:
+		return nil, web.NewBadRequestError("format file harus .csv atau .xlsx")
+	}
+
+	headerRow, namaColumn := -1, -1
+	for i, row := range records {
+		for j, cell := range row {
+			header := strings.ToLower(strings.TrimSpace(cell))
+			if j > 0 && (strings.Contains(header, "nomenklatur") || strings.HasPrefix(header, "nama") || strings.HasPrefix(header, "uraian")) {
+				headerRow, namaColumn = i, j
+				break
+			}
+		}
+		if headerRow >= 0 {
+			break
+		}
+	}
+	if headerRow < 0 {
+		return nil, web.NewBadRequestError("header kolom kode dan nomenklatur tidak ditemukan")
+	}
+
+	var result []nomenklaturBaris
+	var fieldErrors []web.FieldError
+	seen := make(map[string]int)
+	for i := headerRow + 1; i < len(records); i++ {
+		row := records[i]
+		baris := i + 1
+
+		var segmen []string
+		for j := 0; j < namaColumn && j < len(row); j++ {
+			if cell := strings.Trim(strings.TrimSpace(row[j]), "."); cell != "" {
+				segmen = append(segmen, cell)
+			}
+		}
+		nama := ""
+		if namaColumn < len(row) {
+			nama = strings.Join(strings.Fields(row[namaColumn]), " ")
+		}
+		if len(segmen) == 0 && nama == "" {
+			continue
+		}
+
+		kode := strings.Join(segmen, ".")
+		level, err := nomenklaturLevel(kode)
+		if err != nil {
+			fieldErrors = append(fieldErrors, web.FieldError{Field: fmt.Sprintf("baris[%d].kode", baris), Message: err.Error()})
+			continue
+		}
+		if nama == "" {
+			fieldErrors = append(fieldErrors, web.FieldError{Field: fmt.Sprintf("baris[%d].nomenklatur", baris), Message: fmt.Sprintf("nomenklatur kode %s wajib diisi", kode)})
+			continue
+		}
+		if barisAwal, ok := seen[kode]; ok {
+			fieldErrors = append(fieldErrors, web.FieldError{Field: fmt.Sprintf("baris[%d].kode", baris), Message: fmt.Sprintf("kode %s duplikat dengan baris %d", kode, barisAwal)})
+			continue
+		}
+		seen[kode] = baris
+		result = append(result, nomenklaturBaris{Baris: baris, Level: level, Kode: kode, Nama: nama})
+	}
+
+	// hierarki kode: setiap kode selain urusan harus punya induk di file yang sama,
+	// kecuali induk lintas urusan (X.XX) yang memang tidak tercantum di nomenklatur
+	for _, item := range result {
+		parent := nomenklaturParent(item.Kode)
+		if parent == "" || strings.ContainsAny(parent, "Xx") {
+			continue
+		}
+		if _, ok := seen[parent]; !ok {
+			fieldErrors = append(fieldErrors, web.FieldError{Field: fmt.Sprintf("baris[%d].kode", item.Baris), Message: fmt.Sprintf("induk kode %s (%s) tidak ada di file", item.Kode, parent)})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, web.NewFieldValidationError(fieldErrors)
+	}
+	if len(result) == 0 {
+		return nil, web.NewBadRequestError("file tidak berisi data nomenklatur")
+	}
+	return result, nil
+}
+
+// nomenklaturLevel menentukan level dari jumlah segmen kode dan memeriksa panjang tiap segmen
+func nomenklaturLevel(kode string) (string, error) {
+	segmen := strings.Split(kode, ".")
+	var level string
+	switch len(segmen) {
+	case 1:
+		level = domainmaster.NomenklaturUrusan
+	case 2:
+		level = domainmaster.NomenklaturBidangUrusan
+	case 3:
+		level = domainmaster.NomenklaturProgram
+	case 5:
+		level = domainmaster.NomenklaturKegiatan
+	case 6:
+		level = domainmaster.NomenklaturSubKegiatan
+	default:
+		return "", web.NewBadRequestError(fmt.Sprintf("kode %s tidak sesuai format nomenklatur", kode))
+	}
+
+	for i, s := range segmen {
+		if len(s) != nomenklaturSegmen[i] {
+			return "", web.NewBadRequestError(fmt.Sprintf("kode %s: segmen ke-%d harus %d digit", kode, i+1, nomenklaturSegmen[i]))
+		}
+		for _, c := range s {
+			if (c < '0' || c > '9') && c != 'X' && c != 'x' {
+				return "", fmt.Errorf("kode %s: segmen ke-%d hanya boleh angka atau X", kode, i+1)
+			}
+		}
+	}
+	return level, nil
+}
+
+// nomenklaturParent kode induk satu level di atasnya, kegiatan (5 segmen) langsung di bawah program (3 segmen)
+func nomenklaturParent(kode string) string {
+	segmen := strings.Split(kode, ".")
+	switch len(segmen) {
+	case 2, 3, 6:
+		return strings.Join(segmen[:len(segmen)-1], ".")
+	case 5:
+		return strings.Join(segmen[:3], ".")
+	default:
+		return ""
+	}
+}
+
+// nomenklaturId mengikuti format id yang dipakai service master masing-masing level
+func nomenklaturId(level, kode string) string {
+	switch level {
+	case domainmaster.NomenklaturUrusan:
+		return "URU-" + kode
+	case domainmaster.NomenklaturBidangUrusan:
+		return "BID-URU-" + kode
+	case domainmaster.NomenklaturProgram:
+		return "PRGM-" + kode
+	case domainmaster.NomenklaturKegiatan:
+		return "KGT-" + kode
+	default:
+		return "SUB-KEG-" + kode
+	}
+}
+
+// diffNomenklatur membandingkan isi file dengan data existing:
+// kode baru di-insert, nama berubah atau data nonaktif di-update, kode yang tidak ada lagi di file dinonaktifkan (retire).
+// Retire tidak menghapus baris karena masih direferensikan data perencanaan tahun-tahun sebelumnya.
+func diffNomenklatur(rows []nomenklaturBaris, existing []domainmaster.Nomenklatur) nomenklaturChangeSet {
+	changeSet := nomenklaturChangeSet{
+		response: nomenklatur.NomenklaturImportResponse{
+			TotalBaris: len(rows),
+			Insert:     []nomenklatur.NomenklaturPerubahan{},
+			Update:     []nomenklatur.NomenklaturPerubahan{},
+			Retire:     []nomenklatur.NomenklaturPerubahan{},
+		},
+	}
+
+	existingByKode := make(map[string]domainmaster.Nomenklatur, len(existing))
+	for _, item := range existing {
+		existingByKode[item.Level+"|"+item.Kode] = item
+	}
+
+	inFile := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		key := row.Level + "|" + row.Kode
+		inFile[key] = true
+
+		current, ok := existingByKode[key]
+		if !ok {
+			changeSet.insert = append(changeSet.insert, domainmaster.Nomenklatur{
+				Level:    row.Level,
+				Id:       nomenklaturId(row.Level, row.Kode),
+				Kode:     row.Kode,
+				Nama:     row.Nama,
+				IsActive: true,
+			})
+			changeSet.response.Insert = append(changeSet.response.Insert, nomenklatur.NomenklaturPerubahan{
+				Baris: row.Baris, Level: row.Level, Kode: row.Kode, NamaBaru: row.Nama,
+			})
+			continue
+		}
+
+		if current.Nama == row.Nama && current.IsActive {
+			continue
+		}
+		updated := current
+		updated.Nama = row.Nama
+		updated.IsActive = true
+		changeSet.update = append(changeSet.update, updated)
+		changeSet.response.Update = append(changeSet.response.Update, nomenklatur.NomenklaturPerubahan{
+			Baris: row.Baris, Level: row.Level, Kode: row.Kode, NamaLama: current.Nama, NamaBaru: row.Nama,
+		})
+	}
+
+	for _, item := range existing {
+		if !item.IsActive || inFile[item.Level+"|"+item.Kode] {
+			continue
+		}
+		retired := item
+		retired.IsActive = false
+		changeSet.retire = append(changeSet.retire, retired)
+		changeSet.response.Retire = append(changeSet.response.Retire, nomenklatur.NomenklaturPerubahan{
+			Level: item.Level, Kode: item.Kode, NamaLama: item.Nama,
+		})
+	}
+
+	changeSet.response.JumlahInsert = len(changeSet.insert)
+	changeSet.response.JumlahUpdate = len(changeSet.update)
+	changeSet.response.JumlahRetire = len(changeSet.retire)
+	return changeSet
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseNomenklaturFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantRows   int
+		wantErrors int
+	}{
+		{
+			name: "kode satu kolom",
+			content: "Kode,Nomenklatur\n" +
+				"1,URUSAN PEMERINTAHAN WAJIB\n" +
+				"1.01,PENDIDIKAN\n" +
+				"1.01.02,PROGRAM PENGELOLAAN PENDIDIKAN\n" +
+				"1.01.02.2.01,Pengelolaan Pendidikan Sekolah Dasar\n" +
+				"1.01.02.2.01.0001,Pembangunan Unit Sekolah Baru\n",
+			wantRows: 5,
+		},
+		{
+			name: "kode dipecah per level dan program lintas urusan",
+			content: "Urusan,Bidang,Program,Kegiatan,Sub Kegiatan,Nomenklatur Urusan Kabupaten/Kota\n" +
+				"X,XX,01,,,PROGRAM PENUNJANG URUSAN PEMERINTAHAN\n" +
+				"X,XX,01,2.01,,Perencanaan dan Evaluasi Kinerja\n" +
+				"X,XX,01,2.01,0001,Penyusunan Dokumen Perencanaan\n",
+			wantRows: 3,
+		},
+		{
+			name: "format kode salah, duplikat, nama kosong dan induk tidak ada",
+			content: "kode,nama\n" +
+				"1,URUSAN\n" +
+				"1.1,SALAH FORMAT\n" +
+				"1,URUSAN DUPLIKAT\n" +
+				"1.02,\n" +
+				"1.03.01,PROGRAM TANPA BIDANG\n",
+			wantErrors: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseNomenklaturFile("nomenklatur.csv", []byte(tt.content))
+			if tt.wantErrors > 0 {
+				var customErr *web.CustomError
+				if !errors.As(err, &customErr) || len(customErr.Errors) != tt.wantErrors {
+					t.Fatalf("error = %v; want %d field error", err, tt.wantErrors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseNomenklaturFile error = %v", err)
+			}
+			if len(rows) != tt.wantRows {
+				t.Errorf("jumlah baris = %d; want %d", len(rows), tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestParseNomenklaturXlsx(t *testing.T) {
+	file := excelize.NewFile()
+	sheet := file.GetSheetName(0)
+	file.SetSheetRow(sheet, "A1", &[]interface{}{"NOMENKLATUR URUSAN KABUPATEN/KOTA"})
+	file.SetSheetRow(sheet, "A3", &[]interface{}{"Kode", "Nomenklatur"})
+	file.SetSheetRow(sheet, "A4", &[]interface{}{"2", "URUSAN PEMERINTAHAN WAJIB NON PELAYANAN DASAR"})
+	file.SetSheetRow(sheet, "A5", &[]interface{}{"2.07", "TENAGA KERJA"})
+	buffer, err := file.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parseNomenklaturFile("Nomenklatur.XLSX", buffer.Bytes())
+	if err != nil {
+		t.Fatalf("parseNomenklaturFile error = %v", err)
+	}
+	if len(rows) != 2 || rows[1].Level != domainmaster.NomenklaturBidangUrusan || rows[1].Baris != 5 {
+		t.Errorf("rows = %+v", rows)
+	}
+
+	if _, err := parseNomenklaturFile("nomenklatur.pdf", buffer.Bytes()); err == nil {
+		t.Error("format selain csv/xlsx seharusnya ditolak")
+	}
+}
+
+func TestDiffNomenklatur(t *testing.T) {
+	rows := []nomenklaturBaris{
+		{Baris: 2, Level: domainmaster.NomenklaturUrusan, Kode: "1", Nama: "URUSAN WAJIB"},
+		{Baris: 3, Level: domainmaster.NomenklaturBidangUrusan, Kode: "1.01", Nama: "PENDIDIKAN"},
+		{Baris: 4, Level: domainmaster.NomenklaturProgram, Kode: "1.01.02", Nama: "PROGRAM BARU"},
+		{Baris: 5, Level: domainmaster.NomenklaturKegiatan, Kode: "1.01.02.2.01", Nama: "Kegiatan Aktif Kembali"},
+	}
+	existing := []domainmaster.Nomenklatur{
+		{Level: domainmaster.NomenklaturUrusan, Id: "URU-1", Kode: "1", Nama: "URUSAN WAJIB", IsActive: true},
+		{Level: domainmaster.NomenklaturBidangUrusan, Id: "BID-URU-1.01", Kode: "1.01", Nama: "BIDANG PENDIDIKAN", IsActive: true},
+		{Level: domainmaster.NomenklaturKegiatan, Id: "KGT-1.01.02.2.01", Kode: "1.01.02.2.01", Nama: "Kegiatan Aktif Kembali", IsActive: false},
+		{Level: domainmaster.NomenklaturSubKegiatan, Id: "SUB-KEG-1.01.02.2.01.0009", Kode: "1.01.02.2.01.0009", Nama: "Sub Kegiatan Lama", IsActive: true},
+		{Level: domainmaster.NomenklaturSubKegiatan, Id: "SUB-KEG-1.01.02.2.01.0010", Kode: "1.01.02.2.01.0010", Nama: "Sudah Nonaktif", IsActive: false},
+	}
+
+	changeSet := diffNomenklatur(rows, existing)
+
+	if len(changeSet.insert) != 1 || changeSet.insert[0].Id != "PRGM-1.01.02" || !changeSet.insert[0].IsActive {
+		t.Errorf("insert = %+v; want program 1.01.02", changeSet.insert)
+	}
+	if len(changeSet.update) != 2 {
+		t.Fatalf("update = %+v; want nama bidang berubah dan kegiatan aktif kembali", changeSet.update)
+	}
+	if changeSet.response.Update[0].NamaLama != "BIDANG PENDIDIKAN" || changeSet.response.Update[0].NamaBaru != "PENDIDIKAN" {
+		t.Errorf("update bidang = %+v", changeSet.response.Update[0])
+	}
+	if !changeSet.update[1].IsActive {
+		t.Error("kegiatan yang ada lagi di file seharusnya diaktifkan kembali")
+	}
+	if len(changeSet.retire) != 1 || changeSet.retire[0].Kode != "1.01.02.2.01.0009" || changeSet.retire[0].IsActive {
+		t.Errorf("retire = %+v; want hanya sub kegiatan 0009", changeSet.retire)
+	}
+	if changeSet.response.JumlahInsert != 1 || changeSet.response.JumlahUpdate != 2 || changeSet.response.JumlahRetire != 1 {
+		t.Errorf("ringkasan = %+v", changeSet.response)
+	}
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/nomenklatur"
+)
+
+type NomenklaturService interface {
+	PreviewImport(ctx context.Context, filename string, content []byte) (nomenklatur.NomenklaturImportResponse, error)
+	ApplyImport(ctx context.Context, filename string, content []byte) (nomenklatur.NomenklaturImportResponse, error)
+}
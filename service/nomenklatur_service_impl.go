@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/nomenklatur"
+	"ekak_kabupaten_madiun/repository"
+)
+
+type NomenklaturServiceImpl struct {
+	NomenklaturRepository repository.NomenklaturRepository
+	AuditLogRepository    repository.AuditLogRepository
+	DB                    *sql.DB
+}
+
+func NewNomenklaturServiceImpl(nomenklaturRepository repository.NomenklaturRepository, auditLogRepository repository.AuditLogRepository, DB *sql.DB) *NomenklaturServiceImpl {
+	return &NomenklaturServiceImpl{
+		NomenklaturRepository: nomenklaturRepository,
+		AuditLogRepository:    auditLogRepository,
+		DB:                    DB,
+	}
+}
+
+// PreviewImport (dry run) menampilkan insert, update dan retire yang akan terjadi tanpa mengubah data
+func (service *NomenklaturServiceImpl) PreviewImport(ctx context.Context, filename string, content []byte) (nomenklatur.NomenklaturImportResponse, error) {
+	rows, err := parseNomenklaturFile(filename, content)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	existing, err := service.NomenklaturRepository.FindAll(ctx, tx)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	response := diffNomenklatur(rows, existing).response
+	response.DryRun = true
+	return response, nil
+}
+
+// ApplyImport menghitung ulang change set dari file yang sama lalu menerapkannya dalam satu transaksi,
+// jika satu baris gagal seluruh perubahan dibatalkan
+func (service *NomenklaturServiceImpl) ApplyImport(ctx context.Context, filename string, content []byte) (nomenklatur.NomenklaturImportResponse, error) {
+	rows, err := parseNomenklaturFile(filename, content)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	existing, err := service.NomenklaturRepository.FindAll(ctx, tx)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	changeSet := diffNomenklatur(rows, existing)
+	for _, item := range changeSet.insert {
+		if err := service.NomenklaturRepository.Create(ctx, tx, item); err != nil {
+			return nomenklatur.NomenklaturImportResponse{}, err
+		}
+	}
+	for _, item := range changeSet.update {
+		if err := service.NomenklaturRepository.Update(ctx, tx, item); err != nil {
+			return nomenklatur.NomenklaturImportResponse{}, err
+		}
+	}
+	for _, item := range changeSet.retire {
+		if err := service.NomenklaturRepository.Update(ctx, tx, item); err != nil {
+			return nomenklatur.NomenklaturImportResponse{}, err
+		}
+	}
+
+	err = recordAuditLog(ctx, tx, service.AuditLogRepository, domain.AuditLog{
+		Action:     domain.AuditActionImport,
+		EntityType: domain.AuditEntityNomenklatur,
+		EntityId:   filename,
+	}, nil, changeSet.response)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	return changeSet.response, nil
+}
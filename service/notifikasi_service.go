@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/notifikasi"
+)
+
+type NotifikasiService interface {
+	// Kirim menyimpan notifikasi ke inbox penerima di dalam transaksi pemanggil,
+	// sehingga notifikasi hanya muncul jika perubahan yang memicunya ikut commit
+	Kirim(ctx context.Context, tx *sql.Tx, notifikasi domain.Notifikasi, penerima domain.PenerimaNotifikasi) error
+	FindAll(ctx context.Context, query domain.ListQuery) ([]notifikasi.NotifikasiResponse, int, error)
+	JumlahBelumDibaca(ctx context.Context) (notifikasi.JumlahBelumDibacaResponse, error)
+	Baca(ctx context.Context, id int64) error
+	BacaSemua(ctx context.Context) (notifikasi.BacaSemuaResponse, error)
+	// Stream memanggil kirim untuk setiap notifikasi baru milik user setelah lastId (-1 berarti mulai dari sekarang)
+	// dan ping secara berkala, sampai ctx selesai, kirim/ping gagal atau TutupStream dipanggil
+	Stream(ctx context.Context, lastId int64, kirim func(notifikasi.NotifikasiResponse) error, ping func() error) error
+	// TutupStream menghentikan semua stream yang berjalan, dipanggil saat server shutdown
+	TutupStream()
+}
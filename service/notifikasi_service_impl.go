@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/notifikasi"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// intervalStreamNotifikasi jeda pengecekan notifikasi baru untuk setiap stream. Stream membaca dari database,
+	// bukan dari memori, agar notifikasi dari instance lain dan hanya yang sudah commit yang terkirim.
+	intervalStreamNotifikasi = 3 * time.Second
+	// intervalPingNotifikasi heartbeat agar proxy tidak menutup koneksi stream yang idle
+	intervalPingNotifikasi = 25 * time.Second
+	batasStreamNotifikasi  = 50
+)
+
+type NotifikasiServiceImpl struct {
+	NotifikasiRepository repository.NotifikasiRepository
+	DB                   *sql.DB
+	selesai              chan struct{}
+	tutup                sync.Once
+}
+
+func NewNotifikasiServiceImpl(notifikasiRepository repository.NotifikasiRepository, DB *sql.DB) *NotifikasiServiceImpl {
+	return &NotifikasiServiceImpl{
+		NotifikasiRepository: notifikasiRepository,
+		DB:                   DB,
+		selesai:              make(chan struct{}),
+	}
+}
+
+func (service *NotifikasiServiceImpl) Kirim(ctx context.Context, tx *sql.Tx, notifikasi domain.Notifikasi, penerima domain.PenerimaNotifikasi) error {
+	userIds, err := service.NotifikasiRepository.FindPenerima(ctx, tx, penerima)
+	if err != nil {
+		return err
+	}
+	// user yang menjalankan aksi tidak perlu diberi tahu aksinya sendiri
+	claims, _ := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	userIds = saringPenerimaNotifikasi(userIds, claims.UserId)
+	if len(userIds) == 0 {
+		return nil
+	}
+	if notifikasi.CreatedAt.IsZero() {
+		notifikasi.CreatedAt = time.Now()
+	}
+	return service.NotifikasiRepository.Create(ctx, tx, notifikasi, userIds)
+}
+
+func (service *NotifikasiServiceImpl) FindAll(ctx context.Context, query domain.ListQuery) ([]notifikasi.NotifikasiResponse, int, error) {
+	userId, err := userIdNotifikasi(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	notifikasis, total, err := service.NotifikasiRepository.FindByUser(ctx, tx, userId, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	responses := make([]notifikasi.NotifikasiResponse, 0, len(notifikasis))
+	for _, item := range notifikasis {
+		responses = append(responses, toNotifikasiResponse(item))
+	}
+	return responses, total, nil
+}
+
+func (service *NotifikasiServiceImpl) JumlahBelumDibaca(ctx context.Context) (notifikasi.JumlahBelumDibacaResponse, error) {
+	userId, err := userIdNotifikasi(ctx)
+	if err != nil {
+		return notifikasi.JumlahBelumDibacaResponse{}, err
+	}
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return notifikasi.JumlahBelumDibacaResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	total, err := service.NotifikasiRepository.CountBelumDibaca(ctx, tx, userId)
+	if err != nil {
+		return notifikasi.JumlahBelumDibacaResponse{}, err
+	}
+	return notifikasi.JumlahBelumDibacaResponse{BelumDibaca: total}, nil
+}
+
+func (service *NotifikasiServiceImpl) Baca(ctx context.Context, id int64) error {
+	userId, err := userIdNotifikasi(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	err = service.NotifikasiRepository.TandaiDibaca(ctx, tx, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.NewNotFoundError(fmt.Sprintf("notifikasi %d tidak ditemukan", id))
+	}
+	return err
+}
+
+func (service *NotifikasiServiceImpl) BacaSemua(ctx context.Context) (notifikasi.BacaSemuaResponse, error) {
+	userId, err := userIdNotifikasi(ctx)
+	if err != nil {
+		return notifikasi.BacaSemuaResponse{}, err
+	}
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return notifikasi.BacaSemuaResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	ditandai, err := service.NotifikasiRepository.TandaiSemuaDibaca(ctx, tx, userId)
+	if err != nil {
+		return notifikasi.BacaSemuaResponse{}, err
+	}
+	return notifikasi.BacaSemuaResponse{Ditandai: ditandai}, nil
+}
+
+func (service *NotifikasiServiceImpl) Stream(ctx context.Context, lastId int64, kirim func(notifikasi.NotifikasiResponse) error, ping func() error) error {
+	userId, err := userIdNotifikasi(ctx)
+	if err != nil {
+		return err
+	}
+	if lastId < 0 {
+		lastId, err = service.findLastId(ctx, userId)
+		if err != nil {
+			return err
+		}
+	}
+
+	poll := time.NewTicker(intervalStreamNotifikasi)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(intervalPingNotifikasi)
+	defer heartbeat.Stop()
+
+	for {
+		// notifikasi yang tertinggal (reconnect dengan Last-Event-ID) langsung dikirim tanpa menunggu tick
+		notifikasis, err := service.findSetelah(ctx, userId, lastId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, item := range notifikasis {
+			if err := kirim(toNotifikasiResponse(item)); err != nil {
+				return err
+			}
+			lastId = item.Id
+		}
+		if len(notifikasis) == batasStreamNotifikasi {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-service.selesai:
+			return nil
+		case <-heartbeat.C:
+			if err := ping(); err != nil {
+				return err
+			}
+		case <-poll.C:
+		}
+	}
+}
+
+func (service *NotifikasiServiceImpl) TutupStream() {
+	service.tutup.Do(func() { close(service.selesai) })
+}
+
+func (service *NotifikasiServiceImpl) findSetelah(ctx context.Context, userId int, lastId int64) ([]domain.Notifikasi, error) {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+	return service.NotifikasiRepository.FindSetelah(ctx, tx, userId, lastId, batasStreamNotifikasi)
+}
+
+func (service *NotifikasiServiceImpl) findLastId(ctx context.Context, userId int) (int64, error) {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer helper.CommitOrRollback(tx)
+	return service.NotifikasiRepository.FindLastId(ctx, tx, userId)
+}
+
+// userIdNotifikasi inbox selalu milik user yang sedang login
+func userIdNotifikasi(ctx context.Context) (int, error) {
+	claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	if !ok || claims.UserId == 0 {
+		return 0, web.NewForbiddenError("notifikasi membutuhkan user yang login")
+	}
+	return claims.UserId, nil
+}
+
+// saringPenerimaNotifikasi membuang duplikat dan user pengirim dengan urutan tetap
+func saringPenerimaNotifikasi(userIds []int, pengirimId int) []int {
+	seen := make(map[int]bool, len(userIds))
+	result := make([]int, 0, len(userIds))
+	for _, userId := range userIds {
+		if userId == pengirimId || seen[userId] {
+			continue
+		}
+		seen[userId] = true
+		result = append(result, userId)
+	}
+	return result
+}
+
+func toNotifikasiResponse(item domain.Notifikasi) notifikasi.NotifikasiResponse {
+	response := notifikasi.NotifikasiResponse{
+		Id:         item.Id,
+		Jenis:      item.Jenis,
+		Judul:      item.Judul,
+		Pesan:      item.Pesan,
+		EntityType: item.EntityType,
+		EntityId:   item.EntityId,
+		KodeOpd:    item.KodeOpd,
+		Tahun:      item.Tahun,
+		Url:        item.Url,
+		Dibaca:     item.DibacaAt.Valid,
+		CreatedAt:  item.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if item.DibacaAt.Valid {
+		response.DibacaAt = item.DibacaAt.Time.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSaringPenerimaNotifikasi(t *testing.T) {
+	tests := []struct {
+		name       string
+		userIds    []int
+		pengirimId int
+		want       []int
+	}{
+		{name: "tanpa penerima", want: []int{}},
+		{name: "pengirim dibuang", userIds: []int{1, 2, 3}, pengirimId: 2, want: []int{1, 3}},
+		{name: "duplikat dibuang", userIds: []int{3, 1, 3, 1}, want: []int{3, 1}},
+		{name: "hanya pengirim", userIds: []int{5}, pengirimId: 5, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := saringPenerimaNotifikasi(tt.userIds, tt.pengirimId); !slices.Equal(got, tt.want) {
+				t.Errorf("saringPenerimaNotifikasi(%v, %d) = %v, want %v", tt.userIds, tt.pengirimId, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifikasiTransisiRekin(t *testing.T) {
+	rekin := domain.StatusRekinPersetujuan{
+		Id:                 "REKIN-1",
+		NamaRencanaKinerja: "Meningkatnya layanan",
+		PegawaiId:          "199001",
+		NamaPegawai:        "Budi",
+	}
+	tests := []struct {
+		name        string
+		aksi        string
+		status      string
+		catatan     string
+		wantNip     string
+		wantDiPesan string
+	}{
+		{name: "ajukan ke atasan", aksi: domain.AksiRekinAjukan, status: domain.StatusRekinDiajukan, wantNip: "198001", wantDiPesan: "Budi mengajukan"},
+		{name: "setujui ke pemilik", aksi: domain.AksiRekinSetujui, status: domain.StatusRekinDisetujui, wantNip: "199001", wantDiPesan: "berstatus disetujui"},
+		{name: "kembalikan dengan catatan", aksi: domain.AksiRekinKembalikan, status: domain.StatusRekinDikembalikan, catatan: "target kurang", wantNip: "199001", wantDiPesan: ": target kurang"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rekin.Status = tt.status
+			notifikasi, penerima := notifikasiTransisiRekin(tt.aksi, rekin, "198001", tt.catatan)
+			if !slices.Equal(penerima.Nips, []string{tt.wantNip}) || penerima.KodeOpd != "" {
+				t.Errorf("penerima = %+v, want nip %s", penerima, tt.wantNip)
+			}
+			if !strings.Contains(notifikasi.Pesan, tt.wantDiPesan) {
+				t.Errorf("pesan = %q, want mengandung %q", notifikasi.Pesan, tt.wantDiPesan)
+			}
+			if notifikasi.EntityId != rekin.Id || notifikasi.Url != "/persetujuan_rekin/detail/REKIN-1" {
+				t.Errorf("entity = %s url = %s", notifikasi.EntityId, notifikasi.Url)
+			}
+		})
+	}
+}
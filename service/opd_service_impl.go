@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 
 	"ekak_kabupaten_madiun/model/web/lembaga"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
@@ -33,7 +34,7 @@ func NewOpdServiceImpl(opdRepository repository.OpdRepository, lembagaRepository
 }
 
 func (service *OpdServiceImpl) Create(ctx context.Context, request opdmaster.OpdCreateRequest) (opdmaster.OpdResponse, error) {
-	err := service.Validator.Struct(request)
+	err := helper.ValidationError(service.Validator.Struct(request))
 	if err != nil {
 		return opdmaster.OpdResponse{}, err
 	}
@@ -47,7 +48,7 @@ func (service *OpdServiceImpl) Create(ctx context.Context, request opdmaster.Opd
 	// Tambahkan validasi ID Lembaga
 	_, err = service.LembagaRepository.FindById(ctx, tx, request.IdLembaga)
 	if err != nil {
-		return opdmaster.OpdResponse{}, fmt.Errorf("id lembaga tidak ditemukan")
+		return opdmaster.OpdResponse{}, web.NewNotFoundError("id lembaga tidak ditemukan")
 	}
 
 	uuid := uuid.New()
@@ -102,6 +103,10 @@ func (service *OpdServiceImpl) Create(ctx context.Context, request opdmaster.Opd
 }
 
 func (service *OpdServiceImpl) Update(ctx context.Context, request opdmaster.OpdUpdateRequest) (opdmaster.OpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return opdmaster.OpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return opdmaster.OpdResponse{}, err
@@ -117,7 +122,7 @@ func (service *OpdServiceImpl) Update(ctx context.Context, request opdmaster.Opd
 	// Tambahkan validasi ID Lembaga
 	_, err = service.LembagaRepository.FindById(ctx, tx, request.IdLembaga)
 	if err != nil {
-		return opdmaster.OpdResponse{}, fmt.Errorf("id lembaga tidak ditemukan")
+		return opdmaster.OpdResponse{}, web.NewNotFoundError("id lembaga tidak ditemukan")
 	}
 
 	opd.KodeOpd = request.KodeOpd
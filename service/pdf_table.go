@@ -0,0 +1,48 @@
+package service
+
+import "github.com/go-pdf/fpdf"
+
+const pdfLineHeight = 5.0
+
+// writePdfTabelHeader menulis header tabel tebal berlatar abu-abu dengan ukuran font yang sedang aktif
+func writePdfTabelHeader(pdf *fpdf.Fpdf, tr func(string) string, widths []float64, headers []string) {
+	size, _ := pdf.GetFontSize()
+	pdf.SetFont("Times", "B", size)
+	pdf.SetFillColor(217, 217, 217)
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 7, tr(header), "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Times", "", size)
+}
+
+// writePdfTabelBaris menulis satu baris tabel dengan teks terbungkus, tinggi baris mengikuti kolom terpanjang
+func writePdfTabelBaris(pdf *fpdf.Fpdf, tr func(string) string, widths []float64, values []string, aligns string) {
+	lines := make([][]string, len(values))
+	maxLines := 1
+	for i, value := range values {
+		lines[i] = pdf.SplitText(tr(value), widths[i]-2)
+		if len(lines[i]) > maxLines {
+			maxLines = len(lines[i])
+		}
+	}
+	height := float64(maxLines) * pdfLineHeight
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+height > pageHeight-bottom {
+		pdf.AddPage()
+	}
+
+	x, y := pdf.GetXY()
+	for i := range values {
+		pdf.Rect(x, y, widths[i], height, "D")
+		for j, line := range lines[i] {
+			pdf.SetXY(x, y+float64(j)*pdfLineHeight)
+			pdf.CellFormat(widths[i], pdfLineHeight, line, "", 0, string(aligns[i]), false, 0, "")
+		}
+		x += widths[i]
+	}
+	left, _, _, _ := pdf.GetMargins()
+	pdf.SetXY(left, y+height)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/web/pegawai"
 )
 
@@ -10,6 +11,6 @@ type PegawaiService interface {
 	Update(ctx context.Context, request pegawai.PegawaiUpdateRequest) (pegawai.PegawaiResponse, error)
 	Delete(ctx context.Context, id string) error
 	FindById(ctx context.Context, id string) (pegawai.PegawaiResponse, error)
-	FindAll(ctx context.Context, kodeOpd string, nip string) ([]pegawai.PegawaiResponse, error)
+	FindAll(ctx context.Context, query domain.ListQuery) ([]pegawai.PegawaiResponse, int, error)
 	TambahJabatan(ctx context.Context, request pegawai.TambahJabatanRequest) (pegawai.PegawaiResponse, error)
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pegawai"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -35,6 +37,10 @@ func NewPegawaiServiceImpl(
 }
 
 func (service *PegawaiServiceImpl) Create(ctx context.Context, request pegawai.PegawaiCreateRequest) (pegawai.PegawaiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pegawai.PegawaiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pegawai.PegawaiResponse{}, err
@@ -44,7 +50,7 @@ func (service *PegawaiServiceImpl) Create(ctx context.Context, request pegawai.P
 	existingPegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, request.Nip)
 	if err == nil {
 		// Jika tidak ada error, berarti NIP sudah ada
-		return pegawai.PegawaiResponse{}, fmt.Errorf("NIP %s sudah digunakan oleh pegawai %s", request.Nip, existingPegawai.NamaPegawai)
+		return pegawai.PegawaiResponse{}, web.NewConflictError(fmt.Sprintf("NIP %s sudah digunakan oleh pegawai %s", request.Nip, existingPegawai.NamaPegawai))
 	}
 	// Jika error adalah sql.ErrNoRows, berarti NIP belum ada (OK)
 	if err != sql.ErrNoRows {
@@ -76,6 +82,10 @@ func (service *PegawaiServiceImpl) Create(ctx context.Context, request pegawai.P
 }
 
 func (service *PegawaiServiceImpl) Update(ctx context.Context, request pegawai.PegawaiUpdateRequest) (pegawai.PegawaiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pegawai.PegawaiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pegawai.PegawaiResponse{}, err
@@ -93,7 +103,7 @@ func (service *PegawaiServiceImpl) Update(ctx context.Context, request pegawai.P
 		existingPegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, request.Nip)
 		if err == nil {
 			// Jika tidak ada error, berarti NIP sudah digunakan oleh pegawai lain
-			return pegawai.PegawaiResponse{}, fmt.Errorf("NIP %s sudah digunakan oleh pegawai %s", request.Nip, existingPegawai.NamaPegawai)
+			return pegawai.PegawaiResponse{}, web.NewConflictError(fmt.Sprintf("NIP %s sudah digunakan oleh pegawai %s", request.Nip, existingPegawai.NamaPegawai))
 		}
 		// Jika error adalah sql.ErrNoRows, berarti NIP belum ada (OK)
 		if err != sql.ErrNoRows {
@@ -123,7 +133,7 @@ func (service *PegawaiServiceImpl) Delete(ctx context.Context, id string) error
 		return err
 	}
 	if pegawais.Id == "" {
-		return fmt.Errorf("pegawai dengan id %s tidak ditemukan", id)
+		return web.NewNotFoundError(fmt.Sprintf("pegawai dengan id %s tidak ditemukan", id))
 	}
 
 	err = service.pegawaiRepository.Delete(ctx, tx, id)
@@ -157,19 +167,19 @@ func (service *PegawaiServiceImpl) FindById(ctx context.Context, id string) (peg
 	return helper.ToPegawaiResponse(pegawais), nil
 }
 
-func (service *PegawaiServiceImpl) FindAll(ctx context.Context, kodeOpd string, nip string) ([]pegawai.PegawaiResponse, error) {
+func (service *PegawaiServiceImpl) FindAll(ctx context.Context, query domain.ListQuery) ([]pegawai.PegawaiResponse, int, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
-		return []pegawai.PegawaiResponse{}, err
+		return []pegawai.PegawaiResponse{}, 0, err
 	}
 	defer helper.CommitOrRollback(tx)
 
-	pegawais, err := service.pegawaiRepository.FindAll(ctx, tx, kodeOpd, nip)
+	pegawais, total, err := service.pegawaiRepository.FindAllPaged(ctx, tx, query)
 	if err != nil {
-		return []pegawai.PegawaiResponse{}, err
+		return []pegawai.PegawaiResponse{}, 0, err
 	}
 
-	return helper.ToPegawaiResponses(pegawais), nil
+	return helper.ToPegawaiResponses(pegawais), total, nil
 }
 
 func (service *PegawaiServiceImpl) FindPegawaiWithJabatan(ctx context.Context, tx *sql.Tx, nip string) (pegawai.PegawaiResponse, error) {
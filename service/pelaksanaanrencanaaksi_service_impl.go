@@ -15,18 +15,28 @@ import (
 type PelaksanaanRencanaAksiServiceImpl struct {
 	PelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository
 	RencanaAksiRepository            repository.RencanaAksiRepository
+	PersetujuanRekinRepository       repository.PersetujuanRekinRepository
+	RencanaKinerjaRepository         repository.RencanaKinerjaRepository
+	LockDataRepository               repository.LockDataRepository
 	DB                               *sql.DB
 }
 
-func NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, rencanaAksiRepository repository.RencanaAksiRepository, DB *sql.DB) *PelaksanaanRencanaAksiServiceImpl {
+func NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, rencanaAksiRepository repository.RencanaAksiRepository, persetujuanRekinRepository repository.PersetujuanRekinRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB) *PelaksanaanRencanaAksiServiceImpl {
 	return &PelaksanaanRencanaAksiServiceImpl{
 		PelaksanaanRencanaAksiRepository: pelaksanaanRencanaAksiRepository,
 		RencanaAksiRepository:            rencanaAksiRepository,
+		PersetujuanRekinRepository:       persetujuanRekinRepository,
+		RencanaKinerjaRepository:         rencanaKinerjaRepository,
+		LockDataRepository:               lockDataRepository,
 		DB:                               DB,
 	}
 }
 
 func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, request rencanaaksi.PelaksanaanRencanaAksiCreateRequest) (rencanaaksi.PelaksanaanRencanaAksiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
@@ -35,12 +45,12 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, re
 
 	// Validasi bulan
 	if request.Bulan < 1 || request.Bulan > 12 {
-		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("bulan harus antara 1 dan 12")
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, web.NewBadRequestError("bulan harus antara 1 dan 12")
 	}
 
 	//validasi bobot
 	if request.Bobot < 1 {
-		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("bobot harus diisi lebih dari 0")
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, web.NewBadRequestError("bobot harus diisi lebih dari 0")
 	}
 
 	// PERBAIKAN: Lock rencana_aksi untuk mencegah race condition
@@ -48,6 +58,15 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, re
 	if err != nil {
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan RencanaAksi: %v", err)
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
+	if err := checkStatusRekin(ctx, tx, service.PersetujuanRekinRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
 
 	// Check dengan SELECT FOR UPDATE
 	exists, err := service.PelaksanaanRencanaAksiRepository.ExistsByRencanaAksiIdAndBulan(ctx, tx, request.RencanaAksiId, request.Bulan)
@@ -55,7 +74,7 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, re
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal memeriksa keberadaan bulan: %v", err)
 	}
 	if exists {
-		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("bulan %d sudah ada untuk rencana aksi ini", request.Bulan)
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, web.NewConflictError(fmt.Sprintf("bulan %d sudah ada untuk rencana aksi ini", request.Bulan))
 	}
 
 	// Periksa total bobot yang sudah ada untuk rencana kinerja ini
@@ -67,7 +86,7 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, re
 	sisaBobot := 100 - totalBobot
 
 	if request.Bobot > sisaBobot {
-		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("bobot melebihi sisa yang tersedia. Sisa bobot: %d", sisaBobot)
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, web.NewBadRequestError(fmt.Sprintf("bobot melebihi sisa yang tersedia. Sisa bobot: %d", sisaBobot))
 	}
 
 	newID := fmt.Sprintf("%05d", rand.Intn(100000))
@@ -102,6 +121,10 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, re
 }
 
 func (service *PelaksanaanRencanaAksiServiceImpl) Update(ctx context.Context, request rencanaaksi.PelaksanaanRencanaAksiUpdateRequest) (rencanaaksi.PelaksanaanRencanaAksiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan PelaksanaanRencanaAksi: %v", err)
@@ -115,7 +138,7 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Update(ctx context.Context, re
 
 	//validasi bobot
 	if request.Bobot < 1 {
-		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("bobot harus diisi lebih dari 0")
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, web.NewBadRequestError("bobot harus diisi lebih dari 0")
 	}
 
 	// Dapatkan PelaksanaanRencanaAksi yang ada
@@ -146,6 +169,15 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Update(ctx context.Context, re
 		}
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan PelaksanaanRencanaAksi: %v", err)
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
+	if err := checkStatusRekin(ctx, tx, service.PersetujuanRekinRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
 
 	// Periksa total bobot yang sudah ada untuk rencana kinerja ini
 	totalBobot, err := service.RencanaAksiRepository.GetTotalBobotForRencanaKinerja(ctx, tx, rencanaAksi.RencanaKinerjaId)
@@ -200,7 +232,7 @@ func (service *PelaksanaanRencanaAksiServiceImpl) FindById(ctx context.Context,
 	pelaksanaan, err := service.PelaksanaanRencanaAksiRepository.FindById(ctx, tx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("pelaksanaan rencana aksi dengan ID %s tidak ditemukan", id)
+			return rencanaaksi.PelaksanaanRencanaAksiResponse{}, web.NewNotFoundError(fmt.Sprintf("pelaksanaan rencana aksi dengan ID %s tidak ditemukan", id))
 		}
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan pelaksanaan rencana aksi: %v", err)
 	}
@@ -210,6 +242,9 @@ func (service *PelaksanaanRencanaAksiServiceImpl) FindById(ctx context.Context,
 	if err != nil {
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan RencanaAksi: %v", err)
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
 
 	// Hitung total bobot dan sisa bobot
 	totalBobot, err := service.RencanaAksiRepository.GetTotalBobotForRencanaKinerja(ctx, tx, rencanaAksi.RencanaKinerjaId)
@@ -252,6 +287,9 @@ func (service *PelaksanaanRencanaAksiServiceImpl) FindByRencanaAksiId(ctx contex
 	if err != nil {
 		return nil, fmt.Errorf("gagal mendapatkan RencanaAksi: %v", err)
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return nil, err
+	}
 
 	// Hitung total bobot dan sisa bobot
 	totalBobot, err := service.RencanaAksiRepository.GetTotalBobotForRencanaKinerja(ctx, tx, rencanaAksi.RencanaKinerjaId)
@@ -288,6 +326,20 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Delete(ctx context.Context, id
 	}
 	defer tx.Rollback()
 
+	pelaksanaan, err := service.PelaksanaanRencanaAksiRepository.FindById(ctx, tx, id)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("gagal mendapatkan pelaksanaan rencana aksi: %v", err)
+	}
+	if err := checkRenaksiAccess(ctx, tx, service.RencanaKinerjaRepository, pelaksanaan.RencanaAksiId); err != nil {
+		return err
+	}
+	if err := checkRenaksiLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, pelaksanaan.RencanaAksiId); err != nil {
+		return err
+	}
+	if err := checkStatusRekinByRenaksi(ctx, tx, service.PersetujuanRekinRepository, pelaksanaan.RencanaAksiId); err != nil {
+		return err
+	}
+
 	err = service.PelaksanaanRencanaAksiRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus pelaksanaan rencana aksi: %v", err)
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/periodetahun"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -43,8 +44,8 @@ func (service *PeriodeServiceImpl) validatePeriodeOverlap(ctx context.Context, t
 	}
 
 	if len(existingPeriodes) > 0 {
-		return fmt.Errorf("periode tahun %s-%s dengan jenis periode %s overlap dengan periode yang sudah ada: %s-%s (jenis periode: %s)",
-			tahunAwal, tahunAkhir, jenisPeriode, existingPeriodes[0].TahunAwal, existingPeriodes[0].TahunAkhir, existingPeriodes[0].JenisPeriode)
+		return web.NewConflictError(fmt.Sprintf("periode tahun %s-%s dengan jenis periode %s overlap dengan periode yang sudah ada: %s-%s (jenis periode: %s)",
+			tahunAwal, tahunAkhir, jenisPeriode, existingPeriodes[0].TahunAwal, existingPeriodes[0].TahunAkhir, existingPeriodes[0].JenisPeriode))
 	}
 	return nil
 }
@@ -56,13 +57,17 @@ func (service *PeriodeServiceImpl) validatePeriodeOverlapExcludeCurrent(ctx cont
 	}
 
 	if len(existingPeriodes) > 0 {
-		return fmt.Errorf("periode tahun %s-%s dengan jenis periode %s overlap dengan periode yang sudah ada: %s-%s (jenis periode: %s)",
-			tahunAwal, tahunAkhir, jenisPeriode, existingPeriodes[0].TahunAwal, existingPeriodes[0].TahunAkhir, existingPeriodes[0].JenisPeriode)
+		return web.NewConflictError(fmt.Sprintf("periode tahun %s-%s dengan jenis periode %s overlap dengan periode yang sudah ada: %s-%s (jenis periode: %s)",
+			tahunAwal, tahunAkhir, jenisPeriode, existingPeriodes[0].TahunAwal, existingPeriodes[0].TahunAkhir, existingPeriodes[0].JenisPeriode))
 	}
 	return nil
 }
 
 func (service *PeriodeServiceImpl) Create(ctx context.Context, request periodetahun.PeriodeCreateRequest) (periodetahun.PeriodeResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return periodetahun.PeriodeResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return periodetahun.PeriodeResponse{}, err
@@ -92,16 +97,16 @@ func (service *PeriodeServiceImpl) Create(ctx context.Context, request periodeta
 	// Generate dan simpan tahun-tahun periode
 	tahunAwal, err := strconv.Atoi(request.TahunAwal)
 	if err != nil {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("invalid tahun awal format: %v", err)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("invalid tahun awal format: %v", err))
 	}
 
 	tahunAkhir, err := strconv.Atoi(request.TahunAkhir)
 	if err != nil {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("invalid tahun akhir format: %v", err)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("invalid tahun akhir format: %v", err))
 	}
 
 	if tahunAkhir < tahunAwal {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("tahun akhir (%d) harus lebih besar dari tahun awal (%d)", tahunAkhir, tahunAwal)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("tahun akhir (%d) harus lebih besar dari tahun awal (%d)", tahunAkhir, tahunAwal))
 	}
 
 	var tahunList []string
@@ -130,6 +135,10 @@ func (service *PeriodeServiceImpl) Create(ctx context.Context, request periodeta
 }
 
 func (service *PeriodeServiceImpl) Update(ctx context.Context, request periodetahun.PeriodeUpdateRequest) (periodetahun.PeriodeResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return periodetahun.PeriodeResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return periodetahun.PeriodeResponse{}, err
@@ -168,16 +177,16 @@ func (service *PeriodeServiceImpl) Update(ctx context.Context, request periodeta
 	// Generate dan simpan tahun-tahun periode baru
 	tahunAwal, err := strconv.Atoi(request.TahunAwal)
 	if err != nil {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("invalid tahun awal format: %v", err)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("invalid tahun awal format: %v", err))
 	}
 
 	tahunAkhir, err := strconv.Atoi(request.TahunAkhir)
 	if err != nil {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("invalid tahun akhir format: %v", err)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("invalid tahun akhir format: %v", err))
 	}
 
 	if tahunAkhir < tahunAwal {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("tahun akhir (%d) harus lebih besar dari tahun awal (%d)", tahunAkhir, tahunAwal)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("tahun akhir (%d) harus lebih besar dari tahun awal (%d)", tahunAkhir, tahunAwal))
 	}
 
 	var tahunList []string
@@ -217,12 +226,12 @@ func (service *PeriodeServiceImpl) FindByTahun(ctx context.Context, tahun string
 
 	tahunAwal, err := strconv.Atoi(periode.TahunAwal)
 	if err != nil {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("invalid tahun awal format: %v", err)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("invalid tahun awal format: %v", err))
 	}
 
 	tahunAkhir, err := strconv.Atoi(periode.TahunAkhir)
 	if err != nil {
-		return periodetahun.PeriodeResponse{}, fmt.Errorf("invalid tahun akhir format: %v", err)
+		return periodetahun.PeriodeResponse{}, web.NewBadRequestError(fmt.Sprintf("invalid tahun akhir format: %v", err))
 	}
 
 	var tahunList []string
@@ -256,12 +265,12 @@ func (service *PeriodeServiceImpl) FindAll(ctx context.Context, jenis_periode st
 		// Generate tahunList seperti di FindByTahun
 		tahunAwal, err := strconv.Atoi(periode.TahunAwal)
 		if err != nil {
-			return nil, fmt.Errorf("invalid tahun awal format: %v", err)
+			return nil, web.NewBadRequestError(fmt.Sprintf("invalid tahun awal format: %v", err))
 		}
 
 		tahunAkhir, err := strconv.Atoi(periode.TahunAkhir)
 		if err != nil {
-			return nil, fmt.Errorf("invalid tahun akhir format: %v", err)
+			return nil, web.NewBadRequestError(fmt.Sprintf("invalid tahun akhir format: %v", err))
 		}
 
 		var tahunList []string
@@ -11,23 +11,38 @@ import (
 
 type PermasalahanRekinServiceImpl struct {
 	PermasalahanRekinRepository repository.PermasalahanRekinRepository
+	RencanaKinerjaRepository    repository.RencanaKinerjaRepository
+	LockDataRepository          repository.LockDataRepository
 	DB                          *sql.DB
 }
 
-func NewPermasalahanRekinServiceImpl(permasalahanRekinRepository repository.PermasalahanRekinRepository, DB *sql.DB) *PermasalahanRekinServiceImpl {
+func NewPermasalahanRekinServiceImpl(permasalahanRekinRepository repository.PermasalahanRekinRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB) *PermasalahanRekinServiceImpl {
 	return &PermasalahanRekinServiceImpl{
 		PermasalahanRekinRepository: permasalahanRekinRepository,
+		RencanaKinerjaRepository:    rencanaKinerjaRepository,
+		LockDataRepository:          lockDataRepository,
 		DB:                          DB,
 	}
 }
 
 func (service *PermasalahanRekinServiceImpl) Create(ctx context.Context, request permasalahan.PermasalahanRekinCreateRequest) (permasalahan.PermasalahanRekinResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return permasalahan.PermasalahanRekinResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, request.RekinId); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+	if err := checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, request.RekinId); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+
 	permasalahanDomain := domain.PermasalahanRekin{
 		Id:                helper.GenerateRandomNumber(6),
 		RekinId:           request.RekinId,
@@ -53,12 +68,20 @@ func (service *PermasalahanRekinServiceImpl) Create(ctx context.Context, request
 }
 
 func (service *PermasalahanRekinServiceImpl) Update(ctx context.Context, request permasalahan.PermasalahanRekinUpdateRequest) (permasalahan.PermasalahanRekinResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return permasalahan.PermasalahanRekinResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := service.checkPermasalahanAccess(ctx, tx, request.Id); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+
 	permasalahanDomain := domain.PermasalahanRekin{
 		Id:                request.Id,
 		Permasalahan:      request.Permasalahan,
@@ -88,6 +111,10 @@ func (service *PermasalahanRekinServiceImpl) Delete(ctx context.Context, id int)
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := service.checkPermasalahanAccess(ctx, tx, id); err != nil {
+		return err
+	}
+
 	err = service.PermasalahanRekinRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return err
@@ -103,6 +130,12 @@ func (service *PermasalahanRekinServiceImpl) FindAll(ctx context.Context, rekinI
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if rekinId != nil {
+		if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, *rekinId); err != nil {
+			return nil, err
+		}
+	}
+
 	permasalahanList, err := service.PermasalahanRekinRepository.FindAll(ctx, tx, rekinId)
 	if err != nil {
 		return nil, err
@@ -134,6 +167,9 @@ func (service *PermasalahanRekinServiceImpl) FindById(ctx context.Context, id in
 	if err != nil {
 		return permasalahan.PermasalahanRekinResponse{}, err
 	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, result.RekinId); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
 
 	return permasalahan.PermasalahanRekinResponse{
 		Id:                result.Id,
@@ -144,3 +180,18 @@ func (service *PermasalahanRekinServiceImpl) FindById(ctx context.Context, id in
 		JenisPermasalahan: result.JenisPermasalahan,
 	}, nil
 }
+
+// checkPermasalahanAccess cek akses OPD dan kunci rekin pemilik permasalahan, id yang tidak ditemukan dibiarkan ke repository
+func (service *PermasalahanRekinServiceImpl) checkPermasalahanAccess(ctx context.Context, tx *sql.Tx, id int) error {
+	result, err := service.PermasalahanRekinRepository.FindById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkRekinAccess(ctx, tx, service.RencanaKinerjaRepository, result.RekinId); err != nil {
+		return err
+	}
+	return checkRekinLock(ctx, tx, service.RencanaKinerjaRepository, service.LockDataRepository, result.RekinId)
+}
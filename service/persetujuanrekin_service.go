@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/persetujuanrekin"
+)
+
+type PersetujuanRekinService interface {
+	// Transisi menjalankan satu aksi alur persetujuan rencana kinerja
+	Transisi(ctx context.Context, request persetujuanrekin.PersetujuanRekinRequest) (persetujuanrekin.StatusRekinResponse, error)
+	FindByRekinId(ctx context.Context, rekinId string) (persetujuanrekin.StatusRekinResponse, error)
+	// FindMenunggu rekin bawahan yang diajukan ke user yang sedang login
+	FindMenunggu(ctx context.Context, tahun string) ([]persetujuanrekin.StatusRekinResponse, error)
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/persetujuanrekin"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type PersetujuanRekinServiceImpl struct {
+	PersetujuanRekinRepository repository.PersetujuanRekinRepository
+	AuditLogRepository         repository.AuditLogRepository
+	NotifikasiService          NotifikasiService
+	DB                         *sql.DB
+	Validate                   *validator.Validate
+}
+
+func NewPersetujuanRekinServiceImpl(persetujuanRekinRepository repository.PersetujuanRekinRepository, auditLogRepository repository.AuditLogRepository, notifikasiService NotifikasiService, DB *sql.DB, validate *validator.Validate) *PersetujuanRekinServiceImpl {
+	return &PersetujuanRekinServiceImpl{
+		PersetujuanRekinRepository: persetujuanRekinRepository,
+		AuditLogRepository:         auditLogRepository,
+		NotifikasiService:          notifikasiService,
+		DB:                         DB,
+		Validate:                   validate,
+	}
+}
+
+// urutanAksiRekin urutan aksi pada aksi_tersedia
+var urutanAksiRekin = []string{
+	domain.AksiRekinAjukan,
+	domain.AksiRekinSetujui,
+	domain.AksiRekinKembalikan,
+	domain.AksiRekinFinalkan,
+	domain.AksiRekinBuka,
+}
+
+func (service *PersetujuanRekinServiceImpl) Transisi(ctx context.Context, request persetujuanrekin.PersetujuanRekinRequest) (persetujuanrekin.StatusRekinResponse, error) {
+	request.Catatan = strings.TrimSpace(request.Catatan)
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	if !ok || claims.Nip == "" {
+		return persetujuanrekin.StatusRekinResponse{}, web.NewForbiddenError("aksi persetujuan rencana kinerja membutuhkan user dengan NIP")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	// status, riwayat, audit dan notifikasi harus tersimpan bersama, commit hanya jika semua berhasil
+	defer tx.Rollback()
+
+	rekin, nipAtasan, err := service.findRekin(ctx, tx, request.RekinId)
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	statusAwal := domain.NormalisasiStatusRekin(rekin.Status)
+
+	transisi, err := validasiTransisiRekin(request.Aksi, statusAwal, aktorRekin(claims, rekin, nipAtasan), request.Catatan)
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	// tanpa atasan di struktur organisasi rekin yang diajukan tidak akan pernah bisa disetujui
+	if request.Aksi == domain.AksiRekinAjukan && nipAtasan == "" {
+		return persetujuanrekin.StatusRekinResponse{}, web.NewConflictError(fmt.Sprintf("atasan pegawai %s belum ditetapkan di struktur organisasi tahun %s", rekin.PegawaiId, rekin.Tahun))
+	}
+
+	if err := service.PersetujuanRekinRepository.UpdateStatus(ctx, tx, rekin.Id, transisi.Ke); err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	_, err = service.PersetujuanRekinRepository.CreateRiwayat(ctx, tx, domain.RiwayatStatusRekin{
+		RekinId:     rekin.Id,
+		Aksi:        request.Aksi,
+		StatusAwal:  statusAwal,
+		StatusAkhir: transisi.Ke,
+		Catatan:     request.Catatan,
+		NipAktor:    claims.Nip,
+		NipAtasan:   nipAtasan,
+		KodeOpd:     rekin.KodeOpd,
+		Tahun:       rekin.Tahun,
+	})
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+
+	before := rekin
+	rekin.Status = transisi.Ke
+	err = recordAuditLog(ctx, tx, service.AuditLogRepository, domain.AuditLog{
+		Action:     auditActionTransisiRekin(request.Aksi),
+		EntityType: domain.AuditEntityRencanaKinerja,
+		EntityId:   rekin.Id,
+		KodeOpd:    rekin.KodeOpd,
+		Tahun:      rekin.Tahun,
+	}, before, rekin)
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+
+	notifikasi, penerima := notifikasiTransisiRekin(request.Aksi, rekin, nipAtasan, request.Catatan)
+	if err := service.NotifikasiService.Kirim(ctx, tx, notifikasi, penerima); err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+
+	response, err := service.toStatusRekinResponse(ctx, tx, claims, rekin, nipAtasan)
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	return response, nil
+}
+
+func (service *PersetujuanRekinServiceImpl) FindByRekinId(ctx context.Context, rekinId string) (persetujuanrekin.StatusRekinResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rekin, nipAtasan, err := service.findRekin(ctx, tx, rekinId)
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	claims, _ := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	return service.toStatusRekinResponse(ctx, tx, claims, rekin, nipAtasan)
+}
+
+func (service *PersetujuanRekinServiceImpl) FindMenunggu(ctx context.Context, tahun string) ([]persetujuanrekin.StatusRekinResponse, error) {
+	claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	if !ok || claims.Nip == "" {
+		return nil, web.NewForbiddenError("daftar persetujuan rencana kinerja membutuhkan user dengan NIP")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rekins, err := service.PersetujuanRekinRepository.FindByAtasan(ctx, tx, claims.Nip, tahun, domain.StatusRekinDiajukan)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]persetujuanrekin.StatusRekinResponse, 0, len(rekins))
+	for _, rekin := range rekins {
+		responses = append(responses, toStatusRekinResponse(claims, rekin, claims.Nip))
+	}
+	return responses, nil
+}
+
+// findRekin rekin beserta NIP atasan pemilik rekin, setelah cek akses OPD
+func (service *PersetujuanRekinServiceImpl) findRekin(ctx context.Context, tx *sql.Tx, rekinId string) (domain.StatusRekinPersetujuan, string, error) {
+	rekin, err := service.PersetujuanRekinRepository.FindRekin(ctx, tx, rekinId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return rekin, "", web.NewNotFoundError(fmt.Sprintf("rencana kinerja %s tidak ditemukan", rekinId))
+	}
+	if err != nil {
+		return rekin, "", err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, rekin.KodeOpd); err != nil {
+		return rekin, "", err
+	}
+	nipAtasan, err := service.PersetujuanRekinRepository.FindNipAtasan(ctx, tx, rekin.PegawaiId, rekin.KodeOpd, rekin.Tahun)
+	if err != nil {
+		return rekin, "", err
+	}
+	return rekin, nipAtasan, nil
+}
+
+func (service *PersetujuanRekinServiceImpl) toStatusRekinResponse(ctx context.Context, tx *sql.Tx, claims web.JWTClaim, rekin domain.StatusRekinPersetujuan, nipAtasan string) (persetujuanrekin.StatusRekinResponse, error) {
+	riwayats, err := service.PersetujuanRekinRepository.FindRiwayat(ctx, tx, rekin.Id)
+	if err != nil {
+		return persetujuanrekin.StatusRekinResponse{}, err
+	}
+	response := toStatusRekinResponse(claims, rekin, nipAtasan)
+	response.Riwayat = make([]persetujuanrekin.RiwayatStatusRekinResponse, 0, len(riwayats))
+	for _, riwayat := range riwayats {
+		response.Riwayat = append(response.Riwayat, persetujuanrekin.RiwayatStatusRekinResponse{
+			Id:          riwayat.Id,
+			Aksi:        riwayat.Aksi,
+			StatusAwal:  riwayat.StatusAwal,
+			StatusAkhir: riwayat.StatusAkhir,
+			Catatan:     riwayat.Catatan,
+			NipAktor:    riwayat.NipAktor,
+			NamaAktor:   riwayat.NamaAktor,
+			NipAtasan:   riwayat.NipAtasan,
+			CreatedAt:   riwayat.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return response, nil
+}
+
+func toStatusRekinResponse(claims web.JWTClaim, rekin domain.StatusRekinPersetujuan, nipAtasan string) persetujuanrekin.StatusRekinResponse {
+	status := domain.NormalisasiStatusRekin(rekin.Status)
+	return persetujuanrekin.StatusRekinResponse{
+		RekinId:            rekin.Id,
+		NamaRencanaKinerja: rekin.NamaRencanaKinerja,
+		Status:             status,
+		KodeOpd:            rekin.KodeOpd,
+		Tahun:              rekin.Tahun,
+		PegawaiId:          rekin.PegawaiId,
+		NamaPegawai:        rekin.NamaPegawai,
+		NipAtasan:          nipAtasan,
+		Terkunci:           domain.StatusRekinTerkunci(status),
+		AksiTersedia:       aksiTersediaRekin(status, aktorRekin(claims, rekin, nipAtasan)),
+	}
+}
+
+// aktorRekin peran user terhadap rekin. super_admin dapat bertindak sebagai atasan
+// agar rekin tidak tertahan ketika struktur organisasi belum lengkap.
+func aktorRekin(claims web.JWTClaim, rekin domain.StatusRekinPersetujuan, nipAtasan string) map[string]bool {
+	aktor := make(map[string]bool)
+	if claims.Nip != "" && claims.Nip == rekin.PegawaiId {
+		aktor[domain.AktorRekinPemilik] = true
+	}
+	if claims.Nip != "" && claims.Nip == nipAtasan {
+		aktor[domain.AktorRekinAtasan] = true
+	}
+	if helper.HasAnyRole(claims.Roles, helper.RoleSuperAdmin) {
+		aktor[domain.AktorRekinAtasan] = true
+		aktor[domain.AktorRekinAdmin] = true
+	}
+	if helper.HasAnyRole(claims.Roles, helper.RoleAdminOpd) && claims.KodeOpd == rekin.KodeOpd {
+		aktor[domain.AktorRekinAdmin] = true
+	}
+	return aktor
+}
+
+// validasiTransisiRekin memeriksa aksi terhadap status rekin, peran user dan catatan
+func validasiTransisiRekin(aksi string, status string, aktor map[string]bool, catatan string) (domain.TransisiRekin, error) {
+	transisi, ok := domain.TransisiStatusRekin[aksi]
+	if !ok {
+		return transisi, web.NewBadRequestError(fmt.Sprintf("aksi %s tidak dikenal", aksi))
+	}
+	if !slices.Contains(transisi.Dari, domain.NormalisasiStatusRekin(status)) {
+		return transisi, web.NewConflictError(fmt.Sprintf("aksi %s tidak dapat dijalankan pada rencana kinerja berstatus %s", aksi, domain.NormalisasiStatusRekin(status)))
+	}
+	if !bolehTransisiRekin(transisi, aktor) {
+		return transisi, web.NewForbiddenError(fmt.Sprintf("aksi %s hanya dapat dijalankan oleh %s", aksi, strings.Join(transisi.Aktor, "/")))
+	}
+	if transisi.CatatanWajib && catatan == "" {
+		return transisi, web.NewBadRequestError(fmt.Sprintf("catatan wajib diisi untuk aksi %s", aksi))
+	}
+	return transisi, nil
+}
+
+func bolehTransisiRekin(transisi domain.TransisiRekin, aktor map[string]bool) bool {
+	for _, peran := range transisi.Aktor {
+		if aktor[peran] {
+			return true
+		}
+	}
+	return false
+}
+
+func aksiTersediaRekin(status string, aktor map[string]bool) []string {
+	aksis := make([]string, 0)
+	for _, aksi := range urutanAksiRekin {
+		transisi := domain.TransisiStatusRekin[aksi]
+		if slices.Contains(transisi.Dari, status) && bolehTransisiRekin(transisi, aktor) {
+			aksis = append(aksis, aksi)
+		}
+	}
+	return aksis
+}
+
+// notifikasiTransisiRekin rekin yang diajukan diberitahukan ke atasan, aksi lain ke pemilik rekin
+func notifikasiTransisiRekin(aksi string, rekin domain.StatusRekinPersetujuan, nipAtasan string, catatan string) (domain.Notifikasi, domain.PenerimaNotifikasi) {
+	notifikasi := domain.Notifikasi{
+		Jenis:      domain.JenisNotifikasiPersetujuanRekin,
+		Judul:      fmt.Sprintf("Rencana kinerja %s", rekin.Status),
+		Pesan:      fmt.Sprintf("Rencana kinerja \"%s\" berstatus %s", rekin.NamaRencanaKinerja, rekin.Status),
+		EntityType: domain.AuditEntityRencanaKinerja,
+		EntityId:   rekin.Id,
+		KodeOpd:    rekin.KodeOpd,
+		Tahun:      rekin.Tahun,
+		Url:        "/persetujuan_rekin/detail/" + rekin.Id,
+	}
+	if aksi == domain.AksiRekinAjukan {
+		notifikasi.Judul = "Rencana kinerja menunggu persetujuan"
+		notifikasi.Pesan = fmt.Sprintf("%s mengajukan rencana kinerja \"%s\"", rekin.NamaPegawai, rekin.NamaRencanaKinerja)
+		return notifikasi, domain.PenerimaNotifikasi{Nips: []string{nipAtasan}}
+	}
+	if catatan != "" {
+		notifikasi.Pesan += ": " + catatan
+	}
+	return notifikasi, domain.PenerimaNotifikasi{Nips: []string{rekin.PegawaiId}}
+}
+
+func auditActionTransisiRekin(aksi string) string {
+	switch aksi {
+	case domain.AksiRekinSetujui, domain.AksiRekinFinalkan:
+		return domain.AuditActionApprove
+	case domain.AksiRekinKembalikan, domain.AksiRekinBuka:
+		return domain.AuditActionReject
+	default:
+		return domain.AuditActionUpdate
+	}
+}
+
+// checkStatusRekin menolak perubahan indikator, rencana aksi dan rincian belanja milik rekin
+// yang sedang diajukan, sudah disetujui atau final. Rekin yang tidak ditemukan dibiarkan ke validasi pemanggil.
+func checkStatusRekin(ctx context.Context, tx *sql.Tx, persetujuanRekinRepository repository.PersetujuanRekinRepository, rekinId string) error {
+	if rekinId == "" {
+		return nil
+	}
+	rekin, err := persetujuanRekinRepository.FindRekin(ctx, tx, rekinId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if domain.StatusRekinTerkunci(rekin.Status) {
+		return web.NewLockedError(fmt.Sprintf("rencana kinerja %s berstatus %s, kembalikan terlebih dahulu untuk mengubah data", rekin.Id, domain.NormalisasiStatusRekin(rekin.Status)))
+	}
+	return nil
+}
+
+// checkStatusRekinByRenaksi checkStatusRekin untuk data turunan rencana aksi
+func checkStatusRekinByRenaksi(ctx context.Context, tx *sql.Tx, persetujuanRekinRepository repository.PersetujuanRekinRepository, renaksiId string) error {
+	rekinId, err := persetujuanRekinRepository.FindRekinIdByRenaksiId(ctx, tx, renaksiId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return checkStatusRekin(ctx, tx, persetujuanRekinRepository, rekinId)
+}
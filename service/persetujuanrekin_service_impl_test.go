@@ -0,0 +1,105 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestValidasiTransisiRekin(t *testing.T) {
+	pemilik := map[string]bool{domain.AktorRekinPemilik: true}
+	atasan := map[string]bool{domain.AktorRekinAtasan: true}
+	admin := map[string]bool{domain.AktorRekinAdmin: true}
+
+	tests := []struct {
+		name     string
+		aksi     string
+		status   string
+		aktor    map[string]bool
+		catatan  string
+		wantCode int
+	}{
+		{name: "pemilik ajukan draft", aksi: domain.AksiRekinAjukan, status: domain.StatusRekinDraft, aktor: pemilik},
+		{name: "status lama dianggap draft", aksi: domain.AksiRekinAjukan, status: "aktif", aktor: pemilik},
+		{name: "ajukan ulang setelah dikembalikan", aksi: domain.AksiRekinAjukan, status: domain.StatusRekinDikembalikan, aktor: pemilik},
+		{name: "atasan setujui", aksi: domain.AksiRekinSetujui, status: domain.StatusRekinDiajukan, aktor: atasan},
+		{name: "pemilik tidak dapat menyetujui sendiri", aksi: domain.AksiRekinSetujui, status: domain.StatusRekinDiajukan, aktor: pemilik, wantCode: http.StatusForbidden},
+		{name: "kembalikan tanpa catatan", aksi: domain.AksiRekinKembalikan, status: domain.StatusRekinDiajukan, aktor: atasan, wantCode: http.StatusBadRequest},
+		{name: "kembalikan dengan catatan", aksi: domain.AksiRekinKembalikan, status: domain.StatusRekinDisetujui, aktor: atasan, catatan: "target belum sesuai"},
+		{name: "finalkan sebelum disetujui", aksi: domain.AksiRekinFinalkan, status: domain.StatusRekinDiajukan, aktor: admin, wantCode: http.StatusConflict},
+		{name: "admin finalkan", aksi: domain.AksiRekinFinalkan, status: domain.StatusRekinDisetujui, aktor: admin},
+		{name: "pemilik tidak dapat menandai final", aksi: domain.AksiRekinFinalkan, status: domain.StatusRekinDisetujui, aktor: pemilik, wantCode: http.StatusForbidden},
+		{name: "admin buka final", aksi: domain.AksiRekinBuka, status: domain.StatusRekinFinal, aktor: admin, catatan: "revisi anggaran"},
+		{name: "aksi tidak dikenal", aksi: "hapus", status: domain.StatusRekinDraft, aktor: admin, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validasiTransisiRekin(tt.aksi, tt.status, tt.aktor, tt.catatan)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("error = %v; want nil", err)
+				}
+				return
+			}
+			customErr, ok := err.(*web.CustomError)
+			if !ok {
+				t.Fatalf("error = %v; want *web.CustomError", err)
+			}
+			if customErr.Code != tt.wantCode {
+				t.Errorf("code = %d; want %d", customErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAktorRekin(t *testing.T) {
+	rekin := domain.StatusRekinPersetujuan{Id: "REKIN-1", PegawaiId: "198001", KodeOpd: "1.01"}
+
+	tests := []struct {
+		name   string
+		claims web.JWTClaim
+		want   []string
+	}{
+		{name: "pemilik", claims: web.JWTClaim{Nip: "198001", KodeOpd: "1.01", Roles: []string{"asn"}}, want: []string{domain.AktorRekinPemilik}},
+		{name: "atasan dari struktur organisasi", claims: web.JWTClaim{Nip: "197501", KodeOpd: "1.01", Roles: []string{"asn"}}, want: []string{domain.AktorRekinAtasan}},
+		{name: "admin opd yang sama", claims: web.JWTClaim{Nip: "199001", KodeOpd: "1.01", Roles: []string{helper.RoleAdminOpd}}, want: []string{domain.AktorRekinAdmin}},
+		{name: "admin opd lain", claims: web.JWTClaim{Nip: "199002", KodeOpd: "2.01", Roles: []string{helper.RoleAdminOpd}}},
+		{name: "super admin", claims: web.JWTClaim{Nip: "199003", Roles: []string{helper.RoleSuperAdmin}}, want: []string{domain.AktorRekinAdmin, domain.AktorRekinAtasan}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedKeys(aktorRekin(tt.claims, rekin, "197501"))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("aktor = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAksiTersediaRekin(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		aktor  map[string]bool
+		want   []string
+	}{
+		{name: "draft oleh pemilik", status: domain.StatusRekinDraft, aktor: map[string]bool{domain.AktorRekinPemilik: true}, want: []string{domain.AksiRekinAjukan}},
+		{name: "diajukan oleh atasan", status: domain.StatusRekinDiajukan, aktor: map[string]bool{domain.AktorRekinAtasan: true}, want: []string{domain.AksiRekinSetujui, domain.AksiRekinKembalikan}},
+		{name: "disetujui oleh super admin", status: domain.StatusRekinDisetujui, aktor: map[string]bool{domain.AktorRekinAtasan: true, domain.AktorRekinAdmin: true}, want: []string{domain.AksiRekinKembalikan, domain.AksiRekinFinalkan}},
+		{name: "final oleh pemilik", status: domain.StatusRekinFinal, aktor: map[string]bool{domain.AktorRekinPemilik: true}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := aksiTersediaRekin(tt.status, tt.aktor)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("aksi = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web/pkopd"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const pkPdfFontSize = 10.0
+
+var pkPdfNamaFile = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// pkPdfDocument berisi data satu dokumen PK yang siap dicetak
+type pkPdfDocument struct {
+	namaOpd    string
+	tahun      int
+	pegawai    pkopd.PkPegawai
+	namaAtasan string
+	nipAtasan  string
+	jabatan    string
+}
+
+// newPkPdfDocuments menyusun dokumen PK untuk setiap pegawai pada response PK OPD.
+// Pihak kedua untuk level strategic (kepala OPD) diambil dari kepala daerah jika atasan belum terisi.
+func newPkPdfDocuments(pk pkopd.PkOpdResponse, nip string) []pkPdfDocument {
+	var documents []pkPdfDocument
+	for _, level := range pk.PkItem {
+		for _, pegawai := range level.Pegawais {
+			if nip != "" && pegawai.Nip != nip {
+				continue
+			}
+			document := pkPdfDocument{
+				namaOpd:    pk.NamaOpd,
+				tahun:      pk.Tahun,
+				pegawai:    pegawai,
+				namaAtasan: pegawai.NamaAtasan,
+				nipAtasan:  pegawai.NipAtasan,
+				jabatan:    pegawai.JabatanAtasan,
+			}
+			if document.namaAtasan == "" && len(pk.SasaranPemdas) > 0 {
+				document.namaAtasan = pk.SasaranPemdas[0].NamaKepalaPemda
+				document.nipAtasan = pk.SasaranPemdas[0].NipKepalaPemda
+			}
+			if document.jabatan == "" && pegawai.LevelPk == 4 {
+				document.jabatan = "Bupati " + strings.TrimPrefix(helper.NamaPemda(), "Kabupaten ")
+			}
+			documents = append(documents, document)
+		}
+	}
+	return documents
+}
+
+// buildPkPdf mencetak satu atau lebih dokumen PK ke dalam satu file PDF
+func buildPkPdf(documents []pkPdfDocument) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for _, document := range documents {
+		writePkPernyataan(pdf, tr, document)
+		writePkLampiran(pdf, tr, document)
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// buildPkZip mencetak PDF PK tiap pegawai dan membungkusnya dalam satu file zip
+func buildPkZip(documents []pkPdfDocument) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	used := make(map[string]int)
+
+	for _, document := range documents {
+		content, err := buildPkPdf([]pkPdfDocument{document})
+		if err != nil {
+			return nil, err
+		}
+
+		name := pkPdfFilename(document)
+		used[name]++
+		if used[name] > 1 {
+			name = strings.TrimSuffix(name, ".pdf") + "_" + strconv.Itoa(used[name]) + ".pdf"
+		}
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func pkPdfFilename(document pkPdfDocument) string {
+	nama := strings.Trim(pkPdfNamaFile.ReplaceAllString(document.pegawai.Nama, "_"), "_")
+	return fmt.Sprintf("PK_%d_%s_%s.pdf", document.tahun, document.pegawai.Nip, nama)
+}
+
+func writePkPernyataan(pdf *fpdf.Fpdf, tr func(string) string, document pkPdfDocument) {
+	pegawai := document.pegawai
+	pdf.AddPage()
+
+	pdf.SetFont("Times", "B", 12)
+	for _, judul := range []string{
+		fmt.Sprintf("PERJANJIAN KINERJA TAHUN %d", document.tahun),
+		strings.ToUpper(document.namaOpd),
+		strings.ToUpper(helper.NamaPemda()),
+	} {
+		pdf.CellFormat(0, 6, tr(judul), "", 1, "C", false, 0, "")
+	}
+	pdf.Ln(8)
+
+	pdf.SetFont("Times", "", pkPdfFontSize+1)
+	pdf.MultiCell(0, 6, tr("Dalam rangka mewujudkan manajemen pemerintahan yang efektif, transparan dan akuntabel serta berorientasi pada hasil, kami yang bertanda tangan di bawah ini:"), "", "J", false)
+	pdf.Ln(3)
+
+	writePkIdentitas(pdf, tr, pegawai.Nama, pegawai.JabatanPegawai)
+	pdf.MultiCell(0, 6, tr("selanjutnya disebut pihak pertama"), "", "L", false)
+	pdf.Ln(3)
+	writePkIdentitas(pdf, tr, document.namaAtasan, document.jabatan)
+	pdf.MultiCell(0, 6, tr("selaku atasan pihak pertama, selanjutnya disebut pihak kedua"), "", "L", false)
+	pdf.Ln(3)
+
+	pdf.MultiCell(0, 6, tr("Pihak pertama berjanji akan mewujudkan target kinerja yang seharusnya sesuai lampiran perjanjian ini, dalam rangka mencapai target kinerja jangka menengah seperti yang telah ditetapkan dalam dokumen perencanaan. Keberhasilan dan kegagalan pencapaian target kinerja tersebut menjadi tanggung jawab kami."), "", "J", false)
+	pdf.Ln(3)
+	pdf.MultiCell(0, 6, tr("Pihak kedua akan melakukan supervisi yang diperlukan serta akan melakukan evaluasi terhadap capaian kinerja dari perjanjian ini dan mengambil tindakan yang diperlukan dalam rangka pemberian penghargaan dan sanksi."), "", "J", false)
+	pdf.Ln(10)
+
+	writePkTandaTangan(pdf, tr, document)
+}
+
+func writePkIdentitas(pdf *fpdf.Fpdf, tr func(string) string, nama, jabatan string) {
+	for _, baris := range [][2]string{{"Nama", nama}, {"Jabatan", jabatan}} {
+		pdf.CellFormat(10, 6, "", "", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 6, tr(baris[0]), "", 0, "L", false, 0, "")
+		pdf.CellFormat(5, 6, ":", "", 0, "L", false, 0, "")
+		pdf.MultiCell(0, 6, tr(baris[1]), "", "L", false)
+	}
+}
+
+func writePkLampiran(pdf *fpdf.Fpdf, tr func(string) string, document pkPdfDocument) {
+	pegawai := document.pegawai
+	pdf.AddPage()
+
+	pdf.SetFont("Times", "B", 12)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("LAMPIRAN PERJANJIAN KINERJA TAHUN %d", document.tahun)), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, tr(strings.ToUpper(pegawai.JabatanPegawai)), "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	sasaranWidths := []float64{10, 60, 60, 20, 20}
+	pdf.SetFont("Times", "", pkPdfFontSize)
+	writePdfTabelHeader(pdf, tr, sasaranWidths, []string{"No", "Sasaran", "Indikator", "Target", "Satuan"})
+	for i, pk := range pegawai.Pks {
+		if len(pk.Indikators) == 0 {
+			writePdfTabelBaris(pdf, tr, sasaranWidths, []string{strconv.Itoa(i + 1), pk.RekinPemilikPk, "-", "-", "-"}, "CLLCC")
+			continue
+		}
+		for j, indikator := range pk.Indikators {
+			no, sasaran := "", ""
+			if j == 0 {
+				no, sasaran = strconv.Itoa(i+1), pk.RekinPemilikPk
+			}
+			target, satuan := "-", "-"
+			if len(indikator.Targets) > 0 {
+				target, satuan = indikator.Targets[0].Target, indikator.Targets[0].Satuan
+			}
+			writePdfTabelBaris(pdf, tr, sasaranWidths, []string{no, sasaran, indikator.Indikator, target, satuan}, "CLLCC")
+		}
+	}
+	pdf.Ln(6)
+
+	anggaranWidths := []float64{10, 95, 40, 25}
+	pdf.SetFont("Times", "", pkPdfFontSize)
+	writePdfTabelHeader(pdf, tr, anggaranWidths, []string{"No", "Program / Kegiatan / Sub Kegiatan", "Anggaran", "Keterangan"})
+	for i, item := range pegawai.Item {
+		writePdfTabelBaris(pdf, tr, anggaranWidths, []string{strconv.Itoa(i + 1), item.KodeItem + " " + item.NamaItem, helper.FormatRupiah(item.PaguItem), translateJenisItem(pegawai.LevelPk)}, "CLRC")
+	}
+	pdf.SetFont("Times", "B", pkPdfFontSize)
+	pdf.CellFormat(anggaranWidths[0]+anggaranWidths[1], 7, "Jumlah", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(anggaranWidths[2], 7, tr(helper.FormatRupiah(pegawai.TotalPagu)), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(anggaranWidths[3], 7, "", "1", 1, "C", false, 0, "")
+	pdf.Ln(10)
+
+	writePkTandaTangan(pdf, tr, document)
+}
+
+func writePkTandaTangan(pdf *fpdf.Fpdf, tr func(string) string, document pkPdfDocument) {
+	pegawai := document.pegawai
+	width := 85.0
+
+	pdf.SetFont("Times", "", pkPdfFontSize+1)
+	pdf.CellFormat(width, 6, "", "", 0, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr(fmt.Sprintf("%s, ........................ %d", strings.TrimPrefix(helper.NamaPemda(), "Kabupaten "), document.tahun)), "", 1, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr("Pihak Kedua,"), "", 0, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr("Pihak Pertama,"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr(document.jabatan), "", 0, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr(pegawai.JabatanPegawai), "", 1, "C", false, 0, "")
+	pdf.Ln(22)
+
+	pdf.SetFont("Times", "BU", pkPdfFontSize+1)
+	pdf.CellFormat(width, 6, tr(document.namaAtasan), "", 0, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr(pegawai.Nama), "", 1, "C", false, 0, "")
+	pdf.SetFont("Times", "", pkPdfFontSize+1)
+	pdf.CellFormat(width, 6, tr(pkNip(document.nipAtasan)), "", 0, "C", false, 0, "")
+	pdf.CellFormat(width, 6, tr(pkNip(pegawai.Nip)), "", 1, "C", false, 0, "")
+}
+
+func pkNip(nip string) string {
+	if nip == "" {
+		return ""
+	}
+	return "NIP. " + nip
+}
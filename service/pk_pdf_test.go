@@ -0,0 +1,90 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"ekak_kabupaten_madiun/model/web/pkopd"
+	"strings"
+	"testing"
+)
+
+func TestPkPdf(t *testing.T) {
+	pk := pkopd.PkOpdResponse{
+		KodeOpd: "1.01.0.00.0.00.01.0000",
+		NamaOpd: "Dinas Pendidikan",
+		Tahun:   2025,
+		SasaranPemdas: []pkopd.SasaranPemdaPk{
+			{NamaKepalaPemda: "Hari Wuryanto", NipKepalaPemda: "-"},
+		},
+		PkItem: []pkopd.PkOpdByLevel{
+			{
+				LevelPk: 4,
+				Pegawais: []pkopd.PkPegawai{{
+					Nama:           "Kepala Dinas",
+					Nip:            "197001011990031001",
+					JabatanPegawai: "Kepala Dinas Pendidikan",
+					LevelPk:        4,
+					Pks: []pkopd.PkAsn{{
+						RekinPemilikPk: "Meningkatnya kualitas pendidikan",
+						Indikators: []pkopd.IndikatorPk{{
+							Indikator: "Angka partisipasi sekolah",
+							Targets:   []pkopd.TargetIndPk{{Target: "98", Satuan: "%"}},
+						}},
+					}},
+					Item:      []pkopd.ItemPk{{KodeItem: "1.01.01", NamaItem: "Program Penunjang", PaguItem: 1500000}},
+					TotalPagu: 1500000,
+				}},
+			},
+			{
+				LevelPk: 5,
+				Pegawais: []pkopd.PkPegawai{{
+					Nama:           "Kepala Bidang",
+					Nip:            "198001012005011001",
+					JabatanPegawai: "Kepala Bidang SD",
+					NamaAtasan:     "Kepala Dinas",
+					NipAtasan:      "197001011990031001",
+					JabatanAtasan:  "Kepala Dinas Pendidikan",
+					LevelPk:        5,
+				}},
+			},
+		},
+	}
+
+	documents := newPkPdfDocuments(pk, "")
+	if len(documents) != 2 {
+		t.Fatalf("jumlah dokumen = %d; want 2", len(documents))
+	}
+	if documents[0].namaAtasan != "Hari Wuryanto" || !strings.HasPrefix(documents[0].jabatan, "Bupati") {
+		t.Errorf("pihak kedua kepala OPD = %s/%s; want kepala daerah", documents[0].namaAtasan, documents[0].jabatan)
+	}
+	if documents[1].namaAtasan != "Kepala Dinas" {
+		t.Errorf("pihak kedua = %s; want Kepala Dinas", documents[1].namaAtasan)
+	}
+
+	if got := newPkPdfDocuments(pk, "198001012005011001"); len(got) != 1 {
+		t.Fatalf("filter nip jumlah dokumen = %d; want 1", len(got))
+	}
+
+	content, err := buildPkPdf(documents[:1])
+	if err != nil {
+		t.Fatalf("buildPkPdf error = %v", err)
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF")) {
+		t.Error("hasil buildPkPdf bukan PDF")
+	}
+
+	archive, err := buildPkZip(documents)
+	if err != nil {
+		t.Fatalf("buildPkZip error = %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("hasil buildPkZip bukan zip: %v", err)
+	}
+	if len(reader.File) != 2 {
+		t.Fatalf("jumlah file zip = %d; want 2", len(reader.File))
+	}
+	if reader.File[0].Name != "PK_2025_197001011990031001_Kepala_Dinas.pdf" {
+		t.Errorf("nama file = %s", reader.File[0].Name)
+	}
+}
@@ -9,4 +9,6 @@ type PkService interface {
 	FindByKodeOpdTahun(ctx context.Context, kodeOpd string, tahun int) (pkopd.PkOpdResponse, error)
 	HubungkanRekin(ctx context.Context, request pkopd.PkOpdRequest) (pkopd.PkOpdResponse, error)
 	HubungkanAtasan(ctx context.Context, request pkopd.HubungkanAtasanRequest) (pkopd.PkOpdResponse, error)
+	GeneratePdfPegawai(ctx context.Context, kodeOpd string, tahun int, nip string) ([]byte, error)
+	GeneratePdfOpdZip(ctx context.Context, kodeOpd string, tahun int) ([]byte, error)
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/pegawai"
 	"ekak_kabupaten_madiun/model/web/pkopd"
@@ -25,8 +26,10 @@ type PkServiceImpl struct {
 	rekinService                 RencanaKinerjaService
 	opdService                   OpdService
 	strukturOrganisasiRepository repository.StrukturOrganisasiRepository
+	lockDataRepository           repository.LockDataRepository
 	Validate                     *validator.Validate
 	DB                           *sql.DB
+	Cache                        *helper.Cache
 }
 
 func NewPkServiceImpl(
@@ -35,8 +38,10 @@ func NewPkServiceImpl(
 	rekinService RencanaKinerjaService,
 	opdService OpdService,
 	strukturOrganisasiRepository repository.StrukturOrganisasiRepository,
+	lockDataRepository repository.LockDataRepository,
 	validate *validator.Validate,
 	DB *sql.DB,
+	cache *helper.Cache,
 ) *PkServiceImpl {
 	return &PkServiceImpl{
 		pkOpdRepository:              pkOpdRepository,
@@ -44,12 +49,22 @@ func NewPkServiceImpl(
 		rekinService:                 rekinService,
 		opdService:                   opdService,
 		strukturOrganisasiRepository: strukturOrganisasiRepository,
+		lockDataRepository:           lockDataRepository,
 		Validate:                     validate,
 		DB:                           DB,
+		Cache:                        cache,
 	}
 }
 
 func (service *PkServiceImpl) FindByKodeOpdTahun(ctx context.Context, kodeOpd string, tahun int) (pkopd.PkOpdResponse, error) {
+	key := helper.NewCacheKey(helper.CacheEntityPk, kodeOpd, strconv.Itoa(tahun)).WithTags(helper.CacheTagOpdTahun(kodeOpd, strconv.Itoa(tahun)))
+	return helper.Remember(ctx, service.Cache, key, helper.ReadCacheTTL, func() (pkopd.PkOpdResponse, error) {
+		return service.findByKodeOpdTahun(ctx, kodeOpd, tahun)
+	})
+}
+
+// findByKodeOpdTahun menyusun PK OPD langsung dari database, dipanggil FindByKodeOpdTahun saat cache kosong
+func (service *PkServiceImpl) findByKodeOpdTahun(ctx context.Context, kodeOpd string, tahun int) (pkopd.PkOpdResponse, error) {
 	log.Printf("[INFO] PK OPD FIND BY KODE OPD TAHUN")
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -62,7 +77,7 @@ func (service *PkServiceImpl) FindByKodeOpdTahun(ctx context.Context, kodeOpd st
 	opd, err := service.opdService.FindByKodeOpd(ctx, kodeOpd)
 	if err != nil {
 		log.Printf("[ERROR] Find OPD by kodeOpd: %v", err)
-		return pkopd.PkOpdResponse{}, fmt.Errorf("OPD TIDAK DITEMUKAN")
+		return pkopd.PkOpdResponse{}, web.NewNotFoundError("OPD TIDAK DITEMUKAN")
 	}
 	// base info nama opd dan kepala opd
 	namaOpd := opd.NamaOpd
@@ -71,7 +86,7 @@ func (service *PkServiceImpl) FindByKodeOpdTahun(ctx context.Context, kodeOpd st
 	// end check opd
 
 	// all pegawai in opd
-	pegawais, err := service.pegawaiService.FindAll(ctx, kodeOpd, "")
+	pegawais, _, err := service.pegawaiService.FindAll(ctx, domain.ListQuery{Filters: map[string]string{"kode_opd": kodeOpd}})
 	if err != nil {
 		log.Printf("[ERROR] Find Pegawai kodeOpd: %v", err)
 		return pkopd.PkOpdResponse{}, fmt.Errorf("terjadi kesalahan sistem")
@@ -570,9 +585,9 @@ func (service *PkServiceImpl) HubungkanRekin(
 ) (resp pkopd.PkOpdResponse, err error) {
 
 	// 1. validasi
-	if err = service.Validate.Struct(request); err != nil {
+	if err = helper.ValidationError(service.Validate.Struct(request)); err != nil {
 		log.Printf("Invalid hubungkan rekin request: %v", err)
-		return pkopd.PkOpdResponse{}, fmt.Errorf("validasi gagal")
+		return pkopd.PkOpdResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
@@ -594,12 +609,16 @@ func (service *PkServiceImpl) HubungkanRekin(
 	tahun := request.Tahun
 	tahunStr := strconv.Itoa(tahun)
 
+	if err = checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockPk, kodeOpd, tahunStr); err != nil {
+		return pkopd.PkOpdResponse{}, err
+	}
+
 	// 2. ambil OPD
 	var opd opdmaster.OpdResponse
 	opd, err = service.opdService.FindByKodeOpd(ctx, kodeOpd)
 	if err != nil {
 		log.Printf("[ERROR] Find OPD: %v", err)
-		return pkopd.PkOpdResponse{}, fmt.Errorf("OPD tidak ditemukan")
+		return pkopd.PkOpdResponse{}, web.NewNotFoundError("OPD tidak ditemukan")
 	}
 
 	// 3. ambil rekin atasan
@@ -609,12 +628,12 @@ func (service *PkServiceImpl) HubungkanRekin(
 		sasaranPemdaId, err := strconv.Atoi(request.IdRekinAtasan)
 		if err != nil {
 			log.Printf("[ERROR] Find Rekin Pemda: %v", err)
-			return pkopd.PkOpdResponse{}, fmt.Errorf("rekin pemda tidak ditemukan")
+			return pkopd.PkOpdResponse{}, web.NewNotFoundError("rekin pemda tidak ditemukan")
 		}
 		rekinPemda, err := service.pkOpdRepository.FindSasaranPemdaById(ctx, tx, sasaranPemdaId)
 		if err != nil {
 			log.Printf("[ERROR] Find Rekin Pemda: %v", err)
-			return pkopd.PkOpdResponse{}, fmt.Errorf("rekin pemda tidak ditemukan")
+			return pkopd.PkOpdResponse{}, web.NewNotFoundError("rekin pemda tidak ditemukan")
 		}
 		rekinAtasan = rencanakinerja.RencanaKinerjaResponse{
 			Id:                 strconv.Itoa(rekinPemda.SasaranPemdaId),
@@ -632,7 +651,7 @@ func (service *PkServiceImpl) HubungkanRekin(
 		)
 		if err != nil {
 			log.Printf("[ERROR] Find Rekin Atasan: %v", err)
-			return pkopd.PkOpdResponse{}, fmt.Errorf("rekin atasan tidak ditemukan")
+			return pkopd.PkOpdResponse{}, web.NewNotFoundError("rekin atasan tidak ditemukan")
 		}
 	}
 
@@ -646,7 +665,7 @@ func (service *PkServiceImpl) HubungkanRekin(
 	)
 	if err != nil {
 		log.Printf("[ERROR] Find Rekin Pemilik: %v", err)
-		return pkopd.PkOpdResponse{}, fmt.Errorf("rekin pemilik tidak ditemukan")
+		return pkopd.PkOpdResponse{}, web.NewNotFoundError("rekin pemilik tidak ditemukan")
 	}
 
 	var pk domain.PkOpd
@@ -699,6 +718,7 @@ func (service *PkServiceImpl) HubungkanRekin(
 	if err = tx.Commit(); err != nil {
 		return
 	}
+	service.Cache.Invalidate(ctx, helper.CacheTagOpdTahun(kodeOpd, tahunStr))
 
 	// 8. ambil full response (transaction baru)
 	return service.FindByKodeOpdTahun(ctx, kodeOpd, tahun)
@@ -709,8 +729,8 @@ func (service *PkServiceImpl) HubungkanAtasan(
 	request pkopd.HubungkanAtasanRequest,
 ) (resp pkopd.PkOpdResponse, err error) {
 
-	if err = service.Validate.Struct(request); err != nil {
-		return pkopd.PkOpdResponse{}, fmt.Errorf("validasi gagal")
+	if err = helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return pkopd.PkOpdResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
@@ -727,6 +747,10 @@ func (service *PkServiceImpl) HubungkanAtasan(
 		}
 	}()
 
+	if err = checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockPk, request.KodeOpd, strconv.Itoa(request.Tahun)); err != nil {
+		return pkopd.PkOpdResponse{}, err
+	}
+
 	strukturOrganisasi := domain.StrukturOrganisasi{
 		KodeOpd:    request.KodeOpd,
 		Tahun:      request.Tahun,
@@ -741,10 +765,39 @@ func (service *PkServiceImpl) HubungkanAtasan(
 	if err = tx.Commit(); err != nil {
 		return
 	}
+	service.Cache.Invalidate(ctx, helper.CacheTagOpdTahun(request.KodeOpd, strconv.Itoa(request.Tahun)))
 
 	return service.FindByKodeOpdTahun(ctx, request.KodeOpd, request.Tahun)
 }
 
+// GeneratePdfPegawai mencetak dokumen PK satu pegawai (pernyataan, lampiran sasaran dan anggaran) dalam format PDF
+func (service *PkServiceImpl) GeneratePdfPegawai(ctx context.Context, kodeOpd string, tahun int, nip string) ([]byte, error) {
+	pk, err := service.FindByKodeOpdTahun(ctx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := newPkPdfDocuments(pk, nip)
+	if len(documents) == 0 {
+		return nil, web.NewNotFoundError(fmt.Sprintf("PK pegawai dengan NIP %s tahun %d tidak ditemukan", nip, tahun))
+	}
+	return buildPkPdf(documents)
+}
+
+// GeneratePdfOpdZip mencetak dokumen PK seluruh pegawai OPD, satu file PDF per pegawai dalam satu zip
+func (service *PkServiceImpl) GeneratePdfOpdZip(ctx context.Context, kodeOpd string, tahun int) ([]byte, error) {
+	pk, err := service.FindByKodeOpdTahun(ctx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := newPkPdfDocuments(pk, "")
+	if len(documents) == 0 {
+		return nil, web.NewNotFoundError(fmt.Sprintf("PK OPD %s tahun %d belum ada", kodeOpd, tahun))
+	}
+	return buildPkZip(documents)
+}
+
 func translateJenisItem(level int) string {
 	switch level {
 	case 4:
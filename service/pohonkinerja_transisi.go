@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// validasiTransisiPokin memeriksa aksi terhadap status pohon kinerja saat ini sesuai domain.TransisiStatusPokin
+func validasiTransisiPokin(aksi string, status string) (domain.TransisiPokin, error) {
+	transisi, ok := domain.TransisiStatusPokin[aksi]
+	if !ok {
+		return transisi, web.NewBadRequestError(fmt.Sprintf("aksi %s tidak dikenal", aksi))
+	}
+	if transisi.BolehDari(status) {
+		return transisi, nil
+	}
+	if len(transisi.Dari) > 0 {
+		return transisi, web.NewBadRequestError(fmt.Sprintf("hanya pohon kinerja dengan status %s yang dapat %s", strings.Join(transisi.Dari, " atau "), transisi.KataKerja))
+	}
+	return transisi, web.NewBadRequestError(fmt.Sprintf("pohon kinerja dengan status '%s' tidak dapat %s", status, transisi.KataKerja))
+}
+
+// jalankanTransisiPokin validasi status, ubah status dan catat riwayat dalam transaksi pemanggil
+func jalankanTransisiPokin(ctx context.Context, tx *sql.Tx, pohonKinerjaRepository repository.PohonKinerjaRepository, pokinId int, aksi string, alasan string) error {
+	pokin, err := pohonKinerjaRepository.FindStatusPokin(ctx, tx, pokinId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.NewNotFoundError(fmt.Sprintf("pohon kinerja %d tidak ditemukan", pokinId))
+	}
+	if err != nil {
+		return err
+	}
+	transisi, err := validasiTransisiPokin(aksi, pokin.Status)
+	if err != nil {
+		return err
+	}
+	return ubahStatusPokin(ctx, tx, pohonKinerjaRepository, pokin, aksi, transisi, alasan)
+}
+
+// ubahStatusPokin mengubah status pohon kinerja yang sudah lolos validasi transisi dan mencatat riwayatnya.
+// Aksi yang tidak mengubah status tidak dicatat.
+func ubahStatusPokin(ctx context.Context, tx *sql.Tx, pohonKinerjaRepository repository.PohonKinerjaRepository, pokin domain.PohonKinerja, aksi string, transisi domain.TransisiPokin, alasan string) error {
+	if transisi.Ke == "" {
+		return nil
+	}
+	err := pohonKinerjaRepository.UpdatePokinStatus(ctx, tx, pokin.Id, transisi.Ke)
+	if err != nil {
+		return err
+	}
+
+	// job runner tidak membawa user info, aktor dicatat kosong
+	claims, _ := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	return pohonKinerjaRepository.CreateRiwayatStatus(ctx, tx, domain.RiwayatStatusPokin{
+		PokinId:     pokin.Id,
+		Aksi:        aksi,
+		StatusAwal:  pokin.Status,
+		StatusAkhir: transisi.Ke,
+		Alasan:      alasan,
+		NipAktor:    claims.Nip,
+		KodeOpd:     pokin.KodeOpd,
+		Tahun:       pokin.Tahun,
+	})
+}
+
+// aktorPokin peran user pada alur status pohon kinerja, saat ini hanya super_admin sebagai admin pemda
+func aktorPokin(claims web.JWTClaim) map[string]bool {
+	aktor := make(map[string]bool)
+	if helper.HasAnyRole(claims.Roles, helper.RoleSuperAdmin) {
+		aktor[domain.AktorPokinAdminPemda] = true
+	}
+	return aktor
+}
+
+// aksiTersediaPokin tombol aksi status pohon kinerja yang boleh dijalankan user
+func aksiTersediaPokin(pokinId int, status string, aktor map[string]bool) []web.ActionButton {
+	host := os.Getenv("host")
+	port := os.Getenv("port")
+
+	actions := make([]web.ActionButton, 0)
+	for _, aksi := range domain.UrutanAksiPokin {
+		transisi := domain.TransisiStatusPokin[aksi]
+		if transisi.Path == "" || !transisi.BolehDari(status) {
+			continue
+		}
+		boleh := false
+		for _, peran := range transisi.Aktor {
+			boleh = boleh || aktor[peran]
+		}
+		if !boleh {
+			continue
+		}
+		actions = append(actions, web.ActionButton{
+			NameAction: transisi.Nama,
+			Method:     transisi.Method,
+			Url:        fmt.Sprintf("%s:%s%s", host, port, isiParamPath(transisi.Path, strconv.Itoa(pokinId))),
+		})
+	}
+	return actions
+}
+
+// isiParamPath mengganti parameter path httprouter (:nama) dengan nilai id
+func isiParamPath(path string, id string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = id
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func toRiwayatStatusPokinResponses(riwayats []domain.RiwayatStatusPokin) []pohonkinerja.RiwayatStatusPokinResponse {
+	responses := make([]pohonkinerja.RiwayatStatusPokinResponse, 0, len(riwayats))
+	for _, riwayat := range riwayats {
+		responses = append(responses, pohonkinerja.RiwayatStatusPokinResponse{
+			Id:          riwayat.Id,
+			Aksi:        riwayat.Aksi,
+			StatusAwal:  riwayat.StatusAwal,
+			StatusAkhir: riwayat.StatusAkhir,
+			Alasan:      riwayat.Alasan,
+			NipAktor:    riwayat.NipAktor,
+			NamaAktor:   riwayat.NamaAktor,
+			CreatedAt:   riwayat.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return responses
+}
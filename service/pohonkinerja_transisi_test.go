@@ -0,0 +1,104 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/middleware"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"slices"
+	"testing"
+)
+
+func TestValidasiTransisiPokin(t *testing.T) {
+	tests := []struct {
+		name    string
+		aksi    string
+		status  string
+		wantKe  string
+		wantErr bool
+	}{
+		{name: "terima pokin menunggu", aksi: domain.AksiPokinTerima, status: domain.StatusPokinMenungguDisetujui, wantKe: domain.StatusPokinDisetujui},
+		{name: "terima ulang pokin ditolak", aksi: domain.AksiPokinTerima, status: domain.StatusPokinDitolak, wantKe: domain.StatusPokinDisetujui},
+		{name: "terima pokin yang sudah disetujui", aksi: domain.AksiPokinTerima, status: domain.StatusPokinDisetujui, wantErr: true},
+		{name: "tolak pokin menunggu", aksi: domain.AksiPokinTolak, status: domain.StatusPokinMenungguDisetujui, wantKe: domain.StatusPokinDitolak},
+		{name: "tolak pokin yang sudah ditolak", aksi: domain.AksiPokinTolak, status: domain.StatusPokinDitolak, wantErr: true},
+		{name: "setujui crosscutting", aksi: domain.AksiPokinSetujuiCrosscutting, status: domain.StatusPokinCrosscuttingMenunggu, wantKe: domain.StatusPokinCrosscuttingDisetujui},
+		{name: "tolak crosscutting yang sudah disetujui", aksi: domain.AksiPokinTolakCrosscutting, status: domain.StatusPokinCrosscuttingDisetujui, wantErr: true},
+		{name: "clone pokin pemda", aksi: domain.AksiPokinClone, status: ""},
+		{name: "clone pokin tarik opd", aksi: domain.AksiPokinClone, status: domain.StatusPokinTarikOpd, wantErr: true},
+		{name: "batal terima pokin disetujui", aksi: domain.AksiPokinBatalTerima, status: domain.StatusPokinDisetujui, wantKe: domain.StatusPokinMenungguDisetujui},
+		{name: "batal terima pokin yang belum disetujui", aksi: domain.AksiPokinBatalTerima, status: domain.StatusPokinMenungguDisetujui, wantErr: true},
+		{name: "aksi tidak dikenal", aksi: "hapus", status: domain.StatusPokinMenungguDisetujui, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transisi, err := validasiTransisiPokin(tt.aksi, tt.status)
+			if tt.wantErr {
+				if _, ok := err.(*web.CustomError); !ok {
+					t.Fatalf("error = %v; want *web.CustomError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v; want nil", err)
+			}
+			if transisi.Ke != tt.wantKe {
+				t.Errorf("status tujuan = %q; want %q", transisi.Ke, tt.wantKe)
+			}
+		})
+	}
+}
+
+func TestAksiTersediaPokin(t *testing.T) {
+	t.Setenv("host", "http://localhost")
+	t.Setenv("port", "8080")
+	adminPemda := aktorPokin(web.JWTClaim{Roles: []string{helper.RoleSuperAdmin}})
+
+	tests := []struct {
+		name   string
+		status string
+		aktor  map[string]bool
+		want   []string
+	}{
+		{name: "pokin menunggu", status: domain.StatusPokinMenungguDisetujui, aktor: adminPemda, want: []string{
+			"POST http://localhost:8080/pohon_kinerja_admin/clone_pokin_pemda/create",
+			"PUT http://localhost:8080/pohon_kinerja_admin/tolak_pokin/7",
+			"POST http://localhost:8080/clone_pokin_pemda/7",
+		}},
+		{name: "crosscutting tanpa endpoint", status: domain.StatusPokinCrosscuttingMenunggu, aktor: adminPemda, want: []string{
+			"POST http://localhost:8080/clone_pokin_pemda/7",
+		}},
+		{name: "tarik pokin opd", status: domain.StatusPokinTarikOpd, aktor: adminPemda},
+		{name: "bukan admin pemda", status: domain.StatusPokinMenungguDisetujui, aktor: aktorPokin(web.JWTClaim{Roles: []string{helper.RoleAdminOpd}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, action := range aksiTersediaPokin(7, tt.status, tt.aktor) {
+				got = append(got, action.Method+" "+action.Url)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("aksi = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// tombol aksi hanya untuk endpoint yang terdaftar dan bisa diakses aktor pada tabel transisi
+func TestTransisiPokinSesuaiRoutePermission(t *testing.T) {
+	for aksi, transisi := range domain.TransisiStatusPokin {
+		if transisi.Path == "" {
+			continue
+		}
+		permission, ok := middleware.FindRoutePermission(transisi.Method, isiParamPath(transisi.Path, "1"))
+		if !ok {
+			t.Errorf("aksi %s: route %s %s tidak terdaftar", aksi, transisi.Method, transisi.Path)
+			continue
+		}
+		if !permission.IsAllowed([]string{helper.RoleSuperAdmin}) {
+			t.Errorf("aksi %s: route %s %s tidak bisa diakses admin pemda", aksi, transisi.Method, transisi.Path)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/model/web/job"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 )
 
@@ -23,7 +24,9 @@ type PohonKinerjaAdminService interface {
 	FindListOpdAllTematik(ctx context.Context, tahun string) ([]pohonkinerja.TematikListOpdResponse, error)
 	RekapIntermediate(ctx context.Context, tahun string) (pohonkinerja.IntermediateResponse, error)
 	FindAllTematik(ctx context.Context, tahun string) (pohonkinerja.PohonKinerjaAdminResponse, error)
-	ClonePokinPemda(ctx context.Context, request pohonkinerja.PohonKinerjaCloneHierarchyRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error)
+	ClonePokinPemda(ctx context.Context, request pohonkinerja.PohonKinerjaCloneHierarchyRequest) (job.JobResponse, error)
+	// FindTransisi status pohon kinerja, aksi status yang boleh dijalankan user dan riwayat transisinya
+	FindTransisi(ctx context.Context, id int) (pohonkinerja.TransisiPokinResponse, error)
 
 	//find pokin for dropdown
 	FindPokinByTematik(ctx context.Context, tahun string) ([]pohonkinerja.PohonKinerjaAdminResponseData, error)
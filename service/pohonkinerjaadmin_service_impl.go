@@ -5,9 +5,12 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/job"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
 	"strconv"
 
 	"log"
@@ -29,10 +32,14 @@ type PohonKinerjaAdminServiceImpl struct {
 	csfRepository             repository.CSFRepository
 	DB                        *sql.DB
 	programUnggulanRepository repository.ProgramUnggulanRepository
+	auditLogRepository        repository.AuditLogRepository
+	jobService                JobService
+	cache                     *helper.Cache
+	notifikasiService         NotifikasiService
 }
 
-func NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, csfRepository repository.CSFRepository, DB *sql.DB, pegawaiRepository repository.PegawaiRepository, reviewRepository repository.ReviewRepository, programUnggulanRepository repository.ProgramUnggulanRepository) *PohonKinerjaAdminServiceImpl {
-	return &PohonKinerjaAdminServiceImpl{
+func NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, csfRepository repository.CSFRepository, DB *sql.DB, pegawaiRepository repository.PegawaiRepository, reviewRepository repository.ReviewRepository, programUnggulanRepository repository.ProgramUnggulanRepository, auditLogRepository repository.AuditLogRepository, jobService JobService, cache *helper.Cache, notifikasiService NotifikasiService) *PohonKinerjaAdminServiceImpl {
+	service := &PohonKinerjaAdminServiceImpl{
 		pohonKinerjaRepository:    pohonKinerjaRepository,
 		opdRepository:             opdRepository,
 		pegawaiRepository:         pegawaiRepository,
@@ -40,10 +47,20 @@ func NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepository repository.PohonKine
 		reviewRepository:          reviewRepository,
 		csfRepository:             csfRepository,
 		programUnggulanRepository: programUnggulanRepository,
+		auditLogRepository:        auditLogRepository,
+		jobService:                jobService,
+		cache:                     cache,
+		notifikasiService:         notifikasiService,
 	}
+	jobService.RegisterHandler(domain.JobClonePokinPemda, JobHandler{Run: service.runClonePokinPemdaJob})
+	return service
 }
 
 func (service *PohonKinerjaAdminServiceImpl) Create(ctx context.Context, request pohonkinerja.PohonKinerjaAdminCreateRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	log.Printf("Memulai proses pembuatan PohonKinerja untuk tahun: %s", request.Tahun)
 
 	tx, err := service.DB.Begin()
@@ -51,7 +68,8 @@ func (service *PohonKinerjaAdminServiceImpl) Create(ctx context.Context, request
 		log.Printf("Error memulai transaksi: %v", err)
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Persiapkan data pelaksana
 	var pelaksanaList []domain.PelaksanaPokin
@@ -153,6 +171,10 @@ func (service *PohonKinerjaAdminServiceImpl) Create(ctx context.Context, request
 	}
 
 	log.Printf("Berhasil membuat PohonKinerja dengan ID: %d", result.Id)
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionCreate, result.Id, nil)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
 
 	// CSF
 	tahunCsf, err := strconv.Atoi(request.Tahun)
@@ -256,23 +278,32 @@ func (service *PohonKinerjaAdminServiceImpl) Create(ctx context.Context, request
 		Tagging:     taggingResponses,
 	}
 
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
 	log.Printf("Proses pembuatan PohonKinerja selesai")
 	return response, nil
 }
 
 // new
 func (service *PohonKinerjaAdminServiceImpl) Update(ctx context.Context, request pohonkinerja.PohonKinerjaAdminUpdateRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Cek apakah data exists
 	existingPokin, err := service.pohonKinerjaRepository.FindPokinAdminById(ctx, tx, request.Id)
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaRepository, request.Id)
 
 	// Jika ini adalah pohon kinerja yang di-clone (clone_from ≠ 0)
 	// Hanya bisa update pelaksana saja
@@ -298,6 +329,10 @@ func (service *PohonKinerjaAdminServiceImpl) Update(ctx context.Context, request
 		if err != nil {
 			return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 		}
+		err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionUpdate, request.Id, auditBefore)
+		if err != nil {
+			return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+		}
 
 		// Build response
 		var pelaksanaResponses []pohonkinerja.PelaksanaOpdResponse
@@ -332,6 +367,10 @@ func (service *PohonKinerjaAdminServiceImpl) Update(ctx context.Context, request
 			}
 		}
 
+		if err := tx.Commit(); err != nil {
+			return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+		}
+
 		// Return response dengan data yang sudah ada sebelumnya
 		return pohonkinerja.PohonKinerjaAdminResponseData{
 			Id:         findidpokin.Id,
@@ -605,6 +644,14 @@ func (service *PohonKinerjaAdminServiceImpl) Update(ctx context.Context, request
 		})
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionUpdate, request.Id, auditBefore)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	return pohonkinerja.PohonKinerjaAdminResponseData{
 		Id:         updatedPokin.Id,
 		Parent:     updatedPokin.Parent,
@@ -1025,7 +1072,8 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Cek apakah data exists sebelum dihapus
 	pokin, err := service.pohonKinerjaRepository.FindPokinAdminById(ctx, tx, id)
@@ -1035,9 +1083,11 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 
 	// Validasi level pohon: hanya validasi batas bawah
 	if pokin.LevelPohon < 0 {
-		return fmt.Errorf("level pohon kinerja tidak valid")
+		return web.NewBadRequestError("level pohon kinerja tidak valid")
 	}
 
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaRepository, id)
+
 	// Cek apakah data adalah hasil clone
 	cloneFrom, err := service.pohonKinerjaRepository.CheckCloneFrom(ctx, tx, id)
 	if err != nil {
@@ -1050,7 +1100,10 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 		if err != nil {
 			return fmt.Errorf("gagal menghapus data clone: %v", err)
 		}
-		return nil
+		if err := recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionDelete, id, auditBefore); err != nil {
+			return err
+		}
+		return tx.Commit()
 	}
 
 	// Jika data adalah asli (clone_from = 0), hapus data beserta semua yang terkait
@@ -1059,7 +1112,10 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 		return fmt.Errorf("gagal menghapus data: %v", err)
 	}
 
-	return nil
+	if err := recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionDelete, id, auditBefore); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (service *PohonKinerjaAdminServiceImpl) FindById(ctx context.Context, id int) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
@@ -1220,82 +1276,85 @@ func (service *PohonKinerjaAdminServiceImpl) FindAll(ctx context.Context, tahun
 }
 
 func (service *PohonKinerjaAdminServiceImpl) FindSubTematik(ctx context.Context, tahun string) (pohonkinerja.OutcomeResponse, error) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return pohonkinerja.OutcomeResponse{}, err
-	}
-	defer helper.CommitOrRollback(tx)
+	key := helper.NewCacheKey(helper.CacheEntityRekapOutcome, tahun).WithTags(helper.CacheTagEntity(helper.CacheEntityPokinPemda))
+	return helper.Remember(ctx, service.cache, key, helper.ReadCacheTTL, func() (pohonkinerja.OutcomeResponse, error) {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return pohonkinerja.OutcomeResponse{}, err
+		}
+		defer helper.CommitOrRollback(tx)
 
-	// Ambil semua data pohon kinerja
-	pokins, err := service.pohonKinerjaRepository.FindPokinAdminAll(ctx, tx, tahun)
-	if err != nil {
-		return pohonkinerja.OutcomeResponse{}, err
-	}
+		// Ambil semua data pohon kinerja
+		pokins, err := service.pohonKinerjaRepository.FindPokinAdminAll(ctx, tx, tahun)
+		if err != nil {
+			return pohonkinerja.OutcomeResponse{}, err
+		}
 
-	// Buat map untuk menyimpan data berdasarkan level dan parent
-	pohonMap := make(map[int]map[int][]domain.PohonKinerja)
-	for i := 0; i <= 1; i++ { // Inisialisasi level 0, 1, dan 2
-		pohonMap[i] = make(map[int][]domain.PohonKinerja)
-	}
+		// Buat map untuk menyimpan data berdasarkan level dan parent
+		pohonMap := make(map[int]map[int][]domain.PohonKinerja)
+		for i := 0; i <= 1; i++ { // Inisialisasi level 0, 1, dan 2
+			pohonMap[i] = make(map[int][]domain.PohonKinerja)
+		}
 
-	// Filter dan kelompokkan data berdasarkan level dan parent
-	for _, p := range pokins {
-		// Ambil data OPD jika ada
-		if p.KodeOpd != "" {
-			opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, p.KodeOpd)
-			if err == nil {
-				p.NamaOpd = opd.NamaOpd
+		// Filter dan kelompokkan data berdasarkan level dan parent
+		for _, p := range pokins {
+			// Ambil data OPD jika ada
+			if p.KodeOpd != "" {
+				opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, p.KodeOpd)
+				if err == nil {
+					p.NamaOpd = opd.NamaOpd
+				}
 			}
-		}
 
-		// Kelompokkan berdasarkan level dan parent
-		if p.LevelPohon >= 0 && p.LevelPohon <= 1 {
-			pohonMap[p.LevelPohon][p.Parent] = append(pohonMap[p.LevelPohon][p.Parent], p)
+			// Kelompokkan berdasarkan level dan parent
+			if p.LevelPohon >= 0 && p.LevelPohon <= 1 {
+				pohonMap[p.LevelPohon][p.Parent] = append(pohonMap[p.LevelPohon][p.Parent], p)
+			}
 		}
-	}
 
-	// Bangun response dimulai dari Tematik (level 0)
-	var tematiks []pohonkinerja.OutcomeTematikResponse
-
-	// Ambil semua tematik (level 0)
-	for _, tematik := range pokins {
-		if tematik.LevelPohon == 0 { // Fokus pada level 0 (tematik)
-			tematikResp := pohonkinerja.OutcomeTematikResponse{
-				Id:         tematik.Id,
-				Parent:     nil,
-				Tema:       tematik.NamaPohon,
-				JenisPohon: tematik.JenisPohon,
-				LevelPohon: tematik.LevelPohon,
-				Indikators: helper.ConvertToIndikatorResponses(tematik.Indikator),
-				Child:      []interface{}{},
-			}
-
-			// Cari subtematik (level 1) yang memiliki parent tematik ini
-			if subtematiks := pohonMap[1][tematik.Id]; len(subtematiks) > 0 {
-				for _, subtematik := range subtematiks {
-
-					subtematikResp := pohonkinerja.OutcomeSubtematikResponse{
-
-						Id:         subtematik.Id,
-						Parent:     subtematik.Parent,
-						Tema:       subtematik.NamaPohon,
-						JenisPohon: subtematik.JenisPohon,
-						LevelPohon: subtematik.LevelPohon,
-						Indikators: helper.ConvertToIndikatorResponses(subtematik.Indikator),
-					}
+		// Bangun response dimulai dari Tematik (level 0)
+		var tematiks []pohonkinerja.OutcomeTematikResponse
 
-					tematikResp.Child = append(tematikResp.Child, subtematikResp)
+		// Ambil semua tematik (level 0)
+		for _, tematik := range pokins {
+			if tematik.LevelPohon == 0 { // Fokus pada level 0 (tematik)
+				tematikResp := pohonkinerja.OutcomeTematikResponse{
+					Id:         tematik.Id,
+					Parent:     nil,
+					Tema:       tematik.NamaPohon,
+					JenisPohon: tematik.JenisPohon,
+					LevelPohon: tematik.LevelPohon,
+					Indikators: helper.ConvertToIndikatorResponses(tematik.Indikator),
+					Child:      []interface{}{},
 				}
-			}
 
-			tematiks = append(tematiks, tematikResp)
+				// Cari subtematik (level 1) yang memiliki parent tematik ini
+				if subtematiks := pohonMap[1][tematik.Id]; len(subtematiks) > 0 {
+					for _, subtematik := range subtematiks {
+
+						subtematikResp := pohonkinerja.OutcomeSubtematikResponse{
+
+							Id:         subtematik.Id,
+							Parent:     subtematik.Parent,
+							Tema:       subtematik.NamaPohon,
+							JenisPohon: subtematik.JenisPohon,
+							LevelPohon: subtematik.LevelPohon,
+							Indikators: helper.ConvertToIndikatorResponses(subtematik.Indikator),
+						}
+
+						tematikResp.Child = append(tematikResp.Child, subtematikResp)
+					}
+				}
+
+				tematiks = append(tematiks, tematikResp)
+			}
 		}
-	}
 
-	return pohonkinerja.OutcomeResponse{
-		Tahun:   tahun,
-		Tematik: tematiks,
-	}, nil
+		return pohonkinerja.OutcomeResponse{
+			Tahun:   tahun,
+			Tematik: tematiks,
+		}, nil
+	})
 }
 
 // func (service *PohonKinerjaAdminServiceImpl) FindPokinAdminByIdHierarki(ctx context.Context, idPokin int) (pohonkinerja.TematikResponse, error) {
@@ -1313,7 +1372,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindSubTematik(ctx context.Context,
 
 // 	// Validasi level pohon harus 0
 // 	if pokin.LevelPohon != 0 {
-// 		return pohonkinerja.TematikResponse{}, fmt.Errorf("id yang diberikan bukan merupakan level tematik (level 0)")
+// 		return pohonkinerja.TematikResponse{}, web.NewBadRequestError("id yang diberikan bukan merupakan level tematik (level 0)")
 // 	}
 
 // 	// Ambil semua data pohon kinerja
@@ -1456,7 +1515,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinAdminByIdHierarki(ctx cont
 		return pohonkinerja.TematikResponse{}, err
 	}
 	if pokin.LevelPohon != 0 {
-		return pohonkinerja.TematikResponse{}, fmt.Errorf("id yang diberikan bukan merupakan level tematik (level 0)")
+		return pohonkinerja.TematikResponse{}, web.NewBadRequestError("id yang diberikan bukan merupakan level tematik (level 0)")
 	}
 	// ── 1. Ambil seluruh hierarki sekaligus (sudah termasuk indikator+target+pelaksana) ──
 	pokins, err := service.pohonKinerjaRepository.FindPokinAdminByIdHierarki(ctx, tx, idPokin)
@@ -1603,11 +1662,16 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinAdminByIdHierarki(ctx cont
 }
 
 func (service *PohonKinerjaAdminServiceImpl) CreateStrategicAdmin(ctx context.Context, request pohonkinerja.PohonKinerjaAdminStrategicCreateRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Cek apakah pohon kinerja sudah pernah diclone
 	cloneFrom, err := service.pohonKinerjaRepository.CheckCloneFrom(ctx, tx, request.IdToClone)
@@ -1652,7 +1716,7 @@ func (service *PohonKinerjaAdminServiceImpl) CreateStrategicAdmin(ctx context.Co
 		KodeOpd:    existingPokin.KodeOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "tarik pokin opd",
+		Status:     domain.StatusPokinTarikOpd,
 		CloneFrom:  cloneReference,
 		IsActive:   existingPokin.IsActive,
 	}
@@ -1736,7 +1800,7 @@ func (service *PohonKinerjaAdminServiceImpl) CreateStrategicAdmin(ctx context.Co
 		NamaOpd:    namaOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "tarik pokin opd",
+		Status:     domain.StatusPokinTarikOpd,
 		IsActive:   existingPokin.IsActive,
 		Indikators: indikatorResponses,
 		Childs:     childs,
@@ -1747,6 +1811,14 @@ func (service *PohonKinerjaAdminServiceImpl) CreateStrategicAdmin(ctx context.Co
 		},
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionClone, response.Id, nil)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	return response, nil
 }
 
@@ -1842,7 +1914,7 @@ func (service *PohonKinerjaAdminServiceImpl) cloneChildrenHierarchy(ctx context.
 			KodeOpd:    child.KodeOpd,
 			Keterangan: child.Keterangan,
 			Tahun:      child.Tahun,
-			Status:     "tarik pokin opd",
+			Status:     domain.StatusPokinTarikOpd,
 			CloneFrom:  child.Id,
 			IsActive:   child.IsActive,
 		}
@@ -1883,7 +1955,7 @@ func (service *PohonKinerjaAdminServiceImpl) cloneChildrenHierarchy(ctx context.
 			NamaOpd:    namaOpd,
 			Keterangan: child.Keterangan,
 			Tahun:      child.Tahun,
-			Status:     "tarik pokin opd",
+			Status:     domain.StatusPokinTarikOpd,
 			IsActive:   child.IsActive, // Tambahkan ini
 			Indikators: indikatorResponses,
 			Childs:     childChilds,
@@ -1900,11 +1972,17 @@ func (service *PohonKinerjaAdminServiceImpl) cloneChildrenHierarchy(ctx context.
 }
 
 func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.Context, request pohonkinerja.PohonKinerjaAdminStrategicCreateRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	// status dan riwayat status harus tersimpan bersama, commit hanya jika seluruh langkah berhasil
+	defer tx.Rollback()
 
 	// Validasi status pokin
 	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.IdToClone)
@@ -1912,8 +1990,9 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
 
-	if status != "menunggu_disetujui" && status != "ditolak" {
-		return pohonkinerja.PohonKinerjaAdminResponseData{}, errors.New("hanya pohon kinerja dengan status menunggu_disetujui atau ditolak yang dapat diclone")
+	terima, err := validasiTransisiPokin(domain.AksiPokinTerima, status)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
 
 	// Fungsi helper untuk clone indikator dan target
@@ -1954,16 +2033,16 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 	var updateStatusRecursive func(ctx context.Context, tx *sql.Tx, pokinId int, parentKodeOpd string) error
 	updateStatusRecursive = func(ctx context.Context, tx *sql.Tx, pokinId int, parentKodeOpd string) error {
 		// Ambil data pokin saat ini
-		pokin, err := service.pohonKinerjaRepository.FindPokinAdminById(ctx, tx, pokinId)
+		pokin, err := service.pohonKinerjaRepository.FindStatusPokin(ctx, tx, pokinId)
 		if err != nil {
 			return err
 		}
 
 		// Cek apakah kode_opd sama dengan parent atau ini adalah pohon pertama
 		if parentKodeOpd == "" || pokin.KodeOpd == parentKodeOpd {
-			// Update status hanya jika kode_opd sesuai dan statusnya menunggu_disetujui atau ditolak
-			if pokin.Status == "menunggu_disetujui" || pokin.Status == "ditolak" {
-				err = service.pohonKinerjaRepository.UpdatePokinStatus(ctx, tx, pokinId, "disetujui")
+			// Update status hanya jika kode_opd sesuai dan statusnya boleh diterima
+			if terima.BolehDari(pokin.Status) {
+				err = ubahStatusPokin(ctx, tx, service.pohonKinerjaRepository, pokin, domain.AksiPokinTerima, terima, "")
 				if err != nil {
 					return err
 				}
@@ -2049,7 +2128,7 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 			KodeOpd:    existingPokin.KodeOpd,
 			Keterangan: existingPokin.Keterangan,
 			Tahun:      existingPokin.Tahun,
-			Status:     "pokin dari pemda",
+			Status:     domain.StatusPokinDariPemda,
 			CloneFrom:  pokinId,
 			Pelaksana:  existingPokin.Pelaksana,
 		}
@@ -2204,6 +2283,14 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 		Tagging:    taggingResponses,
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionClone, response.Id, nil)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	return response, nil
 }
 
@@ -2248,7 +2335,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinByStrategic(ctx context.Co
 	if kodeOpd != "" {
 		_, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 		if err != nil {
-			return nil, errors.New("kode opd tidak ditemukan")
+			return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 		}
 	}
 
@@ -2299,7 +2386,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinByTactical(ctx context.Con
 	if kodeOpd != "" {
 		_, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 		if err != nil {
-			return nil, errors.New("kode opd tidak ditemukan")
+			return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 		}
 	}
 
@@ -2349,7 +2436,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinByOperational(ctx context.
 	if kodeOpd != "" {
 		_, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 		if err != nil {
-			return nil, errors.New("kode opd tidak ditemukan")
+			return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 		}
 	}
 
@@ -2396,7 +2483,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinByStatus(ctx context.Conte
 	}
 	defer helper.CommitOrRollback(tx)
 
-	pokins, err := service.pohonKinerjaRepository.FindPokinByStatus(ctx, tx, kodeOpd, tahun, "menunggu_disetujui")
+	pokins, err := service.pohonKinerjaRepository.FindPokinByStatus(ctx, tx, kodeOpd, tahun, domain.StatusPokinMenungguDisetujui)
 	if err != nil {
 		return nil, err
 	}
@@ -2422,31 +2509,60 @@ func (service *PohonKinerjaAdminServiceImpl) TolakPokin(ctx context.Context, req
 	if err != nil {
 		return err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	// status dan riwayat status harus tersimpan bersama, commit hanya jika seluruh langkah berhasil
+	defer tx.Rollback()
 
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.Id)
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaRepository, request.Id)
+	err = jalankanTransisiPokin(ctx, tx, service.pohonKinerjaRepository, request.Id, domain.AksiPokinTolak, request.Alasan)
 	if err != nil {
 		return err
 	}
 
-	if status != "menunggu_disetujui" {
-		return errors.New("hanya pohon kinerja dengan status menunggu_disetujui yang dapat ditolak")
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionReject, request.Id, auditBefore)
+	if err != nil {
+		return err
 	}
-
-	err = service.pohonKinerjaRepository.UpdatePokinStatusTolak(ctx, tx, request.Id, "ditolak")
+	err = service.kirimNotifikasiTolakPokin(ctx, tx, request.Id, request.Alasan)
 	if err != nil {
 		return err
 	}
+	return tx.Commit()
+}
 
-	return nil
+// kirimNotifikasiTolakPokin memberi tahu admin OPD tujuan bahwa pokin pemda yang dikirim ke OPD-nya ditolak
+func (service *PohonKinerjaAdminServiceImpl) kirimNotifikasiTolakPokin(ctx context.Context, tx *sql.Tx, pokinId int, alasan string) error {
+	pokin, err := service.pohonKinerjaRepository.FindById(ctx, tx, pokinId)
+	if err != nil {
+		return err
+	}
+	pesan := fmt.Sprintf("Pohon kinerja pemda \"%s\" ditolak", pokin.NamaPohon)
+	if alasan != "" {
+		pesan += ": " + alasan
+	}
+	return service.notifikasiService.Kirim(ctx, tx, domain.Notifikasi{
+		Jenis:      domain.JenisNotifikasiPokinDitolak,
+		Judul:      "Pohon kinerja pemda ditolak",
+		Pesan:      pesan,
+		EntityType: domain.AuditEntityPohonKinerja,
+		EntityId:   strconv.Itoa(pokinId),
+		KodeOpd:    pokin.KodeOpd,
+		Tahun:      pokin.Tahun,
+		Url:        fmt.Sprintf("/pohon_kinerja/transisi/%d", pokinId),
+	}, domain.PenerimaNotifikasi{KodeOpd: pokin.KodeOpd, Roles: []string{helper.RoleAdminOpd}})
 }
 
 func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context, request pohonkinerja.PohonKinerjaAdminStrategicCreateRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Cek apakah pohon kinerja sudah pernah diclone
 	cloneFrom, err := service.pohonKinerjaRepository.CheckCloneFrom(ctx, tx, request.IdToClone)
@@ -2472,11 +2588,11 @@ func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context
 
 	// Validasi JenisPohon
 	if request.JenisPohon == "" {
-		return pohonkinerja.PohonKinerjaAdminResponseData{}, errors.New("jenis pohon tidak boleh kosong")
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, web.NewBadRequestError("jenis pohon tidak boleh kosong")
 	}
 
 	// Update status pokin yang diclone menjadi disetujui
-	err = service.pohonKinerjaRepository.UpdatePokinStatus(ctx, tx, request.IdToClone, "disetujui")
+	err = jalankanTransisiPokin(ctx, tx, service.pohonKinerjaRepository, request.IdToClone, domain.AksiPokinTerima, "")
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
@@ -2497,7 +2613,7 @@ func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context
 		KodeOpd:    existingPokin.KodeOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "crosscutting_menunggu",
+		Status:     domain.StatusPokinCrosscuttingMenunggu,
 		CloneFrom:  cloneReference,
 		Pelaksana:  existingPokin.Pelaksana,
 	}
@@ -2575,11 +2691,19 @@ func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context
 		NamaOpd:    namaOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "crosscutting_menunggu",
+		Status:     domain.StatusPokinCrosscuttingMenunggu,
 		Indikators: indikatorResponses,
 		Pelaksana:  pelaksanaResponses,
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionCreate, response.Id, nil)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	return response, nil
 }
 
@@ -2594,7 +2718,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinByCrosscuttingStatus(ctx c
 	if kodeOpd != "" {
 		_, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 		if err != nil {
-			return nil, errors.New("kode opd tidak ditemukan")
+			return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 		}
 	}
 
@@ -2691,7 +2815,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinFromPemda(ctx context.Cont
 	if kodeOpd != "" {
 		_, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 		if err != nil {
-			return nil, errors.New("kode opd tidak ditemukan")
+			return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 		}
 	}
 
@@ -2710,7 +2834,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinFromPemda(ctx context.Cont
 		// if pokin.Status != "menunggu_disetujui" && pokin.Status != "ditolak" {
 		// 	continue
 		// }
-		if pokin.Status != "menunggu_disetujui" {
+		if pokin.Status != domain.StatusPokinMenungguDisetujui {
 			continue
 		}
 
@@ -2780,27 +2904,25 @@ func (service *PohonKinerjaAdminServiceImpl) TolakCrosscutting(ctx context.Conte
 	if err != nil {
 		return err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	// status dan riwayat status harus tersimpan bersama, commit hanya jika seluruh langkah berhasil
+	defer tx.Rollback()
 
 	if request.Id == 0 {
-		return errors.New("id tidak boleh kosong")
+		return web.NewBadRequestError("id tidak boleh kosong")
 	}
 
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.Id)
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaRepository, request.Id)
+	err = jalankanTransisiPokin(ctx, tx, service.pohonKinerjaRepository, request.Id, domain.AksiPokinTolakCrosscutting, request.Alasan)
 	if err != nil {
 		return err
 	}
 
-	if status != "crosscutting_menunggu" {
-		return errors.New("hanya pohon kinerja dengan status crosscutting_menunggu yang dapat ditolak")
-	}
-
-	err = service.pohonKinerjaRepository.UpdatePokinStatusTolak(ctx, tx, request.Id, "crosscutting_ditolak")
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionReject, request.Id, auditBefore)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	return tx.Commit()
 }
 
 func (service *PohonKinerjaAdminServiceImpl) SetujuiCrosscutting(ctx context.Context, request pohonkinerja.PohonKinerjaAdminTolakRequest) error {
@@ -2808,27 +2930,25 @@ func (service *PohonKinerjaAdminServiceImpl) SetujuiCrosscutting(ctx context.Con
 	if err != nil {
 		return err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	// status dan riwayat status harus tersimpan bersama, commit hanya jika seluruh langkah berhasil
+	defer tx.Rollback()
 
 	if request.Id == 0 {
-		return errors.New("id tidak boleh kosong")
+		return web.NewBadRequestError("id tidak boleh kosong")
 	}
 
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.Id)
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaRepository, request.Id)
+	err = jalankanTransisiPokin(ctx, tx, service.pohonKinerjaRepository, request.Id, domain.AksiPokinSetujuiCrosscutting, request.Alasan)
 	if err != nil {
 		return err
 	}
 
-	if status != "crosscutting_menunggu" {
-		return errors.New("hanya pohon kinerja dengan status crosscutting_menunggu yang dapat disetujui")
-	}
-
-	err = service.pohonKinerjaRepository.UpdatePokinStatusTolak(ctx, tx, request.Id, "crosscutting_disetujui")
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionApprove, request.Id, auditBefore)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	return tx.Commit()
 }
 
 func (service *PohonKinerjaAdminServiceImpl) FindPokinFromOpd(ctx context.Context, kodeOpd string, tahun string, levelPohon int) ([]pohonkinerja.PohonKinerjaAdminResponseData, error) {
@@ -2842,7 +2962,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinFromOpd(ctx context.Contex
 	if kodeOpd != "" {
 		_, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 		if err != nil {
-			return nil, errors.New("kode opd tidak ditemukan")
+			return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 		}
 	}
 
@@ -2858,7 +2978,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinFromOpd(ctx context.Contex
 	var result []pohonkinerja.PohonKinerjaAdminResponseData
 	for _, pokin := range pokins {
 		// Skip pohon kinerja dengan status yang tidak diinginkan
-		if pokin.Status == "menunggu_disetujui" || pokin.Status == "crosscutting_menunggu" || pokin.Status == "tarik pokin opd" || pokin.Status == "disetujui" || pokin.Status == "ditolak" || pokin.Status == "crosscutting_ditolak" {
+		if domain.StatusPokinDalamAlur(pokin.Status) {
 			continue
 		}
 
@@ -2920,7 +3040,8 @@ func (service *PohonKinerjaAdminServiceImpl) AktiforNonAktifTematik(ctx context.
 		}
 		return "gagal dinonaktifkan", err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Verifikasi bahwa pohon kinerja yang akan diubah adalah tematik (level 0)
 	pokin, err := service.pohonKinerjaRepository.FindById(ctx, tx, request.Id)
@@ -2933,9 +3054,9 @@ func (service *PohonKinerjaAdminServiceImpl) AktiforNonAktifTematik(ctx context.
 
 	if pokin.LevelPohon != 0 {
 		if request.IsActive {
-			return "gagal diaktifkan", fmt.Errorf("pohon kinerja dengan id %d bukan merupakan tematik (level 0)", request.Id)
+			return "gagal diaktifkan", web.NewBadRequestError(fmt.Sprintf("pohon kinerja dengan id %d bukan merupakan tematik (level 0)", request.Id))
 		}
-		return "gagal dinonaktifkan", fmt.Errorf("pohon kinerja dengan id %d bukan merupakan tematik (level 0)", request.Id)
+		return "gagal dinonaktifkan", web.NewBadRequestError(fmt.Sprintf("pohon kinerja dengan id %d bukan merupakan tematik (level 0)", request.Id))
 	}
 
 	// Dapatkan semua children dan clone yang terkait
@@ -2947,6 +3068,8 @@ func (service *PohonKinerjaAdminServiceImpl) AktiforNonAktifTematik(ctx context.
 		return "gagal dinonaktifkan", err
 	}
 
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaRepository, request.Id)
+
 	// Update status tematik
 	err = service.pohonKinerjaRepository.UpdateTematikStatus(ctx, tx, request.Id, request.IsActive)
 	if err != nil {
@@ -2967,6 +3090,14 @@ func (service *PohonKinerjaAdminServiceImpl) AktiforNonAktifTematik(ctx context.
 		}
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionUpdate, request.Id, auditBefore)
+	if err != nil {
+		return "gagal mencatat audit log", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "gagal menyimpan status tematik", err
+	}
+
 	if request.IsActive {
 		return "berhasil diaktifkan", nil
 	}
@@ -3021,90 +3152,93 @@ func (service *PohonKinerjaAdminServiceImpl) FindListOpdAllTematik(ctx context.C
 }
 
 func (service *PohonKinerjaAdminServiceImpl) RekapIntermediate(ctx context.Context, tahun string) (pohonkinerja.IntermediateResponse, error) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return pohonkinerja.IntermediateResponse{}, err
-	}
-	defer helper.CommitOrRollback(tx)
-	// Ambil semua data pohon kinerja
-	pokins, err := service.pohonKinerjaRepository.FindPokinAdminAll(ctx, tx, tahun)
-	if err != nil {
-		return pohonkinerja.IntermediateResponse{}, err
-	}
+	key := helper.NewCacheKey(helper.CacheEntityRekapIntermediate, tahun).WithTags(helper.CacheTagEntity(helper.CacheEntityPokinPemda))
+	return helper.Remember(ctx, service.cache, key, helper.ReadCacheTTL, func() (pohonkinerja.IntermediateResponse, error) {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return pohonkinerja.IntermediateResponse{}, err
+		}
+		defer helper.CommitOrRollback(tx)
+		// Ambil semua data pohon kinerja
+		pokins, err := service.pohonKinerjaRepository.FindPokinAdminAll(ctx, tx, tahun)
+		if err != nil {
+			return pohonkinerja.IntermediateResponse{}, err
+		}
 
-	// Buat map untuk menyimpan data berdasarkan level dan parent
-	pohonMap := make(map[int]map[int][]domain.PohonKinerja)
-	for i := 1; i <= 4; i++ { // Inisialisasi level 1, 2, dan 4
-		pohonMap[i] = make(map[int][]domain.PohonKinerja)
-	}
+		// Buat map untuk menyimpan data berdasarkan level dan parent
+		pohonMap := make(map[int]map[int][]domain.PohonKinerja)
+		for i := 1; i <= 4; i++ { // Inisialisasi level 1, 2, dan 4
+			pohonMap[i] = make(map[int][]domain.PohonKinerja)
+		}
 
-	// Filter dan kelompokkan data berdasarkan level dan parent
-	for _, p := range pokins {
-		// Kelompokkan berdasarkan level dan parent
-		if p.LevelPohon == 1 || p.LevelPohon == 2 || p.LevelPohon == 4 {
-			pohonMap[p.LevelPohon][p.Parent] = append(pohonMap[p.LevelPohon][p.Parent], p)
-		}
-	}
-
-	// Bangun response dimulai dari Subtematik (level 1)
-	var intermediates []pohonkinerja.IntermediateSubtematikResponse
-
-	// Ambil semua subtematik (level 1)
-	for _, subtematik := range pokins {
-		if subtematik.LevelPohon == 1 { // Fokus pada level 1 (subtematik)
-			subtematikResp := pohonkinerja.IntermediateSubtematikResponse{
-				Id:         subtematik.Id,
-				Parent:     subtematik.Parent,
-				Tema:       subtematik.NamaPohon,
-				JenisPohon: subtematik.JenisPohon,
-				LevelPohon: subtematik.LevelPohon,
-				Indikators: helper.ConvertToIndikatorResponses(subtematik.Indikator),
-				Child:      []interface{}{},
-			}
-
-			// Cari subsubtematik (level 2) yang memiliki parent subtematik ini
-			if subsubtematiks := pohonMap[2][subtematik.Id]; len(subsubtematiks) > 0 {
-				for _, subsubtematik := range subsubtematiks {
-					subsubtematikResp := pohonkinerja.IntermediateSubSubtematikResponse{
-						Id:         subsubtematik.Id,
-						Parent:     subsubtematik.Parent,
-						Tema:       subsubtematik.NamaPohon,
-						JenisPohon: subsubtematik.JenisPohon,
-						LevelPohon: subsubtematik.LevelPohon,
-						Indikators: helper.ConvertToIndikatorResponses(subsubtematik.Indikator),
-						Child:      []interface{}{},
-					}
+		// Filter dan kelompokkan data berdasarkan level dan parent
+		for _, p := range pokins {
+			// Kelompokkan berdasarkan level dan parent
+			if p.LevelPohon == 1 || p.LevelPohon == 2 || p.LevelPohon == 4 {
+				pohonMap[p.LevelPohon][p.Parent] = append(pohonMap[p.LevelPohon][p.Parent], p)
+			}
+		}
 
-					// Cari strategic (level 4) yang memiliki parent subsubtematik ini
-					if strategics := pohonMap[4][subsubtematik.Id]; len(strategics) > 0 {
-						for _, strategic := range strategics {
-
-							strategicResp := pohonkinerja.IntermediateStrategicPemdaResponse{
-								Id:         strategic.Id,
-								Parent:     strategic.Parent,
-								Tema:       strategic.NamaPohon,
-								JenisPohon: strategic.JenisPohon,
-								LevelPohon: strategic.LevelPohon,
-								Indikators: helper.ConvertToIndikatorResponses(strategic.Indikator),
-								Child:      []interface{}{},
-							}
+		// Bangun response dimulai dari Subtematik (level 1)
+		var intermediates []pohonkinerja.IntermediateSubtematikResponse
 
-							subsubtematikResp.Child = append(subsubtematikResp.Child, strategicResp)
+		// Ambil semua subtematik (level 1)
+		for _, subtematik := range pokins {
+			if subtematik.LevelPohon == 1 { // Fokus pada level 1 (subtematik)
+				subtematikResp := pohonkinerja.IntermediateSubtematikResponse{
+					Id:         subtematik.Id,
+					Parent:     subtematik.Parent,
+					Tema:       subtematik.NamaPohon,
+					JenisPohon: subtematik.JenisPohon,
+					LevelPohon: subtematik.LevelPohon,
+					Indikators: helper.ConvertToIndikatorResponses(subtematik.Indikator),
+					Child:      []interface{}{},
+				}
+
+				// Cari subsubtematik (level 2) yang memiliki parent subtematik ini
+				if subsubtematiks := pohonMap[2][subtematik.Id]; len(subsubtematiks) > 0 {
+					for _, subsubtematik := range subsubtematiks {
+						subsubtematikResp := pohonkinerja.IntermediateSubSubtematikResponse{
+							Id:         subsubtematik.Id,
+							Parent:     subsubtematik.Parent,
+							Tema:       subsubtematik.NamaPohon,
+							JenisPohon: subsubtematik.JenisPohon,
+							LevelPohon: subsubtematik.LevelPohon,
+							Indikators: helper.ConvertToIndikatorResponses(subsubtematik.Indikator),
+							Child:      []interface{}{},
 						}
-					}
 
-					subtematikResp.Child = append(subtematikResp.Child, subsubtematikResp)
+						// Cari strategic (level 4) yang memiliki parent subsubtematik ini
+						if strategics := pohonMap[4][subsubtematik.Id]; len(strategics) > 0 {
+							for _, strategic := range strategics {
+
+								strategicResp := pohonkinerja.IntermediateStrategicPemdaResponse{
+									Id:         strategic.Id,
+									Parent:     strategic.Parent,
+									Tema:       strategic.NamaPohon,
+									JenisPohon: strategic.JenisPohon,
+									LevelPohon: strategic.LevelPohon,
+									Indikators: helper.ConvertToIndikatorResponses(strategic.Indikator),
+									Child:      []interface{}{},
+								}
+
+								subsubtematikResp.Child = append(subsubtematikResp.Child, strategicResp)
+							}
+						}
+
+						subtematikResp.Child = append(subtematikResp.Child, subsubtematikResp)
+					}
 				}
-			}
 
-			intermediates = append(intermediates, subtematikResp)
+				intermediates = append(intermediates, subtematikResp)
+			}
 		}
-	}
 
-	return pohonkinerja.IntermediateResponse{
-		Tahun:        tahun,
-		Intermediate: intermediates,
-	}, nil
+		return pohonkinerja.IntermediateResponse{
+			Tahun:        tahun,
+			Intermediate: intermediates,
+		}, nil
+	})
 }
 
 func (service *PohonKinerjaAdminServiceImpl) FindAllTematik(ctx context.Context, tahun string) (pohonkinerja.PohonKinerjaAdminResponse, error) {
@@ -3150,8 +3284,100 @@ func (service *PohonKinerjaAdminServiceImpl) FindAllTematik(ctx context.Context,
 	}, nil
 }
 
-func (service *PohonKinerjaAdminServiceImpl) ClonePokinPemda(ctx context.Context, request pohonkinerja.PohonKinerjaCloneHierarchyRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+// ClonePokinPemda memeriksa pohon sumber lalu memasukkan clone hierarki ke antrian job,
+// data pohon hasil clone tersedia di result job setelah selesai
+func (service *PohonKinerjaAdminServiceImpl) ClonePokinPemda(ctx context.Context, request pohonkinerja.PohonKinerjaCloneHierarchyRequest) (job.JobResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return job.JobResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	sourcePokin, err := service.pohonKinerjaRepository.FindPokinAdminById(ctx, tx, request.IdPokinSource)
+	if err != nil {
+		return job.JobResponse{}, web.NewNotFoundError(fmt.Sprintf("pohon kinerja %d tidak ditemukan", request.IdPokinSource))
+	}
+	if _, err := validasiTransisiPokin(domain.AksiPokinClone, sourcePokin.Status); err != nil {
+		return job.JobResponse{}, err
+	}
+	alreadyCloned, err := service.pohonKinerjaRepository.CheckIfSourceAlreadyCloned(ctx, tx, request.IdPokinSource, request.TahunTarget)
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	if alreadyCloned {
+		return job.JobResponse{}, web.NewConflictError(fmt.Sprintf("pohon kinerja ini sudah pernah di clone di tahun %s", request.TahunTarget))
+	}
+
+	cloneJob, err := service.jobService.Enqueue(ctx, tx, domain.JobClonePokinPemda, request, "", request.TahunTarget)
+	if err != nil {
+		return job.JobResponse{}, fmt.Errorf("gagal membuat job clone: %w", err)
+	}
+	return toJobResponse(cloneJob), nil
+}
+
+func (service *PohonKinerjaAdminServiceImpl) FindTransisi(ctx context.Context, id int) (pohonkinerja.TransisiPokinResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return pohonkinerja.TransisiPokinResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	pokin, err := service.pohonKinerjaRepository.FindStatusPokin(ctx, tx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pohonkinerja.TransisiPokinResponse{}, web.NewNotFoundError(fmt.Sprintf("pohon kinerja %d tidak ditemukan", id))
+	}
+	if err != nil {
+		return pohonkinerja.TransisiPokinResponse{}, err
+	}
+	// pohon kinerja pemda (tanpa kode_opd) dapat dilihat semua OPD
+	if pokin.KodeOpd != "" {
+		if err := helper.ValidateKodeOpdAccess(ctx, pokin.KodeOpd); err != nil {
+			return pohonkinerja.TransisiPokinResponse{}, err
+		}
+	}
+
+	riwayats, err := service.pohonKinerjaRepository.FindRiwayatStatus(ctx, tx, id)
+	if err != nil {
+		return pohonkinerja.TransisiPokinResponse{}, err
+	}
+
+	claims, _ := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
+	return pohonkinerja.TransisiPokinResponse{
+		PokinId: pokin.Id,
+		Status:  pokin.Status,
+		Action:  aksiTersediaPokin(pokin.Id, pokin.Status, aktorPokin(claims)),
+		Riwayat: toRiwayatStatusPokinResponses(riwayats),
+	}, nil
+}
+
+// invalidateRekapPokinCache rekap outcome dan intermediate memuat seluruh pohon kinerja satu tahun,
+// sehingga setiap perubahan pohon kinerja menghapus cache rekap. Dipanggil lewat defer sebelum
+// CommitOrRollback agar berjalan setelah commit.
+func invalidateRekapPokinCache(ctx context.Context, cache *helper.Cache) {
+	cache.Invalidate(ctx, helper.CacheTagEntity(helper.CacheEntityPokinPemda))
+}
+
+func (service *PohonKinerjaAdminServiceImpl) runClonePokinPemdaJob(ctx context.Context, cloneJob domain.Job, progress JobProgressFunc) (domain.JobResult, error) {
+	var request pohonkinerja.PohonKinerjaCloneHierarchyRequest
+	if err := json.Unmarshal(cloneJob.Payload, &request); err != nil {
+		return domain.JobResult{}, web.NewBadRequestError(fmt.Sprintf("payload job clone pohon kinerja pemda tidak valid: %v", err))
+	}
+
+	response, err := service.clonePokinPemda(ctx, request)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+	invalidateRekapPokinCache(ctx, service.cache)
+	return domain.JobResult{Data: response}, nil
+}
+
+// clonePokinPemda clone hierarki pohon kinerja pemda dalam satu transaksi, dijalankan oleh job runner
+func (service *PohonKinerjaAdminServiceImpl) clonePokinPemda(ctx context.Context, request pohonkinerja.PohonKinerjaCloneHierarchyRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
+	tx, err := service.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
@@ -3187,15 +3413,15 @@ func (service *PohonKinerjaAdminServiceImpl) ClonePokinPemda(ctx context.Context
 	}
 
 	// 2. Validasi - source tidak boleh berstatus 'tarik pokin opd'
-	if sourcePokin.Status == "tarik pokin opd" {
-		return pohonkinerja.PohonKinerjaAdminResponseData{}, fmt.Errorf("tidak dapat clone pohon kinerja dengan status 'tarik pokin opd'")
+	if _, err = validasiTransisiPokin(domain.AksiPokinClone, sourcePokin.Status); err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
 	alreadyCloned, err := service.pohonKinerjaRepository.CheckIfSourceAlreadyCloned(ctx, tx, request.IdPokinSource, request.TahunTarget)
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
 	if alreadyCloned {
-		return pohonkinerja.PohonKinerjaAdminResponseData{}, fmt.Errorf("pohon kinerja ini sudah pernah di clone di tahun %s", request.TahunTarget)
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, web.NewConflictError(fmt.Sprintf("pohon kinerja ini sudah pernah di clone di tahun %s", request.TahunTarget))
 	}
 
 	// 3. Clone secara rekursif dan dapatkan ID root
@@ -3265,5 +3491,10 @@ func (service *PohonKinerjaAdminServiceImpl) ClonePokinPemda(ctx context.Context
 		Pelaksana:  pelaksanaResponses,
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaRepository, domain.AuditActionClone, response.Id, nil)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
+	}
+
 	return response, nil
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/model/web/job"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/model/web/strategic"
 )
@@ -20,7 +21,7 @@ type PohonKinerjaOpdService interface {
 	DeletePokinPemdaInOpd(ctx context.Context, id int) error
 	UpdateParent(ctx context.Context, pohonKinerja pohonkinerja.PohonKinerjaUpdateParentRequest) (pohonkinerja.PohonKinerjaOpdResponse, error)
 	FindidPokinWithAllTema(ctx context.Context, id int) (pohonkinerja.PohonKinerjaAdminResponse, error)
-	CloneByKodeOpdAndTahun(ctx context.Context, request pohonkinerja.PohonKinerjaCloneRequest) error
+	CloneByKodeOpdAndTahun(ctx context.Context, request pohonkinerja.PohonKinerjaCloneRequest) (job.JobResponse, error)
 	CheckPokinExistsByTahun(ctx context.Context, kodeOpd string, tahun string) (bool, error)
 	FindAllPokinParentClonePokinOpd(ctx context.Context, kodeOpd, tahun string, levelPohon *int) ([]pohonkinerja.PohonKinerjaOpdResponse, error)
 	CountPokinPemda(ctx context.Context, kodeOpd, tahun string) (pohonkinerja.CountPokinPemdaResponse, error)
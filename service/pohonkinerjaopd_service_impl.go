@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/job"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/model/web/strategic"
 	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -35,11 +38,14 @@ type PohonKinerjaOpdServiceImpl struct {
 	RedisClient               *redis.Client
 	CSFRepository             repository.CSFRepository
 	sasaranOpdRepository      repository.SasaranOpdRepository
+	auditLogRepository        repository.AuditLogRepository
+	jobService                JobService
+	cache                     *helper.Cache
 }
 
 func NewPohonKinerjaOpdServiceImpl(pohonKinerjaOpdRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, pegawaiRepository repository.PegawaiRepository, tujuanOpdRepository repository.TujuanOpdRepository, crosscuttingOpdRepository repository.CrosscuttingOpdRepository, reviewRepository repository.ReviewRepository, DB *sql.DB, validate *validator.Validate,
-	programUnggulanRepository repository.ProgramUnggulanRepository, redisClient *redis.Client, csfRepository repository.CSFRepository, sasaranOpdRepository repository.SasaranOpdRepository) *PohonKinerjaOpdServiceImpl {
-	return &PohonKinerjaOpdServiceImpl{
+	programUnggulanRepository repository.ProgramUnggulanRepository, redisClient *redis.Client, csfRepository repository.CSFRepository, sasaranOpdRepository repository.SasaranOpdRepository, auditLogRepository repository.AuditLogRepository, jobService JobService, cache *helper.Cache) *PohonKinerjaOpdServiceImpl {
+	service := &PohonKinerjaOpdServiceImpl{
 		pohonKinerjaOpdRepository: pohonKinerjaOpdRepository,
 		opdRepository:             opdRepository,
 		pegawaiRepository:         pegawaiRepository,
@@ -52,28 +58,42 @@ func NewPohonKinerjaOpdServiceImpl(pohonKinerjaOpdRepository repository.PohonKin
 		RedisClient:               redisClient,
 		CSFRepository:             csfRepository,
 		sasaranOpdRepository:      sasaranOpdRepository,
+		auditLogRepository:        auditLogRepository,
+		jobService:                jobService,
+		cache:                     cache,
 	}
+	jobService.RegisterHandler(domain.JobClonePokinOpd, JobHandler{Run: service.runClonePokinOpdJob})
+	return service
 }
 
 func (service *PohonKinerjaOpdServiceImpl) Create(ctx context.Context, request pohonkinerja.PohonKinerjaCreateRequest) (pohonkinerja.PohonKinerjaOpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Validasi request
 	if request.NamaPohon == "" {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("nama program tidak boleh kosong")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewBadRequestError("nama program tidak boleh kosong")
+	}
+
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
 
 	// Validasi kode OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 	if opd.KodeOpd == "" {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("kode opd tidak valid")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewBadRequestError("kode opd tidak valid")
 	}
 
 	// Validasi dan persiapan data pelaksana
@@ -87,10 +107,10 @@ func (service *PohonKinerjaOpdServiceImpl) Create(ctx context.Context, request p
 		// Validasi setiap pelaksana
 		pegawaiPelaksana, err := service.pegawaiRepository.FindById(ctx, tx, pelaksanaReq.PegawaiId)
 		if err != nil {
-			return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("pelaksana tidak ditemukan")
+			return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("pelaksana tidak ditemukan")
 		}
 		if pegawaiPelaksana.Id == "" {
-			return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("pelaksana tidak ditemukan")
+			return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("pelaksana tidak ditemukan")
 		}
 
 		// Tambahkan ke list pelaksana
@@ -213,6 +233,11 @@ func (service *PohonKinerjaOpdServiceImpl) Create(ctx context.Context, request p
 		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaOpdRepository, domain.AuditActionCreate, result.Id, nil)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	// Update tagging responses dengan ID yang sudah di-generate
 	for i, tagging := range result.TaggingPokin {
 		if i < len(taggingResponses) {
@@ -249,28 +274,40 @@ func (service *PohonKinerjaOpdServiceImpl) Create(ctx context.Context, request p
 		Tagging:     taggingResponses,
 	}
 
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
 	return response, nil
 }
 
 func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request pohonkinerja.PohonKinerjaUpdateRequest) (pohonkinerja.PohonKinerjaOpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// Validasi request
 	if request.NamaPohon == "" {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("nama program tidak boleh kosong")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewBadRequestError("nama program tidak boleh kosong")
+	}
+
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
 
 	// Validasi kode OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 	if opd.KodeOpd == "" {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("kode opd tidak valid")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewBadRequestError("kode opd tidak valid")
 	}
 
 	// Cek apakah ini adalah pohon kinerja yang di-clone
@@ -281,7 +318,7 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 
 	// Jika ini adalah pohon kinerja yang di-clone, tidak boleh diupdate
 	if cloneFrom != 0 {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("tidak dapat mengupdate pohon kinerja yang merupakan hasil clone")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewBadRequestError("tidak dapat mengupdate pohon kinerja yang merupakan hasil clone")
 	}
 
 	// Dapatkan semua pohon kinerja yang terkait (asli dan clone)
@@ -290,9 +327,13 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 	// Tambahkan pohon kinerja yang sedang diupdate
 	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data pohon kinerja tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("data pohon kinerja tidak ditemukan")
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, existingPokin.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
 	pokinsToUpdate = append(pokinsToUpdate, existingPokin)
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaOpdRepository, request.Id)
 
 	// Cari pohon kinerja yang merupakan clone dari yang sedang diupdate
 	clonedPokins, err := service.pohonKinerjaOpdRepository.FindPokinByCloneFrom(ctx, tx, request.Id)
@@ -309,7 +350,7 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 		pelaksanaId := fmt.Sprintf("PLKS-%s", uuid.New().String()[:8])
 		pegawaiPelaksana, err := service.pegawaiRepository.FindById(ctx, tx, pelaksanaReq.PegawaiId)
 		if err != nil {
-			return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("pelaksana tidak ditemukan")
+			return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("pelaksana tidak ditemukan")
 		}
 
 		pelaksanaList = append(pelaksanaList, domain.PelaksanaPokin{
@@ -570,6 +611,14 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 		})
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaOpdRepository, domain.AuditActionUpdate, request.Id, auditBefore)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	return pohonkinerja.PohonKinerjaOpdResponse{
 		Id:                     updatedPokin.Id,
 		Parent:                 strconv.Itoa(updatedPokin.Parent),
@@ -595,13 +644,19 @@ func (service *PohonKinerjaOpdServiceImpl) Delete(ctx context.Context, id int) e
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// 1. Cek apakah pohon kinerja dengan ID tersebut ada
-	_, err = service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
+	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("pohon kinerja tidak ditemukan: %v", err)
 	}
+	if err := helper.ValidateKodeOpdAccess(ctx, existingPokin.KodeOpd); err != nil {
+		return err
+	}
+
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaOpdRepository, id)
 
 	// 2. Lakukan penghapusan dengan fungsi baru
 	err = service.pohonKinerjaOpdRepository.Delete(ctx, tx, id)
@@ -609,7 +664,11 @@ func (service *PohonKinerjaOpdServiceImpl) Delete(ctx context.Context, id int) e
 		return fmt.Errorf("gagal menghapus pohon kinerja: %v", err)
 	}
 
-	return nil
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaOpdRepository, domain.AuditActionDelete, id, auditBefore)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (service *PohonKinerjaOpdServiceImpl) FindById(ctx context.Context, id int) (pohonkinerja.PohonKinerjaOpdResponse, error) {
@@ -627,13 +686,16 @@ func (service *PohonKinerjaOpdServiceImpl) FindById(ctx context.Context, id int)
 
 	// 2. Validasi data pohon kinerja
 	if pokin.Id == 0 {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("data tidak ditemukan")
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, pokin.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
 
 	// 3. Ambil data OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, pokin.KodeOpd)
 	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data opd tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("data opd tidak ditemukan")
 	}
 
 	// 4. Ambil data pelaksana
@@ -752,7 +814,7 @@ func (service *PohonKinerjaOpdServiceImpl) FindAll(ctx context.Context, kodeOpd,
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdAllResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdAllResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// Inisialisasi response dasar
@@ -1095,7 +1157,7 @@ func (service *PohonKinerjaOpdServiceImpl) FindAllArah(ctx context.Context, kode
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 	if err != nil {
-		return strategic.StrategicArahKebijakanOpdAllResponse{}, errors.New("kode opd tidak ditemukan")
+		return strategic.StrategicArahKebijakanOpdAllResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// Inisialisasi response dasar
@@ -1186,7 +1248,7 @@ func (service *PohonKinerjaOpdServiceImpl) FindAllArah(ctx context.Context, kode
 // 	// Validasi OPD
 // 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 // 	if err != nil {
-// 		return strategicarahkebijakan.StrategicArahKebijakanPemdaAllResponse{}, errors.New("kode opd tidak ditemukan")
+// 		return strategicarahkebijakan.StrategicArahKebijakanPemdaAllResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 // 	}
 
 // 	// Inisialisasi response dasar
@@ -1698,7 +1760,7 @@ func (service *PohonKinerjaOpdServiceImpl) FindStrategicNoParent(ctx context.Con
 	// Validasi kode OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 	if err != nil {
-		return nil, errors.New("kode opd tidak ditemukan")
+		return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// Ambil data strategic dengan level pohon 4
@@ -1735,8 +1797,22 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePelaksana(ctx context.Context,
 	if err != nil {
 		return err
 	}
-	defer helper.CommitOrRollback(tx)
-	return service.pohonKinerjaOpdRepository.DeletePelaksanaPokin(ctx, tx, pelaksanaId)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
+
+	err = service.pohonKinerjaOpdRepository.DeletePelaksanaPokin(ctx, tx, pelaksanaId)
+	if err != nil {
+		return err
+	}
+	err = recordAuditLog(ctx, tx, service.auditLogRepository, domain.AuditLog{
+		Action:     domain.AuditActionDelete,
+		EntityType: domain.AuditEntityPelaksanaPokin,
+		EntityId:   pelaksanaId,
+	}, nil, nil)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // Tambahkan fungsi helper untuk membangun OperationalN response
@@ -2387,13 +2463,17 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePokinPemdaInOpd(ctx context.Con
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
 
 	// 1. Cek apakah pohon kinerja dengan ID tersebut ada
-	_, err = service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
+	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("pohon kinerja tidak ditemukan: %v", err)
 	}
+	if err := helper.ValidateKodeOpdAccess(ctx, existingPokin.KodeOpd); err != nil {
+		return err
+	}
 
 	// 2. Cek apakah ini adalah pohon kinerja yang di-clone dan dapatkan ID aslinya
 	cloneFrom, err := service.pohonKinerjaOpdRepository.CheckCloneFrom(ctx, tx, id)
@@ -2402,47 +2482,75 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePokinPemdaInOpd(ctx context.Con
 	}
 
 	if cloneFrom == 0 {
-		return fmt.Errorf("pohon kinerja ini bukan merupakan hasil clone dari pemda")
+		return web.NewBadRequestError("pohon kinerja ini bukan merupakan hasil clone dari pemda")
 	}
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaOpdRepository, id)
 
 	// 3. Hapus data clone saat ini
 	err = service.pohonKinerjaOpdRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus pohon kinerja clone: %v", err)
 	}
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaOpdRepository, domain.AuditActionDelete, id, auditBefore)
+	if err != nil {
+		return err
+	}
 
-	// 4. Update status pohon kinerja asli (yang di-clone) menjadi "ditolak"
-	err = service.pohonKinerjaOpdRepository.UpdatePokinStatusFromApproved(ctx, tx, cloneFrom)
+	// 4. Kembalikan status pohon kinerja asli (yang di-clone) menjadi menunggu_disetujui
+	err = jalankanTransisiPokin(ctx, tx, service.pohonKinerjaOpdRepository, cloneFrom, domain.AksiPokinBatalTerima, "")
 	if err != nil {
-		return fmt.Errorf("gagal mengupdate status pohon kinerja asli: %v", err)
+		return err
 	}
 
-	// 5. Dapatkan dan update status semua child dari pohon kinerja asli
+	// 5. Dapatkan hierarki pohon kinerja asli
 	originalHierarchy, err := service.pohonKinerjaOpdRepository.FindPokinAdminByIdHierarki(ctx, tx, cloneFrom)
 	if err != nil {
 		return fmt.Errorf("gagal mendapatkan hierarki pohon kinerja asli: %v", err)
 	}
 
-	// 6. Update status untuk semua child dari pohon kinerja asli
+	// 6. Kembalikan status child yang ikut disetujui, child dengan status lain dibiarkan
+	batalTerima := domain.TransisiStatusPokin[domain.AksiPokinBatalTerima]
 	for _, originalPokin := range originalHierarchy {
-		if originalPokin.Id != cloneFrom { // Skip pohon kinerja utama karena sudah diupdate
-			err = service.pohonKinerjaOpdRepository.UpdatePokinStatusFromApproved(ctx, tx, originalPokin.Id)
-			if err != nil {
-				log.Printf("Warning: gagal mengupdate status child pohon kinerja asli ID %d: %v", originalPokin.Id, err)
-				continue // Lanjutkan ke child berikutnya meskipun ada error
-			}
+		if originalPokin.Id == cloneFrom { // Skip pohon kinerja utama karena sudah diupdate
+			continue
+		}
+		child, err := service.pohonKinerjaOpdRepository.FindStatusPokin(ctx, tx, originalPokin.Id)
+		if err != nil {
+			return fmt.Errorf("gagal mendapatkan status child pohon kinerja asli ID %d: %w", originalPokin.Id, err)
+		}
+		if !batalTerima.BolehDari(child.Status) {
+			continue
+		}
+		err = ubahStatusPokin(ctx, tx, service.pohonKinerjaOpdRepository, child, domain.AksiPokinBatalTerima, batalTerima, "")
+		if err != nil {
+			return fmt.Errorf("gagal mengupdate status child pohon kinerja asli ID %d: %w", originalPokin.Id, err)
 		}
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (service *PohonKinerjaOpdServiceImpl) UpdateParent(ctx context.Context, pohonKinerja pohonkinerja.PohonKinerjaUpdateParentRequest) (pohonkinerja.PohonKinerjaOpdResponse, error) {
+	if err := helper.ValidateRequest(pohonKinerja); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaOpdResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
+
+	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, pohonKinerja.Id)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, fmt.Errorf("pohon kinerja tidak ditemukan: %v", err)
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, existingPokin.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaOpdRepository, pohonKinerja.Id)
 
 	pokin := domain.PohonKinerja{
 		Id:     pohonKinerja.Id,
@@ -2454,6 +2562,14 @@ func (service *PohonKinerjaOpdServiceImpl) UpdateParent(ctx context.Context, poh
 		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
 
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaOpdRepository, domain.AuditActionUpdate, pohonKinerja.Id, auditBefore)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	return pohonkinerja.PohonKinerjaOpdResponse{
 		Id:     pokin.Id,
 		Parent: fmt.Sprint(pokin.Parent),
@@ -2511,7 +2627,7 @@ func (service *PohonKinerjaOpdServiceImpl) FindidPokinWithAllTema(ctx context.Co
 
 	// Validasi level
 	if targetPokin.LevelPohon < 4 || targetPokin.LevelPohon > 6 {
-		return pohonkinerja.PohonKinerjaAdminResponse{}, fmt.Errorf("ID harus merujuk ke level Strategic (4), Tactical (5), atau Operational (6)")
+		return pohonkinerja.PohonKinerjaAdminResponse{}, web.NewBadRequestError("ID harus merujuk ke level Strategic (4), Tactical (5), atau Operational (6)")
 	}
 
 	// Helper functions (sama seperti sebelumnya)
@@ -2685,26 +2801,66 @@ func (service *PohonKinerjaOpdServiceImpl) FindidPokinWithAllTema(ctx context.Co
 
 	return response, nil
 }
-func (service *PohonKinerjaOpdServiceImpl) CloneByKodeOpdAndTahun(ctx context.Context, request pohonkinerja.PohonKinerjaCloneRequest) error {
+// CloneByKodeOpdAndTahun memasukkan clone pohon kinerja OPD ke antrian job, progress dipantau lewat /jobs/:id
+func (service *PohonKinerjaOpdServiceImpl) CloneByKodeOpdAndTahun(ctx context.Context, request pohonkinerja.PohonKinerjaCloneRequest) (job.JobResponse, error) {
 	// Validasi request
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
-		return err
+		return job.JobResponse{}, err
+	}
+
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return job.JobResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
 	if err != nil {
-		return err
+		return job.JobResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	cloneJob, err := service.jobService.Enqueue(ctx, tx, domain.JobClonePokinOpd, request, request.KodeOpd, request.TahunTujuan)
+	if err != nil {
+		return job.JobResponse{}, fmt.Errorf("gagal membuat job clone: %w", err)
+	}
+	return toJobResponse(cloneJob), nil
+}
+
+func (service *PohonKinerjaOpdServiceImpl) runClonePokinOpdJob(ctx context.Context, cloneJob domain.Job, progress JobProgressFunc) (domain.JobResult, error) {
+	var request pohonkinerja.PohonKinerjaCloneRequest
+	if err := json.Unmarshal(cloneJob.Payload, &request); err != nil {
+		return domain.JobResult{}, web.NewBadRequestError(fmt.Sprintf("payload job clone pohon kinerja tidak valid: %v", err))
+	}
+
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+	defer tx.Rollback()
+
 	// Lakukan cloning
 	err = service.pohonKinerjaOpdRepository.ClonePokinOpd(ctx, tx, request.KodeOpd, request.TahunSumber, request.TahunTujuan)
 	if err != nil {
-		return fmt.Errorf("gagal melakukan cloning: %v", err)
+		return domain.JobResult{}, fmt.Errorf("gagal melakukan cloning: %v", err)
 	}
+	progress(90)
 
-	return nil
+	err = recordAuditLog(ctx, tx, service.auditLogRepository, domain.AuditLog{
+		Action:     domain.AuditActionClone,
+		EntityType: domain.AuditEntityPohonKinerja,
+		EntityId:   request.KodeOpd,
+		KodeOpd:    request.KodeOpd,
+		Tahun:      request.TahunTujuan,
+	}, nil, request)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.JobResult{}, err
+	}
+	invalidateRekapPokinCache(ctx, service.cache)
+	return domain.JobResult{}, nil
 }
 
 func (service *PohonKinerjaOpdServiceImpl) CheckPokinExistsByTahun(ctx context.Context, kodeOpd string, tahun string) (bool, error) {
@@ -2733,7 +2889,7 @@ func (service *PohonKinerjaOpdServiceImpl) CountPokinPemda(ctx context.Context,
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 	if err != nil {
-		return pohonkinerja.CountPokinPemdaResponse{}, errors.New("kode opd tidak ditemukan")
+		return pohonkinerja.CountPokinPemdaResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// Hitung jumlah pokin pemda per level
@@ -3357,7 +3513,7 @@ func buildLeaderboardTematikTree(nodes []repository.LeaderboardTematikNode) []po
 }
 
 func (service *PohonKinerjaOpdServiceImpl) UpsertLeaderboardHidden(ctx context.Context, req pohonkinerja.LeaderboardHiddenUpsertRequest) error {
-	if err := service.Validate.Struct(req); err != nil {
+	if err := helper.ValidationError(service.Validate.Struct(req)); err != nil {
 		return err
 	}
 	tx, err := service.DB.Begin()
@@ -3366,7 +3522,7 @@ func (service *PohonKinerjaOpdServiceImpl) UpsertLeaderboardHidden(ctx context.C
 	}
 	defer helper.CommitOrRollback(tx)
 	if _, err := service.opdRepository.FindByKodeOpd(ctx, tx, req.KodeOpd); err != nil {
-		return errors.New("kode opd tidak ditemukan")
+		return web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 	if err := service.pohonKinerjaOpdRepository.UpsertLeaderboardHidden(ctx, tx, req.KodeOpd, req.Tahun, req.IsHidden); err != nil {
 		return err
@@ -3396,7 +3552,7 @@ func (service *PohonKinerjaOpdServiceImpl) FindAllPokinParentClonePokinOpd(ctx c
 	}
 	defer helper.CommitOrRollback(tx)
 	if _, err := service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd); err != nil {
-		return nil, errors.New("kode opd tidak ditemukan")
+		return nil, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 	pokins, err := service.pohonKinerjaOpdRepository.FindPokinByParentClonePokinOpd(ctx, tx, kodeOpd, tahun, levelPohon)
 	if err != nil {
@@ -3421,16 +3577,33 @@ func (service *PohonKinerjaOpdServiceImpl) FindAllPokinParentClonePokinOpd(ctx c
 }
 
 func (service *PohonKinerjaOpdServiceImpl) UpdateParentClone(ctx context.Context, req pohonkinerja.PohonKinerjaUpdateParentRequest) (pohonkinerja.PohonKinerjaUpdateParentCloneResponse, error) {
+	if err := helper.ValidateRequest(req); err != nil {
+		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	defer invalidateRekapPokinCache(ctx, service.cache)
+	defer tx.Rollback()
+	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, req.Id)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, fmt.Errorf("pohon kinerja tidak ditemukan: %v", err)
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, existingPokin.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
+	}
+	auditBefore := pohonKinerjaAuditSnapshot(ctx, tx, service.pohonKinerjaOpdRepository, req.Id)
 	pokin := domain.PohonKinerja{Id: req.Id, Parent: req.Parent}
 	pokin, err = service.pohonKinerjaOpdRepository.UpdateParent(ctx, tx, pokin)
 	if err != nil {
 		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
 	}
+	err = recordPohonKinerjaAudit(ctx, tx, service.auditLogRepository, service.pohonKinerjaOpdRepository, domain.AuditActionUpdate, req.Id, auditBefore)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
+	}
 	pokinFull, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, pokin.Id)
 	if err != nil {
 		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
@@ -3465,6 +3638,9 @@ func (service *PohonKinerjaOpdServiceImpl) UpdateParentClone(ctx context.Context
 			return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
 		}
 		if len(kids) == 0 {
+			if err := tx.Commit(); err != nil {
+				return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
+			}
 			return out, nil
 		}
 		childIds := make([]int, len(kids))
@@ -3476,17 +3652,20 @@ func (service *PohonKinerjaOpdServiceImpl) UpdateParentClone(ctx context.Context
 			return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
 		}
 	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
+	}
 	return out, nil
 }
 
 func (service *PohonKinerjaOpdServiceImpl) pohonKinerjaOpdResponseFromPokinDomain(ctx context.Context, tx *sql.Tx, pokin domain.PohonKinerja) (pohonkinerja.PohonKinerjaOpdResponse, error) {
 	if pokin.Id == 0 {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("data tidak ditemukan")
 	}
 
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, pokin.KodeOpd)
 	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data opd tidak ditemukan")
+		return pohonkinerja.PohonKinerjaOpdResponse{}, web.NewNotFoundError("data opd tidak ditemukan")
 	}
 
 	pelaksanaList, err := service.pohonKinerjaOpdRepository.FindPelaksanaPokin(ctx, tx, fmt.Sprint(pokin.Id))
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 )
 
@@ -10,5 +11,5 @@ type ProgramService interface {
 	Update(ctx context.Context, request programkegiatan.ProgramKegiatanUpdateRequest) (programkegiatan.ProgramKegiatanResponse, error)
 	Delete(ctx context.Context, id string) error
 	FindById(ctx context.Context, id string) (programkegiatan.ProgramKegiatanResponse, error)
-	FindAll(ctx context.Context) ([]programkegiatan.ProgramKegiatanResponse, error)
+	FindAll(ctx context.Context, query domain.ListQuery) ([]programkegiatan.ProgramKegiatanResponse, int, error)
 }
@@ -26,6 +26,10 @@ func NewProgramServiceImpl(programRepository repository.ProgramRepository, DB *s
 }
 
 func (service *ProgramServiceImpl) Create(ctx context.Context, request programkegiatan.ProgramKegiatanCreateRequest) (programkegiatan.ProgramKegiatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return programkegiatan.ProgramKegiatanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return programkegiatan.ProgramKegiatanResponse{}, err
@@ -115,6 +119,10 @@ func (service *ProgramServiceImpl) Create(ctx context.Context, request programke
 }
 
 func (service *ProgramServiceImpl) Update(ctx context.Context, request programkegiatan.ProgramKegiatanUpdateRequest) (programkegiatan.ProgramKegiatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return programkegiatan.ProgramKegiatanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return programkegiatan.ProgramKegiatanResponse{}, err
@@ -312,17 +320,17 @@ func (service *ProgramServiceImpl) FindById(ctx context.Context, programId strin
 	}, nil
 }
 
-func (service *ProgramServiceImpl) FindAll(ctx context.Context) ([]programkegiatan.ProgramKegiatanResponse, error) {
+func (service *ProgramServiceImpl) FindAll(ctx context.Context, query domain.ListQuery) ([]programkegiatan.ProgramKegiatanResponse, int, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("gagal memulai transaksi: %v", err)
+		return nil, 0, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer helper.CommitOrRollback(tx)
 
 	// Mengambil semua program
-	results, err := service.programRepository.FindAll(ctx, tx)
+	results, total, err := service.programRepository.FindAllPaged(ctx, tx, query)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mengambil data program: %v", err)
+		return nil, 0, fmt.Errorf("gagal mengambil data program: %v", err)
 	}
 
 	var programResponses []programkegiatan.ProgramKegiatanResponse
@@ -332,7 +340,7 @@ func (service *ProgramServiceImpl) FindAll(ctx context.Context) ([]programkegiat
 		// Mengambil semua indikator untuk program ini
 		indikators, err := service.programRepository.FindIndikatorByProgramId(ctx, tx, program.Id)
 		if err != nil {
-			return nil, fmt.Errorf("gagal mengambil data indikator untuk program %s: %v", program.Id, err)
+			return nil, 0, fmt.Errorf("gagal mengambil data indikator untuk program %s: %v", program.Id, err)
 		}
 
 		var indikatorResponses []programkegiatan.IndikatorResponse
@@ -340,7 +348,7 @@ func (service *ProgramServiceImpl) FindAll(ctx context.Context) ([]programkegiat
 			// Mengambil semua target untuk setiap indikator
 			targets, err := service.programRepository.FindTargetByIndikatorId(ctx, tx, indikator.Id)
 			if err != nil {
-				return nil, fmt.Errorf("gagal mengambil data target untuk indikator %s: %v", indikator.Id, err)
+				return nil, 0, fmt.Errorf("gagal mengambil data target untuk indikator %s: %v", indikator.Id, err)
 			}
 
 			// Membuat response untuk semua target
@@ -379,5 +387,5 @@ func (service *ProgramServiceImpl) FindAll(ctx context.Context) ([]programkegiat
 		programResponses = append(programResponses, programResponse)
 	}
 
-	return programResponses, nil
+	return programResponses, total, nil
 }
@@ -1,13 +1,13 @@
 package service
 
 import (
+	"ekak_kabupaten_madiun/model/web"
 	"context"
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/web/programprioritaspusat"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
 	"fmt"
 	"strconv"
 
@@ -30,7 +30,7 @@ func NewProgramPrioritasPusatServiceImpl(programPrioritasPusatRepository reposit
 }
 
 func (service *ProgramPrioritasPusatServiceImpl) Create(ctx context.Context, request programprioritaspusat.ProgramPrioritasPusatCreateRequest) (programprioritaspusat.ProgramPrioritasPusatResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return programprioritaspusat.ProgramPrioritasPusatResponse{}, err
 	}
@@ -69,7 +69,7 @@ func (service *ProgramPrioritasPusatServiceImpl) Create(ctx context.Context, req
 }
 
 func (service *ProgramPrioritasPusatServiceImpl) Update(ctx context.Context, request programprioritaspusat.ProgramPrioritasPusatUpdateRequest) (programprioritaspusat.ProgramPrioritasPusatResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return programprioritaspusat.ProgramPrioritasPusatResponse{}, err
 	}
@@ -217,7 +217,7 @@ func (service *ProgramPrioritasPusatServiceImpl) FindByTahun(ctx context.Context
 	// Validasi format tahun
 	_, err = strconv.Atoi(tahun)
 	if err != nil {
-		return nil, errors.New("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 
 	results, err := service.ProgramPrioritasPusatRepository.FindByTahun(ctx, tx, tahun)
@@ -251,7 +251,7 @@ func (service *ProgramPrioritasPusatServiceImpl) FindUnusedByTahun(ctx context.C
 	// Validasi format tahun
 	_, err = strconv.Atoi(tahun)
 	if err != nil {
-		return nil, errors.New("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 
 	results, err := service.ProgramPrioritasPusatRepository.FindUnusedByTahun(ctx, tx, tahun)
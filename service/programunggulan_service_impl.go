@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programunggulan"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
 	"fmt"
 	"strconv"
 
@@ -30,7 +30,7 @@ func NewProgramUnggulanServiceImpl(programUnggulanRepository repository.ProgramU
 }
 
 func (service *ProgramUnggulanServiceImpl) Create(ctx context.Context, request programunggulan.ProgramUnggulanCreateRequest) (programunggulan.ProgramUnggulanResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return programunggulan.ProgramUnggulanResponse{}, err
 	}
@@ -69,7 +69,7 @@ func (service *ProgramUnggulanServiceImpl) Create(ctx context.Context, request p
 }
 
 func (service *ProgramUnggulanServiceImpl) Update(ctx context.Context, request programunggulan.ProgramUnggulanUpdateRequest) (programunggulan.ProgramUnggulanResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return programunggulan.ProgramUnggulanResponse{}, err
 	}
@@ -212,7 +212,7 @@ func (service *ProgramUnggulanServiceImpl) FindByTahun(ctx context.Context, tahu
 	// Validasi format tahun
 	_, err = strconv.Atoi(tahun)
 	if err != nil {
-		return nil, errors.New("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 
 	results, err := service.ProgramUnggulanRepository.FindByTahun(ctx, tx, tahun)
@@ -246,7 +246,7 @@ func (service *ProgramUnggulanServiceImpl) FindUnusedByTahun(ctx context.Context
 	// Validasi format tahun
 	_, err = strconv.Atoi(tahun)
 	if err != nil {
-		return nil, errors.New("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 
 	results, err := service.ProgramUnggulanRepository.FindUnusedByTahun(ctx, tx, tahun)
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+)
+
+type RealisasiService interface {
+	// Create mengisi realisasi satu periode, periode yang sudah diisi akan diperbarui
+	Create(ctx context.Context, request realisasi.RealisasiIndikatorCreateRequest) (realisasi.RealisasiIndikatorResponse, error)
+	Update(ctx context.Context, request realisasi.RealisasiIndikatorUpdateRequest) (realisasi.RealisasiIndikatorResponse, error)
+	Delete(ctx context.Context, id int) error
+	FindByIndikator(ctx context.Context, indikatorId string, tahun string) (realisasi.CapaianIndikatorResponse, error)
+	UpdatePolaritas(ctx context.Context, request realisasi.PolaritasIndikatorRequest) (realisasi.PolaritasIndikatorResponse, error)
+	CapaianOpd(ctx context.Context, kodeOpd string, tahun string) (realisasi.CapaianOpdResponse, error)
+	CapaianPemda(ctx context.Context, tahun string) (realisasi.CapaianPemdaResponse, error)
+}
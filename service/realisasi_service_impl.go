@@ -0,0 +1,680 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Sumber capaian sasaran di CapaianSasaranResponse
+const (
+	sumberCapaianIndikator    = "indikator"
+	sumberCapaianPohonKinerja = "pohon_kinerja"
+)
+
+type RealisasiServiceImpl struct {
+	RealisasiRepository repository.RealisasiRepository
+	LockDataRepository  repository.LockDataRepository
+	AuditLogRepository  repository.AuditLogRepository
+	DB                  *sql.DB
+	Validate            *validator.Validate
+}
+
+func NewRealisasiServiceImpl(realisasiRepository repository.RealisasiRepository, lockDataRepository repository.LockDataRepository, auditLogRepository repository.AuditLogRepository, DB *sql.DB, validate *validator.Validate) *RealisasiServiceImpl {
+	return &RealisasiServiceImpl{
+		RealisasiRepository: realisasiRepository,
+		LockDataRepository:  lockDataRepository,
+		AuditLogRepository:  auditLogRepository,
+		DB:                  DB,
+		Validate:            validate,
+	}
+}
+
+func (service *RealisasiServiceImpl) Create(ctx context.Context, request realisasi.RealisasiIndikatorCreateRequest) (realisasi.RealisasiIndikatorResponse, error) {
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	if request.Periode > domain.JumlahPeriodeRealisasi[request.JenisPeriode] {
+		return realisasi.RealisasiIndikatorResponse{}, web.NewBadRequestError(fmt.Sprintf("periode %s hanya 1 sampai %d", request.JenisPeriode, domain.JumlahPeriodeRealisasi[request.JenisPeriode]))
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	defer tx.Rollback()
+
+	indikator, err := service.findIndikatorForUpdate(ctx, tx, request.IndikatorId, request.Tahun)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+
+	existing, err := service.RealisasiRepository.FindByIndikator(ctx, tx, request.IndikatorId, request.Tahun)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	var before *domain.RealisasiIndikator
+	for i, item := range existing {
+		if item.JenisPeriode != request.JenisPeriode {
+			return realisasi.RealisasiIndikatorResponse{}, web.NewConflictError(fmt.Sprintf(
+				"realisasi indikator %s tahun %s sudah diisi per %s, hapus realisasi tersebut sebelum mengisi per %s",
+				request.IndikatorId, request.Tahun, item.JenisPeriode, request.JenisPeriode))
+		}
+		if item.Periode == request.Periode {
+			before = &existing[i]
+		}
+	}
+
+	data := domain.RealisasiIndikator{
+		IndikatorId:  request.IndikatorId,
+		Tahun:        request.Tahun,
+		JenisPeriode: request.JenisPeriode,
+		Periode:      request.Periode,
+		Realisasi:    *request.Realisasi,
+		Bukti:        request.Bukti,
+		KodeOpd:      indikator.KodeOpd,
+	}
+	action := domain.AuditActionCreate
+	if before != nil {
+		action = domain.AuditActionUpdate
+		data.Id = before.Id
+		data.CreatedBy = before.CreatedBy
+		err = service.RealisasiRepository.Update(ctx, tx, data)
+	} else {
+		if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok {
+			data.CreatedBy = claims.Nip
+		}
+		data, err = service.RealisasiRepository.Create(ctx, tx, data)
+	}
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+
+	err = service.recordAudit(ctx, tx, action, data, before, &data)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	response, err := service.realisasiResponse(ctx, tx, data, indikator.Polaritas)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	return response, nil
+}
+
+func (service *RealisasiServiceImpl) Update(ctx context.Context, request realisasi.RealisasiIndikatorUpdateRequest) (realisasi.RealisasiIndikatorResponse, error) {
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := service.findRealisasi(ctx, tx, request.Id)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	indikator, err := service.findIndikatorForUpdate(ctx, tx, before.IndikatorId, before.Tahun)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+
+	data := before
+	data.Realisasi = *request.Realisasi
+	data.Bukti = request.Bukti
+	data.KodeOpd = indikator.KodeOpd
+	if err := service.RealisasiRepository.Update(ctx, tx, data); err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+
+	err = service.recordAudit(ctx, tx, domain.AuditActionUpdate, data, &before, &data)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	response, err := service.realisasiResponse(ctx, tx, data, indikator.Polaritas)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	return response, nil
+}
+
+func (service *RealisasiServiceImpl) Delete(ctx context.Context, id int) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := service.findRealisasi(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := service.findIndikatorForUpdate(ctx, tx, before.IndikatorId, before.Tahun); err != nil {
+		return err
+	}
+
+	if err := service.RealisasiRepository.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := service.recordAudit(ctx, tx, domain.AuditActionDelete, before, &before, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (service *RealisasiServiceImpl) FindByIndikator(ctx context.Context, indikatorId string, tahun string) (realisasi.CapaianIndikatorResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	indikator, err := service.findIndikator(ctx, tx, indikatorId)
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+	// indikator pemda boleh dilihat semua OPD
+	if indikator.KodeOpd != "" {
+		if err := helper.ValidateKodeOpdAccess(ctx, indikator.KodeOpd); err != nil {
+			return realisasi.CapaianIndikatorResponse{}, err
+		}
+	}
+
+	target, satuan, err := service.RealisasiRepository.FindTarget(ctx, tx, indikatorId, tahun)
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+	items, err := service.RealisasiRepository.FindByIndikator(ctx, tx, indikatorId, tahun)
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+
+	return toCapaianIndikatorResponse(domain.CapaianIndikator{
+		Id:        indikator.Id,
+		Indikator: indikator.Indikator,
+		Polaritas: indikator.Polaritas,
+		Target:    target,
+		Satuan:    satuan,
+	}, items), nil
+}
+
+func (service *RealisasiServiceImpl) UpdatePolaritas(ctx context.Context, request realisasi.PolaritasIndikatorRequest) (realisasi.PolaritasIndikatorResponse, error) {
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return realisasi.PolaritasIndikatorResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.PolaritasIndikatorResponse{}, err
+	}
+	defer tx.Rollback()
+
+	indikator, err := service.findIndikator(ctx, tx, request.IndikatorId)
+	if err != nil {
+		return realisasi.PolaritasIndikatorResponse{}, err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, indikator.KodeOpd); err != nil {
+		return realisasi.PolaritasIndikatorResponse{}, err
+	}
+
+	if indikator.Polaritas != request.Polaritas {
+		if err := service.RealisasiRepository.UpdatePolaritas(ctx, tx, indikator.Id, request.Polaritas); err != nil {
+			return realisasi.PolaritasIndikatorResponse{}, err
+		}
+		err = recordAuditLog(ctx, tx, service.AuditLogRepository, domain.AuditLog{
+			Action:     domain.AuditActionUpdate,
+			EntityType: domain.AuditEntityIndikator,
+			EntityId:   indikator.Id,
+			KodeOpd:    indikator.KodeOpd,
+		}, map[string]string{"polaritas": indikator.Polaritas}, map[string]string{"polaritas": request.Polaritas})
+		if err != nil {
+			return realisasi.PolaritasIndikatorResponse{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return realisasi.PolaritasIndikatorResponse{}, err
+	}
+
+	return realisasi.PolaritasIndikatorResponse{
+		IndikatorId: indikator.Id,
+		Indikator:   indikator.Indikator,
+		Polaritas:   request.Polaritas,
+	}, nil
+}
+
+func (service *RealisasiServiceImpl) CapaianOpd(ctx context.Context, kodeOpd string, tahun string) (realisasi.CapaianOpdResponse, error) {
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return realisasi.CapaianOpdResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.CapaianOpdResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	capaian, err := service.loadCapaianKinerja(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return realisasi.CapaianOpdResponse{}, err
+	}
+	sasarans, err := service.RealisasiRepository.FindSasaranOpd(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return realisasi.CapaianOpdResponse{}, err
+	}
+
+	response := realisasi.CapaianOpdResponse{
+		KodeOpd:      kodeOpd,
+		Tahun:        tahun,
+		PohonKinerja: capaian.pohon(),
+		SasaranOpd:   capaian.sasaran(sasarans, capaian.indikatorSasaranOpd),
+	}
+	// capaian OPD dari sasaran opd, jika belum ada sasaran memakai pohon kinerja teratas
+	var nilai []*float64
+	for _, sasaran := range response.SasaranOpd {
+		nilai = append(nilai, sasaran.Capaian)
+	}
+	if len(response.SasaranOpd) == 0 {
+		for _, pokin := range response.PohonKinerja {
+			nilai = append(nilai, pokin.Capaian)
+		}
+	}
+	response.Capaian = rataRataCapaian(nilai)
+	return response, nil
+}
+
+func (service *RealisasiServiceImpl) CapaianPemda(ctx context.Context, tahun string) (realisasi.CapaianPemdaResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.CapaianPemdaResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	// seluruh pohon kinerja pemda dan OPD dihitung agar sasaran pemda bisa memakai capaian pohon tematiknya
+	capaian, err := service.loadCapaianKinerja(ctx, tx, "", tahun)
+	if err != nil {
+		return realisasi.CapaianPemdaResponse{}, err
+	}
+	capaian.pohon()
+	sasarans, err := service.RealisasiRepository.FindSasaranPemda(ctx, tx, tahun)
+	if err != nil {
+		return realisasi.CapaianPemdaResponse{}, err
+	}
+
+	response := realisasi.CapaianPemdaResponse{
+		Tahun:        tahun,
+		SasaranPemda: capaian.sasaran(sasarans, capaian.indikatorSasaranPemda),
+	}
+	var nilai []*float64
+	for _, sasaran := range response.SasaranPemda {
+		nilai = append(nilai, sasaran.Capaian)
+	}
+	response.Capaian = rataRataCapaian(nilai)
+	return response, nil
+}
+
+func (service *RealisasiServiceImpl) loadCapaianKinerja(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) (*capaianKinerja, error) {
+	indikators, err := service.RealisasiRepository.FindCapaianIndikator(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	realisasis, err := service.RealisasiRepository.FindByTahun(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	rekins, err := service.RealisasiRepository.FindCapaianRekin(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	pokins, err := service.RealisasiRepository.FindCapaianPokin(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	return newCapaianKinerja(indikators, realisasis, rekins, pokins), nil
+}
+
+func (service *RealisasiServiceImpl) findIndikator(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.IndikatorRealisasi, error) {
+	indikator, err := service.RealisasiRepository.FindIndikator(ctx, tx, indikatorId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return indikator, web.NewNotFoundError(fmt.Sprintf("indikator %s tidak ditemukan", indikatorId))
+	}
+	return indikator, err
+}
+
+// findIndikatorForUpdate indikator yang realisasinya akan diubah, indikator pemda (tanpa kode_opd) hanya bisa diubah super_admin
+func (service *RealisasiServiceImpl) findIndikatorForUpdate(ctx context.Context, tx *sql.Tx, indikatorId string, tahun string) (domain.IndikatorRealisasi, error) {
+	indikator, err := service.findIndikator(ctx, tx, indikatorId)
+	if err != nil {
+		return indikator, err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, indikator.KodeOpd); err != nil {
+		return indikator, err
+	}
+	if err := checkLockData(ctx, tx, service.LockDataRepository, domain.JenisLockRealisasiKinerja, indikator.KodeOpd, tahun); err != nil {
+		return indikator, err
+	}
+	return indikator, nil
+}
+
+func (service *RealisasiServiceImpl) findRealisasi(ctx context.Context, tx *sql.Tx, id int) (domain.RealisasiIndikator, error) {
+	data, err := service.RealisasiRepository.FindById(ctx, tx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return data, web.NewNotFoundError(fmt.Sprintf("realisasi %d tidak ditemukan", id))
+	}
+	return data, err
+}
+
+func (service *RealisasiServiceImpl) recordAudit(ctx context.Context, tx *sql.Tx, action string, data domain.RealisasiIndikator, before, after *domain.RealisasiIndikator) error {
+	var beforeData, afterData interface{}
+	if before != nil {
+		beforeData = before
+	}
+	if after != nil {
+		afterData = after
+	}
+	return recordAuditLog(ctx, tx, service.AuditLogRepository, domain.AuditLog{
+		Action:     action,
+		EntityType: domain.AuditEntityRealisasi,
+		EntityId:   strconv.Itoa(data.Id),
+		KodeOpd:    data.KodeOpd,
+		Tahun:      data.Tahun,
+	}, beforeData, afterData)
+}
+
+func (service *RealisasiServiceImpl) realisasiResponse(ctx context.Context, tx *sql.Tx, data domain.RealisasiIndikator, polaritas string) (realisasi.RealisasiIndikatorResponse, error) {
+	target, _, err := service.RealisasiRepository.FindTarget(ctx, tx, data.IndikatorId, data.Tahun)
+	if err != nil {
+		return realisasi.RealisasiIndikatorResponse{}, err
+	}
+	return toRealisasiIndikatorResponse(data, target, polaritas), nil
+}
+
+func toRealisasiIndikatorResponse(data domain.RealisasiIndikator, target string, polaritas string) realisasi.RealisasiIndikatorResponse {
+	response := realisasi.RealisasiIndikatorResponse{
+		Id:           data.Id,
+		IndikatorId:  data.IndikatorId,
+		Tahun:        data.Tahun,
+		JenisPeriode: data.JenisPeriode,
+		Periode:      data.Periode,
+		Realisasi:    data.Realisasi,
+		Bukti:        data.Bukti,
+		KodeOpd:      data.KodeOpd,
+		CreatedBy:    data.CreatedBy,
+	}
+	if nilaiTarget, ok := parseTargetCapaian(target); ok {
+		response.Capaian = hitungCapaian(nilaiTarget, data.Realisasi, polaritas)
+	}
+	if !data.UpdatedAt.IsZero() {
+		response.UpdatedAt = data.UpdatedAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
+
+// toCapaianIndikatorResponse realisasi bersifat kumulatif, capaian tahunan memakai periode terakhir yang diisi
+func toCapaianIndikatorResponse(indikator domain.CapaianIndikator, items []domain.RealisasiIndikator) realisasi.CapaianIndikatorResponse {
+	polaritas := indikator.Polaritas
+	if polaritas == "" {
+		polaritas = domain.PolaritasPositif
+	}
+	response := realisasi.CapaianIndikatorResponse{
+		Id:        indikator.Id,
+		Indikator: indikator.Indikator,
+		Polaritas: polaritas,
+		Target:    indikator.Target,
+		Satuan:    indikator.Satuan,
+		Realisasi: make([]realisasi.RealisasiIndikatorResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		response.Realisasi = append(response.Realisasi, toRealisasiIndikatorResponse(item, indikator.Target, polaritas))
+	}
+
+	if terakhir, ok := realisasiTerakhir(items); ok {
+		nilai := terakhir.Realisasi
+		response.JenisPeriode = terakhir.JenisPeriode
+		response.PeriodeTerakhir = terakhir.Periode
+		response.RealisasiTerakhir = &nilai
+		if target, ok := parseTargetCapaian(indikator.Target); ok {
+			response.Capaian = hitungCapaian(target, nilai, polaritas)
+		}
+	}
+	return response
+}
+
+// parseTargetCapaian target disimpan sebagai teks ("85", "85,5", "12.5 %", "1.250,5"),
+// target yang bukan angka (contoh "Baik", "WTP") tidak bisa dihitung capaiannya
+func parseTargetCapaian(target string) (float64, bool) {
+	value := strings.ReplaceAll(strings.TrimSpace(target), " ", "")
+	value = strings.TrimSuffix(value, "%")
+	if strings.Contains(value, ",") {
+		// format indonesia: titik pemisah ribuan, koma pemisah desimal
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// hitungCapaian persentase capaian, polaritas negatif memakai rumus (2 x target - realisasi) / target
+// sehingga realisasi di bawah target menghasilkan capaian di atas 100%. Target 0 tidak bisa dihitung.
+func hitungCapaian(target float64, nilaiRealisasi float64, polaritas string) *float64 {
+	if target == 0 {
+		return nil
+	}
+	capaian := nilaiRealisasi / target * 100
+	if polaritas == domain.PolaritasNegatif {
+		capaian = math.Max((2*target-nilaiRealisasi)/target*100, 0)
+	}
+	return bulatkanPersen(capaian)
+}
+
+// realisasiTerakhir realisasi dengan periode paling akhir, triwulan disetarakan dengan bulan terakhirnya
+func realisasiTerakhir(items []domain.RealisasiIndikator) (domain.RealisasiIndikator, bool) {
+	var terakhir domain.RealisasiIndikator
+	bulanTerakhir := 0
+	for _, item := range items {
+		bulan := item.Periode
+		if item.JenisPeriode == domain.JenisPeriodeTriwulan {
+			bulan = item.Periode * 3
+		}
+		if bulan > bulanTerakhir {
+			terakhir = item
+			bulanTerakhir = bulan
+		}
+	}
+	return terakhir, bulanTerakhir > 0
+}
+
+// rataRataCapaian rata-rata capaian yang bisa dihitung, nil jika tidak ada sama sekali
+func rataRataCapaian(nilai []*float64) *float64 {
+	var total float64
+	var jumlah int
+	for _, capaian := range nilai {
+		if capaian != nil {
+			total += *capaian
+			jumlah++
+		}
+	}
+	if jumlah == 0 {
+		return nil
+	}
+	return bulatkanPersen(total / float64(jumlah))
+}
+
+// bulatkanPersen persentase dibulatkan 2 desimal
+func bulatkanPersen(persen float64) *float64 {
+	persen = math.Round(persen*100) / 100
+	return &persen
+}
+
+// capaianKinerja menyusun capaian dari indikator → rencana kinerja → pohon kinerja → sasaran.
+// Indikator dikelompokkan ke satu pemilik dengan urutan rencana kinerja, pohon kinerja, sasaran opd, sasaran pemda.
+type capaianKinerja struct {
+	pokins                []domain.CapaianPokin
+	indikatorRekin        map[string][]realisasi.CapaianIndikatorResponse
+	indikatorPokin        map[int][]realisasi.CapaianIndikatorResponse
+	indikatorSasaranOpd   map[int][]realisasi.CapaianIndikatorResponse
+	indikatorSasaranPemda map[int][]realisasi.CapaianIndikatorResponse
+	rekinPokin            map[int][]realisasi.CapaianRekinResponse
+	// capaianPokin hasil pohon(), dipakai sasaran yang tidak punya indikator sendiri
+	capaianPokin map[int]*float64
+}
+
+func newCapaianKinerja(indikators []domain.CapaianIndikator, realisasis []domain.RealisasiIndikator, rekins []domain.CapaianRekin, pokins []domain.CapaianPokin) *capaianKinerja {
+	realisasiIndikator := make(map[string][]domain.RealisasiIndikator)
+	for _, item := range realisasis {
+		realisasiIndikator[item.IndikatorId] = append(realisasiIndikator[item.IndikatorId], item)
+	}
+
+	capaian := &capaianKinerja{
+		pokins:                pokins,
+		indikatorRekin:        make(map[string][]realisasi.CapaianIndikatorResponse),
+		indikatorPokin:        make(map[int][]realisasi.CapaianIndikatorResponse),
+		indikatorSasaranOpd:   make(map[int][]realisasi.CapaianIndikatorResponse),
+		indikatorSasaranPemda: make(map[int][]realisasi.CapaianIndikatorResponse),
+		rekinPokin:            make(map[int][]realisasi.CapaianRekinResponse),
+		capaianPokin:          make(map[int]*float64),
+	}
+	for _, indikator := range indikators {
+		response := toCapaianIndikatorResponse(indikator, realisasiIndikator[indikator.Id])
+		switch {
+		case indikator.RencanaKinerjaId != "":
+			capaian.indikatorRekin[indikator.RencanaKinerjaId] = append(capaian.indikatorRekin[indikator.RencanaKinerjaId], response)
+		case indikator.PokinId != 0:
+			capaian.indikatorPokin[indikator.PokinId] = append(capaian.indikatorPokin[indikator.PokinId], response)
+		case indikator.SasaranOpdId != 0:
+			capaian.indikatorSasaranOpd[indikator.SasaranOpdId] = append(capaian.indikatorSasaranOpd[indikator.SasaranOpdId], response)
+		case indikator.SasaranPemdaId != 0:
+			capaian.indikatorSasaranPemda[indikator.SasaranPemdaId] = append(capaian.indikatorSasaranPemda[indikator.SasaranPemdaId], response)
+		}
+	}
+
+	for _, rekin := range rekins {
+		indikatorRekin := capaian.indikatorRekin[rekin.Id]
+		capaian.rekinPokin[rekin.IdPohon] = append(capaian.rekinPokin[rekin.IdPohon], realisasi.CapaianRekinResponse{
+			Id:                 rekin.Id,
+			NamaRencanaKinerja: rekin.NamaRencanaKinerja,
+			PegawaiId:          rekin.PegawaiId,
+			Capaian:            capaianIndikator(indikatorRekin),
+			Indikator:          nonNilIndikator(indikatorRekin),
+		})
+	}
+	return capaian
+}
+
+// pohon susunan pohon kinerja beserta capaiannya, pohon yang induknya tidak ikut dimuat menjadi akar
+func (capaian *capaianKinerja) pohon() []realisasi.CapaianPokinResponse {
+	ids := make(map[int]bool, len(capaian.pokins))
+	childs := make(map[int][]domain.CapaianPokin)
+	for _, pokin := range capaian.pokins {
+		ids[pokin.Id] = true
+	}
+	var roots []domain.CapaianPokin
+	for _, pokin := range capaian.pokins {
+		if pokin.Parent == 0 || pokin.Parent == pokin.Id || !ids[pokin.Parent] {
+			roots = append(roots, pokin)
+			continue
+		}
+		childs[pokin.Parent] = append(childs[pokin.Parent], pokin)
+	}
+
+	visited := make(map[int]bool, len(capaian.pokins))
+	var build func(pokin domain.CapaianPokin) realisasi.CapaianPokinResponse
+	build = func(pokin domain.CapaianPokin) realisasi.CapaianPokinResponse {
+		visited[pokin.Id] = true
+		response := realisasi.CapaianPokinResponse{
+			Id:             pokin.Id,
+			Parent:         pokin.Parent,
+			NamaPohon:      pokin.NamaPohon,
+			JenisPohon:     pokin.JenisPohon,
+			LevelPohon:     pokin.LevelPohon,
+			Indikator:      nonNilIndikator(capaian.indikatorPokin[pokin.Id]),
+			RencanaKinerja: make([]realisasi.CapaianRekinResponse, 0),
+			Childs:         make([]realisasi.CapaianPokinResponse, 0),
+		}
+
+		var turunan []*float64
+		for _, child := range childs[pokin.Id] {
+			if visited[child.Id] {
+				continue
+			}
+			childResponse := build(child)
+			response.Childs = append(response.Childs, childResponse)
+			turunan = append(turunan, childResponse.Capaian)
+		}
+		for _, rekin := range capaian.rekinPokin[pokin.Id] {
+			response.RencanaKinerja = append(response.RencanaKinerja, rekin)
+			turunan = append(turunan, rekin.Capaian)
+		}
+
+		response.CapaianLangsung = capaianIndikator(response.Indikator)
+		response.CapaianTurunan = rataRataCapaian(turunan)
+		response.Capaian = response.CapaianLangsung
+		if response.Capaian == nil {
+			response.Capaian = response.CapaianTurunan
+		}
+		capaian.capaianPokin[pokin.Id] = response.Capaian
+		return response
+	}
+
+	responses := make([]realisasi.CapaianPokinResponse, 0, len(roots))
+	for _, root := range roots {
+		responses = append(responses, build(root))
+	}
+	return responses
+}
+
+// sasaran capaian sasaran dari indikatornya sendiri, jika tidak ada memakai capaian pohon kinerja sasaran (pohon() harus dipanggil dulu)
+func (capaian *capaianKinerja) sasaran(sasarans []domain.CapaianSasaran, indikatorSasaran map[int][]realisasi.CapaianIndikatorResponse) []realisasi.CapaianSasaranResponse {
+	responses := make([]realisasi.CapaianSasaranResponse, 0, len(sasarans))
+	for _, sasaran := range sasarans {
+		response := realisasi.CapaianSasaranResponse{
+			Id:          sasaran.Id,
+			NamaSasaran: sasaran.Nama,
+			PokinId:     sasaran.PokinId,
+			Indikator:   nonNilIndikator(indikatorSasaran[sasaran.Id]),
+		}
+		if response.Capaian = capaianIndikator(response.Indikator); response.Capaian != nil {
+			response.SumberCapaian = sumberCapaianIndikator
+		} else if response.Capaian = capaian.capaianPokin[sasaran.PokinId]; response.Capaian != nil {
+			response.SumberCapaian = sumberCapaianPohonKinerja
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func capaianIndikator(indikators []realisasi.CapaianIndikatorResponse) *float64 {
+	nilai := make([]*float64, 0, len(indikators))
+	for _, indikator := range indikators {
+		nilai = append(nilai, indikator.Capaian)
+	}
+	return rataRataCapaian(nilai)
+}
+
+func nonNilIndikator(indikators []realisasi.CapaianIndikatorResponse) []realisasi.CapaianIndikatorResponse {
+	if indikators == nil {
+		return make([]realisasi.CapaianIndikatorResponse, 0)
+	}
+	return indikators
+}
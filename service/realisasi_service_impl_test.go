@@ -0,0 +1,168 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"testing"
+)
+
+func TestParseTargetCapaian(t *testing.T) {
+	tests := []struct {
+		target string
+		want   float64
+		ok     bool
+	}{
+		{target: "85", want: 85, ok: true},
+		{target: "85,5", want: 85.5, ok: true},
+		{target: " 12.5 %", want: 12.5, ok: true},
+		{target: "1.250,75", want: 1250.75, ok: true},
+		{target: "WTP"},
+		{target: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got, ok := parseTargetCapaian(tt.target)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parseTargetCapaian(%q) = (%v, %v), want (%v, %v)", tt.target, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestHitungCapaian(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    float64
+		realisasi float64
+		polaritas string
+		want      *float64
+	}{
+		{name: "positif tercapai sebagian", target: 80, realisasi: 60, polaritas: domain.PolaritasPositif, want: floatPtr(75)},
+		{name: "positif melebihi target", target: 80, realisasi: 100, polaritas: domain.PolaritasPositif, want: floatPtr(125)},
+		{name: "positif dibulatkan", target: 3, realisasi: 1, polaritas: domain.PolaritasPositif, want: floatPtr(33.33)},
+		{name: "negatif di bawah target", target: 10, realisasi: 8, polaritas: domain.PolaritasNegatif, want: floatPtr(120)},
+		{name: "negatif di atas target", target: 10, realisasi: 12, polaritas: domain.PolaritasNegatif, want: floatPtr(80)},
+		{name: "negatif tidak kurang dari nol", target: 10, realisasi: 30, polaritas: domain.PolaritasNegatif, want: floatPtr(0)},
+		{name: "target nol", target: 0, realisasi: 5, polaritas: domain.PolaritasPositif},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hitungCapaian(tt.target, tt.realisasi, tt.polaritas)
+			if !equalCapaian(got, tt.want) {
+				t.Errorf("hitungCapaian() = %v, want %v", formatCapaian(got), formatCapaian(tt.want))
+			}
+		})
+	}
+}
+
+func TestToCapaianIndikatorResponsePeriodeTerakhir(t *testing.T) {
+	indikator := domain.CapaianIndikator{Id: "IND-1", Target: "100", Polaritas: domain.PolaritasPositif}
+	items := []domain.RealisasiIndikator{
+		{IndikatorId: "IND-1", JenisPeriode: domain.JenisPeriodeTriwulan, Periode: 1, Realisasi: 20},
+		{IndikatorId: "IND-1", JenisPeriode: domain.JenisPeriodeTriwulan, Periode: 3, Realisasi: 70},
+		{IndikatorId: "IND-1", JenisPeriode: domain.JenisPeriodeTriwulan, Periode: 2, Realisasi: 45},
+	}
+
+	response := toCapaianIndikatorResponse(indikator, items)
+	if response.PeriodeTerakhir != 3 || !equalCapaian(response.Capaian, floatPtr(70)) {
+		t.Errorf("periode terakhir %d capaian %v, want 3 capaian 70", response.PeriodeTerakhir, formatCapaian(response.Capaian))
+	}
+	if len(response.Realisasi) != 3 || !equalCapaian(response.Realisasi[0].Capaian, floatPtr(20)) {
+		t.Errorf("realisasi per periode = %+v", response.Realisasi)
+	}
+
+	kosong := toCapaianIndikatorResponse(indikator, nil)
+	if kosong.Capaian != nil || kosong.RealisasiTerakhir != nil || kosong.Realisasi == nil {
+		t.Errorf("indikator tanpa realisasi = %+v", kosong)
+	}
+}
+
+func TestCapaianKinerjaRollup(t *testing.T) {
+	// 10 strategic → 11 tactical (indikator sendiri) dan 12 tactical (dari rencana kinerja)
+	pokins := []domain.CapaianPokin{
+		{Id: 10, Parent: 1, LevelPohon: 4},
+		{Id: 11, Parent: 10, LevelPohon: 5},
+		{Id: 12, Parent: 10, LevelPohon: 5},
+	}
+	rekins := []domain.CapaianRekin{
+		{Id: "REKIN-1", IdPohon: 12},
+		{Id: "REKIN-2", IdPohon: 12},
+	}
+	indikators := []domain.CapaianIndikator{
+		{Id: "IND-POKIN", PokinId: 11, Target: "100"},
+		{Id: "IND-REKIN-1", RencanaKinerjaId: "REKIN-1", Target: "50"},
+		{Id: "IND-REKIN-2", RencanaKinerjaId: "REKIN-2", Target: "10", Polaritas: domain.PolaritasNegatif},
+		{Id: "IND-REKIN-3", RencanaKinerjaId: "REKIN-2", Target: "Baik"},
+		{Id: "IND-SASARAN", SasaranOpdId: 2, Target: "40"},
+	}
+	realisasis := []domain.RealisasiIndikator{
+		{IndikatorId: "IND-POKIN", JenisPeriode: domain.JenisPeriodeBulan, Periode: 6, Realisasi: 90},
+		{IndikatorId: "IND-REKIN-1", JenisPeriode: domain.JenisPeriodeTriwulan, Periode: 2, Realisasi: 40},
+		{IndikatorId: "IND-REKIN-2", JenisPeriode: domain.JenisPeriodeTriwulan, Periode: 2, Realisasi: 9},
+		{IndikatorId: "IND-SASARAN", JenisPeriode: domain.JenisPeriodeTriwulan, Periode: 4, Realisasi: 30},
+	}
+
+	capaian := newCapaianKinerja(indikators, realisasis, rekins, pokins)
+	pohon := capaian.pohon()
+	if len(pohon) != 1 || len(pohon[0].Childs) != 2 {
+		t.Fatalf("pohon = %+v, want 1 akar dengan 2 anak", pohon)
+	}
+
+	// rekin 1 = 80, rekin 2 = 110 (indikator non angka diabaikan), pohon 12 = 95, pohon 11 = 90, pohon 10 = 92.5
+	tests := []struct {
+		name string
+		got  *float64
+		want *float64
+	}{
+		{name: "pohon dengan indikator sendiri", got: pohon[0].Childs[0].Capaian, want: floatPtr(90)},
+		{name: "rencana kinerja", got: pohon[0].Childs[1].RencanaKinerja[1].Capaian, want: floatPtr(110)},
+		{name: "pohon dari rencana kinerja", got: pohon[0].Childs[1].Capaian, want: floatPtr(95)},
+		{name: "pohon dari pohon anak", got: pohon[0].Capaian, want: floatPtr(92.5)},
+		{name: "pohon tanpa indikator sendiri", got: pohon[0].CapaianLangsung, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !equalCapaian(tt.got, tt.want) {
+				t.Errorf("capaian = %v, want %v", formatCapaian(tt.got), formatCapaian(tt.want))
+			}
+		})
+	}
+
+	sasarans := capaian.sasaran([]domain.CapaianSasaran{
+		{Id: 1, PokinId: 10},
+		{Id: 2, PokinId: 10},
+		{Id: 3, PokinId: 99},
+	}, capaian.indikatorSasaranOpd)
+	wantSasaran := []struct {
+		capaian *float64
+		sumber  string
+	}{
+		{capaian: floatPtr(92.5), sumber: sumberCapaianPohonKinerja},
+		{capaian: floatPtr(75), sumber: sumberCapaianIndikator},
+		{},
+	}
+	for i, want := range wantSasaran {
+		if !equalCapaian(sasarans[i].Capaian, want.capaian) || sasarans[i].SumberCapaian != want.sumber {
+			t.Errorf("sasaran %d = (%v, %q), want (%v, %q)", sasarans[i].Id, formatCapaian(sasarans[i].Capaian), sasarans[i].SumberCapaian, formatCapaian(want.capaian), want.sumber)
+		}
+	}
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func equalCapaian(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func formatCapaian(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
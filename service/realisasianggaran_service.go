@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/realisasianggaran"
+)
+
+// RealisasiAnggaranService bulan 0 pada laporan berarti bulan berjalan (bulan 12 untuk tahun yang sudah lewat)
+type RealisasiAnggaranService interface {
+	// Create mengisi realisasi satu bulan, bulan yang sudah diisi akan diperbarui
+	Create(ctx context.Context, request realisasianggaran.RealisasiAnggaranCreateRequest) (realisasianggaran.RealisasiAnggaranResponse, error)
+	Update(ctx context.Context, request realisasianggaran.RealisasiAnggaranUpdateRequest) (realisasianggaran.RealisasiAnggaranResponse, error)
+	Delete(ctx context.Context, id int) error
+	FindByRencanaAksi(ctx context.Context, renaksiId string, bulan int) (realisasianggaran.RencanaAksiSerapanResponse, error)
+	Laporan(ctx context.Context, kodeOpd string, tahun string, bulan int) (realisasianggaran.LaporanSerapanResponse, error)
+	Deviasi(ctx context.Context, kodeOpd string, tahun string, bulan int, toleransi float64) (realisasianggaran.DeviasiSerapanResponse, error)
+}
@@ -0,0 +1,628 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasianggaran"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type RealisasiAnggaranServiceImpl struct {
+	RealisasiAnggaranRepository repository.RealisasiAnggaranRepository
+	LockDataRepository          repository.LockDataRepository
+	AuditLogRepository          repository.AuditLogRepository
+	DB                          *sql.DB
+	Validate                    *validator.Validate
+}
+
+func NewRealisasiAnggaranServiceImpl(realisasiAnggaranRepository repository.RealisasiAnggaranRepository, lockDataRepository repository.LockDataRepository, auditLogRepository repository.AuditLogRepository, DB *sql.DB, validate *validator.Validate) *RealisasiAnggaranServiceImpl {
+	return &RealisasiAnggaranServiceImpl{
+		RealisasiAnggaranRepository: realisasiAnggaranRepository,
+		LockDataRepository:          lockDataRepository,
+		AuditLogRepository:          auditLogRepository,
+		DB:                          DB,
+		Validate:                    validate,
+	}
+}
+
+func (service *RealisasiAnggaranServiceImpl) Create(ctx context.Context, request realisasianggaran.RealisasiAnggaranCreateRequest) (realisasianggaran.RealisasiAnggaranResponse, error) {
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	defer tx.Rollback()
+
+	kodeOpd, tahun, err := service.findRencanaAksiForUpdate(ctx, tx, request.RenaksiId)
+	if err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+
+	data := domain.RealisasiAnggaran{
+		RenaksiId:  request.RenaksiId,
+		Bulan:      request.Bulan,
+		Realisasi:  *request.Realisasi,
+		Keterangan: request.Keterangan,
+		KodeOpd:    kodeOpd,
+		Tahun:      tahun,
+	}
+	existing, err := service.RealisasiAnggaranRepository.FindByRenaksiBulan(ctx, tx, request.RenaksiId, request.Bulan)
+	switch {
+	case err == nil:
+		data.Id = existing.Id
+		data.CreatedBy = existing.CreatedBy
+		if err := service.RealisasiAnggaranRepository.Update(ctx, tx, data); err != nil {
+			return realisasianggaran.RealisasiAnggaranResponse{}, err
+		}
+		err = service.recordAudit(ctx, tx, domain.AuditActionUpdate, data, existing, data)
+		if err != nil {
+			return realisasianggaran.RealisasiAnggaranResponse{}, err
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok {
+			data.CreatedBy = claims.Nip
+		}
+		data, err = service.RealisasiAnggaranRepository.Create(ctx, tx, data)
+		if err != nil {
+			return realisasianggaran.RealisasiAnggaranResponse{}, err
+		}
+		err = service.recordAudit(ctx, tx, domain.AuditActionCreate, data, nil, data)
+		if err != nil {
+			return realisasianggaran.RealisasiAnggaranResponse{}, err
+		}
+	default:
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	return toRealisasiAnggaranResponse(data), nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) Update(ctx context.Context, request realisasianggaran.RealisasiAnggaranUpdateRequest) (realisasianggaran.RealisasiAnggaranResponse, error) {
+	if err := helper.ValidationError(service.Validate.Struct(request)); err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	defer tx.Rollback()
+
+	before, err := service.findRealisasiAnggaran(ctx, tx, request.Id)
+	if err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	kodeOpd, tahun, err := service.findRencanaAksiForUpdate(ctx, tx, before.RenaksiId)
+	if err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+
+	data := before
+	data.Realisasi = *request.Realisasi
+	data.Keterangan = request.Keterangan
+	data.KodeOpd = kodeOpd
+	data.Tahun = tahun
+	if err := service.RealisasiAnggaranRepository.Update(ctx, tx, data); err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	if err := service.recordAudit(ctx, tx, domain.AuditActionUpdate, data, before, data); err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return realisasianggaran.RealisasiAnggaranResponse{}, err
+	}
+	return toRealisasiAnggaranResponse(data), nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) Delete(ctx context.Context, id int) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := service.findRealisasiAnggaran(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := service.findRencanaAksiForUpdate(ctx, tx, before.RenaksiId); err != nil {
+		return err
+	}
+
+	if err := service.RealisasiAnggaranRepository.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := service.recordAudit(ctx, tx, domain.AuditActionDelete, before, before, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (service *RealisasiAnggaranServiceImpl) FindByRencanaAksi(ctx context.Context, renaksiId string, bulan int) (realisasianggaran.RencanaAksiSerapanResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasianggaran.RencanaAksiSerapanResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	kodeOpd, tahun, err := service.findKodeOpdTahun(ctx, tx, renaksiId)
+	if err != nil {
+		return realisasianggaran.RencanaAksiSerapanResponse{}, err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return realisasianggaran.RencanaAksiSerapanResponse{}, err
+	}
+	bulan, err = bulanLaporanSerapan(tahun, bulan, time.Now())
+	if err != nil {
+		return realisasianggaran.RencanaAksiSerapanResponse{}, err
+	}
+
+	data, err := service.loadSerapan(ctx, tx, kodeOpd, tahun, renaksiId)
+	if err != nil {
+		return realisasianggaran.RencanaAksiSerapanResponse{}, err
+	}
+	if len(data.renaksis) == 0 {
+		return realisasianggaran.RencanaAksiSerapanResponse{}, web.NewNotFoundError(fmt.Sprintf("rencana aksi %s tidak ditemukan", renaksiId))
+	}
+
+	response := data.rencanaAksi(data.renaksis[0], bulan, domain.ToleransiDeviasiSerapan)
+	response.Bulan = data.bulan(data.renaksis[0])
+	return response, nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) Laporan(ctx context.Context, kodeOpd string, tahun string, bulan int) (realisasianggaran.LaporanSerapanResponse, error) {
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return realisasianggaran.LaporanSerapanResponse{}, err
+	}
+	bulan, err := bulanLaporanSerapan(tahun, bulan, time.Now())
+	if err != nil {
+		return realisasianggaran.LaporanSerapanResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasianggaran.LaporanSerapanResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	data, err := service.loadSerapan(ctx, tx, kodeOpd, tahun, "")
+	if err != nil {
+		return realisasianggaran.LaporanSerapanResponse{}, err
+	}
+	program, ringkasan := data.laporan(bulan)
+	return realisasianggaran.LaporanSerapanResponse{
+		KodeOpd:          kodeOpd,
+		Tahun:            tahun,
+		Bulan:            bulan,
+		RingkasanSerapan: ringkasan,
+		Program:          program,
+	}, nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) Deviasi(ctx context.Context, kodeOpd string, tahun string, bulan int, toleransi float64) (realisasianggaran.DeviasiSerapanResponse, error) {
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return realisasianggaran.DeviasiSerapanResponse{}, err
+	}
+	if toleransi < 0 || toleransi > 100 {
+		return realisasianggaran.DeviasiSerapanResponse{}, web.NewBadRequestError("toleransi harus antara 0 sampai 100")
+	}
+	bulan, err := bulanLaporanSerapan(tahun, bulan, time.Now())
+	if err != nil {
+		return realisasianggaran.DeviasiSerapanResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasianggaran.DeviasiSerapanResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	data, err := service.loadSerapan(ctx, tx, kodeOpd, tahun, "")
+	if err != nil {
+		return realisasianggaran.DeviasiSerapanResponse{}, err
+	}
+
+	response := realisasianggaran.DeviasiSerapanResponse{
+		KodeOpd:           kodeOpd,
+		Tahun:             tahun,
+		Bulan:             bulan,
+		Toleransi:         toleransi,
+		JumlahRencanaAksi: len(data.renaksis),
+		RencanaAksi:       data.deviasi(bulan, toleransi),
+	}
+	response.JumlahDeviasi = len(response.RencanaAksi)
+	return response, nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) loadSerapan(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, renaksiId string) (*serapanAnggaran, error) {
+	renaksis, err := service.RealisasiAnggaranRepository.FindRencanaAksi(ctx, tx, kodeOpd, tahun, renaksiId)
+	if err != nil {
+		return nil, err
+	}
+	bobot, err := service.RealisasiAnggaranRepository.FindBobot(ctx, tx, kodeOpd, tahun, renaksiId)
+	if err != nil {
+		return nil, err
+	}
+	realisasis, err := service.RealisasiAnggaranRepository.FindRealisasi(ctx, tx, kodeOpd, tahun, renaksiId)
+	if err != nil {
+		return nil, err
+	}
+	pagu, err := service.RealisasiAnggaranRepository.FindPagu(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	return newSerapanAnggaran(renaksis, bobot, realisasis, pagu), nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) findKodeOpdTahun(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	kodeOpd, tahun, err := service.RealisasiAnggaranRepository.FindKodeOpdTahun(ctx, tx, renaksiId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", web.NewNotFoundError(fmt.Sprintf("rencana aksi %s tidak ditemukan", renaksiId))
+	}
+	return kodeOpd, tahun, err
+}
+
+// findRencanaAksiForUpdate kode_opd dan tahun rencana aksi yang realisasinya akan diubah setelah cek akses dan lock
+func (service *RealisasiAnggaranServiceImpl) findRencanaAksiForUpdate(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	kodeOpd, tahun, err := service.findKodeOpdTahun(ctx, tx, renaksiId)
+	if err != nil {
+		return "", "", err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return "", "", err
+	}
+	if err := checkLockData(ctx, tx, service.LockDataRepository, domain.JenisLockRealisasiAnggaran, kodeOpd, tahun); err != nil {
+		return "", "", err
+	}
+	return kodeOpd, tahun, nil
+}
+
+func (service *RealisasiAnggaranServiceImpl) findRealisasiAnggaran(ctx context.Context, tx *sql.Tx, id int) (domain.RealisasiAnggaran, error) {
+	data, err := service.RealisasiAnggaranRepository.FindById(ctx, tx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return data, web.NewNotFoundError(fmt.Sprintf("realisasi anggaran %d tidak ditemukan", id))
+	}
+	return data, err
+}
+
+func (service *RealisasiAnggaranServiceImpl) recordAudit(ctx context.Context, tx *sql.Tx, action string, data domain.RealisasiAnggaran, before, after interface{}) error {
+	return recordAuditLog(ctx, tx, service.AuditLogRepository, domain.AuditLog{
+		Action:     action,
+		EntityType: domain.AuditEntityRealisasiAnggaran,
+		EntityId:   strconv.Itoa(data.Id),
+		KodeOpd:    data.KodeOpd,
+		Tahun:      data.Tahun,
+	}, before, after)
+}
+
+func toRealisasiAnggaranResponse(data domain.RealisasiAnggaran) realisasianggaran.RealisasiAnggaranResponse {
+	response := realisasianggaran.RealisasiAnggaranResponse{
+		Id:         data.Id,
+		RenaksiId:  data.RenaksiId,
+		Bulan:      data.Bulan,
+		Realisasi:  data.Realisasi,
+		Keterangan: data.Keterangan,
+		KodeOpd:    data.KodeOpd,
+		Tahun:      data.Tahun,
+		CreatedBy:  data.CreatedBy,
+	}
+	if !data.UpdatedAt.IsZero() {
+		response.UpdatedAt = data.UpdatedAt.Format("2006-01-02 15:04:05")
+	}
+	return response
+}
+
+// bulanLaporanSerapan bulan 0 diganti bulan berjalan untuk tahun ini, bulan 12 untuk tahun lalu dan bulan 1 untuk tahun depan
+func bulanLaporanSerapan(tahun string, bulan int, now time.Time) (int, error) {
+	if bulan < 0 || bulan > 12 {
+		return 0, web.NewBadRequestError("bulan harus antara 1 sampai 12")
+	}
+	if bulan > 0 {
+		return bulan, nil
+	}
+	tahunLaporan, err := strconv.Atoi(tahun)
+	if err != nil {
+		return 0, web.NewBadRequestError(fmt.Sprintf("tahun %s tidak valid", tahun))
+	}
+	switch {
+	case tahunLaporan < now.Year():
+		return 12, nil
+	case tahunLaporan > now.Year():
+		return 1, nil
+	default:
+		return int(now.Month()), nil
+	}
+}
+
+// rencanaSerapanBulan persentase bobot pelaksanaan yang dijadwalkan sampai bulan tersebut, nil jika rencana aksi belum punya jadwal
+func rencanaSerapanBulan(bobot map[int]int, bulan int) *float64 {
+	var total, sampaiBulan int
+	for bulanBobot, nilai := range bobot {
+		total += nilai
+		if bulanBobot <= bulan {
+			sampaiBulan += nilai
+		}
+	}
+	if total <= 0 {
+		return nil
+	}
+	return bulatkanPersen(float64(sampaiBulan) / float64(total) * 100)
+}
+
+func persenSerapan(realisasi int64, basis int64) *float64 {
+	if basis <= 0 {
+		return nil
+	}
+	return bulatkanPersen(float64(realisasi) / float64(basis) * 100)
+}
+
+func deviasiSerapan(serapan, rencana *float64) *float64 {
+	if serapan == nil || rencana == nil {
+		return nil
+	}
+	return bulatkanPersen(*serapan - *rencana)
+}
+
+// statusSerapan dinilai berurutan: melebihi anggaran, tanpa jadwal, belum realisasi padahal sudah dijadwalkan, lalu tertinggal dari toleransi
+func statusSerapan(ringkasan realisasianggaran.RingkasanSerapan, toleransi float64) string {
+	switch {
+	case ringkasan.Realisasi > ringkasan.Anggaran:
+		return domain.StatusSerapanMelebihiAnggaran
+	case ringkasan.RencanaSerapan == nil:
+		return domain.StatusSerapanTanpaJadwal
+	case *ringkasan.RencanaSerapan > 0 && ringkasan.Realisasi == 0:
+		return domain.StatusSerapanBelumRealisasi
+	case ringkasan.Deviasi != nil && -*ringkasan.Deviasi > toleransi:
+		return domain.StatusSerapanTerlambat
+	default:
+		return domain.StatusSerapanSesuai
+	}
+}
+
+// akumulasiSerapan penjumlahan rupiah untuk ringkasan subkegiatan/kegiatan/program.
+// Rencana serapan tertimbang anggaran rencana aksi yang memiliki jadwal.
+type akumulasiSerapan struct {
+	pagu, anggaran, realisasi int64
+	// basis pembagi serapan: pagu subkegiatan atau anggaran jika pagu belum ada
+	basis          int64
+	rencana        float64
+	basisTerjadwal int64
+}
+
+func (akumulasi *akumulasiSerapan) tambahRencanaAksi(ringkasan realisasianggaran.RingkasanSerapan) {
+	akumulasi.anggaran += ringkasan.Anggaran
+	akumulasi.basis += ringkasan.Anggaran
+	akumulasi.realisasi += ringkasan.Realisasi
+	if ringkasan.RencanaSerapan != nil {
+		akumulasi.rencana += float64(ringkasan.Anggaran) * *ringkasan.RencanaSerapan / 100
+		akumulasi.basisTerjadwal += ringkasan.Anggaran
+	}
+}
+
+func (akumulasi *akumulasiSerapan) tambah(lain akumulasiSerapan) {
+	akumulasi.pagu += lain.pagu
+	akumulasi.anggaran += lain.anggaran
+	akumulasi.realisasi += lain.realisasi
+	akumulasi.basis += lain.basis
+	akumulasi.rencana += lain.rencana
+	akumulasi.basisTerjadwal += lain.basisTerjadwal
+}
+
+func (akumulasi akumulasiSerapan) ringkasan() realisasianggaran.RingkasanSerapan {
+	ringkasan := realisasianggaran.RingkasanSerapan{
+		Pagu:      akumulasi.pagu,
+		Anggaran:  akumulasi.anggaran,
+		Realisasi: akumulasi.realisasi,
+		Serapan:   persenSerapan(akumulasi.realisasi, akumulasi.basis),
+	}
+	if akumulasi.basisTerjadwal > 0 {
+		ringkasan.RencanaSerapan = bulatkanPersen(akumulasi.rencana / float64(akumulasi.basisTerjadwal) * 100)
+	}
+	ringkasan.Deviasi = deviasiSerapan(ringkasan.Serapan, ringkasan.RencanaSerapan)
+	return ringkasan
+}
+
+// serapanAnggaran data satu OPD dan tahun untuk laporan serapan
+type serapanAnggaran struct {
+	renaksis  []domain.RencanaAksiAnggaran
+	bobot     map[string]map[int]int
+	realisasi map[string]map[int]domain.RealisasiAnggaran
+	pagu      map[string]int64
+}
+
+func newSerapanAnggaran(renaksis []domain.RencanaAksiAnggaran, bobot map[string]map[int]int, realisasis []domain.RealisasiAnggaran, pagu map[string]int64) *serapanAnggaran {
+	realisasi := make(map[string]map[int]domain.RealisasiAnggaran)
+	for _, item := range realisasis {
+		if realisasi[item.RenaksiId] == nil {
+			realisasi[item.RenaksiId] = make(map[int]domain.RealisasiAnggaran)
+		}
+		realisasi[item.RenaksiId][item.Bulan] = item
+	}
+	return &serapanAnggaran{renaksis: renaksis, bobot: bobot, realisasi: realisasi, pagu: pagu}
+}
+
+func (data *serapanAnggaran) realisasiSampai(renaksiId string, bulan int) int64 {
+	var total int64
+	for bulanRealisasi, item := range data.realisasi[renaksiId] {
+		if bulanRealisasi <= bulan {
+			total += item.Realisasi
+		}
+	}
+	return total
+}
+
+func (data *serapanAnggaran) rencanaAksi(renaksi domain.RencanaAksiAnggaran, bulan int, toleransi float64) realisasianggaran.RencanaAksiSerapanResponse {
+	response := realisasianggaran.RencanaAksiSerapanResponse{
+		RenaksiId:          renaksi.Id,
+		NamaRencanaAksi:    renaksi.NamaRencanaAksi,
+		RencanaKinerjaId:   renaksi.RencanaKinerjaId,
+		NamaRencanaKinerja: renaksi.NamaRencanaKinerja,
+		PegawaiId:          renaksi.PegawaiId,
+		KodeSubkegiatan:    renaksi.KodeSubkegiatan,
+		BulanRencana:       make([]int, 0),
+	}
+	response.Anggaran = renaksi.Anggaran
+	response.Realisasi = data.realisasiSampai(renaksi.Id, bulan)
+	response.Serapan = persenSerapan(response.Realisasi, renaksi.Anggaran)
+	response.RencanaSerapan = rencanaSerapanBulan(data.bobot[renaksi.Id], bulan)
+	response.Deviasi = deviasiSerapan(response.Serapan, response.RencanaSerapan)
+	response.Status = statusSerapan(response.RingkasanSerapan, toleransi)
+
+	for bulanRencana, bobot := range data.bobot[renaksi.Id] {
+		if bobot > 0 {
+			response.BulanRencana = append(response.BulanRencana, bulanRencana)
+		}
+	}
+	sort.Ints(response.BulanRencana)
+	return response
+}
+
+// bulan rincian bulan 1-12 satu rencana aksi
+func (data *serapanAnggaran) bulan(renaksi domain.RencanaAksiAnggaran) []realisasianggaran.RealisasiBulanResponse {
+	responses := make([]realisasianggaran.RealisasiBulanResponse, 0, 12)
+	var kumulatif int64
+	for bulan := 1; bulan <= 12; bulan++ {
+		item := data.realisasi[renaksi.Id][bulan]
+		kumulatif += item.Realisasi
+		responses = append(responses, realisasianggaran.RealisasiBulanResponse{
+			Id:                 item.Id,
+			Bulan:              bulan,
+			Bobot:              data.bobot[renaksi.Id][bulan],
+			Realisasi:          item.Realisasi,
+			Keterangan:         item.Keterangan,
+			RealisasiKumulatif: kumulatif,
+			Serapan:            persenSerapan(kumulatif, renaksi.Anggaran),
+			RencanaSerapan:     rencanaSerapanBulan(data.bobot[renaksi.Id], bulan),
+		})
+	}
+	return responses
+}
+
+// laporan hierarki program → kegiatan → subkegiatan → rencana aksi, urut kode
+func (data *serapanAnggaran) laporan(bulan int) ([]realisasianggaran.ProgramSerapanResponse, realisasianggaran.RingkasanSerapan) {
+	type subkegiatanGroup struct {
+		response  realisasianggaran.SubkegiatanSerapanResponse
+		akumulasi akumulasiSerapan
+	}
+	type kegiatanGroup struct {
+		response    realisasianggaran.KegiatanSerapanResponse
+		subkegiatan map[string]*subkegiatanGroup
+	}
+	type programGroup struct {
+		response realisasianggaran.ProgramSerapanResponse
+		kegiatan map[string]*kegiatanGroup
+	}
+
+	programs := make(map[string]*programGroup)
+	for _, renaksi := range data.renaksis {
+		program, ok := programs[renaksi.KodeProgram]
+		if !ok {
+			program = &programGroup{kegiatan: make(map[string]*kegiatanGroup)}
+			program.response.KodeProgram = renaksi.KodeProgram
+			program.response.NamaProgram = renaksi.NamaProgram
+			programs[renaksi.KodeProgram] = program
+		}
+		kegiatan, ok := program.kegiatan[renaksi.KodeKegiatan]
+		if !ok {
+			kegiatan = &kegiatanGroup{subkegiatan: make(map[string]*subkegiatanGroup)}
+			kegiatan.response.KodeKegiatan = renaksi.KodeKegiatan
+			kegiatan.response.NamaKegiatan = renaksi.NamaKegiatan
+			program.kegiatan[renaksi.KodeKegiatan] = kegiatan
+		}
+		subkegiatan, ok := kegiatan.subkegiatan[renaksi.KodeSubkegiatan]
+		if !ok {
+			subkegiatan = &subkegiatanGroup{}
+			subkegiatan.response.KodeSubkegiatan = renaksi.KodeSubkegiatan
+			subkegiatan.response.NamaSubkegiatan = renaksi.NamaSubkegiatan
+			subkegiatan.response.RencanaAksi = make([]realisasianggaran.RencanaAksiSerapanResponse, 0)
+			kegiatan.subkegiatan[renaksi.KodeSubkegiatan] = subkegiatan
+		}
+
+		rencanaAksi := data.rencanaAksi(renaksi, bulan, domain.ToleransiDeviasiSerapan)
+		subkegiatan.response.RencanaAksi = append(subkegiatan.response.RencanaAksi, rencanaAksi)
+		subkegiatan.akumulasi.tambahRencanaAksi(rencanaAksi.RingkasanSerapan)
+	}
+
+	var total akumulasiSerapan
+	responses := make([]realisasianggaran.ProgramSerapanResponse, 0, len(programs))
+	for _, kodeProgram := range sortedKeys(programs) {
+		program := programs[kodeProgram]
+		var akumulasiProgram akumulasiSerapan
+		program.response.Kegiatan = make([]realisasianggaran.KegiatanSerapanResponse, 0, len(program.kegiatan))
+		for _, kodeKegiatan := range sortedKeys(program.kegiatan) {
+			kegiatan := program.kegiatan[kodeKegiatan]
+			var akumulasiKegiatan akumulasiSerapan
+			kegiatan.response.Subkegiatan = make([]realisasianggaran.SubkegiatanSerapanResponse, 0, len(kegiatan.subkegiatan))
+			for _, kodeSubkegiatan := range sortedKeys(kegiatan.subkegiatan) {
+				subkegiatan := kegiatan.subkegiatan[kodeSubkegiatan]
+				// pagu penetapan menggantikan total anggaran rencana aksi sebagai pembagi serapan
+				if pagu := data.pagu[kodeSubkegiatan]; kodeSubkegiatan != "" && pagu > 0 {
+					subkegiatan.akumulasi.pagu = pagu
+					subkegiatan.akumulasi.basis = pagu
+				}
+				subkegiatan.response.RingkasanSerapan = subkegiatan.akumulasi.ringkasan()
+				kegiatan.response.Subkegiatan = append(kegiatan.response.Subkegiatan, subkegiatan.response)
+				akumulasiKegiatan.tambah(subkegiatan.akumulasi)
+			}
+			kegiatan.response.RingkasanSerapan = akumulasiKegiatan.ringkasan()
+			program.response.Kegiatan = append(program.response.Kegiatan, kegiatan.response)
+			akumulasiProgram.tambah(akumulasiKegiatan)
+		}
+		program.response.RingkasanSerapan = akumulasiProgram.ringkasan()
+		responses = append(responses, program.response)
+		total.tambah(akumulasiProgram)
+	}
+	return responses, total.ringkasan()
+}
+
+// deviasi rencana aksi bermasalah, urut dari deviasi paling tertinggal
+func (data *serapanAnggaran) deviasi(bulan int, toleransi float64) []realisasianggaran.RencanaAksiSerapanResponse {
+	responses := make([]realisasianggaran.RencanaAksiSerapanResponse, 0)
+	for _, renaksi := range data.renaksis {
+		rencanaAksi := data.rencanaAksi(renaksi, bulan, toleransi)
+		switch rencanaAksi.Status {
+		case domain.StatusSerapanTerlambat, domain.StatusSerapanBelumRealisasi, domain.StatusSerapanMelebihiAnggaran:
+			responses = append(responses, rencanaAksi)
+		}
+	}
+	sort.SliceStable(responses, func(i, j int) bool {
+		return deviasiLebihTertinggal(responses[i], responses[j])
+	})
+	return responses
+}
+
+// deviasiLebihTertinggal urutan deviasi terkecil dulu, rencana aksi tanpa nilai deviasi (anggaran 0) selalu di akhir
+func deviasiLebihTertinggal(a, b realisasianggaran.RencanaAksiSerapanResponse) bool {
+	if a.Deviasi == nil {
+		return false
+	}
+	if b.Deviasi == nil {
+		return true
+	}
+	return *a.Deviasi < *b.Deviasi
+}
+
+func sortedKeys[V any](items map[string]V) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/realisasianggaran"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBulanLaporanSerapan(t *testing.T) {
+	now := time.Date(2025, time.May, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		tahun   string
+		bulan   int
+		want    int
+		wantErr bool
+	}{
+		{name: "bulan dipilih", tahun: "2025", bulan: 3, want: 3},
+		{name: "tahun berjalan", tahun: "2025", want: 5},
+		{name: "tahun lalu", tahun: "2024", want: 12},
+		{name: "tahun depan", tahun: "2026", want: 1},
+		{name: "bulan tidak valid", tahun: "2025", bulan: 13, wantErr: true},
+		{name: "tahun tidak valid", tahun: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bulanLaporanSerapan(tt.tahun, tt.bulan, now)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("bulanLaporanSerapan(%q, %d) = (%d, %v), want %d", tt.tahun, tt.bulan, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusSerapan(t *testing.T) {
+	tests := []struct {
+		name      string
+		anggaran  int64
+		realisasi int64
+		bobot     map[int]int
+		want      string
+	}{
+		{name: "sesuai jadwal", anggaran: 1000, realisasi: 450, bobot: map[int]int{3: 50, 9: 50}, want: domain.StatusSerapanSesuai},
+		{name: "tertinggal melebihi toleransi", anggaran: 1000, realisasi: 300, bobot: map[int]int{3: 50, 9: 50}, want: domain.StatusSerapanTerlambat},
+		{name: "belum ada realisasi", anggaran: 1000, bobot: map[int]int{3: 50, 9: 50}, want: domain.StatusSerapanBelumRealisasi},
+		{name: "belum dijadwalkan", anggaran: 1000, bobot: map[int]int{9: 100}, want: domain.StatusSerapanSesuai},
+		{name: "melebihi anggaran", anggaran: 1000, realisasi: 1200, bobot: map[int]int{3: 100}, want: domain.StatusSerapanMelebihiAnggaran},
+		{name: "tanpa jadwal", anggaran: 1000, realisasi: 100, want: domain.StatusSerapanTanpaJadwal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newSerapanAnggaran(
+				[]domain.RencanaAksiAnggaran{{Id: "RA-1", Anggaran: tt.anggaran}},
+				map[string]map[int]int{"RA-1": tt.bobot},
+				[]domain.RealisasiAnggaran{{RenaksiId: "RA-1", Bulan: 2, Realisasi: tt.realisasi}},
+				nil,
+			)
+			got := data.rencanaAksi(data.renaksis[0], 6, domain.ToleransiDeviasiSerapan)
+			if got.Status != tt.want {
+				t.Errorf("status = %q (serapan %v, rencana %v), want %q", got.Status, formatCapaian(got.Serapan), formatCapaian(got.RencanaSerapan), tt.want)
+			}
+		})
+	}
+}
+
+func TestLaporanSerapanRollup(t *testing.T) {
+	renaksis := []domain.RencanaAksiAnggaran{
+		{Id: "RA-1", KodeProgram: "1.01.02", KodeKegiatan: "1.01.02.1.01", KodeSubkegiatan: "1.01.02.1.01.0001", Anggaran: 1000},
+		{Id: "RA-2", KodeProgram: "1.01.02", KodeKegiatan: "1.01.02.1.01", KodeSubkegiatan: "1.01.02.1.01.0001", Anggaran: 3000},
+		{Id: "RA-3", KodeProgram: "1.01.02", KodeKegiatan: "1.01.02.1.01", KodeSubkegiatan: "1.01.02.1.01.0002", Anggaran: 2000},
+		{Id: "RA-4", Anggaran: 500},
+	}
+	bobot := map[string]map[int]int{
+		"RA-1": {1: 100},
+		"RA-2": {6: 50, 12: 50},
+		"RA-3": {2: 25, 4: 75},
+	}
+	realisasis := []domain.RealisasiAnggaran{
+		{RenaksiId: "RA-1", Bulan: 1, Realisasi: 600},
+		{RenaksiId: "RA-1", Bulan: 5, Realisasi: 400},
+		{RenaksiId: "RA-2", Bulan: 6, Realisasi: 1000},
+		{RenaksiId: "RA-3", Bulan: 3, Realisasi: 500},
+		{RenaksiId: "RA-3", Bulan: 9, Realisasi: 1000},
+	}
+	pagu := map[string]int64{"1.01.02.1.01.0002": 4000}
+
+	data := newSerapanAnggaran(renaksis, bobot, realisasis, pagu)
+	program, total := data.laporan(6)
+	if len(program) != 2 || program[0].KodeProgram != "" || program[1].KodeProgram != "1.01.02" {
+		t.Fatalf("program = %+v, want rencana aksi tanpa subkegiatan dikelompokkan terpisah", program)
+	}
+	subkegiatan := program[1].Kegiatan[0].Subkegiatan
+
+	// subkegiatan 0001: realisasi 2000 dari anggaran 4000, rencana (1000*100% + 3000*50%) / 4000
+	// subkegiatan 0002: realisasi 500 dari pagu 4000, rencana 100% dari anggaran 2000
+	tests := []struct {
+		name string
+		got  realisasianggaran.RingkasanSerapan
+		want realisasianggaran.RingkasanSerapan
+	}{
+		{
+			name: "subkegiatan tanpa pagu",
+			got:  subkegiatan[0].RingkasanSerapan,
+			want: realisasianggaran.RingkasanSerapan{Anggaran: 4000, Realisasi: 2000, Serapan: floatPtr(50), RencanaSerapan: floatPtr(62.5), Deviasi: floatPtr(-12.5)},
+		},
+		{
+			name: "subkegiatan dengan pagu",
+			got:  subkegiatan[1].RingkasanSerapan,
+			want: realisasianggaran.RingkasanSerapan{Pagu: 4000, Anggaran: 2000, Realisasi: 500, Serapan: floatPtr(12.5), RencanaSerapan: floatPtr(100), Deviasi: floatPtr(-87.5)},
+		},
+		{
+			name: "program",
+			got:  program[1].RingkasanSerapan,
+			want: realisasianggaran.RingkasanSerapan{Pagu: 4000, Anggaran: 6000, Realisasi: 2500, Serapan: floatPtr(31.25), RencanaSerapan: floatPtr(75), Deviasi: floatPtr(-43.75)},
+		},
+		{
+			name: "total opd",
+			got:  total,
+			want: realisasianggaran.RingkasanSerapan{Pagu: 4000, Anggaran: 6500, Realisasi: 2500, Serapan: floatPtr(29.41), RencanaSerapan: floatPtr(75), Deviasi: floatPtr(-45.59)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !equalRingkasanSerapan(tt.got, tt.want) {
+				t.Errorf("ringkasan = %s, want %s", formatRingkasanSerapan(tt.got), formatRingkasanSerapan(tt.want))
+			}
+		})
+	}
+
+	deviasi := data.deviasi(6, domain.ToleransiDeviasiSerapan)
+	if len(deviasi) != 2 || deviasi[0].RenaksiId != "RA-3" || deviasi[1].RenaksiId != "RA-2" {
+		t.Errorf("deviasi = %+v, want RA-3 lalu RA-2", deviasi)
+	}
+
+	// deviasi positif tetap di depan rencana aksi tanpa deviasi
+	tanpaDeviasi := realisasianggaran.RencanaAksiSerapanResponse{RenaksiId: "RA-NIL"}
+	melebihi := realisasianggaran.RencanaAksiSerapanResponse{RenaksiId: "RA-LEBIH", RingkasanSerapan: realisasianggaran.RingkasanSerapan{Deviasi: floatPtr(12.5)}}
+	if deviasiLebihTertinggal(tanpaDeviasi, melebihi) || !deviasiLebihTertinggal(melebihi, tanpaDeviasi) {
+		t.Errorf("rencana aksi tanpa deviasi harus diurutkan setelah deviasi positif")
+	}
+}
+
+func equalRingkasanSerapan(a, b realisasianggaran.RingkasanSerapan) bool {
+	return a.Pagu == b.Pagu && a.Anggaran == b.Anggaran && a.Realisasi == b.Realisasi &&
+		equalCapaian(a.Serapan, b.Serapan) && equalCapaian(a.RencanaSerapan, b.RencanaSerapan) && equalCapaian(a.Deviasi, b.Deviasi)
+}
+
+func formatRingkasanSerapan(ringkasan realisasianggaran.RingkasanSerapan) string {
+	return fmt.Sprintf("{pagu %d anggaran %d realisasi %d serapan %v rencana %v deviasi %v}",
+		ringkasan.Pagu, ringkasan.Anggaran, ringkasan.Realisasi,
+		formatCapaian(ringkasan.Serapan), formatCapaian(ringkasan.RencanaSerapan), formatCapaian(ringkasan.Deviasi))
+}
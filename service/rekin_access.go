@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+)
+
+// checkRekinAccess memeriksa OPD pemilik rekin untuk data turunan rekin yang diambil berdasarkan id
+// (dasar hukum, gambaran umum, inovasi, permasalahan, rencana aksi). Route data tersebut hanya membawa id
+// sehingga pengecekan :kode_opd di middleware tidak berlaku. Rekin yang tidak ditemukan dibiarkan ke validasi pemanggil.
+func checkRekinAccess(ctx context.Context, tx *sql.Tx, rencanaKinerjaRepository repository.RencanaKinerjaRepository, rekinId string) error {
+	if rekinId == "" {
+		return nil
+	}
+	kodeOpd, err := rencanaKinerjaRepository.FindKodeOpdById(ctx, tx, rekinId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return helper.ValidateKodeOpdAccess(ctx, kodeOpd)
+}
+
+// checkRenaksiAccess checkRekinAccess untuk data turunan rencana aksi (pelaksanaan, rincian belanja)
+func checkRenaksiAccess(ctx context.Context, tx *sql.Tx, rencanaKinerjaRepository repository.RencanaKinerjaRepository, renaksiId string) error {
+	if renaksiId == "" {
+		return nil
+	}
+	kodeOpd, _, err := rencanaKinerjaRepository.FindKodeOpdTahunByRenaksiId(ctx, tx, renaksiId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return helper.ValidateKodeOpdAccess(ctx, kodeOpd)
+}
+
+// checkRekinLock cek lock rencana kinerja untuk OPD dan tahun rekin sebelum data turunan rekin diubah.
+// Rekin yang tidak ditemukan dibiarkan ke validasi pemanggil.
+func checkRekinLock(ctx context.Context, tx *sql.Tx, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, rekinId string) error {
+	kodeOpd, tahun, err := rencanaKinerjaRepository.FindKodeOpdTahunById(ctx, tx, rekinId)
+	if err != nil {
+		return err
+	}
+	return checkLockData(ctx, tx, lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahun)
+}
+
+// checkRenaksiLock checkRekinLock untuk data turunan rencana aksi
+func checkRenaksiLock(ctx context.Context, tx *sql.Tx, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, renaksiId string) error {
+	kodeOpd, tahun, err := rencanaKinerjaRepository.FindKodeOpdTahunByRenaksiId(ctx, tx, renaksiId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return checkLockData(ctx, tx, lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahun)
+}
@@ -21,20 +21,28 @@ type RencanaAksiServiceImpl struct {
 	DB                               *sql.DB
 	Validate                         *validator.Validate
 	pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository
+	persetujuanRekinRepository       repository.PersetujuanRekinRepository
+	rencanaKinerjaRepository         repository.RencanaKinerjaRepository
+	lockDataRepository               repository.LockDataRepository
+	cache                            *helper.Cache
 }
 
-func NewRencanaAksiServiceImpl(rencanaAksiRepository repository.RencanaAksiRepository, DB *sql.DB, validate *validator.Validate, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository) *RencanaAksiServiceImpl {
+func NewRencanaAksiServiceImpl(rencanaAksiRepository repository.RencanaAksiRepository, DB *sql.DB, validate *validator.Validate, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, persetujuanRekinRepository repository.PersetujuanRekinRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, lockDataRepository repository.LockDataRepository, cache *helper.Cache) *RencanaAksiServiceImpl {
 	return &RencanaAksiServiceImpl{
 		rencanaAksiRepository:            rencanaAksiRepository,
 		DB:                               DB,
 		Validate:                         validate,
 		pelaksanaanRencanaAksiRepository: pelaksanaanRencanaAksiRepository,
+		persetujuanRekinRepository:       persetujuanRekinRepository,
+		rencanaKinerjaRepository:         rencanaKinerjaRepository,
+		lockDataRepository:               lockDataRepository,
+		cache:                            cache,
 	}
 }
 
 func (service *RencanaAksiServiceImpl) Create(ctx context.Context, request rencanaaksi.RencanaAksiCreateRequest) (rencanaaksi.RencanaAksiResponse, error) {
 	// Validasi request
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return rencanaaksi.RencanaAksiResponse{}, err
 	}
@@ -44,10 +52,25 @@ func (service *RencanaAksiServiceImpl) Create(ctx context.Context, request renca
 		log.Printf("Gagal memulai transaksi: %v", err)
 		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	if request.Urutan <= 0 {
-		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("urutan tidak boleh kurang dari atau sama dengan 0")
+		return rencanaaksi.RencanaAksiResponse{}, web.NewBadRequestError("urutan tidak boleh kurang dari atau sama dengan 0")
+	}
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, request.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+	if err := checkStatusRekin(ctx, tx, service.persetujuanRekinRepository, request.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+	kodeOpd, tahun, err = service.rencanaKinerjaRepository.FindKodeOpdTahunById(ctx, tx, request.RencanaKinerjaId)
+	if err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahun); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
 	}
 
 	// Buat UUID baru dengan format yang diinginkan
@@ -83,7 +106,7 @@ func (service *RencanaAksiServiceImpl) Create(ctx context.Context, request renca
 
 func (service *RencanaAksiServiceImpl) Update(ctx context.Context, request rencanaaksi.RencanaAksiUpdateRequest) (rencanaaksi.RencanaAksiResponse, error) {
 	// Validasi request
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return rencanaaksi.RencanaAksiResponse{}, err
 	}
@@ -93,16 +116,31 @@ func (service *RencanaAksiServiceImpl) Update(ctx context.Context, request renca
 		log.Printf("Gagal memulai transaksi: %v", err)
 		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	if request.Urutan <= 0 {
-		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("urutan tidak boleh kurang dari atau sama dengan 0")
+		return rencanaaksi.RencanaAksiResponse{}, web.NewBadRequestError("urutan tidak boleh kurang dari atau sama dengan 0")
 	}
 
 	// Cek apakah rencana aksi dengan ID tersebut ada
 	existingRencanaAksi, err := service.rencanaAksiRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
-		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("rencana aksi dengan ID %s tidak ditemukan", request.Id)
+		return rencanaaksi.RencanaAksiResponse{}, web.NewNotFoundError(fmt.Sprintf("rencana aksi dengan ID %s tidak ditemukan", request.Id))
+	}
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, existingRencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+	if err := checkStatusRekin(ctx, tx, service.persetujuanRekinRepository, existingRencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+	kodeOpd, tahun, err = service.rencanaKinerjaRepository.FindKodeOpdTahunById(ctx, tx, existingRencanaAksi.RencanaKinerjaId)
+	if err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahun); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
 	}
 
 	// Update data rencana aksi
@@ -134,6 +172,10 @@ func (service *RencanaAksiServiceImpl) FindAll(ctx context.Context, rencanaKiner
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, rencanaKinerjaId); err != nil {
+		return nil, err
+	}
+
 	// Panggil repository untuk mendapatkan semua rencana aksi
 	rencanaAksiList, err := service.rencanaAksiRepository.FindAll(ctx, tx, rencanaKinerjaId)
 	if err != nil {
@@ -235,6 +277,9 @@ func (service *RencanaAksiServiceImpl) FindById(ctx context.Context, id string)
 	if err != nil {
 		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("gagal mengambil rencana aksi: %v", err)
 	}
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
 
 	// Ambil data pelaksanaan rencana aksi untuk rencana aksi ini
 	pelaksanaanList, err := service.pelaksanaanRencanaAksiRepository.FindByRencanaAksiId(ctx, tx, rencanaAksi.Id)
@@ -275,16 +320,31 @@ func (service *RencanaAksiServiceImpl) Delete(ctx context.Context, id string) er
 		log.Printf("Gagal memulai transaksi: %v", err)
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	// Periksa apakah rencana aksi dengan ID tersebut ada
-	_, err = service.rencanaAksiRepository.FindById(ctx, tx, id)
+	rencanaAksi, err := service.rencanaAksiRepository.FindById(ctx, tx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("rencana aksi dengan ID %s tidak ditemukan", id)
+			return web.NewNotFoundError(fmt.Sprintf("rencana aksi dengan ID %s tidak ditemukan", id))
 		}
 		return fmt.Errorf("gagal memeriksa rencana aksi: %v", err)
 	}
+	if err := checkRekinAccess(ctx, tx, service.rencanaKinerjaRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return err
+	}
+	if err := checkStatusRekin(ctx, tx, service.persetujuanRekinRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return err
+	}
+	kodeOpd, tahun, err = service.rencanaKinerjaRepository.FindKodeOpdTahunById(ctx, tx, rencanaAksi.RencanaKinerjaId)
+	if err != nil {
+		return err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahun); err != nil {
+		return err
+	}
 
 	// Panggil repository untuk menghapus rencana aksi
 	err = service.rencanaAksiRepository.Delete(ctx, tx, id)
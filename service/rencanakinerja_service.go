@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/job"
 	"ekak_kabupaten_madiun/model/web/rencanakinerja"
 )
 
@@ -15,6 +16,7 @@ type RencanaKinerjaService interface {
 	RekinsasaranOpd(ctx context.Context, pegawaiId string, kodeOPD string, tahun string) ([]rencanakinerja.RencanaKinerjaResponse, error)
 
 	FindAllRincianKak(ctx context.Context, pegawaiId string, rencanaKinerjaId string) ([]rencanakinerja.DataRincianKerja, error)
+	GenerateKak(ctx context.Context, pegawaiId string, rencanaKinerjaId string, format string) ([]byte, error)
 
 	//rencana kinerja level 1
 	CreateRekinLevel1(ctx context.Context, request rencanakinerja.RencanaKinerjaCreateRequest) (rencanakinerja.RencanaKinerjaResponse, error)
@@ -30,5 +32,5 @@ type RencanaKinerjaService interface {
 	CloneRencanaKinerja(ctx context.Context, rekinId string, tahunBaru string) (rencanakinerja.RencanaKinerjaResponse, error)
 
 	FindByFilter(ctx context.Context, filter domain.FilterParams) ([]rencanakinerja.RencanaKinerjaResponse, error)
-	CloneRekinByKodeOpdAndTahun(ctx context.Context, cloneRequest rencanakinerja.RekinByOpdCloneRequest) error
+	CloneRekinByKodeOpdAndTahun(ctx context.Context, cloneRequest rencanakinerja.RekinByOpdCloneRequest) (job.JobResponse, error)
 }
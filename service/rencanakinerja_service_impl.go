@@ -6,13 +6,15 @@ import (
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/job"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/permasalahan"
 	"ekak_kabupaten_madiun/model/web/rencanaaksi"
 	"ekak_kabupaten_madiun/model/web/rencanakinerja"
 	"ekak_kabupaten_madiun/model/web/subkegiatan"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math/rand"
@@ -50,16 +52,21 @@ type RencanaKinerjaServiceImpl struct {
 	SasaranOpdRepository             repository.SasaranOpdRepository
 	CascadingOpdService              *CascadingOpdServiceImpl
 	// TAMBAHKAN REPOSITORY BARU:
-	cascadingOpdRepository   repository.CascadingOpdRepository
-	programRepository        repository.ProgramRepository
-	rincianBelanjaRepository repository.RincianBelanjaRepository
-	rencanaAksiRepository    repository.RencanaAksiRepository
-	cloneRecordRepository    repository.CloneRecordRepository
+	cascadingOpdRepository     repository.CascadingOpdRepository
+	programRepository          repository.ProgramRepository
+	rincianBelanjaRepository   repository.RincianBelanjaRepository
+	rencanaAksiRepository      repository.RencanaAksiRepository
+	cloneRecordRepository      repository.CloneRecordRepository
+	lockDataRepository         repository.LockDataRepository
+	auditLogRepository         repository.AuditLogRepository
+	jobService                 JobService
+	cache                      *helper.Cache
+	persetujuanRekinRepository repository.PersetujuanRekinRepository
 }
 
-func NewRencanaKinerjaServiceImpl(rencanaKinerjaRepository repository.RencanaKinerjaRepository, DB *sql.DB, validate *validator.Validate, opdRepository repository.OpdRepository, usulanMusrebangRepository repository.UsulanMusrebangRepository, usulanMandatoriRepository repository.UsulanMandatoriRepository, usulanPokokPikiranRepository repository.UsulanPokokPikiranRepository, usulanInisiatifRepository repository.UsulanInisiatifRepository, subKegiatanRepository repository.SubKegiatanRepository, dasarHukumRepository repository.DasarHukumRepository, gambaranUmumRepository repository.GambaranUmumRepository, inovasiRepository repository.InovasiRepository, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, pegawaiRepository repository.PegawaiRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, manualIKRepository repository.ManualIKRepository, permasalahanRekinRepository repository.PermasalahanRekinRepository, subKegiatanTerpilihRepository repository.SubKegiatanTerpilihRepository, subKegiatanService *SubKegiatanServiceImpl, periodeRepository repository.PeriodeRepository, sasaranOpdRepository repository.SasaranOpdRepository, cascadingOpdService *CascadingOpdServiceImpl, cascadingOpdRepository repository.CascadingOpdRepository, programRepository repository.ProgramRepository, rincianBelanjaRepository repository.RincianBelanjaRepository, rencanaAksiRepository repository.RencanaAksiRepository, cloneRecordRepository repository.CloneRecordRepository,
+func NewRencanaKinerjaServiceImpl(rencanaKinerjaRepository repository.RencanaKinerjaRepository, DB *sql.DB, validate *validator.Validate, opdRepository repository.OpdRepository, usulanMusrebangRepository repository.UsulanMusrebangRepository, usulanMandatoriRepository repository.UsulanMandatoriRepository, usulanPokokPikiranRepository repository.UsulanPokokPikiranRepository, usulanInisiatifRepository repository.UsulanInisiatifRepository, subKegiatanRepository repository.SubKegiatanRepository, dasarHukumRepository repository.DasarHukumRepository, gambaranUmumRepository repository.GambaranUmumRepository, inovasiRepository repository.InovasiRepository, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, pegawaiRepository repository.PegawaiRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, manualIKRepository repository.ManualIKRepository, permasalahanRekinRepository repository.PermasalahanRekinRepository, subKegiatanTerpilihRepository repository.SubKegiatanTerpilihRepository, subKegiatanService *SubKegiatanServiceImpl, periodeRepository repository.PeriodeRepository, sasaranOpdRepository repository.SasaranOpdRepository, cascadingOpdService *CascadingOpdServiceImpl, cascadingOpdRepository repository.CascadingOpdRepository, programRepository repository.ProgramRepository, rincianBelanjaRepository repository.RincianBelanjaRepository, rencanaAksiRepository repository.RencanaAksiRepository, cloneRecordRepository repository.CloneRecordRepository, lockDataRepository repository.LockDataRepository, auditLogRepository repository.AuditLogRepository, jobService JobService, cache *helper.Cache, persetujuanRekinRepository repository.PersetujuanRekinRepository,
 ) *RencanaKinerjaServiceImpl {
-	return &RencanaKinerjaServiceImpl{
+	service := &RencanaKinerjaServiceImpl{
 		rencanaKinerjaRepository:         rencanaKinerjaRepository,
 		DB:                               DB,
 		Validate:                         validate,
@@ -84,21 +91,31 @@ func NewRencanaKinerjaServiceImpl(rencanaKinerjaRepository repository.RencanaKin
 		SasaranOpdRepository:             sasaranOpdRepository,
 		CascadingOpdService:              cascadingOpdService,
 		// TAMBAHKAN ASSIGNMENT BARU:
-		cascadingOpdRepository:   cascadingOpdRepository,
-		programRepository:        programRepository,
-		rincianBelanjaRepository: rincianBelanjaRepository,
-		rencanaAksiRepository:    rencanaAksiRepository,
-		cloneRecordRepository:    cloneRecordRepository,
-	}
+		cascadingOpdRepository:     cascadingOpdRepository,
+		programRepository:          programRepository,
+		rincianBelanjaRepository:   rincianBelanjaRepository,
+		rencanaAksiRepository:      rencanaAksiRepository,
+		cloneRecordRepository:      cloneRecordRepository,
+		lockDataRepository:         lockDataRepository,
+		auditLogRepository:         auditLogRepository,
+		jobService:                 jobService,
+		cache:                      cache,
+		persetujuanRekinRepository: persetujuanRekinRepository,
+	}
+	jobService.RegisterHandler(domain.JobCloneRencanaKinerja, JobHandler{
+		Run:      service.runCloneRekinJob,
+		OnFinish: service.finishCloneRekinJob,
+	})
+	return service
 }
 
 func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request rencanakinerja.RencanaKinerjaCreateRequest) (rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses Create RencanaKinerja")
 
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		log.Printf("Validasi gagal: %v", err)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
+		return rencanakinerja.RencanaKinerjaResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
@@ -106,14 +123,22 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 		log.Printf("Gagal memulai transaksi: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	defer service.cache.Invalidate(ctx, helper.CacheTagOpdTahun(request.KodeOpd, request.Tahun))
 	defer helper.CommitOrRollback(tx)
 
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, request.KodeOpd, request.Tahun); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	// Perbaikan pengecekan kode OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Kode OPD %s tidak ditemukan", request.KodeOpd)
-			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak ditemukan", request.KodeOpd)
+			return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("kode OPD %s tidak ditemukan", request.KodeOpd))
 		}
 		log.Printf("Gagal memeriksa kode OPD: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memeriksa kode OPD: %v", err)
@@ -121,7 +146,7 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 
 	if opd.KodeOpd == "" {
 		log.Printf("Kode OPD %s tidak valid", request.KodeOpd)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak valid", request.KodeOpd)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewBadRequestError(fmt.Sprintf("kode OPD %s tidak valid", request.KodeOpd))
 	}
 
 	pegawais, err := service.pegawaiRepository.FindByNip(ctx, tx, request.PegawaiId)
@@ -132,7 +157,7 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 
 	if pegawais.Id == "" {
 		log.Printf("Pegawai dengan Nip %s tidak ditemukan", request.PegawaiId)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pegawai dengan Nip %s tidak ditemukan", request.PegawaiId)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("pegawai dengan Nip %s tidak ditemukan", request.PegawaiId))
 	}
 
 	pohon, err := service.pohonKinerjaRepository.FindById(ctx, tx, request.IdPohon)
@@ -145,6 +170,9 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 		log.Printf("Pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 	}
+	if err := validatePohonRekinAccess(ctx, pohon); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	year := time.Now().Year()
@@ -155,7 +183,7 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 		IdPohon:              request.IdPohon,
 		NamaRencanaKinerja:   request.NamaRencanaKinerja,
 		Tahun:                request.Tahun,
-		StatusRencanaKinerja: request.StatusRencanaKinerja,
+		StatusRencanaKinerja: domain.StatusRekinDraft,
 		Catatan:              request.Catatan,
 		KodeOpd:              request.KodeOpd,
 		PegawaiId:            pegawais.Nip,
@@ -209,6 +237,10 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 		log.Printf("Gagal menyimpan RencanaKinerja: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menyimpan RencanaKinerja: %v", err)
 	}
+	err = recordRencanaKinerjaAudit(ctx, tx, service.auditLogRepository, service.rencanaKinerjaRepository, domain.AuditActionCreate, rencanaKinerja.Id, nil)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	rencanaKinerja.NamaOpd = opd.NamaOpd
 	rencanaKinerja.NamaPegawai = pegawais.NamaPegawai
@@ -223,10 +255,10 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request rencanakinerja.RencanaKinerjaUpdateRequest) (rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses Update RencanaKinerja")
 
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		log.Printf("Validasi gagal: %v", err)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
+		return rencanakinerja.RencanaKinerjaResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
@@ -234,14 +266,29 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 		log.Printf("Gagal memulai transaksi: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	// Jika tahun atau OPD rekin berubah, tampilan di tahun/OPD lama juga ikut diinvalidasi.
+	var kodeOpdLama, tahunLama string
+	defer func() {
+		service.cache.Invalidate(ctx, helper.CacheTagOpdTahun(request.KodeOpd, request.Tahun))
+		if kodeOpdLama != "" && (kodeOpdLama != request.KodeOpd || tahunLama != request.Tahun) {
+			service.cache.Invalidate(ctx, helper.CacheTagOpdTahun(kodeOpdLama, tahunLama))
+		}
+	}()
 	defer helper.CommitOrRollback(tx)
 
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, request.KodeOpd, request.Tahun); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Kode OPD %s tidak ditemukan", request.KodeOpd)
-			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak ditemukan", request.KodeOpd)
+			return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("kode OPD %s tidak ditemukan", request.KodeOpd))
 		}
 		log.Printf("Gagal memeriksa kode OPD: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memeriksa kode OPD: %v", err)
@@ -249,7 +296,7 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 
 	if opd.KodeOpd == "" {
 		log.Printf("Kode OPD %s tidak valid", request.KodeOpd)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak valid", request.KodeOpd)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewBadRequestError(fmt.Sprintf("kode OPD %s tidak valid", request.KodeOpd))
 	}
 
 	// Validasi Pegawai
@@ -261,7 +308,7 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 
 	if pegawai.Id == "" {
 		log.Printf("Pegawai dengan NIP %s tidak ditemukan", request.PegawaiId)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pegawai dengan NIP %s tidak ditemukan", request.PegawaiId)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("pegawai dengan NIP %s tidak ditemukan", request.PegawaiId))
 	}
 
 	pohon, err := service.pohonKinerjaRepository.FindById(ctx, tx, request.IdPohon)
@@ -274,6 +321,9 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 		log.Printf("Pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 	}
+	if err := validatePohonRekinAccess(ctx, pohon); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	//
 	var rencanaKinerja domain.RencanaKinerja
@@ -283,16 +333,22 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 			log.Printf("Gagal menemukan RencanaKinerja: %v", err)
 			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menemukan RencanaKinerja: %v", err)
 		}
-	} else {
-		randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
-		rencanaKinerja.Id = fmt.Sprintf("REKIN-PEG-%s", randomDigits)
-		log.Printf("Membuat RencanaKinerja baru dengan ID: %s", rencanaKinerja.Id)
+		if err := helper.ValidateKodeOpdAccess(ctx, rencanaKinerja.KodeOpd); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		if err := checkStatusRekin(ctx, tx, service.persetujuanRekinRepository, rencanaKinerja.Id); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		kodeOpdLama, tahunLama = rencanaKinerja.KodeOpd, rencanaKinerja.Tahun
 	}
 
 	rencanaKinerja.IdPohon = request.IdPohon
 	rencanaKinerja.NamaRencanaKinerja = request.NamaRencanaKinerja
 	rencanaKinerja.Tahun = request.Tahun
-	rencanaKinerja.StatusRencanaKinerja = request.StatusRencanaKinerja
+	// status tidak diubah di sini, hanya lewat alur persetujuan
 	rencanaKinerja.Catatan = request.Catatan
 	rencanaKinerja.KodeOpd = request.KodeOpd
 	rencanaKinerja.PegawaiId = request.PegawaiId
@@ -337,12 +393,18 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 		rencanaKinerja.Indikator[i] = indikator
 	}
 
+	auditBefore := rencanaKinerjaAuditSnapshot(ctx, tx, service.rencanaKinerjaRepository, request.Id)
+
 	log.Println("Memanggil repository.Update")
 	rencanaKinerja, err = service.rencanaKinerjaRepository.Update(ctx, tx, rencanaKinerja)
 	if err != nil {
 		log.Printf("Gagal memperbarui RencanaKinerja: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memperbarui RencanaKinerja: %v", err)
 	}
+	err = recordRencanaKinerjaAudit(ctx, tx, service.auditLogRepository, service.rencanaKinerjaRepository, domain.AuditActionUpdate, request.Id, auditBefore)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	rencanaKinerja.NamaOpd = opd.NamaOpd
 	rencanaKinerja.NamaPegawai = pegawai.NamaPegawai
@@ -355,6 +417,15 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 	return response, nil
 }
 
+// validatePohonRekinAccess pohon kinerja yang dipilih rekin harus milik OPD user.
+// Pohon pemda tanpa kode_opd tidak dibatasi.
+func validatePohonRekinAccess(ctx context.Context, pohon domain.PohonKinerja) error {
+	if pohon.KodeOpd == "" {
+		return nil
+	}
+	return helper.ValidateKodeOpdAccess(ctx, pohon.KodeOpd)
+}
+
 func (service *RencanaKinerjaServiceImpl) FindAll(ctx context.Context, pegawaiId string, kodeOPD string, tahun string) ([]rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses FindAll RencanaKinerja")
 
@@ -660,7 +731,7 @@ func (service *RencanaKinerjaServiceImpl) FindById(ctx context.Context, id strin
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("RencanaKinerja tidak ditemukan untuk ID: %s", id)
-			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("rencana kinerja tidak ditemukan")
+			return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError("rencana kinerja tidak ditemukan")
 		}
 		log.Printf("Gagal menemukan rencana kinerja: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menemukan rencana kinerja: %v", err)
@@ -668,6 +739,10 @@ func (service *RencanaKinerjaServiceImpl) FindById(ctx context.Context, id strin
 
 	log.Printf("RencanaKinerja ditemukan: %+v", rencanaKinerja)
 
+	if err := helper.ValidateKodeOpdAccess(ctx, rencanaKinerja.KodeOpd); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	indikators, err := service.rencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, rencanaKinerja.Id)
 	if err != nil {
 		log.Printf("Gagal menemukan indikator: %v", err)
@@ -721,14 +796,35 @@ func (service *RencanaKinerjaServiceImpl) Delete(ctx context.Context, id string)
 	if err != nil {
 		return err
 	}
+	var kodeOpd, tahun string
+	defer func() {
+		if kodeOpd != "" {
+			service.cache.Invalidate(ctx, helper.CacheTagOpdTahun(kodeOpd, tahun))
+		}
+	}()
 	defer helper.CommitOrRollback(tx)
 
 	rencanaKinerja, err := service.rencanaKinerjaRepository.FindById(ctx, tx, id, "", "")
 	if err != nil {
 		return err
 	}
+	kodeOpd, tahun = rencanaKinerja.KodeOpd, rencanaKinerja.Tahun
+	if err := helper.ValidateKodeOpdAccess(ctx, rencanaKinerja.KodeOpd); err != nil {
+		return err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun); err != nil {
+		return err
+	}
+	if err := checkStatusRekin(ctx, tx, service.persetujuanRekinRepository, rencanaKinerja.Id); err != nil {
+		return err
+	}
 
-	return service.rencanaKinerjaRepository.Delete(ctx, tx, rencanaKinerja.Id)
+	auditBefore := rencanaKinerjaAuditSnapshot(ctx, tx, service.rencanaKinerjaRepository, rencanaKinerja.Id)
+	err = service.rencanaKinerjaRepository.Delete(ctx, tx, rencanaKinerja.Id)
+	if err != nil {
+		return err
+	}
+	return recordRencanaKinerjaAudit(ctx, tx, service.auditLogRepository, service.rencanaKinerjaRepository, domain.AuditActionDelete, rencanaKinerja.Id, auditBefore)
 }
 
 func (service *RencanaKinerjaServiceImpl) FindAllRincianKak(ctx context.Context, pegawaiId string, rencanaKinerjaId string) ([]rencanakinerja.DataRincianKerja, error) {
@@ -749,6 +845,11 @@ func (service *RencanaKinerjaServiceImpl) FindAllRincianKak(ctx context.Context,
 
 	var responses []rencanakinerja.DataRincianKerja
 	for _, rencanaKinerja := range rencanaKinerjaList {
+		// KAK hanya boleh diambil dari rencana kinerja OPD sendiri
+		if err := helper.ValidateKodeOpdAccess(ctx, rencanaKinerja.KodeOpd); err != nil {
+			return nil, err
+		}
+
 		// Ambil indikator untuk setiap rencana kinerja
 		indikators, err := service.rencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, rencanaKinerja.Id)
 		if err != nil && err != sql.ErrNoRows {
@@ -1083,6 +1184,27 @@ func (service *RencanaKinerjaServiceImpl) FindAllRincianKak(ctx context.Context,
 	return responses, nil
 }
 
+// GenerateKak menyusun dokumen KAK satu rencana kinerja dari rincian kak dalam format docx atau pdf
+func (service *RencanaKinerjaServiceImpl) GenerateKak(ctx context.Context, pegawaiId string, rencanaKinerjaId string, format string) ([]byte, error) {
+	if format != "docx" && format != "pdf" {
+		return nil, web.NewBadRequestError(fmt.Sprintf("format KAK %s tidak didukung, gunakan docx atau pdf", format))
+	}
+
+	rincianKak, err := service.FindAllRincianKak(ctx, pegawaiId, rencanaKinerjaId)
+	if err != nil {
+		return nil, err
+	}
+	if len(rincianKak) == 0 {
+		return nil, web.NewNotFoundError("rencana kinerja tidak ditemukan")
+	}
+
+	document := newKakDocument(rincianKak[0])
+	if format == "docx" {
+		return buildKakDocx(document)
+	}
+	return buildKakPdf(document)
+}
+
 func (service *RencanaKinerjaServiceImpl) RekinsasaranOpd(ctx context.Context, pegawaiId string, kodeOPD string, tahun string) ([]rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses RekinsasaranOpd")
 
@@ -1206,10 +1328,10 @@ func (service *RencanaKinerjaServiceImpl) RekinsasaranOpd(ctx context.Context, p
 func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context, request rencanakinerja.RencanaKinerjaCreateRequest) (rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses Create RencanaKinerja")
 
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		log.Printf("Validasi gagal: %v", err)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
+		return rencanakinerja.RencanaKinerjaResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
@@ -1219,12 +1341,19 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, request.KodeOpd, request.Tahun); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	// Perbaikan pengecekan kode OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Kode OPD %s tidak ditemukan", request.KodeOpd)
-			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak ditemukan", request.KodeOpd)
+			return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("kode OPD %s tidak ditemukan", request.KodeOpd))
 		}
 		log.Printf("Gagal memeriksa kode OPD: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memeriksa kode OPD: %v", err)
@@ -1232,7 +1361,7 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 
 	if opd.KodeOpd == "" {
 		log.Printf("Kode OPD %s tidak valid", request.KodeOpd)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak valid", request.KodeOpd)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewBadRequestError(fmt.Sprintf("kode OPD %s tidak valid", request.KodeOpd))
 	}
 
 	pegawais, err := service.pegawaiRepository.FindByNip(ctx, tx, request.PegawaiId)
@@ -1243,7 +1372,7 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 
 	if pegawais.Id == "" {
 		log.Printf("Pegawai dengan Nip %s tidak ditemukan", request.PegawaiId)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pegawai dengan Nip %s tidak ditemukan", request.PegawaiId)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("pegawai dengan Nip %s tidak ditemukan", request.PegawaiId))
 	}
 
 	pohon, err := service.pohonKinerjaRepository.FindById(ctx, tx, request.IdPohon)
@@ -1256,6 +1385,9 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 		log.Printf("Pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 	}
+	if err := validatePohonRekinAccess(ctx, pohon); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	year := time.Now().Year()
@@ -1268,7 +1400,7 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 		NamaRencanaKinerja:   request.NamaRencanaKinerja,
 		Tahun:                request.Tahun,
 		KodeSubKegiatan:      "",
-		StatusRencanaKinerja: request.StatusRencanaKinerja,
+		StatusRencanaKinerja: domain.StatusRekinDraft,
 		Catatan:              request.Catatan,
 		KodeOpd:              request.KodeOpd,
 		PegawaiId:            pegawais.Nip,
@@ -1350,6 +1482,10 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 		log.Printf("Gagal menyimpan RencanaKinerja: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menyimpan RencanaKinerja: %v", err)
 	}
+	err = recordRencanaKinerjaAudit(ctx, tx, service.auditLogRepository, service.rencanaKinerjaRepository, domain.AuditActionCreate, rencanaKinerja.Id, nil)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	rencanaKinerja.NamaOpd = opd.NamaOpd
 	rencanaKinerja.NamaPegawai = pegawais.NamaPegawai
@@ -1364,10 +1500,10 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context, request rencanakinerja.RencanaKinerjaUpdateRequest) (rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses Update RencanaKinerja")
 
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		log.Printf("Validasi gagal: %v", err)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
+		return rencanakinerja.RencanaKinerjaResponse{}, err
 	}
 
 	tx, err := service.DB.Begin()
@@ -1377,12 +1513,19 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, request.KodeOpd, request.Tahun); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Kode OPD %s tidak ditemukan", request.KodeOpd)
-			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak ditemukan", request.KodeOpd)
+			return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("kode OPD %s tidak ditemukan", request.KodeOpd))
 		}
 		log.Printf("Gagal memeriksa kode OPD: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memeriksa kode OPD: %v", err)
@@ -1390,7 +1533,7 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 
 	if opd.KodeOpd == "" {
 		log.Printf("Kode OPD %s tidak valid", request.KodeOpd)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("kode OPD %s tidak valid", request.KodeOpd)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewBadRequestError(fmt.Sprintf("kode OPD %s tidak valid", request.KodeOpd))
 	}
 
 	// Validasi Pegawai
@@ -1402,7 +1545,7 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 
 	if pegawai.Id == "" {
 		log.Printf("Pegawai dengan NIP %s tidak ditemukan", request.PegawaiId)
-		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pegawai dengan NIP %s tidak ditemukan", request.PegawaiId)
+		return rencanakinerja.RencanaKinerjaResponse{}, web.NewNotFoundError(fmt.Sprintf("pegawai dengan NIP %s tidak ditemukan", request.PegawaiId))
 	}
 
 	pohon, err := service.pohonKinerjaRepository.FindById(ctx, tx, request.IdPohon)
@@ -1415,6 +1558,9 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 		log.Printf("Pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("pohon kinerja dengan ID %v tidak ditemukan", request.IdPohon)
 	}
+	if err := validatePohonRekinAccess(ctx, pohon); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	var rencanaKinerja domain.RencanaKinerja
 
@@ -1423,6 +1569,15 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 		if err != nil {
 			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menemukan RencanaKinerja: %v", err)
 		}
+		if err := helper.ValidateKodeOpdAccess(ctx, rencanaKinerja.KodeOpd); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		if err := checkStatusRekin(ctx, tx, service.persetujuanRekinRepository, rencanaKinerja.Id); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
 
 		// Ambil semua indikator lama
 		indikatorLamaList, err := service.rencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, rencanaKinerja.Id)
@@ -1453,7 +1608,7 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 	rencanaKinerja.SasaranOpdId = helper.EmptyIntIfNull(request.SasaranOpdId)
 	rencanaKinerja.NamaRencanaKinerja = request.NamaRencanaKinerja
 	rencanaKinerja.Tahun = request.Tahun
-	rencanaKinerja.StatusRencanaKinerja = request.StatusRencanaKinerja
+	// status tidak diubah di sini, hanya lewat alur persetujuan
 	rencanaKinerja.Catatan = request.Catatan
 	rencanaKinerja.KodeOpd = request.KodeOpd
 	rencanaKinerja.PegawaiId = request.PegawaiId
@@ -1582,12 +1737,18 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 		rencanaKinerja.Indikator[i] = indikator
 	}
 
+	auditBefore := rencanaKinerjaAuditSnapshot(ctx, tx, service.rencanaKinerjaRepository, request.Id)
+
 	log.Println("Memanggil repository.Update")
 	rencanaKinerja, err = service.rencanaKinerjaRepository.UpdateRekinLevel1(ctx, tx, rencanaKinerja)
 	if err != nil {
 		log.Printf("Gagal memperbarui RencanaKinerja: %v", err)
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal memperbarui RencanaKinerja: %v", err)
 	}
+	err = recordRencanaKinerjaAudit(ctx, tx, service.auditLogRepository, service.rencanaKinerjaRepository, domain.AuditActionUpdate, request.Id, auditBefore)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	rencanaKinerja.NamaOpd = opd.NamaOpd
 	rencanaKinerja.NamaPegawai = pegawai.NamaPegawai
@@ -1611,7 +1772,7 @@ func (service *RencanaKinerjaServiceImpl) FindIdRekinLevel1(ctx context.Context,
 	rencanaKinerja, err := service.rencanaKinerjaRepository.FindIdRekinLevel1(ctx, tx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return rencanakinerja.RencanaKinerjaLevel1Response{}, fmt.Errorf("rencana kinerja dengan ID %s tidak ditemukan", id)
+			return rencanakinerja.RencanaKinerjaLevel1Response{}, web.NewNotFoundError(fmt.Sprintf("rencana kinerja dengan ID %s tidak ditemukan", id))
 		}
 		return rencanakinerja.RencanaKinerjaLevel1Response{}, fmt.Errorf("gagal mengambil data rencana kinerja: %v", err)
 	}
@@ -1835,7 +1996,7 @@ func (service *RencanaKinerjaServiceImpl) FindRekinAtasan(ctx context.Context, r
 	pokinChild, err := service.CascadingOpdService.cascadingOpdRepository.FindPokinByRekinId(ctx, tx, rekinId)
 	if err != nil {
 		log.Printf("Error: Pohon kinerja not found for rekin_id=%s: %v", rekinId, err)
-		return rencanakinerja.RekinAtasanResponse{}, errors.New("pohon kinerja tidak ditemukan")
+		return rencanakinerja.RekinAtasanResponse{}, web.NewNotFoundError("pohon kinerja tidak ditemukan")
 	}
 
 	log.Printf("Found pohon kinerja: ID=%d, Level=%d, Parent=%d", pokinChild.Id, pokinChild.LevelPohon, pokinChild.Parent)
@@ -1843,13 +2004,13 @@ func (service *RencanaKinerjaServiceImpl) FindRekinAtasan(ctx context.Context, r
 	// 3. Cari parent pohon kinerja
 	if pokinChild.Parent == 0 {
 		log.Printf("Pohon kinerja tidak memiliki parent (sudah di root)")
-		return rencanakinerja.RekinAtasanResponse{}, errors.New("pohon kinerja tidak memiliki parent")
+		return rencanakinerja.RekinAtasanResponse{}, web.NewBadRequestError("pohon kinerja tidak memiliki parent")
 	}
 
 	pokinParent, err := service.CascadingOpdService.cascadingOpdRepository.FindPokinById(ctx, tx, pokinChild.Parent)
 	if err != nil {
 		log.Printf("Error: Parent pohon kinerja not found: %v", err)
-		return rencanakinerja.RekinAtasanResponse{}, errors.New("parent pohon kinerja tidak ditemukan")
+		return rencanakinerja.RekinAtasanResponse{}, web.NewNotFoundError("parent pohon kinerja tidak ditemukan")
 	}
 
 	log.Printf("Found parent pohon kinerja: ID=%d, Level=%d, Nama=%s", pokinParent.Id, pokinParent.LevelPohon, pokinParent.NamaPohon)
@@ -1858,7 +2019,7 @@ func (service *RencanaKinerjaServiceImpl) FindRekinAtasan(ctx context.Context, r
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, pokinParent.KodeOpd)
 	if err != nil {
 		log.Printf("Error: OPD not found for kode_opd=%s: %v", pokinParent.KodeOpd, err)
-		return rencanakinerja.RekinAtasanResponse{}, errors.New("kode opd tidak ditemukan")
+		return rencanakinerja.RekinAtasanResponse{}, web.NewNotFoundError("kode opd tidak ditemukan")
 	}
 
 	// 5. Hitung pagu anggaran parent dari semua children-nya
@@ -2163,6 +2324,13 @@ func (service *RencanaKinerjaServiceImpl) CloneRencanaKinerja(ctx context.Contex
 	if err != nil {
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("rencana kinerja tidak ditemukan: %v", err)
 	}
+	kodeOpd, _, err := service.rencanaKinerjaRepository.FindKodeOpdTahunById(ctx, tx, rekinId)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahunBaru); err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
 
 	// 2. Clone rencana kinerja utama
 	newRekin, err := service.rencanaKinerjaRepository.CloneRencanaKinerja(ctx, tx, rekinId, tahunBaru)
@@ -2344,6 +2512,11 @@ func (service *RencanaKinerjaServiceImpl) CloneRencanaKinerja(ctx context.Contex
 		Indikator:   indikatorResponses,
 	}
 
+	err = recordRencanaKinerjaAudit(ctx, tx, service.auditLogRepository, service.rencanaKinerjaRepository, domain.AuditActionClone, newRekin.Id, nil)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	return response, nil
 }
 
@@ -2389,21 +2562,29 @@ func (service *RencanaKinerjaServiceImpl) FindByFilter(ctx context.Context, filt
 	return response, nil
 }
 
+// CloneRekinByKodeOpdAndTahun memvalidasi dan mencatat clone_record, proses clone dijalankan job runner
 func (service *RencanaKinerjaServiceImpl) CloneRekinByKodeOpdAndTahun(
 	ctx context.Context,
 	cloneRequest rencanakinerja.RekinByOpdCloneRequest,
-) error {
+) (job.JobResponse, error) {
 
 	tx, err := service.DB.Begin()
 	if err != nil {
 		log.Printf("Gagal memulai transaksi: %v", err)
-		return fmt.Errorf("gagal memulai transaksi: %w", err)
+		return job.JobResponse{}, fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	defer tx.Rollback()
 
 	kodeOpd := cloneRequest.KodeOpd
 	tahunSumber := cloneRequest.TahunSumber
+
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return job.JobResponse{}, err
+	}
 	tahunTarget := cloneRequest.TahunTujuan
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRencanaKinerja, kodeOpd, tahunTarget); err != nil {
+		return job.JobResponse{}, err
+	}
 
 	// 1. Check existing clone record
 	existing, err := service.cloneRecordRepository.
@@ -2413,26 +2594,26 @@ func (service *RencanaKinerjaServiceImpl) CloneRekinByKodeOpdAndTahun(
 		// bedakan error
 		if err.Error() != "clone record not found" {
 			log.Printf("error checking clone record: %v", err)
-			return fmt.Errorf("gagal cek data clone: %w", err)
+			return job.JobResponse{}, fmt.Errorf("gagal cek data clone: %w", err)
 		}
 	}
-	if existing.Status == "DONE" || existing.Status == "DONE_WITH_WARNING" {
+	if existing.Status == domain.JobStatusDone || existing.Status == domain.JobStatusDoneWithWarning {
 		// sudah pernah clone
-		return fmt.Errorf(
+		return job.JobResponse{}, web.NewConflictError(fmt.Sprintf(
 			"rekin opd %s dari %s ke %s sudah di-clone pada %v status %s",
 			kodeOpd,
 			tahunSumber,
 			tahunTarget,
 			existing.CreatedAt.Format("2006-01-02 15:04:05"),
 			existing.Status,
-		)
+		))
 	}
-	if existing.Status == "PROCESS" || existing.Status == "PENDING" {
+	if existing.Status == domain.JobStatusProcess || existing.Status == domain.JobStatusPending {
 		// sudah pernah clone
-		return fmt.Errorf("clone rekin sedang berjalan")
+		return job.JobResponse{}, web.NewConflictError("clone rekin sedang berjalan")
 	}
 
-	// 2. (Optional) insert tracking dulu → untuk lock logical
+	// 2. insert tracking dulu → untuk lock logical
 	newRecord := domain.CloneRecord{
 		KodeClone:   helper.GenerateKodeClone(kodeOpd), // pastikan ada
 		KodeOpd:     kodeOpd,
@@ -2445,33 +2626,72 @@ func (service *RencanaKinerjaServiceImpl) CloneRekinByKodeOpdAndTahun(
 	if err != nil {
 		// handle duplicate (jika UNIQUE constraint ada)
 		log.Printf("error insert clone record: %v", err)
-		return fmt.Errorf("gagal menyimpan record clone: %w", err)
+		return job.JobResponse{}, fmt.Errorf("gagal menyimpan record clone: %w", err)
 	}
 
-	// 4. jalankan async (OUTSIDE TX)
-	go func(recordId int, req rencanakinerja.RekinByOpdCloneRequest) {
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("panic clone: %v", r)
-				service.updateStatusSafe(recordId, "FAILED", fmt.Sprintf("%v", r))
-			}
-		}()
+	err = recordAuditLog(ctx, tx, service.auditLogRepository, domain.AuditLog{
+		Action:     domain.AuditActionClone,
+		EntityType: domain.AuditEntityRencanaKinerja,
+		EntityId:   kodeOpd,
+		KodeOpd:    kodeOpd,
+		Tahun:      tahunTarget,
+	}, nil, cloneRequest)
+	if err != nil {
+		return job.JobResponse{}, err
+	}
 
-		warningMsg, err := service.doCloneBackground(context.Background(), req, recordId)
-		if err != nil {
-			log.Printf("clone background gagal: %v", err)
-			service.updateStatusSafe(recordId, "FAILED", err.Error())
-			return
-		}
-		if warningMsg != "" {
-			service.updateStatusSafe(recordId, "DONE_WITH_WARNING", warningMsg)
-			return
-		}
+	// 3. masukkan ke antrian job, clone_record diperbarui oleh handler job
+	cloneJob, err := service.jobService.Enqueue(ctx, tx, domain.JobCloneRencanaKinerja, rekinCloneJobPayload{
+		CloneRecordId: cloneRecord.Id,
+		Request:       cloneRequest,
+	}, kodeOpd, tahunTarget)
+	if err != nil {
+		return job.JobResponse{}, fmt.Errorf("gagal membuat job clone: %w", err)
+	}
 
-		service.updateStatusSafe(recordId, "DONE", "")
-	}(cloneRecord.Id, cloneRequest)
+	if err := tx.Commit(); err != nil {
+		return job.JobResponse{}, fmt.Errorf("gagal commit transaksi: %w", err)
+	}
+	return toJobResponse(cloneJob), nil
+}
+
+type rekinCloneJobPayload struct {
+	CloneRecordId int                                   `json:"clone_record_id"`
+	Request       rencanakinerja.RekinByOpdCloneRequest `json:"request"`
+}
+
+func (service *RencanaKinerjaServiceImpl) runCloneRekinJob(ctx context.Context, cloneJob domain.Job, progress JobProgressFunc) (domain.JobResult, error) {
+	var payload rekinCloneJobPayload
+	if err := json.Unmarshal(cloneJob.Payload, &payload); err != nil {
+		return domain.JobResult{}, web.NewBadRequestError(fmt.Sprintf("payload job clone rekin tidak valid: %v", err))
+	}
+
+	service.updateStatusSafe(payload.CloneRecordId, domain.JobStatusProcess, "")
+
+	warnings, err := service.doCloneBackground(ctx, payload.Request, progress)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+	return domain.JobResult{Warnings: cloneWarnings(warnings)}, nil
+}
+
+// finishCloneRekinJob menyamakan status clone_record dengan status akhir job
+func (service *RencanaKinerjaServiceImpl) finishCloneRekinJob(ctx context.Context, cloneJob domain.Job) {
+	var payload rekinCloneJobPayload
+	if err := json.Unmarshal(cloneJob.Payload, &payload); err != nil {
+		log.Printf("payload job clone rekin %d tidak valid: %v", cloneJob.Id, err)
+		return
+	}
 
-	return nil
+	message := cloneJob.ErrorMessage
+	if cloneJob.Status == domain.JobStatusDoneWithWarning {
+		parts := make([]string, 0, len(cloneJob.Warnings))
+		for _, warning := range cloneJob.Warnings {
+			parts = append(parts, warning.Message)
+		}
+		message = strings.Join(parts, "; ")
+	}
+	service.updateStatusSafe(payload.CloneRecordId, cloneJob.Status, message)
 }
 
 func toIndikatorResponses(
@@ -2514,20 +2734,19 @@ func toTargetResponses(targets []domain.Target) []rencanakinerja.TargetResponse
 	return responses
 }
 
+// doCloneBackground menyalin rekin beserta detailnya dalam satu transaksi,
+// error di tahap mana pun membatalkan seluruh salinan sehingga job aman diulang
 func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	ctx context.Context,
 	req rencanakinerja.RekinByOpdCloneRequest,
-	recordId int,
-) (warningMsg string, err error) {
+	progress JobProgressFunc,
+) (map[domain.WarningType]int, error) {
 
-	tx, err := service.DB.Begin()
+	tx, err := service.DB.BeginTx(ctx, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer helper.CommitOrRollback(tx)
-
-	// 1. set status PROCESS
-	service.updateStatusSafe(recordId, "PROCESS", "")
+	defer tx.Rollback()
 
 	// kumpulan warning missing dan orphan
 	// dari detail rekin
@@ -2573,8 +2792,7 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 		req.TahunSumber,
 	)
 	if err != nil {
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
 
 	oldToNewRekin := make(map[string]string)
@@ -2597,6 +2815,7 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 		newItem.Tahun = tahunTujuan
 		newItem.IdPohon = 0
 		newItem.KodeSubKegiatan = ""
+		newItem.StatusRencanaKinerja = domain.StatusRekinDraft
 
 		// new item indikator
 		var newItemIndikator []domain.Indikator
@@ -2634,8 +2853,9 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.rencanaKinerjaRepository.CreateBatch(ctx, tx, newRekins)
 	if err != nil {
 		log.Printf("rencanaKinerjaRepository.CreateBatch error: %v", err)
-		return "", err
+		return nil, err
 	}
+	progress(20)
 	// 4. manualik
 	oldIndikatorIds := make([]string, 0, len(oldToNewIndikator))
 	for oldIndId := range oldToNewIndikator {
@@ -2645,7 +2865,7 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	manualIks, err := service.manualIKRepository.FindByIndikatorIds(ctx, tx, oldIndikatorIds)
 	if err != nil {
 		log.Printf("manualIKRepository.FindByIndikatorIds error: %v", err)
-		return "", err
+		return nil, err
 	}
 	if len(manualIks) == 0 {
 		addWarning(warnings, WarningManualIKMissing)
@@ -2664,10 +2884,10 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.manualIKRepository.CreateBatch(ctx, tx, newManualIks)
 	if err != nil {
 		log.Printf("rencanaKinerjaRepository.CreateDetailBatch error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
 
+	progress(35)
 	// 5. renaksis
 	oldRekinIds := make([]string, 0, len(oldToNewRekin))
 	for oldRekinId := range oldToNewRekin {
@@ -2677,7 +2897,7 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	renaksis, err := service.rencanaAksiRepository.FindRenaksiByRekinIds(ctx, tx, oldRekinIds)
 	if err != nil {
 		log.Printf("rencanaAksiRepository.FindRenaksiByRekinIds error: %v", err)
-		return "", err
+		return nil, err
 	}
 	if len(renaksis) == 0 {
 		addWarning(warnings, WarningRenaksiMissing)
@@ -2697,15 +2917,15 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.rencanaAksiRepository.BatchCreate(ctx, tx, newRenaksis)
 	if err != nil {
 		log.Printf("rencanaKinerjaRepository.CreateDetailBatch error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
 	// 6.1. TODO pelaksanaan
+	progress(50)
 	// 7. Dasar Hukum
 	dasarHukums, err := service.DasarHukumRepository.FindByRekinIds(ctx, tx, oldRekinIds)
 	if err != nil {
 		log.Printf("DasarHukumRepository.FindByRekinIds error: %v", err)
-		return "", err
+		return nil, err
 	}
 	if len(dasarHukums) == 0 {
 		addWarning(warnings, WarningDasarHukumMissing)
@@ -2725,14 +2945,14 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.DasarHukumRepository.BatchCreate(ctx, tx, newDasarHukums)
 	if err != nil {
 		log.Printf("DasarHukumRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
+	progress(65)
 	// 8. Permasalahan
 	permasalahans, err := service.permasalahanRekinRepository.FindByRekinIds(ctx, tx, oldRekinIds)
 	if err != nil {
 		log.Printf("service.permasalahanRekinRepository error: %v", err)
-		return "", err
+		return nil, err
 	}
 	if len(permasalahans) == 0 {
 		addWarning(warnings, WarningPermasalahanMissing)
@@ -2751,14 +2971,14 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.permasalahanRekinRepository.BatchCreate(ctx, tx, newPermasalahans)
 	if err != nil {
 		log.Printf("permasalahanRekinRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
+	progress(75)
 	// 9. Gambaran Umum
 	gambaranUmums, err := service.GambaranUmumRepository.FindByRekinIds(ctx, tx, oldRekinIds)
 	if err != nil {
 		log.Printf("GambaranUmumRepository.FindByRekinIds error: %v", err)
-		return "", err
+		return nil, err
 	}
 	if len(gambaranUmums) == 0 {
 		addWarning(warnings, WarningGambaranUmumMissing)
@@ -2778,15 +2998,15 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.GambaranUmumRepository.BatchCreate(ctx, tx, newGamabaranUmums)
 	if err != nil {
 		log.Printf("GambaranUmumRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
 
+	progress(85)
 	// 10. Inovasi
 	inovasis, err := service.InovasiRepository.FindByRekinIds(ctx, tx, oldRekinIds)
 	if err != nil {
 		log.Printf("InovasiRepository.FindByRekinIds error: %v", err)
-		return "", err
+		return nil, err
 	}
 	if len(inovasis) == 0 {
 		addWarning(warnings, WarningInovasiMissing)
@@ -2806,14 +3026,16 @@ func (service *RencanaKinerjaServiceImpl) doCloneBackground(
 	err = service.InovasiRepository.BatchCreate(ctx, tx, newInovasis)
 	if err != nil {
 		log.Printf("InovasiRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
+		return nil, err
 	}
 	// 11. ...
 
-	warningMsg = buildWarningMessage(warnings)
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	progress(100)
 
-	return warningMsg, nil
+	return warnings, nil
 }
 
 func genereateRekinId() string {
@@ -2885,19 +3107,3 @@ func (service *RencanaKinerjaServiceImpl) updateStatusSafe(
 func addWarning(w map[domain.WarningType]int, t domain.WarningType) {
 	w[t]++
 }
-
-func buildWarningMessage(warnings map[domain.WarningType]int) string {
-	if len(warnings) == 0 {
-		return ""
-	}
-
-	var parts []string
-
-	for t, count := range warnings {
-		parts = append(parts,
-			fmt.Sprintf("%d %s", count, t),
-		)
-	}
-
-	return strings.Join(parts, "; ")
-}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"testing"
+)
+
+func TestValidatePohonRekinAccess(t *testing.T) {
+	adminOpd := web.JWTClaim{UserId: 1, KodeOpd: "1.01", Roles: []string{helper.RoleAdminOpd}}
+	superAdmin := web.JWTClaim{UserId: 2, Roles: []string{helper.RoleSuperAdmin}}
+	tests := []struct {
+		name    string
+		claims  web.JWTClaim
+		kodeOpd string
+		wantErr bool
+	}{
+		{name: "pohon OPD sendiri", claims: adminOpd, kodeOpd: "1.01"},
+		{name: "pohon OPD lain", claims: adminOpd, kodeOpd: "1.02", wantErr: true},
+		{name: "pohon pemda tanpa kode_opd", claims: adminOpd},
+		{name: "super admin lintas OPD", claims: superAdmin, kodeOpd: "1.02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), helper.UserInfoKey, tt.claims)
+			err := validatePohonRekinAccess(ctx, domain.PohonKinerja{Id: 1, KodeOpd: tt.kodeOpd})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePohonRekinAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
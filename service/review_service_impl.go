@@ -9,7 +9,9 @@ import (
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/repository"
 	"errors"
+	"fmt"
 	"math/rand"
+	"strconv"
 )
 
 type ReviewServiceImpl struct {
@@ -17,18 +19,26 @@ type ReviewServiceImpl struct {
 	DB                     *sql.DB
 	PohonKinerjaRepository repository.PohonKinerjaRepository
 	pegawaiRepository      repository.PegawaiRepository
+	auditLogRepository     repository.AuditLogRepository
+	notifikasiService      NotifikasiService
 }
 
-func NewReviewServiceImpl(reviewRepository repository.ReviewRepository, db *sql.DB, pohonkinerjaRepository repository.PohonKinerjaRepository, pegawaiRepository repository.PegawaiRepository) *ReviewServiceImpl {
+func NewReviewServiceImpl(reviewRepository repository.ReviewRepository, db *sql.DB, pohonkinerjaRepository repository.PohonKinerjaRepository, pegawaiRepository repository.PegawaiRepository, auditLogRepository repository.AuditLogRepository, notifikasiService NotifikasiService) *ReviewServiceImpl {
 	return &ReviewServiceImpl{
 		ReviewRepository:       reviewRepository,
 		DB:                     db,
 		PohonKinerjaRepository: pohonkinerjaRepository,
 		pegawaiRepository:      pegawaiRepository,
+		auditLogRepository:     auditLogRepository,
+		notifikasiService:      notifikasiService,
 	}
 }
 
 func (service *ReviewServiceImpl) Create(ctx context.Context, request pohonkinerja.ReviewCreateRequest) (pohonkinerja.ReviewResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.ReviewResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.ReviewResponse{}, err
@@ -38,10 +48,10 @@ func (service *ReviewServiceImpl) Create(ctx context.Context, request pohonkiner
 	// Mendapatkan claims dari context
 	claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim)
 	if !ok {
-		return pohonkinerja.ReviewResponse{}, errors.New("unauthorized: invalid user info in context")
+		return pohonkinerja.ReviewResponse{}, web.NewUnauthorizedError("unauthorized: invalid user info in context")
 	}
 	if claims.Nip == "" {
-		return pohonkinerja.ReviewResponse{}, errors.New("unauthorized: NIP tidak ditemukan")
+		return pohonkinerja.ReviewResponse{}, web.NewUnauthorizedError("unauthorized: NIP tidak ditemukan")
 	}
 
 	err = service.PohonKinerjaRepository.ValidatePokinId(ctx, tx, request.IdPohonKinerja)
@@ -73,6 +83,16 @@ func (service *ReviewServiceImpl) Create(ctx context.Context, request pohonkiner
 		return pohonkinerja.ReviewResponse{}, err
 	}
 
+	err = service.recordReviewAudit(ctx, tx, domain.AuditActionCreate, result.IdPohonKinerja, result.Id, nil, result)
+	if err != nil {
+		return pohonkinerja.ReviewResponse{}, err
+	}
+
+	err = service.kirimNotifikasiReview(ctx, tx, result)
+	if err != nil {
+		return pohonkinerja.ReviewResponse{}, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return pohonkinerja.ReviewResponse{}, err
@@ -92,6 +112,10 @@ func (service *ReviewServiceImpl) Create(ctx context.Context, request pohonkiner
 }
 
 func (service *ReviewServiceImpl) Update(ctx context.Context, request pohonkinerja.ReviewUpdateRequest) (pohonkinerja.ReviewResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return pohonkinerja.ReviewResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.ReviewResponse{}, err
@@ -99,9 +123,9 @@ func (service *ReviewServiceImpl) Update(ctx context.Context, request pohonkiner
 	defer tx.Rollback()
 
 	// Cek apakah review ada
-	_, err = service.ReviewRepository.FindById(ctx, tx, request.Id)
+	existingReview, err := service.ReviewRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
-		return pohonkinerja.ReviewResponse{}, errors.New("review tidak ditemukan")
+		return pohonkinerja.ReviewResponse{}, web.NewNotFoundError("review tidak ditemukan")
 	}
 
 	review := domain.Review{
@@ -115,6 +139,14 @@ func (service *ReviewServiceImpl) Update(ctx context.Context, request pohonkiner
 		return pohonkinerja.ReviewResponse{}, err
 	}
 
+	updatedReview := existingReview
+	updatedReview.Review = result.Review
+	updatedReview.Keterangan = result.Keterangan
+	err = service.recordReviewAudit(ctx, tx, domain.AuditActionUpdate, existingReview.IdPohonKinerja, request.Id, existingReview, updatedReview)
+	if err != nil {
+		return pohonkinerja.ReviewResponse{}, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return pohonkinerja.ReviewResponse{}, err
@@ -139,9 +171,9 @@ func (service *ReviewServiceImpl) Delete(ctx context.Context, id int) error {
 	defer tx.Rollback()
 
 	// Cek apakah review ada
-	_, err = service.ReviewRepository.FindById(ctx, tx, id)
+	existingReview, err := service.ReviewRepository.FindById(ctx, tx, id)
 	if err != nil {
-		return errors.New("review tidak ditemukan")
+		return web.NewNotFoundError("review tidak ditemukan")
 	}
 
 	err = service.ReviewRepository.Delete(ctx, tx, id)
@@ -149,6 +181,11 @@ func (service *ReviewServiceImpl) Delete(ctx context.Context, id int) error {
 		return err
 	}
 
+	err = service.recordReviewAudit(ctx, tx, domain.AuditActionDelete, existingReview.IdPohonKinerja, id, existingReview, nil)
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -223,7 +260,7 @@ func (service *ReviewServiceImpl) FindAllReviewByTematik(ctx context.Context, ta
 
 	// Validasi tahun
 	if tahun == "" {
-		return nil, errors.New("tahun harus diisi")
+		return nil, web.NewBadRequestError("tahun harus diisi")
 	}
 
 	reviews, err := service.ReviewRepository.FindAllReviewByTematik(ctx, tx, tahun)
@@ -308,3 +345,41 @@ func (service *ReviewServiceImpl) FindAllReviewOpd(ctx context.Context, kodeOpd,
 
 	return reviewResponses, nil
 }
+
+// kirimNotifikasiReview memberi tahu admin OPD pemilik pohon kinerja yang direview
+func (service *ReviewServiceImpl) kirimNotifikasiReview(ctx context.Context, tx *sql.Tx, review domain.Review) error {
+	pokin, err := service.PohonKinerjaRepository.FindById(ctx, tx, review.IdPohonKinerja)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if pokin.KodeOpd == "" {
+		return nil
+	}
+	return service.notifikasiService.Kirim(ctx, tx, domain.Notifikasi{
+		Jenis:      domain.JenisNotifikasiReview,
+		Judul:      "Review pohon kinerja baru",
+		Pesan:      fmt.Sprintf("Pohon kinerja \"%s\" mendapat review: %s", pokin.NamaPohon, review.Review),
+		EntityType: domain.AuditEntityPohonKinerja,
+		EntityId:   strconv.Itoa(review.IdPohonKinerja),
+		KodeOpd:    pokin.KodeOpd,
+		Tahun:      pokin.Tahun,
+		Url:        fmt.Sprintf("/review_pokin/findall/%d", review.IdPohonKinerja),
+	}, domain.PenerimaNotifikasi{KodeOpd: pokin.KodeOpd, Roles: []string{helper.RoleAdminOpd}})
+}
+
+// recordReviewAudit mencatat perubahan review dengan kode_opd dan tahun dari pohon kinerja yang direview
+func (service *ReviewServiceImpl) recordReviewAudit(ctx context.Context, tx *sql.Tx, action string, idPohonKinerja int, reviewId int, before, after interface{}) error {
+	entry := domain.AuditLog{
+		Action:     action,
+		EntityType: domain.AuditEntityReview,
+		EntityId:   strconv.Itoa(reviewId),
+	}
+	if pokin, err := service.PohonKinerjaRepository.FindById(ctx, tx, idPohonKinerja); err == nil {
+		entry.KodeOpd = pokin.KodeOpd
+		entry.Tahun = pokin.Tahun
+	}
+	return recordAuditLog(ctx, tx, service.auditLogRepository, entry, before, after)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rincianbelanja"
 	"ekak_kabupaten_madiun/repository"
 	"errors"
@@ -14,32 +15,70 @@ import (
 )
 
 type RincianBelanjaServiceImpl struct {
-	rincianBelanjaRepository repository.RincianBelanjaRepository
-	pegawaiRepository        repository.PegawaiRepository
-	DB                       *sql.DB
+	rincianBelanjaRepository   repository.RincianBelanjaRepository
+	pegawaiRepository          repository.PegawaiRepository
+	lockDataRepository         repository.LockDataRepository
+	persetujuanRekinRepository repository.PersetujuanRekinRepository
+	DB                         *sql.DB
+	cache                      *helper.Cache
 }
 
-func NewRincianBelanjaServiceImpl(rincianBelanjaRepository repository.RincianBelanjaRepository, pegawaiRepository repository.PegawaiRepository, DB *sql.DB) *RincianBelanjaServiceImpl {
+func NewRincianBelanjaServiceImpl(rincianBelanjaRepository repository.RincianBelanjaRepository, pegawaiRepository repository.PegawaiRepository, lockDataRepository repository.LockDataRepository, persetujuanRekinRepository repository.PersetujuanRekinRepository, DB *sql.DB, cache *helper.Cache) *RincianBelanjaServiceImpl {
 	return &RincianBelanjaServiceImpl{
-		rincianBelanjaRepository: rincianBelanjaRepository,
-		pegawaiRepository:        pegawaiRepository,
-		DB:                       DB,
+		rincianBelanjaRepository:   rincianBelanjaRepository,
+		pegawaiRepository:          pegawaiRepository,
+		lockDataRepository:         lockDataRepository,
+		persetujuanRekinRepository: persetujuanRekinRepository,
+		DB:                         DB,
+		cache:                      cache,
 	}
 }
 
+// checkLockRincianBelanja cek akses OPD dan lock rincian belanja berdasarkan OPD dan tahun rencana kinerja
+// pemilik renaksi, serta status persetujuan rencana kinerja tersebut. OPD dan tahun dikembalikan untuk invalidasi cache.
+func (service *RincianBelanjaServiceImpl) checkLockRincianBelanja(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	kodeOpd, tahun, err := service.rincianBelanjaRepository.FindKodeOpdTahunByRenaksiId(ctx, tx, renaksiId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", web.NewNotFoundError("rencana aksi tidak ditemukan")
+		}
+		return "", "", err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, kodeOpd); err != nil {
+		return "", "", err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockRincianBelanja, kodeOpd, tahun); err != nil {
+		return "", "", err
+	}
+	if err := checkStatusRekinByRenaksi(ctx, tx, service.persetujuanRekinRepository, renaksiId); err != nil {
+		return "", "", err
+	}
+	return kodeOpd, tahun, nil
+}
+
 func (service *RincianBelanjaServiceImpl) Create(ctx context.Context, request rincianbelanja.RincianBelanjaCreateRequest) (rincianbelanja.RencanaAksiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return rincianbelanja.RencanaAksiResponse{}, err
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	// Validasi request
 	if request.RenaksiId == "" {
-		return rincianbelanja.RencanaAksiResponse{}, errors.New("renaksi_id tidak boleh kosong")
+		return rincianbelanja.RencanaAksiResponse{}, web.NewBadRequestError("renaksi_id tidak boleh kosong")
 	}
 	if request.Anggaran < 0 {
-		return rincianbelanja.RencanaAksiResponse{}, errors.New("anggaran tidak boleh negatif")
+		return rincianbelanja.RencanaAksiResponse{}, web.NewBadRequestError("anggaran tidak boleh negatif")
+	}
+	kodeOpd, tahun, err = service.checkLockRincianBelanja(ctx, tx, request.RenaksiId)
+	if err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
 	}
 
 	// Konversi request ke domain model
@@ -65,10 +104,16 @@ func (service *RincianBelanjaServiceImpl) Create(ctx context.Context, request ri
 }
 
 func (service *RincianBelanjaServiceImpl) Update(ctx context.Context, request rincianbelanja.RincianBelanjaUpdateRequest) (rincianbelanja.RencanaAksiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return rincianbelanja.RencanaAksiResponse{}, err
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	// Cek apakah data exists
@@ -77,7 +122,11 @@ func (service *RincianBelanjaServiceImpl) Update(ctx context.Context, request ri
 		return rincianbelanja.RencanaAksiResponse{}, err
 	}
 	if existing.RenaksiId == "" {
-		return rincianbelanja.RencanaAksiResponse{}, errors.New("rincian belanja tidak ditemukan")
+		return rincianbelanja.RencanaAksiResponse{}, web.NewNotFoundError("rincian belanja tidak ditemukan")
+	}
+	kodeOpd, tahun, err = service.checkLockRincianBelanja(ctx, tx, request.RenaksiId)
+	if err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
 	}
 
 	// Konversi request ke domain model
@@ -583,18 +632,28 @@ func (service *RincianBelanjaServiceImpl) LaporanRincianBelanjaPegawai(ctx conte
 }
 
 func (service *RincianBelanjaServiceImpl) Upsert(ctx context.Context, request rincianbelanja.RincianBelanjaCreateRequest) (rincianbelanja.RencanaAksiResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return rincianbelanja.RencanaAksiResponse{}, err
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	// Validasi request
 	if request.RenaksiId == "" {
-		return rincianbelanja.RencanaAksiResponse{}, errors.New("renaksi_id tidak boleh kosong")
+		return rincianbelanja.RencanaAksiResponse{}, web.NewBadRequestError("renaksi_id tidak boleh kosong")
 	}
 	if request.Anggaran < 0 {
-		return rincianbelanja.RencanaAksiResponse{}, errors.New("anggaran tidak boleh negatif")
+		return rincianbelanja.RencanaAksiResponse{}, web.NewBadRequestError("anggaran tidak boleh negatif")
+	}
+	kodeOpd, tahun, err = service.checkLockRincianBelanja(ctx, tx, request.RenaksiId)
+	if err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
 	}
 
 	// Konversi request ke domain model
@@ -22,6 +22,10 @@ func NewRoleServiceImpl(roleRepository repository.RoleRepository, DB *sql.DB) *R
 }
 
 func (service *RoleServiceImpl) Create(ctx context.Context, request user.RoleCreateRequest) (user.RoleResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return user.RoleResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return user.RoleResponse{}, err
@@ -43,6 +47,10 @@ func (service *RoleServiceImpl) Create(ctx context.Context, request user.RoleCre
 }
 
 func (service *RoleServiceImpl) Update(ctx context.Context, request user.RoleUpdateRequest) (user.RoleResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return user.RoleResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return user.RoleResponse{}, err
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/job"
+	"ekak_kabupaten_madiun/model/web/rollforward"
+)
+
+type RollForwardService interface {
+	RollForwardOpd(ctx context.Context, request rollforward.RollForwardOpdRequest) (job.JobResponse, error)
+}
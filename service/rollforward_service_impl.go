@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/job"
+	"ekak_kabupaten_madiun/model/web/rollforward"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pohon kinerja dengan jenis ini (atau status domain.StatusPokinDalamAlur) tidak ikut roll forward,
+// sama dengan aturan ClonePokinOpd
+var rollForwardJenisPemda = map[string]bool{
+	"Strategic Pemda":   true,
+	"Tactical Pemda":    true,
+	"Operational Pemda": true,
+}
+
+// parent pohon kinerja yang induknya tidak ikut disalin
+const rollForwardParentLepas = -100
+
+type RollForwardServiceImpl struct {
+	RollForwardRepository repository.RollForwardRepository
+	LockDataRepository    repository.LockDataRepository
+	AuditLogRepository    repository.AuditLogRepository
+	JobService            JobService
+	DB                    *sql.DB
+}
+
+func NewRollForwardServiceImpl(rollForwardRepository repository.RollForwardRepository, lockDataRepository repository.LockDataRepository, auditLogRepository repository.AuditLogRepository, jobService JobService, DB *sql.DB) *RollForwardServiceImpl {
+	service := &RollForwardServiceImpl{
+		RollForwardRepository: rollForwardRepository,
+		LockDataRepository:    lockDataRepository,
+		AuditLogRepository:    auditLogRepository,
+		JobService:            jobService,
+		DB:                    DB,
+	}
+	jobService.RegisterHandler(domain.JobRollForwardOpd, JobHandler{Run: service.runRollForwardOpdJob})
+	return service
+}
+
+// rollForwardOpdPayload payload job roll forward, tahun tujuan selalu tahun sumber + 1
+type rollForwardOpdPayload struct {
+	KodeOpd     string `json:"kode_opd"`
+	TahunSumber string `json:"tahun_sumber"`
+	TahunTujuan string `json:"tahun_tujuan"`
+}
+
+// RollForwardOpd memasukkan roll forward paket perencanaan OPD (pohon kinerja sampai sub kegiatan terpilih)
+// dari tahun N ke N+1 ke antrian job. Laporan hasil salin ada di result job.
+func (service *RollForwardServiceImpl) RollForwardOpd(ctx context.Context, request rollforward.RollForwardOpdRequest) (job.JobResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return job.JobResponse{}, err
+	}
+	if err := helper.ValidateKodeOpdAccess(ctx, request.KodeOpd); err != nil {
+		return job.JobResponse{}, err
+	}
+
+	tahunSumber, err := strconv.Atoi(request.TahunSumber)
+	if err != nil {
+		return job.JobResponse{}, web.NewBadRequestError("tahun sumber tidak valid")
+	}
+	payload := rollForwardOpdPayload{
+		KodeOpd:     request.KodeOpd,
+		TahunSumber: request.TahunSumber,
+		TahunTujuan: strconv.Itoa(tahunSumber + 1),
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return job.JobResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	if err := checkLockData(ctx, tx, service.LockDataRepository, domain.JenisLockRencanaKinerja, payload.KodeOpd, payload.TahunTujuan); err != nil {
+		return job.JobResponse{}, err
+	}
+
+	rollForwardJob, err := service.JobService.Enqueue(ctx, tx, domain.JobRollForwardOpd, payload, payload.KodeOpd, payload.TahunTujuan)
+	if err != nil {
+		return job.JobResponse{}, fmt.Errorf("gagal membuat job roll forward: %w", err)
+	}
+	return toJobResponse(rollForwardJob), nil
+}
+
+// runRollForwardOpdJob menyalin seluruh paket dalam satu transaksi, jika satu langkah gagal tidak ada data yang tersimpan
+func (service *RollForwardServiceImpl) runRollForwardOpdJob(ctx context.Context, rollForwardJob domain.Job, progress JobProgressFunc) (domain.JobResult, error) {
+	var payload rollForwardOpdPayload
+	if err := json.Unmarshal(rollForwardJob.Payload, &payload); err != nil {
+		return domain.JobResult{}, web.NewBadRequestError(fmt.Sprintf("payload job roll forward tidak valid: %v", err))
+	}
+
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+	defer tx.Rollback()
+
+	// dokumen bisa saja dikunci setelah job masuk antrian
+	if err := checkLockData(ctx, tx, service.LockDataRepository, domain.JenisLockRencanaKinerja, payload.KodeOpd, payload.TahunTujuan); err != nil {
+		return domain.JobResult{}, err
+	}
+
+	report := newRollForwardReport()
+
+	pokinIds, err := service.rollForwardPokin(ctx, tx, payload, report)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+	progress(50)
+
+	if err := service.rollForwardRekin(ctx, tx, payload, pokinIds, report); err != nil {
+		return domain.JobResult{}, err
+	}
+	progress(95)
+
+	response := report.response(payload)
+	err = recordAuditLog(ctx, tx, service.AuditLogRepository, domain.AuditLog{
+		Action:     domain.AuditActionClone,
+		EntityType: domain.AuditEntityRollForward,
+		EntityId:   payload.KodeOpd,
+		KodeOpd:    payload.KodeOpd,
+		Tahun:      payload.TahunTujuan,
+	}, nil, response.Ringkasan)
+	if err != nil {
+		return domain.JobResult{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.JobResult{}, err
+	}
+	return domain.JobResult{Warnings: cloneWarnings(report.warnings()), Data: response}, nil
+}
+
+// rollForwardPokin menyalin pohon kinerja beserta indikator, target, pelaksana, tagging dan CSF.
+// Hasilnya map id pohon sumber → id pohon tahun tujuan, termasuk pohon yang sudah pernah disalin.
+func (service *RollForwardServiceImpl) rollForwardPokin(ctx context.Context, tx *sql.Tx, payload rollForwardOpdPayload, report *rollForwardReport) (map[int]int, error) {
+	sourcePokins, err := service.RollForwardRepository.FindPokin(ctx, tx, payload.KodeOpd, payload.TahunSumber)
+	if err != nil {
+		return nil, err
+	}
+	sudahDisalin, err := service.RollForwardRepository.FindPokinCloneMap(ctx, tx, payload.KodeOpd, payload.TahunTujuan)
+	if err != nil {
+		return nil, err
+	}
+
+	pokinIds := make(map[int]int)
+	created := make(map[int]int)
+	for _, pokin := range sourcePokins {
+		item := domain.RollForwardItem{
+			Entitas:  domain.RollForwardPohonKinerja,
+			IdSumber: strconv.Itoa(pokin.Id),
+			Nama:     pokin.NamaPohon,
+		}
+		if alasan := rollForwardPokinSkipReason(pokin); alasan != "" {
+			item.Alasan = alasan
+			report.lewati(item)
+			continue
+		}
+		if existingId, ok := sudahDisalin[pokin.Id]; ok {
+			pokinIds[pokin.Id] = existingId
+			item.IdTujuan = strconv.Itoa(existingId)
+			item.Alasan = "pohon kinerja sudah pernah disalin ke tahun tujuan"
+			report.konflik(item)
+			continue
+		}
+
+		parent, lepas := rollForwardParent(pokin.Parent, pokinIds)
+		newId, err := service.RollForwardRepository.InsertPokin(ctx, tx, pokin.Id, parent, payload.TahunSumber, payload.TahunTujuan)
+		if err != nil {
+			return nil, err
+		}
+		pokinIds[pokin.Id] = newId
+		created[pokin.Id] = newId
+		report.disalin(domain.RollForwardPohonKinerja, 1)
+
+		if lepas {
+			item.Entitas = domain.RollForwardRelasiPohon
+			item.IdTujuan = strconv.Itoa(newId)
+			item.Alasan = "induk pohon kinerja tidak ikut disalin, pohon disalin tanpa induk"
+			report.lewati(item)
+		}
+	}
+
+	// data turunan hanya disalin untuk pohon yang baru dibuat agar tidak dobel
+	indikator, target, err := service.RollForwardRepository.CloneIndikatorPokin(ctx, tx, created, payload.TahunTujuan)
+	if err != nil {
+		return nil, err
+	}
+	report.disalin(domain.RollForwardIndikatorPokin, indikator)
+	report.disalin(domain.RollForwardTargetPokin, target)
+
+	pelaksana, err := service.RollForwardRepository.ClonePelaksanaPokin(ctx, tx, created)
+	if err != nil {
+		return nil, err
+	}
+	report.disalin(domain.RollForwardPelaksanaPokin, pelaksana)
+
+	tagging, keterangan, keteranganDilewati, err := service.RollForwardRepository.CloneTagging(ctx, tx, created, payload.TahunTujuan)
+	if err != nil {
+		return nil, err
+	}
+	report.disalin(domain.RollForwardTagging, tagging)
+	report.disalin(domain.RollForwardKeteranganTagging, keterangan)
+	report.dilewati(domain.RollForwardKeteranganTagging, keteranganDilewati)
+
+	csf, err := service.RollForwardRepository.CloneCsf(ctx, tx, created, payload.TahunTujuan)
+	if err != nil {
+		return nil, err
+	}
+	report.disalin(domain.RollForwardCsf, csf)
+
+	return pokinIds, nil
+}
+
+// rollForwardRekin menyalin rencana kinerja beserta indikator, target, rencana aksi dan sub kegiatan terpilih.
+// id_pohon di-remap memakai pokinIds hasil rollForwardPokin.
+func (service *RollForwardServiceImpl) rollForwardRekin(ctx context.Context, tx *sql.Tx, payload rollForwardOpdPayload, pokinIds map[int]int, report *rollForwardReport) error {
+	sourceRekins, err := service.RollForwardRepository.FindRekin(ctx, tx, payload.KodeOpd, payload.TahunSumber)
+	if err != nil {
+		return err
+	}
+	targetRekins, err := service.RollForwardRepository.FindRekin(ctx, tx, payload.KodeOpd, payload.TahunTujuan)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]string, len(targetRekins))
+	for _, rekin := range targetRekins {
+		existing[rollForwardRekinKey(rekin)] = rekin.Id
+	}
+
+	rekinIds := make(map[string]string)
+	for _, rekin := range sourceRekins {
+		item := domain.RollForwardItem{
+			Entitas:  domain.RollForwardRencanaKinerja,
+			IdSumber: rekin.Id,
+			Nama:     rekin.Nama,
+		}
+		if existingId, ok := existing[rollForwardRekinKey(rekin)]; ok {
+			item.IdTujuan = existingId
+			item.Alasan = "rencana kinerja dengan nama yang sama sudah ada untuk pegawai ini di tahun tujuan"
+			report.konflik(item)
+			continue
+		}
+
+		// id_pohon 0 jika rekin belum punya pohon atau pohonnya tidak ikut disalin
+		idPohon := pokinIds[rekin.IdPohon]
+		newId := genereateRekinId()
+		if err := service.RollForwardRepository.InsertRekin(ctx, tx, rekin.Id, newId, idPohon, payload.TahunTujuan); err != nil {
+			return err
+		}
+		rekinIds[rekin.Id] = newId
+		existing[rollForwardRekinKey(rekin)] = newId
+		report.disalin(domain.RollForwardRencanaKinerja, 1)
+
+		if rekin.IdPohon != 0 && idPohon == 0 {
+			item.Entitas = domain.RollForwardRelasiPohon
+			item.IdTujuan = newId
+			item.Alasan = "pohon kinerja rencana kinerja tidak ikut disalin, rencana kinerja disalin tanpa pohon kinerja"
+			report.lewati(item)
+		}
+	}
+
+	indikator, target, err := service.RollForwardRepository.CloneIndikatorRekin(ctx, tx, rekinIds, payload.TahunTujuan)
+	if err != nil {
+		return err
+	}
+	report.disalin(domain.RollForwardIndikatorRekin, indikator)
+	report.disalin(domain.RollForwardTargetRekin, target)
+
+	renaksi, err := service.RollForwardRepository.CloneRencanaAksi(ctx, tx, rekinIds)
+	if err != nil {
+		return err
+	}
+	report.disalin(domain.RollForwardRencanaAksi, renaksi)
+
+	subKegiatan, dilewati, err := service.RollForwardRepository.CloneSubKegiatanTerpilih(ctx, tx, rekinIds)
+	if err != nil {
+		return err
+	}
+	report.disalin(domain.RollForwardSubKegiatanTerpilih, subKegiatan)
+	for _, item := range dilewati {
+		report.lewati(item)
+	}
+	return nil
+}
+
+// rollForwardPokinSkipReason alasan pohon kinerja tidak ikut disalin, kosong jika ikut disalin
+func rollForwardPokinSkipReason(pokin domain.RollForwardPokin) string {
+	if rollForwardJenisPemda[pokin.JenisPohon] {
+		return "pohon kinerja pemda disalin lewat clone pohon kinerja pemda"
+	}
+	if domain.StatusPokinDalamAlur(pokin.Status) {
+		return fmt.Sprintf("status pohon kinerja %s tidak ikut disalin", pokin.Status)
+	}
+	return ""
+}
+
+// rollForwardParent parent baru pohon kinerja, lepas bernilai true jika induknya tidak ikut disalin
+func rollForwardParent(parent int, pokinIds map[int]int) (newParent int, lepas bool) {
+	if parent == 0 {
+		return 0, false
+	}
+	if mapped, ok := pokinIds[parent]; ok {
+		return mapped, false
+	}
+	return rollForwardParentLepas, true
+}
+
+// rollForwardRekinKey rencana kinerja dianggap sama jika pegawai dan namanya sama
+func rollForwardRekinKey(rekin domain.RollForwardRekin) string {
+	return rekin.PegawaiId + "|" + strings.ToLower(strings.TrimSpace(rekin.Nama))
+}
+
+// rollForwardReport mengumpulkan jumlah disalin/dilewati/konflik per entitas sesuai urutan proses
+type rollForwardReport struct {
+	urutan        []string
+	ringkasan     map[string]*rollforward.RollForwardRingkasanResponse
+	dilewatiItems []domain.RollForwardItem
+	konflikItems  []domain.RollForwardItem
+}
+
+func newRollForwardReport() *rollForwardReport {
+	return &rollForwardReport{ringkasan: make(map[string]*rollforward.RollForwardRingkasanResponse)}
+}
+
+func (report *rollForwardReport) entitas(nama string) *rollforward.RollForwardRingkasanResponse {
+	ringkasan, ok := report.ringkasan[nama]
+	if !ok {
+		ringkasan = &rollforward.RollForwardRingkasanResponse{Entitas: nama}
+		report.ringkasan[nama] = ringkasan
+		report.urutan = append(report.urutan, nama)
+	}
+	return ringkasan
+}
+
+func (report *rollForwardReport) disalin(entitas string, jumlah int) {
+	report.entitas(entitas).Disalin += jumlah
+}
+
+func (report *rollForwardReport) dilewati(entitas string, jumlah int) {
+	report.entitas(entitas).Dilewati += jumlah
+}
+
+func (report *rollForwardReport) lewati(item domain.RollForwardItem) {
+	report.dilewati(item.Entitas, 1)
+	report.dilewatiItems = append(report.dilewatiItems, item)
+}
+
+func (report *rollForwardReport) konflik(item domain.RollForwardItem) {
+	report.entitas(item.Entitas).Konflik++
+	report.konflikItems = append(report.konflikItems, item)
+}
+
+// warnings jumlah dilewati dan konflik per entitas untuk status DONE_WITH_WARNING
+func (report *rollForwardReport) warnings() map[domain.WarningType]int {
+	warnings := make(map[domain.WarningType]int)
+	for _, ringkasan := range report.ringkasan {
+		if ringkasan.Dilewati > 0 {
+			warnings[domain.WarningType(ringkasan.Entitas+"_dilewati")] = ringkasan.Dilewati
+		}
+		if ringkasan.Konflik > 0 {
+			warnings[domain.WarningType(ringkasan.Entitas+"_konflik")] = ringkasan.Konflik
+		}
+	}
+	return warnings
+}
+
+func (report *rollForwardReport) response(payload rollForwardOpdPayload) rollforward.RollForwardReportResponse {
+	response := rollforward.RollForwardReportResponse{
+		KodeOpd:     payload.KodeOpd,
+		TahunSumber: payload.TahunSumber,
+		TahunTujuan: payload.TahunTujuan,
+		Ringkasan:   make([]rollforward.RollForwardRingkasanResponse, 0, len(report.urutan)),
+		Dilewati:    toRollForwardItemResponses(report.dilewatiItems),
+		Konflik:     toRollForwardItemResponses(report.konflikItems),
+	}
+	for _, nama := range report.urutan {
+		response.Ringkasan = append(response.Ringkasan, *report.ringkasan[nama])
+	}
+	return response
+}
+
+func toRollForwardItemResponses(items []domain.RollForwardItem) []rollforward.RollForwardItemResponse {
+	responses := make([]rollforward.RollForwardItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, rollforward.RollForwardItemResponse{
+			Entitas:  item.Entitas,
+			IdSumber: item.IdSumber,
+			IdTujuan: item.IdTujuan,
+			Nama:     item.Nama,
+			Alasan:   item.Alasan,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/rollforward"
+	"reflect"
+	"testing"
+)
+
+func TestRollForwardPokinSkipReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		pokin  domain.RollForwardPokin
+		lewati bool
+	}{
+		{name: "pohon opd", pokin: domain.RollForwardPokin{JenisPohon: "Tactical", Status: ""}},
+		{name: "pohon pemda", pokin: domain.RollForwardPokin{JenisPohon: "Strategic Pemda"}, lewati: true},
+		{name: "menunggu persetujuan", pokin: domain.RollForwardPokin{JenisPohon: "Operational", Status: "menunggu_disetujui"}, lewati: true},
+		{name: "crosscutting ditolak", pokin: domain.RollForwardPokin{JenisPohon: "Operational", Status: "crosscutting_ditolak"}, lewati: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rollForwardPokinSkipReason(tt.pokin); (got != "") != tt.lewati {
+				t.Errorf("rollForwardPokinSkipReason() = %q, want lewati %v", got, tt.lewati)
+			}
+		})
+	}
+}
+
+func TestRollForwardParent(t *testing.T) {
+	pokinIds := map[int]int{10: 110, 11: 111}
+	tests := []struct {
+		name       string
+		parent     int
+		wantParent int
+		wantLepas  bool
+	}{
+		{name: "root", parent: 0, wantParent: 0},
+		{name: "induk ikut disalin", parent: 11, wantParent: 111},
+		{name: "induk tidak disalin", parent: 99, wantParent: rollForwardParentLepas, wantLepas: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, lepas := rollForwardParent(tt.parent, pokinIds)
+			if parent != tt.wantParent || lepas != tt.wantLepas {
+				t.Errorf("rollForwardParent(%d) = (%d, %v), want (%d, %v)", tt.parent, parent, lepas, tt.wantParent, tt.wantLepas)
+			}
+		})
+	}
+}
+
+func TestRollForwardReport(t *testing.T) {
+	report := newRollForwardReport()
+	report.disalin(domain.RollForwardPohonKinerja, 3)
+	report.lewati(domain.RollForwardItem{Entitas: domain.RollForwardPohonKinerja, IdSumber: "7", Alasan: "pemda"})
+	report.konflik(domain.RollForwardItem{Entitas: domain.RollForwardRencanaKinerja, IdSumber: "REKIN-1", IdTujuan: "REKIN-2"})
+	report.disalin(domain.RollForwardCsf, 0)
+
+	response := report.response(rollForwardOpdPayload{KodeOpd: "1.01", TahunSumber: "2025", TahunTujuan: "2026"})
+	wantRingkasan := []rollforward.RollForwardRingkasanResponse{
+		{Entitas: domain.RollForwardPohonKinerja, Disalin: 3, Dilewati: 1},
+		{Entitas: domain.RollForwardRencanaKinerja, Konflik: 1},
+		{Entitas: domain.RollForwardCsf},
+	}
+	if !reflect.DeepEqual(response.Ringkasan, wantRingkasan) {
+		t.Errorf("Ringkasan = %+v, want %+v", response.Ringkasan, wantRingkasan)
+	}
+	if len(response.Dilewati) != 1 || len(response.Konflik) != 1 || response.Konflik[0].IdTujuan != "REKIN-2" {
+		t.Errorf("Dilewati = %+v, Konflik = %+v", response.Dilewati, response.Konflik)
+	}
+
+	wantWarnings := map[domain.WarningType]int{
+		"pohon_kinerja_dilewati":  1,
+		"rencana_kinerja_konflik": 1,
+	}
+	if got := report.warnings(); !reflect.DeepEqual(got, wantWarnings) {
+		t.Errorf("warnings() = %v, want %v", got, wantWarnings)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/model/web/sasaranopd"
 	"ekak_kabupaten_madiun/repository"
@@ -28,6 +29,8 @@ type SasaranOpdServiceImpl struct {
 	DB                        *sql.DB
 	validate                  *validator.Validate
 	tujuanOpdRepository       repository.TujuanOpdRepository
+	lockDataRepository        repository.LockDataRepository
+	Cache                     *helper.Cache
 }
 
 func NewSasaranOpdServiceImpl(
@@ -38,8 +41,10 @@ func NewSasaranOpdServiceImpl(
 	pegawaiRepository repository.PegawaiRepository,
 	pohonkinerjaRepository repository.PohonKinerjaRepository,
 	tujuanOpdRepository repository.TujuanOpdRepository,
+	lockDataRepository repository.LockDataRepository,
 	db *sql.DB,
 	validate *validator.Validate,
+	cache *helper.Cache,
 ) *SasaranOpdServiceImpl {
 	return &SasaranOpdServiceImpl{
 		sasaranOpdRepository:      sasaranOpdRepository,
@@ -49,8 +54,10 @@ func NewSasaranOpdServiceImpl(
 		pegawaiRepository:         pegawaiRepository,
 		pohonkinerjaRepository:    pohonkinerjaRepository,
 		tujuanOpdRepository:       tujuanOpdRepository,
+		lockDataRepository:        lockDataRepository,
 		DB:                        db,
 		validate:                  validate,
+		Cache:                     cache,
 	}
 }
 
@@ -242,19 +249,25 @@ func (service *SasaranOpdServiceImpl) Create(ctx context.Context, request sasara
 	if err != nil {
 		return nil, err
 	}
+	var kodeOpd string
+	defer func() { invalidateRenstraCache(ctx, service.Cache, kodeOpd) }()
 	defer helper.CommitOrRollback(tx)
 
-	if err := service.validate.Struct(request); err != nil {
+	if err := helper.ValidationError(service.validate.Struct(request)); err != nil {
 		return nil, err
 	}
 
-	_, err = service.pohonkinerjaRepository.FindById(ctx, tx, request.IdPohon)
+	pokin, err := service.pohonkinerjaRepository.FindById(ctx, tx, request.IdPohon)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("data pohon kinerja dengan ID %d tidak ditemukan", request.IdPohon)
+			return nil, web.NewNotFoundError(fmt.Sprintf("data pohon kinerja dengan ID %d tidak ditemukan", request.IdPohon))
 		}
 		return nil, fmt.Errorf("gagal mengambil data pohon kinerja: %v", err)
 	}
+	kodeOpd = pokin.KodeOpd
+	if err := checkLockDataPeriode(ctx, tx, service.lockDataRepository, domain.JenisLockSasaranOpd, pokin.KodeOpd, request.TahunAwal, request.TahunAkhir); err != nil {
+		return nil, err
+	}
 	// Generate ID yang lebih unik untuk sasaran OPD
 	idSasaran := rand.Intn(100000)
 
@@ -309,7 +322,7 @@ func (service *SasaranOpdServiceImpl) Create(ctx context.Context, request sasara
 
 	TujuanOpd, err := service.tujuanOpdRepository.FindById(ctx, tx, sasaranOpd.IdTujuanOpd)
 	if err != nil {
-		return nil, errors.New("tujuan opd tidak ditemukan")
+		return nil, web.NewNotFoundError("tujuan opd tidak ditemukan")
 	}
 
 	// Buat response dengan indikator dan target
@@ -352,35 +365,44 @@ func (service *SasaranOpdServiceImpl) Create(ctx context.Context, request sasara
 }
 
 func (service *SasaranOpdServiceImpl) Update(ctx context.Context, request sasaranopd.SasaranOpdUpdateRequest) (*sasaranopd.SasaranOpdCreateResponse, error) {
-	if err := service.validate.Struct(request); err != nil {
+	if err := helper.ValidationError(service.validate.Struct(request)); err != nil {
 		return nil, err
 	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
+	var kodeOpdSasaran string
+	defer func() { invalidateRenstraCache(ctx, service.Cache, kodeOpdSasaran) }()
 	defer helper.CommitOrRollback(tx)
 	// Validasi tahun
 	tahunAwalInt, err := strconv.Atoi(request.TahunAwal)
 	if err != nil {
-		return nil, errors.New("format tahun awal tidak valid")
+		return nil, web.NewBadRequestError("format tahun awal tidak valid")
 	}
 	tahunAkhirInt, err := strconv.Atoi(request.TahunAkhir)
 	if err != nil {
-		return nil, errors.New("format tahun akhir tidak valid")
+		return nil, web.NewBadRequestError("format tahun akhir tidak valid")
 	}
 	if tahunAkhirInt < tahunAwalInt {
-		return nil, errors.New("tahun akhir tidak boleh lebih kecil dari tahun awal")
+		return nil, web.NewBadRequestError("tahun akhir tidak boleh lebih kecil dari tahun awal")
 	}
 	// Validasi sasaran OPD ada
 	existingSasaran, err := service.sasaranOpdRepository.FindByIdSasaran(ctx, tx, request.IdSasaranOpd)
 	if err != nil {
-		return nil, errors.New("sasaran opd tidak ditemukan")
+		return nil, web.NewNotFoundError("sasaran opd tidak ditemukan")
+	}
+	kodeOpdSasaran, err = service.kodeOpdSasaran(ctx, tx, request.IdSasaranOpd)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLockDataPeriode(ctx, tx, service.lockDataRepository, domain.JenisLockSasaranOpd, kodeOpdSasaran, request.TahunAwal, request.TahunAkhir); err != nil {
+		return nil, err
 	}
 	// Validasi tujuan OPD ada
 	tujuanOpd, err := service.tujuanOpdRepository.FindById(ctx, tx, request.IdTujuanOpd)
 	if err != nil {
-		return nil, errors.New("tujuan opd tidak ditemukan")
+		return nil, web.NewNotFoundError("tujuan opd tidak ditemukan")
 	}
 	// Bangun daftar indikator domain + response
 	var indikatorList []domain.Indikator
@@ -398,7 +420,7 @@ func (service *SasaranOpdServiceImpl) Update(ctx context.Context, request sasara
 		}
 		// Validasi: setiap indikator wajib punya minimal 1 target
 		if len(indikatorReq.Target) == 0 {
-			return nil, fmt.Errorf("indikator '%s' harus memiliki minimal 1 target", indikatorReq.Indikator)
+			return nil, web.NewBadRequestError(fmt.Sprintf("indikator '%s' harus memiliki minimal 1 target", indikatorReq.Indikator))
 		}
 		var targetList []domain.Target
 		var targetResponses []sasaranopd.TargetDetail
@@ -486,8 +508,23 @@ func (service *SasaranOpdServiceImpl) Delete(ctx context.Context, id string) err
 	if err != nil {
 		return err
 	}
+	var kodeOpdSasaran string
+	defer func() { invalidateRenstraCache(ctx, service.Cache, kodeOpdSasaran) }()
 	defer helper.CommitOrRollback(tx)
 
+	if idSasaran, convErr := strconv.Atoi(id); convErr == nil {
+		existingSasaran, err := service.sasaranOpdRepository.FindByIdSasaran(ctx, tx, idSasaran)
+		if err == nil {
+			kodeOpdSasaran, err = service.kodeOpdSasaran(ctx, tx, idSasaran)
+			if err != nil {
+				return err
+			}
+			if err := checkLockDataPeriode(ctx, tx, service.lockDataRepository, domain.JenisLockSasaranOpd, kodeOpdSasaran, existingSasaran.TahunAwal, existingSasaran.TahunAkhir); err != nil {
+				return err
+			}
+		}
+	}
+
 	err = service.sasaranOpdRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return err
@@ -682,7 +719,7 @@ func (service *SasaranOpdServiceImpl) FindByTahun(ctx context.Context, kodeOpd s
 	// Validasi tahun
 	tahunInt, err := strconv.Atoi(tahun)
 	if err != nil {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 
 	// Ambil data
@@ -792,16 +829,19 @@ func (service *SasaranOpdServiceImpl) FindByTahun(ctx context.Context, kodeOpd s
 func (s *SasaranOpdServiceImpl) FindSasaranRenstra(
 	ctx context.Context, kodeOpd, tahunAwal, tahunAkhir, jenisPeriode string,
 ) ([]sasaranopd.SasaranOpdResponse, error) {
-	tx, err := s.DB.Begin()
-	if err != nil {
-		return nil, err
-	}
-	defer helper.CommitOrRollback(tx)
-	sasaranOpds, err := s.sasaranOpdRepository.FindSasaranByPeriod(ctx, tx, kodeOpd, tahunAwal, tahunAkhir, jenisPeriode, "renstra")
-	if err != nil {
-		return nil, err
-	}
-	return s.buildSasaranResponse(ctx, tx, kodeOpd, sasaranOpds)
+	key := helper.NewCacheKey(helper.CacheEntitySasaranOpd, "renstra", kodeOpd, tahunAwal, tahunAkhir, jenisPeriode).WithTags(helper.CacheTagOpd(kodeOpd))
+	return helper.Remember(ctx, s.Cache, key, helper.ReadCacheTTL, func() ([]sasaranopd.SasaranOpdResponse, error) {
+		tx, err := s.DB.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer helper.CommitOrRollback(tx)
+		sasaranOpds, err := s.sasaranOpdRepository.FindSasaranByPeriod(ctx, tx, kodeOpd, tahunAwal, tahunAkhir, jenisPeriode, "renstra")
+		if err != nil {
+			return nil, err
+		}
+		return s.buildSasaranResponse(ctx, tx, kodeOpd, sasaranOpds)
+	})
 }
 func (s *SasaranOpdServiceImpl) FindSasaranRanwal(
 	ctx context.Context, kodeOpd, tahun, jenisPeriode string,
@@ -923,32 +963,40 @@ func (service *SasaranOpdServiceImpl) CreateRenjaIndikator(
 	jenis string,
 	requests []sasaranopd.IndikatorCreateRequest,
 ) ([]sasaranopd.IndikatorResponse, error) {
+	for _, request := range requests {
+		if err := helper.ValidateRequest(request); err != nil {
+			return nil, err
+		}
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.sasaranOpdRepository.FindById(ctx, tx, sasaranOpdId) // ← lowercase
+	sasaran, err := service.sasaranOpdRepository.FindById(ctx, tx, sasaranOpdId) // ← lowercase
 	if err != nil {
-		return nil, fmt.Errorf("sasaran opd id %d tidak ditemukan", sasaranOpdId)
+		return nil, web.NewNotFoundError(fmt.Sprintf("sasaran opd id %d tidak ditemukan", sasaranOpdId))
 	}
 	var indikatorDomains []domain.Indikator
 	var responses []sasaranopd.IndikatorResponse
 	for _, req := range requests {
 		if req.Indikator == "" {
-			return nil, fmt.Errorf("nama indikator tidak boleh kosong")
+			return nil, web.NewBadRequestError("nama indikator tidak boleh kosong")
 		}
 		if len(req.Target) != 1 {
-			return nil, fmt.Errorf("setiap indikator harus memiliki tepat 1 target")
+			return nil, web.NewBadRequestError("setiap indikator harus memiliki tepat 1 target")
 		}
 		if req.Target[0].Target == "" {
-			return nil, fmt.Errorf("nilai target tidak boleh kosong")
+			return nil, web.NewBadRequestError("nilai target tidak boleh kosong")
 		}
 		if req.Target[0].Satuan == "" {
-			return nil, fmt.Errorf("satuan tidak boleh kosong")
+			return nil, web.NewBadRequestError("satuan tidak boleh kosong")
 		}
 		if req.Target[0].Tahun == "" {
-			return nil, fmt.Errorf("tahun target tidak boleh kosong")
+			return nil, web.NewBadRequestError("tahun target tidak boleh kosong")
+		}
+		if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockSasaranOpd, sasaran.KodeOpd, req.Target[0].Tahun); err != nil {
+			return nil, err
 		}
 		kodeIndikator := fmt.Sprintf("IND-SAR-%s", uuid.New().String()[:5])
 		targetId := fmt.Sprintf("TRG-SAR-%s", uuid.New().String()[:5])
@@ -988,29 +1036,41 @@ func (service *SasaranOpdServiceImpl) CreateRenjaIndikator(
 }
 
 func (service *SasaranOpdServiceImpl) UpdateRenjaIndikator(ctx context.Context, kodeIndikator string, jenis string, request sasaranopd.IndikatorUpdateRequest) (sasaranopd.IndikatorResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return sasaranopd.IndikatorResponse{}, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return sasaranopd.IndikatorResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
+	existingIndikator, err := service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return sasaranopd.IndikatorResponse{}, fmt.Errorf("indikator dengan kode %s tidak ditemukan", kodeIndikator)
+			return sasaranopd.IndikatorResponse{}, web.NewNotFoundError(fmt.Sprintf("indikator dengan kode %s tidak ditemukan", kodeIndikator))
 		}
 		return sasaranopd.IndikatorResponse{}, err
 	}
 	if request.Indikator == "" {
-		return sasaranopd.IndikatorResponse{}, fmt.Errorf("nama indikator tidak boleh kosong")
+		return sasaranopd.IndikatorResponse{}, web.NewBadRequestError("nama indikator tidak boleh kosong")
 	}
 	if len(request.Target) != 1 {
-		return sasaranopd.IndikatorResponse{}, fmt.Errorf("harus memiliki tepat 1 target")
+		return sasaranopd.IndikatorResponse{}, web.NewBadRequestError("harus memiliki tepat 1 target")
 	}
 	if request.Target[0].Target == "" {
-		return sasaranopd.IndikatorResponse{}, fmt.Errorf("nilai target tidak boleh kosong")
+		return sasaranopd.IndikatorResponse{}, web.NewBadRequestError("nilai target tidak boleh kosong")
 	}
 	if request.Target[0].Tahun == "" {
-		return sasaranopd.IndikatorResponse{}, fmt.Errorf("tahun target tidak boleh kosong")
+		return sasaranopd.IndikatorResponse{}, web.NewBadRequestError("tahun target tidak boleh kosong")
+	}
+	kodeOpdSasaran, err := service.kodeOpdSasaran(ctx, tx, existingIndikator.SasaranOpdId)
+	if err != nil {
+		return sasaranopd.IndikatorResponse{}, err
+	}
+	for _, tahun := range []string{existingIndikator.Tahun, request.Target[0].Tahun} {
+		if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockSasaranOpd, kodeOpdSasaran, tahun); err != nil {
+			return sasaranopd.IndikatorResponse{}, err
+		}
 	}
 	targetId := request.Target[0].Id
 	if targetId == "" {
@@ -1054,12 +1114,37 @@ func (service *SasaranOpdServiceImpl) DeleteRenjaIndikator(ctx context.Context,
 		return err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator) // ← lowercase
+	existingIndikator, err := service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator) // ← lowercase
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("kode indikator %s tidak ditemukan", kodeIndikator)
+			return web.NewNotFoundError(fmt.Sprintf("kode indikator %s tidak ditemukan", kodeIndikator))
 		}
 		return err
 	}
+	kodeOpdSasaran, err := service.kodeOpdSasaran(ctx, tx, existingIndikator.SasaranOpdId)
+	if err != nil {
+		return err
+	}
+	if err := checkLockData(ctx, tx, service.lockDataRepository, domain.JenisLockSasaranOpd, kodeOpdSasaran, existingIndikator.Tahun); err != nil {
+		return err
+	}
 	return service.sasaranOpdRepository.DeleteIndikatorTargetRenja(ctx, tx, kodeIndikator) // ← lowercase
 }
+
+// kodeOpdSasaran mengambil kode_opd sasaran opd dari pohon kinerja induknya (kosong jika sasaran tidak ditemukan)
+func (service *SasaranOpdServiceImpl) kodeOpdSasaran(ctx context.Context, tx *sql.Tx, sasaranOpdId int) (string, error) {
+	if sasaranOpdId == 0 {
+		return "", nil
+	}
+	sasaran, err := service.sasaranOpdRepository.FindById(ctx, tx, sasaranOpdId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == "sasaran opd not found" {
+			return "", nil
+		}
+		return "", err
+	}
+	if sasaran == nil {
+		return "", nil
+	}
+	return sasaran.KodeOpd, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/sasaranpemda"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -58,6 +59,10 @@ func generateTargetIdSasaran() string {
 }
 
 func (service *SasaranPemdaServiceImpl) Create(ctx context.Context, request sasaranpemda.SasaranPemdaCreateRequest) (sasaranpemda.SasaranPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return sasaranpemda.SasaranPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return sasaranpemda.SasaranPemdaResponse{}, err
@@ -72,13 +77,13 @@ func (service *SasaranPemdaServiceImpl) Create(ctx context.Context, request sasa
 
 	// Validasi level pohon kinerja (1-3)
 	if pokinData.LevelPohon < 1 || pokinData.LevelPohon > 3 {
-		return sasaranpemda.SasaranPemdaResponse{}, fmt.Errorf("level pohon kinerja harus berada di antara 1-3, level saat ini: %d", pokinData.LevelPohon)
+		return sasaranpemda.SasaranPemdaResponse{}, web.NewBadRequestError(fmt.Sprintf("level pohon kinerja harus berada di antara 1-3, level saat ini: %d", pokinData.LevelPohon))
 	}
 
 	// Validasi tujuan pemda exists
 	exists := service.TujuanPemdaRepository.IsIdExists(ctx, tx, request.TujuanPemdaId)
 	if !exists {
-		return sasaranpemda.SasaranPemdaResponse{}, fmt.Errorf("tujuan pemda dengan id %d tidak ditemukan", request.TujuanPemdaId)
+		return sasaranpemda.SasaranPemdaResponse{}, web.NewNotFoundError(fmt.Sprintf("tujuan pemda dengan id %d tidak ditemukan", request.TujuanPemdaId))
 	}
 
 	// Validasi periode
@@ -209,6 +214,10 @@ func (service *SasaranPemdaServiceImpl) Create(ctx context.Context, request sasa
 	}, nil
 }
 func (service *SasaranPemdaServiceImpl) Update(ctx context.Context, request sasaranpemda.SasaranPemdaUpdateRequest) (sasaranpemda.SasaranPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return sasaranpemda.SasaranPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return sasaranpemda.SasaranPemdaResponse{}, err
@@ -225,7 +234,7 @@ func (service *SasaranPemdaServiceImpl) Update(ctx context.Context, request sasa
 	}
 
 	if !service.TujuanPemdaRepository.IsIdExists(ctx, tx, request.TujuanPemdaId) {
-		return sasaranpemda.SasaranPemdaResponse{}, fmt.Errorf("tujuan pemda dengan id %d tidak ditemukan", request.TujuanPemdaId)
+		return sasaranpemda.SasaranPemdaResponse{}, web.NewNotFoundError(fmt.Sprintf("tujuan pemda dengan id %d tidak ditemukan", request.TujuanPemdaId))
 	}
 	// Validasi sasaran pemda pemda exists
 	sasaranPemda, err := service.SasaranPemdaRepository.FindById(ctx, tx, sasaranPemdaId)
@@ -240,7 +249,7 @@ func (service *SasaranPemdaServiceImpl) Update(ctx context.Context, request sasa
 
 	// Validasi level pohon kinerja (1-3)
 	if pokinData.LevelPohon < 1 || pokinData.LevelPohon > 3 {
-		return sasaranpemda.SasaranPemdaResponse{}, fmt.Errorf("level pohon kinerja harus berada di antara 1-3, level saat ini: %d", pokinData.LevelPohon)
+		return sasaranpemda.SasaranPemdaResponse{}, web.NewBadRequestError(fmt.Sprintf("level pohon kinerja harus berada di antara 1-3, level saat ini: %d", pokinData.LevelPohon))
 	}
 
 	periode, err := service.PeriodeRepository.FindById(ctx, tx, request.PeriodeId)
@@ -480,8 +489,8 @@ func (service *SasaranPemdaServiceImpl) FindAllWithPokin(ctx context.Context, ta
 		return nil, fmt.Errorf("error validating periode: %v", err)
 	}
 	if count == 0 {
-		return nil, fmt.Errorf("periode dengan tahun_awal %s, tahun_akhir %s, dan jenis_periode %s tidak ditemukan",
-			tahunAwal, tahunAkhir, jenisPeriode)
+		return nil, web.NewNotFoundError(fmt.Sprintf("periode dengan tahun_awal %s, tahun_akhir %s, dan jenis_periode %s tidak ditemukan",
+			tahunAwal, tahunAkhir, jenisPeriode))
 	}
 
 	// Ambil data dari repository
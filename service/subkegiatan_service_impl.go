@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/subkegiatan"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -35,7 +35,7 @@ func NewSubKegiatanServiceImpl(subKegiatanRepository repository.SubKegiatanRepos
 }
 
 func (service *SubKegiatanServiceImpl) Create(ctx context.Context, request subkegiatan.SubKegiatanCreateRequest) (subkegiatan.SubKegiatanResponse, error) {
-	err := service.validator.Struct(request)
+	err := helper.ValidationError(service.validator.Struct(request))
 	if err != nil {
 		log.Println("Validasi gagal:", err)
 		return subkegiatan.SubKegiatanResponse{}, err
@@ -103,7 +103,7 @@ func (service *SubKegiatanServiceImpl) Create(ctx context.Context, request subke
 }
 
 func (service *SubKegiatanServiceImpl) Update(ctx context.Context, request subkegiatan.SubKegiatanUpdateRequest) (subkegiatan.SubKegiatanResponse, error) {
-	err := service.validator.Struct(request)
+	err := helper.ValidationError(service.validator.Struct(request))
 	if err != nil {
 		log.Println("Validasi gagal:", err)
 		return subkegiatan.SubKegiatanResponse{}, err
@@ -180,7 +180,7 @@ func (service *SubKegiatanServiceImpl) FindById(ctx context.Context, subKegiatan
 	subKegiatan, err := service.subKegiatanRepository.FindById(ctx, tx, subKegiatanId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return subkegiatan.SubKegiatanResponse{}, fmt.Errorf("sub kegiatan dengan id %s tidak ditemukan", subKegiatanId)
+			return subkegiatan.SubKegiatanResponse{}, web.NewNotFoundError(fmt.Sprintf("sub kegiatan dengan id %s tidak ditemukan", subKegiatanId))
 		}
 		log.Println("Gagal mencari data sub kegiatan:", err)
 		return subkegiatan.SubKegiatanResponse{}, err
@@ -219,19 +219,19 @@ func (service *SubKegiatanServiceImpl) FindById(ctx context.Context, subKegiatan
 	return helper.ToSubKegiatanResponse(subKegiatan), nil
 }
 
-func (service *SubKegiatanServiceImpl) FindAll(ctx context.Context) ([]subkegiatan.SubKegiatanResponse, error) {
+func (service *SubKegiatanServiceImpl) FindAll(ctx context.Context, query domain.ListQuery) ([]subkegiatan.SubKegiatanResponse, int, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
 		log.Println("Gagal memulai transaksi:", err)
-		return []subkegiatan.SubKegiatanResponse{}, err
+		return []subkegiatan.SubKegiatanResponse{}, 0, err
 	}
 	defer helper.CommitOrRollback(tx)
 
 	// Ambil data SubKegiatan
-	subKegiatans, err := service.subKegiatanRepository.FindAll(ctx, tx)
+	subKegiatans, total, err := service.subKegiatanRepository.FindAllPaged(ctx, tx, query)
 	if err != nil {
 		log.Println("Gagal mencari data sub kegiatan:", err)
-		return []subkegiatan.SubKegiatanResponse{}, err
+		return []subkegiatan.SubKegiatanResponse{}, 0, err
 	}
 
 	// Untuk setiap SubKegiatan, ambil data Indikator dan Target
@@ -245,7 +245,7 @@ func (service *SubKegiatanServiceImpl) FindAll(ctx context.Context) ([]subkegiat
 				continue
 			}
 			log.Printf("Gagal mengambil indikator untuk subkegiatan %s: %v", subKegiatan.Id, err)
-			return []subkegiatan.SubKegiatanResponse{}, err
+			return []subkegiatan.SubKegiatanResponse{}, 0, err
 		}
 
 		// Untuk setiap Indikator, ambil Target
@@ -258,7 +258,7 @@ func (service *SubKegiatanServiceImpl) FindAll(ctx context.Context) ([]subkegiat
 					continue
 				}
 				log.Printf("Gagal mengambil target untuk indikator %s: %v", indikator.Id, err)
-				return []subkegiatan.SubKegiatanResponse{}, err
+				return []subkegiatan.SubKegiatanResponse{}, 0, err
 			}
 			indikators[j].Target = targets
 		}
@@ -266,13 +266,13 @@ func (service *SubKegiatanServiceImpl) FindAll(ctx context.Context) ([]subkegiat
 		subKegiatans[i].Indikator = indikators
 	}
 
-	return helper.ToSubKegiatanResponses(subKegiatans), nil
+	return helper.ToSubKegiatanResponses(subKegiatans), total, nil
 }
 
 func (service *SubKegiatanServiceImpl) Delete(ctx context.Context, subKegiatanId string) error {
 	// Validasi ID
 	if subKegiatanId == "" {
-		return errors.New("subkegiatan id tidak boleh kosong")
+		return web.NewBadRequestError("subkegiatan id tidak boleh kosong")
 	}
 
 	// Mulai transaksi
@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/subkegiatan"
 	"ekak_kabupaten_madiun/repository"
-	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -22,9 +22,10 @@ type SubKegiatanTerpilihServiceImpl struct {
 	opdRepository                 repository.OpdRepository
 	DB                            *sql.DB
 	Validate                      *validator.Validate
+	cache                         *helper.Cache
 }
 
-func NewSubKegiatanTerpilihServiceImpl(rencanaKinerjaRepository repository.RencanaKinerjaRepository, subKegiatanRepository repository.SubKegiatanRepository, subKegiatanTerpilihRepository repository.SubKegiatanTerpilihRepository, opdRepository repository.OpdRepository, DB *sql.DB, Validate *validator.Validate) *SubKegiatanTerpilihServiceImpl {
+func NewSubKegiatanTerpilihServiceImpl(rencanaKinerjaRepository repository.RencanaKinerjaRepository, subKegiatanRepository repository.SubKegiatanRepository, subKegiatanTerpilihRepository repository.SubKegiatanTerpilihRepository, opdRepository repository.OpdRepository, DB *sql.DB, Validate *validator.Validate, cache *helper.Cache) *SubKegiatanTerpilihServiceImpl {
 	return &SubKegiatanTerpilihServiceImpl{
 		RencanaKinerjaRepository:      rencanaKinerjaRepository,
 		SubKegiatanRepository:         subKegiatanRepository,
@@ -32,14 +33,21 @@ func NewSubKegiatanTerpilihServiceImpl(rencanaKinerjaRepository repository.Renca
 		opdRepository:                 opdRepository,
 		DB:                            DB,
 		Validate:                      Validate,
+		cache:                         cache,
 	}
 }
 
 func (service *SubKegiatanTerpilihServiceImpl) Update(ctx context.Context, request subkegiatan.SubKegiatanTerpilihUpdateRequest) (subkegiatan.SubKegiatanTerpilihResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return subkegiatan.SubKegiatanTerpilihResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return subkegiatan.SubKegiatanTerpilihResponse{}, err
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	var rencanaKinerja domain.RencanaKinerja
@@ -49,14 +57,15 @@ func (service *SubKegiatanTerpilihServiceImpl) Update(ctx context.Context, reque
 			log.Printf("Gagal menemukan RencanaKinerja: %v", err)
 			return subkegiatan.SubKegiatanTerpilihResponse{}, fmt.Errorf("gagal menemukan RencanaKinerja: %v", err)
 		}
+		kodeOpd, tahun = rencanaKinerja.KodeOpd, rencanaKinerja.Tahun
 	} else {
-		return subkegiatan.SubKegiatanTerpilihResponse{}, errors.New("id rencana kinerja tidak boleh kosong")
+		return subkegiatan.SubKegiatanTerpilihResponse{}, web.NewBadRequestError("id rencana kinerja tidak boleh kosong")
 	}
 
 	// Cek apakah data dengan kode_subkegiatan tersebut ada
 	_, err = service.SubKegiatanRepository.FindByKodeSubKegiatan(ctx, tx, request.KodeSubKegiatan)
 	if err != nil {
-		return subkegiatan.SubKegiatanTerpilihResponse{}, errors.New("subkegiatan tidak ditemukan")
+		return subkegiatan.SubKegiatanTerpilihResponse{}, web.NewNotFoundError("subkegiatan tidak ditemukan")
 	}
 
 	subKegiatanTerpilih := domain.SubKegiatanTerpilih{
@@ -87,7 +96,7 @@ func (service *SubKegiatanTerpilihServiceImpl) FindByKodeSubKegiatan(ctx context
 
 	result, err := service.SubKegiatanRepository.FindByKodeSubKegiatan(ctx, tx, kodeSubKegiatan)
 	if err != nil {
-		return subkegiatan.SubKegiatanTerpilihResponse{}, errors.New("subkegiatan tidak ditemukan")
+		return subkegiatan.SubKegiatanTerpilihResponse{}, web.NewNotFoundError("subkegiatan tidak ditemukan")
 	}
 
 	return subkegiatan.SubKegiatanTerpilihResponse{
@@ -103,17 +112,24 @@ func (service *SubKegiatanTerpilihServiceImpl) Delete(ctx context.Context, id st
 	if err != nil {
 		return err
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	// Validasi: Cek apakah data dengan id dan kodeSubKegiatan ada
 	_, err = service.SubKegiatanTerpilihRepository.FindByIdAndKodeSubKegiatan(ctx, tx, id, kodeSubKegiatan)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("data tidak ditemukan")
+			return web.NewNotFoundError("data tidak ditemukan")
 		}
 		return err
 	}
 
+	kodeOpd, tahun, err = service.RencanaKinerjaRepository.FindKodeOpdTahunById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
 	// Lanjutkan dengan penghapusan jika data ditemukan
 	err = service.SubKegiatanTerpilihRepository.Delete(ctx, tx, id, kodeSubKegiatan)
 	if err != nil {
@@ -124,6 +140,10 @@ func (service *SubKegiatanTerpilihServiceImpl) Delete(ctx context.Context, id st
 }
 
 func (service *SubKegiatanTerpilihServiceImpl) CreateRekin(ctx context.Context, request subkegiatan.SubKegiatanCreateRekinRequest) ([]subkegiatan.SubKegiatanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return nil, err
+	}
+
 	// Konversi single ID ke array
 	kodeSubKegiatanArray := []string{request.KodeSubKegiatan}
 
@@ -131,13 +151,16 @@ func (service *SubKegiatanTerpilihServiceImpl) CreateRekin(ctx context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
 	// Cek apakah rencana kinerja dengan ID yang diberikan ada
-	_, err = service.RencanaKinerjaRepository.FindById(ctx, tx, request.RekinId, "", "")
+	rencanaKinerja, err := service.RencanaKinerjaRepository.FindById(ctx, tx, request.RekinId, "", "")
 	if err != nil {
 		return nil, fmt.Errorf("rencana kinerja dengan id %s tidak ditemukan: %v", request.RekinId, err)
 	}
+	kodeOpd, tahun = rencanaKinerja.KodeOpd, rencanaKinerja.Tahun
 
 	var updatedSubKegiatans []domain.SubKegiatan
 
@@ -151,7 +174,7 @@ func (service *SubKegiatanTerpilihServiceImpl) CreateRekin(ctx context.Context,
 
 		// Cek apakah usulan sudah memiliki rekin_id
 		if existingSubKegiatan.RekinId != "" {
-			return nil, fmt.Errorf("subkegiatan dengan kode %s sudah memiliki rencana kinerja", kodeSubKegiatan)
+			return nil, web.NewConflictError(fmt.Sprintf("subkegiatan dengan kode %s sudah memiliki rencana kinerja", kodeSubKegiatan))
 		}
 
 		// Update rekin_id dan status
@@ -179,8 +202,15 @@ func (service *SubKegiatanTerpilihServiceImpl) DeleteSubKegiatanTerpilih(ctx con
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
+	var kodeOpd, tahun string
+	defer invalidateOpdTahunCache(ctx, service.cache, &kodeOpd, &tahun)
 	defer helper.CommitOrRollback(tx)
 
+	kodeOpd, tahun, err = service.SubKegiatanTerpilihRepository.FindKodeOpdTahunById(ctx, tx, idSubKegiatan)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil rencana kinerja subkegiatan terpilih: %v", err)
+	}
+
 	err = service.SubKegiatanTerpilihRepository.DeleteSubKegiatanTerpilih(ctx, tx, idSubKegiatan)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus subkegiatan terpilih: %v", err)
@@ -200,7 +230,7 @@ func (service *SubKegiatanTerpilihServiceImpl) DeleteSubKegiatanTerpilih(ctx con
 // 	kode, err := service.SubKegiatanRepository.FindByKodeSubKegiatan(ctx, tx, request.KodeSubkegiatan)
 // 	if err != nil {
 // 		if err == sql.ErrNoRows {
-// 			return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("kode subkegiatan %s tidak ditemukan dalam database", request.KodeSubkegiatan)
+// 			return subkegiatan.SubKegiatanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("kode subkegiatan %s tidak ditemukan dalam database", request.KodeSubkegiatan))
 // 		}
 // 		return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data kode subkegiatan: %v", err)
 // 	}
@@ -209,7 +239,7 @@ func (service *SubKegiatanTerpilihServiceImpl) DeleteSubKegiatanTerpilih(ctx con
 // 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 // 	if err != nil {
 // 		if err == sql.ErrNoRows {
-// 			return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd)
+// 			return subkegiatan.SubKegiatanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd))
 // 		}
 // 		return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data OPD: %v", err)
 // 	}
@@ -236,6 +266,10 @@ func (service *SubKegiatanTerpilihServiceImpl) DeleteSubKegiatanTerpilih(ctx con
 // }
 
 func (service *SubKegiatanTerpilihServiceImpl) UpdateOpd(ctx context.Context, request subkegiatan.SubKegiatanOpdUpdateRequest) (subkegiatan.SubKegiatanOpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return subkegiatan.SubKegiatanOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return subkegiatan.SubKegiatanOpdResponse{}, err
@@ -246,7 +280,7 @@ func (service *SubKegiatanTerpilihServiceImpl) UpdateOpd(ctx context.Context, re
 	kode, err := service.SubKegiatanRepository.FindByKodeSubKegiatan(ctx, tx, request.KodeSubkegiatan)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("kode subkegiatan %s tidak ditemukan dalam database", request.KodeSubkegiatan)
+			return subkegiatan.SubKegiatanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("kode subkegiatan %s tidak ditemukan dalam database", request.KodeSubkegiatan))
 		}
 		return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data kode subkegiatan: %v", err)
 	}
@@ -255,7 +289,7 @@ func (service *SubKegiatanTerpilihServiceImpl) UpdateOpd(ctx context.Context, re
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("OPD dengan kode %s tidak ditemukan", request.KodeOpd)
+			return subkegiatan.SubKegiatanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan", request.KodeOpd))
 		}
 		return subkegiatan.SubKegiatanOpdResponse{}, err
 	}
@@ -322,7 +356,7 @@ func (service *SubKegiatanTerpilihServiceImpl) FindById(ctx context.Context, id
 	result, err := service.SubKegiatanTerpilihRepository.FindById(ctx, tx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return subkegiatan.SubKegiatanOpdResponse{}, fmt.Errorf("subkegiatan dengan id %d tidak ditemukan", id)
+			return subkegiatan.SubKegiatanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("subkegiatan dengan id %d tidak ditemukan", id))
 		}
 		return subkegiatan.SubKegiatanOpdResponse{}, err
 	}
@@ -365,14 +399,14 @@ func (service *SubKegiatanTerpilihServiceImpl) FindAllSubkegiatanByBidangUrusanO
 
 	// Validasi format kode OPD
 	if !isValidKodeOpd(kodeOpd) {
-		return nil, fmt.Errorf("format kode OPD tidak valid")
+		return nil, web.NewBadRequestError("format kode OPD tidak valid")
 	}
 
 	// Cek apakah OPD ada
 	_, err = service.opdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("OPD dengan kode %s tidak ditemukan", kodeOpd)
+			return nil, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan", kodeOpd))
 		}
 		return nil, err
 	}
@@ -401,6 +435,10 @@ func isValidKodeOpd(kodeOpd string) bool {
 }
 
 func (service *SubKegiatanTerpilihServiceImpl) CreateOpdMultiple(ctx context.Context, request subkegiatan.SubKegiatanOpdMultipleCreateRequest) (subkegiatan.SubKegiatanOpdMultipleResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return subkegiatan.SubKegiatanOpdMultipleResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return subkegiatan.SubKegiatanOpdMultipleResponse{}, err
@@ -411,7 +449,7 @@ func (service *SubKegiatanTerpilihServiceImpl) CreateOpdMultiple(ctx context.Con
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return subkegiatan.SubKegiatanOpdMultipleResponse{}, fmt.Errorf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd)
+			return subkegiatan.SubKegiatanOpdMultipleResponse{}, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd))
 		}
 		return subkegiatan.SubKegiatanOpdMultipleResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data OPD: %v", err)
 	}
@@ -6,9 +6,11 @@ import (
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/tujuanopd"
 	"ekak_kabupaten_madiun/repository"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -27,9 +29,10 @@ type TujuanOpdServiceImpl struct {
 	BidangUrusanRepository repository.BidangUrusanRepository
 	LockDataRepository     repository.LockDataRepository
 	DB                     *sql.DB
+	Cache                  *helper.Cache
 }
 
-func NewTujuanOpdServiceImpl(tujuanOpdRepository repository.TujuanOpdRepository, opdRepository repository.OpdRepository, periodeRepository repository.PeriodeRepository, bidangUrusanRepository repository.BidangUrusanRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB) *TujuanOpdServiceImpl {
+func NewTujuanOpdServiceImpl(tujuanOpdRepository repository.TujuanOpdRepository, opdRepository repository.OpdRepository, periodeRepository repository.PeriodeRepository, bidangUrusanRepository repository.BidangUrusanRepository, lockDataRepository repository.LockDataRepository, DB *sql.DB, cache *helper.Cache) *TujuanOpdServiceImpl {
 	return &TujuanOpdServiceImpl{
 		TujuanOpdRepository:    tujuanOpdRepository,
 		OpdRepository:          opdRepository,
@@ -37,29 +40,35 @@ func NewTujuanOpdServiceImpl(tujuanOpdRepository repository.TujuanOpdRepository,
 		BidangUrusanRepository: bidangUrusanRepository,
 		LockDataRepository:     lockDataRepository,
 		DB:                     DB,
+		Cache:                  cache,
 	}
 }
 
 func (service *TujuanOpdServiceImpl) Create(ctx context.Context, request tujuanopd.TujuanOpdCreateRequest) (tujuanopd.TujuanOpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return tujuanopd.TujuanOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return tujuanopd.TujuanOpdResponse{}, err
 	}
+	defer invalidateRenstraCache(ctx, service.Cache, request.KodeOpd)
 	defer helper.CommitOrRollback(tx)
 	periode, err := service.PeriodeRepository.FindById(ctx, tx, request.PeriodeId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("periode dengan id %d tidak ditemukan", request.PeriodeId)
+			return tujuanopd.TujuanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("periode dengan id %d tidak ditemukan", request.PeriodeId))
 		}
 		return tujuanopd.TujuanOpdResponse{}, err
 	}
 	tahunAwal, err := strconv.Atoi(periode.TahunAwal)
 	if err != nil {
-		return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("format tahun awal periode tidak valid: %s", periode.TahunAwal)
+		return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("format tahun awal periode tidak valid: %s", periode.TahunAwal))
 	}
 	tahunAkhir, err := strconv.Atoi(periode.TahunAkhir)
 	if err != nil {
-		return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("format tahun akhir periode tidak valid: %s", periode.TahunAkhir)
+		return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("format tahun akhir periode tidak valid: %s", periode.TahunAkhir))
 	}
 	_, err = service.BidangUrusanRepository.FindByKodeBidangUrusan(ctx, tx, request.KodeBidangUrusan)
 	if err != nil {
@@ -100,7 +109,7 @@ func (service *TujuanOpdServiceImpl) Create(ctx context.Context, request tujuano
 		for _, targetReq := range indikatorReq.Target {
 			tahunTarget, err := strconv.Atoi(targetReq.Tahun)
 			if err != nil {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("format tahun target tidak valid: %s", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("format tahun target tidak valid: %s", targetReq.Tahun))
 			}
 			if tahunTarget < tahunAwal || tahunTarget > tahunAkhir {
 				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf(
@@ -109,14 +118,14 @@ func (service *TujuanOpdServiceImpl) Create(ctx context.Context, request tujuano
 				)
 			}
 			if tahunMap[targetReq.Tahun] {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("tahun target %s duplikat", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewConflictError(fmt.Sprintf("tahun target %s duplikat", targetReq.Tahun))
 			}
 			tahunMap[targetReq.Tahun] = true
 			if targetReq.Target == "" {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("target untuk tahun %s tidak boleh kosong", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("target untuk tahun %s tidak boleh kosong", targetReq.Tahun))
 			}
 			if targetReq.Satuan == "" {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("satuan untuk tahun %s tidak boleh kosong", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("satuan untuk tahun %s tidak boleh kosong", targetReq.Tahun))
 			}
 			uuidTrg := uuid.New().String()[:5]
 			targetDomain := domain.Target{
@@ -138,25 +147,30 @@ func (service *TujuanOpdServiceImpl) Create(ctx context.Context, request tujuano
 }
 
 func (service *TujuanOpdServiceImpl) Update(ctx context.Context, request tujuanopd.TujuanOpdUpdateRequest) (tujuanopd.TujuanOpdResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return tujuanopd.TujuanOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return tujuanopd.TujuanOpdResponse{}, err
 	}
+	defer invalidateRenstraCache(ctx, service.Cache, request.KodeOpd)
 	defer helper.CommitOrRollback(tx)
 	periode, err := service.PeriodeRepository.FindById(ctx, tx, request.PeriodeId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("periode dengan id %d tidak ditemukan", request.PeriodeId)
+			return tujuanopd.TujuanOpdResponse{}, web.NewNotFoundError(fmt.Sprintf("periode dengan id %d tidak ditemukan", request.PeriodeId))
 		}
 		return tujuanopd.TujuanOpdResponse{}, err
 	}
 	tahunAwal, err := strconv.Atoi(periode.TahunAwal)
 	if err != nil {
-		return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("format tahun awal periode tidak valid: %s", periode.TahunAwal)
+		return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("format tahun awal periode tidak valid: %s", periode.TahunAwal))
 	}
 	tahunAkhir, err := strconv.Atoi(periode.TahunAkhir)
 	if err != nil {
-		return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("format tahun akhir periode tidak valid: %s", periode.TahunAkhir)
+		return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("format tahun akhir periode tidak valid: %s", periode.TahunAkhir))
 	}
 	_, err = service.TujuanOpdRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
@@ -214,7 +228,7 @@ func (service *TujuanOpdServiceImpl) Update(ctx context.Context, request tujuano
 		for _, targetReq := range indikatorReq.Target {
 			tahunTarget, err := strconv.Atoi(targetReq.Tahun)
 			if err != nil {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("format tahun target tidak valid: %s", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("format tahun target tidak valid: %s", targetReq.Tahun))
 			}
 			if tahunTarget < tahunAwal || tahunTarget > tahunAkhir {
 				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf(
@@ -223,14 +237,14 @@ func (service *TujuanOpdServiceImpl) Update(ctx context.Context, request tujuano
 				)
 			}
 			if tahunMap[targetReq.Tahun] {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("tahun target %s duplikat", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewConflictError(fmt.Sprintf("tahun target %s duplikat", targetReq.Tahun))
 			}
 			tahunMap[targetReq.Tahun] = true
 			if targetReq.Target == "" {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("target untuk tahun %s tidak boleh kosong", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("target untuk tahun %s tidak boleh kosong", targetReq.Tahun))
 			}
 			if targetReq.Satuan == "" {
-				return tujuanopd.TujuanOpdResponse{}, fmt.Errorf("satuan untuk tahun %s tidak boleh kosong", targetReq.Tahun)
+				return tujuanopd.TujuanOpdResponse{}, web.NewBadRequestError(fmt.Sprintf("satuan untuk tahun %s tidak boleh kosong", targetReq.Tahun))
 			}
 			var targetId string
 			if targetReq.Id != "" {
@@ -262,12 +276,15 @@ func (service *TujuanOpdServiceImpl) Delete(ctx context.Context, tujuanOpdId int
 	if err != nil {
 		return err
 	}
+	var kodeOpd string
+	defer func() { invalidateRenstraCache(ctx, service.Cache, kodeOpd) }()
 	defer helper.CommitOrRollback(tx)
 
-	_, err = service.TujuanOpdRepository.FindById(ctx, tx, tujuanOpdId)
+	tujuanOpd, err := service.TujuanOpdRepository.FindById(ctx, tx, tujuanOpdId)
 	if err != nil {
 		return err
 	}
+	kodeOpd = tujuanOpd.KodeOpd
 
 	return service.TujuanOpdRepository.Delete(ctx, tx, tujuanOpdId)
 }
@@ -370,13 +387,13 @@ func (service *TujuanOpdServiceImpl) FindAll(ctx context.Context, kodeOpd string
 
 	// Validasi tahun
 	if len(tahunAwal) != 4 || len(tahunAkhir) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahunAwal); err != nil {
-		return nil, fmt.Errorf("tahun awal harus berupa angka")
+		return nil, web.NewBadRequestError("tahun awal harus berupa angka")
 	}
 	if _, err := strconv.Atoi(tahunAkhir); err != nil {
-		return nil, fmt.Errorf("tahun akhir harus berupa angka")
+		return nil, web.NewBadRequestError("tahun akhir harus berupa angka")
 	}
 
 	// Ambil data OPD
@@ -517,13 +534,13 @@ func (service *TujuanOpdServiceImpl) FindTujuanOpdOnlyName(ctx context.Context,
 
 	// Validasi tahun
 	if len(tahunAwal) != 4 || len(tahunAkhir) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahunAwal); err != nil {
-		return nil, fmt.Errorf("tahun awal harus berupa angka")
+		return nil, web.NewBadRequestError("tahun awal harus berupa angka")
 	}
 	if _, err := strconv.Atoi(tahunAkhir); err != nil {
-		return nil, fmt.Errorf("tahun akhir harus berupa angka")
+		return nil, web.NewBadRequestError("tahun akhir harus berupa angka")
 	}
 
 	// Ambil data OPD
@@ -608,10 +625,10 @@ func (service *TujuanOpdServiceImpl) FindTujuanOpdByTahun(ctx context.Context, k
 
 	// Validasi tahun
 	if len(tahun) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahun); err != nil {
-		return nil, fmt.Errorf("tahun harus berupa angka")
+		return nil, web.NewBadRequestError("tahun harus berupa angka")
 	}
 
 	// Ambil data OPD
@@ -921,37 +938,40 @@ func (service *TujuanOpdServiceImpl) FindTujuanRenstra(
 	kodeOpd, tahunAwal, tahunAkhir, jenisPeriode string,
 ) ([]tujuanopd.TujuanOpdwithBidangUrusanResponse, error) {
 	if len(tahunAwal) != 4 || len(tahunAkhir) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahunAwal); err != nil {
-		return nil, fmt.Errorf("tahun awal harus berupa angka")
+		return nil, web.NewBadRequestError("tahun awal harus berupa angka")
 	}
 	if _, err := strconv.Atoi(tahunAkhir); err != nil {
-		return nil, fmt.Errorf("tahun akhir harus berupa angka")
+		return nil, web.NewBadRequestError("tahun akhir harus berupa angka")
 	}
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return nil, err
-	}
-	defer helper.CommitOrRollback(tx)
-	opd, err := service.OpdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
-	if err != nil {
-		return nil, err
-	}
-	tujuanOpds, err := service.TujuanOpdRepository.FindAllByPeriod(
-		ctx, tx, kodeOpd, tahunAwal, tahunAkhir, jenisPeriode, "renstra",
-	)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return make([]tujuanopd.TujuanOpdwithBidangUrusanResponse, 0), nil
+	key := helper.NewCacheKey(helper.CacheEntityTujuanOpd, "renstra", kodeOpd, tahunAwal, tahunAkhir, jenisPeriode).WithTags(helper.CacheTagOpd(kodeOpd))
+	return helper.Remember(ctx, service.Cache, key, helper.ReadCacheTTL, func() ([]tujuanopd.TujuanOpdwithBidangUrusanResponse, error) {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return nil, err
 		}
-		return nil, err
-	}
-	bidangUrusanMap, err := service.fetchBidangUrusanMap(ctx, tx, tujuanOpds)
-	if err != nil {
-		return nil, err
-	}
-	return BuildTujuanOpdBidangResponse(tujuanOpds, opd, bidangUrusanMap, &TujuanOpdBidangResponseOpts{ForceIndicatorJenisRankhir: true}), nil
+		defer helper.CommitOrRollback(tx)
+		opd, err := service.OpdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
+		if err != nil {
+			return nil, err
+		}
+		tujuanOpds, err := service.TujuanOpdRepository.FindAllByPeriod(
+			ctx, tx, kodeOpd, tahunAwal, tahunAkhir, jenisPeriode, "renstra",
+		)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return make([]tujuanopd.TujuanOpdwithBidangUrusanResponse, 0), nil
+			}
+			return nil, err
+		}
+		bidangUrusanMap, err := service.fetchBidangUrusanMap(ctx, tx, tujuanOpds)
+		if err != nil {
+			return nil, err
+		}
+		return BuildTujuanOpdBidangResponse(tujuanOpds, opd, bidangUrusanMap, &TujuanOpdBidangResponseOpts{ForceIndicatorJenisRankhir: true}), nil
+	})
 }
 
 // ─────────────────────────────────────────────────────────────────
@@ -964,10 +984,10 @@ func (service *TujuanOpdServiceImpl) FindTujuanRanwal(
 	kodeOpd, tahun, jenisPeriode string,
 ) ([]tujuanopd.TujuanOpdwithBidangUrusanResponse, error) {
 	if len(tahun) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahun); err != nil {
-		return nil, fmt.Errorf("tahun harus berupa angka")
+		return nil, web.NewBadRequestError("tahun harus berupa angka")
 	}
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -1005,10 +1025,10 @@ func (service *TujuanOpdServiceImpl) FindTujuanRankhir(
 	kodeOpd, tahun, jenisPeriode string,
 ) ([]tujuanopd.TujuanOpdwithBidangUrusanResponse, error) {
 	if len(tahun) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahun); err != nil {
-		return nil, fmt.Errorf("tahun harus berupa angka")
+		return nil, web.NewBadRequestError("tahun harus berupa angka")
 	}
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -1072,10 +1092,10 @@ func mergeRanwalPrioritasRankhirPerTujuan(ranwalOpds, rankhirOpds []domain.Tujua
 
 // func (service *TujuanOpdServiceImpl) FindTujuanRankhir(	ctx context.Context,	kodeOpd, tahun, jenisPeriode string,) ([]tujuanopd.TujuanOpdwithBidangUrusanResponse, error) {
 // 	if len(tahun) != 4 {
-// 		return nil, fmt.Errorf("format tahun tidak valid")
+// 		return nil, web.NewBadRequestError("format tahun tidak valid")
 // 	}
 // 	if _, err := strconv.Atoi(tahun); err != nil {
-// 		return nil, fmt.Errorf("tahun harus berupa angka")
+// 		return nil, web.NewBadRequestError("tahun harus berupa angka")
 // 	}
 // 	tx, err := service.DB.Begin()
 // 	if err != nil {
@@ -1108,32 +1128,40 @@ func (service *TujuanOpdServiceImpl) CreateTujuanRenjaIndikator(
 	jenis string,
 	requests []tujuanopd.IndikatorCreateRequest,
 ) ([]tujuanopd.IndikatorResponse, error) {
+	for _, request := range requests {
+		if err := helper.ValidateRequest(request); err != nil {
+			return nil, err
+		}
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.TujuanOpdRepository.FindById(ctx, tx, tujuanOpdId)
+	tujuan, err := service.TujuanOpdRepository.FindById(ctx, tx, tujuanOpdId)
 	if err != nil {
-		return nil, fmt.Errorf("tujuan opd id %d tidak ditemukan", tujuanOpdId)
+		return nil, web.NewNotFoundError(fmt.Sprintf("tujuan opd id %d tidak ditemukan", tujuanOpdId))
 	}
 	var indikatorDomains []domain.Indikator
 	var responses []tujuanopd.IndikatorResponse
 	for _, req := range requests {
 		if req.Indikator == "" {
-			return nil, fmt.Errorf("nama indikator tidak boleh kosong")
+			return nil, web.NewBadRequestError("nama indikator tidak boleh kosong")
 		}
 		if len(req.Target) != 1 {
-			return nil, fmt.Errorf("setiap indikator harus memiliki tepat 1 target")
+			return nil, web.NewBadRequestError("setiap indikator harus memiliki tepat 1 target")
 		}
 		if req.Target[0].Target == "" {
-			return nil, fmt.Errorf("nilai target tidak boleh kosong")
+			return nil, web.NewBadRequestError("nilai target tidak boleh kosong")
 		}
 		if req.Target[0].Satuan == "" {
-			return nil, fmt.Errorf("satuan tidak boleh kosong")
+			return nil, web.NewBadRequestError("satuan tidak boleh kosong")
 		}
 		if req.Target[0].Tahun == "" {
-			return nil, fmt.Errorf("tahun target tidak boleh kosong")
+			return nil, web.NewBadRequestError("tahun target tidak boleh kosong")
+		}
+		if err := checkLockData(ctx, tx, service.LockDataRepository, lockJenisTujuanOpd, tujuan.KodeOpd, req.Target[0].Tahun); err != nil {
+			return nil, err
 		}
 		kodeIndikator := fmt.Sprintf("IND-TJN-%s", uuid.New().String()[:5])
 		targetId := fmt.Sprintf("TRG-TJN-%s", uuid.New().String()[:5])
@@ -1181,28 +1209,40 @@ func (service *TujuanOpdServiceImpl) UpdateTujuanRenjaIndikator(
 	jenis string,
 	request tujuanopd.IndikatorUpdateRequest, // ← single object
 ) (tujuanopd.IndikatorResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return tujuanopd.IndikatorResponse{}, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return tujuanopd.IndikatorResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
 	// Validasi: pastikan kode_indikator ada di DB
-	_, err = service.TujuanOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
+	existingIndikator, err := service.TujuanOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
-		return tujuanopd.IndikatorResponse{}, fmt.Errorf("indikator dengan kode %s tidak ditemukan", kodeIndikator)
+		return tujuanopd.IndikatorResponse{}, web.NewNotFoundError(fmt.Sprintf("indikator dengan kode %s tidak ditemukan", kodeIndikator))
 	}
 	// Validasi field wajib
 	if request.Indikator == "" {
-		return tujuanopd.IndikatorResponse{}, fmt.Errorf("nama indikator tidak boleh kosong")
+		return tujuanopd.IndikatorResponse{}, web.NewBadRequestError("nama indikator tidak boleh kosong")
 	}
 	if len(request.Target) != 1 {
-		return tujuanopd.IndikatorResponse{}, fmt.Errorf("harus memiliki tepat 1 target")
+		return tujuanopd.IndikatorResponse{}, web.NewBadRequestError("harus memiliki tepat 1 target")
 	}
 	if request.Target[0].Target == "" {
-		return tujuanopd.IndikatorResponse{}, fmt.Errorf("nilai target tidak boleh kosong")
+		return tujuanopd.IndikatorResponse{}, web.NewBadRequestError("nilai target tidak boleh kosong")
 	}
 	if request.Target[0].Tahun == "" {
-		return tujuanopd.IndikatorResponse{}, fmt.Errorf("tahun target tidak boleh kosong")
+		return tujuanopd.IndikatorResponse{}, web.NewBadRequestError("tahun target tidak boleh kosong")
+	}
+	kodeOpdTujuan, err := service.kodeOpdTujuan(ctx, tx, existingIndikator.TujuanOpdId)
+	if err != nil {
+		return tujuanopd.IndikatorResponse{}, err
+	}
+	for _, tahun := range []string{existingIndikator.Tahun, request.Target[0].Tahun} {
+		if err := checkLockData(ctx, tx, service.LockDataRepository, lockJenisTujuanOpd, kodeOpdTujuan, tahun); err != nil {
+			return tujuanopd.IndikatorResponse{}, err
+		}
 	}
 	targetId := request.Target[0].Id
 	if targetId == "" {
@@ -1246,25 +1286,47 @@ func (service *TujuanOpdServiceImpl) DeleteTujuanRenjaIndikator(ctx context.Cont
 		return err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.TujuanOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
+	existingIndikator, err := service.TujuanOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("kode indikator %s tidak ditemukan", kodeIndikator)
+			return web.NewNotFoundError(fmt.Sprintf("kode indikator %s tidak ditemukan", kodeIndikator))
 		}
 		return err // ← tampilkan error asli (bukan dibungkus)
 	}
+	kodeOpdTujuan, err := service.kodeOpdTujuan(ctx, tx, existingIndikator.TujuanOpdId)
+	if err != nil {
+		return err
+	}
+	if err := checkLockData(ctx, tx, service.LockDataRepository, lockJenisTujuanOpd, kodeOpdTujuan, existingIndikator.Tahun); err != nil {
+		return err
+	}
 	return service.TujuanOpdRepository.DeleteIndikatorTargetRenja(ctx, tx, kodeIndikator)
 }
 
+// kodeOpdTujuan mengambil kode_opd tujuan opd pemilik indikator (kosong jika tujuan tidak ditemukan)
+func (service *TujuanOpdServiceImpl) kodeOpdTujuan(ctx context.Context, tx *sql.Tx, tujuanOpdId int) (string, error) {
+	if tujuanOpdId == 0 {
+		return "", nil
+	}
+	tujuan, err := service.TujuanOpdRepository.FindById(ctx, tx, tujuanOpdId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == fmt.Sprintf("tujuan opd with id %d not found", tujuanOpdId) {
+			return "", nil
+		}
+		return "", err
+	}
+	return tujuan.KodeOpd, nil
+}
+
 func (service *TujuanOpdServiceImpl) FindTujuanPenetapan(
 	ctx context.Context,
 	kodeOpd, tahun, jenisPeriode string,
 ) ([]tujuanopd.TujuanOpdwithBidangUrusanResponse, error) {
 	if len(tahun) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	if _, err := strconv.Atoi(tahun); err != nil {
-		return nil, fmt.Errorf("tahun harus berupa angka")
+		return nil, web.NewBadRequestError("tahun harus berupa angka")
 	}
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -1291,11 +1353,11 @@ func (service *TujuanOpdServiceImpl) FindTujuanPenetapan(
 	return service.buildTujuanOpdResponse(tujuanOpds, opd, bidangUrusanMap), nil
 }
 
-const lockJenisTujuanOpd = "tujuan_opd"
+const lockJenisTujuanOpd = domain.JenisLockTujuanOpd
 
 func (service *TujuanOpdServiceImpl) TujuanOpdPenetapan(ctx context.Context, kodeOpd, tahun, jenisPeriode string) ([]tujuanopd.TujuanOpdPenetapanResponse, error) {
 	if len(tahun) != 4 {
-		return nil, fmt.Errorf("format tahun tidak valid")
+		return nil, web.NewBadRequestError("format tahun tidak valid")
 	}
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -1414,6 +1476,16 @@ func (service *TujuanOpdServiceImpl) SetTujuanOpdLocked(
 	return service.TujuanOpdRepository.SetTujuanOpdLocked(ctx, tx, tujuanOpdId, locked)
 }
 
+// invalidateRenstraCache menghapus cache renstra tujuan dan sasaran OPD (sasaran memuat nama tujuan).
+// Jika kode OPD tidak diketahui seluruh cache kedua entitas dihapus.
+func invalidateRenstraCache(ctx context.Context, cache *helper.Cache, kodeOpd string) {
+	if kodeOpd == "" {
+		cache.Invalidate(ctx, helper.CacheTagEntity(helper.CacheEntityTujuanOpd), helper.CacheTagEntity(helper.CacheEntitySasaranOpd))
+		return
+	}
+	cache.Invalidate(ctx, helper.CacheTagOpd(kodeOpd))
+}
+
 func parseTujuanOpdId(kode string) int {
 	parts := strings.Split(kode, "-")
 	if len(parts) == 0 {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/tujuanpemda"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -60,6 +61,10 @@ func generateTargetId() string {
 }
 
 func (service *TujuanPemdaServiceImpl) Create(ctx context.Context, request tujuanpemda.TujuanPemdaCreateRequest) (tujuanpemda.TujuanPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return tujuanpemda.TujuanPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return tujuanpemda.TujuanPemdaResponse{}, err
@@ -207,6 +212,10 @@ func (service *TujuanPemdaServiceImpl) Create(ctx context.Context, request tujua
 }
 
 func (service *TujuanPemdaServiceImpl) Update(ctx context.Context, request tujuanpemda.TujuanPemdaUpdateRequest) (tujuanpemda.TujuanPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return tujuanpemda.TujuanPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return tujuanpemda.TujuanPemdaResponse{}, err
@@ -512,6 +521,10 @@ func (service *TujuanPemdaServiceImpl) toTujuanPemdaResponse(tujuanPemda domain.
 }
 
 func (service *TujuanPemdaServiceImpl) UpdatePeriode(ctx context.Context, request tujuanpemda.TujuanPemdaUpdateRequest) (tujuanpemda.TujuanPemdaResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return tujuanpemda.TujuanPemdaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return tujuanpemda.TujuanPemdaResponse{}, err
@@ -521,14 +534,14 @@ func (service *TujuanPemdaServiceImpl) UpdatePeriode(ctx context.Context, reques
 	// Validasi tujuan pemda exists
 	exists := service.TujuanPemdaRepository.IsIdExists(ctx, tx, request.Id)
 	if !exists {
-		return tujuanpemda.TujuanPemdaResponse{}, fmt.Errorf("periode pemda dengan id %d tidak ditemukan", request.Id)
+		return tujuanpemda.TujuanPemdaResponse{}, web.NewNotFoundError(fmt.Sprintf("periode pemda dengan id %d tidak ditemukan", request.Id))
 	}
 
 	// Validasi periode exists jika periode_id tidak 0
 	if request.PeriodeId != 0 {
 		exists = service.PeriodeRepository.IsIdExists(ctx, tx, request.PeriodeId)
 		if !exists {
-			return tujuanpemda.TujuanPemdaResponse{}, fmt.Errorf("periode dengan id %d tidak ditemukan", request.PeriodeId)
+			return tujuanpemda.TujuanPemdaResponse{}, web.NewNotFoundError(fmt.Sprintf("periode dengan id %d tidak ditemukan", request.PeriodeId))
 		}
 	}
 
@@ -577,11 +590,11 @@ func (service *TujuanPemdaServiceImpl) FindAllWithPokin(ctx context.Context, tah
 
 	tahunAwalInt, err := strconv.Atoi(tahunAwal)
 	if err != nil {
-		return nil, fmt.Errorf("format tahun awal tidak valid: %v", err)
+		return nil, web.NewBadRequestError(fmt.Sprintf("format tahun awal tidak valid: %v", err))
 	}
 	tahunAkhirInt, err := strconv.Atoi(tahunAkhir)
 	if err != nil {
-		return nil, fmt.Errorf("format tahun akhir tidak valid: %v", err)
+		return nil, web.NewBadRequestError(fmt.Sprintf("format tahun akhir tidak valid: %v", err))
 	}
 
 	// Buat map untuk mengelompokkan berdasarkan PokinId
@@ -743,7 +756,7 @@ func (service *TujuanPemdaServiceImpl) FindPokinWithPeriode(ctx context.Context,
 
 	// Validasi jenis periode
 	if jenisPeriode == "" {
-		return tujuanpemda.PokinWithPeriodeResponse{}, fmt.Errorf("jenis periode tidak boleh kosong")
+		return tujuanpemda.PokinWithPeriodeResponse{}, web.NewBadRequestError("jenis periode tidak boleh kosong")
 	}
 
 	// Ambil data pokin dengan periode
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/bidangurusanresponse"
 	"ekak_kabupaten_madiun/model/web/urusanrespon"
 	"ekak_kabupaten_madiun/repository"
@@ -24,6 +25,10 @@ func NewUrusanServiceImpl(urusanRepository repository.UrusanRepository, DB *sql.
 }
 
 func (service *UrusanServiceImpl) Create(ctx context.Context, request urusanrespon.UrusanCreateRequest) (urusanrespon.UrusanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return urusanrespon.UrusanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return urusanrespon.UrusanResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
@@ -51,6 +56,10 @@ func (service *UrusanServiceImpl) Create(ctx context.Context, request urusanresp
 }
 
 func (service *UrusanServiceImpl) Update(ctx context.Context, request urusanrespon.UrusanUpdateRequest) (urusanrespon.UrusanResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return urusanrespon.UrusanResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return urusanrespon.UrusanResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
@@ -128,7 +137,7 @@ func (service *UrusanServiceImpl) Delete(ctx context.Context, id string) error {
 	// Cek apakah urusan dengan ID tersebut ada
 	_, err = service.UrusanRepository.FindById(ctx, tx, id)
 	if err != nil {
-		return fmt.Errorf("urusan dengan id %s tidak ditemukan", id)
+		return web.NewNotFoundError(fmt.Sprintf("urusan dengan id %s tidak ditemukan", id))
 	}
 
 	return service.UrusanRepository.Delete(ctx, tx, id)
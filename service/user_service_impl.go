@@ -5,33 +5,46 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/user"
 	"ekak_kabupaten_madiun/repository"
 	"errors"
-	"sort"
+	"log"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserServiceImpl struct {
-	UserRepository    repository.UserRepository
-	RoleRepository    repository.RoleRepository
-	PegawaiRepository repository.PegawaiRepository
-	OpdRepository     repository.OpdRepository
-	DB                *sql.DB
+	UserRepository         repository.UserRepository
+	RoleRepository         repository.RoleRepository
+	PegawaiRepository      repository.PegawaiRepository
+	OpdRepository          repository.OpdRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	DB                     *sql.DB
+	RedisClient            *redis.Client
 }
 
-func NewUserServiceImpl(userRepository repository.UserRepository, roleRepository repository.RoleRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, db *sql.DB) *UserServiceImpl {
+func NewUserServiceImpl(userRepository repository.UserRepository, roleRepository repository.RoleRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, refreshTokenRepository repository.RefreshTokenRepository, db *sql.DB, redisClient *redis.Client) *UserServiceImpl {
 	return &UserServiceImpl{
-		UserRepository:    userRepository,
-		RoleRepository:    roleRepository,
-		PegawaiRepository: pegawaiRepository,
-		OpdRepository:     opdRepository,
-		DB:                db,
+		UserRepository:         userRepository,
+		RoleRepository:         roleRepository,
+		PegawaiRepository:      pegawaiRepository,
+		OpdRepository:          opdRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		DB:                     db,
+		RedisClient:            redisClient,
 	}
 }
 
 func (service *UserServiceImpl) Create(ctx context.Context, request user.UserCreateRequest) (user.UserResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return user.UserResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return user.UserResponse{}, err
@@ -40,20 +53,20 @@ func (service *UserServiceImpl) Create(ctx context.Context, request user.UserCre
 
 	// Validasi input dasar
 	if request.Nip == "" {
-		return user.UserResponse{}, errors.New("nip harus diisi")
+		return user.UserResponse{}, web.NewBadRequestError("nip harus diisi")
 	}
 	if request.Password == "" {
-		return user.UserResponse{}, errors.New("password harus diisi")
+		return user.UserResponse{}, web.NewBadRequestError("password harus diisi")
 	}
 	if len(request.Role) == 0 {
-		return user.UserResponse{}, errors.New("role harus diisi")
+		return user.UserResponse{}, web.NewBadRequestError("role harus diisi")
 	}
 
 	// Validasi NIP dengan data pegawai
 	_, err = service.PegawaiRepository.FindByNip(ctx, tx, request.Nip)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return user.UserResponse{}, errors.New("nip tidak terdaftar di data pegawai")
+			return user.UserResponse{}, web.NewBadRequestError("nip tidak terdaftar di data pegawai")
 		}
 		return user.UserResponse{}, err
 	}
@@ -66,7 +79,7 @@ func (service *UserServiceImpl) Create(ctx context.Context, request user.UserCre
 		role, err := service.RoleRepository.FindById(ctx, tx, roleRequest.RoleId)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				return user.UserResponse{}, errors.New("role tidak ditemukan")
+				return user.UserResponse{}, web.NewNotFoundError("role tidak ditemukan")
 			}
 			return user.UserResponse{}, err
 		}
@@ -114,6 +127,10 @@ func (service *UserServiceImpl) Create(ctx context.Context, request user.UserCre
 }
 
 func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpdateRequest) (user.UserResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return user.UserResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return user.UserResponse{}, err
@@ -126,18 +143,18 @@ func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpd
 		return user.UserResponse{}, err
 	}
 	if existingUser.Id == 0 {
-		return user.UserResponse{}, errors.New("user tidak ditemukan")
+		return user.UserResponse{}, web.NewNotFoundError("user tidak ditemukan")
 	}
 
 	// Validasi input dasar
 	if request.Nip == "" {
-		return user.UserResponse{}, errors.New("nip harus diisi")
+		return user.UserResponse{}, web.NewBadRequestError("nip harus diisi")
 	}
 	if request.Email == "" {
-		return user.UserResponse{}, errors.New("email harus diisi")
+		return user.UserResponse{}, web.NewBadRequestError("email harus diisi")
 	}
 	if len(request.Role) == 0 {
-		return user.UserResponse{}, errors.New("role harus diisi")
+		return user.UserResponse{}, web.NewBadRequestError("role harus diisi")
 	}
 
 	// Siapkan slice untuk menyimpan roles
@@ -148,7 +165,7 @@ func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpd
 		role, err := service.RoleRepository.FindById(ctx, tx, roleRequest.RoleId)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				return user.UserResponse{}, errors.New("role tidak ditemukan")
+				return user.UserResponse{}, web.NewNotFoundError("role tidak ditemukan")
 			}
 			return user.UserResponse{}, err
 		}
@@ -179,6 +196,12 @@ func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpd
 		return user.UserResponse{}, err
 	}
 
+	// Perubahan user (role, nip, status aktif) harus berlaku segera, cabut semua sesi login
+	err = service.revokeAllSessions(ctx, tx, existingUser.Id)
+	if err != nil {
+		return user.UserResponse{}, err
+	}
+
 	// Konversi role ke response
 	var roleResponses []user.RoleResponse
 	for _, role := range updatedUser.Role {
@@ -211,7 +234,7 @@ func (service *UserServiceImpl) Delete(ctx context.Context, id int) error {
 		return err
 	}
 	if existingUser.Id == 0 {
-		return errors.New("user tidak ditemukan")
+		return web.NewNotFoundError("user tidak ditemukan")
 	}
 
 	err = service.UserRepository.Delete(ctx, tx, id)
@@ -219,19 +242,24 @@ func (service *UserServiceImpl) Delete(ctx context.Context, id int) error {
 		return err
 	}
 
+	err = service.revokeAllSessions(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (service *UserServiceImpl) FindAll(ctx context.Context, kodeOpd string) ([]user.UserResponse, error) {
+func (service *UserServiceImpl) FindAll(ctx context.Context, query domain.ListQuery) ([]user.UserResponse, int, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer helper.CommitOrRollback(tx)
 
-	users, err := service.UserRepository.FindAll(ctx, tx, kodeOpd)
+	users, total, err := service.UserRepository.FindAllPaged(ctx, tx, query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// Ambil seluruh NIP user secara unik untuk batch query pegawai
@@ -249,7 +277,7 @@ func (service *UserServiceImpl) FindAll(ctx context.Context, kodeOpd string) ([]
 
 	pegawaiByNip, err := service.PegawaiRepository.FindPegawaiByNipsBatch(ctx, tx, nips)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var userResponses []user.UserResponse
@@ -284,12 +312,7 @@ func (service *UserServiceImpl) FindAll(ctx context.Context, kodeOpd string) ([]
 		userResponses = append(userResponses, userResponse)
 	}
 
-	sort.Slice(userResponses, func(i, j int) bool {
-		return userResponses[i].NamaPegawai < userResponses[j].NamaPegawai
-
-	})
-
-	return userResponses, nil
+	return userResponses, total, nil
 }
 
 func (service *UserServiceImpl) FindById(ctx context.Context, id int) (user.UserResponse, error) {
@@ -307,7 +330,7 @@ func (service *UserServiceImpl) FindById(ctx context.Context, id int) (user.User
 
 	// Cek apakah user ditemukan
 	if userDomain.Id == 0 {
-		return user.UserResponse{}, errors.New("user tidak ditemukan")
+		return user.UserResponse{}, web.NewNotFoundError("user tidak ditemukan")
 	}
 
 	// Konversi role domain ke role response
@@ -444,12 +467,124 @@ func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLogi
 		return user.UserLoginResponse{}, errors.New("akun tidak aktif")
 	}
 
+	return service.createSession(ctx, tx, userDomain, pegawaiDomain, opdDomain, uuid.New().String())
+}
+
+func (service *UserServiceImpl) Refresh(ctx context.Context, request user.UserRefreshRequest) (user.UserLoginResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	if request.RefreshToken == "" {
+		return user.UserLoginResponse{}, errors.New("refresh token harus diisi")
+	}
+
+	refreshToken, err := service.RefreshTokenRepository.FindByTokenHash(ctx, tx, helper.HashRefreshToken(request.RefreshToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user.UserLoginResponse{}, errors.New("refresh token tidak valid")
+		}
+		return user.UserLoginResponse{}, err
+	}
+
+	if refreshToken.RevokedAt.Valid {
+		// Refresh token lama dipakai ulang, kemungkinan bocor: cabut seluruh sesi tersebut
+		log.Printf("refresh token sesi %s dipakai ulang, sesi dicabut", refreshToken.SessionId)
+		err = service.RefreshTokenRepository.RevokeBySessionId(ctx, tx, refreshToken.SessionId)
+		if err != nil {
+			return user.UserLoginResponse{}, err
+		}
+		if err := helper.RevokeSessionAccessTokens(ctx, service.RedisClient, refreshToken.SessionId); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		return user.UserLoginResponse{}, errors.New("refresh token sudah tidak berlaku, silakan login kembali")
+	}
+
+	if time.Now().After(refreshToken.ExpiredAt) {
+		return user.UserLoginResponse{}, errors.New("refresh token sudah kedaluwarsa, silakan login kembali")
+	}
+
+	// Ambil ulang data user agar perubahan role/OPD langsung masuk ke token baru
+	userDomain, err := service.UserRepository.FindById(ctx, tx, refreshToken.UserId)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+	if userDomain.Id == 0 {
+		return user.UserLoginResponse{}, errors.New("user tidak ditemukan")
+	}
+	if !userDomain.IsActive {
+		return user.UserLoginResponse{}, errors.New("akun tidak aktif")
+	}
+
+	pegawaiDomain, err := service.PegawaiRepository.FindByNip(ctx, tx, userDomain.Nip)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	opdDomain, err := service.OpdRepository.FindByKodeOpd(ctx, tx, pegawaiDomain.KodeOpd)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	// Rotasi: refresh token lama hanya bisa dipakai sekali
+	err = service.RefreshTokenRepository.RevokeById(ctx, tx, refreshToken.Id)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	return service.createSession(ctx, tx, userDomain, pegawaiDomain, opdDomain, refreshToken.SessionId)
+}
+
+func (service *UserServiceImpl) Logout(ctx context.Context, request user.UserLogoutRequest) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	var sessionIds []string
+	if claims, ok := ctx.Value(helper.UserInfoKey).(web.JWTClaim); ok && claims.SessionId != "" {
+		sessionIds = append(sessionIds, claims.SessionId)
+	}
+
+	if request.RefreshToken != "" {
+		refreshToken, err := service.RefreshTokenRepository.FindByTokenHash(ctx, tx, helper.HashRefreshToken(request.RefreshToken))
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if err == nil {
+			sessionIds = append(sessionIds, refreshToken.SessionId)
+		}
+	}
+
+	if len(sessionIds) == 0 {
+		return web.NewNotFoundError("sesi login tidak ditemukan")
+	}
+
+	for _, sessionId := range sessionIds {
+		err = service.RefreshTokenRepository.RevokeBySessionId(ctx, tx, sessionId)
+		if err != nil {
+			return err
+		}
+		if err := helper.RevokeSessionAccessTokens(ctx, service.RedisClient, sessionId); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// createSession membuat access token dan refresh token baru untuk sesi login sessionId
+func (service *UserServiceImpl) createSession(ctx context.Context, tx *sql.Tx, userDomain domain.Users, pegawaiDomain domainmaster.Pegawai, opdDomain domainmaster.Opd, sessionId string) (user.UserLoginResponse, error) {
 	roleNames := make([]string, 0, len(userDomain.Role))
 	for _, role := range userDomain.Role {
 		roleNames = append(roleNames, role.Role)
 	}
 
-	token := helper.CreateNewJWT(
+	token := helper.CreateAccessToken(
+		sessionId,
 		userDomain.Id,
 		pegawaiDomain.Id,
 		userDomain.Email,
@@ -460,13 +595,43 @@ func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLogi
 		roleNames,
 	)
 
+	refreshToken, refreshTokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	_, err = service.RefreshTokenRepository.Create(ctx, tx, domain.RefreshToken{
+		SessionId: sessionId,
+		UserId:    userDomain.Id,
+		TokenHash: refreshTokenHash,
+		ExpiredAt: time.Now().Add(helper.RefreshTokenTTL()),
+	})
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
 	response := user.UserLoginResponse{
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(helper.AccessTokenTTL().Seconds()),
 	}
 
 	return response, nil
 }
 
+// revokeAllSessions mencabut semua refresh token user dan access token yang masih beredar
+func (service *UserServiceImpl) revokeAllSessions(ctx context.Context, tx *sql.Tx, userId int) error {
+	err := service.RefreshTokenRepository.RevokeByUserId(ctx, tx, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := helper.RevokeUserAccessTokens(ctx, service.RedisClient, userId); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return nil
+}
+
 func (service *UserServiceImpl) FindByKodeOpdAndRole(ctx context.Context, kodeOpd string, roleName string) ([]user.UserResponse, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -476,10 +641,10 @@ func (service *UserServiceImpl) FindByKodeOpdAndRole(ctx context.Context, kodeOp
 
 	// Validasi input
 	if kodeOpd == "" {
-		return nil, errors.New("kode opd harus diisi")
+		return nil, web.NewBadRequestError("kode opd harus diisi")
 	}
 	if roleName == "" {
-		return nil, errors.New("role harus diisi")
+		return nil, web.NewBadRequestError("role harus diisi")
 	}
 
 	users, err := service.UserRepository.FindByKodeOpdAndRole(ctx, tx, kodeOpd, roleName)
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -29,6 +30,10 @@ func NewUsulanInisiatifServiceImpl(usulanInisiatifRepository repository.UsulanIn
 }
 
 func (service *UsulanInisiatifServiceImpl) Create(ctx context.Context, request usulan.UsulanInisiatifCreateRequest) (usulan.UsulanInisiatifResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanInisiatifResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanInisiatifResponse{}, err
@@ -40,7 +45,7 @@ func (service *UsulanInisiatifServiceImpl) Create(ctx context.Context, request u
 	pegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, request.PegawaiId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return usulan.UsulanInisiatifResponse{}, fmt.Errorf("pegawai dengan NIP %s tidak ditemukan dalam database", request.PegawaiId)
+			return usulan.UsulanInisiatifResponse{}, web.NewNotFoundError(fmt.Sprintf("pegawai dengan NIP %s tidak ditemukan dalam database", request.PegawaiId))
 		}
 		return usulan.UsulanInisiatifResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data pegawai: %v", err)
 	}
@@ -49,7 +54,7 @@ func (service *UsulanInisiatifServiceImpl) Create(ctx context.Context, request u
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return usulan.UsulanInisiatifResponse{}, fmt.Errorf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd)
+			return usulan.UsulanInisiatifResponse{}, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd))
 		}
 		return usulan.UsulanInisiatifResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data OPD: %v", err)
 	}
@@ -78,6 +83,10 @@ func (service *UsulanInisiatifServiceImpl) Create(ctx context.Context, request u
 }
 
 func (service *UsulanInisiatifServiceImpl) Update(ctx context.Context, request usulan.UsulanInisiatifUpdateRequest) (usulan.UsulanInisiatifResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanInisiatifResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanInisiatifResponse{}, err
@@ -87,7 +96,7 @@ func (service *UsulanInisiatifServiceImpl) Update(ctx context.Context, request u
 	// Cari usulan inisiatif berdasarkan ID
 	existingUsulan, err := service.UsulanInisiatifRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
-		return usulan.UsulanInisiatifResponse{}, fmt.Errorf("usulan inisiatif dengan ID %s tidak ditemukan", request.Id)
+		return usulan.UsulanInisiatifResponse{}, web.NewNotFoundError(fmt.Sprintf("usulan inisiatif dengan ID %s tidak ditemukan", request.Id))
 	}
 
 	existingUsulan.Usulan = request.Usulan
@@ -115,7 +124,7 @@ func (service *UsulanInisiatifServiceImpl) FindById(ctx context.Context, idUsula
 
 	usulanInisiatif, err := service.UsulanInisiatifRepository.FindById(ctx, tx, idUsulan)
 	if err != nil {
-		return usulan.UsulanInisiatifResponse{}, fmt.Errorf("usulan inisiatif dengan ID %s tidak ditemukan", idUsulan)
+		return usulan.UsulanInisiatifResponse{}, web.NewNotFoundError(fmt.Sprintf("usulan inisiatif dengan ID %s tidak ditemukan", idUsulan))
 	}
 
 	response := helper.ToUsulanInisiatifResponse(usulanInisiatif)
@@ -148,7 +157,7 @@ func (service *UsulanInisiatifServiceImpl) Delete(ctx context.Context, idUsulan
 	// Cari usulan inisiatif berdasarkan ID
 	_, err = service.UsulanInisiatifRepository.FindById(ctx, tx, idUsulan)
 	if err != nil {
-		return fmt.Errorf("usulan inisiatif dengan ID %s tidak ditemukan", idUsulan)
+		return web.NewNotFoundError(fmt.Sprintf("usulan inisiatif dengan ID %s tidak ditemukan", idUsulan))
 	}
 
 	// Jika usulan inisiatif ditemukan, lanjutkan dengan penghapusan
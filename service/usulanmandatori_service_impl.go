@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -29,6 +30,10 @@ func NewUsulanMandatoriServiceImpl(usulanMandatoriRepository repository.UsulanMa
 }
 
 func (service *UsulanMandatoriServiceImpl) Create(ctx context.Context, request usulan.UsulanMandatoriCreateRequest) (usulan.UsulanMandatoriResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanMandatoriResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanMandatoriResponse{}, err
@@ -41,7 +46,7 @@ func (service *UsulanMandatoriServiceImpl) Create(ctx context.Context, request u
 	pegawai, err := service.pegawaiRepository.FindByNip(ctx, tx, request.PegawaiId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return usulan.UsulanMandatoriResponse{}, fmt.Errorf("pegawai dengan NIP %s tidak ditemukan dalam database", request.PegawaiId)
+			return usulan.UsulanMandatoriResponse{}, web.NewNotFoundError(fmt.Sprintf("pegawai dengan NIP %s tidak ditemukan dalam database", request.PegawaiId))
 		}
 		return usulan.UsulanMandatoriResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data pegawai: %v", err)
 	}
@@ -49,7 +54,7 @@ func (service *UsulanMandatoriServiceImpl) Create(ctx context.Context, request u
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return usulan.UsulanMandatoriResponse{}, fmt.Errorf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd)
+			return usulan.UsulanMandatoriResponse{}, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan dalam database", request.KodeOpd))
 		}
 		return usulan.UsulanMandatoriResponse{}, fmt.Errorf("terjadi kesalahan saat mencari data OPD: %v", err)
 	}
@@ -75,6 +80,10 @@ func (service *UsulanMandatoriServiceImpl) Create(ctx context.Context, request u
 }
 
 func (service *UsulanMandatoriServiceImpl) Update(ctx context.Context, request usulan.UsulanMandatoriUpdateRequest) (usulan.UsulanMandatoriResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanMandatoriResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanMandatoriResponse{}, err
@@ -157,7 +166,7 @@ func (service *UsulanMandatoriServiceImpl) Delete(ctx context.Context, idUsulan
 
 	_, err = service.usulanMandatoriRepository.FindById(ctx, tx, idUsulan)
 	if err != nil {
-		return fmt.Errorf("usulan dengan ID %s tidak ditemukan", idUsulan)
+		return web.NewNotFoundError(fmt.Sprintf("usulan dengan ID %s tidak ditemukan", idUsulan))
 	}
 
 	err = service.usulanMandatoriRepository.Delete(ctx, tx, idUsulan)
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/web/usulan"
 )
 
@@ -9,7 +10,7 @@ type UsulanMusrebangService interface {
 	Create(ctx context.Context, request usulan.UsulanMusrebangCreateRequest) (usulan.UsulanMusrebangResponse, error)
 	Update(ctx context.Context, request usulan.UsulanMusrebangUpdateRequest) (usulan.UsulanMusrebangResponse, error)
 	FindById(ctx context.Context, idUsulan string) (usulan.UsulanMusrebangResponse, error)
-	FindAll(ctx context.Context, kodeOpd *string, is_active *bool, rekinId *string, status *string) ([]usulan.UsulanMusrebangResponse, error)
+	FindAll(ctx context.Context, query domain.ListQuery) ([]usulan.UsulanMusrebangResponse, int, error)
 	Delete(ctx context.Context, idUsulan string) error
 	CreateRekin(ctx context.Context, request usulan.UsulanMusrebangCreateRekinRequest) ([]usulan.UsulanMusrebangResponse, error)
 	DeleteUsulanTerpilih(ctx context.Context, idUsulan string) error
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -29,6 +30,10 @@ func NewUsulanMusrebangServiceImpl(usulanMusrebangRepository repository.UsulanMu
 }
 
 func (service *UsulanMusrebangServiceImpl) Create(ctx context.Context, request usulan.UsulanMusrebangCreateRequest) (usulan.UsulanMusrebangResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanMusrebangResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanMusrebangResponse{}, err
@@ -59,6 +64,10 @@ func (service *UsulanMusrebangServiceImpl) Create(ctx context.Context, request u
 }
 
 func (service *UsulanMusrebangServiceImpl) Update(ctx context.Context, request usulan.UsulanMusrebangUpdateRequest) (usulan.UsulanMusrebangResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanMusrebangResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanMusrebangResponse{}, err
@@ -109,16 +118,16 @@ func (service *UsulanMusrebangServiceImpl) FindById(ctx context.Context, idUsula
 	return helper.ToUsulanMusrebangResponse(usulanMusrebang), nil
 }
 
-func (service *UsulanMusrebangServiceImpl) FindAll(ctx context.Context, kodeOpd *string, is_active *bool, rekinId *string, status *string) ([]usulan.UsulanMusrebangResponse, error) {
+func (service *UsulanMusrebangServiceImpl) FindAll(ctx context.Context, query domain.ListQuery) ([]usulan.UsulanMusrebangResponse, int, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
-		return []usulan.UsulanMusrebangResponse{}, err
+		return []usulan.UsulanMusrebangResponse{}, 0, err
 	}
 	defer helper.CommitOrRollback(tx)
 
-	usulanMusrebang, err := service.usulanMusrebangRepository.FindAll(ctx, tx, kodeOpd, is_active, rekinId, status)
+	usulanMusrebang, total, err := service.usulanMusrebangRepository.FindAllPaged(ctx, tx, query)
 	if err != nil {
-		return []usulan.UsulanMusrebangResponse{}, err
+		return []usulan.UsulanMusrebangResponse{}, 0, err
 	}
 
 	// Buat map untuk menyimpan data OPD yang sudah diambil
@@ -145,7 +154,7 @@ func (service *UsulanMusrebangServiceImpl) FindAll(ctx context.Context, kodeOpd
 
 	// Konversi ke response setelah nama OPD diisi
 	usulanMusrebangResponses := helper.ToUsulanMusrebangResponses(usulanMusrebang)
-	return usulanMusrebangResponses, nil
+	return usulanMusrebangResponses, total, nil
 }
 
 func (service *UsulanMusrebangServiceImpl) Delete(ctx context.Context, idUsulan string) error {
@@ -171,6 +180,10 @@ func (service *UsulanMusrebangServiceImpl) Delete(ctx context.Context, idUsulan
 }
 
 func (service *UsulanMusrebangServiceImpl) CreateRekin(ctx context.Context, request usulan.UsulanMusrebangCreateRekinRequest) ([]usulan.UsulanMusrebangResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return nil, err
+	}
+
 	// Konversi single ID ke array
 	idUsulanArray := []string{request.IdUsulan}
 
@@ -198,7 +211,7 @@ func (service *UsulanMusrebangServiceImpl) CreateRekin(ctx context.Context, requ
 
 		// Cek apakah usulan sudah memiliki rekin_id
 		if existingUsulan.RekinId != "" {
-			return nil, fmt.Errorf("usulan musrebang dengan id %s sudah memiliki rencana kinerja", idUsulan)
+			return nil, web.NewConflictError(fmt.Sprintf("usulan musrebang dengan id %s sudah memiliki rencana kinerja", idUsulan))
 		}
 
 		// Update rekin_id dan status
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -29,6 +30,10 @@ func NewUsulanPokokPikiranServiceImpl(usulanPokokPikiranRepository repository.Us
 }
 
 func (service *UsulanPokokPikiranServiceImpl) Create(ctx context.Context, request usulan.UsulanPokokPikiranCreateRequest) (usulan.UsulanPokokPikiranResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanPokokPikiranResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanPokokPikiranResponse{}, err
@@ -58,6 +63,10 @@ func (service *UsulanPokokPikiranServiceImpl) Create(ctx context.Context, reques
 }
 
 func (service *UsulanPokokPikiranServiceImpl) Update(ctx context.Context, request usulan.UsulanPokokPikiranUpdateRequest) (usulan.UsulanPokokPikiranResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return usulan.UsulanPokokPikiranResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return usulan.UsulanPokokPikiranResponse{}, err
@@ -144,7 +153,7 @@ func (service *UsulanPokokPikiranServiceImpl) Delete(ctx context.Context, idUsul
 
 	_, err = service.UsulanPokokPikiranRepository.FindById(ctx, tx, idUsulan)
 	if err != nil {
-		return fmt.Errorf("usulan dengan ID %s tidak ditemukan", idUsulan)
+		return web.NewNotFoundError(fmt.Sprintf("usulan dengan ID %s tidak ditemukan", idUsulan))
 	}
 
 	err = service.UsulanPokokPikiranRepository.Delete(ctx, tx, idUsulan)
@@ -154,6 +163,10 @@ func (service *UsulanPokokPikiranServiceImpl) Delete(ctx context.Context, idUsul
 }
 
 func (service *UsulanPokokPikiranServiceImpl) CreateRekin(ctx context.Context, request usulan.UsulanPokokPikiranCreateRekinRequest) ([]usulan.UsulanPokokPikiranResponse, error) {
+	if err := helper.ValidateRequest(request); err != nil {
+		return nil, err
+	}
+
 	// Konversi single ID ke array
 	idUsulanArray := []string{request.IdUsulan}
 
@@ -181,7 +194,7 @@ func (service *UsulanPokokPikiranServiceImpl) CreateRekin(ctx context.Context, r
 
 		// Cek apakah usulan sudah memiliki rekin_id
 		if existingUsulan.RekinId != "" {
-			return nil, fmt.Errorf("usulan pokok pikiran dengan id %s sudah memiliki rencana kinerja", idUsulan)
+			return nil, web.NewConflictError(fmt.Sprintf("usulan pokok pikiran dengan id %s sudah memiliki rencana kinerja", idUsulan))
 		}
 
 		// Update rekin_id dan status
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/usulan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -28,8 +29,10 @@ func NewUsulanTerpilihServiceImpl(usulanTerpilihRepository repository.UsulanTerp
 }
 
 func (service *UsulanTerpilihServiceImpl) Create(ctx context.Context, request usulan.UsulanTerpilihCreateRequest) (usulan.UsulanTerpilihResponse, error) {
-	err := service.Validate.Struct(request)
-	helper.PanicIfError(err)
+	err := helper.ValidationError(service.Validate.Struct(request))
+	if err != nil {
+		return usulan.UsulanTerpilihResponse{}, err
+	}
 
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
@@ -41,7 +44,7 @@ func (service *UsulanTerpilihServiceImpl) Create(ctx context.Context, request us
 		return usulan.UsulanTerpilihResponse{}, fmt.Errorf("gagal memvalidasi jenis usulan dan usulan ID: %v", err)
 	}
 	if !isValid {
-		return usulan.UsulanTerpilihResponse{}, fmt.Errorf("jenis usulan atau usulan ID tidak sesuai dengan yang mandatori")
+		return usulan.UsulanTerpilihResponse{}, web.NewBadRequestError("jenis usulan atau usulan ID tidak sesuai dengan yang mandatori")
 	}
 
 	// Memeriksa apakah usulan dengan jenis dan id yang sama sudah ada
@@ -50,7 +53,7 @@ func (service *UsulanTerpilihServiceImpl) Create(ctx context.Context, request us
 		return usulan.UsulanTerpilihResponse{}, fmt.Errorf("gagal memeriksa keberadaan usulan: %v", err)
 	}
 	if exists {
-		return usulan.UsulanTerpilihResponse{}, fmt.Errorf("usulan dengan jenis dan id yang sama sudah ada")
+		return usulan.UsulanTerpilihResponse{}, web.NewConflictError("usulan dengan jenis dan id yang sama sudah ada")
 	}
 
 	// Membuat UUID baru
@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	visimisipemda "ekak_kabupaten_madiun/model/web/visimisi"
 	"ekak_kabupaten_madiun/repository"
-	"fmt"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
@@ -28,7 +28,7 @@ func NewVisiPemdaServiceImpl(visiPemdaRepository repository.VisiPemdaRepository,
 }
 
 func (service *VisiPemdaServiceImpl) Create(ctx context.Context, request visimisipemda.VisiPemdaCreateRequest) (visimisipemda.VisiPemdaResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return visimisipemda.VisiPemdaResponse{}, err
 	}
@@ -56,7 +56,7 @@ func (service *VisiPemdaServiceImpl) Create(ctx context.Context, request visimis
 }
 
 func (service *VisiPemdaServiceImpl) Update(ctx context.Context, request visimisipemda.VisiPemdaUpdateRequest) (visimisipemda.VisiPemdaResponse, error) {
-	err := service.Validate.Struct(request)
+	err := helper.ValidationError(service.Validate.Struct(request))
 	if err != nil {
 		return visimisipemda.VisiPemdaResponse{}, err
 	}
@@ -127,13 +127,13 @@ func (service *VisiPemdaServiceImpl) FindAll(ctx context.Context, tahunAwal stri
 	if tahunAwal != "" {
 		_, err := strconv.Atoi(tahunAwal)
 		if err != nil {
-			return nil, fmt.Errorf("format tahun awal tidak valid")
+			return nil, web.NewBadRequestError("format tahun awal tidak valid")
 		}
 	}
 	if tahunAkhir != "" {
 		_, err := strconv.Atoi(tahunAkhir)
 		if err != nil {
-			return nil, fmt.Errorf("format tahun akhir tidak valid")
+			return nil, web.NewBadRequestError("format tahun akhir tidak valid")
 		}
 	}
 
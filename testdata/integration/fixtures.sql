@@ -0,0 +1,38 @@
+-- Data contoh kabupaten untuk test integrasi (go test -tags integration).
+-- Id ditulis eksplisit agar bisa dirujuk dari konstanta di integration_harness_test.go.
+
+INSERT INTO tb_periode (id, tahun_awal, tahun_akhir, jenis_periode) VALUES
+    (1, '2025', '2029', 'RPJMD');
+
+INSERT INTO tb_operasional_daerah (id, kode_opd, nama_opd, singkatan, alamat, telepon, fax, email, website, nama_kepala_opd, nip_kepala_opd, pangkat_kepala, id_lembaga) VALUES
+    ('OPD-IT-DINKES', '1.02.0.00.0.00.01.0000', 'Dinas Kesehatan', 'DINKES', 'Jl. Pahlawan No. 1', '0351-000001', '-', 'dinkes@madiunkab.go.id', 'dinkes.madiunkab.go.id', 'dr. Sri Wahyuni', '197001012000012001', 'Pembina Utama Muda', 'LEMBAGA-IT'),
+    ('OPD-IT-BAPPEDA', '5.01.5.05.0.00.01.0000', 'Badan Perencanaan Pembangunan Daerah', 'BAPPEDA', 'Jl. Pahlawan No. 2', '0351-000002', '-', 'bappeda@madiunkab.go.id', 'bappeda.madiunkab.go.id', 'Ir. Bambang Susilo', '197202022000031002', 'Pembina Utama Muda', 'LEMBAGA-IT'),
+    ('OPD-IT-DINSOS', '1.06.0.00.0.00.01.0000', 'Dinas Sosial', 'DINSOS', 'Jl. Pahlawan No. 3', '0351-000003', '-', 'dinsos@madiunkab.go.id', 'dinsos.madiunkab.go.id', 'Drs. Agus Prasetyo', '197303032000041003', 'Pembina Tingkat I', 'LEMBAGA-IT');
+
+INSERT INTO tb_pegawai (id, nama, nip, kode_opd) VALUES
+    ('PEG-IT-001', 'dr. Sri Wahyuni', '197001012000012001', '1.02.0.00.0.00.01.0000'),
+    ('PEG-IT-002', 'Rina Kartika', '198505052010012004', '1.02.0.00.0.00.01.0000'),
+    ('PEG-IT-003', 'Ir. Bambang Susilo', '197202022000031002', '5.01.5.05.0.00.01.0000'),
+    ('PEG-IT-004', 'Dedi Kurniawan', '198707072011011005', '5.01.5.05.0.00.01.0000'),
+    ('PEG-IT-005', 'Drs. Agus Prasetyo', '197303032000041003', '1.06.0.00.0.00.01.0000');
+
+-- pohon kinerja pemda level 0-3 dan pohon kinerja OPD level 4-6 tahun 2025
+INSERT INTO tb_pohon_kinerja (id, parent, nama_pohon, jenis_pohon, level_pohon, kode_opd, keterangan, tahun, status) VALUES
+    (101, 0, 'Meningkatnya Kualitas Sumber Daya Manusia', 'Tematik', 0, '', '', 2025, ''),
+    (102, 101, 'Meningkatnya Derajat Kesehatan Masyarakat', 'Sub Tematik', 1, '', '', 2025, ''),
+    (103, 102, 'Menurunnya Angka Kematian Ibu dan Bayi', 'Sub Sub Tematik', 2, '', '', 2025, ''),
+    (104, 103, 'Meningkatnya Cakupan Pelayanan Kesehatan Dasar', 'Super Sub Tematik', 3, '', '', 2025, ''),
+    (201, 104, 'Meningkatnya Mutu Pelayanan Kesehatan', 'Strategic', 4, '1.02.0.00.0.00.01.0000', '', 2025, ''),
+    (202, 201, 'Meningkatnya Cakupan Pelayanan Kesehatan Ibu Hamil', 'Tactical', 5, '1.02.0.00.0.00.01.0000', '', 2025, ''),
+    (203, 202, 'Terlaksananya Pemeriksaan Ibu Hamil Sesuai Standar', 'Operational', 6, '1.02.0.00.0.00.01.0000', '', 2025, ''),
+    (301, 104, 'Meningkatnya Kualitas Perencanaan Pembangunan Kesehatan', 'Strategic', 4, '5.01.5.05.0.00.01.0000', '', 2025, ''),
+    (302, 301, 'Tersusunnya Dokumen Perencanaan Bidang Kesehatan', 'Tactical', 5, '5.01.5.05.0.00.01.0000', '', 2025, '');
+
+INSERT INTO tb_pelaksana_pokin (id, pohon_kinerja_id, pegawai_id) VALUES
+    ('PLKS-IT-001', '201', 'PEG-IT-001'),
+    ('PLKS-IT-002', '202', 'PEG-IT-002'),
+    ('PLKS-IT-003', '301', 'PEG-IT-003');
+
+INSERT INTO tb_rencana_kinerja (id, nama_rencana_kinerja, tahun, status_rencana_kinerja, catatan, pegawai_id, kode_opd, kode_subkegiatan, id_pohon, periode_id, tahun_awal, tahun_akhir, jenis_periode) VALUES
+    ('REKIN-IT-0001', 'Meningkatnya mutu pelayanan kesehatan di fasilitas kesehatan', '2025', 'aktif', '-', '197001012000012001', '1.02.0.00.0.00.01.0000', '', 201, 1, '2025', '2029', 'RPJMD'),
+    ('REKIN-IT-0002', 'Meningkatnya cakupan pelayanan kesehatan ibu hamil', '2025', 'aktif', '-', '198505052010012004', '1.02.0.00.0.00.01.0000', '', 202, 1, '2025', '2029', 'RPJMD');
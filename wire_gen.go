@@ -10,12 +10,11 @@ import (
 	"ekak_kabupaten_madiun/app"
 	"ekak_kabupaten_madiun/controller"
 	"ekak_kabupaten_madiun/dataseeder"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/middleware"
 	"ekak_kabupaten_madiun/repository"
 	"ekak_kabupaten_madiun/service"
-	"net/http"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 
 	_ "ekak_kabupaten_madiun/docs"
@@ -23,11 +22,10 @@ import (
 
 // Injectors from injector.go:
 
-func InitializeServer() *http.Server {
+func InitializeServer() *Server {
 	rencanaKinerjaRepositoryImpl := repository.NewRencanaKinerjaRepositoryImpl()
 	db := app.GetConnection()
-	v := _wireValue
-	validate := validator.New(v...)
+	validate := helper.NewValidator()
 	opdRepositoryImpl := repository.NewOpdRepositoryImpl()
 	usulanMusrebangRepositoryImpl := repository.NewUsulanMusrebangRepositoryImpl()
 	usulanMandatoriRepositoryImpl := repository.NewUsulanMandatoriRepositoryImpl()
@@ -53,13 +51,21 @@ func InitializeServer() *http.Server {
 	rincianBelanjaRepositoryImpl := repository.NewRincianBelanjaRepositoryImpl()
 	rencanaAksiRepositoryImpl := repository.NewRencanaAksiRepositoryImpl()
 	client := app.GetRedisClient()
+	cache := helper.NewCache(client)
 	cascadingOpdServiceImpl := service.NewCascadingOpdServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, pegawaiRepositoryImpl, tujuanOpdRepositoryImpl, rencanaKinerjaRepositoryImpl, db, programRepositoryImpl, cascadingOpdRepositoryImpl, bidangUrusanRepositoryImpl, rincianBelanjaRepositoryImpl, rencanaAksiRepositoryImpl, client)
 	cloneRecordRepositoryImpl := repository.NewCloneRecordRepositoryImpl()
-	rencanaKinerjaServiceImpl := service.NewRencanaKinerjaServiceImpl(rencanaKinerjaRepositoryImpl, db, validate, opdRepositoryImpl, usulanMusrebangRepositoryImpl, usulanMandatoriRepositoryImpl, usulanPokokPikiranRepositoryImpl, usulanInisiatifRepositoryImpl, subKegiatanRepositoryImpl, dasarHukumRepositoryImpl, gambaranUmumRepositoryImpl, inovasiRepositoryImpl, pelaksanaanRencanaAksiRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, manualIKRepositoryImpl, permasalahanRekinRepositoryImpl, subKegiatanTerpilihRepositoryImpl, subKegiatanServiceImpl, periodeRepositoryImpl, sasaranOpdRepositoryImpl, cascadingOpdServiceImpl, cascadingOpdRepositoryImpl, programRepositoryImpl, rincianBelanjaRepositoryImpl, rencanaAksiRepositoryImpl, cloneRecordRepositoryImpl)
+	lockDataRepositoryImpl := repository.NewLockDataRepositoryImpl()
+	auditLogRepositoryImpl := repository.NewAuditLogRepositoryImpl()
+	notifikasiRepositoryImpl := repository.NewNotifikasiRepositoryImpl()
+	notifikasiServiceImpl := service.NewNotifikasiServiceImpl(notifikasiRepositoryImpl, db)
+	jobRepositoryImpl := repository.NewJobRepositoryImpl()
+	jobServiceImpl := service.NewJobServiceImpl(jobRepositoryImpl, db)
+	persetujuanRekinRepositoryImpl := repository.NewPersetujuanRekinRepositoryImpl()
+	rencanaKinerjaServiceImpl := service.NewRencanaKinerjaServiceImpl(rencanaKinerjaRepositoryImpl, db, validate, opdRepositoryImpl, usulanMusrebangRepositoryImpl, usulanMandatoriRepositoryImpl, usulanPokokPikiranRepositoryImpl, usulanInisiatifRepositoryImpl, subKegiatanRepositoryImpl, dasarHukumRepositoryImpl, gambaranUmumRepositoryImpl, inovasiRepositoryImpl, pelaksanaanRencanaAksiRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, manualIKRepositoryImpl, permasalahanRekinRepositoryImpl, subKegiatanTerpilihRepositoryImpl, subKegiatanServiceImpl, periodeRepositoryImpl, sasaranOpdRepositoryImpl, cascadingOpdServiceImpl, cascadingOpdRepositoryImpl, programRepositoryImpl, rincianBelanjaRepositoryImpl, rencanaAksiRepositoryImpl, cloneRecordRepositoryImpl, lockDataRepositoryImpl, auditLogRepositoryImpl, jobServiceImpl, cache, persetujuanRekinRepositoryImpl)
 	rencanaKinerjaControllerImpl := controller.NewRencanaKinerjaControllerImpl(rencanaKinerjaServiceImpl)
-	rencanaAksiServiceImpl := service.NewRencanaAksiServiceImpl(rencanaAksiRepositoryImpl, db, validate, pelaksanaanRencanaAksiRepositoryImpl)
+	rencanaAksiServiceImpl := service.NewRencanaAksiServiceImpl(rencanaAksiRepositoryImpl, db, validate, pelaksanaanRencanaAksiRepositoryImpl, persetujuanRekinRepositoryImpl, rencanaKinerjaRepositoryImpl, lockDataRepositoryImpl, cache)
 	rencanaAksiControllerImpl := controller.NewRencanaAksiControllerImpl(rencanaAksiServiceImpl)
-	pelaksanaanRencanaAksiServiceImpl := service.NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepositoryImpl, rencanaAksiRepositoryImpl, db)
+	pelaksanaanRencanaAksiServiceImpl := service.NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepositoryImpl, rencanaAksiRepositoryImpl, persetujuanRekinRepositoryImpl, rencanaKinerjaRepositoryImpl, lockDataRepositoryImpl, db)
 	pelaksanaanRencanaAksiControllerImpl := controller.NewPelaksanaanRencanaAksiControllerImpl(pelaksanaanRencanaAksiServiceImpl)
 	usulanMusrebangServiceImpl := service.NewUsulanMusrebangServiceImpl(usulanMusrebangRepositoryImpl, rencanaKinerjaRepositoryImpl, opdRepositoryImpl, db)
 	usulanMusrebangControllerImpl := controller.NewUsulanMusrebangControllerImpl(usulanMusrebangServiceImpl)
@@ -72,21 +78,21 @@ func InitializeServer() *http.Server {
 	usulanTerpilihRepositoryImpl := repository.NewUsulanTerpilihRepositoryImpl()
 	usulanTerpilihServiceImpl := service.NewUsulanTerpilihServiceImpl(usulanTerpilihRepositoryImpl, db, validate)
 	usulanTerpilihControllerImpl := controller.NewUsulanTerpilihControllerImpl(usulanTerpilihServiceImpl)
-	gambaranUmumServiceImpl := service.NewGambaranUmumServiceImpl(gambaranUmumRepositoryImpl, db)
+	gambaranUmumServiceImpl := service.NewGambaranUmumServiceImpl(gambaranUmumRepositoryImpl, rencanaKinerjaRepositoryImpl, lockDataRepositoryImpl, db)
 	gambaranUmumControllerImpl := controller.NewGambaranUmumControllerImpl(gambaranUmumServiceImpl)
-	dasarHukumServiceImpl := service.NewDasarHukumServiceImpl(dasarHukumRepositoryImpl, db)
+	dasarHukumServiceImpl := service.NewDasarHukumServiceImpl(dasarHukumRepositoryImpl, rencanaKinerjaRepositoryImpl, lockDataRepositoryImpl, db)
 	dasarHukumControllerImpl := controller.NewDasarHukumControllerImpl(dasarHukumServiceImpl)
-	inovasiServiceImpl := service.NewInovasiServiceImpl(inovasiRepositoryImpl, db)
+	inovasiServiceImpl := service.NewInovasiServiceImpl(inovasiRepositoryImpl, rencanaKinerjaRepositoryImpl, lockDataRepositoryImpl, db)
 	inovasiControllerImpl := controller.NewInovasiControllerImpl(inovasiServiceImpl)
 	subKegiatanControllerImpl := controller.NewSubKegiatanControllerImpl(subKegiatanServiceImpl)
-	subKegiatanTerpilihServiceImpl := service.NewSubKegiatanTerpilihServiceImpl(rencanaKinerjaRepositoryImpl, subKegiatanRepositoryImpl, subKegiatanTerpilihRepositoryImpl, opdRepositoryImpl, db, validate)
+	subKegiatanTerpilihServiceImpl := service.NewSubKegiatanTerpilihServiceImpl(rencanaKinerjaRepositoryImpl, subKegiatanRepositoryImpl, subKegiatanTerpilihRepositoryImpl, opdRepositoryImpl, db, validate, cache)
 	subKegiatanTerpilihControllerImpl := controller.NewSubKegiatanTerpilihControllerImpl(subKegiatanTerpilihServiceImpl)
 	crosscuttingOpdRepositoryImpl := repository.NewCrosscuttingOpdRepositoryImpl()
 	reviewRepositoryImpl := repository.NewReviewRepositoryImpl()
 	programUnggulanRepositoryImpl := repository.NewProgramUnggulanRepositoryImpl()
 	programPrioritasPusatRepositoryImpl := repository.NewProgramPrioritasPusatRepositoryImpl()
 	csfRepository := repository.NewCSFRepositoryImpl()
-	pohonKinerjaOpdServiceImpl := service.NewPohonKinerjaOpdServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, pegawaiRepositoryImpl, tujuanOpdRepositoryImpl, crosscuttingOpdRepositoryImpl, reviewRepositoryImpl, db, validate, programUnggulanRepositoryImpl, client, csfRepository, sasaranOpdRepositoryImpl)
+	pohonKinerjaOpdServiceImpl := service.NewPohonKinerjaOpdServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, pegawaiRepositoryImpl, tujuanOpdRepositoryImpl, crosscuttingOpdRepositoryImpl, reviewRepositoryImpl, db, validate, programUnggulanRepositoryImpl, client, csfRepository, sasaranOpdRepositoryImpl, auditLogRepositoryImpl, jobServiceImpl, cache)
 	pohonKinerjaOpdControllerImpl := controller.NewPohonKinerjaOpdControllerImpl(pohonKinerjaOpdServiceImpl)
 	jabatanPegawaiRepositoryImpl := repository.NewJabatanPegawaiRepositoryImpl()
 	pegawaiServiceImpl := service.NewPegawaiServiceImpl(pegawaiRepositoryImpl, opdRepositoryImpl, jabatanPegawaiRepositoryImpl, db)
@@ -97,7 +103,7 @@ func InitializeServer() *http.Server {
 	jabatanRepositoryImpl := repository.NewJabatanRepositoryImpl()
 	jabatanServiceImpl := service.NewJabatanServiceImpl(jabatanRepositoryImpl, opdRepositoryImpl, db)
 	jabatanControllerImpl := controller.NewJabatanControllerImpl(jabatanServiceImpl)
-	pohonKinerjaAdminServiceImpl := service.NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, csfRepository, db, pegawaiRepositoryImpl, reviewRepositoryImpl, programUnggulanRepositoryImpl)
+	pohonKinerjaAdminServiceImpl := service.NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, csfRepository, db, pegawaiRepositoryImpl, reviewRepositoryImpl, programUnggulanRepositoryImpl, auditLogRepositoryImpl, jobServiceImpl, cache, notifikasiServiceImpl)
 	pohonKinerjaAdminControllerImpl := controller.NewPohonKinerjaAdminControllerImpl(pohonKinerjaAdminServiceImpl)
 	opdServiceImpl := service.NewOpdServiceImpl(opdRepositoryImpl, lembagaRepositoryImpl, db, validate)
 	opdControllerImpl := controller.NewOpdControllerImpl(opdServiceImpl)
@@ -113,18 +119,18 @@ func InitializeServer() *http.Server {
 	kegiatanControllerImpl := controller.NewKegiatanControllerImpl(kegiatanServiceImpl)
 	userRepositoryImpl := repository.NewUserRepositoryImpl()
 	roleRepositoryImpl := repository.NewRoleRepositoryImpl()
-	userServiceImpl := service.NewUserServiceImpl(userRepositoryImpl, roleRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, db)
+	refreshTokenRepositoryImpl := repository.NewRefreshTokenRepositoryImpl()
+	userServiceImpl := service.NewUserServiceImpl(userRepositoryImpl, roleRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, refreshTokenRepositoryImpl, db, client)
 	userControllerImpl := controller.NewUserControllerImpl(userServiceImpl)
 	roleServiceImpl := service.NewRoleServiceImpl(roleRepositoryImpl, db)
 	roleControllerImpl := controller.NewRoleControllerImpl(roleServiceImpl)
-	lockDataRepositoryImpl := repository.NewLockDataRepositoryImpl()
-	tujuanOpdServiceImpl := service.NewTujuanOpdServiceImpl(tujuanOpdRepositoryImpl, opdRepositoryImpl, periodeRepositoryImpl, bidangUrusanRepositoryImpl, lockDataRepositoryImpl, db)
+	tujuanOpdServiceImpl := service.NewTujuanOpdServiceImpl(tujuanOpdRepositoryImpl, opdRepositoryImpl, periodeRepositoryImpl, bidangUrusanRepositoryImpl, lockDataRepositoryImpl, db, cache)
 	tujuanOpdControllerImpl := controller.NewTujuanOpdControllerImpl(tujuanOpdServiceImpl)
-	crosscuttingOpdServiceImpl := service.NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepositoryImpl, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, db)
+	crosscuttingOpdServiceImpl := service.NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepositoryImpl, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, db, auditLogRepositoryImpl, notifikasiServiceImpl)
 	crosscuttingOpdControllerImpl := controller.NewCrosscuttingOpdControllerImpl(crosscuttingOpdServiceImpl)
 	manualIKServiceImpl := service.NewManualIKServiceImpl(manualIKRepositoryImpl, db, validate)
 	manualIKControllerImpl := controller.NewManualIKControllerImpl(manualIKServiceImpl)
-	reviewServiceImpl := service.NewReviewServiceImpl(reviewRepositoryImpl, db, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl)
+	reviewServiceImpl := service.NewReviewServiceImpl(reviewRepositoryImpl, db, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl, auditLogRepositoryImpl, notifikasiServiceImpl)
 	reviewControllerImpl := controller.NewReviewControllerImpl(reviewServiceImpl)
 	periodeServiceImpl := service.NewPeriodeServiceImpl(periodeRepositoryImpl, db)
 	periodeControllerImpl := controller.NewPeriodeControllerImpl(periodeServiceImpl)
@@ -136,12 +142,12 @@ func InitializeServer() *http.Server {
 	sasaranPemdaRepositoryImpl := repository.NewSasaranPemdaRepositoryImpl()
 	sasaranPemdaServiceImpl := service.NewSasaranPemdaServiceImpl(sasaranPemdaRepositoryImpl, periodeRepositoryImpl, pohonKinerjaRepositoryImpl, tujuanPemdaRepositoryImpl, db)
 	sasaranPemdaControllerImpl := controller.NewSasaranPemdaControllerImpl(sasaranPemdaServiceImpl)
-	permasalahanRekinServiceImpl := service.NewPermasalahanRekinServiceImpl(permasalahanRekinRepositoryImpl, db)
+	permasalahanRekinServiceImpl := service.NewPermasalahanRekinServiceImpl(permasalahanRekinRepositoryImpl, rencanaKinerjaRepositoryImpl, lockDataRepositoryImpl, db)
 	permasalahanRekinControllerImpl := controller.NewPermasalahanRekinControllerImpl(permasalahanRekinServiceImpl)
 	ikuRepositoryImpl := repository.NewIkuRepositoryImpl()
 	ikuServiceImpl := service.NewIkuServiceImpl(ikuRepositoryImpl, db)
 	ikuControllerImpl := controller.NewIkuControllerImpl(ikuServiceImpl)
-	sasaranOpdServiceImpl := service.NewSasaranOpdServiceImpl(sasaranOpdRepositoryImpl, opdRepositoryImpl, rencanaKinerjaRepositoryImpl, manualIKRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, tujuanOpdRepositoryImpl, db, validate)
+	sasaranOpdServiceImpl := service.NewSasaranOpdServiceImpl(sasaranOpdRepositoryImpl, opdRepositoryImpl, rencanaKinerjaRepositoryImpl, manualIKRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, tujuanOpdRepositoryImpl, lockDataRepositoryImpl, db, validate, cache)
 	sasaranOpdControllerImpl := controller.NewSasaranOpdControllerImpl(sasaranOpdServiceImpl)
 	visiPemdaServiceImpl := service.NewVisiPemdaServiceImpl(visiPemdaRepositoryImpl, validate, db)
 	visiPemdaControllerImpl := controller.NewVisiPemdaControllerImpl(visiPemdaServiceImpl)
@@ -151,7 +157,7 @@ func InitializeServer() *http.Server {
 	matrixRenstraServiceImpl := service.NewMatrixRenstraServiceImpl(matrixRenstraRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, db)
 	matrixRenstraControllerImpl := controller.NewMatrixRenstraControllerImpl(matrixRenstraServiceImpl)
 	cascadingOpdControllerImpl := controller.NewCascadingOpdControllerImpl(cascadingOpdServiceImpl)
-	rincianBelanjaServiceImpl := service.NewRincianBelanjaServiceImpl(rincianBelanjaRepositoryImpl, pegawaiRepositoryImpl, db)
+	rincianBelanjaServiceImpl := service.NewRincianBelanjaServiceImpl(rincianBelanjaRepositoryImpl, pegawaiRepositoryImpl, lockDataRepositoryImpl, persetujuanRekinRepositoryImpl, db, cache)
 	rincianBelanjaControllerImpl := controller.NewRincianBelanjaControllerImpl(rincianBelanjaServiceImpl)
 	kelompokAnggaranRepositoryImpl := repository.NewKelompokAnggaranRepositoryImpl()
 	kelompokAnggaranServiceImpl := service.NewKelompokAnggaranServiceImpl(kelompokAnggaranRepositoryImpl, db, validate)
@@ -163,33 +169,56 @@ func InitializeServer() *http.Server {
 	programPrioritasPusatServiceImpl := service.NewProgramPrioritasPusatServiceImpl(programPrioritasPusatRepositoryImpl, db, validate)
 	programPrioritasPusatControllerImpl := controller.NewProgramPrioritasPusatControllerImpl(programPrioritasPusatServiceImpl)
 	matrixRenjaRepositoryImpl := repository.NewMatrixRenjaRepositoryImpl()
-	matrixRenjaServiceImpl := service.NewMatrixRenjaServiceImpl(matrixRenjaRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, db)
+	matrixRenjaServiceImpl := service.NewMatrixRenjaServiceImpl(matrixRenjaRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, lockDataRepositoryImpl, db, cache)
 	matrixRenjaControllerImpl := controller.NewMatrixRenjaControllerImpl(matrixRenjaServiceImpl)
 	pkRepositoryImpl := repository.NewPkRepositoryImpl()
 	strukturOrganisasiRepositoryImpl := repository.NewStrukturOrganisasiRepositoryImpl()
-	pkServiceImpl := service.NewPkServiceImpl(pkRepositoryImpl, pegawaiServiceImpl, rencanaKinerjaServiceImpl, opdServiceImpl, strukturOrganisasiRepositoryImpl, validate, db)
+	pkServiceImpl := service.NewPkServiceImpl(pkRepositoryImpl, pegawaiServiceImpl, rencanaKinerjaServiceImpl, opdServiceImpl, strukturOrganisasiRepositoryImpl, lockDataRepositoryImpl, validate, db, cache)
 	pkControllerImpl := controller.NewPkControllerImpl(pkServiceImpl)
 	strategicArahKebijakanServiceImpl := service.NewStrategicArahKebijakanPemdaServiceImpl(csfRepository, db, tujuanPemdaRepositoryImpl, sasaranPemdaRepositoryImpl)
 	StrategicArahKebijakanControllerImpl := controller.NewStrategicArahKebijakanPemdaControllerImpl(strategicArahKebijakanServiceImpl)
-	router := app.NewRouter(rencanaKinerjaControllerImpl, rencanaAksiControllerImpl, pelaksanaanRencanaAksiControllerImpl, usulanMusrebangControllerImpl, usulanMandatoriControllerImpl, usulanPokokPikiranControllerImpl, usulanInisiatifControllerImpl, usulanTerpilihControllerImpl, gambaranUmumControllerImpl, dasarHukumControllerImpl, inovasiControllerImpl, subKegiatanControllerImpl, subKegiatanTerpilihControllerImpl, pohonKinerjaOpdControllerImpl, pegawaiControllerImpl, lembagaControllerImpl, jabatanControllerImpl, pohonKinerjaAdminControllerImpl, opdControllerImpl, programControllerImpl, urusanControllerImpl, bidangUrusanControllerImpl, kegiatanControllerImpl, userControllerImpl, roleControllerImpl, tujuanOpdControllerImpl, crosscuttingOpdControllerImpl, manualIKControllerImpl, reviewControllerImpl, periodeControllerImpl, tujuanPemdaControllerImpl, sasaranPemdaControllerImpl, permasalahanRekinControllerImpl, ikuControllerImpl, sasaranOpdControllerImpl, visiPemdaControllerImpl, misiPemdaControllerImpl, matrixRenstraControllerImpl, cascadingOpdControllerImpl, rincianBelanjaControllerImpl, kelompokAnggaranControllerImpl, csfController, programUnggulanControllerImpl, programPrioritasPusatControllerImpl, matrixRenjaControllerImpl, pkControllerImpl, StrategicArahKebijakanControllerImpl)
-	authMiddleware := middleware.NewAuthMiddleware(router)
-	server := NewServer(authMiddleware)
+	lockDataServiceImpl := service.NewLockDataServiceImpl(lockDataRepositoryImpl, db, validate)
+	lockDataControllerImpl := controller.NewLockDataControllerImpl(lockDataServiceImpl)
+	auditLogServiceImpl := service.NewAuditLogServiceImpl(auditLogRepositoryImpl, db)
+	auditLogControllerImpl := controller.NewAuditLogControllerImpl(auditLogServiceImpl)
+	nomenklaturRepositoryImpl := repository.NewNomenklaturRepositoryImpl()
+	nomenklaturServiceImpl := service.NewNomenklaturServiceImpl(nomenklaturRepositoryImpl, auditLogRepositoryImpl, db)
+	nomenklaturControllerImpl := controller.NewNomenklaturControllerImpl(nomenklaturServiceImpl)
+	jobControllerImpl := controller.NewJobControllerImpl(jobServiceImpl)
+	rollForwardRepositoryImpl := repository.NewRollForwardRepositoryImpl()
+	rollForwardServiceImpl := service.NewRollForwardServiceImpl(rollForwardRepositoryImpl, lockDataRepositoryImpl, auditLogRepositoryImpl, jobServiceImpl, db)
+	rollForwardControllerImpl := controller.NewRollForwardControllerImpl(rollForwardServiceImpl)
+	realisasiRepositoryImpl := repository.NewRealisasiRepositoryImpl()
+	realisasiServiceImpl := service.NewRealisasiServiceImpl(realisasiRepositoryImpl, lockDataRepositoryImpl, auditLogRepositoryImpl, db, validate)
+	realisasiControllerImpl := controller.NewRealisasiControllerImpl(realisasiServiceImpl)
+	realisasiAnggaranRepositoryImpl := repository.NewRealisasiAnggaranRepositoryImpl()
+	realisasiAnggaranServiceImpl := service.NewRealisasiAnggaranServiceImpl(realisasiAnggaranRepositoryImpl, lockDataRepositoryImpl, auditLogRepositoryImpl, db, validate)
+	realisasiAnggaranControllerImpl := controller.NewRealisasiAnggaranControllerImpl(realisasiAnggaranServiceImpl)
+	persetujuanRekinServiceImpl := service.NewPersetujuanRekinServiceImpl(persetujuanRekinRepositoryImpl, auditLogRepositoryImpl, notifikasiServiceImpl, db, validate)
+	persetujuanRekinControllerImpl := controller.NewPersetujuanRekinControllerImpl(persetujuanRekinServiceImpl)
+	notifikasiControllerImpl := controller.NewNotifikasiControllerImpl(notifikasiServiceImpl)
+	healthServiceImpl := service.NewHealthServiceImpl(db, client)
+	healthControllerImpl := controller.NewHealthControllerImpl(healthServiceImpl)
+	metricsControllerImpl := controller.NewMetricsControllerImpl(db)
+	router := app.NewRouter(rencanaKinerjaControllerImpl, rencanaAksiControllerImpl, pelaksanaanRencanaAksiControllerImpl, usulanMusrebangControllerImpl, usulanMandatoriControllerImpl, usulanPokokPikiranControllerImpl, usulanInisiatifControllerImpl, usulanTerpilihControllerImpl, gambaranUmumControllerImpl, dasarHukumControllerImpl, inovasiControllerImpl, subKegiatanControllerImpl, subKegiatanTerpilihControllerImpl, pohonKinerjaOpdControllerImpl, pegawaiControllerImpl, lembagaControllerImpl, jabatanControllerImpl, pohonKinerjaAdminControllerImpl, opdControllerImpl, programControllerImpl, urusanControllerImpl, bidangUrusanControllerImpl, kegiatanControllerImpl, userControllerImpl, roleControllerImpl, tujuanOpdControllerImpl, crosscuttingOpdControllerImpl, manualIKControllerImpl, reviewControllerImpl, periodeControllerImpl, tujuanPemdaControllerImpl, sasaranPemdaControllerImpl, permasalahanRekinControllerImpl, ikuControllerImpl, sasaranOpdControllerImpl, visiPemdaControllerImpl, misiPemdaControllerImpl, matrixRenstraControllerImpl, cascadingOpdControllerImpl, rincianBelanjaControllerImpl, kelompokAnggaranControllerImpl, csfController, programUnggulanControllerImpl, programPrioritasPusatControllerImpl, matrixRenjaControllerImpl, pkControllerImpl, StrategicArahKebijakanControllerImpl, lockDataControllerImpl, auditLogControllerImpl, nomenklaturControllerImpl, jobControllerImpl, rollForwardControllerImpl, realisasiControllerImpl, realisasiAnggaranControllerImpl, persetujuanRekinControllerImpl, notifikasiControllerImpl, healthControllerImpl, metricsControllerImpl)
+	authMiddleware := middleware.NewAuthMiddleware(router, client)
+	server := NewServer(authMiddleware, jobServiceImpl, healthServiceImpl, notifikasiServiceImpl, db, client)
 	return server
 }
 
-var (
-	_wireValue = []validator.Option{}
-)
-
-func InitializeSeeder() dataseeder.Seeder {
+func InitializeSeeder(config dataseeder.SeedConfig) dataseeder.Seeder {
 	db := app.GetConnection()
 	roleRepositoryImpl := repository.NewRoleRepositoryImpl()
 	roleSeederImpl := dataseeder.NewRoleSeederImpl(roleRepositoryImpl)
-	userRepositoryImpl := repository.NewUserRepositoryImpl()
-	userSeederImpl := dataseeder.NewUserSeederImpl(userRepositoryImpl, roleRepositoryImpl)
+	opdRepositoryImpl := repository.NewOpdRepositoryImpl()
+	opdSeederImpl := dataseeder.NewOpdSeederImpl(opdRepositoryImpl)
+	periodeRepositoryImpl := repository.NewPeriodeRepositoryImpl()
+	periodeSeederImpl := dataseeder.NewPeriodeSeederImpl(periodeRepositoryImpl)
 	pegawaiRepositoryImpl := repository.NewPegawaiRepositoryImpl()
 	pegawaiSeederImpl := dataseeder.NewPegawaiSeederImpl(db, pegawaiRepositoryImpl)
-	seederImpl := dataseeder.NewSeederImpl(db, roleSeederImpl, userSeederImpl, pegawaiSeederImpl)
+	userRepositoryImpl := repository.NewUserRepositoryImpl()
+	userSeederImpl := dataseeder.NewUserSeederImpl(userRepositoryImpl, roleRepositoryImpl, config)
+	seederImpl := dataseeder.NewSeederImpl(db, config, roleSeederImpl, opdSeederImpl, periodeSeederImpl, pegawaiSeederImpl, userSeederImpl)
 	return seederImpl
 }
 
@@ -243,9 +272,9 @@ var kegiatanSet = wire.NewSet(repository.NewKegiatanRepositoryImpl, wire.Bind(ne
 
 var roleSet = wire.NewSet(repository.NewRoleRepositoryImpl, wire.Bind(new(repository.RoleRepository), new(*repository.RoleRepositoryImpl)), service.NewRoleServiceImpl, wire.Bind(new(service.RoleService), new(*service.RoleServiceImpl)), controller.NewRoleControllerImpl, wire.Bind(new(controller.RoleController), new(*controller.RoleControllerImpl)))
 
-var userSet = wire.NewSet(repository.NewUserRepositoryImpl, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)), service.NewUserServiceImpl, wire.Bind(new(service.UserService), new(*service.UserServiceImpl)), controller.NewUserControllerImpl, wire.Bind(new(controller.UserController), new(*controller.UserControllerImpl)))
+var userSet = wire.NewSet(repository.NewUserRepositoryImpl, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)), repository.NewRefreshTokenRepositoryImpl, wire.Bind(new(repository.RefreshTokenRepository), new(*repository.RefreshTokenRepositoryImpl)), service.NewUserServiceImpl, wire.Bind(new(service.UserService), new(*service.UserServiceImpl)), controller.NewUserControllerImpl, wire.Bind(new(controller.UserController), new(*controller.UserControllerImpl)))
 
-var seederProviderSet = wire.NewSet(dataseeder.NewSeederImpl, wire.Bind(new(dataseeder.Seeder), new(*dataseeder.SeederImpl)), dataseeder.NewRoleSeederImpl, wire.Bind(new(dataseeder.RoleSeeder), new(*dataseeder.RoleSeederImpl)), dataseeder.NewUserSeederImpl, wire.Bind(new(dataseeder.UserSeeder), new(*dataseeder.UserSeederImpl)), dataseeder.NewPegawaiSeederImpl, wire.Bind(new(dataseeder.PegawaiSeeder), new(*dataseeder.PegawaiSeederImpl)))
+var seederProviderSet = wire.NewSet(dataseeder.NewSeederImpl, wire.Bind(new(dataseeder.Seeder), new(*dataseeder.SeederImpl)), dataseeder.NewRoleSeederImpl, wire.Bind(new(dataseeder.RoleSeeder), new(*dataseeder.RoleSeederImpl)), dataseeder.NewUserSeederImpl, wire.Bind(new(dataseeder.UserSeeder), new(*dataseeder.UserSeederImpl)), dataseeder.NewPegawaiSeederImpl, wire.Bind(new(dataseeder.PegawaiSeeder), new(*dataseeder.PegawaiSeederImpl)), dataseeder.NewOpdSeederImpl, wire.Bind(new(dataseeder.OpdSeeder), new(*dataseeder.OpdSeederImpl)), dataseeder.NewPeriodeSeederImpl, wire.Bind(new(dataseeder.PeriodeSeeder), new(*dataseeder.PeriodeSeederImpl)))
 
 var tujuanOpdSet = wire.NewSet(repository.NewTujuanOpdRepositoryImpl, wire.Bind(new(repository.TujuanOpdRepository), new(*repository.TujuanOpdRepositoryImpl)), service.NewTujuanOpdServiceImpl, wire.Bind(new(service.TujuanOpdService), new(*service.TujuanOpdServiceImpl)), controller.NewTujuanOpdControllerImpl, wire.Bind(new(controller.TujuanOpdController), new(*controller.TujuanOpdControllerImpl)))
 
@@ -295,4 +324,8 @@ var jabatanPegawaiSet = wire.NewSet(repository.NewJabatanPegawaiRepositoryImpl,
 
 var cloneRecordSet = wire.NewSet(repository.NewCloneRecordRepositoryImpl, wire.Bind(new(repository.CloneRecordRepository), new(*repository.CloneRecordRepositoryImpl)))
 
-var lockDataRepository = wire.NewSet(repository.NewLockDataRepositoryImpl, wire.Bind(new(repository.LockDataRepository), new(*repository.LockDataRepositoryImpl)))
+var lockDataSet = wire.NewSet(repository.NewLockDataRepositoryImpl, wire.Bind(new(repository.LockDataRepository), new(*repository.LockDataRepositoryImpl)), service.NewLockDataServiceImpl, wire.Bind(new(service.LockDataService), new(*service.LockDataServiceImpl)), controller.NewLockDataControllerImpl, wire.Bind(new(controller.LockDataController), new(*controller.LockDataControllerImpl)))
+
+var auditLogSet = wire.NewSet(repository.NewAuditLogRepositoryImpl, wire.Bind(new(repository.AuditLogRepository), new(*repository.AuditLogRepositoryImpl)), service.NewAuditLogServiceImpl, wire.Bind(new(service.AuditLogService), new(*service.AuditLogServiceImpl)), controller.NewAuditLogControllerImpl, wire.Bind(new(controller.AuditLogController), new(*controller.AuditLogControllerImpl)))
+
+var nomenklaturSet = wire.NewSet(repository.NewNomenklaturRepositoryImpl, wire.Bind(new(repository.NomenklaturRepository), new(*repository.NomenklaturRepositoryImpl)), service.NewNomenklaturServiceImpl, wire.Bind(new(service.NomenklaturService), new(*service.NomenklaturServiceImpl)), controller.NewNomenklaturControllerImpl, wire.Bind(new(controller.NomenklaturController), new(*controller.NomenklaturControllerImpl)))